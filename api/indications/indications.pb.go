@@ -0,0 +1,807 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: api/indications/indications.proto
+
+package indications
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// InjectIndicationRequest request to send a custom indication to a subscription
+type InjectIndicationRequest struct {
+	GnbId uint64 `protobuf:"varint,1,opt,name=gnb_id,json=gnbId,proto3" json:"gnb_id,omitempty"`
+	// subscription_id ID of the subscription, i.e. {ricInstanceId}-{ricRequestorId}-{ranFunctionId}
+	SubscriptionId string `protobuf:"bytes,2,opt,name=subscription_id,json=subscriptionId,proto3" json:"subscription_id,omitempty"`
+	// action REPORT action of the subscription the indication is sent to; all its REPORT actions if not set
+	//
+	// Types that are valid to be assigned to Action:
+	//	*InjectIndicationRequest_ActionId
+	Action isInjectIndicationRequest_Action `protobuf_oneof:"action"`
+	// header protobuf encoded indication header of the service model of the subscription
+	Header []byte `protobuf:"bytes,4,opt,name=header,proto3" json:"header,omitempty"`
+	// message protobuf encoded indication message of the service model of the subscription
+	Message []byte `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	// encoded the header and message are already encoded per the service model, e.g. in ASN.1, and sent as is
+	Encoded bool `protobuf:"varint,6,opt,name=encoded,proto3" json:"encoded,omitempty"`
+}
+
+func (m *InjectIndicationRequest) Reset()         { *m = InjectIndicationRequest{} }
+func (m *InjectIndicationRequest) String() string { return proto.CompactTextString(m) }
+func (*InjectIndicationRequest) ProtoMessage()    {}
+func (*InjectIndicationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38b9f9d7a5836623, []int{0}
+}
+func (m *InjectIndicationRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InjectIndicationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InjectIndicationRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InjectIndicationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InjectIndicationRequest.Merge(m, src)
+}
+func (m *InjectIndicationRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *InjectIndicationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_InjectIndicationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InjectIndicationRequest proto.InternalMessageInfo
+
+type isInjectIndicationRequest_Action interface {
+	isInjectIndicationRequest_Action()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type InjectIndicationRequest_ActionId struct {
+	ActionId int32 `protobuf:"varint,3,opt,name=action_id,json=actionId,proto3,oneof" json:"action_id,omitempty"`
+}
+
+func (*InjectIndicationRequest_ActionId) isInjectIndicationRequest_Action() {}
+
+func (m *InjectIndicationRequest) GetAction() isInjectIndicationRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return nil
+}
+
+func (m *InjectIndicationRequest) GetGnbId() uint64 {
+	if m != nil {
+		return m.GnbId
+	}
+	return 0
+}
+
+func (m *InjectIndicationRequest) GetSubscriptionId() string {
+	if m != nil {
+		return m.SubscriptionId
+	}
+	return ""
+}
+
+func (m *InjectIndicationRequest) GetActionId() int32 {
+	if x, ok := m.GetAction().(*InjectIndicationRequest_ActionId); ok {
+		return x.ActionId
+	}
+	return 0
+}
+
+func (m *InjectIndicationRequest) GetHeader() []byte {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *InjectIndicationRequest) GetMessage() []byte {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *InjectIndicationRequest) GetEncoded() bool {
+	if m != nil {
+		return m.Encoded
+	}
+	return false
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*InjectIndicationRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*InjectIndicationRequest_ActionId)(nil),
+	}
+}
+
+type InjectIndicationResponse struct {
+}
+
+func (m *InjectIndicationResponse) Reset()         { *m = InjectIndicationResponse{} }
+func (m *InjectIndicationResponse) String() string { return proto.CompactTextString(m) }
+func (*InjectIndicationResponse) ProtoMessage()    {}
+func (*InjectIndicationResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_38b9f9d7a5836623, []int{1}
+}
+func (m *InjectIndicationResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *InjectIndicationResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_InjectIndicationResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *InjectIndicationResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_InjectIndicationResponse.Merge(m, src)
+}
+func (m *InjectIndicationResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *InjectIndicationResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_InjectIndicationResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_InjectIndicationResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*InjectIndicationRequest)(nil), "onos.ransim.indications.InjectIndicationRequest")
+	proto.RegisterType((*InjectIndicationResponse)(nil), "onos.ransim.indications.InjectIndicationResponse")
+}
+
+func init() { proto.RegisterFile("api/indications/indications.proto", fileDescriptor_38b9f9d7a5836623) }
+
+var fileDescriptor_38b9f9d7a5836623 = []byte{
+	// 316 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x91, 0xbf, 0x4e, 0xf3, 0x30,
+	0x14, 0xc5, 0xe3, 0xef, 0x6b, 0x43, 0x6b, 0x10, 0x20, 0x4b, 0xd0, 0xa8, 0x12, 0x51, 0xe8, 0x42,
+	0x16, 0x12, 0xfe, 0x3d, 0x41, 0x27, 0xb2, 0x30, 0x78, 0x64, 0x41, 0x8e, 0x6d, 0xa5, 0x46, 0xc4,
+	0x0e, 0xbe, 0x8e, 0x78, 0x03, 0x66, 0x1e, 0x8b, 0x09, 0x75, 0x64, 0x44, 0xed, 0x8b, 0xa0, 0xb4,
+	0x14, 0xa2, 0xa2, 0x0e, 0x6c, 0x3e, 0xe7, 0x77, 0x7c, 0x64, 0xdf, 0x8b, 0x8f, 0x59, 0xa5, 0x52,
+	0xa5, 0x85, 0xe2, 0xcc, 0x29, 0xa3, 0xa1, 0x7d, 0x4e, 0x2a, 0x6b, 0x9c, 0x21, 0x03, 0xa3, 0x0d,
+	0x24, 0x96, 0x69, 0x50, 0x65, 0xd2, 0xc2, 0xa3, 0x37, 0x84, 0x07, 0x99, 0xbe, 0x97, 0xdc, 0x65,
+	0xdf, 0x2e, 0x95, 0x8f, 0xb5, 0x04, 0x47, 0x0e, 0xb0, 0x5f, 0xe8, 0xfc, 0x4e, 0x89, 0x00, 0x45,
+	0x28, 0xee, 0xd0, 0x6e, 0xa1, 0xf3, 0x4c, 0x90, 0x13, 0xbc, 0x07, 0x75, 0x0e, 0xdc, 0xaa, 0xaa,
+	0x49, 0x37, 0xfc, 0x5f, 0x84, 0xe2, 0x3e, 0xdd, 0x6d, 0xdb, 0x99, 0x20, 0x47, 0xb8, 0xcf, 0xf8,
+	0x2a, 0xf2, 0x3f, 0x42, 0x71, 0xf7, 0xda, 0xa3, 0xbd, 0xa5, 0x95, 0x09, 0x72, 0x88, 0xfd, 0x89,
+	0x64, 0x42, 0xda, 0xa0, 0x13, 0xa1, 0x78, 0x87, 0x7e, 0x29, 0x12, 0xe0, 0xad, 0x52, 0x02, 0xb0,
+	0x42, 0x06, 0xdd, 0x05, 0x58, 0xc9, 0x86, 0x48, 0xcd, 0x8d, 0x90, 0x22, 0xf0, 0x23, 0x14, 0xf7,
+	0xe8, 0x4a, 0x8e, 0x7b, 0xd8, 0x5f, 0xf6, 0x8e, 0x86, 0x38, 0xf8, 0xfd, 0x1f, 0xa8, 0x8c, 0x06,
+	0x79, 0xf1, 0x8c, 0xf0, 0xf6, 0x8f, 0x0d, 0xe4, 0x09, 0xef, 0xaf, 0x67, 0xc9, 0x59, 0xb2, 0x61,
+	0x54, 0xc9, 0x86, 0x31, 0x0d, 0xcf, 0xff, 0x70, 0x63, 0xf9, 0x90, 0xf1, 0xcd, 0xeb, 0x2c, 0x44,
+	0xd3, 0x59, 0x88, 0x3e, 0x66, 0x21, 0x7a, 0x99, 0x87, 0xde, 0x74, 0x1e, 0x7a, 0xef, 0xf3, 0xd0,
+	0xbb, 0xbd, 0x2a, 0x94, 0x9b, 0xd4, 0x79, 0xc2, 0x4d, 0x99, 0x36, 0xb5, 0x95, 0x35, 0x4d, 0x47,
+	0x6a, 0x99, 0x3e, 0x05, 0x55, 0xd6, 0x0f, 0xcc, 0x19, 0x9b, 0xae, 0x2d, 0x3c, 0xf7, 0x17, 0x5b,
+	0xbe, 0xfc, 0x1c, 0x00, 0xbd, 0xc7, 0x65, 0xe1, 0x0a, 0x02, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IndicationsClient is the client API for Indications service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IndicationsClient interface {
+	// InjectIndication sends the indication of the request to its subscription
+	InjectIndication(ctx context.Context, in *InjectIndicationRequest, opts ...grpc.CallOption) (*InjectIndicationResponse, error)
+}
+
+type indicationsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIndicationsClient(cc *grpc.ClientConn) IndicationsClient {
+	return &indicationsClient{cc}
+}
+
+func (c *indicationsClient) InjectIndication(ctx context.Context, in *InjectIndicationRequest, opts ...grpc.CallOption) (*InjectIndicationResponse, error) {
+	out := new(InjectIndicationResponse)
+	err := c.cc.Invoke(ctx, "/onos.ransim.indications.Indications/InjectIndication", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IndicationsServer is the server API for Indications service.
+type IndicationsServer interface {
+	// InjectIndication sends the indication of the request to its subscription
+	InjectIndication(context.Context, *InjectIndicationRequest) (*InjectIndicationResponse, error)
+}
+
+// UnimplementedIndicationsServer can be embedded to have forward compatible implementations.
+type UnimplementedIndicationsServer struct {
+}
+
+func (*UnimplementedIndicationsServer) InjectIndication(ctx context.Context, req *InjectIndicationRequest) (*InjectIndicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InjectIndication not implemented")
+}
+
+func RegisterIndicationsServer(s *grpc.Server, srv IndicationsServer) {
+	s.RegisterService(&_Indications_serviceDesc, srv)
+}
+
+func _Indications_InjectIndication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InjectIndicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndicationsServer).InjectIndication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.ransim.indications.Indications/InjectIndication",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndicationsServer).InjectIndication(ctx, req.(*InjectIndicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Indications_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.ransim.indications.Indications",
+	HandlerType: (*IndicationsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "InjectIndication",
+			Handler:    _Indications_InjectIndication_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/indications/indications.proto",
+}
+
+func (m *InjectIndicationRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InjectIndicationRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InjectIndicationRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Encoded {
+		i--
+		if m.Encoded {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
+	}
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintIndications(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Header) > 0 {
+		i -= len(m.Header)
+		copy(dAtA[i:], m.Header)
+		i = encodeVarintIndications(dAtA, i, uint64(len(m.Header)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Action != nil {
+		{
+			size := m.Action.Size()
+			i -= size
+			if _, err := m.Action.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	if len(m.SubscriptionId) > 0 {
+		i -= len(m.SubscriptionId)
+		copy(dAtA[i:], m.SubscriptionId)
+		i = encodeVarintIndications(dAtA, i, uint64(len(m.SubscriptionId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.GnbId != 0 {
+		i = encodeVarintIndications(dAtA, i, uint64(m.GnbId))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *InjectIndicationRequest_ActionId) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InjectIndicationRequest_ActionId) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	i = encodeVarintIndications(dAtA, i, uint64(m.ActionId))
+	i--
+	dAtA[i] = 0x18
+	return len(dAtA) - i, nil
+}
+func (m *InjectIndicationResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *InjectIndicationResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *InjectIndicationResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIndications(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIndications(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *InjectIndicationRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GnbId != 0 {
+		n += 1 + sovIndications(uint64(m.GnbId))
+	}
+	l = len(m.SubscriptionId)
+	if l > 0 {
+		n += 1 + l + sovIndications(uint64(l))
+	}
+	if m.Action != nil {
+		n += m.Action.Size()
+	}
+	l = len(m.Header)
+	if l > 0 {
+		n += 1 + l + sovIndications(uint64(l))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovIndications(uint64(l))
+	}
+	if m.Encoded {
+		n += 2
+	}
+	return n
+}
+
+func (m *InjectIndicationRequest_ActionId) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	n += 1 + sovIndications(uint64(m.ActionId))
+	return n
+}
+func (m *InjectIndicationResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovIndications(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIndications(x uint64) (n int) {
+	return sovIndications(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *InjectIndicationRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndications
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InjectIndicationRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InjectIndicationRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GnbId", wireType)
+			}
+			m.GnbId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GnbId |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SubscriptionId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndications
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndications
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.SubscriptionId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ActionId", wireType)
+			}
+			var v int32
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Action = &InjectIndicationRequest_ActionId{v}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIndications
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndications
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Header = append(m.Header[:0], dAtA[iNdEx:postIndex]...)
+			if m.Header == nil {
+				m.Header = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIndications
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIndications
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = append(m.Message[:0], dAtA[iNdEx:postIndex]...)
+			if m.Message == nil {
+				m.Message = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Encoded", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Encoded = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndications(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndications
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *InjectIndicationResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIndications
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: InjectIndicationResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: InjectIndicationResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIndications(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthIndications
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipIndications(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowIndications
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowIndications
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthIndications
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupIndications
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthIndications
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthIndications        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowIndications          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupIndications = fmt.Errorf("proto: unexpected end of group")
+)
@@ -0,0 +1,1765 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: api/transfer/transfer.proto
+
+package transfer
+
+import (
+	context "context"
+	encoding_binary "encoding/binary"
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// Coordinate geographical location
+type Coordinate struct {
+	Lat float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng float64 `protobuf:"fixed64,2,opt,name=lng,proto3" json:"lng,omitempty"`
+}
+
+func (m *Coordinate) Reset()         { *m = Coordinate{} }
+func (m *Coordinate) String() string { return proto.CompactTextString(m) }
+func (*Coordinate) ProtoMessage()    {}
+func (*Coordinate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_571b43c9383ddb0d, []int{0}
+}
+func (m *Coordinate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Coordinate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Coordinate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Coordinate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Coordinate.Merge(m, src)
+}
+func (m *Coordinate) XXX_Size() int {
+	return m.Size()
+}
+func (m *Coordinate) XXX_DiscardUnknown() {
+	xxx_messageInfo_Coordinate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Coordinate proto.InternalMessageInfo
+
+func (m *Coordinate) GetLat() float64 {
+	if m != nil {
+		return m.Lat
+	}
+	return 0
+}
+
+func (m *Coordinate) GetLng() float64 {
+	if m != nil {
+		return m.Lng
+	}
+	return 0
+}
+
+// Route route of a transferred UE
+type Route struct {
+	Points      []*Coordinate `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	Color       string        `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	SpeedAvg    uint32        `protobuf:"varint,3,opt,name=speed_avg,json=speedAvg,proto3" json:"speed_avg,omitempty"`
+	SpeedStdDev uint32        `protobuf:"varint,4,opt,name=speed_std_dev,json=speedStdDev,proto3" json:"speed_std_dev,omitempty"`
+	Reverse     bool          `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"`
+	NextPoint   uint32        `protobuf:"varint,6,opt,name=next_point,json=nextPoint,proto3" json:"next_point,omitempty"`
+	Paused      bool          `protobuf:"varint,7,opt,name=paused,proto3" json:"paused,omitempty"`
+}
+
+func (m *Route) Reset()         { *m = Route{} }
+func (m *Route) String() string { return proto.CompactTextString(m) }
+func (*Route) ProtoMessage()    {}
+func (*Route) Descriptor() ([]byte, []int) {
+	return fileDescriptor_571b43c9383ddb0d, []int{1}
+}
+func (m *Route) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Route) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Route.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Route) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Route.Merge(m, src)
+}
+func (m *Route) XXX_Size() int {
+	return m.Size()
+}
+func (m *Route) XXX_DiscardUnknown() {
+	xxx_messageInfo_Route.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Route proto.InternalMessageInfo
+
+func (m *Route) GetPoints() []*Coordinate {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+func (m *Route) GetColor() string {
+	if m != nil {
+		return m.Color
+	}
+	return ""
+}
+
+func (m *Route) GetSpeedAvg() uint32 {
+	if m != nil {
+		return m.SpeedAvg
+	}
+	return 0
+}
+
+func (m *Route) GetSpeedStdDev() uint32 {
+	if m != nil {
+		return m.SpeedStdDev
+	}
+	return 0
+}
+
+func (m *Route) GetReverse() bool {
+	if m != nil {
+		return m.Reverse
+	}
+	return false
+}
+
+func (m *Route) GetNextPoint() uint32 {
+	if m != nil {
+		return m.NextPoint
+	}
+	return 0
+}
+
+func (m *Route) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+// UEContext state of a UE handed over to a cell of another simulator instance
+type UEContext struct {
+	Imsi     uint64      `protobuf:"varint,1,opt,name=imsi,proto3" json:"imsi,omitempty"`
+	PlmnId   uint32      `protobuf:"varint,2,opt,name=plmn_id,json=plmnId,proto3" json:"plmn_id,omitempty"`
+	Type     string      `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	RrcState int32       `protobuf:"varint,4,opt,name=rrc_state,json=rrcState,proto3" json:"rrc_state,omitempty"`
+	Location *Coordinate `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Heading  uint32      `protobuf:"varint,6,opt,name=heading,proto3" json:"heading,omitempty"`
+	Speed    float64     `protobuf:"fixed64,7,opt,name=speed,proto3" json:"speed,omitempty"`
+	Mobility string      `protobuf:"bytes,8,opt,name=mobility,proto3" json:"mobility,omitempty"`
+	Crnti    uint32      `protobuf:"varint,9,opt,name=crnti,proto3" json:"crnti,omitempty"`
+	Tac      uint32      `protobuf:"varint,10,opt,name=tac,proto3" json:"tac,omitempty"`
+	// ncgi target cell of the handover
+	Ncgi  uint64 `protobuf:"varint,11,opt,name=ncgi,proto3" json:"ncgi,omitempty"`
+	Route *Route `protobuf:"bytes,12,opt,name=route,proto3" json:"route,omitempty"`
+}
+
+func (m *UEContext) Reset()         { *m = UEContext{} }
+func (m *UEContext) String() string { return proto.CompactTextString(m) }
+func (*UEContext) ProtoMessage()    {}
+func (*UEContext) Descriptor() ([]byte, []int) {
+	return fileDescriptor_571b43c9383ddb0d, []int{2}
+}
+func (m *UEContext) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UEContext) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UEContext.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UEContext) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UEContext.Merge(m, src)
+}
+func (m *UEContext) XXX_Size() int {
+	return m.Size()
+}
+func (m *UEContext) XXX_DiscardUnknown() {
+	xxx_messageInfo_UEContext.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UEContext proto.InternalMessageInfo
+
+func (m *UEContext) GetImsi() uint64 {
+	if m != nil {
+		return m.Imsi
+	}
+	return 0
+}
+
+func (m *UEContext) GetPlmnId() uint32 {
+	if m != nil {
+		return m.PlmnId
+	}
+	return 0
+}
+
+func (m *UEContext) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *UEContext) GetRrcState() int32 {
+	if m != nil {
+		return m.RrcState
+	}
+	return 0
+}
+
+func (m *UEContext) GetLocation() *Coordinate {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *UEContext) GetHeading() uint32 {
+	if m != nil {
+		return m.Heading
+	}
+	return 0
+}
+
+func (m *UEContext) GetSpeed() float64 {
+	if m != nil {
+		return m.Speed
+	}
+	return 0
+}
+
+func (m *UEContext) GetMobility() string {
+	if m != nil {
+		return m.Mobility
+	}
+	return ""
+}
+
+func (m *UEContext) GetCrnti() uint32 {
+	if m != nil {
+		return m.Crnti
+	}
+	return 0
+}
+
+func (m *UEContext) GetTac() uint32 {
+	if m != nil {
+		return m.Tac
+	}
+	return 0
+}
+
+func (m *UEContext) GetNcgi() uint64 {
+	if m != nil {
+		return m.Ncgi
+	}
+	return 0
+}
+
+func (m *UEContext) GetRoute() *Route {
+	if m != nil {
+		return m.Route
+	}
+	return nil
+}
+
+type TransferUERequest struct {
+	Ue *UEContext `protobuf:"bytes,1,opt,name=ue,proto3" json:"ue,omitempty"`
+}
+
+func (m *TransferUERequest) Reset()         { *m = TransferUERequest{} }
+func (m *TransferUERequest) String() string { return proto.CompactTextString(m) }
+func (*TransferUERequest) ProtoMessage()    {}
+func (*TransferUERequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_571b43c9383ddb0d, []int{3}
+}
+func (m *TransferUERequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferUERequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferUERequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferUERequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferUERequest.Merge(m, src)
+}
+func (m *TransferUERequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferUERequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferUERequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferUERequest proto.InternalMessageInfo
+
+func (m *TransferUERequest) GetUe() *UEContext {
+	if m != nil {
+		return m.Ue
+	}
+	return nil
+}
+
+type TransferUEResponse struct {
+}
+
+func (m *TransferUEResponse) Reset()         { *m = TransferUEResponse{} }
+func (m *TransferUEResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferUEResponse) ProtoMessage()    {}
+func (*TransferUEResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_571b43c9383ddb0d, []int{4}
+}
+func (m *TransferUEResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferUEResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferUEResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferUEResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferUEResponse.Merge(m, src)
+}
+func (m *TransferUEResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferUEResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferUEResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferUEResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*Coordinate)(nil), "onos.ransim.transfer.Coordinate")
+	proto.RegisterType((*Route)(nil), "onos.ransim.transfer.Route")
+	proto.RegisterType((*UEContext)(nil), "onos.ransim.transfer.UEContext")
+	proto.RegisterType((*TransferUERequest)(nil), "onos.ransim.transfer.TransferUERequest")
+	proto.RegisterType((*TransferUEResponse)(nil), "onos.ransim.transfer.TransferUEResponse")
+}
+
+func init() { proto.RegisterFile("api/transfer/transfer.proto", fileDescriptor_571b43c9383ddb0d) }
+
+var fileDescriptor_571b43c9383ddb0d = []byte{
+	// 541 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0xc1, 0x6f, 0xd3, 0x3e,
+	0x14, 0x5e, 0xba, 0xb5, 0x4b, 0x5e, 0x7f, 0x93, 0x7e, 0x58, 0x13, 0x58, 0x9b, 0x08, 0x55, 0x2e,
+	0xf4, 0x42, 0xca, 0xca, 0x85, 0x03, 0x17, 0xd8, 0x76, 0x40, 0x5c, 0x90, 0xc7, 0x2e, 0x5c, 0x22,
+	0x37, 0x31, 0x99, 0x21, 0xb1, 0x83, 0xed, 0x54, 0xdb, 0x7f, 0xc1, 0x9f, 0xc5, 0x71, 0x47, 0x8e,
+	0xa8, 0x3d, 0xf1, 0x5f, 0x20, 0xbf, 0xb4, 0xdd, 0x24, 0x2a, 0xc1, 0xed, 0x7d, 0x9f, 0x9f, 0x3f,
+	0xbf, 0xef, 0xb3, 0x0d, 0xc7, 0xbc, 0x91, 0x13, 0x67, 0xb8, 0xb2, 0x9f, 0x84, 0xd9, 0x14, 0x69,
+	0x63, 0xb4, 0xd3, 0xe4, 0x50, 0x2b, 0x6d, 0x53, 0xcf, 0xc9, 0x3a, 0x5d, 0xaf, 0x25, 0xcf, 0x01,
+	0x4e, 0xb5, 0x36, 0x85, 0x54, 0xdc, 0x09, 0xf2, 0x3f, 0xec, 0x56, 0xdc, 0xd1, 0x60, 0x14, 0x8c,
+	0x03, 0xe6, 0x4b, 0x64, 0x54, 0x49, 0x7b, 0x2b, 0x46, 0x95, 0xc9, 0xaf, 0x00, 0xfa, 0x4c, 0xb7,
+	0x4e, 0x90, 0x97, 0x30, 0x68, 0xb4, 0x54, 0xce, 0xd2, 0x60, 0xb4, 0x3b, 0x1e, 0x4e, 0x47, 0xe9,
+	0xb6, 0x23, 0xd2, 0x3b, 0x7d, 0xb6, 0xea, 0x27, 0x87, 0xd0, 0xcf, 0x75, 0xa5, 0x0d, 0xea, 0x46,
+	0xac, 0x03, 0xe4, 0x18, 0x22, 0xdb, 0x08, 0x51, 0x64, 0x7c, 0x5e, 0xd2, 0xdd, 0x51, 0x30, 0x3e,
+	0x60, 0x21, 0x12, 0xaf, 0xe7, 0x25, 0x49, 0xe0, 0xa0, 0x5b, 0xb4, 0xae, 0xc8, 0x0a, 0x31, 0xa7,
+	0x7b, 0xd8, 0x30, 0x44, 0xf2, 0xc2, 0x15, 0x67, 0x62, 0x4e, 0x28, 0xec, 0x1b, 0x31, 0x17, 0xc6,
+	0x0a, 0xda, 0x1f, 0x05, 0xe3, 0x90, 0xad, 0x21, 0x79, 0x0c, 0xa0, 0xc4, 0xb5, 0xcb, 0xf0, 0x7c,
+	0x3a, 0xc0, 0xad, 0x91, 0x67, 0xde, 0x7b, 0x82, 0x3c, 0x84, 0x41, 0xc3, 0x5b, 0x2b, 0x0a, 0xba,
+	0x8f, 0xfb, 0x56, 0x28, 0x59, 0xf4, 0x20, 0xba, 0x3c, 0x3f, 0xd5, 0xca, 0x89, 0x6b, 0x47, 0x08,
+	0xec, 0xc9, 0xda, 0x4a, 0x8c, 0x67, 0x8f, 0x61, 0x4d, 0x1e, 0xc1, 0x7e, 0x53, 0xd5, 0x2a, 0x93,
+	0x05, 0x7a, 0x39, 0x60, 0x03, 0x0f, 0xdf, 0x16, 0xbe, 0xd9, 0xdd, 0x34, 0x02, 0x7d, 0x44, 0x0c,
+	0x6b, 0x6f, 0xd0, 0x98, 0x3c, 0xb3, 0x8e, 0x3b, 0x81, 0xf3, 0xf7, 0x59, 0x68, 0x4c, 0x7e, 0xe1,
+	0x31, 0x79, 0x05, 0x61, 0xa5, 0x73, 0xee, 0xa4, 0x56, 0x38, 0xfd, 0xbf, 0xe4, 0xb9, 0xd9, 0xe1,
+	0xad, 0x5f, 0x09, 0x5e, 0x48, 0x55, 0xae, 0xdc, 0xad, 0xa1, 0xcf, 0x1a, 0x33, 0x42, 0x6b, 0x01,
+	0xeb, 0x00, 0x39, 0x82, 0xb0, 0xd6, 0x33, 0x59, 0x49, 0x77, 0x43, 0x43, 0x1c, 0x71, 0x83, 0xf1,
+	0x76, 0x8c, 0x72, 0x92, 0x46, 0xa8, 0xd4, 0x01, 0xff, 0x12, 0x1c, 0xcf, 0x29, 0x20, 0xe7, 0x4b,
+	0x6f, 0x51, 0xe5, 0xa5, 0xa4, 0xc3, 0x2e, 0x0f, 0x5f, 0x93, 0x13, 0xe8, 0x1b, 0xff, 0x38, 0xe8,
+	0x7f, 0x68, 0xe1, 0x78, 0xbb, 0x05, 0x7c, 0x3f, 0xac, 0xeb, 0x4c, 0xce, 0xe0, 0xc1, 0x87, 0xd5,
+	0xc2, 0xe5, 0x39, 0x13, 0x5f, 0x5b, 0x61, 0x1d, 0x99, 0x40, 0xaf, 0x15, 0x98, 0xf4, 0x70, 0xfa,
+	0x64, 0xbb, 0xc8, 0xe6, 0x62, 0x58, 0xaf, 0x15, 0xc9, 0x21, 0x90, 0xfb, 0x2a, 0xb6, 0xd1, 0xca,
+	0x8a, 0xe9, 0x17, 0x08, 0xd7, 0x2c, 0xc9, 0x00, 0xee, 0x3a, 0xc8, 0xd3, 0xed, 0xa2, 0x7f, 0x4c,
+	0x72, 0x34, 0xfe, 0x7b, 0x63, 0x77, 0xd8, 0x9b, 0x77, 0xdf, 0x17, 0x71, 0x70, 0xbb, 0x88, 0x83,
+	0x9f, 0x8b, 0x38, 0xf8, 0xb6, 0x8c, 0x77, 0x6e, 0x97, 0xf1, 0xce, 0x8f, 0x65, 0xbc, 0xf3, 0xf1,
+	0xa4, 0x94, 0xee, 0xaa, 0x9d, 0xa5, 0xb9, 0xae, 0x27, 0x5e, 0xad, 0x31, 0xfa, 0xb3, 0xc8, 0xdd,
+	0xc4, 0x70, 0xf5, 0xcc, 0xca, 0xba, 0xad, 0xb8, 0xd3, 0x66, 0x72, 0xff, 0xf7, 0xce, 0x06, 0xf8,
+	0x6b, 0x5f, 0xfc, 0x1e, 0x00, 0xbb, 0x59, 0xc0, 0x95, 0xd4, 0x03, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TransferClient is the client API for Transfer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TransferClient interface {
+	// TransferUE admits the UE whose context is given on its target cell
+	TransferUE(ctx context.Context, in *TransferUERequest, opts ...grpc.CallOption) (*TransferUEResponse, error)
+}
+
+type transferClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTransferClient(cc *grpc.ClientConn) TransferClient {
+	return &transferClient{cc}
+}
+
+func (c *transferClient) TransferUE(ctx context.Context, in *TransferUERequest, opts ...grpc.CallOption) (*TransferUEResponse, error) {
+	out := new(TransferUEResponse)
+	err := c.cc.Invoke(ctx, "/onos.ransim.transfer.Transfer/TransferUE", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TransferServer is the server API for Transfer service.
+type TransferServer interface {
+	// TransferUE admits the UE whose context is given on its target cell
+	TransferUE(context.Context, *TransferUERequest) (*TransferUEResponse, error)
+}
+
+// UnimplementedTransferServer can be embedded to have forward compatible implementations.
+type UnimplementedTransferServer struct {
+}
+
+func (*UnimplementedTransferServer) TransferUE(ctx context.Context, req *TransferUERequest) (*TransferUEResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferUE not implemented")
+}
+
+func RegisterTransferServer(s *grpc.Server, srv TransferServer) {
+	s.RegisterService(&_Transfer_serviceDesc, srv)
+}
+
+func _Transfer_TransferUE_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferUERequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransferServer).TransferUE(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/onos.ransim.transfer.Transfer/TransferUE",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransferServer).TransferUE(ctx, req.(*TransferUERequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Transfer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "onos.ransim.transfer.Transfer",
+	HandlerType: (*TransferServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TransferUE",
+			Handler:    _Transfer_TransferUE_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/transfer/transfer.proto",
+}
+
+func (m *Coordinate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Coordinate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Coordinate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Lng != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Lng))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Lat != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Lat))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Route) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Route) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Route) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Paused {
+		i--
+		if m.Paused {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.NextPoint != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.NextPoint))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Reverse {
+		i--
+		if m.Reverse {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.SpeedStdDev != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.SpeedStdDev))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SpeedAvg != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.SpeedAvg))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Color) > 0 {
+		i -= len(m.Color)
+		copy(dAtA[i:], m.Color)
+		i = encodeVarintTransfer(dAtA, i, uint64(len(m.Color)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Points) > 0 {
+		for iNdEx := len(m.Points) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Points[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransfer(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UEContext) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UEContext) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UEContext) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Route != nil {
+		{
+			size, err := m.Route.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransfer(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	if m.Ncgi != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.Ncgi))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.Tac != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.Tac))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Crnti != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.Crnti))
+		i--
+		dAtA[i] = 0x48
+	}
+	if len(m.Mobility) > 0 {
+		i -= len(m.Mobility)
+		copy(dAtA[i:], m.Mobility)
+		i = encodeVarintTransfer(dAtA, i, uint64(len(m.Mobility)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Speed != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Speed))))
+		i--
+		dAtA[i] = 0x39
+	}
+	if m.Heading != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.Heading))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Location != nil {
+		{
+			size, err := m.Location.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransfer(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.RrcState != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.RrcState))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Type) > 0 {
+		i -= len(m.Type)
+		copy(dAtA[i:], m.Type)
+		i = encodeVarintTransfer(dAtA, i, uint64(len(m.Type)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.PlmnId != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.PlmnId))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Imsi != 0 {
+		i = encodeVarintTransfer(dAtA, i, uint64(m.Imsi))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferUERequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferUERequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferUERequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Ue != nil {
+		{
+			size, err := m.Ue.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTransfer(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferUEResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferUEResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferUEResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTransfer(dAtA []byte, offset int, v uint64) int {
+	offset -= sovTransfer(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Coordinate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Lat != 0 {
+		n += 9
+	}
+	if m.Lng != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *Route) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Points) > 0 {
+		for _, e := range m.Points {
+			l = e.Size()
+			n += 1 + l + sovTransfer(uint64(l))
+		}
+	}
+	l = len(m.Color)
+	if l > 0 {
+		n += 1 + l + sovTransfer(uint64(l))
+	}
+	if m.SpeedAvg != 0 {
+		n += 1 + sovTransfer(uint64(m.SpeedAvg))
+	}
+	if m.SpeedStdDev != 0 {
+		n += 1 + sovTransfer(uint64(m.SpeedStdDev))
+	}
+	if m.Reverse {
+		n += 2
+	}
+	if m.NextPoint != 0 {
+		n += 1 + sovTransfer(uint64(m.NextPoint))
+	}
+	if m.Paused {
+		n += 2
+	}
+	return n
+}
+
+func (m *UEContext) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Imsi != 0 {
+		n += 1 + sovTransfer(uint64(m.Imsi))
+	}
+	if m.PlmnId != 0 {
+		n += 1 + sovTransfer(uint64(m.PlmnId))
+	}
+	l = len(m.Type)
+	if l > 0 {
+		n += 1 + l + sovTransfer(uint64(l))
+	}
+	if m.RrcState != 0 {
+		n += 1 + sovTransfer(uint64(m.RrcState))
+	}
+	if m.Location != nil {
+		l = m.Location.Size()
+		n += 1 + l + sovTransfer(uint64(l))
+	}
+	if m.Heading != 0 {
+		n += 1 + sovTransfer(uint64(m.Heading))
+	}
+	if m.Speed != 0 {
+		n += 9
+	}
+	l = len(m.Mobility)
+	if l > 0 {
+		n += 1 + l + sovTransfer(uint64(l))
+	}
+	if m.Crnti != 0 {
+		n += 1 + sovTransfer(uint64(m.Crnti))
+	}
+	if m.Tac != 0 {
+		n += 1 + sovTransfer(uint64(m.Tac))
+	}
+	if m.Ncgi != 0 {
+		n += 1 + sovTransfer(uint64(m.Ncgi))
+	}
+	if m.Route != nil {
+		l = m.Route.Size()
+		n += 1 + l + sovTransfer(uint64(l))
+	}
+	return n
+}
+
+func (m *TransferUERequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ue != nil {
+		l = m.Ue.Size()
+		n += 1 + l + sovTransfer(uint64(l))
+	}
+	return n
+}
+
+func (m *TransferUEResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func sovTransfer(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTransfer(x uint64) (n int) {
+	return sovTransfer(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Coordinate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransfer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Coordinate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Coordinate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lat", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Lat = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lng", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Lng = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransfer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Route) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransfer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Route: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Route: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Points", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Points = append(m.Points, &Coordinate{})
+			if err := m.Points[len(m.Points)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Color", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Color = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpeedAvg", wireType)
+			}
+			m.SpeedAvg = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SpeedAvg |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SpeedStdDev", wireType)
+			}
+			m.SpeedStdDev = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SpeedStdDev |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Reverse", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Reverse = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextPoint", wireType)
+			}
+			m.NextPoint = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.NextPoint |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Paused", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Paused = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransfer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UEContext) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransfer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UEContext: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UEContext: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Imsi", wireType)
+			}
+			m.Imsi = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Imsi |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PlmnId", wireType)
+			}
+			m.PlmnId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.PlmnId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Type = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RrcState", wireType)
+			}
+			m.RrcState = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RrcState |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Location", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Location == nil {
+				m.Location = &Coordinate{}
+			}
+			if err := m.Location.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Heading", wireType)
+			}
+			m.Heading = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Heading |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Speed", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Speed = float64(math.Float64frombits(v))
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Mobility", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Mobility = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Crnti", wireType)
+			}
+			m.Crnti = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Crnti |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tac", wireType)
+			}
+			m.Tac = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Tac |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ncgi", wireType)
+			}
+			m.Ncgi = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Ncgi |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Route", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Route == nil {
+				m.Route = &Route{}
+			}
+			if err := m.Route.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransfer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferUERequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransfer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferUERequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferUERequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ue", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ue == nil {
+				m.Ue = &UEContext{}
+			}
+			if err := m.Ue.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransfer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TransferUEResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransfer
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TransferUEResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TransferUEResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransfer(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTransfer
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipTransfer(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowTransfer
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowTransfer
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthTransfer
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupTransfer
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthTransfer
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthTransfer        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowTransfer          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupTransfer = fmt.Errorf("proto: unexpected end of group")
+)
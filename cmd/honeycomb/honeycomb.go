@@ -11,9 +11,9 @@ import (
 	"os"
 	"strconv"
 
-	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/utils/honeycomb"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
 )
@@ -54,7 +54,7 @@ func getHoneycombTopoCommand() *cobra.Command {
 	cmd.Flags().Int("max-neighbors", 5, "Maximum number of neighbors a cell will have; -1 no limit")
 	cmd.Flags().StringSlice("service-models", []string{"kpm/1", "rcpre2/3", "kpm2/4", "mho/5"}, "List of service models supported by the nodes")
 	cmd.Flags().StringSlice("controller-addresses", []string{"onos-e2t"}, "List of E2T controller addresses or service names")
-	cmd.Flags().String("plmnid", "315010", "PlmnID in MCC-MNC format, e.g. CCCNNN or CCCNN")
+	cmd.Flags().String("plmnid", "315010", "PlmnID in MCC-MNC format, e.g. CCCNNN, CCCNN or CCC-NNN")
 	cmd.Flags().Uint("ue-count", 0, "User Equipment count")
 	cmd.Flags().Uint("ue-count-per-cell", 15, "Desired UE count per cell")
 	cmd.Flags().String("gnbid-start", "5152", "GnbID start in hex")
@@ -99,12 +99,17 @@ func runHoneycombTopoCommand(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	plmnID, err := plmn.Parse(plmnid)
+	if err != nil {
+		return err
+	}
+
 	fmt.Printf("Creating honeycomb array of %d towers with %d cells each.\n", numTowers, sectorsPerTower)
 
 	mapCenter := model.Coordinate{Lat: latitude, Lng: longitude}
 
 	m, err := honeycomb.GenerateHoneycombTopology(mapCenter, numTowers, sectorsPerTower,
-		types.PlmnIDFromString(plmnid), uint32(gnbidStart), pitch, maxDistance, maxNeighbors,
+		plmnID, uint32(gnbidStart), pitch, maxDistance, maxNeighbors,
 		controllerAddresses, serviceModels, singleNode, minPci, maxPci, maxCollisions, earfcnStart, cellTypes, deformScale)
 	if err != nil {
 		return err
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/spf13/cobra"
+)
+
+func getGetCellsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cells",
+		Short: "List all simulated cells",
+		Args:  cobra.NoArgs,
+		RunE:  runGetCellsCommand,
+	}
+}
+
+func getGetCellCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cell <ncgi>",
+		Short: "Get a simulated cell",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGetCellCommand,
+	}
+}
+
+func getWatchCellsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cells",
+		Short: "Watch changes of simulated cells",
+		Args:  cobra.NoArgs,
+		RunE:  runWatchCellsCommand,
+	}
+	cmd.Flags().Bool("no-replay", false, "do not replay the existing cells")
+	return cmd
+}
+
+func getDeleteCellCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cell <ncgi>",
+		Short: "Delete a simulated cell",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteCellCommand,
+	}
+}
+
+func parseNCGI(arg string) (types.NCGI, error) {
+	ncgi, err := strconv.ParseUint(arg, 0, 64)
+	if err != nil {
+		return 0, err
+	}
+	return types.NCGI(ncgi), nil
+}
+
+func printCellHeader() {
+	fmt.Printf("%-16s %-6s %-10s %-10s %-8s %-8s %-8s %s\n", "NCGI", "PCI", "Lat", "Lng", "TxPower", "Idle", "Conn", "Neighbors")
+}
+
+func printCell(cell *types.Cell) {
+	fmt.Printf("%-16x %-6d %-10.5f %-10.5f %-8.2f %-8d %-8d %v\n", cell.NCGI, cell.Pci,
+		cell.Location.GetLat(), cell.Location.GetLng(), cell.TxPowerdB,
+		cell.RrcIdleCount, cell.RrcConnectedCount, cell.Neighbors)
+}
+
+func runGetCellsCommand(cmd *cobra.Command, args []string) error {
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewCellModelClient(conn)
+
+	stream, err := client.ListCells(context.Background(), &modelapi.ListCellsRequest{})
+	if err != nil {
+		return err
+	}
+	printCellHeader()
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		printCell(response.Cell)
+	}
+}
+
+func runGetCellCommand(cmd *cobra.Command, args []string) error {
+	ncgi, err := parseNCGI(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewCellModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	response, err := client.GetCell(ctx, &modelapi.GetCellRequest{NCGI: ncgi})
+	if err != nil {
+		return err
+	}
+	printCellHeader()
+	printCell(response.Cell)
+	return nil
+}
+
+func runWatchCellsCommand(cmd *cobra.Command, args []string) error {
+	noReplay, _ := cmd.Flags().GetBool("no-replay")
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewCellModelClient(conn)
+
+	stream, err := client.WatchCells(context.Background(), &modelapi.WatchCellsRequest{NoReplay: noReplay})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fmt.Printf("%-8s ", response.Type)
+		printCell(response.Cell)
+	}
+}
+
+func runDeleteCellCommand(cmd *cobra.Command, args []string) error {
+	ncgi, err := parseNCGI(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewCellModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	_, err = client.DeleteCell(ctx, &modelapi.DeleteCellRequest{NCGI: ncgi})
+	return err
+}
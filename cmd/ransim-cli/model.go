@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"io/ioutil"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	modelservice "github.com/onosproject/ran-simulator/pkg/api/model"
+	"github.com/spf13/cobra"
+)
+
+func getLoadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "load <model-file> [<metrics-file>...]",
+		Short: "Load a model and optionally metric data sets into the simulator",
+		Args:  cobra.MinimumNArgs(1),
+		RunE:  runLoadCommand,
+	}
+	cmd.Flags().Bool("resume", true, "resume the simulation after loading the data sets")
+	return cmd
+}
+
+func runLoadCommand(cmd *cobra.Command, args []string) error {
+	resume, _ := cmd.Flags().GetBool("resume")
+	dataSets := make([]*modelapi.DataSet, 0, len(args))
+	for i, fileName := range args {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+		dataSetType := "model"
+		if i > 0 {
+			dataSetType = "metrics"
+		}
+		dataSets = append(dataSets, &modelapi.DataSet{Type: dataSetType, Data: data})
+	}
+
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewModelServiceClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	_, err = client.Load(ctx, &modelapi.LoadRequest{DataSet: dataSets, Resume: resume})
+	return err
+}
+
+func getReloadCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reload <model-file>",
+		Short: "Apply the changes of a model to the running simulation without restarting it",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runReloadCommand,
+	}
+}
+
+func runReloadCommand(cmd *cobra.Command, args []string) error {
+	data, err := ioutil.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewModelServiceClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	dataSets := []*modelapi.DataSet{{Type: modelservice.ReloadDataSetType, Data: data}}
+	_, err = client.Load(ctx, &modelapi.LoadRequest{DataSet: dataSets})
+	return err
+}
@@ -0,0 +1,223 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
+	"github.com/spf13/cobra"
+)
+
+func getGetNodesCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "nodes",
+		Short: "List all simulated E2 nodes",
+		Args:  cobra.NoArgs,
+		RunE:  runGetNodesCommand,
+	}
+}
+
+func getGetNodeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "node <gnbid>",
+		Short: "Get a simulated E2 node",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGetNodeCommand,
+	}
+}
+
+func getWatchNodesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "nodes",
+		Short: "Watch changes of simulated E2 nodes",
+		Args:  cobra.NoArgs,
+		RunE:  runWatchNodesCommand,
+	}
+	cmd.Flags().Bool("no-replay", false, "do not replay the existing nodes")
+	return cmd
+}
+
+func getDeleteNodeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "node <gnbid>",
+		Short: "Delete a simulated E2 node",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteNodeCommand,
+	}
+}
+
+func getAgentCommand(command string, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   fmt.Sprintf("%s <gnbid>", command),
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAgentCommand(cmd, command, args)
+		},
+	}
+}
+
+func getGetPlmnIDCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "plmnid",
+		Short: "Get the PLMN ID used by the simulator",
+		Args:  cobra.NoArgs,
+		RunE:  runGetPlmnIDCommand,
+	}
+}
+
+func parseGnbID(arg string) (types.GnbID, error) {
+	gnbID, err := strconv.ParseUint(arg, 0, 64)
+	if err != nil {
+		return 0, err
+	}
+	return types.GnbID(gnbID), nil
+}
+
+func printNode(node *types.Node) {
+	fmt.Printf("%-16x %-10s %-24v %-24v %v\n", node.GnbID, node.Status, node.Controllers, node.ServiceModels, node.CellNCGIs)
+}
+
+func printNodeHeader() {
+	fmt.Printf("%-16s %-10s %-24s %-24s %s\n", "GnbID", "Status", "Controllers", "ServiceModels", "Cells")
+}
+
+func runGetNodesCommand(cmd *cobra.Command, args []string) error {
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewNodeModelClient(conn)
+
+	stream, err := client.ListNodes(context.Background(), &modelapi.ListNodesRequest{})
+	if err != nil {
+		return err
+	}
+	printNodeHeader()
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		printNode(response.Node)
+	}
+}
+
+func runGetNodeCommand(cmd *cobra.Command, args []string) error {
+	gnbID, err := parseGnbID(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewNodeModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	response, err := client.GetNode(ctx, &modelapi.GetNodeRequest{GnbID: gnbID})
+	if err != nil {
+		return err
+	}
+	printNodeHeader()
+	printNode(response.Node)
+	return nil
+}
+
+func runWatchNodesCommand(cmd *cobra.Command, args []string) error {
+	noReplay, _ := cmd.Flags().GetBool("no-replay")
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewNodeModelClient(conn)
+
+	stream, err := client.WatchNodes(context.Background(), &modelapi.WatchNodesRequest{NoReplay: noReplay})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fmt.Printf("%-8s ", response.Type)
+		printNode(response.Node)
+	}
+}
+
+func runDeleteNodeCommand(cmd *cobra.Command, args []string) error {
+	gnbID, err := parseGnbID(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewNodeModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	_, err = client.DeleteNode(ctx, &modelapi.DeleteNodeRequest{GnbID: gnbID})
+	return err
+}
+
+func runAgentCommand(cmd *cobra.Command, command string, args []string) error {
+	gnbID, err := parseGnbID(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewNodeModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	response, err := client.AgentControl(ctx, &modelapi.AgentControlRequest{GnbID: gnbID, Command: command})
+	if err != nil {
+		return err
+	}
+	printNodeHeader()
+	printNode(response.Node)
+	return nil
+}
+
+func runGetPlmnIDCommand(cmd *cobra.Command, args []string) error {
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewNodeModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	response, err := client.GetPlmnID(ctx, &modelapi.PlmnIDRequest{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%x (%s)\n", response.PlmnID, plmn.String(response.PlmnID))
+	return nil
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/onosproject/onos-ric-sdk-go/pkg/e2/creds"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	defaultAddress = "ran-simulator:5150"
+	defaultTimeout = 15 * time.Second
+
+	addressFlag = "service-address"
+	noTLSFlag   = "no-tls"
+	timeoutFlag = "timeout"
+)
+
+// A command line tool for inspecting and controlling a running RAN simulator instance
+func main() {
+	rootCmd := getRootCommand()
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func getRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ransim-cli",
+		Short: "RAN simulator runtime inspection and control tool",
+	}
+	cmd.PersistentFlags().String(addressFlag, defaultAddress, "the RAN simulator gRPC endpoint")
+	cmd.PersistentFlags().Bool(noTLSFlag, false, "if present, do not use TLS")
+	cmd.PersistentFlags().Duration(timeoutFlag, defaultTimeout, "timeout for unary requests")
+
+	cmd.AddCommand(getGetCommand())
+	cmd.AddCommand(getWatchCommand())
+	cmd.AddCommand(getSetCommand())
+	cmd.AddCommand(getDeleteCommand())
+	cmd.AddCommand(getLoadCommand())
+	cmd.AddCommand(getReloadCommand())
+	cmd.AddCommand(getHandoverCommand())
+	cmd.AddCommand(getAgentCommand("start", "Start the E2 agent of a simulated node"))
+	cmd.AddCommand(getAgentCommand("stop", "Stop the E2 agent of a simulated node"))
+	return cmd
+}
+
+func getGetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get {nodes|node|cells|cell|ues|ue|uecount|plmnid}",
+		Short: "Commands for retrieving simulated entities",
+	}
+	cmd.AddCommand(getGetNodesCommand())
+	cmd.AddCommand(getGetNodeCommand())
+	cmd.AddCommand(getGetCellsCommand())
+	cmd.AddCommand(getGetCellCommand())
+	cmd.AddCommand(getGetUEsCommand())
+	cmd.AddCommand(getGetUECommand())
+	cmd.AddCommand(getGetUECountCommand())
+	cmd.AddCommand(getGetPlmnIDCommand())
+	return cmd
+}
+
+func getWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch {nodes|cells|ues}",
+		Short: "Commands for watching changes of simulated entities",
+	}
+	cmd.AddCommand(getWatchNodesCommand())
+	cmd.AddCommand(getWatchCellsCommand())
+	cmd.AddCommand(getWatchUEsCommand())
+	return cmd
+}
+
+func getSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set {uecount}",
+		Short: "Commands for changing simulation parameters",
+	}
+	cmd.AddCommand(getSetUECountCommand())
+	return cmd
+}
+
+func getDeleteCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete {node|cell|ue}",
+		Short: "Commands for deleting simulated entities",
+	}
+	cmd.AddCommand(getDeleteNodeCommand())
+	cmd.AddCommand(getDeleteCellCommand())
+	cmd.AddCommand(getDeleteUECommand())
+	return cmd
+}
+
+// getConnection opens a gRPC connection to the RAN simulator using the connection flags
+func getConnection(cmd *cobra.Command) (*grpc.ClientConn, error) {
+	address, _ := cmd.Flags().GetString(addressFlag)
+	noTLS, _ := cmd.Flags().GetBool(noTLSFlag)
+
+	opts := make([]grpc.DialOption, 0)
+	if noTLS {
+		opts = append(opts, grpc.WithInsecure())
+	} else {
+		tlsConfig, err := creds.GetClientCredentials()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	}
+	return grpc.DialContext(context.Background(), address, opts...)
+}
+
+// getContext returns a context bounded by the timeout flag
+func getContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	timeout, _ := cmd.Flags().GetDuration(timeoutFlag)
+	return context.WithTimeout(context.Background(), timeout)
+}
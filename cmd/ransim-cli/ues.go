@@ -0,0 +1,247 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/spf13/cobra"
+)
+
+func getGetUEsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ues",
+		Short: "List all simulated UEs",
+		Args:  cobra.NoArgs,
+		RunE:  runGetUEsCommand,
+	}
+}
+
+func getGetUECommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ue <imsi>",
+		Short: "Get a simulated UE",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runGetUECommand,
+	}
+}
+
+func getGetUECountCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uecount",
+		Short: "Get the number of simulated UEs",
+		Args:  cobra.NoArgs,
+		RunE:  runGetUECountCommand,
+	}
+}
+
+func getWatchUEsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ues",
+		Short: "Watch changes of simulated UEs",
+		Args:  cobra.NoArgs,
+		RunE:  runWatchUEsCommand,
+	}
+	cmd.Flags().Bool("no-replay", false, "do not replay the existing UEs")
+	return cmd
+}
+
+func getSetUECountCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "uecount <count>",
+		Short: "Set the number of simulated UEs",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runSetUECountCommand,
+	}
+}
+
+func getDeleteUECommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "ue <imsi>",
+		Short: "Delete a simulated UE",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDeleteUECommand,
+	}
+}
+
+func getHandoverCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "handover <imsi> <ncgi>",
+		Short: "Hand over a simulated UE to the specified cell",
+		Args:  cobra.ExactArgs(2),
+		RunE:  runHandoverCommand,
+	}
+}
+
+func parseIMSI(arg string) (types.IMSI, error) {
+	imsi, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return types.IMSI(imsi), nil
+}
+
+func printUEHeader() {
+	fmt.Printf("%-16s %-16s %-10s %-10s %-8s %-10s %s\n", "IMSI", "Serving Cell", "Lat", "Lng", "CRNTI", "RRC State", "Strength")
+}
+
+func printUE(ue *types.Ue) {
+	fmt.Printf("%-16d %-16x %-10.5f %-10.5f %-8d %-10d %.2f\n", ue.IMSI, ue.ServingTower,
+		ue.Position.GetLat(), ue.Position.GetLng(), ue.CRNTI, ue.RrcState, ue.ServingTowerStrength)
+}
+
+func runGetUEsCommand(cmd *cobra.Command, args []string) error {
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	stream, err := client.ListUEs(context.Background(), &modelapi.ListUEsRequest{})
+	if err != nil {
+		return err
+	}
+	printUEHeader()
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		printUE(response.Ue)
+	}
+}
+
+func runGetUECommand(cmd *cobra.Command, args []string) error {
+	imsi, err := parseIMSI(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	response, err := client.GetUE(ctx, &modelapi.GetUERequest{IMSI: imsi})
+	if err != nil {
+		return err
+	}
+	printUEHeader()
+	printUE(response.Ue)
+	return nil
+}
+
+func runGetUECountCommand(cmd *cobra.Command, args []string) error {
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	response, err := client.GetUECount(ctx, &modelapi.GetUECountRequest{})
+	if err != nil {
+		return err
+	}
+	fmt.Println(response.Count)
+	return nil
+}
+
+func runWatchUEsCommand(cmd *cobra.Command, args []string) error {
+	noReplay, _ := cmd.Flags().GetBool("no-replay")
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	stream, err := client.WatchUEs(context.Background(), &modelapi.WatchUEsRequest{NoReplay: noReplay})
+	if err != nil {
+		return err
+	}
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fmt.Printf("%-8s ", response.Type)
+		printUE(response.Ue)
+	}
+}
+
+func runSetUECountCommand(cmd *cobra.Command, args []string) error {
+	count, err := strconv.ParseUint(args[0], 10, 32)
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	_, err = client.SetUECount(ctx, &modelapi.SetUECountRequest{Count: uint32(count)})
+	return err
+}
+
+func runDeleteUECommand(cmd *cobra.Command, args []string) error {
+	imsi, err := parseIMSI(args[0])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	_, err = client.DeleteUE(ctx, &modelapi.DeleteUERequest{IMSI: imsi})
+	return err
+}
+
+func runHandoverCommand(cmd *cobra.Command, args []string) error {
+	imsi, err := parseIMSI(args[0])
+	if err != nil {
+		return err
+	}
+	ncgi, err := parseNCGI(args[1])
+	if err != nil {
+		return err
+	}
+	conn, err := getConnection(cmd)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	client := modelapi.NewUEModelClient(conn)
+
+	ctx, cancel := getContext(cmd)
+	defer cancel()
+	_, err = client.MoveToCell(ctx, &modelapi.MoveToCellRequest{IMSI: imsi, NCGI: ncgi})
+	return err
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/onosproject/ran-simulator/pkg/e2agent/recorder"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/replay"
+	"github.com/spf13/cobra"
+)
+
+// A tool to inspect and replay the E2AP messages recorded by the simulated E2 nodes
+func main() {
+	rootCmd := getRootCommand()
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+func getRootCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ransim-replay",
+		Short: "E2 traffic recording inspection and replay tool",
+	}
+	cmd.AddCommand(getDumpCommand())
+	cmd.AddCommand(getRunCommand())
+	return cmd
+}
+
+func getDumpCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dump <recording>",
+		Short: "List the messages of a recording",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runDumpCommand,
+	}
+	cmd.Flags().BoolP("verbose", "v", false, "print the message contents")
+	return cmd
+}
+
+func getRunCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run <recording>",
+		Short: "Replay the indications of a recording against a RIC",
+		Args:  cobra.ExactArgs(1),
+		RunE:  runRunCommand,
+	}
+	cmd.Flags().String("address", "onos-e2t:36421", "address of the E2T endpoint")
+	cmd.Flags().Float64("speed", 1.0, "replay speed factor relative to the recorded timing; 0 replays as fast as possible")
+	return cmd
+}
+
+func readRecording(path string) ([]*recorder.Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return recorder.ReadAll(file)
+}
+
+func runDumpCommand(cmd *cobra.Command, args []string) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	records, err := readRecording(args[0])
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		fmt.Printf("%-30s %-8s %s\n", record.Time.Format("2006-01-02T15:04:05.000000"), record.Direction, record.Type)
+		if verbose {
+			fmt.Println(record.Message)
+		}
+	}
+	return nil
+}
+
+func runRunCommand(cmd *cobra.Command, args []string) error {
+	address, _ := cmd.Flags().GetString("address")
+	speed, _ := cmd.Flags().GetFloat64("speed")
+	if speed < 0 {
+		return fmt.Errorf("speed must not be negative")
+	}
+	records, err := readRecording(args[0])
+	if err != nil {
+		return err
+	}
+	return replay.NewReplayer(records, speed).Run(context.Background(), address)
+}
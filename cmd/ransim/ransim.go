@@ -20,11 +20,16 @@ See ../../docs/run.md for how to run the application.
 package main
 
 import (
+	"context"
 	"flag"
 	"math/rand"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/export"
 	"github.com/onosproject/ran-simulator/pkg/manager"
 )
 
@@ -47,17 +52,35 @@ func main() {
 
 	rand.Seed(time.Now().UnixNano())
 
-	ready := make(chan bool)
-
 	var serviceModelPlugins arrayFlags
 	flag.Var(&serviceModelPlugins, "serviceModel", "names of service model plugins to load (repeated)")
 	caPath := flag.String("caPath", "", "path to CA certificate")
 	keyPath := flag.String("keyPath", "", "path to client private key")
 	certPath := flag.String("certPath", "", "path to client certificate")
 	grpcPort := flag.Int("grpcPort", 5150, "GRPC port for e2T server")
+	restPort := flag.Int("restPort", 0, "REST gateway port; the gateway is disabled if not specified")
+	feedFrameRate := flag.Int("feedFrameRate", 10, "default frame rate of the visualization feed")
+	watchModel := flag.Bool("watchModel", false, "reload the model whenever the model file changes")
 	modelName := flag.String("modelName", "model", "RANSim model file/resource name")
 	metricName := flag.String("metricName", "", "RANSim metric file/resource name")
 	hoLogic := flag.String("hoLogic", "local", "the location of handover logic {local, mho}")
+	persistencePath := flag.String("persistence", "", "file persisting the simulation state across restarts; disabled if not specified")
+	shard := flag.Int("shard", -1, "shard simulated by this instance if the model is sharded; derived from the host name ordinal if not specified")
+	transferPort := flag.Int("transferPort", 5152, "port of the internal gRPC server through which the peered shards hand UEs over to each other")
+	persistenceInterval := flag.Duration("persistenceInterval", 5*time.Second, "interval at which the simulation state is persisted")
+	authEnabled := flag.Bool("auth", false, "authenticate the northbound API requests with the OIDC server given by the OIDC_SERVER_URL environment variable")
+	var operatorGroups arrayFlags
+	flag.Var(&operatorGroups, "operatorGroup", "group of the users allowed to mutate the simulation when authentication is enabled (repeated); defaults to operator")
+	topoAddress := flag.String("topoAddress", "", "address of the onos-topo service the nodes and cells of the model are imported from; disabled if not specified")
+	topoExport := flag.Bool("topoExport", false, "export the simulated nodes and cells to the onos-topo service given by the topoAddress argument")
+	asn1SelfCheck := flag.Bool("asn1SelfCheck", false, "decode back every encoded E2SM payload and log the mismatches with its source; for debugging only")
+	otlpEndpoint := flag.String("otlpEndpoint", "", "OTLP/HTTP endpoint of the OpenTelemetry collector the traces of the E2 procedures are exported to, e.g. http://otel-collector:4318")
+	auditLog := flag.String("auditLog", "", "JSON lines file the audit log of the E2 setup, subscription and control procedures is appended to")
+	exportURL := flag.String("exportURL", "", "NATS server, e.g. nats://nats:4222, or Kafka brokers, e.g. kafka://kafka-0:9092,kafka-1:9092, the UE, cell and handover events are exported to, using TLS given the nats+tls or kafka+tls scheme; disabled if not specified")
+	exportCAPath := flag.String("exportCAPath", "", "CA certificate the brokers the events are exported to over TLS are verified with; system roots if not specified")
+	exportFormat := flag.String("exportFormat", export.FormatJSON, "encoding of the exported events {json, protobuf}")
+	exportTopicPrefix := flag.String("exportTopicPrefix", export.DefaultTopicPrefix, "prefix of the topics the events are exported to")
+	shutdownTimeout := flag.Duration("shutdownTimeout", 25*time.Second, "time allowed to remove the nodes from the RIC and persist the simulation state upon termination")
 	flag.Parse()
 
 	if *hoLogic != "local" && *hoLogic != "mho" {
@@ -74,12 +97,40 @@ func main() {
 		ModelName:           *modelName,
 		MetricName:          *metricName,
 		HOLogic:             *hoLogic,
+		RESTPort:            *restPort,
+		FeedFrameRate:       *feedFrameRate,
+		WatchModel:          *watchModel,
+		PersistencePath:     *persistencePath,
+		PersistenceInterval: *persistenceInterval,
+		Shard:               *shard,
+		TransferPort:        *transferPort,
+		AuthEnabled:         *authEnabled,
+		OperatorGroups:      operatorGroups,
+		TopoAddress:         *topoAddress,
+		TopoExport:          *topoExport,
+		ASN1SelfCheck:       *asn1SelfCheck,
+		OTLPEndpoint:        *otlpEndpoint,
+		AuditLog:            *auditLog,
+		ExportURL:           *exportURL,
+		ExportCAPath:        *exportCAPath,
+		ExportFormat:        *exportFormat,
+		ExportTopicPrefix:   *exportTopicPrefix,
 	}
 
 	mgr, err := manager.NewManager(cfg)
 	if err == nil {
 		mgr.Run()
-		<-ready
-		mgr.Close()
+
+		// Kubernetes sends SIGTERM upon rolling restarts and kills the pod after its termination grace period,
+		// 30 seconds by default
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, syscall.SIGTERM, os.Interrupt)
+		sig := <-signals
+		log.Infof("Received %v signal", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		if err := mgr.Shutdown(ctx); err != nil {
+			log.Warn(err)
+		}
 	}
 }
@@ -3,11 +3,16 @@ module github.com/onosproject/ran-simulator
 go 1.16
 
 require (
+	github.com/Shopify/sarama v1.29.1
 	github.com/cenkalti/backoff v2.2.1+incompatible
 	github.com/cenkalti/backoff/v4 v4.1.0
+	github.com/fsnotify/fsnotify v1.5.1
 	github.com/garyburd/redigo v1.1.1-0.20170914051019-70e1b1943d4f // indirect
+	github.com/gogo/protobuf v1.3.2
+	github.com/golang-jwt/jwt v3.2.2+incompatible
 	github.com/google/uuid v1.2.0
 	github.com/gregjones/httpcache v0.0.0-20190611155906-901d90724c79 // indirect
+	github.com/grpc-ecosystem/go-grpc-middleware v1.3.0
 	github.com/onosproject/helmit v0.6.19
 	github.com/onosproject/onos-api/go v0.8.0
 	github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm v0.8.4
@@ -23,6 +28,7 @@ require (
 	github.com/spf13/cobra v1.2.1
 	github.com/spf13/viper v1.9.0
 	github.com/stretchr/testify v1.7.0
+	golang.org/x/net v0.0.0-20210813160813-60bc85c4be6d
 	google.golang.org/grpc v1.41.0
 	google.golang.org/protobuf v1.27.1
 	googlemaps.github.io/maps v1.3.2
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package analysis
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/coverage"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+)
+
+// CoveragePath path of the coverage analysis
+const CoveragePath = "/v1/analysis/coverage"
+
+// CoverageHandler serves the coverage, overlap and best-server analysis of the simulated cells as GeoJSON
+type CoverageHandler struct {
+	cellStore cells.Store
+}
+
+// NewCoverageHandler creates a new coverage analysis handler
+func NewCoverageHandler(cellStore cells.Store) *CoverageHandler {
+	return &CoverageHandler{
+		cellStore: cellStore,
+	}
+}
+
+// ServeHTTP runs the analysis; the defaults of the analysis can be overridden using the optional "resolution",
+// "threshold" and "margin" query parameters
+func (h *CoverageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	options := coverage.DefaultOptions()
+	for name, value := range map[string]*float64{
+		"resolution": &options.Resolution,
+		"threshold":  &options.Threshold,
+		"margin":     &options.Margin,
+	} {
+		if param := r.URL.Query().Get(name); param != "" {
+			v, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				gateway.WriteJSON(w, nil, errors.NewInvalid("invalid %s %s", name, param))
+				return
+			}
+			*value = v
+		}
+	}
+
+	cellList, err := h.cellStore.List(r.Context())
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+	collection, err := coverage.Analyze(cellList, options)
+	gateway.WriteJSON(w, collection, err)
+}
+
+// HeatmapPath path of the signal strength heatmap
+const HeatmapPath = "/v1/analysis/heatmap"
+
+// HeatmapHandler serves the signal strength heatmap of a cell, or of the strongest signal of all cells, as a grid
+// of values or as a PNG image
+type HeatmapHandler struct {
+	cellStore cells.Store
+}
+
+// NewHeatmapHandler creates a new heatmap handler
+func NewHeatmapHandler(cellStore cells.Store) *HeatmapHandler {
+	return &HeatmapHandler{
+		cellStore: cellStore,
+	}
+}
+
+// ServeHTTP rasterizes the signal strength; the cell is given by the optional "ncgi" query parameter and the area by
+// the optional "bbox" query parameter, as "minLat,minLng,maxLat,maxLng". The defaults of the heatmap can be
+// overridden using the optional "resolution", "floor", "ceiling" and "margin" query parameters. The heatmap is
+// returned as a PNG image if the "format" query parameter is "png".
+func (h *HeatmapHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	query := r.URL.Query()
+	options := coverage.DefaultHeatmapOptions()
+	for name, value := range map[string]*float64{
+		"resolution": &options.Resolution,
+		"floor":      &options.Floor,
+		"ceiling":    &options.Ceiling,
+		"margin":     &options.Margin,
+	} {
+		if param := query.Get(name); param != "" {
+			v, err := strconv.ParseFloat(param, 64)
+			if err != nil {
+				gateway.WriteJSON(w, nil, errors.NewInvalid("invalid %s %s", name, param))
+				return
+			}
+			*value = v
+		}
+	}
+	if param := query.Get("ncgi"); param != "" {
+		ncgi, err := strconv.ParseUint(param, 0, 64)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid NCGI %s", param))
+			return
+		}
+		options.NCGI = types.NCGI(ncgi)
+	}
+	if param := query.Get("bbox"); param != "" {
+		bounds := strings.Split(param, ",")
+		values := make([]float64, len(bounds))
+		for i, bound := range bounds {
+			v, err := strconv.ParseFloat(strings.TrimSpace(bound), 64)
+			if err != nil || len(bounds) != 4 {
+				gateway.WriteJSON(w, nil, errors.NewInvalid("invalid bbox %s", param))
+				return
+			}
+			values[i] = v
+		}
+		options.Min = &model.Coordinate{Lat: values[0], Lng: values[1]}
+		options.Max = &model.Coordinate{Lat: values[2], Lng: values[3]}
+	}
+
+	cellList, err := h.cellStore.List(r.Context())
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+	heatmap, err := coverage.NewHeatmap(cellList, options)
+	if err != nil || query.Get("format") != "png" {
+		gateway.WriteJSON(w, heatmap, err)
+		return
+	}
+	var buf bytes.Buffer
+	if err := heatmap.EncodePNG(&buf); err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+	w.Header().Set("Content-Type", "image/png")
+	_, _ = w.Write(buf.Bytes())
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/audit"
+)
+
+var log = logging.GetLogger("api", "audit")
+
+// Path path served by the handler
+const Path = "/v1/audit"
+
+// Export formats
+const (
+	// JSON exports the entries as a JSON array
+	JSON = "json"
+	// JSONL exports the entries as JSON lines, one entry per line
+	JSONL = "jsonl"
+)
+
+// Handler queries and exports the audit log of the E2 setup, subscription and control procedures
+type Handler struct {
+	log *audit.Log
+}
+
+// NewHandler creates a new audit log API handler
+func NewHandler(log *audit.Log) *Handler {
+	return &Handler{
+		log: log,
+	}
+}
+
+// ServeHTTP lists the entries of the audit log on GET /v1/audit, oldest first, as JSON or, given the format=jsonl
+// query parameter, as a JSON lines file. The entries are filtered by the optional "node", "procedure", "outcome" and
+// "since" query parameters, the latter as an RFC 3339 time.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	query := r.URL.Query()
+	filter := audit.Filter{
+		Node:      query.Get("node"),
+		Procedure: query.Get("procedure"),
+		Outcome:   query.Get("outcome"),
+	}
+	if param := query.Get("since"); param != "" {
+		since, err := time.Parse(time.RFC3339, param)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid time %s", param))
+			return
+		}
+		filter.Since = since
+	}
+
+	entries := h.log.List(filter)
+	switch format := query.Get("format"); format {
+	case "", JSON:
+		gateway.WriteJSON(w, entries, nil)
+	case JSONL:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", `attachment; filename="audit.jsonl"`)
+		if err := audit.WriteJSONL(w, entries); err != nil {
+			log.Warn(err)
+		}
+	default:
+		gateway.WriteJSON(w, nil, errors.NewInvalid("unknown format %s", format))
+	}
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	grpcauth "github.com/onosproject/onos-lib-go/pkg/grpc/auth"
+	"google.golang.org/grpc/metadata"
+)
+
+// GroupsKey metadata key of the groups claim of the token of an authenticated request
+const GroupsKey = "groups"
+
+// DefaultOperatorGroup group whose members are operators unless other groups are configured
+const DefaultOperatorGroup = "operator"
+
+// claimKeys metadata keys set from the claims of the token of an authenticated request
+var claimKeys = []string{"name", "email", "aud", "exp", "iat", "iss", "sub", "at_hash", "preferred_username", GroupsKey, "roles"}
+
+// Role role of a user of the northbound API
+type Role int
+
+const (
+	// Viewer can read the simulation state; all authenticated users are viewers
+	Viewer Role = iota
+	// Operator can also mutate the simulation state, e.g. nodes, cells, UEs and routes
+	Operator
+)
+
+func (r Role) String() string {
+	return [...]string{"viewer", "operator"}[r]
+}
+
+// Authorizer checks the roles of the users of the northbound API. The tokens of the requests are authenticated by the
+// northbound server, which passes their groups claim in the request metadata; users in one of the operator groups are
+// operators, the other users are viewers.
+type Authorizer struct {
+	enabled        bool
+	operatorGroups map[string]bool
+}
+
+// NewAuthorizer creates an authorizer; all users are operators if authentication is disabled
+func NewAuthorizer(enabled bool, operatorGroups []string) *Authorizer {
+	if len(operatorGroups) == 0 {
+		operatorGroups = []string{DefaultOperatorGroup}
+	}
+	groups := make(map[string]bool, len(operatorGroups))
+	for _, group := range operatorGroups {
+		groups[group] = true
+	}
+	return &Authorizer{
+		enabled:        enabled,
+		operatorGroups: groups,
+	}
+}
+
+// Authorize returns a permission denied error unless the user of the request has the given role
+func (a *Authorizer) Authorize(ctx context.Context, role Role) error {
+	if a == nil || !a.enabled || a.roleOf(ctx) >= role {
+		return nil
+	}
+	return errors.Status(errors.NewForbidden("the %s role is required", role)).Err()
+}
+
+// AuthorizeRequest authenticates the bearer token of the given REST request, as the northbound server does for gRPC
+// requests, and returns a permission denied error unless its user has the given role; it is meant for the REST
+// handlers which are not served through the gRPC APIs
+func (a *Authorizer) AuthorizeRequest(r *http.Request, role Role) error {
+	if a == nil || !a.enabled {
+		return nil
+	}
+	md := metadata.Pairs("authorization", r.Header.Get("Authorization"))
+	ctx, err := Authenticate(metadata.NewIncomingContext(r.Context(), md))
+	if err != nil {
+		return err
+	}
+	return a.Authorize(ctx, role)
+}
+
+// Authenticate authenticates the bearer token of the request as onos-lib-go does, once the metadata set from the
+// claims of the token are dropped from the request: onos-lib-go only sets the claims the token carries, which would
+// otherwise let users claim groups, and so roles, their token does not carry
+func Authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	for _, key := range claimKeys {
+		delete(md, key)
+	}
+	return grpcauth.AuthenticationInterceptor(metadata.NewIncomingContext(ctx, md))
+}
+
+// roleOf returns the role of the user of the request
+func (a *Authorizer) roleOf(ctx context.Context) Role {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return Viewer
+	}
+	for _, value := range md.Get(GroupsKey) {
+		for _, group := range strings.FieldsFunc(value, isGroupSeparator) {
+			if a.operatorGroups[group] {
+				return Operator
+			}
+		}
+	}
+	return Viewer
+}
+
+// isGroupSeparator returns true for the characters separating the groups of the groups claim
+func isGroupSeparator(r rune) bool {
+	return r == ';' || r == ',' || r == ' ' || r == '[' || r == ']'
+}
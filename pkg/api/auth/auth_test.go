@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	libauth "github.com/onosproject/onos-lib-go/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorize(t *testing.T) {
+	viewer := metadata.NewIncomingContext(context.Background(), metadata.Pairs(GroupsKey, "lab;viewers"))
+	operator := metadata.NewIncomingContext(context.Background(), metadata.Pairs(GroupsKey, "lab;operator"))
+
+	// All users are operators if authentication is disabled
+	authorizer := NewAuthorizer(false, nil)
+	assert.NoError(t, authorizer.Authorize(viewer, Operator))
+	var none *Authorizer
+	assert.NoError(t, none.Authorize(context.Background(), Operator))
+
+	authorizer = NewAuthorizer(true, nil)
+	assert.NoError(t, authorizer.Authorize(viewer, Viewer))
+	assert.NoError(t, authorizer.Authorize(operator, Operator))
+	err := authorizer.Authorize(viewer, Operator)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Error(t, authorizer.Authorize(context.Background(), Operator))
+
+	// The default operator group is not an operator group once other groups are configured
+	guest := metadata.NewIncomingContext(context.Background(), metadata.Pairs(GroupsKey, "guests;operator"))
+	authorizer = NewAuthorizer(true, []string{"admins", "lab"})
+	assert.NoError(t, authorizer.Authorize(viewer, Operator))
+	assert.NoError(t, authorizer.Authorize(operator, Operator))
+	assert.Error(t, authorizer.Authorize(guest, Operator))
+}
+
+func TestAuthorizeRequest(t *testing.T) {
+	request := httptest.NewRequest(http.MethodDelete, "/v1/outages/1", nil)
+	assert.NoError(t, NewAuthorizer(false, nil).AuthorizeRequest(request, Operator))
+
+	// Requests without a bearer token are not authenticated
+	err := NewAuthorizer(true, nil).AuthorizeRequest(request, Operator)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+// newToken returns a bearer token signed with the shared secret of the test and carrying the given claims
+func newToken(t *testing.T, claims jwt.MapClaims) string {
+	assert.NoError(t, os.Setenv(libauth.SharedSecretKey, "testkey"))
+	claims["exp"] = float64(time.Now().Add(time.Hour).Unix())
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("testkey"))
+	assert.NoError(t, err)
+	return "bearer " + token
+}
+
+func TestAuthenticate(t *testing.T) {
+	authorizer := NewAuthorizer(true, nil)
+
+	// The groups sent by the client are dropped, since the token carries no groups claim
+	md := metadata.Pairs("authorization", newToken(t, jwt.MapClaims{"name": "guest"}), GroupsKey, DefaultOperatorGroup)
+	ctx, err := Authenticate(metadata.NewIncomingContext(context.Background(), md))
+	assert.NoError(t, err)
+	assert.NoError(t, authorizer.Authorize(ctx, Viewer))
+	assert.Equal(t, codes.PermissionDenied, status.Code(authorizer.Authorize(ctx, Operator)))
+
+	// The groups claim of the token prevails
+	md = metadata.Pairs("authorization", newToken(t, jwt.MapClaims{"groups": []string{DefaultOperatorGroup}}))
+	ctx, err = Authenticate(metadata.NewIncomingContext(context.Background(), md))
+	assert.NoError(t, err)
+	assert.NoError(t, authorizer.Authorize(ctx, Operator))
+}
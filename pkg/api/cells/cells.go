@@ -12,6 +12,7 @@ import (
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
 	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
@@ -21,29 +22,33 @@ import (
 var log = liblog.GetLogger("api", "cells")
 
 // NewService returns a new model Service
-func NewService(cellStore cells.Store) service.Service {
+func NewService(cellStore cells.Store, authorizer *auth.Authorizer) service.Service {
 	return &Service{
-		cellStore: cellStore,
+		cellStore:  cellStore,
+		authorizer: authorizer,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	cellStore cells.Store
+	cellStore  cells.Store
+	authorizer *auth.Authorizer
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		cellStore: s.cellStore,
+		cellStore:  s.cellStore,
+		authorizer: s.authorizer,
 	}
 	modelapi.RegisterCellModelServer(r, server)
 }
 
 // Server implements the TrafficSim gRPC service for administrative facilities.
 type Server struct {
-	cellStore cells.Store
+	cellStore  cells.Store
+	authorizer *auth.Authorizer
 }
 
 func cellToAPI(cell *model.Cell) *types.Cell {
@@ -123,6 +128,9 @@ func eventA3ParamsToAPI(params model.EventA3Params) *types.EventA3Params {
 
 // CreateCell creates a new simulated cell
 func (s *Server) CreateCell(ctx context.Context, request *modelapi.CreateCellRequest) (*modelapi.CreateCellResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received create cell request: %v", request)
 	err := s.cellStore.Add(ctx, cellToModel(request.Cell))
 	if err != nil {
@@ -143,8 +151,16 @@ func (s *Server) GetCell(ctx context.Context, request *modelapi.GetCellRequest)
 
 // UpdateCell updates the specified simulated cell
 func (s *Server) UpdateCell(ctx context.Context, request *modelapi.UpdateCellRequest) (*modelapi.UpdateCellResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received update cell request: %v", request)
-	err := s.cellStore.Update(ctx, cellToModel(request.Cell))
+	cell := cellToModel(request.Cell)
+	// Names, labels and tracking areas are not part of the API; the cell keeps those it has
+	if prev, err := s.cellStore.Get(ctx, cell.NCGI); err == nil {
+		cell.Name, cell.Labels, cell.TAC = prev.Name, prev.Labels, prev.TAC
+	}
+	err := s.cellStore.Update(ctx, cell)
 	if err != nil {
 		return nil, err
 	}
@@ -153,6 +169,9 @@ func (s *Server) UpdateCell(ctx context.Context, request *modelapi.UpdateCellReq
 
 // DeleteCell deletes the specified simulated cell
 func (s *Server) DeleteCell(ctx context.Context, request *modelapi.DeleteCellRequest) (*modelapi.DeleteCellResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received delete cell request: %v", request)
 	_, err := s.cellStore.Delete(ctx, request.NCGI)
 	if err != nil {
@@ -202,6 +221,19 @@ func (s *Server) WatchCells(request *modelapi.WatchCellsRequest, server modelapi
 	}
 
 	for cellEvent := range ch {
+		// The cells updated at once are sent as individual updates, which is all the API conveys
+		if cellEvent.Type == cells.TopologyChanged {
+			for _, cell := range cellEvent.Value.([]*model.Cell) {
+				response := &modelapi.WatchCellsResponse{
+					Cell: cellToAPI(cell),
+					Type: modelapi.EventType_UPDATED,
+				}
+				if err := server.Send(response); err != nil {
+					return err
+				}
+			}
+			continue
+		}
 		response := &modelapi.WatchCellsResponse{
 			Cell: cellToAPI(cellEvent.Value.(*model.Cell)),
 			Type: eventType(cellEvent.Type.(cells.CellEvent)),
@@ -0,0 +1,173 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package celltransactions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+)
+
+var log = logging.GetLogger("api", "celltransactions")
+
+// Path path served by the handler
+const Path = "/v1/celltransactions"
+
+// Bounds of the cell parameters
+const (
+	maxPCI  = 1007
+	maxTilt = 90
+)
+
+// Change changes to the parameters of a cell; parameters which are not given are left unchanged
+type Change struct {
+	NCGI            types.NCGI   `json:"ncgi"`
+	TxPowerDB       *float64     `json:"txPowerDb,omitempty"`
+	ElectricalTilt  *int32       `json:"electricalTilt,omitempty"` // degrees, from -90 to 90
+	PCI             *uint32      `json:"pci,omitempty"`            // from 0 to 1007
+	AddNeighbors    []types.NCGI `json:"addNeighbors,omitempty"`
+	RemoveNeighbors []types.NCGI `json:"removeNeighbors,omitempty"`
+}
+
+// Transaction changes to apply to several cells at once
+type Transaction struct {
+	Changes []Change `json:"changes"`
+}
+
+// Result outcome of a transaction
+type Result struct {
+	ID    string       `json:"id"` // key of the topology changed event of the transaction
+	Cells []types.NCGI `json:"cells"`
+}
+
+// Handler applies coordinated changes to the parameters of several cells, e.g. the plan of an optimization xApp,
+// as a single transaction: the changes are validated as a whole and either all of them are applied or none is
+type Handler struct {
+	mu        sync.RWMutex
+	cellStore cells.Store
+}
+
+// NewHandler creates a new cell transactions API handler
+func NewHandler(cellStore cells.Store) *Handler {
+	return &Handler{
+		cellStore: cellStore,
+	}
+}
+
+// Reset makes the handler operate on the given store, which replaces the previous one
+func (h *Handler) Reset(cellStore cells.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cellStore = cellStore
+}
+
+// ServeHTTP applies the transaction given as body on POST /v1/celltransactions
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodPost) {
+		return
+	}
+	transaction := &Transaction{}
+	if err := json.NewDecoder(r.Body).Decode(transaction); err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid(err.Error()))
+		return
+	}
+	result, err := h.Apply(r.Context(), transaction)
+	gateway.WriteJSON(w, result, err)
+}
+
+// Apply validates the changes of the transaction against the current cells and applies them at once, with a single
+// topology changed event; if any change is invalid, no cell is changed
+func (h *Handler) Apply(ctx context.Context, transaction *Transaction) (*Result, error) {
+	if len(transaction.Changes) == 0 {
+		return nil, errors.NewInvalid("transaction has no changes")
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	updated := make([]*model.Cell, 0, len(transaction.Changes))
+	result := &Result{ID: uuid.New().String(), Cells: make([]types.NCGI, 0, len(transaction.Changes))}
+	for _, change := range transaction.Changes {
+		for _, ncgi := range result.Cells {
+			if ncgi == change.NCGI {
+				return nil, errors.NewInvalid("cell %d is changed more than once", change.NCGI)
+			}
+		}
+		cell, err := h.apply(ctx, change)
+		if err != nil {
+			return nil, err
+		}
+		updated = append(updated, cell)
+		result.Cells = append(result.Cells, cell.NCGI)
+	}
+	if err := h.cellStore.UpdateCells(ctx, result.ID, updated); err != nil {
+		return nil, err
+	}
+	log.Infof("Transaction %s updated cells %v", result.ID, result.Cells)
+	return result, nil
+}
+
+// apply returns a copy of the cell with the change applied, leaving the cell itself unchanged
+func (h *Handler) apply(ctx context.Context, change Change) (*model.Cell, error) {
+	cell, err := h.cellStore.Get(ctx, change.NCGI)
+	if err != nil {
+		return nil, errors.NewNotFound("cell %d not found", change.NCGI)
+	}
+	updated := *cell
+	updated.Neighbors = append([]types.NCGI{}, cell.Neighbors...)
+
+	if change.TxPowerDB != nil {
+		updated.TxPowerDB = *change.TxPowerDB
+	}
+	if change.ElectricalTilt != nil {
+		if *change.ElectricalTilt < -maxTilt || *change.ElectricalTilt > maxTilt {
+			return nil, errors.NewInvalid("invalid electrical tilt %d of cell %d", *change.ElectricalTilt, change.NCGI)
+		}
+		updated.Sector.ElectricalTilt = *change.ElectricalTilt
+	}
+	if change.PCI != nil {
+		if *change.PCI > maxPCI {
+			return nil, errors.NewInvalid("invalid PCI %d of cell %d", *change.PCI, change.NCGI)
+		}
+		updated.PCI = *change.PCI
+	}
+
+	for _, neighbor := range change.RemoveNeighbors {
+		i := indexOf(updated.Neighbors, neighbor)
+		if i < 0 {
+			return nil, errors.NewInvalid("cell %d is not a neighbor of cell %d", neighbor, change.NCGI)
+		}
+		updated.Neighbors = append(updated.Neighbors[:i], updated.Neighbors[i+1:]...)
+	}
+	for _, neighbor := range change.AddNeighbors {
+		if neighbor == change.NCGI {
+			return nil, errors.NewInvalid("cell %d cannot be its own neighbor", neighbor)
+		}
+		if _, err := h.cellStore.Get(ctx, neighbor); err != nil {
+			return nil, errors.NewNotFound("neighbor cell %d of cell %d not found", neighbor, change.NCGI)
+		}
+		if indexOf(updated.Neighbors, neighbor) < 0 {
+			updated.Neighbors = append(updated.Neighbors, neighbor)
+		}
+	}
+	return &updated, nil
+}
+
+func indexOf(ncgis []types.NCGI, ncgi types.NCGI) int {
+	for i, other := range ncgis {
+		if other == ncgi {
+			return i
+		}
+	}
+	return -1
+}
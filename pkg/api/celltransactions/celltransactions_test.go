@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package celltransactions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	handler := NewHandler(cellStore)
+	ch := make(chan event.Event, 10)
+	assert.NoError(t, cellStore.Watch(ctx, ch))
+
+	cell1, cell2, cell3 := m.Cells["cell1"].NCGI, m.Cells["cell2"].NCGI, m.Cells["cell3"].NCGI
+	power, tilt, pci := 20.0, int32(-4), uint32(42)
+	result, err := handler.Apply(ctx, &Transaction{Changes: []Change{
+		{NCGI: cell1, TxPowerDB: &power, ElectricalTilt: &tilt, AddNeighbors: []types.NCGI{cell3}},
+		{NCGI: cell2, PCI: &pci},
+	}})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.NCGI{cell1, cell2}, result.Cells)
+
+	cell, err := cellStore.Get(ctx, cell1)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, cell.TxPowerDB)
+	assert.Equal(t, int32(-4), cell.Sector.ElectricalTilt)
+	assert.Contains(t, cell.Neighbors, cell3)
+	cell, err = cellStore.Get(ctx, cell2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(42), cell.PCI)
+
+	// A single event reports all updated cells
+	e := <-ch
+	assert.Equal(t, cells.TopologyChanged, e.Type)
+	assert.Equal(t, result.ID, e.Key)
+	assert.Len(t, e.Value.([]*model.Cell), 2)
+	assert.Len(t, ch, 0)
+
+	// No cell is changed if any change is invalid
+	power = 5
+	pci = 2000
+	_, err = handler.Apply(ctx, &Transaction{Changes: []Change{
+		{NCGI: cell1, TxPowerDB: &power, RemoveNeighbors: []types.NCGI{cell3}},
+		{NCGI: cell2, PCI: &pci},
+	}})
+	assert.Error(t, err)
+	cell, err = cellStore.Get(ctx, cell1)
+	assert.NoError(t, err)
+	assert.Equal(t, 20.0, cell.TxPowerDB)
+	assert.Contains(t, cell.Neighbors, cell3)
+	assert.Len(t, ch, 0)
+
+	_, err = handler.Apply(ctx, &Transaction{Changes: []Change{{NCGI: cell1}, {NCGI: cell1}}})
+	assert.Error(t, err)
+	_, err = handler.Apply(ctx, &Transaction{Changes: []Change{{NCGI: cell1, AddNeighbors: []types.NCGI{cell1}}}})
+	assert.Error(t, err)
+	_, err = handler.Apply(ctx, &Transaction{Changes: []Change{{NCGI: cell2, RemoveNeighbors: []types.NCGI{cell3}}}})
+	assert.Error(t, err)
+	_, err = handler.Apply(ctx, &Transaction{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+)
+
+var log = liblog.GetLogger("api", "controllers")
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/controllers"
+
+// Controller E2T endpoint along with the nodes whose agents connect to it
+type Controller struct {
+	Name    string        `json:"name"`
+	Address string        `json:"address"`
+	Port    int           `json:"port"`
+	Nodes   []types.GnbID `json:"nodes"`
+}
+
+// Handler lists the nodes served by each controller and re-homes nodes to a different controller at runtime
+type Handler struct {
+	mu        sync.RWMutex
+	model     *model.Model
+	nodeStore nodes.Store
+}
+
+// NewHandler creates a new controllers API handler
+func NewHandler(m *model.Model, nodeStore nodes.Store) *Handler {
+	return &Handler{
+		model:     m,
+		nodeStore: nodeStore,
+	}
+}
+
+// Reset makes the handler serve the given model and node store, which replace the previous ones
+func (h *Handler) Reset(m *model.Model, nodeStore nodes.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.model = m
+	h.nodeStore = nodeStore
+}
+
+// ServeHTTP returns the controllers and their nodes on GET /v1/controllers and re-homes a node on
+// PUT /v1/controllers/{controller}/nodes/{gnbid}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	elements := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+	switch {
+	case len(elements) == 1 && elements[0] == "":
+		if gateway.AllowMethods(w, r, http.MethodGet) {
+			controllers, err := h.list(r.Context())
+			gateway.WriteJSON(w, controllers, err)
+		}
+	case len(elements) == 3 && elements[1] == "nodes":
+		if !gateway.AllowMethods(w, r, http.MethodPut) {
+			return
+		}
+		gnbID, err := strconv.ParseUint(elements[2], 0, 64)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid GnbID %s", elements[2]))
+			return
+		}
+		controller, err := h.rehome(r.Context(), types.GnbID(gnbID), elements[0])
+		gateway.WriteJSON(w, controller, err)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// list returns the controllers of the model along with the nodes whose agents connect to them
+func (h *Handler) list(ctx context.Context) ([]Controller, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	nodeList, err := h.nodeStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	served := make(map[string][]types.GnbID)
+	for _, node := range nodeList {
+		if len(node.Controllers) > 0 {
+			served[node.Controllers[0]] = append(served[node.Controllers[0]], node.GnbID)
+		}
+	}
+
+	controllers := make([]Controller, 0, len(h.model.Controllers))
+	for name, controller := range h.model.Controllers {
+		gnbIDs := served[name]
+		sort.Slice(gnbIDs, func(i, j int) bool { return gnbIDs[i] < gnbIDs[j] })
+		controllers = append(controllers, Controller{
+			Name:    name,
+			Address: controller.Address,
+			Port:    controller.Port,
+			Nodes:   gnbIDs,
+		})
+	}
+	sort.Slice(controllers, func(i, j int) bool { return controllers[i].Name < controllers[j].Name })
+	return controllers, nil
+}
+
+// rehome makes the given node served by the given controller; the agent of the node is restarted by the
+// resulting node update event
+func (h *Handler) rehome(ctx context.Context, gnbID types.GnbID, name string) (*Controller, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	controller, err := h.model.GetController(name)
+	if err != nil {
+		return nil, errors.NewNotFound("controller %s not found", name)
+	}
+	node, err := h.nodeStore.Get(ctx, gnbID)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Re-homing node %d to controller %s", gnbID, name)
+	updated := *node
+	updated.Controllers = []string{name}
+	if err := h.nodeStore.Update(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &Controller{
+		Name:    name,
+		Address: controller.Address,
+		Port:    controller.Port,
+		Nodes:   []types.GnbID{gnbID},
+	}, nil
+}
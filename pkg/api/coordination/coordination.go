@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coordination
+
+import (
+	"net/http"
+
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/coordination"
+)
+
+// Path path served by the handler
+const Path = coordination.Path
+
+// Handler exposes the timing of the instance, which the other shards synchronize with if the instance is shard 0
+type Handler struct {
+	coordinator *coordination.Coordinator
+}
+
+// NewHandler creates a new coordination API handler
+func NewHandler(coordinator *coordination.Coordinator) *Handler {
+	return &Handler{
+		coordinator: coordinator,
+	}
+}
+
+// ServeHTTP returns the timing of the instance on GET /v1/coordination
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	gateway.WriteJSON(w, h.coordinator.Timing(), nil)
+}
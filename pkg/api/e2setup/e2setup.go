@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package e2setup
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+)
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/e2setup"
+
+// NodeResult outcome of the latest E2 setup procedure of a node; the result is absent until the RIC responds
+type NodeResult struct {
+	GnbID  types.GnbID          `json:"gnbid"`
+	Result *model.E2SetupResult `json:"result,omitempty"`
+}
+
+// Handler exposes the outcome of the E2 setup procedure of the nodes, so that rejected RAN functions can be
+// diagnosed without going through the logs
+type Handler struct {
+	mu        sync.RWMutex
+	nodeStore nodes.Store
+}
+
+// NewHandler creates a new E2 setup API handler
+func NewHandler(nodeStore nodes.Store) *Handler {
+	return &Handler{
+		nodeStore: nodeStore,
+	}
+}
+
+// Reset makes the handler serve the given node store, which replaces the previous one
+func (h *Handler) Reset(nodeStore nodes.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nodeStore = nodeStore
+}
+
+// ServeHTTP returns the E2 setup outcome of all nodes on GET /v1/e2setup and of a single node on
+// GET /v1/e2setup/{gnbid}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	elements := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+	switch {
+	case len(elements) == 1 && elements[0] == "":
+		results, err := h.list(r.Context())
+		gateway.WriteJSON(w, results, err)
+	case len(elements) == 1:
+		gnbID, err := strconv.ParseUint(elements[0], 0, 64)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid GnbID %s", elements[0]))
+			return
+		}
+		result, err := h.get(r.Context(), types.GnbID(gnbID))
+		gateway.WriteJSON(w, result, err)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *Handler) list(ctx context.Context) ([]NodeResult, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	nodeList, err := h.nodeStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]NodeResult, 0, len(nodeList))
+	for _, node := range nodeList {
+		results = append(results, NodeResult{GnbID: node.GnbID, Result: node.E2Setup})
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].GnbID < results[j].GnbID })
+	return results, nil
+}
+
+func (h *Handler) get(ctx context.Context, gnbID types.GnbID) (*NodeResult, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	node, err := h.nodeStore.Get(ctx, gnbID)
+	if err != nil {
+		return nil, err
+	}
+	return &NodeResult{GnbID: node.GnbID, Result: node.E2Setup}, nil
+}
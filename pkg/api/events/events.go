@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	eventsapi "github.com/onosproject/ran-simulator/api/events"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/eventbus"
+	"google.golang.org/grpc"
+)
+
+var log = liblog.GetLogger("api", "events")
+
+// Path path served by the REST handler
+const Path = "/v1/events"
+
+// NewService returns a new events Service streaming the events of the given bus
+func NewService(bus *eventbus.Bus, authorizer *auth.Authorizer) service.Service {
+	return &Service{
+		bus:        bus,
+		authorizer: authorizer,
+	}
+}
+
+// Service is a Service implementation for the events of the simulation
+type Service struct {
+	service.Service
+	bus        *eventbus.Bus
+	authorizer *auth.Authorizer
+}
+
+// Register registers the events Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	server := &Server{
+		bus:        s.bus,
+		authorizer: s.authorizer,
+	}
+	eventsapi.RegisterEventsServer(r, server)
+}
+
+// Server implements the events gRPC service
+type Server struct {
+	bus        *eventbus.Bus
+	authorizer *auth.Authorizer
+}
+
+// WatchEvents streams the events of the requested topics, or of all topics if none is requested, in the order they
+// occur; the stream ends with an unavailable error if the client does not keep up
+func (s *Server) WatchEvents(request *eventsapi.WatchEventsRequest, stream eventsapi.Events_WatchEventsServer) error {
+	if err := s.authorizer.Authorize(stream.Context(), auth.Viewer); err != nil {
+		return err
+	}
+	topics := request.GetTopics()
+	if err := validateTopics(topics); err != nil {
+		return errors.Status(err).Err()
+	}
+	log.Debugf("Watching events of topics %v", topics)
+	for event := range s.bus.Subscribe(stream.Context(), topics...) {
+		response, err := event.Proto()
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+	if err := stream.Context().Err(); err != nil {
+		return err
+	}
+	return errors.Status(errors.NewUnavailable("events stream fell behind")).Err()
+}
+
+// validateTopics returns an error if any of the given topics is unknown
+func validateTopics(topics []string) error {
+	for _, topic := range topics {
+		known := false
+		for _, t := range eventbus.Topics {
+			known = known || t == topic
+		}
+		if !known {
+			return errors.NewInvalid("unknown topic %s; known topics are %s", topic, strings.Join(eventbus.Topics, ", "))
+		}
+	}
+	return nil
+}
+
+// Handler streams the events of the simulation over HTTP as JSON lines
+type Handler struct {
+	bus *eventbus.Bus
+}
+
+// NewHandler creates a new events API handler streaming the events of the given bus
+func NewHandler(bus *eventbus.Bus) *Handler {
+	return &Handler{
+		bus: bus,
+	}
+}
+
+// ServeHTTP streams the events on GET /v1/events, one JSON object per line, until the client goes away; the topic
+// query parameter, which may be repeated, selects the topics of the events
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	topics := r.URL.Query()["topic"]
+	if err := validateTopics(topics); err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	if flusher != nil {
+		flusher.Flush()
+	}
+	encoder := json.NewEncoder(w)
+	for event := range h.bus.Subscribe(r.Context(), topics...) {
+		if err := encoder.Encode(event); err != nil {
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package feed
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"golang.org/x/net/websocket"
+)
+
+var log = liblog.GetLogger("api", "feed")
+
+const (
+	// DefaultFrameRate default number of frames per second
+	DefaultFrameRate = 10
+	// MaxFrameRate maximum number of frames per second a client may request
+	MaxFrameRate = 60
+)
+
+// Feed streams cell geometries and UE positions to map-based GUI clients over WebSocket.
+// The first frame sent to a client is a snapshot; subsequent frames only carry changes.
+type Feed struct {
+	cellStore cells.Store
+	ueStore   ues.Store
+	frameRate int
+	mu        sync.Mutex
+	last      *snapshot
+}
+
+// snapshot cells and UEs listed for a frame, shared by all the clients; it must not be modified
+type snapshot struct {
+	taken time.Time
+	cells []Cell
+	ues   []UE
+}
+
+// NewFeed creates a new visualization feed using the given default frame rate
+func NewFeed(cellStore cells.Store, ueStore ues.Store, frameRate int) *Feed {
+	if frameRate <= 0 || frameRate > MaxFrameRate {
+		frameRate = DefaultFrameRate
+	}
+	return &Feed{
+		cellStore: cellStore,
+		ueStore:   ueStore,
+		frameRate: frameRate,
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and starts streaming frames;
+// the frame rate can be tailored using the optional "fps" query parameter
+func (f *Feed) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	frameRate := f.frameRate
+	if fps := r.URL.Query().Get("fps"); fps != "" {
+		value, err := strconv.Atoi(fps)
+		if err != nil || value <= 0 || value > MaxFrameRate {
+			http.Error(w, "invalid frame rate", http.StatusBadRequest)
+			return
+		}
+		frameRate = value
+	}
+	websocket.Handler(func(ws *websocket.Conn) {
+		f.stream(ws, frameRate)
+	}).ServeHTTP(w, r)
+}
+
+// snapshot returns the cells and UEs of the current frame; the stores are listed at most once per frame of the
+// maximum frame rate, whatever the number of clients, and the clients listing them concurrently share the snapshot
+func (f *Feed) snapshot(ctx context.Context) (*snapshot, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	now := time.Now()
+	if f.last != nil && now.Sub(f.last.taken) < time.Second/MaxFrameRate {
+		return f.last, nil
+	}
+	cellList, err := f.cellStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ueList := f.ueStore.ListAllUEs(ctx)
+	s := &snapshot{
+		taken: now,
+		cells: make([]Cell, 0, len(cellList)),
+		ues:   make([]UE, 0, len(ueList)),
+	}
+	for _, cell := range cellList {
+		s.cells = append(s.cells, cellToFeed(cell))
+	}
+	for _, ue := range ueList {
+		s.ues = append(s.ues, ueToFeed(ue))
+	}
+	f.last = s
+	return s, nil
+}
+
+func (f *Feed) stream(ws *websocket.Conn, frameRate int) {
+	log.Infof("Streaming visualization feed to %s at %d fps", ws.Request().RemoteAddr, frameRate)
+	defer ws.Close()
+	ctx, cancel := context.WithCancel(ws.Request().Context())
+	defer cancel()
+
+	// Detects the client going away; the client is not expected to send anything
+	go func() {
+		var msg string
+		for {
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				cancel()
+				return
+			}
+		}
+	}()
+
+	st := newState()
+	ticker := time.NewTicker(time.Second / time.Duration(frameRate))
+	defer ticker.Stop()
+	for {
+		s, err := f.snapshot(ctx)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		frame := st.next(s.cells, s.ues)
+		if frame.Type == Snapshot || !frame.IsEmpty() {
+			if err := websocket.JSON.Send(ws, frame); err != nil {
+				log.Debugf("Visualization feed client %s went away: %v", ws.Request().RemoteAddr, err)
+				return
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package feed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingUEStore counts the listings of the UEs
+type countingUEStore struct {
+	ues.Store
+	listings int
+}
+
+func (s *countingUEStore) ListAllUEs(ctx context.Context) []*model.UE {
+	s.listings++
+	return s.Store.ListAllUEs(ctx)
+}
+
+func TestSharedSnapshot(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	cellStore := cells.NewCellRegistry(m.Cells, nodes.NewNodeRegistry(m.Nodes))
+	ueStore := &countingUEStore{Store: ues.NewUERegistry(m.UECount, cellStore, "connected")}
+	f := NewFeed(cellStore, ueStore, DefaultFrameRate)
+
+	// The clients of a frame share its snapshot
+	s1, err := f.snapshot(ctx)
+	assert.NoError(t, err)
+	s2, err := f.snapshot(ctx)
+	assert.NoError(t, err)
+	assert.Same(t, s1, s2)
+	assert.Equal(t, 1, ueStore.listings)
+	assert.Len(t, s1.ues, int(m.UECount))
+	assert.Len(t, s1.cells, len(m.Cells))
+
+	// The stores are listed again for the next frame
+	f.last.taken = f.last.taken.Add(-time.Second)
+	s3, err := f.snapshot(ctx)
+	assert.NoError(t, err)
+	assert.NotSame(t, s1, s3)
+	assert.Equal(t, 2, ueStore.listings)
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package feed
+
+import (
+	"math"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// positionEpsilon is the minimum change in coordinates which is reported in a delta frame
+const positionEpsilon = 1e-7
+
+// state tracks what has been sent to a single feed client so that only the changes are sent
+type state struct {
+	sequence uint64
+	cells    map[types.NCGI]Cell
+	ues      map[types.IMSI]UE
+}
+
+func newState() *state {
+	return &state{
+		cells: make(map[types.NCGI]Cell),
+		ues:   make(map[types.IMSI]UE),
+	}
+}
+
+// next computes the next frame from the current cells and UEs; the first frame is always a snapshot
+func (s *state) next(cells []Cell, ues []UE) *Frame {
+	frame := &Frame{
+		Type:     Delta,
+		Sequence: s.sequence,
+	}
+	if s.sequence == 0 {
+		frame.Type = Snapshot
+	}
+	s.sequence++
+
+	seenCells := make(map[types.NCGI]bool, len(cells))
+	for _, cell := range cells {
+		seenCells[cell.NCGI] = true
+		if prev, ok := s.cells[cell.NCGI]; !ok || prev != cell {
+			frame.Cells = append(frame.Cells, cell)
+			s.cells[cell.NCGI] = cell
+		}
+	}
+	for ncgi := range s.cells {
+		if !seenCells[ncgi] {
+			frame.RemovedCells = append(frame.RemovedCells, ncgi)
+			delete(s.cells, ncgi)
+		}
+	}
+
+	seenUEs := make(map[types.IMSI]bool, len(ues))
+	for _, ue := range ues {
+		seenUEs[ue.IMSI] = true
+		if prev, ok := s.ues[ue.IMSI]; !ok || ueChanged(prev, ue) {
+			frame.UEs = append(frame.UEs, ue)
+			s.ues[ue.IMSI] = ue
+		}
+	}
+	for imsi := range s.ues {
+		if !seenUEs[imsi] {
+			frame.RemovedUEs = append(frame.RemovedUEs, imsi)
+			delete(s.ues, imsi)
+		}
+	}
+	return frame
+}
+
+func ueChanged(prev UE, ue UE) bool {
+	return prev.ServingCell != ue.ServingCell ||
+		prev.Heading != ue.Heading ||
+		math.Abs(prev.Lat-ue.Lat) > positionEpsilon ||
+		math.Abs(prev.Lng-ue.Lng) > positionEpsilon
+}
@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package feed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateDeltas(t *testing.T) {
+	cells := []Cell{
+		{NCGI: 1, Lat: 1, Lng: 1, Azimuth: 0, Arc: 120},
+		{NCGI: 2, Lat: 2, Lng: 2, Azimuth: 120, Arc: 120},
+	}
+	ues := []UE{
+		{IMSI: 100, Lat: 1.5, Lng: 1.5, ServingCell: 1},
+		{IMSI: 200, Lat: 2.5, Lng: 2.5, ServingCell: 2},
+	}
+
+	st := newState()
+	frame := st.next(cells, ues)
+	assert.Equal(t, Snapshot, frame.Type)
+	assert.Len(t, frame.Cells, 2)
+	assert.Len(t, frame.UEs, 2)
+
+	frame = st.next(cells, ues)
+	assert.Equal(t, Delta, frame.Type)
+	assert.True(t, frame.IsEmpty())
+
+	ues[0].Lat = 1.6
+	ues[1].ServingCell = 1
+	frame = st.next(cells, ues)
+	assert.Len(t, frame.UEs, 2)
+	assert.Len(t, frame.Cells, 0)
+
+	frame = st.next(cells[:1], ues[:1])
+	assert.Equal(t, uint64(3), frame.Sequence)
+	assert.Len(t, frame.UEs, 0)
+	assert.Len(t, frame.RemovedCells, 1)
+	assert.Len(t, frame.RemovedUEs, 1)
+	assert.Equal(t, uint64(200), uint64(frame.RemovedUEs[0]))
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package feed
+
+import (
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// FrameType type of a feed frame
+type FrameType string
+
+const (
+	// Snapshot frame carrying the complete state
+	Snapshot FrameType = "snapshot"
+	// Delta frame carrying only the changes since the previous frame
+	Delta FrameType = "delta"
+)
+
+// Cell cell geometry as presented on the map
+type Cell struct {
+	NCGI    types.NCGI `json:"ncgi"`
+	Name    string     `json:"name,omitempty"`
+	Lat     float64    `json:"lat"`
+	Lng     float64    `json:"lng"`
+	Azimuth int32      `json:"azimuth"`
+	Arc     int32      `json:"arc"`
+	Color   string     `json:"color"`
+	TxPower float64    `json:"txPower"`
+}
+
+// UE UE position and serving cell line as presented on the map
+type UE struct {
+	IMSI          types.IMSI          `json:"imsi"`
+	Lat           float64             `json:"lat"`
+	Lng           float64             `json:"lng"`
+	Heading       uint32              `json:"heading"`
+	Speed         float64             `json:"speed"`              // km/h
+	Mobility      model.MobilityClass `json:"mobility,omitempty"` // mobility class, if any
+	ServingCell   types.NCGI          `json:"servingCell,omitempty"`
+	Strength      float64             `json:"strength,omitempty"`
+	SecondaryCell types.NCGI          `json:"secondaryCell,omitempty"` // primary cell of the secondary cell group, if dual connected
+	Throughput    float64             `json:"throughput,omitempty"`    // downlink throughput in kbps over all aggregated carriers
+}
+
+// Frame a single frame of the visualization feed
+type Frame struct {
+	Type         FrameType    `json:"type"`
+	Sequence     uint64       `json:"sequence"`
+	Cells        []Cell       `json:"cells,omitempty"`
+	UEs          []UE         `json:"ues,omitempty"`
+	RemovedCells []types.NCGI `json:"removedCells,omitempty"`
+	RemovedUEs   []types.IMSI `json:"removedUes,omitempty"`
+}
+
+// IsEmpty returns true if the frame carries no changes
+func (f *Frame) IsEmpty() bool {
+	return len(f.Cells) == 0 && len(f.UEs) == 0 && len(f.RemovedCells) == 0 && len(f.RemovedUEs) == 0
+}
+
+func cellToFeed(cell *model.Cell) Cell {
+	return Cell{
+		NCGI:    cell.NCGI,
+		Name:    cell.Name,
+		Lat:     cell.Sector.Center.Lat,
+		Lng:     cell.Sector.Center.Lng,
+		Azimuth: cell.Sector.Azimuth,
+		Arc:     cell.Sector.Arc,
+		Color:   cell.Color,
+		TxPower: cell.TxPowerDB,
+	}
+}
+
+func ueToFeed(ue *model.UE) UE {
+	u := UE{
+		IMSI:       ue.IMSI,
+		Lat:        ue.Location.Lat,
+		Lng:        ue.Location.Lng,
+		Heading:    ue.Heading,
+		Speed:      ue.Speed,
+		Mobility:   ue.Mobility,
+		Throughput: ue.Throughput(),
+	}
+	if ue.Cell != nil {
+		u.ServingCell = ue.Cell.NCGI
+		u.Strength = ue.Cell.Strength
+	}
+	if ue.SecondaryCell != nil {
+		u.SecondaryCell = ue.SecondaryCell.NCGI
+	}
+	return u
+}
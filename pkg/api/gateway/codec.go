@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package gateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The messages of the onos-api northbound APIs are generated with gogo protobuf
+var marshaler = jsonpb.Marshaler{
+	EmitDefaults: true,
+}
+
+var unmarshaler = jsonpb.Unmarshaler{
+	AllowUnknownFields: true,
+}
+
+// httpStatus maps gRPC status codes to HTTP status codes
+var httpStatus = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           http.StatusRequestTimeout,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusPreconditionFailed,
+	codes.Aborted:            http.StatusConflict,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+}
+
+// readRequest decodes the JSON request body into the given message
+func readRequest(w http.ResponseWriter, r *http.Request, message proto.Message) bool {
+	if err := unmarshaler.Unmarshal(r.Body, message); err != nil {
+		writeError(w, errors.NewInvalid(err.Error()))
+		return false
+	}
+	return true
+}
+
+// writeResponse encodes the given message as the JSON response body
+func writeResponse(w http.ResponseWriter, message proto.Message, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body, err := marshal(message)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// writeList encodes the given messages as a JSON array
+func writeList(w http.ResponseWriter, messages []proto.Message) {
+	list := make([]json.RawMessage, 0, len(messages))
+	for _, message := range messages {
+		body, err := marshal(message)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		list = append(list, body)
+	}
+	body, err := json.Marshal(list)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// marshal encodes the given message as JSON
+func marshal(message proto.Message) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := marshaler.Marshal(&buf, message); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteJSON encodes the given value as the JSON response body, or writes the given error if not nil; it is meant for
+// handlers registered using Handle which serve plain JSON rather than protobuf messages
+func WriteJSON(w http.ResponseWriter, value interface{}, err error) {
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	body, err := json.Marshal(value)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// writeError writes the given error using the HTTP status matching its gRPC status code
+func writeError(w http.ResponseWriter, err error) {
+	st, ok := status.FromError(err)
+	if !ok {
+		st = errors.Status(err)
+	}
+	code, ok := httpStatus[st.Code()]
+	if !ok {
+		code = http.StatusInternalServerError
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"code":    st.Code().String(),
+		"message": st.Message(),
+	})
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
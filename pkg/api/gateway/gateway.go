@@ -0,0 +1,442 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	_ "embed" // required for embedding the OpenAPI specification
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	metricsapi "github.com/onosproject/onos-api/go/onos/ransim/metrics"
+	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
+	"github.com/onosproject/ran-simulator/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+var log = liblog.GetLogger("api", "gateway")
+
+const requestTimeout = 15 * time.Second
+
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// Gateway exposes the northbound gRPC APIs as REST+JSON endpoints
+type Gateway struct {
+	conn       *grpc.ClientConn
+	server     *http.Server
+	mux        *http.ServeMux
+	authorizer *auth.Authorizer
+	nodes      modelapi.NodeModelClient
+	cells      modelapi.CellModelClient
+	ues        modelapi.UEModelClient
+	metrics    metricsapi.MetricsServiceClient
+}
+
+// NewGateway creates a new REST gateway which proxies requests to the given gRPC endpoint, connecting to it using the
+// given client TLS configuration. The gateway serves HTTPS only, using the given server TLS configuration, since the
+// requests carry the bearer tokens forwarded to the gRPC endpoint; the requests of the additional handlers are
+// authorized with the given authorizer.
+func NewGateway(grpcAddress string, port int, clientTLSConfig *tls.Config, serverTLSConfig *tls.Config,
+	authorizer *auth.Authorizer) (*Gateway, error) {
+	conn, err := grpc.DialContext(context.Background(), grpcAddress,
+		grpc.WithTransportCredentials(credentials.NewTLS(clientTLSConfig)),
+		grpc.WithUnaryInterceptor(tracing.UnaryClientInterceptor()))
+	if err != nil {
+		return nil, err
+	}
+	return newGateway(conn, port, serverTLSConfig, authorizer), nil
+}
+
+// newGateway creates a REST gateway which proxies requests through the given connection
+func newGateway(conn *grpc.ClientConn, port int, serverTLSConfig *tls.Config, authorizer *auth.Authorizer) *Gateway {
+	g := &Gateway{
+		conn:       conn,
+		authorizer: authorizer,
+		nodes:      modelapi.NewNodeModelClient(conn),
+		cells:      modelapi.NewCellModelClient(conn),
+		ues:        modelapi.NewUEModelClient(conn),
+		metrics:    metricsapi.NewMetricsServiceClient(conn),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/openapi.yaml", g.handleOpenAPI)
+	mux.HandleFunc("/v1/plmnid", g.handlePlmnID)
+	mux.HandleFunc("/v1/nodes", g.handleNodes)
+	mux.HandleFunc("/v1/nodes/", g.handleNode)
+	mux.HandleFunc("/v1/cells", g.handleCells)
+	mux.HandleFunc("/v1/cells/", g.handleCell)
+	mux.HandleFunc("/v1/ues", g.handleUEs)
+	mux.HandleFunc("/v1/ues/", g.handleUE)
+	mux.HandleFunc("/v1/uecount", g.handleUECount)
+	mux.HandleFunc("/v1/metrics/", g.handleMetrics)
+
+	g.mux = mux
+	g.server = &http.Server{
+		Addr:      fmt.Sprintf(":%d", port),
+		Handler:   mux,
+		TLSConfig: serverTLSConfig,
+	}
+	return g
+}
+
+// Handle registers an additional handler for the given pattern; it must be called before Start. Since the handler
+// is not served through the gRPC APIs, the gateway authenticates its requests itself and requires the users of the
+// requests which mutate the simulation, i.e. of all methods but GET and HEAD, to be operators.
+func (g *Gateway) Handle(pattern string, handler http.Handler) {
+	g.mux.Handle(pattern, g.authorize(handler))
+}
+
+// authorize authenticates the requests of the given handler and authorizes those which mutate the simulation
+func (g *Gateway) authorize(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role := auth.Viewer
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			role = auth.Operator
+		}
+		if err := g.authorizer.AuthorizeRequest(r, role); err != nil {
+			writeError(w, err)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
+// Start starts serving REST requests over HTTPS
+func (g *Gateway) Start() {
+	log.Infof("Starting REST gateway on %s", g.server.Addr)
+	go func() {
+		// The certificate is given by the TLS configuration of the server
+		if err := g.server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
+			log.Error(err)
+		}
+	}()
+}
+
+// Stop stops the REST gateway
+func (g *Gateway) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	if err := g.server.Shutdown(ctx); err != nil {
+		log.Warn(err)
+	}
+	if err := g.conn.Close(); err != nil {
+		log.Warn(err)
+	}
+}
+
+// requestContext returns the context of the gRPC call proxying the request, which carries the authorization header
+// and the trace context of the request
+func requestContext(r *http.Request) (context.Context, context.CancelFunc) {
+	ctx := tracing.Extract(r.Context(), r.Header)
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+	}
+	return context.WithTimeout(ctx, requestTimeout)
+}
+
+func (g *Gateway) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPISpec)
+}
+
+func (g *Gateway) handlePlmnID(w http.ResponseWriter, r *http.Request) {
+	if !AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+	response, err := g.nodes.GetPlmnID(ctx, &modelapi.PlmnIDRequest{})
+	writeResponse(w, response, err)
+}
+
+func (g *Gateway) handleNodes(w http.ResponseWriter, r *http.Request) {
+	if !AllowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+	if r.Method == http.MethodPost {
+		node := &types.Node{}
+		if !readRequest(w, r, node) {
+			return
+		}
+		response, err := g.nodes.CreateNode(ctx, &modelapi.CreateNodeRequest{Node: node})
+		writeResponse(w, response, err)
+		return
+	}
+
+	stream, err := g.nodes.ListNodes(ctx, &modelapi.ListNodesRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	list := make([]proto.Message, 0)
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			writeError(w, err)
+			return
+		}
+		list = append(list, response.Node)
+	}
+	writeList(w, list)
+}
+
+// handleNode serves /v1/nodes/{gnbid} and /v1/nodes/{gnbid}/agent/{command}
+func (g *Gateway) handleNode(w http.ResponseWriter, r *http.Request) {
+	elements := pathElements(r, "/v1/nodes/")
+	gnbID, err := strconv.ParseUint(elements[0], 0, 64)
+	if err != nil {
+		writeError(w, errors.NewInvalid("invalid GnbID %s", elements[0]))
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+
+	if len(elements) == 3 && elements[1] == "agent" {
+		if !AllowMethods(w, r, http.MethodPost) {
+			return
+		}
+		response, err := g.nodes.AgentControl(ctx, &modelapi.AgentControlRequest{GnbID: types.GnbID(gnbID), Command: elements[2]})
+		writeResponse(w, response, err)
+		return
+	} else if len(elements) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		response, err := g.nodes.GetNode(ctx, &modelapi.GetNodeRequest{GnbID: types.GnbID(gnbID)})
+		writeResponse(w, response, err)
+	case http.MethodPut:
+		node := &types.Node{}
+		if !readRequest(w, r, node) {
+			return
+		}
+		node.GnbID = types.GnbID(gnbID)
+		response, err := g.nodes.UpdateNode(ctx, &modelapi.UpdateNodeRequest{Node: node})
+		writeResponse(w, response, err)
+	case http.MethodDelete:
+		response, err := g.nodes.DeleteNode(ctx, &modelapi.DeleteNodeRequest{GnbID: types.GnbID(gnbID)})
+		writeResponse(w, response, err)
+	default:
+		AllowMethods(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+func (g *Gateway) handleCells(w http.ResponseWriter, r *http.Request) {
+	if !AllowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+	if r.Method == http.MethodPost {
+		cell := &types.Cell{}
+		if !readRequest(w, r, cell) {
+			return
+		}
+		response, err := g.cells.CreateCell(ctx, &modelapi.CreateCellRequest{Cell: cell})
+		writeResponse(w, response, err)
+		return
+	}
+
+	stream, err := g.cells.ListCells(ctx, &modelapi.ListCellsRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	list := make([]proto.Message, 0)
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			writeError(w, err)
+			return
+		}
+		list = append(list, response.Cell)
+	}
+	writeList(w, list)
+}
+
+// handleCell serves /v1/cells/{ncgi}
+func (g *Gateway) handleCell(w http.ResponseWriter, r *http.Request) {
+	elements := pathElements(r, "/v1/cells/")
+	ncgi, err := strconv.ParseUint(elements[0], 0, 64)
+	if err != nil || len(elements) != 1 {
+		writeError(w, errors.NewInvalid("invalid NCGI %s", elements[0]))
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+
+	switch r.Method {
+	case http.MethodGet:
+		response, err := g.cells.GetCell(ctx, &modelapi.GetCellRequest{NCGI: types.NCGI(ncgi)})
+		writeResponse(w, response, err)
+	case http.MethodPut:
+		cell := &types.Cell{}
+		if !readRequest(w, r, cell) {
+			return
+		}
+		cell.NCGI = types.NCGI(ncgi)
+		response, err := g.cells.UpdateCell(ctx, &modelapi.UpdateCellRequest{Cell: cell})
+		writeResponse(w, response, err)
+	case http.MethodDelete:
+		response, err := g.cells.DeleteCell(ctx, &modelapi.DeleteCellRequest{NCGI: types.NCGI(ncgi)})
+		writeResponse(w, response, err)
+	default:
+		AllowMethods(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
+
+func (g *Gateway) handleUEs(w http.ResponseWriter, r *http.Request) {
+	if !AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+	stream, err := g.ues.ListUEs(ctx, &modelapi.ListUEsRequest{})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	list := make([]proto.Message, 0)
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			writeError(w, err)
+			return
+		}
+		list = append(list, response.Ue)
+	}
+	writeList(w, list)
+}
+
+// handleUE serves /v1/ues/{imsi} and /v1/ues/{imsi}/cell/{ncgi}
+func (g *Gateway) handleUE(w http.ResponseWriter, r *http.Request) {
+	elements := pathElements(r, "/v1/ues/")
+	imsi, err := strconv.ParseUint(elements[0], 10, 64)
+	if err != nil {
+		writeError(w, errors.NewInvalid("invalid IMSI %s", elements[0]))
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+
+	if len(elements) == 3 && elements[1] == "cell" {
+		if !AllowMethods(w, r, http.MethodPost) {
+			return
+		}
+		ncgi, err := strconv.ParseUint(elements[2], 0, 64)
+		if err != nil {
+			writeError(w, errors.NewInvalid("invalid NCGI %s", elements[2]))
+			return
+		}
+		response, err := g.ues.MoveToCell(ctx, &modelapi.MoveToCellRequest{IMSI: types.IMSI(imsi), NCGI: types.NCGI(ncgi)})
+		writeResponse(w, response, err)
+		return
+	} else if len(elements) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		response, err := g.ues.GetUE(ctx, &modelapi.GetUERequest{IMSI: types.IMSI(imsi)})
+		writeResponse(w, response, err)
+	case http.MethodDelete:
+		response, err := g.ues.DeleteUE(ctx, &modelapi.DeleteUERequest{IMSI: types.IMSI(imsi)})
+		writeResponse(w, response, err)
+	default:
+		AllowMethods(w, r, http.MethodGet, http.MethodDelete)
+	}
+}
+
+func (g *Gateway) handleUECount(w http.ResponseWriter, r *http.Request) {
+	if !AllowMethods(w, r, http.MethodGet, http.MethodPut) {
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+	if r.Method == http.MethodPut {
+		request := &modelapi.SetUECountRequest{}
+		if !readRequest(w, r, request) {
+			return
+		}
+		response, err := g.ues.SetUECount(ctx, request)
+		writeResponse(w, response, err)
+		return
+	}
+	response, err := g.ues.GetUECount(ctx, &modelapi.GetUECountRequest{})
+	writeResponse(w, response, err)
+}
+
+// handleMetrics serves /v1/metrics/{entityid} and /v1/metrics/{entityid}/{name}
+func (g *Gateway) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	elements := pathElements(r, "/v1/metrics/")
+	entityID, err := strconv.ParseUint(elements[0], 0, 64)
+	if err != nil {
+		writeError(w, errors.NewInvalid("invalid entity ID %s", elements[0]))
+		return
+	}
+	ctx, cancel := requestContext(r)
+	defer cancel()
+
+	if len(elements) == 1 {
+		if !AllowMethods(w, r, http.MethodGet) {
+			return
+		}
+		response, err := g.metrics.List(ctx, &metricsapi.ListRequest{EntityID: entityID})
+		writeResponse(w, response, err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		response, err := g.metrics.Get(ctx, &metricsapi.GetRequest{EntityID: entityID, Name: elements[1]})
+		writeResponse(w, response, err)
+	case http.MethodDelete:
+		response, err := g.metrics.Delete(ctx, &metricsapi.DeleteRequest{EntityID: entityID, Name: elements[1]})
+		writeResponse(w, response, err)
+	default:
+		AllowMethods(w, r, http.MethodGet, http.MethodDelete)
+	}
+}
+
+func pathElements(r *http.Request, prefix string) []string {
+	return strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+}
+
+// AllowMethods checks the request uses one of the given methods and rejects it otherwise
+func AllowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	return false
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
+	"github.com/onosproject/ran-simulator/pkg/api/cells"
+	"github.com/onosproject/ran-simulator/pkg/api/nodes"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	cellstore "github.com/onosproject/ran-simulator/pkg/store/cells"
+	nodestore "github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/utils/cacert"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestGateway creates a gateway proxying its requests to the node and cell services of the test model
+func newTestGateway(t *testing.T, port int) *Gateway {
+	m := &model.Model{}
+	err := model.LoadConfig(m, "../../model/test")
+	assert.NoError(t, err)
+	nodeStore := nodestore.NewNodeRegistry(m.Nodes)
+	cellStore := cellstore.NewCellRegistry(m.Cells, nodeStore)
+	authorizer := auth.NewAuthorizer(false, nil)
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	nodes.NewService(nodeStore, m.PlmnID, authorizer).Register(server)
+	cells.NewService(cellStore, authorizer).Register(server)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}))
+	assert.NoError(t, err)
+
+	serverTLSConfig, err := cacert.NewServerTLSConfig("", "")
+	assert.NoError(t, err)
+	return newGateway(conn, port, serverTLSConfig, authorizer)
+}
+
+// serve serves the given request through the gateway and returns the recorded response
+func serve(g *Gateway, method string, target string, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	g.mux.ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return w
+}
+
+func TestNodeHandlers(t *testing.T) {
+	g := newTestGateway(t, 0)
+
+	w := serve(g, http.MethodGet, "/v1/plmnid", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"plmnid":`)
+
+	w = serve(g, http.MethodGet, "/v1/nodes", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	var list []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list, 2)
+
+	w = serve(g, http.MethodGet, "/v1/nodes/144470", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "144470")
+
+	w = serve(g, http.MethodPost, "/v1/nodes", `{"enbid": "144472", "cellEcgis": ["84325717505"]}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(g, http.MethodGet, "/v1/nodes/144472", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "144472")
+	w = serve(g, http.MethodDelete, "/v1/nodes/144472", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	w = serve(g, http.MethodGet, "/v1/nodes", "")
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list, 2)
+
+	// Invalid requests are rejected by the gateway itself
+	w = serve(g, http.MethodGet, "/v1/nodes/tower", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(g, http.MethodPost, "/v1/nodes", `{"gnbId":`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(g, http.MethodPatch, "/v1/nodes", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestCellHandlers(t *testing.T) {
+	g := newTestGateway(t, 0)
+
+	w := serve(g, http.MethodGet, "/v1/cells", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var list []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	assert.Len(t, list, 4)
+
+	w = serve(g, http.MethodGet, "/v1/cells/84325717505", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "84325717505")
+
+	w = serve(g, http.MethodGet, "/v1/cells/84325717505/neighbors", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = serve(g, http.MethodGet, "/v1/cells/1", "")
+	assert.Contains(t, w.Body.String(), "not found")
+}
+
+func TestServeTLS(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	port := lis.Addr().(*net.TCPAddr).Port
+	assert.NoError(t, lis.Close())
+
+	g := newTestGateway(t, port)
+	g.Start()
+	defer g.Stop()
+
+	// The gateway presents the default certificate, which is verified against the default CA
+	clientTLSConfig, err := cacert.NewClientTLSConfig("")
+	assert.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}, Timeout: time.Second}
+	url := fmt.Sprintf("https://localhost:%d/v1/openapi.yaml", port)
+	var response *http.Response
+	assert.Eventually(t, func() bool {
+		response, err = client.Get(url)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.NotNil(t, response.TLS)
+	assert.NoError(t, response.Body.Close())
+
+	// Plain HTTP requests, whose bearer tokens would travel in the clear, are not served
+	response, err = client.Get(fmt.Sprintf("http://localhost:%d/v1/openapi.yaml", port))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	assert.NoError(t, response.Body.Close())
+
+	// Clients which do not trust the CA of the certificate are rejected by the handshake
+	_, err = (&http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{}}, Timeout: time.Second}).Get(url)
+	assert.Error(t, err)
+}
+
+func TestHandle(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// All requests are served if authentication is disabled
+	g := &Gateway{mux: http.NewServeMux(), authorizer: auth.NewAuthorizer(false, nil)}
+	g.Handle("/v1/feed", handler)
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		w := httptest.NewRecorder()
+		g.mux.ServeHTTP(w, httptest.NewRequest(method, "/v1/feed", nil))
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	}
+
+	// Requests without a bearer token are not served, whatever their method
+	g = &Gateway{mux: http.NewServeMux(), authorizer: auth.NewAuthorizer(true, nil)}
+	g.Handle("/v1/feed", handler)
+	for _, method := range []string{http.MethodGet, http.MethodHead, http.MethodDelete} {
+		w := httptest.NewRecorder()
+		g.mux.ServeHTTP(w, httptest.NewRequest(method, "/v1/feed", nil))
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	}
+}
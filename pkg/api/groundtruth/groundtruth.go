@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package groundtruth
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/groundtruth"
+)
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/groundtruth"
+
+// Handler serves the ground truth of the UEs, so that localization and prediction xApps can be evaluated against it
+type Handler struct {
+	oracle *groundtruth.Oracle
+}
+
+// NewHandler creates a new ground truth API handler
+func NewHandler(oracle *groundtruth.Oracle) *Handler {
+	return &Handler{
+		oracle: oracle,
+	}
+}
+
+// ServeHTTP lists the ground truth of all UEs on GET /v1/groundtruth and gets the ground truth of a UE on
+// GET /v1/groundtruth/{imsi}; the ground truth is degraded as given by the optional "noise" query parameter, in
+// meters, and "delay" query parameter, as a duration such as 5s
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	options, err := parseOptions(r)
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	if element == "" {
+		truths, err := h.oracle.List(r.Context(), options)
+		gateway.WriteJSON(w, truths, err)
+		return
+	}
+	imsi, err := strconv.ParseUint(element, 10, 64)
+	if err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid("invalid IMSI %s", element))
+		return
+	}
+	truth, err := h.oracle.Get(r.Context(), types.IMSI(imsi), options)
+	gateway.WriteJSON(w, truth, err)
+}
+
+func parseOptions(r *http.Request) (groundtruth.Options, error) {
+	var options groundtruth.Options
+	query := r.URL.Query()
+	if param := query.Get("noise"); param != "" {
+		noise, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return options, errors.NewInvalid("invalid noise %s", param)
+		}
+		options.Noise = noise
+	}
+	if param := query.Get("delay"); param != "" {
+		delay, err := time.ParseDuration(param)
+		if err != nil {
+			return options, errors.NewInvalid("invalid delay %s", param)
+		}
+		options.Delay = delay
+	}
+	return options, nil
+}
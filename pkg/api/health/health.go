@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+var log = liblog.GetLogger("api", "health")
+
+// ServiceName is the name under which the readiness of the simulator is reported
+const ServiceName = "onos.ransim"
+
+// NewService returns a new health Service; the simulator is reported as not ready until SetReady is called
+func NewService() *Service {
+	server := health.NewServer()
+	server.SetServingStatus("", healthapi.HealthCheckResponse_NOT_SERVING)
+	server.SetServingStatus(ServiceName, healthapi.HealthCheckResponse_NOT_SERVING)
+	return &Service{
+		server: server,
+	}
+}
+
+// Service is a Service implementation for the gRPC health checking protocol
+type Service struct {
+	service.Service
+	server *health.Server
+}
+
+// Register registers the health Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	healthapi.RegisterHealthServer(r, s.server)
+}
+
+// SetReady changes the readiness reported to the health checks
+func (s *Service) SetReady(ready bool) {
+	status := healthapi.HealthCheckResponse_NOT_SERVING
+	if ready {
+		status = healthapi.HealthCheckResponse_SERVING
+	}
+	log.Infof("Readiness changed to %s", status)
+	s.server.SetServingStatus("", status)
+	s.server.SetServingStatus(ServiceName, status)
+}
+
+// IsReady returns whether the simulator is reported as ready
+func (s *Service) IsReady() bool {
+	resp, err := s.server.Check(context.Background(), &healthapi.HealthCheckRequest{Service: ServiceName})
+	return err == nil && resp.Status == healthapi.HealthCheckResponse_SERVING
+}
+
+var _ service.Service = &Service{}
@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	healthapi "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestReadiness(t *testing.T) {
+	s := NewService()
+	assert.False(t, s.IsReady())
+
+	s.SetReady(true)
+	assert.True(t, s.IsReady())
+	resp, err := s.server.Check(context.Background(), &healthapi.HealthCheckRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, healthapi.HealthCheckResponse_SERVING, resp.Status)
+
+	s.SetReady(false)
+	assert.False(t, s.IsReady())
+}
@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identities
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+var log = logging.GetLogger("api", "identities")
+
+// Path path served by the handler
+const Path = "/v1/identities"
+
+// Export formats
+const (
+	// JSON exports the identities as a JSON array
+	JSON = "json"
+	// CSV exports the identities as CSV, with a header line
+	CSV = "csv"
+)
+
+// Identity identities of a UE in the E2 messages and its assignments in the simulation
+type Identity struct {
+	IMSI        types.IMSI          `json:"imsi"`
+	AmfUeNgapID int64               `json:"amfUeNgapId"` // UE ID of the E2SM-MHO indications and control requests
+	CRNTI       types.CRNTI         `json:"crnti"`
+	GnbID       types.GnbID         `json:"gnbId,omitempty"` // serving node
+	NCGI        types.NCGI          `json:"ncgi,omitempty"`  // serving cell
+	Type        model.UEType        `json:"type,omitempty"`
+	Mobility    model.MobilityClass `json:"mobility,omitempty"`
+	Route       *Route              `json:"route,omitempty"` // route the UE follows, if any
+}
+
+// Route route assigned to a UE
+type Route struct {
+	Points      int    `json:"points"`
+	SpeedAvg    uint32 `json:"speedAvg"`    // meters per hour
+	SpeedStdDev uint32 `json:"speedStdDev"` // meters per hour
+}
+
+var csvHeader = []string{"imsi", "amfUeNgapId", "crnti", "gnbId", "ncgi", "type", "mobility", "routePoints",
+	"routeSpeedAvg", "routeSpeedStdDev"}
+
+// Handler exports the mapping of the IMSIs of the UEs to the UE IDs carried in the E2 messages, along with their
+// route and profile, so that the data collected by the RIC can be joined against the simulation ground truth
+type Handler struct {
+	mu         sync.RWMutex
+	ueStore    ues.Store
+	routeStore routes.Store
+}
+
+// NewHandler creates a new UE identities API handler
+func NewHandler(ueStore ues.Store, routeStore routes.Store) *Handler {
+	return &Handler{
+		ueStore:    ueStore,
+		routeStore: routeStore,
+	}
+}
+
+// Reset makes the handler operate on the given stores, which replace the previous ones
+func (h *Handler) Reset(ueStore ues.Store, routeStore routes.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ueStore = ueStore
+	h.routeStore = routeStore
+}
+
+// ServeHTTP exports the identities of the UEs on GET /v1/identities, as JSON or, given the format=csv query
+// parameter, as a CSV file
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	identities := h.List(r.Context())
+	switch format := r.URL.Query().Get("format"); format {
+	case "", JSON:
+		gateway.WriteJSON(w, identities, nil)
+	case CSV:
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="ue-identities.csv"`)
+		if err := WriteCSV(w, identities); err != nil {
+			log.Warn(err)
+		}
+	default:
+		gateway.WriteJSON(w, nil, errors.NewInvalid("unknown format %s", format))
+	}
+}
+
+// List returns the identities of all UEs, in ascending order of IMSI
+func (h *Handler) List(ctx context.Context) []Identity {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ueList := h.ueStore.ListAllUEs(ctx)
+	identities := make([]Identity, 0, len(ueList))
+	for _, ue := range ueList {
+		identity := Identity{
+			IMSI:        ue.IMSI,
+			AmfUeNgapID: int64(ue.IMSI),
+			CRNTI:       ue.CRNTI,
+			Type:        ue.Type,
+			Mobility:    ue.Mobility,
+		}
+		if ue.Cell != nil {
+			identity.GnbID = ue.Cell.ID
+			identity.NCGI = ue.Cell.NCGI
+		}
+		if route, err := h.routeStore.Get(ctx, ue.IMSI); err == nil {
+			identity.Route = &Route{
+				Points:      len(route.Points),
+				SpeedAvg:    route.SpeedAvg,
+				SpeedStdDev: route.SpeedStdDev,
+			}
+		}
+		identities = append(identities, identity)
+	}
+	sort.Slice(identities, func(i, j int) bool { return identities[i].IMSI < identities[j].IMSI })
+	return identities
+}
+
+// WriteCSV writes the identities as CSV; the route columns are empty for the UEs without route
+func WriteCSV(w io.Writer, identities []Identity) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, identity := range identities {
+		record := []string{
+			strconv.FormatUint(uint64(identity.IMSI), 10),
+			strconv.FormatInt(identity.AmfUeNgapID, 10),
+			strconv.FormatUint(uint64(identity.CRNTI), 10),
+			strconv.FormatUint(uint64(identity.GnbID), 10),
+			strconv.FormatUint(uint64(identity.NCGI), 10),
+			string(identity.Type),
+			string(identity.Mobility),
+			"", "", "",
+		}
+		if identity.Route != nil {
+			record[7] = strconv.Itoa(identity.Route.Points)
+			record[8] = strconv.FormatUint(uint64(identity.Route.SpeedAvg), 10)
+			record[9] = strconv.FormatUint(uint64(identity.Route.SpeedStdDev), 10)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package identities
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strconv"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentities(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	ueStore := ues.NewUERegistry(m.UECount, cellStore, "connected")
+	routeStore := routes.NewRouteRegistry()
+	handler := NewHandler(ueStore, routeStore)
+
+	ueList := ueStore.ListAllUEs(ctx)
+	routed := ueList[0]
+	assert.NoError(t, routeStore.Add(ctx, &model.Route{
+		IMSI:     routed.IMSI,
+		Points:   []*model.Coordinate{{Lat: 52.12, Lng: 13.4}, {Lat: 52.13, Lng: 13.41}},
+		SpeedAvg: 40000,
+	}))
+
+	identities := handler.List(ctx)
+	assert.Len(t, identities, len(ueList))
+	for i, identity := range identities {
+		if i > 0 {
+			assert.True(t, identities[i-1].IMSI < identity.IMSI)
+		}
+		ue, err := ueStore.Get(ctx, identity.IMSI)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(ue.IMSI), identity.AmfUeNgapID)
+		assert.Equal(t, ue.CRNTI, identity.CRNTI)
+		assert.Equal(t, ue.Cell.NCGI, identity.NCGI)
+		assert.Equal(t, ue.Cell.ID, identity.GnbID)
+		if identity.IMSI == routed.IMSI {
+			assert.Equal(t, &Route{Points: 2, SpeedAvg: 40000}, identity.Route)
+		} else {
+			assert.Nil(t, identity.Route)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteCSV(buf, identities))
+	records, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, records, len(identities)+1)
+	assert.Equal(t, csvHeader, records[0])
+	assert.Equal(t, strconv.FormatUint(uint64(identities[0].IMSI), 10), records[1][0])
+}
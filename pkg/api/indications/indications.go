@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indications
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	indicationsapi "github.com/onosproject/ran-simulator/api/indications"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
+	"github.com/onosproject/ran-simulator/pkg/e2agent"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/agents"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"google.golang.org/grpc"
+)
+
+var log = liblog.GetLogger("api", "indications")
+
+// NewService returns a new indications Service injecting indications with the given injector
+func NewService(injector *Injector, authorizer *auth.Authorizer) service.Service {
+	return &Service{
+		injector:   injector,
+		authorizer: authorizer,
+	}
+}
+
+// Service is a Service implementation for the injection of custom indications
+type Service struct {
+	service.Service
+	injector   *Injector
+	authorizer *auth.Authorizer
+}
+
+// Register registers the indications Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	server := &Server{
+		injector:   s.injector,
+		authorizer: s.authorizer,
+	}
+	indicationsapi.RegisterIndicationsServer(r, server)
+}
+
+// Server implements the indications gRPC service
+type Server struct {
+	injector   *Injector
+	authorizer *auth.Authorizer
+}
+
+// InjectIndication sends the indication of the request to its subscription
+func (s *Server) InjectIndication(ctx context.Context, request *indicationsapi.InjectIndicationRequest) (*indicationsapi.InjectIndicationResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
+	if err := s.injector.Inject(ctx, request); err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	return &indicationsapi.InjectIndicationResponse{}, nil
+}
+
+// Injector injects custom indications into the subscriptions of the nodes, which lets xApp developers test edge
+// case payloads without modifying the simulator
+type Injector struct {
+	mu     sync.RWMutex
+	agents agents.Agents
+}
+
+// NewInjector creates a new injector; indications can not be injected until the agents are started
+func NewInjector() *Injector {
+	return &Injector{}
+}
+
+// Reset makes the injector inject indications into the subscriptions of the given agents, which replace the previous
+// ones
+func (i *Injector) Reset(agents agents.Agents) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.agents = agents
+}
+
+// Inject sends the indication of the request to its subscription
+func (i *Injector) Inject(ctx context.Context, request *indicationsapi.InjectIndicationRequest) error {
+	if len(request.Message) == 0 {
+		return errors.NewInvalid("indication message is missing")
+	}
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.agents == nil {
+		return errors.NewUnavailable("E2 agents are not started")
+	}
+	indication := e2agent.Indication{
+		Header:  request.Header,
+		Message: request.Message,
+		Encoded: request.Encoded,
+	}
+	if action, ok := request.Action.(*indicationsapi.InjectIndicationRequest_ActionId); ok {
+		actionID := e2aptypes.RicActionID(action.ActionId)
+		indication.ActionID = &actionID
+	}
+	gnbID := types.GnbID(request.GnbId)
+	id := subscriptions.ID(request.SubscriptionId)
+	log.Debugf("Injecting indication into subscription %s of node %d", id, gnbID)
+	return i.agents.InjectIndication(ctx, gnbID, id, indication)
+}
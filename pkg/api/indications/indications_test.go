@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indications
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	indicationsapi "github.com/onosproject/ran-simulator/api/indications"
+	"github.com/onosproject/ran-simulator/pkg/e2agent"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/agents"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/stretchr/testify/assert"
+)
+
+// testAgents records the injected indications
+type testAgents struct {
+	agents.Agents
+	gnbID      types.GnbID
+	id         subscriptions.ID
+	indication e2agent.Indication
+}
+
+func (a *testAgents) InjectIndication(ctx context.Context, gnbID types.GnbID, id subscriptions.ID, indication e2agent.Indication) error {
+	a.gnbID, a.id, a.indication = gnbID, id, indication
+	return nil
+}
+
+func TestInjectIndication(t *testing.T) {
+	injector := NewInjector()
+	server := &Server{injector: injector}
+	request := &indicationsapi.InjectIndicationRequest{
+		GnbId:          144470,
+		SubscriptionId: "1",
+		Action:         &indicationsapi.InjectIndicationRequest_ActionId{ActionId: 3},
+		Header:         []byte{1, 2},
+		Message:        []byte{3, 4, 5},
+	}
+
+	_, err := server.InjectIndication(context.Background(), request)
+	assert.True(t, errors.IsUnavailable(errors.FromGRPC(err)))
+
+	ta := &testAgents{}
+	injector.Reset(ta)
+	_, err = server.InjectIndication(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, types.GnbID(144470), ta.gnbID)
+	assert.Equal(t, subscriptions.ID("1"), ta.id)
+	assert.Equal(t, []byte{1, 2}, ta.indication.Header)
+	assert.Equal(t, []byte{3, 4, 5}, ta.indication.Message)
+	assert.EqualValues(t, 3, *ta.indication.ActionID)
+	assert.False(t, ta.indication.Encoded)
+
+	request.Action = nil
+	_, err = server.InjectIndication(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, ta.indication.ActionID)
+
+	err = injector.Inject(context.Background(), &indicationsapi.InjectIndicationRequest{GnbId: 144470, SubscriptionId: "1"})
+	assert.True(t, errors.IsInvalid(err))
+}
@@ -0,0 +1,115 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package interference
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+)
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/interference"
+
+// Interference external interference of a cell
+type Interference struct {
+	NCGI  types.NCGI `json:"ncgi"`
+	Level float64    `json:"level"` // rise in dB of the noise floor of the UEs served by the cell
+}
+
+// Handler gets and sets the external interference of the cells, degrading the SINR of the UEs they serve
+type Handler struct {
+	mu        sync.RWMutex
+	cellStore cells.Store
+}
+
+// NewHandler creates a new interference API handler
+func NewHandler(cellStore cells.Store) *Handler {
+	return &Handler{
+		cellStore: cellStore,
+	}
+}
+
+// Reset makes the handler operate on the given cell store, which replaces the previous one
+func (h *Handler) Reset(cellStore cells.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cellStore = cellStore
+}
+
+func (h *Handler) getCellStore() cells.Store {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.cellStore
+}
+
+// ServeHTTP lists the cells with external interference on GET /v1/interference, gets the interference of a cell on
+// GET /v1/interference/{ncgi}, sets it to the level query parameter on PUT /v1/interference/{ncgi} and clears it on
+// DELETE /v1/interference/{ncgi}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cellStore := h.getCellStore()
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	if element == "" {
+		if gateway.AllowMethods(w, r, http.MethodGet) {
+			list(w, r, cellStore)
+		}
+		return
+	}
+	if strings.Contains(element, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if !gateway.AllowMethods(w, r, http.MethodGet, http.MethodPut, http.MethodDelete) {
+		return
+	}
+	ncgi, err := strconv.ParseUint(element, 0, 64)
+	if err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid("invalid NCGI %s", element))
+		return
+	}
+	cell, err := cellStore.Get(r.Context(), types.NCGI(ncgi))
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+
+	var level float64
+	switch r.Method {
+	case http.MethodGet:
+		gateway.WriteJSON(w, Interference{NCGI: cell.NCGI, Level: cell.Interference}, nil)
+		return
+	case http.MethodPut:
+		value := r.URL.Query().Get("level")
+		level, err = strconv.ParseFloat(value, 64)
+		if err != nil || level < 0 {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid interference level %s", value))
+			return
+		}
+	}
+	updated := *cell
+	updated.Interference = level
+	gateway.WriteJSON(w, nil, cellStore.Update(r.Context(), &updated))
+}
+
+func list(w http.ResponseWriter, r *http.Request, cellStore cells.Store) {
+	cellList, err := cellStore.List(r.Context())
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+	interferences := make([]Interference, 0)
+	for _, cell := range cellList {
+		if cell.Interference != 0 {
+			interferences = append(interferences, Interference{NCGI: cell.NCGI, Level: cell.Interference})
+		}
+	}
+	gateway.WriteJSON(w, interferences, nil)
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 
@@ -25,29 +26,33 @@ import (
 var log = liblog.GetLogger("api", "metrics")
 
 // NewService returns a new metrics Service
-func NewService(metricsStore metrics.Store) service.Service {
+func NewService(metricsStore metrics.Store, authorizer *auth.Authorizer) service.Service {
 	return &Service{
-		store: metricsStore,
+		store:      metricsStore,
+		authorizer: authorizer,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	store metrics.Store
+	store      metrics.Store
+	authorizer *auth.Authorizer
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		s.store,
+		store:      s.store,
+		authorizer: s.authorizer,
 	}
 	metricsapi.RegisterMetricsServiceServer(r, server)
 }
 
 // Server implements the metrics gRPC service
 type Server struct {
-	store metrics.Store
+	store      metrics.Store
+	authorizer *auth.Authorizer
 }
 
 func bitWidth(t string) int {
@@ -119,6 +124,9 @@ func metricToAPI(entityID uint64, name string, v interface{}) *metricsapi.Metric
 
 // Set sets the value of the specified metric
 func (s *Server) Set(ctx context.Context, request *metricsapi.SetRequest) (*metricsapi.SetResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received set metric request: %+v", request)
 	m := request.Metric
 	err := s.store.Set(ctx, m.EntityID, m.Key, value(m.Value, m.Type))
@@ -139,6 +147,9 @@ func (s *Server) Get(ctx context.Context, request *metricsapi.GetRequest) (*metr
 
 // Delete deletes the value of the specified metric
 func (s *Server) Delete(ctx context.Context, request *metricsapi.DeleteRequest) (*metricsapi.DeleteResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received delete metric request: %+v", request)
 	err := s.store.Delete(ctx, request.EntityID, request.Name)
 	if err != nil {
@@ -149,6 +160,9 @@ func (s *Server) Delete(ctx context.Context, request *metricsapi.DeleteRequest)
 
 // DeleteAll deletes all metrics of the specified entity
 func (s *Server) DeleteAll(ctx context.Context, request *metricsapi.DeleteAllRequest) (*metricsapi.DeleteAllResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received delete all metric request: %+v", request)
 	err := s.store.DeleteAll(ctx, request.EntityID)
 	if err != nil {
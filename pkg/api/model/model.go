@@ -10,11 +10,15 @@ import (
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
 	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
 	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"google.golang.org/grpc"
 )
 
 var log = liblog.GetLogger("api", "model")
 
+// ReloadDataSetType is the data set type requesting an incremental reload of the running model
+const ReloadDataSetType = "reload"
+
 // ManagementDelegate provides means to clear and load the model and resume the simulation
 type ManagementDelegate interface {
 	// PauseAndClear pauses simulation and clears the model
@@ -23,6 +27,9 @@ type ManagementDelegate interface {
 	// LoadModel loads the new model into the simulator
 	LoadModel(ctx context.Context, modelData []byte) error
 
+	// ReloadModel applies the changes of the given model to the running simulation
+	ReloadModel(ctx context.Context, modelData []byte) error
+
 	// LoadMetrics loads new metrics into the simulator
 	LoadMetrics(ctx context.Context, name string, metricsData []byte) error
 
@@ -31,35 +38,50 @@ type ManagementDelegate interface {
 }
 
 // NewService returns a new model Service
-func NewService(delegate ManagementDelegate) service.Service {
+func NewService(delegate ManagementDelegate, authorizer *auth.Authorizer) service.Service {
 	return &Service{
-		delegate: delegate,
+		delegate:   delegate,
+		authorizer: authorizer,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	delegate ManagementDelegate
+	delegate   ManagementDelegate
+	authorizer *auth.Authorizer
 }
 
 // Register registers the ModelService with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		delegate: s.delegate,
+		delegate:   s.delegate,
+		authorizer: s.authorizer,
 	}
 	modelapi.RegisterModelServiceServer(r, server)
 }
 
 // Server implements the ModelService gRPC service
 type Server struct {
-	delegate ManagementDelegate
+	delegate   ManagementDelegate
+	authorizer *auth.Authorizer
 }
 
 // Load loads new data sets into the simulator
 func (s *Server) Load(ctx context.Context, request *modelapi.LoadRequest) (*modelapi.LoadResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received model load request: %v", request)
 
+	// Apply incremental changes without pausing the simulation
+	if len(request.DataSet) == 1 && request.DataSet[0].Type == ReloadDataSetType {
+		if err := s.delegate.ReloadModel(ctx, request.DataSet[0].Data); err != nil {
+			return nil, err
+		}
+		return &modelapi.LoadResponse{}, nil
+	}
+
 	// Stop simulation and clear model
 	s.delegate.PauseAndClear(ctx)
 
@@ -85,6 +107,9 @@ func (s *Server) Load(ctx context.Context, request *modelapi.LoadRequest) (*mode
 
 // Clear clears model data
 func (s *Server) Clear(ctx context.Context, request *modelapi.ClearRequest) (*modelapi.ClearResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received model clear request: %v", request)
 	s.delegate.PauseAndClear(ctx)
 	if request.Resume {
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package monitor
+
+import (
+	"net/http"
+
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/monitor"
+)
+
+// Path path served by the handler
+const Path = "/v1/monitor"
+
+// Handler exposes the resource usage of the simulation sampled by the self-health monitor
+type Handler struct {
+	monitor *monitor.Monitor
+}
+
+// NewHandler creates a new monitor API handler
+func NewHandler(monitor *monitor.Monitor) *Handler {
+	return &Handler{
+		monitor: monitor,
+	}
+}
+
+// ServeHTTP returns the latest sample on GET /v1/monitor; a new sample is taken if the sample query parameter is set
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	if r.URL.Query().Get("sample") != "" {
+		gateway.WriteJSON(w, h.monitor.Sample(r.Context()), nil)
+		return
+	}
+	gateway.WriteJSON(w, h.monitor.Last(r.Context()), nil)
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package names
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+)
+
+// Path path served by the handler
+const Path = "/v1/names"
+
+// Kinds of named entities
+const (
+	// Node E2 node, identified by its gNB ID
+	Node = "node"
+	// Cell cell, identified by its NCGI
+	Cell = "cell"
+)
+
+// Entry named node or cell
+type Entry struct {
+	Name   string            `json:"name"`
+	Kind   string            `json:"kind"`
+	ID     uint64            `json:"id"` // gNB ID of a node, NCGI of a cell
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Handler lists the nodes and cells by their friendly names, so that clients can refer to them by name rather than
+// by their gNB ID or NCGI
+type Handler struct {
+	mu        sync.RWMutex
+	nodeStore nodes.Store
+	cellStore cells.Store
+}
+
+// NewHandler creates a new names API handler
+func NewHandler(nodeStore nodes.Store, cellStore cells.Store) *Handler {
+	return &Handler{
+		nodeStore: nodeStore,
+		cellStore: cellStore,
+	}
+}
+
+// Reset makes the handler operate on the given stores, which replace the previous ones
+func (h *Handler) Reset(nodeStore nodes.Store, cellStore cells.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.nodeStore = nodeStore
+	h.cellStore = cellStore
+}
+
+// ServeHTTP lists the named nodes and cells on GET /v1/names. The name query parameter looks up the nodes and cells
+// with the given name, and the label query parameter, given as key=value, those with the given label.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	entries, err := h.List(r.Context())
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+	query := r.URL.Query()
+	if label := query.Get("label"); label != "" {
+		kv := strings.SplitN(label, "=", 2)
+		if len(kv) != 2 {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid label %s; expected key=value", label))
+			return
+		}
+		entries = filter(entries, func(entry Entry) bool {
+			value, ok := entry.Labels[kv[0]]
+			return ok && value == kv[1]
+		})
+	}
+	if name := query.Get("name"); name != "" {
+		entries = filter(entries, func(entry Entry) bool {
+			return entry.Name == name
+		})
+		if len(entries) == 0 {
+			gateway.WriteJSON(w, nil, errors.NewNotFound("no node or cell named %s", name))
+			return
+		}
+	}
+	gateway.WriteJSON(w, entries, nil)
+}
+
+// List returns the nodes and cells in order of name, nodes first if a node and a cell have the same name
+func (h *Handler) List(ctx context.Context) ([]Entry, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	nodeList, err := h.nodeStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cellList, err := h.cellStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(nodeList)+len(cellList))
+	for _, node := range nodeList {
+		entries = append(entries, Entry{Name: node.Name, Kind: Node, ID: uint64(node.GnbID), Labels: node.Labels})
+	}
+	for _, cell := range cellList {
+		entries = append(entries, Entry{Name: cell.Name, Kind: Cell, ID: uint64(cell.NCGI), Labels: cell.Labels})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind == Node
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+func filter(entries []Entry, keep func(entry Entry) bool) []Entry {
+	kept := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if keep(entry) {
+			kept = append(kept, entry)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package names
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNames(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	cell, err := cellStore.Get(ctx, m.Cells["cell2"].NCGI)
+	assert.NoError(t, err)
+	cell.Labels = map[string]string{"site": "rooftop"}
+	assert.NoError(t, cellStore.Update(ctx, cell))
+	handler := NewHandler(nodeStore, cellStore)
+
+	entries, err := handler.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, entries, len(m.Nodes)+len(m.Cells))
+	assert.Equal(t, Entry{Name: "cell1", Kind: Cell, ID: uint64(m.Cells["cell1"].NCGI)}, entries[0])
+
+	get := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Path+query, nil))
+		return w
+	}
+	w := get("?name=node2")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	assert.Equal(t, []Entry{{Name: "node2", Kind: Node, ID: uint64(m.Nodes["node2"].GnbID)}}, entries)
+
+	w = get("?label=site=rooftop")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "cell2", entries[0].Name)
+
+	assert.Equal(t, http.StatusNotFound, get("?name=Tower-9").Code)
+	assert.Equal(t, http.StatusBadRequest, get("?label=site").Code)
+}
@@ -8,6 +8,7 @@ package nodes
 import (
 	"context"
 
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 
 	modelapi "github.com/onosproject/onos-api/go/onos/ransim/model"
@@ -22,33 +23,37 @@ import (
 var log = liblog.GetLogger("api", "nodes")
 
 // NewService returns a new model Service
-func NewService(nodeStore nodes.Store, plmnID types.PlmnID) service.Service {
+func NewService(nodeStore nodes.Store, plmnID types.PlmnID, authorizer *auth.Authorizer) service.Service {
 	return &Service{
-		plmnID:    plmnID,
-		nodeStore: nodeStore,
+		plmnID:     plmnID,
+		nodeStore:  nodeStore,
+		authorizer: authorizer,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	plmnID    types.PlmnID
-	nodeStore nodes.Store
+	plmnID     types.PlmnID
+	nodeStore  nodes.Store
+	authorizer *auth.Authorizer
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		plmnID:    s.plmnID,
-		nodeStore: s.nodeStore,
+		plmnID:     s.plmnID,
+		nodeStore:  s.nodeStore,
+		authorizer: s.authorizer,
 	}
 	modelapi.RegisterNodeModelServer(r, server)
 }
 
 // Server implements the TrafficSim gRPC service for administrative facilities.
 type Server struct {
-	plmnID    types.PlmnID
-	nodeStore nodes.Store
+	plmnID     types.PlmnID
+	nodeStore  nodes.Store
+	authorizer *auth.Authorizer
 }
 
 func nodeToAPI(node *model.Node) *types.Node {
@@ -78,6 +83,9 @@ func (s *Server) GetPlmnID(ctx context.Context, request *modelapi.PlmnIDRequest)
 
 // CreateNode creates a new simulated E2 node
 func (s *Server) CreateNode(ctx context.Context, request *modelapi.CreateNodeRequest) (*modelapi.CreateNodeResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received create node request: %+v", request)
 	err := s.nodeStore.Add(ctx, nodeToModel(request.Node))
 	if err != nil {
@@ -98,8 +106,16 @@ func (s *Server) GetNode(ctx context.Context, request *modelapi.GetNodeRequest)
 
 // UpdateNode updates the specified simulated E2 node
 func (s *Server) UpdateNode(ctx context.Context, request *modelapi.UpdateNodeRequest) (*modelapi.UpdateNodeResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received update node request: %+v", request)
-	err := s.nodeStore.Update(ctx, nodeToModel(request.Node))
+	node := nodeToModel(request.Node)
+	// Names and labels are not part of the API; the node keeps those it has
+	if prev, err := s.nodeStore.Get(ctx, node.GnbID); err == nil {
+		node.Name, node.Labels = prev.Name, prev.Labels
+	}
+	err := s.nodeStore.Update(ctx, node)
 	if err != nil {
 		return nil, err
 	}
@@ -108,6 +124,9 @@ func (s *Server) UpdateNode(ctx context.Context, request *modelapi.UpdateNodeReq
 
 // DeleteNode deletes the specified simulated E2 node
 func (s *Server) DeleteNode(ctx context.Context, request *modelapi.DeleteNodeRequest) (*modelapi.DeleteNodeResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received delete node request: %v", request)
 	_, err := s.nodeStore.Delete(ctx, request.GnbID)
 	if err != nil {
@@ -171,6 +190,9 @@ func (s *Server) WatchNodes(request *modelapi.WatchNodesRequest, server modelapi
 
 // AgentControl allows control over the lifecycle of the agent running on behalf of the simulated E2 node
 func (s *Server) AgentControl(ctx context.Context, request *modelapi.AgentControlRequest) (*modelapi.AgentControlResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	node, err := s.nodeStore.Get(ctx, request.GnbID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package outages
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/outage"
+)
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/outages"
+
+// Handler puts cells out of service and back in service, so that coverage holes can be created on demand
+type Handler struct {
+	scheduler *outage.Scheduler
+}
+
+// NewHandler creates a new outages API handler
+func NewHandler(scheduler *outage.Scheduler) *Handler {
+	return &Handler{
+		scheduler: scheduler,
+	}
+}
+
+// ServeHTTP lists the failed cells on GET /v1/outages, puts a cell out of service on PUT /v1/outages/{ncgi}, for the
+// duration given by the duration query parameter if any, and back in service on DELETE /v1/outages/{ncgi}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	if element == "" {
+		if gateway.AllowMethods(w, r, http.MethodGet) {
+			gateway.WriteJSON(w, h.scheduler.List(r.Context()), nil)
+		}
+		return
+	}
+	if strings.Contains(element, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	if !gateway.AllowMethods(w, r, http.MethodPut, http.MethodDelete) {
+		return
+	}
+	ncgi, err := strconv.ParseUint(element, 0, 64)
+	if err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid("invalid NCGI %s", element))
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		gateway.WriteJSON(w, nil, h.scheduler.Recover(r.Context(), types.NCGI(ncgi)))
+		return
+	}
+	var duration time.Duration
+	if value := r.URL.Query().Get("duration"); value != "" {
+		duration, err = time.ParseDuration(value)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid duration %s", value))
+			return
+		}
+	}
+	gateway.WriteJSON(w, nil, h.scheduler.Fail(r.Context(), types.NCGI(ncgi), duration))
+}
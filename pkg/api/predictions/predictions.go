@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package predictions
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/prediction"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+var log = liblog.GetLogger("api", "predictions")
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/predictions"
+
+// Handler lets external components, such as trajectory prediction xApps, register the next cell predicted for UEs
+// and retrieve how the predictions fared against the actual handovers
+type Handler struct {
+	tracker   prediction.Tracker
+	cellStore cells.Store
+	ueStore   ues.Store
+}
+
+// NewHandler creates a new prediction API handler
+func NewHandler(tracker prediction.Tracker, cellStore cells.Store, ueStore ues.Store) *Handler {
+	return &Handler{
+		tracker:   tracker,
+		cellStore: cellStore,
+		ueStore:   ueStore,
+	}
+}
+
+// setRequest body of a prediction registration
+type setRequest struct {
+	NCGI types.NCGI `json:"ncgi"`
+}
+
+// ServeHTTP serves /v1/predictions, /v1/predictions/stats and /v1/predictions/{imsi}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	switch {
+	case element == "":
+		h.handlePredictions(w, r)
+	case element == "stats":
+		if !gateway.AllowMethods(w, r, http.MethodGet) {
+			return
+		}
+		gateway.WriteJSON(w, h.tracker.Stats(), nil)
+	default:
+		imsi, err := strconv.ParseUint(element, 10, 64)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid IMSI %s", element))
+			return
+		}
+		h.handlePrediction(w, r, types.IMSI(imsi))
+	}
+}
+
+func (h *Handler) handlePredictions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		gateway.WriteJSON(w, h.tracker.List(), nil)
+	case http.MethodDelete:
+		h.tracker.Clear()
+		gateway.WriteJSON(w, struct{}{}, nil)
+	default:
+		gateway.AllowMethods(w, r, http.MethodGet, http.MethodDelete)
+	}
+}
+
+func (h *Handler) handlePrediction(w http.ResponseWriter, r *http.Request, imsi types.IMSI) {
+	switch r.Method {
+	case http.MethodGet:
+		p, err := h.tracker.Get(imsi)
+		gateway.WriteJSON(w, p, err)
+	case http.MethodPut:
+		request := &setRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid(err.Error()))
+			return
+		}
+		if _, err := h.ueStore.Get(r.Context(), imsi); err != nil {
+			gateway.WriteJSON(w, nil, err)
+			return
+		}
+		if _, err := h.cellStore.Get(r.Context(), request.NCGI); err != nil {
+			gateway.WriteJSON(w, nil, err)
+			return
+		}
+		log.Debugf("Registering prediction of cell %d for UE %d", request.NCGI, imsi)
+		gateway.WriteJSON(w, h.tracker.Set(imsi, request.NCGI), nil)
+	case http.MethodDelete:
+		p, err := h.tracker.Delete(imsi)
+		gateway.WriteJSON(w, p, err)
+	default:
+		gateway.AllowMethods(w, r, http.MethodGet, http.MethodPut, http.MethodDelete)
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package restarts
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/agents"
+)
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/restarts"
+
+// DefaultBootTime time a restarted node takes to boot unless the request gives one
+const DefaultBootTime = 5 * time.Second
+
+// Handler simulates warm restarts of nodes, so that the reconciliation of the RIC after node restarts can be exercised
+type Handler struct {
+	mu     sync.RWMutex
+	agents agents.Agents
+}
+
+// NewHandler creates a new restarts API handler; restarts are unavailable until the agents are started
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Reset makes the handler restart the nodes of the given agents, which replace the previous ones
+func (h *Handler) Reset(agents agents.Agents) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.agents = agents
+}
+
+// ServeHTTP restarts a node on POST /v1/restarts/{gnbid}; the node boots for the duration given by the bootTime query
+// parameter, if any
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodPost) {
+		return
+	}
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	if element == "" || strings.Contains(element, "/") {
+		http.NotFound(w, r)
+		return
+	}
+	gnbID, err := strconv.ParseUint(element, 0, 64)
+	if err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid("invalid GnbID %s", element))
+		return
+	}
+	bootTime := DefaultBootTime
+	if value := r.URL.Query().Get("bootTime"); value != "" {
+		bootTime, err = time.ParseDuration(value)
+		if err != nil || bootTime < 0 {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid boot time %s", value))
+			return
+		}
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.agents == nil {
+		gateway.WriteJSON(w, nil, errors.NewUnavailable("E2 agents are not started"))
+		return
+	}
+	gateway.WriteJSON(w, nil, h.agents.Restart(r.Context(), types.GnbID(gnbID), bootTime))
+}
@@ -7,6 +7,7 @@ package routes
 
 import (
 	"context"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
 
 	"github.com/onosproject/ran-simulator/pkg/store/event"
@@ -22,9 +23,10 @@ import (
 var log = liblog.GetLogger("api", "routes")
 
 // NewService returns a new model Service
-func NewService(routeStore routes.Store) service.Service {
+func NewService(routeStore routes.Store, authorizer *auth.Authorizer) service.Service {
 	return &Service{
 		routeStore: routeStore,
+		authorizer: authorizer,
 	}
 }
 
@@ -32,12 +34,14 @@ func NewService(routeStore routes.Store) service.Service {
 type Service struct {
 	service.Service
 	routeStore routes.Store
+	authorizer *auth.Authorizer
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
 		routeStore: s.routeStore,
+		authorizer: s.authorizer,
 	}
 	modelapi.RegisterRouteModelServer(r, server)
 }
@@ -45,6 +49,7 @@ func (s *Service) Register(r *grpc.Server) {
 // Server implements the Routes gRPC service for administrative facilities.
 type Server struct {
 	routeStore routes.Store
+	authorizer *auth.Authorizer
 }
 
 func routeToAPI(route *model.Route) *types.Route {
@@ -81,6 +86,9 @@ func routeToModel(route *types.Route) *model.Route {
 
 // CreateRoute creates a new simulated route of a UE
 func (s *Server) CreateRoute(ctx context.Context, request *modelapi.CreateRouteRequest) (*modelapi.CreateRouteResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received create route request: %+v", request)
 	err := s.routeStore.Add(ctx, routeToModel(request.Route))
 	if err != nil {
@@ -101,6 +109,9 @@ func (s *Server) GetRoute(ctx context.Context, request *modelapi.GetRouteRequest
 
 // DeleteRoute deletes the specified simulated E2 route
 func (s *Server) DeleteRoute(ctx context.Context, request *modelapi.DeleteRouteRequest) (*modelapi.DeleteRouteResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received delete route request: %v", request)
 	_, err := s.routeStore.Delete(ctx, request.IMSI)
 	if err != nil {
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scaling
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/scaling"
+)
+
+// Path path served by the handler
+const Path = "/v1/scale"
+
+// Handler lets soak tests grow and shrink the number of simulated nodes and cells at runtime
+type Handler struct {
+	scaler *scaling.Scaler
+}
+
+// NewHandler creates a new scaling API handler
+func NewHandler(scaler *scaling.Scaler) *Handler {
+	return &Handler{
+		scaler: scaler,
+	}
+}
+
+// scaleRequest body of a scaling request
+type scaleRequest struct {
+	Clusters *uint `json:"clusters"`
+}
+
+// ServeHTTP returns the clusters currently added on GET and scales to the requested number of clusters on PUT
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		gateway.WriteJSON(w, h.scaler.Status(), nil)
+	case http.MethodPut:
+		request := &scaleRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid(err.Error()))
+			return
+		}
+		if request.Clusters == nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("number of clusters is required"))
+			return
+		}
+		status, err := h.scaler.Scale(r.Context(), *request.Clusters)
+		gateway.WriteJSON(w, status, err)
+	default:
+		gateway.AllowMethods(w, r, http.MethodGet, http.MethodPut)
+	}
+}
@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package server serves the northbound gRPC APIs as the onos-lib-go northbound server does, except that the requests
+// are authenticated with auth.Authenticate, which drops the metadata that only the token may carry, and traced
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	grpc_auth "github.com/grpc-ecosystem/go-grpc-middleware/auth"
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/onos-lib-go/pkg/northbound"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
+	"github.com/onosproject/ran-simulator/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = liblog.GetLogger("api", "server")
+
+// Server northbound gRPC server
+type Server struct {
+	cfg      *northbound.ServerConfig
+	services []northbound.Service
+	server   *grpc.Server
+}
+
+// NewServer creates a server with the given configuration
+func NewServer(cfg *northbound.ServerConfig) *Server {
+	return &Server{
+		cfg: cfg,
+	}
+}
+
+// AddService adds a service to be registered on Serve
+func (s *Server) AddService(service northbound.Service) {
+	s.services = append(s.services, service)
+}
+
+// Serve serves the services until the server is stopped; started is called with the address of the server once it
+// listens
+func (s *Server) Serve(started func(string)) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.cfg.Port))
+	if err != nil {
+		return err
+	}
+	tlsCfg, err := s.tlsConfig()
+	if err != nil {
+		return err
+	}
+	// The requests are traced first, so that the requests failing authentication are traced as well
+	unaryInterceptors := []grpc.UnaryServerInterceptor{tracing.UnaryServerInterceptor()}
+	streamInterceptors := []grpc.StreamServerInterceptor{tracing.StreamServerInterceptor()}
+	if s.cfg.SecurityCfg.AuthenticationEnabled {
+		log.Info("Authentication Enabled")
+		unaryInterceptors = append(unaryInterceptors, grpc_auth.UnaryServerInterceptor(auth.Authenticate))
+		streamInterceptors = append(streamInterceptors, grpc_auth.StreamServerInterceptor(auth.Authenticate))
+	}
+
+	s.server = grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsCfg)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)))
+	for _, service := range s.services {
+		service.Register(s.server)
+	}
+	started(lis.Addr().String())
+
+	log.Infof("Starting RPC server on address: %s", lis.Addr().String())
+	return s.server.Serve(lis)
+}
+
+// tlsConfig returns the TLS configuration of the server, using the default certificates and CA unless configured
+func (s *Server) tlsConfig() (*tls.Config, error) {
+	tlsCfg := &tls.Config{}
+	var cert tls.Certificate
+	var err error
+	if *s.cfg.CertPath == "" && *s.cfg.KeyPath == "" {
+		cert, err = tls.X509KeyPair([]byte(certs.DefaultLocalhostCrt), []byte(certs.DefaultLocalhostKey))
+	} else {
+		log.Infof("Loading certs: %s %s", *s.cfg.CertPath, *s.cfg.KeyPath)
+		cert, err = tls.LoadX509KeyPair(*s.cfg.CertPath, *s.cfg.KeyPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	tlsCfg.Certificates = []tls.Certificate{cert}
+
+	// Insecure servers ask the clients for a certificate, which is verified if given, but do not require it
+	if s.cfg.Insecure {
+		tlsCfg.ClientAuth = tls.RequestClientCert
+	} else {
+		tlsCfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	if *s.cfg.CaPath == "" {
+		tlsCfg.ClientCAs, err = certs.GetCertPoolDefault()
+	} else {
+		tlsCfg.ClientCAs, err = certs.GetCertPool(*s.cfg.CaPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tlsCfg, nil
+}
+
+// Stop stops the server
+func (s *Server) Stop() {
+	if s.server != nil {
+		s.server.Stop()
+	}
+}
+
+// GracefulStop stops the server once the pending requests are served
+func (s *Server) GracefulStop() {
+	if s.server != nil {
+		s.server.GracefulStop()
+	}
+}
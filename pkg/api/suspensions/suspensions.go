@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package suspensions
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/agents"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+)
+
+var log = logging.GetLogger("api", "suspensions")
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/suspensions"
+
+// Suspension subscription whose indications are withheld
+type Suspension struct {
+	GnbID          types.GnbID      `json:"gnbid"`
+	SubscriptionID subscriptions.ID `json:"subscriptionId"`
+	// ResumeTime time the indications resume, if the suspension is timed
+	ResumeTime *time.Time `json:"resumeTime,omitempty"`
+}
+
+// Handler suspends and resumes the indications of individual subscriptions without deleting them, so that the
+// behavior of xApps under indication gaps and their subscription supervision can be exercised
+type Handler struct {
+	mu     sync.RWMutex
+	agents agents.Agents
+}
+
+// NewHandler creates a new suspensions API handler; suspensions are unavailable until the agents are started
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Reset makes the handler suspend the subscriptions of the given agents, which replace the previous ones
+func (h *Handler) Reset(agents agents.Agents) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.agents = agents
+}
+
+// ServeHTTP lists the suspended subscriptions on GET /v1/suspensions, suspends the indications of a subscription on
+// PUT /v1/suspensions/{gnbid}/{subscriptionId}, for the duration given by the duration query parameter if any, and
+// resumes them on DELETE /v1/suspensions/{gnbid}/{subscriptionId}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	if element == "" {
+		if gateway.AllowMethods(w, r, http.MethodGet) {
+			gateway.WriteJSON(w, h.List(), nil)
+		}
+		return
+	}
+	elements := strings.Split(element, "/")
+	if len(elements) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	if !gateway.AllowMethods(w, r, http.MethodPut, http.MethodDelete) {
+		return
+	}
+	gnbID, err := strconv.ParseUint(elements[0], 0, 64)
+	if err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid("invalid GnbID %s", elements[0]))
+		return
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.agents == nil {
+		gateway.WriteJSON(w, nil, errors.NewUnavailable("E2 agents are not started"))
+		return
+	}
+	sub, err := h.agents.Subscription(types.GnbID(gnbID), subscriptions.ID(elements[1]))
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		sub.Resume()
+		log.Infof("Resumed indications of subscription %s of node %d", sub.ID, gnbID)
+		gateway.WriteJSON(w, nil, nil)
+		return
+	}
+	var duration time.Duration
+	if value := r.URL.Query().Get("duration"); value != "" {
+		duration, err = time.ParseDuration(value)
+		if err != nil || duration < 0 {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid duration %s", value))
+			return
+		}
+	}
+	sub.Suspend(duration)
+	log.Infof("Suspended indications of subscription %s of node %d", sub.ID, gnbID)
+	gateway.WriteJSON(w, nil, nil)
+}
+
+// List returns the suspended subscriptions, ordered by node and subscription ID
+func (h *Handler) List() []Suspension {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	list := make([]Suspension, 0)
+	if h.agents == nil {
+		return list
+	}
+	for gnbID, subs := range h.agents.Subscriptions() {
+		for _, sub := range subs {
+			suspended, resumeTime := sub.Suspension()
+			if !suspended {
+				continue
+			}
+			suspension := Suspension{GnbID: gnbID, SubscriptionID: sub.ID}
+			if !resumeTime.IsZero() {
+				suspension.ResumeTime = &resumeTime
+			}
+			list = append(list, suspension)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].GnbID != list[j].GnbID {
+			return list[i].GnbID < list[j].GnbID
+		}
+		return list[i].SubscriptionID < list[j].SubscriptionID
+	})
+	return list
+}
@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trackingareas
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/trackingareas"
+
+// TrackingArea cells of a tracking area and the UEs registered in it
+type TrackingArea struct {
+	TAC   uint32       `json:"tac"`
+	Cells []types.NCGI `json:"cells"`
+	UEs   []types.IMSI `json:"ues"`
+}
+
+// Handler lists the tracking areas of the cells along with the UEs registered in them
+type Handler struct {
+	mu        sync.RWMutex
+	cellStore cells.Store
+	ueStore   ues.Store
+}
+
+// NewHandler creates a new tracking areas API handler
+func NewHandler(cellStore cells.Store, ueStore ues.Store) *Handler {
+	return &Handler{
+		cellStore: cellStore,
+		ueStore:   ueStore,
+	}
+}
+
+// Reset makes the handler operate on the given stores, which replace the previous ones
+func (h *Handler) Reset(cellStore cells.Store, ueStore ues.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.cellStore = cellStore
+	h.ueStore = ueStore
+}
+
+// ServeHTTP lists the tracking areas on GET /v1/trackingareas and gets a tracking area on GET /v1/trackingareas/{tac};
+// the imsi query parameter looks up the tracking area the UE is registered in
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodGet) {
+		return
+	}
+	areas, err := h.List(r.Context())
+	if err != nil {
+		gateway.WriteJSON(w, nil, err)
+		return
+	}
+
+	element := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	if element != "" {
+		tac, err := strconv.ParseUint(element, 0, 32)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid tracking area code %s", element))
+			return
+		}
+		for _, area := range areas {
+			if area.TAC == uint32(tac) {
+				gateway.WriteJSON(w, area, nil)
+				return
+			}
+		}
+		gateway.WriteJSON(w, nil, errors.NewNotFound("tracking area %d not found", tac))
+		return
+	}
+
+	if param := r.URL.Query().Get("imsi"); param != "" {
+		imsi, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid("invalid IMSI %s", param))
+			return
+		}
+		for _, area := range areas {
+			for _, registered := range area.UEs {
+				if registered == types.IMSI(imsi) {
+					gateway.WriteJSON(w, []TrackingArea{area}, nil)
+					return
+				}
+			}
+		}
+		gateway.WriteJSON(w, nil, errors.NewNotFound("UE %d is not registered in any tracking area", imsi))
+		return
+	}
+	gateway.WriteJSON(w, areas, nil)
+}
+
+// List returns the tracking areas of the cells in order of tracking area code; the UEs registered in a tracking area
+// without cells, e.g. after the update of their cells, are listed in a tracking area of their own
+func (h *Handler) List(ctx context.Context) ([]TrackingArea, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	cellList, err := h.cellStore.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	areas := make(map[uint32]*TrackingArea)
+	get := func(tac uint32) *TrackingArea {
+		area, ok := areas[tac]
+		if !ok {
+			area = &TrackingArea{TAC: tac, Cells: make([]types.NCGI, 0), UEs: make([]types.IMSI, 0)}
+			areas[tac] = area
+		}
+		return area
+	}
+	for _, cell := range cellList {
+		area := get(cell.TrackingArea())
+		area.Cells = append(area.Cells, cell.NCGI)
+	}
+	for _, ue := range h.ueStore.ListAllUEs(ctx) {
+		if ue.TAC != 0 {
+			area := get(ue.TAC)
+			area.UEs = append(area.UEs, ue.IMSI)
+		}
+	}
+
+	list := make([]TrackingArea, 0, len(areas))
+	for _, area := range areas {
+		sort.Slice(area.Cells, func(i, j int) bool { return area.Cells[i] < area.Cells[j] })
+		sort.Slice(area.UEs, func(i, j int) bool { return area.UEs[i] < area.UEs[j] })
+		list = append(list, *area)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].TAC < list[j].TAC })
+	return list, nil
+}
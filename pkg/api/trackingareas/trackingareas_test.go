@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trackingareas
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackingAreas(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	// The NCGIs of the test model do not follow the default split of the NCI between the gNB ID and the cell ID, which
+	// puts the cells of both nodes in the same derived tracking area; the cells of the second node get their own
+	for _, name := range []string{"cell3", "cell4"} {
+		cell := m.Cells[name]
+		cell.TAC = m.Cells["cell1"].TrackingArea() + 1
+		m.Cells[name] = cell
+	}
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	ueStore := ues.NewUERegistry(1, cellStore, "connected")
+	tac, other := m.Cells["cell1"].TrackingArea(), m.Cells["cell3"].TrackingArea()
+	ue := ueStore.ListAllUEs(ctx)[0]
+	assert.NoError(t, ueStore.SetTrackingArea(ctx, ue.IMSI, tac))
+	handler := NewHandler(cellStore, ueStore)
+
+	areas, err := handler.List(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, areas, len(m.Nodes))
+	assert.Equal(t, tac, areas[0].TAC)
+	assert.Len(t, areas[0].Cells, 2)
+	assert.Equal(t, ue.IMSI, areas[0].UEs[0])
+	assert.Empty(t, areas[1].UEs)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, Prefix+path, nil))
+		return w
+	}
+	w := get(fmt.Sprintf("/%d", other))
+	assert.Equal(t, http.StatusOK, w.Code)
+	area := TrackingArea{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &area))
+	assert.Equal(t, other, area.TAC)
+
+	w = get(fmt.Sprintf("?imsi=%d", ue.IMSI))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &areas))
+	assert.Equal(t, tac, areas[0].TAC)
+
+	assert.Equal(t, http.StatusNotFound, get("/7").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/x").Code)
+	assert.Equal(t, http.StatusNotFound, get("?imsi=1").Code)
+}
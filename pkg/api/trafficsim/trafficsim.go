@@ -8,6 +8,7 @@ package trafficsim
 import (
 	"context"
 
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 
 	simapi "github.com/onosproject/onos-api/go/onos/ransim/trafficsim"
@@ -24,37 +25,41 @@ import (
 var log = liblog.GetLogger("trafficsim")
 
 // NewService returns a new trafficsim Service
-func NewService(model *model.Model, cellStore cells.Store, ueStore ues.Store) service.Service {
+func NewService(model *model.Model, cellStore cells.Store, ueStore ues.Store, authorizer *auth.Authorizer) service.Service {
 	return &Service{
-		model:     model,
-		cellStore: cellStore,
-		ueStore:   ueStore,
+		model:      model,
+		cellStore:  cellStore,
+		ueStore:    ueStore,
+		authorizer: authorizer,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	model     *model.Model
-	cellStore cells.Store
-	ueStore   ues.Store
+	model      *model.Model
+	cellStore  cells.Store
+	ueStore    ues.Store
+	authorizer *auth.Authorizer
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		model:     s.model,
-		cellStore: s.cellStore,
-		ueStore:   s.ueStore,
+		model:      s.model,
+		cellStore:  s.cellStore,
+		ueStore:    s.ueStore,
+		authorizer: s.authorizer,
 	}
 	simapi.RegisterTrafficServer(r, server)
 }
 
 // Server implements the TrafficSim gRPC service for administrative facilities.
 type Server struct {
-	model     *model.Model
-	cellStore cells.Store
-	ueStore   ues.Store
+	model      *model.Model
+	cellStore  cells.Store
+	ueStore    ues.Store
+	authorizer *auth.Authorizer
 }
 
 // GetMapLayout :
@@ -146,6 +151,9 @@ func (s *Server) WatchUes(request *simapi.WatchUesRequest, server simapi.Traffic
 
 // SetNumberUEs changes the number of UEs in the simulation
 func (s *Server) SetNumberUEs(ctx context.Context, req *simapi.SetNumberUEsRequest) (*simapi.SetNumberUEsResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	ueCount := req.GetNumber()
 	log.Infof("Number of simulated UEs changed to %d", ueCount)
 	s.ueStore.SetUECount(ctx, uint(ueCount))
@@ -154,6 +162,9 @@ func (s *Server) SetNumberUEs(ctx context.Context, req *simapi.SetNumberUEsReque
 
 // ResetMetrics resets the metrics on demand
 func (s *Server) ResetMetrics(ctx context.Context, req *simapi.ResetMetricsMsg) (*simapi.ResetMetricsMsg, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	// TODO: Reimplement
 	return nil, nil
 }
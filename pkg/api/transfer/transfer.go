@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	service "github.com/onosproject/onos-lib-go/pkg/northbound"
+	transferapi "github.com/onosproject/ran-simulator/api/transfer"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = liblog.GetLogger("api", "transfer")
+
+// transferTimeout bounds the time a UE waits to be admitted by another instance
+const transferTimeout = 2 * time.Second
+
+// NewUEContext returns the context of the UE handed over to the target cell, along with its route if any
+func NewUEContext(ue *model.UE, route *model.Route, target types.NCGI) *transferapi.UEContext {
+	c := &transferapi.UEContext{
+		Imsi:     uint64(ue.IMSI),
+		PlmnId:   uint32(ue.PlmnID),
+		Type:     string(ue.Type),
+		RrcState: int32(ue.RrcState),
+		Location: coordinateToAPI(&ue.Location),
+		Heading:  ue.Heading,
+		Speed:    ue.Speed,
+		Mobility: string(ue.Mobility),
+		Crnti:    uint32(ue.CRNTI),
+		Tac:      ue.TAC,
+		Ncgi:     uint64(target),
+	}
+	if route != nil {
+		c.Route = &transferapi.Route{
+			Color:       route.Color,
+			SpeedAvg:    route.SpeedAvg,
+			SpeedStdDev: route.SpeedStdDev,
+			Reverse:     route.Reverse,
+			NextPoint:   route.NextPoint,
+			Paused:      route.Paused,
+		}
+		for _, point := range route.Points {
+			c.Route.Points = append(c.Route.Points, coordinateToAPI(point))
+		}
+	}
+	return c
+}
+
+// ueFromContext returns the UE served by the target cell of the given context, and its route if any; the
+// measurements and the radio resources of the UE are established anew by the target instance
+func ueFromContext(c *transferapi.UEContext) (*model.UE, *model.Route) {
+	ncgi := types.NCGI(c.GetNcgi())
+	rrcState := e2sm_mho.Rrcstatus(c.GetRrcState())
+	ue := &model.UE{
+		IMSI:     types.IMSI(c.GetImsi()),
+		PlmnID:   types.PlmnID(c.GetPlmnId()),
+		Type:     model.UEType(c.GetType()),
+		RrcState: rrcState,
+		Location: coordinateFromAPI(c.GetLocation()),
+		Heading:  c.GetHeading(),
+		Speed:    c.GetSpeed(),
+		Mobility: model.MobilityClass(c.GetMobility()),
+		Cell: &model.UECell{
+			ID:   types.GnbID(ncgi), // placeholder, as for the UEs created by the registry
+			NCGI: ncgi,
+		},
+		CRNTI:        types.CRNTI(c.GetCrnti()),
+		IsAdmitted:   rrcState != e2sm_mho.Rrcstatus_RRCSTATUS_IDLE,
+		RrcStateTime: clock.Now(),
+		TAC:          c.GetTac(),
+	}
+	if c.GetRoute() == nil {
+		return ue, nil
+	}
+	route := &model.Route{
+		IMSI:        ue.IMSI,
+		Color:       c.Route.Color,
+		SpeedAvg:    c.Route.SpeedAvg,
+		SpeedStdDev: c.Route.SpeedStdDev,
+		Reverse:     c.Route.Reverse,
+		NextPoint:   c.Route.NextPoint,
+		Paused:      c.Route.Paused,
+	}
+	for _, point := range c.Route.Points {
+		location := coordinateFromAPI(point)
+		route.Points = append(route.Points, &location)
+	}
+	return ue, route
+}
+
+func coordinateToAPI(coordinate *model.Coordinate) *transferapi.Coordinate {
+	return &transferapi.Coordinate{
+		Lat: coordinate.Lat,
+		Lng: coordinate.Lng,
+	}
+}
+
+func coordinateFromAPI(coordinate *transferapi.Coordinate) model.Coordinate {
+	return model.Coordinate{
+		Lat: coordinate.GetLat(),
+		Lng: coordinate.GetLng(),
+	}
+}
+
+// Admitter admits the UEs handed over by other simulator instances
+type Admitter interface {
+	AdmitUE(ctx context.Context, ue *model.UE, route *model.Route) error
+}
+
+// NewService returns a new transfer Service admitting the UEs handed over by other instances with the given admitter;
+// the requests are authorized with the given authorizer, if any, since the instances may also be authenticated by the
+// certificates they present
+func NewService(admitter Admitter, authorizer *auth.Authorizer) service.Service {
+	return &Service{
+		admitter:   admitter,
+		authorizer: authorizer,
+	}
+}
+
+// Service is a Service implementation for the handover of UEs between simulator instances
+type Service struct {
+	service.Service
+	admitter   Admitter
+	authorizer *auth.Authorizer
+}
+
+// Register registers the transfer Service with the gRPC server.
+func (s *Service) Register(r *grpc.Server) {
+	server := &Server{
+		admitter:   s.admitter,
+		authorizer: s.authorizer,
+	}
+	transferapi.RegisterTransferServer(r, server)
+}
+
+// Server implements the transfer gRPC service
+type Server struct {
+	admitter   Admitter
+	authorizer *auth.Authorizer
+}
+
+// TransferUE admits the UE whose context is given on its target cell
+func (s *Server) TransferUE(ctx context.Context, request *transferapi.TransferUERequest) (*transferapi.TransferUEResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
+	if request.GetUe() == nil {
+		return nil, errors.Status(errors.NewInvalid("UE context is missing")).Err()
+	}
+	ue, route := ueFromContext(request.Ue)
+	log.Debugf("Received context of UE %d for cell %d", ue.IMSI, ue.Cell.NCGI)
+	if err := s.admitter.AdmitUE(ctx, ue, route); err != nil {
+		return nil, errors.Status(err).Err()
+	}
+	return &transferapi.TransferUEResponse{}, nil
+}
+
+// Client hands UEs over to the other simulator instances, connecting to each instance upon the first handover to it
+type Client struct {
+	sharding  model.ShardingConfig
+	tlsConfig *tls.Config
+	mu        sync.Mutex
+	conns     map[uint]*grpc.ClientConn
+}
+
+// NewClient creates a new transfer client for the peers of the given sharding configuration; the instances of a
+// deployment share their CA and authenticate each other with the certificates of the given TLS configuration
+func NewClient(sharding model.ShardingConfig, tlsConfig *tls.Config) *Client {
+	return &Client{
+		sharding:  sharding,
+		tlsConfig: tlsConfig,
+		conns:     make(map[uint]*grpc.ClientConn),
+	}
+}
+
+// connect returns the connection to the given shard
+func (c *Client) connect(ctx context.Context, shard uint) (*grpc.ClientConn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if conn, ok := c.conns[shard]; ok {
+		return conn, nil
+	}
+	address := c.sharding.PeerAddress(shard)
+	conn, err := grpc.DialContext(ctx, address, grpc.WithTransportCredentials(credentials.NewTLS(c.tlsConfig)))
+	if err != nil {
+		return nil, errors.NewUnavailable("unable to connect to shard %d at %s: %v", shard, address, err)
+	}
+	c.conns[shard] = conn
+	return conn, nil
+}
+
+// Transfer hands the UE, along with its route if any, over to the target cell owned by the given shard
+func (c *Client) Transfer(ctx context.Context, shard uint, ue *model.UE, route *model.Route, target types.NCGI) error {
+	conn, err := c.connect(ctx, shard)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, transferTimeout)
+	defer cancel()
+	request := &transferapi.TransferUERequest{
+		Ue: NewUEContext(ue, route, target),
+	}
+	if _, err := transferapi.NewTransferClient(conn).TransferUE(ctx, request); err != nil {
+		return errors.FromGRPC(err)
+	}
+	return nil
+}
+
+// Close closes the connections to the other instances
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for shard, conn := range c.conns {
+		if err := conn.Close(); err != nil {
+			log.Warn(err)
+		}
+		delete(c.conns, shard)
+	}
+}
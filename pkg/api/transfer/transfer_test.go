@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transfer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	transferapi "github.com/onosproject/ran-simulator/api/transfer"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type admitter struct {
+	ue    *model.UE
+	route *model.Route
+	err   error
+}
+
+func (a *admitter) AdmitUE(ctx context.Context, ue *model.UE, route *model.Route) error {
+	a.ue, a.route = ue, route
+	return a.err
+}
+
+func TestTransferUE(t *testing.T) {
+	ue := &model.UE{
+		IMSI:     types.IMSI(315010999999999),
+		Type:     "phone",
+		RrcState: e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED,
+		Location: model.Coordinate{Lat: 52.486, Lng: 13.412},
+		Heading:  90,
+		Cell:     &model.UECell{NCGI: types.NCGI(84325717505)},
+		TAC:      7,
+	}
+	route := &model.Route{
+		IMSI:      ue.IMSI,
+		Points:    []*model.Coordinate{{Lat: 52.48, Lng: 13.41}, {Lat: 52.49, Lng: 13.42}},
+		SpeedAvg:  50000,
+		NextPoint: 1,
+	}
+	target := types.NCGI(0xfffffffffffff01)
+	// The context goes through its protobuf encoding, as it does between instances
+	data, err := proto.Marshal(&transferapi.TransferUERequest{Ue: NewUEContext(ue, route, target)})
+	assert.NoError(t, err)
+	request := &transferapi.TransferUERequest{}
+	assert.NoError(t, proto.Unmarshal(data, request))
+
+	a := &admitter{}
+	server := &Server{admitter: a}
+	_, err = server.TransferUE(context.TODO(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, ue.IMSI, a.ue.IMSI)
+	assert.Equal(t, target, a.ue.Cell.NCGI)
+	assert.Equal(t, ue.RrcState, a.ue.RrcState)
+	assert.Equal(t, ue.Location, a.ue.Location)
+	assert.Equal(t, ue.TAC, a.ue.TAC)
+	assert.True(t, a.ue.IsAdmitted)
+	assert.Equal(t, route.Points, a.route.Points)
+	assert.Equal(t, route.NextPoint, a.route.NextPoint)
+
+	a.err = errors.NewNotFound("cell not found")
+	_, err = server.TransferUE(context.TODO(), request)
+	assert.True(t, errors.IsNotFound(errors.FromGRPC(err)))
+
+	_, err = server.TransferUE(context.TODO(), &transferapi.TransferUERequest{})
+	assert.True(t, errors.IsInvalid(errors.FromGRPC(err)))
+}
@@ -0,0 +1,154 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uecontrol
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+var log = logging.GetLogger("api", "uecontrol")
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/uecontrol"
+
+// Commands applicable to a UE
+const (
+	// Pause stops the UE where it is on its route
+	Pause = "pause"
+	// Resume resumes the movement of the UE along its route
+	Resume = "resume"
+	// Teleport moves the UE to a location
+	Teleport = "teleport"
+)
+
+// TeleportRequest body of a teleport command
+type TeleportRequest struct {
+	Location model.Coordinate `json:"location"`
+	// Heading of the UE at the location; the UE keeps its heading if none is given
+	Heading *uint32 `json:"heading,omitempty"`
+	// Reevaluate updates the signal strength and the serving cell of the UE at once rather than upon the next tick
+	// of the mobility driver
+	Reevaluate bool `json:"reevaluate,omitempty"`
+}
+
+// Handler pauses, resumes and teleports individual UEs, e.g. to set up a demo or a test precisely
+type Handler struct {
+	mu         sync.RWMutex
+	ueStore    ues.Store
+	routeStore routes.Store
+	driver     mobility.Driver
+}
+
+// NewHandler creates a new UE control API handler; UEs are re-evaluated by the given driver
+func NewHandler(ueStore ues.Store, routeStore routes.Store, driver mobility.Driver) *Handler {
+	return &Handler{
+		ueStore:    ueStore,
+		routeStore: routeStore,
+		driver:     driver,
+	}
+}
+
+// Reset makes the handler operate on the given stores, which replace the previous ones
+func (h *Handler) Reset(ueStore ues.Store, routeStore routes.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ueStore = ueStore
+	h.routeStore = routeStore
+}
+
+// ServeHTTP applies the command to the UE on POST /v1/uecontrol/{imsi}/{command}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodPost) {
+		return
+	}
+	elements := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/"), "/")
+	if len(elements) != 2 {
+		http.NotFound(w, r)
+		return
+	}
+	imsi, err := strconv.ParseUint(elements[0], 10, 64)
+	if err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid("invalid IMSI %s", elements[0]))
+		return
+	}
+
+	switch elements[1] {
+	case Pause, Resume:
+		gateway.WriteJSON(w, nil, h.SetPaused(r.Context(), types.IMSI(imsi), elements[1] == Pause))
+	case Teleport:
+		request := &TeleportRequest{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			gateway.WriteJSON(w, nil, errors.NewInvalid(err.Error()))
+			return
+		}
+		gateway.WriteJSON(w, nil, h.Teleport(r.Context(), types.IMSI(imsi), request))
+	default:
+		gateway.WriteJSON(w, nil, errors.NewNotFound("unknown command %s", elements[1]))
+	}
+}
+
+// SetPaused pauses or resumes the movement of the UE along its route; a paused UE has no speed. UEs without a
+// route do not move, so pausing or resuming them has no effect.
+func (h *Handler) SetPaused(ctx context.Context, imsi types.IMSI, paused bool) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ue, err := h.ueStore.Get(ctx, imsi)
+	if err != nil {
+		return err
+	}
+	if err := h.routeStore.Pause(ctx, imsi, paused); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !paused {
+		log.Infof("UE %d resumed", imsi)
+		return nil
+	}
+	log.Infof("UE %d paused", imsi)
+	return h.ueStore.SetMobility(ctx, imsi, ue.Mobility, 0)
+}
+
+// Teleport moves the UE to the location of the request; a UE on a route which is not paused heads from there to the
+// next waypoint of its route
+func (h *Handler) Teleport(ctx context.Context, imsi types.IMSI, request *TeleportRequest) error {
+	if request.Location.Lat < -90 || request.Location.Lat > 90 || request.Location.Lng < -180 || request.Location.Lng > 180 {
+		return errors.NewInvalid("invalid location %v", request.Location)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	ue, err := h.ueStore.Get(ctx, imsi)
+	if err != nil {
+		return err
+	}
+	heading := ue.Heading
+	if request.Heading != nil {
+		heading = *request.Heading % 360
+	}
+	if err := h.ueStore.MoveToCoordinate(ctx, imsi, request.Location, heading); err != nil {
+		return err
+	}
+	log.Infof("UE %d teleported to %.6f,%.6f", imsi, request.Location.Lat, request.Location.Lng)
+	if request.Reevaluate && h.driver != nil {
+		h.driver.Reevaluate(ctx, imsi)
+	}
+	return nil
+}
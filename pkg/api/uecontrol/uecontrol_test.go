@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uecontrol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHandler(t *testing.T) (*Handler, ues.Store, routes.Store) {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	ueStore := ues.NewUERegistry(m.UECount, cellStore, "connected")
+	routeStore := routes.NewRouteRegistry()
+	return NewHandler(ueStore, routeStore, nil), ueStore, routeStore
+}
+
+func TestPauseResume(t *testing.T) {
+	ctx := context.Background()
+	handler, ueStore, routeStore := newTestHandler(t)
+	ue := ueStore.ListAllUEs(ctx)[0]
+
+	// UEs without a route do not move
+	assert.NoError(t, handler.SetPaused(ctx, ue.IMSI, true))
+
+	assert.NoError(t, routeStore.Add(ctx, &model.Route{
+		IMSI:     ue.IMSI,
+		Points:   []*model.Coordinate{{Lat: 50, Lng: 0}, {Lat: 50.001, Lng: 0}},
+		SpeedAvg: 36000,
+	}))
+	assert.NoError(t, handler.SetPaused(ctx, ue.IMSI, true))
+	route, err := routeStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.True(t, route.Paused)
+	ue, err = ueStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, ue.Speed)
+
+	assert.NoError(t, handler.SetPaused(ctx, ue.IMSI, false))
+	route, err = routeStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.False(t, route.Paused)
+
+	assert.True(t, errors.IsNotFound(handler.SetPaused(ctx, 1, true)))
+}
+
+func TestTeleport(t *testing.T) {
+	ctx := context.Background()
+	handler, ueStore, _ := newTestHandler(t)
+	ue := ueStore.ListAllUEs(ctx)[0]
+	heading := ue.Heading
+
+	location := model.Coordinate{Lat: 52.52, Lng: 13.405}
+	assert.NoError(t, handler.Teleport(ctx, ue.IMSI, &TeleportRequest{Location: location}))
+	ue, err := ueStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, location, ue.Location)
+	assert.Equal(t, heading, ue.Heading)
+
+	north := uint32(360)
+	assert.NoError(t, handler.Teleport(ctx, ue.IMSI, &TeleportRequest{Location: location, Heading: &north}))
+	ue, err = ueStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), ue.Heading)
+
+	assert.True(t, errors.IsInvalid(handler.Teleport(ctx, ue.IMSI, &TeleportRequest{Location: model.Coordinate{Lat: 91}})))
+	assert.True(t, errors.IsNotFound(handler.Teleport(ctx, 1, &TeleportRequest{Location: location})))
+}
@@ -0,0 +1,263 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uegroups
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+var log = logging.GetLogger("api", "uegroups")
+
+// Prefix path prefix served by the handler
+const Prefix = "/v1/uegroups"
+
+// Operations applicable to a group of UEs
+const (
+	// Select lists the UEs of the group
+	Select = "select"
+	// Move moves the UEs of the group to a cell or a location
+	Move = "move"
+	// Detach removes the UEs of the group and their routes
+	Detach = "detach"
+	// Profile changes the type and the mobility class of the UEs of the group
+	Profile = "profile"
+	// Route assigns a route to the UEs of the group
+	Route = "route"
+)
+
+// Selector selects a group of UEs; the UEs must match all criteria given and all UEs are selected if none is given
+type Selector struct {
+	NCGI       types.NCGI         `json:"ncgi,omitempty"`       // serving cell of the UEs
+	Polygon    []model.Coordinate `json:"polygon,omitempty"`    // region the UEs are located in
+	IMSIPrefix string             `json:"imsiPrefix,omitempty"` // leading digits of the IMSI of the UEs
+}
+
+// Request body of a group operation; only the parameters of the requested operation are used
+type Request struct {
+	Selector Selector `json:"selector"`
+
+	// Move
+	TargetNCGI types.NCGI        `json:"targetNcgi,omitempty"`
+	Location   *model.Coordinate `json:"location,omitempty"`
+	Heading    uint32            `json:"heading,omitempty"`
+
+	// Profile
+	Type     model.UEType        `json:"type,omitempty"`
+	Mobility model.MobilityClass `json:"mobility,omitempty"`
+
+	// Route
+	Points      []model.Coordinate `json:"points,omitempty"`
+	SpeedAvg    uint32             `json:"speedAvg,omitempty"`    // meters per hour
+	SpeedStdDev uint32             `json:"speedStdDev,omitempty"` // meters per hour
+}
+
+// Result outcome of a group operation
+type Result struct {
+	Selected int               `json:"selected"`
+	Applied  int               `json:"applied"`
+	IMSIs    []types.IMSI      `json:"imsis,omitempty"`    // UEs selected, for the select operation only
+	Failures map[string]string `json:"failures,omitempty"` // error of each UE the operation failed for, keyed by IMSI
+}
+
+// Handler applies operations to groups of UEs selected by serving cell, region and IMSI prefix, so that scenario
+// scripts do not need one call per UE
+type Handler struct {
+	mu         sync.RWMutex
+	ueStore    ues.Store
+	routeStore routes.Store
+}
+
+// NewHandler creates a new UE groups API handler
+func NewHandler(ueStore ues.Store, routeStore routes.Store) *Handler {
+	return &Handler{
+		ueStore:    ueStore,
+		routeStore: routeStore,
+	}
+}
+
+// Reset makes the handler operate on the given stores, which replace the previous ones
+func (h *Handler) Reset(ueStore ues.Store, routeStore routes.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ueStore = ueStore
+	h.routeStore = routeStore
+}
+
+// ServeHTTP applies the operation to the selected UEs on POST /v1/uegroups/{operation}
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !gateway.AllowMethods(w, r, http.MethodPost) {
+		return
+	}
+	operation := strings.Trim(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	request := &Request{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		gateway.WriteJSON(w, nil, errors.NewInvalid(err.Error()))
+		return
+	}
+	result, err := h.Apply(r.Context(), operation, request)
+	gateway.WriteJSON(w, result, err)
+}
+
+// Apply applies the operation to the UEs selected by the request
+func (h *Handler) Apply(ctx context.Context, operation string, request *Request) (*Result, error) {
+	apply, err := h.operation(operation, request)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	imsis := h.selectUEs(ctx, request.Selector)
+	result := &Result{Selected: len(imsis)}
+	if apply == nil {
+		result.IMSIs = imsis
+		return result, nil
+	}
+	for _, imsi := range imsis {
+		if err := apply(ctx, imsi); err != nil {
+			if result.Failures == nil {
+				result.Failures = make(map[string]string)
+			}
+			result.Failures[strconv.FormatUint(uint64(imsi), 10)] = err.Error()
+			continue
+		}
+		result.Applied++
+	}
+	log.Infof("Applied %s to %d of %d UEs", operation, result.Applied, result.Selected)
+	return result, nil
+}
+
+// operation validates the parameters of the operation and returns the function applying it to a single UE; no
+// function is returned for the select operation
+func (h *Handler) operation(operation string, request *Request) (func(ctx context.Context, imsi types.IMSI) error, error) {
+	switch operation {
+	case Select:
+		return nil, nil
+	case Move:
+		if request.TargetNCGI == 0 && request.Location == nil {
+			return nil, errors.NewInvalid("target cell or location is required")
+		}
+		return func(ctx context.Context, imsi types.IMSI) error {
+			if request.Location != nil {
+				return h.ueStore.MoveToCoordinate(ctx, imsi, *request.Location, request.Heading)
+			}
+			return h.ueStore.MoveToCell(ctx, imsi, request.TargetNCGI, 0)
+		}, nil
+	case Detach:
+		return func(ctx context.Context, imsi types.IMSI) error {
+			if _, err := h.ueStore.Delete(ctx, imsi); err != nil {
+				return err
+			}
+			_, _ = h.routeStore.Delete(ctx, imsi)
+			return nil
+		}, nil
+	case Profile:
+		if request.Type == "" && request.Mobility == "" {
+			return nil, errors.NewInvalid("type or mobility class is required")
+		}
+		if request.Mobility != "" && !request.Mobility.IsValid() {
+			return nil, errors.NewInvalid("unknown mobility class %s", request.Mobility)
+		}
+		return h.setProfile(request), nil
+	case Route:
+		if len(request.Points) < 2 {
+			return nil, errors.NewInvalid("route must have at least two points")
+		}
+		return h.assignRoute(request), nil
+	default:
+		return nil, errors.NewNotFound("unknown operation %s", operation)
+	}
+}
+
+// setProfile sets the type and the mobility class of a UE; the route of the UE, if any, is given the nominal speed
+// of the mobility class, since the speed of UEs on a route is determined by their route
+func (h *Handler) setProfile(request *Request) func(ctx context.Context, imsi types.IMSI) error {
+	return func(ctx context.Context, imsi types.IMSI) error {
+		if request.Type != "" {
+			if err := h.ueStore.SetType(ctx, imsi, request.Type); err != nil {
+				return err
+			}
+		}
+		if request.Mobility == "" {
+			return nil
+		}
+		speed := request.Mobility.Speed()
+		if err := h.ueStore.SetMobility(ctx, imsi, request.Mobility, speed); err != nil {
+			return err
+		}
+		route, err := h.routeStore.Get(ctx, imsi)
+		if err != nil {
+			return nil
+		}
+		updated := *route
+		updated.SpeedAvg = uint32(speed * 1000)
+		updated.SpeedStdDev = 0
+		return h.replaceRoute(ctx, &updated)
+	}
+}
+
+// assignRoute assigns the route of the request to a UE, replacing its current route
+func (h *Handler) assignRoute(request *Request) func(ctx context.Context, imsi types.IMSI) error {
+	return func(ctx context.Context, imsi types.IMSI) error {
+		if _, err := h.ueStore.Get(ctx, imsi); err != nil {
+			return err
+		}
+		route := &model.Route{
+			IMSI:        imsi,
+			Points:      make([]*model.Coordinate, 0, len(request.Points)),
+			SpeedAvg:    request.SpeedAvg,
+			SpeedStdDev: request.SpeedStdDev,
+			Color:       utils.RandomColor(),
+		}
+		for i := range request.Points {
+			point := request.Points[i]
+			route.Points = append(route.Points, &point)
+		}
+		return h.replaceRoute(ctx, route)
+	}
+}
+
+func (h *Handler) replaceRoute(ctx context.Context, route *model.Route) error {
+	_, _ = h.routeStore.Delete(ctx, route.IMSI)
+	return h.routeStore.Add(ctx, route)
+}
+
+// selectUEs returns the IMSIs of the UEs matching the selector, in ascending order
+func (h *Handler) selectUEs(ctx context.Context, selector Selector) []types.IMSI {
+	var candidates []*model.UE
+	if selector.NCGI != 0 {
+		candidates = h.ueStore.ListUEs(ctx, selector.NCGI)
+	} else {
+		candidates = h.ueStore.ListAllUEs(ctx)
+	}
+
+	imsis := make([]types.IMSI, 0, len(candidates))
+	for _, ue := range candidates {
+		if selector.IMSIPrefix != "" && !strings.HasPrefix(strconv.FormatUint(uint64(ue.IMSI), 10), selector.IMSIPrefix) {
+			continue
+		}
+		if len(selector.Polygon) > 0 && !utils.InPolygon(ue.Location, selector.Polygon) {
+			continue
+		}
+		imsis = append(imsis, ue.IMSI)
+	}
+	sort.Slice(imsis, func(i, j int) bool { return imsis[i] < imsis[j] })
+	return imsis
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package uegroups
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHandler(t *testing.T) (*Handler, ues.Store, routes.Store) {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	ueStore := ues.NewUERegistry(m.UECount, cellStore, "connected")
+	routeStore := routes.NewRouteRegistry()
+	return NewHandler(ueStore, routeStore), ueStore, routeStore
+}
+
+func TestSelect(t *testing.T) {
+	ctx := context.Background()
+	handler, ueStore, _ := newTestHandler(t)
+	all := ueStore.ListAllUEs(ctx)
+
+	result, err := handler.Apply(ctx, Select, &Request{})
+	assert.NoError(t, err)
+	assert.Equal(t, len(all), result.Selected)
+	assert.Len(t, result.IMSIs, len(all))
+
+	ncgi := all[0].Cell.NCGI
+	result, err = handler.Apply(ctx, Select, &Request{Selector: Selector{NCGI: ncgi}})
+	assert.NoError(t, err)
+	assert.Equal(t, len(ueStore.ListUEs(ctx, ncgi)), result.Selected)
+
+	location := all[0].Location
+	result, err = handler.Apply(ctx, Select, &Request{Selector: Selector{Polygon: []model.Coordinate{
+		{Lat: location.Lat - 0.0001, Lng: location.Lng - 0.0001},
+		{Lat: location.Lat - 0.0001, Lng: location.Lng + 0.0001},
+		{Lat: location.Lat + 0.0001, Lng: location.Lng + 0.0001},
+		{Lat: location.Lat + 0.0001, Lng: location.Lng - 0.0001},
+	}}})
+	assert.NoError(t, err)
+	assert.Contains(t, result.IMSIs, all[0].IMSI)
+
+	result, err = handler.Apply(ctx, Select, &Request{Selector: Selector{IMSIPrefix: "x"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Selected)
+}
+
+func TestOperations(t *testing.T) {
+	ctx := context.Background()
+	handler, ueStore, routeStore := newTestHandler(t)
+	ue := ueStore.ListAllUEs(ctx)[0]
+	selector := Selector{IMSIPrefix: strconv.FormatUint(uint64(ue.IMSI), 10)}
+
+	result, err := handler.Apply(ctx, Profile, &Request{Selector: selector, Type: "iot", Mobility: model.Vehicular})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Selected)
+	assert.Equal(t, 1, result.Applied)
+	ue, err = ueStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, model.UEType("iot"), ue.Type)
+	assert.Equal(t, model.Vehicular, ue.Mobility)
+
+	points := []model.Coordinate{{Lat: 52.52, Lng: 13.41}, {Lat: 52.53, Lng: 13.42}}
+	result, err = handler.Apply(ctx, Route, &Request{Selector: selector, Points: points, SpeedAvg: 3000})
+	assert.NoError(t, err)
+	assert.Equal(t, result.Selected, result.Applied)
+	route, err := routeStore.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Len(t, route.Points, 2)
+	assert.Equal(t, uint32(3000), route.SpeedAvg)
+
+	_, err = handler.Apply(ctx, Move, &Request{Selector: selector})
+	assert.Error(t, err)
+	_, err = handler.Apply(ctx, "teleport", &Request{Selector: selector})
+	assert.Error(t, err)
+
+	result, err = handler.Apply(ctx, Detach, &Request{Selector: selector})
+	assert.NoError(t, err)
+	assert.Equal(t, result.Selected, result.Applied)
+	assert.Equal(t, 0, routeStore.Len(ctx))
+	_, err = ueStore.Get(ctx, ue.IMSI)
+	assert.Error(t, err)
+}
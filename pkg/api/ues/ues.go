@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
@@ -23,29 +24,33 @@ import (
 var log = liblog.GetLogger("api", "ues")
 
 // NewService returns a new model Service
-func NewService(ueStore ues.Store) service.Service {
+func NewService(ueStore ues.Store, authorizer *auth.Authorizer) service.Service {
 	return &Service{
-		ueStore: ueStore,
+		ueStore:    ueStore,
+		authorizer: authorizer,
 	}
 }
 
 // Service is a Service implementation for administration.
 type Service struct {
 	service.Service
-	ueStore ues.Store
+	ueStore    ues.Store
+	authorizer *auth.Authorizer
 }
 
 // Register registers the TrafficSim Service with the gRPC server.
 func (s *Service) Register(r *grpc.Server) {
 	server := &Server{
-		ueStore: s.ueStore,
+		ueStore:    s.ueStore,
+		authorizer: s.authorizer,
 	}
 	modelapi.RegisterUEModelServer(r, server)
 }
 
 // Server implements the Routes gRPC service for administrative facilities.
 type Server struct {
-	ueStore ues.Store
+	ueStore    ues.Store
+	authorizer *auth.Authorizer
 }
 
 // GetUECount gets the number of UEs
@@ -55,6 +60,9 @@ func (s *Server) GetUECount(ctx context.Context, request *modelapi.GetUECountReq
 
 // SetUECount sets the number of UEs
 func (s *Server) SetUECount(ctx context.Context, request *modelapi.SetUECountRequest) (*modelapi.SetUECountResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	s.ueStore.SetUECount(ctx, uint(request.Count))
 	return &modelapi.SetUECountResponse{}, nil
 }
@@ -101,6 +109,9 @@ func (s *Server) GetUE(ctx context.Context, request *modelapi.GetUERequest) (*mo
 
 // MoveToCell moves the specified UE to the given cell
 func (s *Server) MoveToCell(ctx context.Context, request *modelapi.MoveToCellRequest) (*modelapi.MoveToCellResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Infof("Received MoveToCell request: %+v", request)
 	err := s.ueStore.MoveToCell(ctx, request.IMSI, request.NCGI, 0)
 	if err != nil {
@@ -111,12 +122,18 @@ func (s *Server) MoveToCell(ctx context.Context, request *modelapi.MoveToCellReq
 
 // MoveToLocation moves the specified UE to the given location
 func (s *Server) MoveToLocation(ctx context.Context, request *modelapi.MoveToLocationRequest) (*modelapi.MoveToLocationResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received MoveToLocation request: %+v", request)
-	return &modelapi.MoveToLocationResponse{}, s.ueStore.MoveToCoordinate(ctx, request.IMSI, model.Coordinate(*request.Location), request.Heading)
+	return &modelapi.MoveToLocationResponse{}, s.ueStore.MoveToCoordinate(ctx, request.IMSI, model.Coordinate{Lat: request.Location.Lat, Lng: request.Location.Lng}, request.Heading)
 }
 
 // DeleteUE removes the specified UE
 func (s *Server) DeleteUE(ctx context.Context, request *modelapi.DeleteUERequest) (*modelapi.DeleteUEResponse, error) {
+	if err := s.authorizer.Authorize(ctx, auth.Operator); err != nil {
+		return nil, err
+	}
 	log.Debugf("Received Delete request: %+v", request)
 	_, err := s.ueStore.Delete(ctx, request.IMSI)
 	return &modelapi.DeleteUEResponse{}, err
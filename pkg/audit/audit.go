@@ -0,0 +1,175 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package audit keeps an append-only log of the E2 setup, subscription and control procedures of the E2 nodes, so
+// that failed interoperability runs can be investigated after the fact
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger("audit")
+
+// Initiators of the audited procedures
+const (
+	InitiatorNode = "node" // the procedure is initiated by the E2 node
+	InitiatorRIC  = "ric"  // the procedure is initiated by the RIC
+)
+
+// Outcomes of the audited procedures
+const (
+	OutcomeSuccess = "success" // successful outcome
+	OutcomeFailure = "failure" // unsuccessful outcome, along with its cause
+	OutcomeError   = "error"   // the procedure could not be completed, e.g. the connection was lost
+)
+
+// DefaultCapacity number of entries kept in memory by default; older entries are only kept in the log file, if any
+const DefaultCapacity = 10000
+
+// Entry audited procedure
+type Entry struct {
+	Seq        uint64            `json:"seq"` // position of the entry in the log, starting at 1
+	Time       time.Time         `json:"time"`
+	Node       string            `json:"node"`                 // E2 node ID
+	Controller string            `json:"controller,omitempty"` // address of the RIC
+	Initiator  string            `json:"initiator"`
+	Procedure  string            `json:"procedure"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+	Outcome    string            `json:"outcome"`
+	Cause      string            `json:"cause,omitempty"`
+	Duration   time.Duration     `json:"duration"` // nanoseconds from the request to the outcome
+}
+
+// Filter selects entries of the log; empty fields select all entries
+type Filter struct {
+	Node      string
+	Procedure string
+	Outcome   string
+	Since     time.Time
+}
+
+func (f Filter) matches(entry *Entry) bool {
+	return (f.Node == "" || f.Node == entry.Node) &&
+		(f.Procedure == "" || f.Procedure == entry.Procedure) &&
+		(f.Outcome == "" || f.Outcome == entry.Outcome) &&
+		!entry.Time.Before(f.Since)
+}
+
+// Log append-only log of audited procedures, kept in memory up to its capacity and appended to a JSON lines file if
+// one is open
+type Log struct {
+	mu        sync.RWMutex
+	capacity  int
+	seq       uint64
+	entries   []*Entry
+	file      *os.File
+	encoder   *json.Encoder
+	listeners []func(Entry)
+}
+
+// NewLog creates a log keeping up to the given number of entries in memory
+func NewLog(capacity int) *Log {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Log{
+		capacity: capacity,
+	}
+}
+
+var defaultLog = NewLog(DefaultCapacity)
+
+// Default returns the log of the E2 nodes of the simulator
+func Default() *Log {
+	return defaultLog
+}
+
+// Open appends the entries of the log to the JSON lines file at the given path, which is created if needed
+func (l *Log) Open(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file != nil {
+		_ = l.file.Close()
+	}
+	l.file = file
+	l.encoder = json.NewEncoder(file)
+	log.Infof("Appending audit log to %s", path)
+	return nil
+}
+
+// Close closes the file of the log, if any; the entries are still kept in memory
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	l.encoder = nil
+	return err
+}
+
+// Listen calls the given function with every entry appended to the log from now on, in the order of the log; the
+// function is called while the log is locked and must not block
+func (l *Log) Listen(listener func(Entry)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.listeners = append(l.listeners, listener)
+}
+
+// Append appends the entry to the log, assigning its sequence number
+func (l *Log) Append(entry Entry) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.seq++
+	entry.Seq = l.seq
+	if len(l.entries) == l.capacity {
+		l.entries = l.entries[1:]
+	}
+	l.entries = append(l.entries, &entry)
+	if l.encoder != nil {
+		if err := l.encoder.Encode(&entry); err != nil {
+			log.Warn(err)
+		}
+	}
+	for _, listener := range l.listeners {
+		listener(entry)
+	}
+}
+
+// List returns the entries in memory selected by the filter, oldest first
+func (l *Log) List(filter Filter) []*Entry {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	entries := make([]*Entry, 0)
+	for _, entry := range l.entries {
+		if filter.matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// WriteJSONL writes the entries as JSON lines, one entry per line
+func WriteJSONL(w io.Writer, entries []*Entry) error {
+	encoder := json.NewEncoder(w)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
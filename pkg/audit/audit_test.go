@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.jsonl")
+
+	l := NewLog(2)
+	assert.NoError(t, l.Open(path))
+	start := time.Now()
+	l.Append(Entry{Time: start, Node: "1", Procedure: "E2Setup", Outcome: OutcomeSuccess})
+	l.Append(Entry{Time: start.Add(time.Second), Node: "1", Procedure: "RICSubscription", Outcome: OutcomeFailure, Cause: "ric_request:RIC_CAUSE_ACTION_NOT_SUPPORTED"})
+	l.Append(Entry{Time: start.Add(2 * time.Second), Node: "2", Procedure: "RICSubscription", Outcome: OutcomeSuccess})
+	assert.NoError(t, l.Close())
+
+	// Only the latest entries are kept in memory
+	entries := l.List(Filter{})
+	assert.Len(t, entries, 2)
+	assert.Equal(t, uint64(2), entries[0].Seq)
+	assert.Equal(t, uint64(3), entries[1].Seq)
+	assert.Len(t, l.List(Filter{Procedure: "RICSubscription", Outcome: OutcomeFailure}), 1)
+	assert.Len(t, l.List(Filter{Node: "2"}), 1)
+	assert.Len(t, l.List(Filter{Since: start.Add(1500 * time.Millisecond)}), 1)
+
+	// All entries are appended to the file
+	file, err := os.Open(path)
+	assert.NoError(t, err)
+	defer file.Close()
+	var seqs []uint64
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry Entry
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		seqs = append(seqs, entry.Seq)
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, seqs)
+
+	// Entries appended after the file is closed are kept in memory only
+	l.Append(Entry{Time: start, Node: "1", Procedure: "RICControl", Outcome: OutcomeError})
+	assert.Len(t, l.List(Filter{Procedure: "RICControl"}), 1)
+}
+
+func TestWriteJSONL(t *testing.T) {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, WriteJSONL(buf, []*Entry{{Seq: 1, Procedure: "E2Setup"}, {Seq: 2, Procedure: "RICControl"}}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], `"procedure":"RICControl"`)
+}
+
+func TestListen(t *testing.T) {
+	l := NewLog(2)
+	l.Append(Entry{Procedure: "E2Setup"})
+	var seqs []uint64
+	l.Listen(func(entry Entry) {
+		seqs = append(seqs, entry.Seq)
+	})
+	l.Append(Entry{Procedure: "RICSubscription"})
+	l.Append(Entry{Procedure: "RICSubscriptionDelete"})
+	assert.Equal(t, []uint64{2, 3}, seqs)
+}
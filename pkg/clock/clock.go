@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of the simulation time
+type Clock interface {
+	// Now returns the current simulation time
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (c systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// NewSystemClock creates a clock that follows the wall clock
+func NewSystemClock() Clock {
+	return systemClock{}
+}
+
+var (
+	mu         sync.RWMutex
+	simulation = NewSystemClock()
+)
+
+// Now returns the current simulation time
+func Now() time.Time {
+	mu.RLock()
+	defer mu.RUnlock()
+	return simulation.Now()
+}
+
+// Set sets the clock used as the source of the simulation time
+func Set(clock Clock) {
+	mu.Lock()
+	defer mu.Unlock()
+	simulation = clock
+}
+
+// OffsetClock follows the wall clock shifted by an adjustable offset, e.g. to follow the clock of another instance
+type OffsetClock struct {
+	mu     sync.RWMutex
+	offset time.Duration
+}
+
+// NewOffsetClock creates a clock that follows the wall clock until its offset is set
+func NewOffsetClock() *OffsetClock {
+	return &OffsetClock{}
+}
+
+// Now returns the wall clock time shifted by the offset
+func (c *OffsetClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return time.Now().Add(c.offset)
+}
+
+// Offset returns the offset of the clock from the wall clock
+func (c *OffsetClock) Offset() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.offset
+}
+
+// SetOffset sets the offset of the clock from the wall clock
+func (c *OffsetClock) SetOffset(offset time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.offset = offset
+}
@@ -6,6 +6,7 @@ package connection
 
 import (
 	"context"
+	"crypto/tls"
 
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 
@@ -17,15 +18,17 @@ import (
 
 	"github.com/onosproject/ran-simulator/pkg/model"
 
-	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/configupdate"
 
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
 	e2connection "github.com/onosproject/ran-simulator/pkg/e2agent/connection"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/transactions"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 
 	"github.com/onosproject/ran-simulator/pkg/store/connections"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 
 	"github.com/onosproject/onos-lib-go/pkg/controller"
 )
@@ -38,29 +41,32 @@ const queueSize = 100
 // NewController returns a new connection controller. This controller is responsible to open and close
 // E2 connections that are the result of the E2 Connection Update procedure or E2 Configuration update procedure
 func NewController(connections connections.Store, node model.Node, model *model.Model,
-	registry *registry.ServiceModelRegistry, subStore *subscriptions.Subscriptions) *controller.Controller {
+	registry *registry.ServiceModelRegistry, subStore *subscriptions.Subscriptions,
+	transactions *transactions.Transactions) *controller.Controller {
 	c := controller.NewController("E2Connections")
 	c.Watch(&Watcher{
 		connections: connections,
 	})
 
 	c.Reconcile(&Reconciler{
-		connections: connections,
-		node:        node,
-		model:       model,
-		registry:    registry,
-		subStore:    subStore,
+		connections:  connections,
+		node:         node,
+		model:        model,
+		registry:     registry,
+		subStore:     subStore,
+		transactions: transactions,
 	})
 	return c
 }
 
 // Reconciler is a E2 connection reconciler
 type Reconciler struct {
-	connections connections.Store
-	node        model.Node
-	model       *model.Model
-	registry    *registry.ServiceModelRegistry
-	subStore    *subscriptions.Subscriptions
+	connections  connections.Store
+	node         model.Node
+	model        *model.Model
+	registry     *registry.ServiceModelRegistry
+	subStore     *subscriptions.Subscriptions
+	transactions *transactions.Transactions
 }
 
 // Reconcile reconciles the state of a device change
@@ -91,24 +97,39 @@ func (r *Reconciler) Reconcile(id controller.ID) (controller.Result, error) {
 }
 
 func (r *Reconciler) configureDataConn(ctx context.Context, connection *connections.Connection) (controller.Result, error) {
-	plmnID := ransimtypes.NewUint24(uint32(r.model.PlmnID))
-	configUpdate, err := configupdate.NewConfigurationUpdate(
-		configupdate.WithTransactionID(int32(2)),
-		configupdate.WithE2NodeID(uint64(r.node.GnbID)),
-		configupdate.WithPlmnID(plmnID.Value())).
-		Build()
+	nodePlmnID, err := r.model.GetNodePlmnID(r.node)
 	if err != nil {
-		log.Warnf("Failed to reconcile opening connection %+v: %s", connection, err)
 		return controller.Result{}, err
 	}
-	log.Info("Sending Configuration update request:%+v", configUpdate)
-	configUpdateAck, configUpdateFailure, err := connection.Client.E2ConfigurationUpdate(ctx, configUpdate)
+	plmnID := plmn.ToUint24(nodePlmnID)
+	var configUpdateAck *e2appducontents.E2NodeConfigurationUpdateAcknowledge
+	var configUpdateFailure *e2appducontents.E2NodeConfigurationUpdateFailure
+	err = r.transactions.Do(ctx, transactions.E2ConfigurationUpdate, func(ctx context.Context, transactionID int32) (int32, error) {
+		configUpdate, err := configupdate.NewConfigurationUpdate(
+			configupdate.WithTransactionID(transactionID),
+			configupdate.WithE2NodeID(uint64(r.node.GnbID)),
+			configupdate.WithE2NodeType(r.node.GetE2NodeType()),
+			configupdate.WithE2NodeIDLength(r.node.E2NodeID.Length),
+			configupdate.WithPlmnID(plmnID.Value())).
+			Build()
+		if err != nil {
+			return 0, err
+		}
+		log.Infof("Sending Configuration update request:%+v", configUpdate)
+		configUpdateAck, configUpdateFailure, err = connection.Client.E2ConfigurationUpdate(ctx, configUpdate)
+		if err != nil {
+			return 0, err
+		} else if configUpdateFailure != nil {
+			return configupdate.GetFailureTransactionID(configUpdateFailure)
+		}
+		return configupdate.GetTransactionID(configUpdateAck)
+	})
 	if err != nil {
 		log.Warnf("Failed to reconcile opening connection %+v: %s", connection, err)
 		return controller.Result{}, err
 	}
 	if configUpdateFailure != nil {
-		err = errors.NewUnknown("Failed to reconcile opening connection %+v: %s", connection, err)
+		err = errors.NewUnknown("Failed to reconcile opening connection %+v: %+v", connection, configUpdateFailure)
 		log.Warn(err)
 		return controller.Result{}, err
 	}
@@ -154,9 +175,16 @@ func (r *Reconciler) reconcileOpenConnection(connection *connections.Connection)
 			e2connection.WithModel(r.model),
 			e2connection.WithSMRegistry(r.registry),
 			e2connection.WithSubStore(r.subStore),
-			e2connection.WithConnectionStore(r.connections))
+			e2connection.WithConnectionStore(r.connections),
+			e2connection.WithTransactions(r.transactions))
 
-		client, err := e2.Connect(ctx, addr, func(channel e2.ClientConn) e2.ClientInterface {
+		tlsConfig, err := r.tlsConfig()
+		if err != nil {
+			log.Warnf("Failed to reconcile opening connection %+v: %s", connection, err)
+			return controller.Result{}, err
+		}
+
+		client, err := e2connection.Dial(ctx, addr, tlsConfig, r.node, func(channel e2.ClientConn) e2.ClientInterface {
 			return e2Connection
 		})
 
@@ -194,6 +222,18 @@ func (r *Reconciler) reconcileOpenConnection(connection *connections.Connection)
 
 }
 
+// tlsConfig returns the TLS configuration of the controller that the node is primarily associated with
+func (r *Reconciler) tlsConfig() (*tls.Config, error) {
+	if len(r.node.Controllers) == 0 {
+		return nil, nil
+	}
+	c, err := r.model.GetController(r.node.Controllers[0])
+	if err != nil {
+		return nil, err
+	}
+	return e2connection.NewTLSConfig(r.model, r.node, c)
+}
+
 func (r *Reconciler) reconcileClosedConnection(connection *connections.Connection) (controller.Result, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
 	defer cancel()
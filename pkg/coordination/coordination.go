@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coordination
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+var log = logging.GetLogger("coordination")
+
+// Path path of the REST gateway serving the timing of an instance
+const Path = "/v1/coordination"
+
+// Timing simulation timing of an instance
+type Timing struct {
+	Shard        uint      `json:"shard"`
+	Epoch        time.Time `json:"epoch"`        // start of the scenario, to which the scheduled events are relative
+	Now          time.Time `json:"now"`          // simulation time of the instance
+	Synchronized bool      `json:"synchronized"` // the instance follows shard 0, or is shard 0
+}
+
+// Coordinator aligns the simulation clock and the start of the scenario of the instance with those of shard 0, so
+// that the sharded instances timestamp their reports consistently and schedule the events of the scenario, e.g.
+// outages, at the same time. Shard 0 serves its timing and the other shards synchronize with it periodically; the
+// clock offset is estimated assuming shard 0 reads its clock halfway through the round trip of the request.
+type Coordinator struct {
+	mu           sync.RWMutex
+	config       model.ShardingConfig
+	shard        uint
+	clock        *clock.OffsetClock
+	epoch        time.Time
+	synchronized bool
+	client       *http.Client
+	cancel       context.CancelFunc
+}
+
+// NewCoordinator creates a coordinator of the given shard
+func NewCoordinator(config model.ShardingConfig, shard uint) *Coordinator {
+	return &Coordinator{
+		config: config,
+		shard:  shard,
+		clock:  clock.NewOffsetClock(),
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// isFollower returns true if the instance synchronizes with shard 0
+func (c *Coordinator) isFollower() bool {
+	return c.config.IsCoordinated() && c.shard != 0
+}
+
+// Start starts the scenario now. The other shards than shard 0 make their clock the simulation clock and try to
+// synchronize with shard 0 within the given timeout, taking over its start of the scenario, and then keep
+// synchronizing in the background; they run unsynchronized until shard 0 is reachable.
+func (c *Coordinator) Start(timeout time.Duration) {
+	c.mu.Lock()
+	c.epoch = c.clock.Now()
+	c.synchronized = !c.isFollower()
+	c.mu.Unlock()
+	if !c.isFollower() {
+		return
+	}
+
+	clock.Set(c.clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	deadline := time.Now().Add(timeout)
+	for {
+		err := c.synchronize(ctx)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			log.Warnf("Running unsynchronized until shard 0 is reachable: %v", err)
+			break
+		}
+		time.Sleep(time.Second)
+	}
+	go c.run(ctx)
+}
+
+// run synchronizes with shard 0 periodically until stopped
+func (c *Coordinator) run(ctx context.Context) {
+	ticker := time.NewTicker(c.config.GetSyncInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.synchronize(ctx); err != nil {
+				log.Warn(err)
+			}
+		}
+	}
+}
+
+// Stop stops synchronizing with shard 0
+func (c *Coordinator) Stop() {
+	if c.cancel != nil {
+		c.cancel()
+	}
+}
+
+// synchronize aligns the clock of the instance and the start of its scenario with those of shard 0
+func (c *Coordinator) synchronize(ctx context.Context) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+c.config.Coordinator+Path, nil)
+	if err != nil {
+		return err
+	}
+	sent := time.Now()
+	resp, err := c.client.Do(request)
+	if err != nil {
+		return errors.NewUnavailable("unable to reach shard 0 at %s: %v", c.config.Coordinator, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return errors.NewUnavailable("shard 0 at %s: %s", c.config.Coordinator, resp.Status)
+	}
+	timing := &Timing{}
+	if err := json.NewDecoder(resp.Body).Decode(timing); err != nil {
+		return err
+	}
+	received := time.Now()
+
+	offset := timing.Now.Sub(sent.Add(received.Sub(sent) / 2))
+	c.clock.SetOffset(offset)
+	c.mu.Lock()
+	first := !c.synchronized
+	c.epoch = timing.Epoch
+	c.synchronized = true
+	c.mu.Unlock()
+	if first {
+		log.Infof("Synchronized with shard 0 at %s: clock offset %v, scenario started at %v", c.config.Coordinator, offset, timing.Epoch)
+	} else {
+		log.Debugf("Synchronized with shard 0 at %s: clock offset %v", c.config.Coordinator, offset)
+	}
+	return nil
+}
+
+// Epoch returns the start of the scenario
+func (c *Coordinator) Epoch() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.epoch
+}
+
+// Timing returns the timing of the instance
+func (c *Coordinator) Timing() Timing {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Timing{
+		Shard:        c.shard,
+		Epoch:        c.epoch,
+		Now:          c.clock.Now(),
+		Synchronized: c.synchronized,
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coordination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCoordinator(t *testing.T) {
+	config := model.ShardingConfig{Shards: 2, SyncInterval: 10 * time.Millisecond}
+	leader := NewCoordinator(config, 0)
+	leader.clock.SetOffset(time.Hour)
+	leader.Start(time.Second)
+	assert.True(t, leader.Timing().Synchronized)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, Path, r.URL.Path)
+		_ = json.NewEncoder(w).Encode(leader.Timing())
+	}))
+	defer server.Close()
+	defer clock.Set(clock.NewSystemClock())
+
+	config.Coordinator = strings.TrimPrefix(server.URL, "http://")
+	follower := NewCoordinator(config, 1)
+	follower.Start(time.Second)
+	defer follower.Stop()
+	timing := follower.Timing()
+	assert.True(t, timing.Synchronized)
+	assert.Equal(t, leader.Epoch().UnixNano(), follower.Epoch().UnixNano())
+	assert.InDelta(t, float64(time.Hour), float64(follower.clock.Offset()), float64(100*time.Millisecond))
+	assert.InDelta(t, float64(leader.Timing().Now.UnixNano()), float64(clock.Now().UnixNano()), float64(100*time.Millisecond))
+
+	// An unreachable shard 0 leaves the follower unsynchronized
+	server.Close()
+	config.Coordinator = "127.0.0.1:1"
+	unsynchronized := NewCoordinator(config, 1)
+	unsynchronized.Start(0)
+	defer unsynchronized.Stop()
+	assert.False(t, unsynchronized.Timing().Synchronized)
+}
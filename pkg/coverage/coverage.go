@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// Kinds of the features of the analysis, given by their "kind" property
+const (
+	// CoverageKind area where the signal of a cell is at least the threshold
+	CoverageKind = "coverage"
+	// OverlapKind area covered by several cells
+	OverlapKind = "overlap"
+	// BestServerKind area where a cell has the strongest signal
+	BestServerKind = "bestServer"
+)
+
+const (
+	// bearingStep angle between the rays traced to find the edge of the coverage of a cell
+	bearingStep = 5
+	// maxGridSquares maximum number of grid squares of an analysis
+	maxGridSquares = 250000
+)
+
+// Options parameters of the analysis
+type Options struct {
+	Resolution float64 // side of the grid squares in meters
+	Threshold  float64 // minimum signal strength of a covered location, as computed by the RF model
+	Margin     float64 // distance in meters by which the analysed area extends beyond the outermost cells
+}
+
+// DefaultOptions returns the default analysis parameters
+func DefaultOptions() Options {
+	return Options{
+		Resolution: 100,
+		Threshold:  -105,
+		Margin:     2000,
+	}
+}
+
+// Analyze computes the coverage polygon of each cell along with the overlap areas and the best-server map of the
+// given cells on a grid, using the same RF model that drives the simulation; the result is a GeoJSON feature
+// collection where the kind of each feature is given by its "kind" property
+func Analyze(cells []*model.Cell, options Options) (*FeatureCollection, error) {
+	if options.Resolution <= 0 || options.Margin < 0 {
+		return nil, errors.NewInvalid("resolution must be positive and margin must not be negative")
+	}
+	collection := newFeatureCollection()
+	if len(cells) == 0 {
+		return collection, nil
+	}
+	sort.Slice(cells, func(i, j int) bool {
+		return cells[i].NCGI < cells[j].NCGI
+	})
+
+	min, max := cellArea(cells, options.Margin)
+	g, err := newGrid(min, max, options.Resolution)
+	if err != nil {
+		return nil, err
+	}
+	rows, columns, latStep, lngStep := g.rows, g.columns, g.latStep, g.lngStep
+
+	squareArea := options.Resolution * options.Resolution
+	bestServers := make(map[types.NCGI][][]Position)
+	overlaps := make(map[string][][]Position)
+	overlapCells := make(map[string][]types.NCGI)
+	for row := 0; row < rows; row++ {
+		for column := 0; column < columns; column++ {
+			sw := model.Coordinate{Lat: min.Lat + float64(row)*latStep, Lng: min.Lng + float64(column)*lngStep}
+			center := model.Coordinate{Lat: sw.Lat + latStep/2, Lng: sw.Lng + lngStep/2}
+			var best *model.Cell
+			var bestStrength float64
+			covering := make([]types.NCGI, 0)
+			for _, cell := range cells {
+				strength := mobility.StrengthAtLocation(center, *cell)
+				if strength < options.Threshold {
+					continue
+				}
+				covering = append(covering, cell.NCGI)
+				if best == nil || strength > bestStrength {
+					best, bestStrength = cell, strength
+				}
+			}
+			if best == nil {
+				continue
+			}
+			ring := square(sw, latStep, lngStep)
+			bestServers[best.NCGI] = append(bestServers[best.NCGI], ring)
+			if len(covering) > 1 {
+				key := overlapKey(covering)
+				overlaps[key] = append(overlaps[key], ring)
+				overlapCells[key] = covering
+			}
+		}
+	}
+
+	maxRange := utils.Distance(min, max)
+	for _, cell := range cells {
+		collection.Features = append(collection.Features, newFeature(coveragePolygon(cell, options, maxRange), map[string]interface{}{
+			"kind": CoverageKind,
+			"ncgi": cell.NCGI,
+		}))
+	}
+	for _, key := range sortedKeys(overlaps) {
+		collection.Features = append(collection.Features, newFeature(newMultiPolygon(overlaps[key]), map[string]interface{}{
+			"kind":  OverlapKind,
+			"ncgis": overlapCells[key],
+			"area":  float64(len(overlaps[key])) * squareArea,
+		}))
+	}
+	for _, cell := range cells {
+		if rings, ok := bestServers[cell.NCGI]; ok {
+			collection.Features = append(collection.Features, newFeature(newMultiPolygon(rings), map[string]interface{}{
+				"kind": BestServerKind,
+				"ncgi": cell.NCGI,
+				"area": float64(len(rings)) * squareArea,
+			}))
+		}
+	}
+	return collection, nil
+}
+
+// grid of squares of equal size covering an area
+type grid struct {
+	min     model.Coordinate
+	rows    int
+	columns int
+	latStep float64
+	lngStep float64
+}
+
+// newGrid returns the grid of squares of the given side in meters covering the area between the given south-west
+// and north-east corners
+func newGrid(min model.Coordinate, max model.Coordinate, resolution float64) (*grid, error) {
+	latStep := resolution / utils.MetersPerDegree
+	lngStep := latStep / utils.AspectRatio((min.Lat+max.Lat)/2)
+	g := &grid{
+		min:     min,
+		rows:    int(math.Ceil((max.Lat - min.Lat) / latStep)),
+		columns: int(math.Ceil((max.Lng - min.Lng) / lngStep)),
+		latStep: latStep,
+		lngStep: lngStep,
+	}
+	if g.rows*g.columns > maxGridSquares {
+		return nil, errors.NewInvalid("analysis needs %d grid squares, more than %d; use a coarser resolution", g.rows*g.columns, maxGridSquares)
+	}
+	return g, nil
+}
+
+// cellArea returns the south-west and north-east corners of the area of the cell sites extended by the given margin
+// in meters
+func cellArea(cells []*model.Cell, margin float64) (model.Coordinate, model.Coordinate) {
+	centers := make([]model.Coordinate, 0, len(cells))
+	for _, cell := range cells {
+		centers = append(centers, cell.Sector.Center)
+	}
+	min, max := utils.BoundingBox(centers)
+	latMargin := margin / utils.MetersPerDegree
+	lngMargin := latMargin / utils.AspectRatio((min.Lat+max.Lat)/2)
+	min.Lat, min.Lng = min.Lat-latMargin, min.Lng-lngMargin
+	max.Lat, max.Lng = max.Lat+latMargin, max.Lng+lngMargin
+	return min, max
+}
+
+// coveragePolygon traces rays from the cell site and returns the polygon joining the farthest covered points;
+// the signal strength decreases monotonically along each ray
+func coveragePolygon(cell *model.Cell, options Options, maxRange float64) *Geometry {
+	ring := make([]Position, 0, 360/bearingStep+1)
+	for bearing := 0; bearing < 360; bearing += bearingStep {
+		edge := cell.Sector.Center
+		for dist := options.Resolution; dist <= maxRange; dist += options.Resolution {
+			point := utils.TargetPoint(cell.Sector.Center, float64(bearing), dist)
+			if mobility.StrengthAtLocation(point, *cell) < options.Threshold {
+				break
+			}
+			edge = point
+		}
+		ring = append(ring, position(edge))
+	}
+	return newPolygon(ring)
+}
+
+// square returns the closed ring of the grid square with the given south-west corner
+func square(sw model.Coordinate, latStep float64, lngStep float64) []Position {
+	return []Position{
+		{sw.Lng, sw.Lat},
+		{sw.Lng + lngStep, sw.Lat},
+		{sw.Lng + lngStep, sw.Lat + latStep},
+		{sw.Lng, sw.Lat + latStep},
+		{sw.Lng, sw.Lat},
+	}
+}
+
+func overlapKey(ncgis []types.NCGI) string {
+	elements := make([]string, 0, len(ncgis))
+	for _, ncgi := range ncgis {
+		elements = append(elements, strconv.FormatUint(uint64(ncgi), 16))
+	}
+	return strings.Join(elements, ",")
+}
+
+func sortedKeys(m map[string][][]Position) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
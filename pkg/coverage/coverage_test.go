@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCell(ncgi types.NCGI, lng float64, azimuth int32) *model.Cell {
+	return &model.Cell{
+		NCGI:      ncgi,
+		TxPowerDB: 11,
+		Sector: model.Sector{
+			Center:  model.Coordinate{Lat: 52.5, Lng: lng},
+			Azimuth: azimuth,
+			Arc:     120,
+		},
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	cells := []*model.Cell{newCell(2, 13.410, 0), newCell(1, 13.400, 0)}
+	collection, err := Analyze(cells, DefaultOptions())
+	assert.NoError(t, err)
+	assert.Equal(t, "FeatureCollection", collection.Type)
+
+	kinds := make(map[string]int)
+	for _, feature := range collection.Features {
+		kinds[feature.Properties["kind"].(string)]++
+		if feature.Properties["kind"] == CoverageKind {
+			ring := feature.Geometry.Coordinates.([][]Position)[0]
+			assert.Equal(t, 360/bearingStep+1, len(ring))
+			assert.Equal(t, ring[0], ring[len(ring)-1])
+		}
+	}
+	assert.Equal(t, 2, kinds[CoverageKind])
+	assert.Equal(t, 2, kinds[BestServerKind])
+	assert.Equal(t, 1, kinds[OverlapKind])
+
+	// Cells are reported in NCGI order
+	assert.Equal(t, types.NCGI(1), collection.Features[0].Properties["ncgi"])
+
+	_, err = json.Marshal(collection)
+	assert.NoError(t, err)
+}
+
+func TestAnalyzeOptions(t *testing.T) {
+	collection, err := Analyze(nil, DefaultOptions())
+	assert.NoError(t, err)
+	assert.Len(t, collection.Features, 0)
+
+	_, err = Analyze([]*model.Cell{newCell(1, 13.4, 0)}, Options{Resolution: 0})
+	assert.Error(t, err)
+	_, err = Analyze([]*model.Cell{newCell(1, 13.4, 0)}, Options{Resolution: 1, Margin: 10000})
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// FeatureCollection GeoJSON feature collection; see RFC 7946
+type FeatureCollection struct {
+	Type     string     `json:"type"`
+	Features []*Feature `json:"features"`
+}
+
+// Feature GeoJSON feature
+type Feature struct {
+	Type       string                 `json:"type"`
+	Geometry   *Geometry              `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Geometry GeoJSON geometry; the coordinates are nested arrays of longitude/latitude positions
+type Geometry struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates"`
+}
+
+// Position GeoJSON position; longitude first
+type Position [2]float64
+
+func newFeatureCollection() *FeatureCollection {
+	return &FeatureCollection{
+		Type:     "FeatureCollection",
+		Features: make([]*Feature, 0),
+	}
+}
+
+func newFeature(geometry *Geometry, properties map[string]interface{}) *Feature {
+	return &Feature{
+		Type:       "Feature",
+		Geometry:   geometry,
+		Properties: properties,
+	}
+}
+
+func position(c model.Coordinate) Position {
+	return Position{c.Lng, c.Lat}
+}
+
+// newPolygon creates a polygon geometry from the given ring; the ring is closed if needed
+func newPolygon(ring []Position) *Geometry {
+	if len(ring) > 0 && ring[0] != ring[len(ring)-1] {
+		ring = append(ring, ring[0])
+	}
+	return &Geometry{
+		Type:        "Polygon",
+		Coordinates: [][]Position{ring},
+	}
+}
+
+// newMultiPolygon creates a multi-polygon geometry from the given single-ring polygons
+func newMultiPolygon(rings [][]Position) *Geometry {
+	polygons := make([][][]Position, 0, len(rings))
+	for _, ring := range rings {
+		polygons = append(polygons, [][]Position{ring})
+	}
+	return &Geometry{
+		Type:        "MultiPolygon",
+		Coordinates: polygons,
+	}
+}
@@ -0,0 +1,155 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// HeatmapOptions parameters of a heatmap
+type HeatmapOptions struct {
+	NCGI       types.NCGI        // cell whose signal is rasterized; the strongest signal of all cells if not set
+	Resolution float64           // side of the pixels in meters
+	Floor      float64           // signal strength of the pixels without signal; weaker signals are raised to it
+	Ceiling    float64           // signal strength rendered with the hottest color; stronger signals are lowered to it
+	Margin     float64           // distance in meters by which the area extends beyond the outermost cells
+	Min        *model.Coordinate // south-west corner of the area; the area covered by the cells if not set
+	Max        *model.Coordinate // north-east corner of the area; the area covered by the cells if not set
+}
+
+// DefaultHeatmapOptions returns the default heatmap parameters
+func DefaultHeatmapOptions() HeatmapOptions {
+	return HeatmapOptions{
+		Resolution: 100,
+		Floor:      -140,
+		Ceiling:    -44,
+		Margin:     2000,
+	}
+}
+
+// Heatmap signal strengths, as computed by the RF model, at the center of the pixels of a grid; the first row is the
+// northernmost and the first column the westernmost
+type Heatmap struct {
+	NCGI    types.NCGI       `json:"ncgi,omitempty"`
+	Min     model.Coordinate `json:"min"`
+	Max     model.Coordinate `json:"max"`
+	Rows    int              `json:"rows"`
+	Columns int              `json:"columns"`
+	Floor   float64          `json:"floor"`
+	Ceiling float64          `json:"ceiling"`
+	Values  [][]float64      `json:"values"`
+}
+
+// NewHeatmap rasterizes the signal strength of a cell, or the strongest signal of the given cells, over an area;
+// failed cells provide no signal
+func NewHeatmap(cells []*model.Cell, options HeatmapOptions) (*Heatmap, error) {
+	if options.Resolution <= 0 || options.Margin < 0 {
+		return nil, errors.NewInvalid("resolution must be positive and margin must not be negative")
+	}
+	if options.Ceiling <= options.Floor {
+		return nil, errors.NewInvalid("ceiling must be above the floor")
+	}
+	if options.NCGI != 0 {
+		var selected []*model.Cell
+		for _, cell := range cells {
+			if cell.NCGI == options.NCGI {
+				selected = append(selected, cell)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, errors.NewNotFound("cell %d not found", options.NCGI)
+		}
+		cells = selected
+	}
+
+	var min, max model.Coordinate
+	switch {
+	case options.Min != nil && options.Max != nil:
+		min, max = *options.Min, *options.Max
+		if min.Lat >= max.Lat || min.Lng >= max.Lng {
+			return nil, errors.NewInvalid("bounding box is empty")
+		}
+	case len(cells) > 0:
+		min, max = cellArea(cells, options.Margin)
+	default:
+		return nil, errors.NewInvalid("bounding box is required if there are no cells")
+	}
+	g, err := newGrid(min, max, options.Resolution)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := &Heatmap{
+		NCGI:    options.NCGI,
+		Min:     min,
+		Max:     max,
+		Rows:    g.rows,
+		Columns: g.columns,
+		Floor:   options.Floor,
+		Ceiling: options.Ceiling,
+		Values:  make([][]float64, g.rows),
+	}
+	for row := 0; row < g.rows; row++ {
+		heatmap.Values[row] = make([]float64, g.columns)
+		lat := max.Lat - (float64(row)+0.5)*g.latStep
+		for column := 0; column < g.columns; column++ {
+			center := model.Coordinate{Lat: lat, Lng: min.Lng + (float64(column)+0.5)*g.lngStep}
+			value := options.Floor
+			for _, cell := range cells {
+				if cell.Failed {
+					continue
+				}
+				strength := mobility.StrengthAtLocation(center, *cell)
+				if !math.IsNaN(strength) && strength > value {
+					value = strength
+				}
+			}
+			heatmap.Values[row][column] = math.Min(value, options.Ceiling)
+		}
+	}
+	return heatmap, nil
+}
+
+// EncodePNG writes the heatmap as a PNG image with one pixel per grid square; the signal strengths are rendered from
+// blue, just above the floor, to red, at the ceiling, and the pixels without signal are transparent
+func (h *Heatmap) EncodePNG(w io.Writer) error {
+	img := image.NewNRGBA(image.Rect(0, 0, h.Columns, h.Rows))
+	for row, values := range h.Values {
+		for column, value := range values {
+			if value <= h.Floor {
+				continue
+			}
+			img.SetNRGBA(column, row, heatColor((value-h.Floor)/(h.Ceiling-h.Floor)))
+		}
+	}
+	return png.Encode(w, img)
+}
+
+// heatColor returns the color of the given heat between 0 and 1, going through the hues from blue to red
+func heatColor(heat float64) color.NRGBA {
+	hue := (1 - math.Max(0, math.Min(heat, 1))) * 240
+	x := 1 - math.Abs(math.Mod(hue/60, 2)-1)
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g = 1, x
+	case hue < 120:
+		r, g = x, 1
+	case hue < 180:
+		g, b = 1, x
+	default:
+		g, b = x, 1
+	}
+	return color.NRGBA{R: uint8(r * 255), G: uint8(g * 255), B: uint8(b * 255), A: 255}
+}
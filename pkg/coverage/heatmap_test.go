@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package coverage
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeatmap(t *testing.T) {
+	cells := []*model.Cell{newCell(1, 13.400, 0), newCell(2, 13.410, 180)}
+	options := DefaultHeatmapOptions()
+	composite, err := NewHeatmap(cells, options)
+	assert.NoError(t, err)
+	assert.True(t, composite.Rows > 0 && composite.Columns > 0)
+	assert.Len(t, composite.Values, composite.Rows)
+	assert.Len(t, composite.Values[0], composite.Columns)
+
+	// The composite is the strongest signal of all cells
+	options.NCGI = 1
+	single, err := NewHeatmap(cells, options)
+	assert.NoError(t, err)
+	assert.Equal(t, composite.Rows, single.Rows)
+	stronger := false
+	for row := range single.Values {
+		for column, value := range single.Values[row] {
+			assert.True(t, composite.Values[row][column] >= value)
+			assert.True(t, value >= options.Floor && value <= options.Ceiling)
+			stronger = stronger || composite.Values[row][column] > value
+		}
+	}
+	assert.True(t, stronger)
+
+	// Failed cells provide no signal
+	cells[0].Failed = true
+	failed, err := NewHeatmap(cells, options)
+	assert.NoError(t, err)
+	for _, values := range failed.Values {
+		for _, value := range values {
+			assert.Equal(t, options.Floor, value)
+		}
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, composite.EncodePNG(&buf))
+	img, err := png.Decode(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, composite.Columns, img.Bounds().Dx())
+	assert.Equal(t, composite.Rows, img.Bounds().Dy())
+}
+
+func TestHeatmapOptions(t *testing.T) {
+	cells := []*model.Cell{newCell(1, 13.400, 0)}
+	options := DefaultHeatmapOptions()
+	options.NCGI = 2
+	_, err := NewHeatmap(cells, options)
+	assert.Error(t, err)
+
+	options = DefaultHeatmapOptions()
+	options.Min = &model.Coordinate{Lat: 52.49, Lng: 13.39}
+	options.Max = &model.Coordinate{Lat: 52.51, Lng: 13.41}
+	heatmap, err := NewHeatmap(cells, options)
+	assert.NoError(t, err)
+	assert.Equal(t, *options.Min, heatmap.Min)
+
+	options.Min, options.Max = options.Max, options.Min
+	_, err = NewHeatmap(cells, options)
+	assert.Error(t, err)
+
+	options = DefaultHeatmapOptions()
+	options.Resolution = 0.1
+	_, err = NewHeatmap(cells, options)
+	assert.Error(t, err)
+	_, err = NewHeatmap(nil, DefaultHeatmapOptions())
+	assert.Error(t, err)
+}
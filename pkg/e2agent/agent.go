@@ -7,7 +7,9 @@ package e2agent
 import (
 	"context"
 	"net"
+	"sync"
 
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/ccc"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/kpm2"
 
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/kpm"
@@ -15,9 +17,11 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
 
 	"github.com/onosproject/ran-simulator/pkg/e2agent/connection"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/transactions"
 
 	"github.com/onosproject/ran-simulator/pkg/mobility"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/mho"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/ni"
 	"github.com/onosproject/ran-simulator/pkg/store/connections"
 	"github.com/onosproject/rrm-son-lib/pkg/handover"
 
@@ -49,18 +53,39 @@ type E2Agent interface {
 
 	// Stop stops the agent
 	Stop() error
+
+	// Shutdown stops the agent within the deadline of the given context
+	Shutdown(ctx context.Context) error
+
+	// Abort stops the agent abruptly, as upon a crash or power loss of the E2 node, without notifying the RIC
+	Abort() error
+
+	// RunningSubscriptions returns the number of subscriptions of the agent whose reporting routine is running
+	RunningSubscriptions() int
+
+	// Subscriptions returns the subscriptions of the agent
+	Subscriptions() ([]*subscriptions.Subscription, error)
+
+	// InjectIndication sends a custom indication to the given subscription
+	InjectIndication(ctx context.Context, id subscriptions.ID, indication Indication) error
 }
 
 // e2Agent is an E2 agent
 type e2Agent struct {
-	node            model.Node
-	model           *model.Model
-	registry        *registry.ServiceModelRegistry
-	subStore        *subscriptions.Subscriptions
-	nodeStore       nodes.Store
-	ueStore         ues.Store
-	cellStore       cells.Store
+	node         model.Node
+	model        *model.Model
+	registry     *registry.ServiceModelRegistry
+	modelPlugins modelplugins.ModelRegistry
+	subStore     *subscriptions.Subscriptions
+	nodeStore    nodes.Store
+	ueStore      ues.Store
+	cellStore    cells.Store
+	transactions *transactions.Transactions
+
+	mu              sync.Mutex
 	connectionStore connections.Store
+	e2Connection    connection.E2Connection
+	stopped         bool
 }
 
 // NewE2Agent creates a new E2 agent
@@ -78,6 +103,10 @@ func NewE2Agent(node model.Node, model *model.Model, modelPluginRegistry modelpl
 		if err != nil {
 			return nil, err
 		}
+		if node.IsEUTRA() && !registry.RanFunctionID(serviceModel.ID).SupportsEUTRA() {
+			log.Warnf("Service model %s with ID %d is not supported by E-UTRA nodes; not exposed by node %d", smID, serviceModel.ID, node.GnbID)
+			continue
+		}
 		switch registry.RanFunctionID(serviceModel.ID) {
 		case registry.Kpm:
 			kpmSm, err := kpm.NewServiceModel(node, model, modelPluginRegistry,
@@ -90,6 +119,17 @@ func NewE2Agent(node model.Node, model *model.Model, modelPluginRegistry modelpl
 				log.Error(err)
 				return nil, err
 			}
+		case registry.Ni:
+			niSm, err := ni.NewServiceModel(node, model, modelPluginRegistry,
+				subStore, nodeStore, ueStore)
+			if err != nil {
+				return nil, err
+			}
+			err = reg.RegisterServiceModel(niSm)
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
 		case registry.Rcpre2:
 			rcSm, err := rc.NewServiceModel(node, model, modelPluginRegistry,
 				subStore, nodeStore, ueStore, cellStore, metricStore)
@@ -104,7 +144,7 @@ func NewE2Agent(node model.Node, model *model.Model, modelPluginRegistry modelpl
 		case registry.Kpm2:
 			log.Info("KPM2 service model for node with eNbID:", node.GnbID)
 			kpm2Sm, err := kpm2.NewServiceModel(node, model,
-				subStore, nodeStore, ueStore)
+				subStore, nodeStore, ueStore, cellStore, metricStore)
 			if err != nil {
 				log.Info("Failure creating KPM2 service model for eNbID:", node.GnbID)
 				return nil, err
@@ -129,16 +169,31 @@ func NewE2Agent(node model.Node, model *model.Model, modelPluginRegistry modelpl
 				log.Error(err)
 				return nil, err
 			}
+		case registry.Ccc:
+			cccSm, err := ccc.NewServiceModel(node, model, subStore, nodeStore, ueStore, cellStore)
+			if err != nil {
+				return nil, err
+			}
+			err = reg.RegisterServiceModel(cccSm)
+			if err != nil {
+				log.Error(err)
+				return nil, err
+			}
+		default:
+			log.Warnf("Service model %s with ID %d is not supported; not exposed by node %d", smID, serviceModel.ID, node.GnbID)
 		}
 	}
 	return &e2Agent{
-		node:      node,
-		registry:  reg,
-		model:     model,
-		subStore:  subStore,
-		nodeStore: nodeStore,
-		ueStore:   ueStore,
-		cellStore: cellStore,
+		node:         node,
+		registry:     reg,
+		modelPlugins: modelPluginRegistry,
+		model:        model,
+		subStore:     subStore,
+		nodeStore:    nodeStore,
+		ueStore:      ueStore,
+		cellStore:    cellStore,
+		// Each new e2 agent allocates the transaction IDs of its own procedures
+		transactions: transactions.NewTransactions(),
 	}, nil
 }
 
@@ -159,10 +214,13 @@ func (a *e2Agent) Start() error {
 		IPAddress: net.ParseIP(controllerAddresses[0]),
 		Port:      uint64(controller.Port),
 	}
-	connectionStore := connections.NewStore()
-	a.connectionStore = connectionStore
+	tlsConfig, err := connection.NewTLSConfig(a.model, a.node, controller)
+	if err != nil {
+		return err
+	}
 
-	c := connectionController.NewController(connectionStore, a.node, a.model, a.registry, a.subStore)
+	connectionStore := connections.NewStore()
+	c := connectionController.NewController(connectionStore, a.node, a.model, a.registry, a.subStore, a.transactions)
 	err = c.Start()
 	if err != nil {
 		return err
@@ -173,36 +231,100 @@ func (a *e2Agent) Start() error {
 		connection.WithSMRegistry(a.registry),
 		connection.WithSubStore(a.subStore),
 		connection.WithRICAddress(ricAddress),
-		connection.WithConnectionStore(connectionStore))
+		connection.WithConnectionStore(connectionStore),
+		connection.WithNodeStore(a.nodeStore),
+		connection.WithTLSConfig(tlsConfig),
+		connection.WithTransactions(a.transactions))
 
-	err = e2Connection.Setup()
-	if err != nil {
-		return err
+	// The agent may be stopped while it attempts to connect to the RIC, which closes the connection and ends the attempts
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return errors.NewCanceled("e2 agent %d is stopped", a.node.GnbID)
 	}
-	return nil
+	a.connectionStore = connectionStore
+	a.e2Connection = e2Connection
+	a.mu.Unlock()
+
+	return e2Connection.Setup()
 }
 
 func (a *e2Agent) Stop() error {
+	return a.Shutdown(context.Background())
+}
+
+// Shutdown cancels the subscriptions of the agent, so that it stops sending indications, and closes its connections.
+// Pending procedures are abandoned once the context is done; the connections are closed regardless.
+func (a *e2Agent) Shutdown(ctx context.Context) error {
+	return a.stop(ctx)
+}
+
+// Abort cancels the subscriptions of the agent and closes its connections as a failing node would, so that the RIC
+// only learns of it from the lost connections
+func (a *e2Agent) Abort() error {
+	return a.stop(context.Background())
+}
+
+// stop stops the agent within the deadline of the given context
+func (a *e2Agent) stop(ctx context.Context) error {
 	log.Debugf("Stopping e2 agent with ID %d:", a.node.GnbID)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	conns := a.connectionStore.List(context.Background())
+	var shutdownErr error
+	a.mu.Lock()
+	a.stopped = true
+	e2Connection, connectionStore := a.e2Connection, a.connectionStore
+	a.mu.Unlock()
+
+	subs, err := a.subStore.List()
+	if err != nil {
+		return err
+	}
+
+	// Cancels all of the subscriptions and stops sending indications
+	for _, sub := range subs {
+		log.Debugf("Cancelling subscription: %s", sub.ID)
+		if err := sub.Stop(ctx); err != nil {
+			log.Warn(err)
+			shutdownErr = err
+		}
+		if err := a.subStore.Remove(sub.ID); err != nil {
+			return err
+		}
+	}
+
+	// Closing the connection set up by the agent also ends its attempts to connect to the RIC
+	if e2Connection != nil {
+		if err := e2Connection.Close(); err != nil {
+			log.Warn(err)
+			shutdownErr = err
+		}
+	}
+	if connectionStore == nil {
+		return shutdownErr
+	}
+	conns := connectionStore.List(context.Background())
 	log.Debugf("List of Connections: %+v", conns)
 	for _, conn := range conns {
 		if conn.Client != nil {
 			log.Debugf("Closing connection: %+v", conn.ID)
-			err := conn.Client.Close()
-			if err != nil {
-				return err
+			if err := conn.Client.Close(); err != nil {
+				log.Warn(err)
+				shutdownErr = err
 			}
-			err = a.connectionStore.Remove(ctx, conn.ID)
-			if err != nil {
+			if err := connectionStore.Remove(context.Background(), conn.ID); err != nil {
 				return err
 			}
 		}
 
 	}
-	return nil
+	return shutdownErr
+}
+
+func (a *e2Agent) RunningSubscriptions() int {
+	return a.subStore.Running()
+}
+
+func (a *e2Agent) Subscriptions() ([]*subscriptions.Subscription, error) {
+	return a.subStore.List()
 }
 
 var _ E2Agent = &e2Agent{}
@@ -6,6 +6,10 @@ package agents
 
 import (
 	"context"
+	"sync"
+	"time"
+
+	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 
 	"github.com/onosproject/rrm-son-lib/pkg/handover"
 
@@ -15,6 +19,7 @@ import (
 
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/ran-simulator/pkg/e2agent"
 	"github.com/onosproject/ran-simulator/pkg/model"
@@ -22,6 +27,7 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/store/agents"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 )
 
@@ -38,6 +44,10 @@ type E2Agents struct {
 	model               *model.Model
 	a3Chan              chan handover.A3HandoverDecision
 	mobilityDriver      mobility.Driver
+	mu                  sync.Mutex
+	cancel              context.CancelFunc
+	controllers         map[types.GnbID]string
+	restarting          map[types.GnbID]bool
 }
 
 // Agents agents interface
@@ -45,82 +55,143 @@ type Agents interface {
 	Start() error
 
 	Stop() error
+
+	Shutdown(ctx context.Context) error
+
+	Restart(ctx context.Context, gnbID types.GnbID, bootTime time.Duration) error
+
+	Subscriptions() map[types.GnbID][]*subscriptions.Subscription
+
+	Subscription(gnbID types.GnbID, id subscriptions.ID) (*subscriptions.Subscription, error)
+
+	InjectIndication(ctx context.Context, gnbID types.GnbID, id subscriptions.ID, indication e2agent.Indication) error
 }
 
-func (agents *E2Agents) processNodeEvents() {
-	ch := make(chan event.Event)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	err := agents.nodeStore.Watch(ctx, ch)
-	if err != nil {
-		log.Error(err)
-	}
+// processNodeEvents starts an E2 agent for every node added to the node store, re-homes the agent
+// of every node updated with a different controller and stops the agent of every node deleted from
+// it until the node events channel is closed
+func (agents *E2Agents) processNodeEvents(ch <-chan event.Event) {
 	for nodeEvent := range ch {
 		log.Debug("Received Node event:", nodeEvent)
 		switch nodeEvent.Type {
 		case nodes.Created:
 			node := nodeEvent.Value.(*model.Node)
-			log.Debugf("Starting e2 agent %d", nodeEvent.Key.(types.GnbID))
-			e2Node, err := e2agent.NewE2Agent(*node, agents.model,
-				agents.modelPluginRegistry, agents.nodeStore, agents.ueStore,
-				agents.cellStore, agents.metricStore, agents.a3Chan, agents.mobilityDriver)
-			if err != nil {
-				log.Error(err)
-				continue
-			}
-			err = agents.agentStore.Add(node.GnbID, e2Node)
-			if err != nil {
-				log.Error(err)
-			}
-
-			err = e2Node.Start()
-			if err != nil {
+			if err := agents.startAgent(*node); err != nil {
 				log.Error(err)
-				err = agents.agentStore.Remove(node.GnbID)
-				if err != nil {
-					log.Error(err)
-				}
 			}
-			err = agents.nodeStore.SetStatus(context.Background(), node.GnbID, "Running")
-			if err != nil {
+		case nodes.Updated:
+			node := nodeEvent.Value.(*model.Node)
+			if err := agents.rehomeAgent(*node); err != nil {
 				log.Error(err)
 			}
-
 		case nodes.Deleted:
-			log.Debugf("Stopping e2 agent %d", nodeEvent.Key.(types.GnbID))
 			node := nodeEvent.Value.(*model.Node)
-			e2Node, err := agents.agentStore.Get(node.GnbID)
-			if err != nil {
+			if err := agents.stopAgent(node.GnbID); err != nil {
 				log.Error(err)
-				continue
-			}
-			err = e2Node.Stop()
-			if err != nil {
-				log.Error(err)
-				continue
 			}
+		}
+	}
+}
 
-			err = agents.nodeStore.SetStatus(context.Background(), node.GnbID, "Stopped")
-			if err != nil {
-				log.Error(err)
-			}
+// startAgent creates the service models and the E2 agent of the given node and starts the agent,
+// unless the node already has an agent; the agent is registered before it is started, without holding
+// the lock while it connects to the RIC, which is retried until it succeeds or the agent is stopped
+func (agents *E2Agents) startAgent(node model.Node) error {
+	agents.mu.Lock()
+	if _, err := agents.agentStore.Get(node.GnbID); err == nil {
+		agents.mu.Unlock()
+		return nil
+	}
+
+	log.Debugf("Starting e2 agent %d", node.GnbID)
+	e2Node, err := e2agent.NewE2Agent(node, agents.model,
+		agents.modelPluginRegistry, agents.nodeStore, agents.ueStore,
+		agents.cellStore, agents.metricStore, agents.a3Chan, agents.mobilityDriver)
+	if err != nil {
+		agents.mu.Unlock()
+		return err
+	}
+	err = agents.agentStore.Add(node.GnbID, e2Node)
+	if err != nil {
+		agents.mu.Unlock()
+		return err
+	}
+	agents.controllers[node.GnbID] = controllerOf(node)
+	agents.mu.Unlock()
 
-			err = agents.agentStore.Remove(node.GnbID)
-			if err != nil {
+	err = e2Node.Start()
+	if err != nil {
+		// The agent may have been stopped, or even replaced, in the meantime
+		agents.mu.Lock()
+		if current, getErr := agents.agentStore.Get(node.GnbID); getErr == nil && current == e2Node {
+			delete(agents.controllers, node.GnbID)
+			if err := agents.agentStore.Remove(node.GnbID); err != nil {
 				log.Error(err)
 			}
-
 		}
+		agents.mu.Unlock()
+		return err
 	}
+	return agents.nodeStore.SetStatus(context.Background(), node.GnbID, "Running")
 }
 
-// NewE2Agents creates a new collection of E2 agents from the specified list of nodes
+// stopAgent stops the E2 agent of the given node and removes it from the agent store
+func (agents *E2Agents) stopAgent(gnbID types.GnbID) error {
+	agents.mu.Lock()
+	defer agents.mu.Unlock()
+	e2Node, err := agents.agentStore.Get(gnbID)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Stopping e2 agent %d", gnbID)
+	err = e2Node.Stop()
+	if err != nil {
+		return err
+	}
+	delete(agents.controllers, gnbID)
+	return agents.agentStore.Remove(gnbID)
+}
+
+// rehomeAgent restarts the E2 agent of the given node if the node is now served by another controller
+// than the one its agent is connected to
+func (agents *E2Agents) rehomeAgent(node model.Node) error {
+	agents.mu.Lock()
+	controller, ok := agents.controllers[node.GnbID]
+	agents.mu.Unlock()
+	if !ok || controller == controllerOf(node) {
+		return nil
+	}
+
+	log.Infof("Re-homing e2 agent %d from controller %s to %s", node.GnbID, controller, controllerOf(node))
+	if err := agents.stopAgent(node.GnbID); err != nil {
+		return err
+	}
+	return agents.startAgent(node)
+}
+
+// SetModel sets the model the agents started from now on are created with; the running agents keep the model they
+// were started with
+func (agents *E2Agents) SetModel(m *model.Model) {
+	agents.mu.Lock()
+	defer agents.mu.Unlock()
+	agents.model = m
+}
+
+// controllerOf returns the controller the E2 agent of the given node connects to
+func controllerOf(node model.Node) string {
+	if len(node.Controllers) == 0 {
+		return ""
+	}
+	return node.Controllers[0]
+}
+
+// NewE2Agents creates a new collection of E2 agents managing the nodes of the specified node store
 func NewE2Agents(m *model.Model, modelPluginRegistry modelplugins.ModelRegistry,
 	nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store, metricStore metrics.Store,
 	a3Chan chan handover.A3HandoverDecision, mobilityDriver mobility.Driver) (*E2Agents, error) {
-	agentStore := agents.NewStore()
 	e2agents := &E2Agents{
-		agentStore:          agentStore,
+		agentStore:          agents.NewStore(),
 		nodeStore:           nodeStore,
 		modelPluginRegistry: modelPluginRegistry,
 		model:               m,
@@ -129,41 +200,35 @@ func NewE2Agents(m *model.Model, modelPluginRegistry modelplugins.ModelRegistry,
 		metricStore:         metricStore,
 		a3Chan:              a3Chan,
 		mobilityDriver:      mobilityDriver,
+		controllers:         make(map[types.GnbID]string),
+		restarting:          make(map[types.GnbID]bool),
 	}
-
-	for _, node := range m.Nodes {
-		e2Node, err := e2agent.NewE2Agent(node, m, modelPluginRegistry, nodeStore, ueStore, cellStore, metricStore, a3Chan, mobilityDriver)
-		if err != nil {
-			log.Error(err)
-			return nil, err
-		}
-
-		err = agentStore.Add(node.GnbID, e2Node)
-		if err != nil {
-			log.Error(err)
-			return nil, err
-		}
-		err = nodeStore.SetStatus(context.Background(), node.GnbID, "Running")
-		if err != nil {
-			log.Error(err)
-			return nil, err
-		}
-	}
-	go e2agents.processNodeEvents()
 	return e2agents, nil
 }
 
-// Start all simulated node agents
+// Start starts the agents of all simulated nodes and keeps starting and stopping agents as nodes
+// are added to and deleted from the node store
 func (agents *E2Agents) Start() error {
 	log.Info("Starting E2 Agents")
-	agentList, err := agents.agentStore.List()
+	// Watch before listing, so that no node added in between is missed; starting an agent twice is a no-op
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan event.Event)
+	err := agents.nodeStore.Watch(ctx, ch)
+	if err != nil {
+		cancel()
+		return err
+	}
+	agents.cancel = cancel
+	go agents.processNodeEvents(ch)
+
+	nodeList, err := agents.nodeStore.List(ctx)
 	if err != nil {
 		log.Error(err)
 		return err
 	}
-	for id, agent := range agentList {
-		log.Debug("Starting agent with e2 node ID:", id)
-		err := agent.Start()
+	for _, node := range nodeList {
+		log.Debug("Starting agent with e2 node ID:", node.GnbID)
+		err := agents.startAgent(*node)
 		if err != nil {
 			return err
 		}
@@ -171,22 +236,243 @@ func (agents *E2Agents) Start() error {
 	return nil
 }
 
-// Stop all simulated node agents
+// Stop stops watching the node store and all simulated node agents
 func (agents *E2Agents) Stop() error {
 	log.Info("Stopping E2 Agents")
+	if agents.cancel != nil {
+		agents.cancel()
+	}
+	agents.mu.Lock()
 	agentList, err := agents.agentStore.List()
 	if err != nil {
+		agents.mu.Unlock()
 		log.Error(err)
 		return err
 	}
-	for id, agent := range agentList {
+	ids := make([]types.GnbID, 0, len(agentList))
+	for id := range agentList {
+		ids = append(ids, id)
+	}
+	agents.mu.Unlock()
+
+	for _, id := range ids {
 		log.Debug("Stopping agent with e2 node ID:", id)
-		err := agent.Stop()
+		err := agents.stopAgent(id)
 		if err != nil {
 			return err
 		}
+		err = agents.nodeStore.SetStatus(context.Background(), id, "Stopped")
+		if err != nil {
+			log.Error(err)
+		}
 	}
 	return nil
 }
 
+// Shutdown stops watching the node store and stops all simulated node agents concurrently, so that thousands of
+// nodes are removed from the RIC within the deadline of the given context; it returns once all agents have stopped
+// or the context is done. The agents are listed from a snapshot of the agent store rather than under the lock of
+// the agents, which an agent being stopped may hold past the deadline.
+func (agents *E2Agents) Shutdown(ctx context.Context) error {
+	log.Info("Shutting down E2 Agents")
+	if agents.cancel != nil {
+		agents.cancel()
+	}
+	e2Nodes, err := agents.agentStore.List()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for id, e2Node := range e2Nodes {
+		wg.Add(1)
+		go func(id types.GnbID, e2Node e2agent.E2Agent) {
+			defer wg.Done()
+			log.Debug("Shutting down agent with e2 node ID:", id)
+			if err := e2Node.Shutdown(ctx); err != nil {
+				log.Warnf("Shutting down agent with e2 node ID %d failed: %v", id, err)
+			}
+			if err := agents.nodeStore.SetStatus(context.Background(), id, "Stopped"); err != nil {
+				log.Error(err)
+			}
+		}(id, e2Node)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.NewTimeout("not all E2 agents have stopped before the shutdown deadline")
+	}
+}
+
+// Restart simulates a warm restart of the given node: its agent is aborted without notifying the RIC, its subscriptions
+// are lost, its connected UEs are released to idle and its cells are off the air for the given boot time, after which
+// a new agent of the node connects and sets up E2 again with the same identities. Restart returns once the agent has
+// been aborted; the node is reported as restarting until the new agent is running.
+func (agents *E2Agents) Restart(ctx context.Context, gnbID types.GnbID, bootTime time.Duration) error {
+	node, err := agents.nodeStore.Get(ctx, gnbID)
+	if err != nil {
+		return err
+	}
+	agents.mu.Lock()
+	if agents.restarting[gnbID] {
+		agents.mu.Unlock()
+		return errors.NewConflict("node %d is already restarting", gnbID)
+	}
+	e2Node, err := agents.agentStore.Get(gnbID)
+	if err != nil {
+		agents.mu.Unlock()
+		return err
+	}
+	log.Infof("Restarting e2 agent %d with a boot time of %s", gnbID, bootTime)
+	if err := e2Node.Abort(); err != nil {
+		log.Warn(err)
+	}
+	delete(agents.controllers, gnbID)
+	if err := agents.agentStore.Remove(gnbID); err != nil {
+		agents.mu.Unlock()
+		return err
+	}
+	agents.restarting[gnbID] = true
+	agents.mu.Unlock()
+
+	if err := agents.nodeStore.SetStatus(ctx, gnbID, "Restarting"); err != nil {
+		log.Warn(err)
+	}
+	failed := agents.powerOff(ctx, node.Cells)
+	go agents.boot(gnbID, failed, bootTime)
+	return nil
+}
+
+// powerOff releases the connected UEs of the given cells to idle and puts the cells which are in service out of
+// service; it returns the cells it put out of service
+func (agents *E2Agents) powerOff(ctx context.Context, ncgis []types.NCGI) []types.NCGI {
+	var failed []types.NCGI
+	for _, ncgi := range ncgis {
+		for _, ue := range agents.ueStore.ListUEs(ctx, ncgi) {
+			if ue.RrcState == mho.Rrcstatus_RRCSTATUS_IDLE {
+				continue
+			}
+			if err := agents.ueStore.UpdateRrcState(ctx, ue.IMSI, mho.Rrcstatus_RRCSTATUS_IDLE); err != nil {
+				log.Warn(err)
+			}
+		}
+		cell, err := agents.cellStore.Get(ctx, ncgi)
+		if err != nil || cell.Failed {
+			continue
+		}
+		if err := agents.cellStore.SetFailed(ctx, ncgi, true); err != nil {
+			log.Warn(err)
+			continue
+		}
+		failed = append(failed, ncgi)
+	}
+	return failed
+}
+
+// boot puts the given cells back in service once the boot time has elapsed and starts a new agent of the node,
+// unless the node has been deleted in the meantime
+func (agents *E2Agents) boot(gnbID types.GnbID, failed []types.NCGI, bootTime time.Duration) {
+	defer func() {
+		agents.mu.Lock()
+		delete(agents.restarting, gnbID)
+		agents.mu.Unlock()
+	}()
+	time.Sleep(bootTime)
+
+	ctx := context.Background()
+	for _, ncgi := range failed {
+		if err := agents.cellStore.SetFailed(ctx, ncgi, false); err != nil {
+			log.Warn(err)
+		}
+	}
+	node, err := agents.nodeStore.Get(ctx, gnbID)
+	if err != nil {
+		log.Infof("Node %d has been deleted while restarting", gnbID)
+		return
+	}
+	log.Infof("Node %s has booted", node.DisplayName())
+	if err := agents.startAgent(*node); err != nil {
+		log.Warnf("Starting e2 agent %d after its restart failed: %v", gnbID, err)
+		if err := agents.nodeStore.SetStatus(ctx, gnbID, "Stopped"); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// RunningSubscriptions returns the number of subscriptions whose reporting routine is running for each agent
+func (agents *E2Agents) RunningSubscriptions() map[types.GnbID]int {
+	agents.mu.Lock()
+	defer agents.mu.Unlock()
+	agentList, err := agents.agentStore.List()
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	running := make(map[types.GnbID]int, len(agentList))
+	for id, e2Node := range agentList {
+		running[id] = e2Node.RunningSubscriptions()
+	}
+	return running
+}
+
+// Subscriptions returns the subscriptions of each running agent
+func (agents *E2Agents) Subscriptions() map[types.GnbID][]*subscriptions.Subscription {
+	agents.mu.Lock()
+	defer agents.mu.Unlock()
+	agentList, err := agents.agentStore.List()
+	if err != nil {
+		log.Error(err)
+		return nil
+	}
+	subs := make(map[types.GnbID][]*subscriptions.Subscription, len(agentList))
+	for id, e2Node := range agentList {
+		nodeSubs, err := e2Node.Subscriptions()
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		subs[id] = nodeSubs
+	}
+	return subs
+}
+
+// Subscription returns the subscription of the agent of the given node with the given ID
+func (agents *E2Agents) Subscription(gnbID types.GnbID, id subscriptions.ID) (*subscriptions.Subscription, error) {
+	agents.mu.Lock()
+	e2Node, err := agents.agentStore.Get(gnbID)
+	agents.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	subs, err := e2Node.Subscriptions()
+	if err != nil {
+		return nil, err
+	}
+	for _, sub := range subs {
+		if sub.ID == id {
+			return sub, nil
+		}
+	}
+	return nil, errors.NewNotFound("subscription %s of node %d not found", id, gnbID)
+}
+
+// InjectIndication sends a custom indication to the subscription of the agent of the given node with the given ID
+func (agents *E2Agents) InjectIndication(ctx context.Context, gnbID types.GnbID, id subscriptions.ID, indication e2agent.Indication) error {
+	agents.mu.Lock()
+	e2Node, err := agents.agentStore.Get(gnbID)
+	agents.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return e2Node.InjectIndication(ctx, id, indication)
+}
+
 var _ Agents = &E2Agents{}
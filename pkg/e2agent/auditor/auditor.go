@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package auditor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/ran-simulator/pkg/audit"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
+	setuputils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/setup"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+)
+
+// Parameters of the audited procedures
+const (
+	parameterRequestorID   = "ricRequestorId"
+	parameterInstanceID    = "ricInstanceId"
+	parameterRanFunctionID = "ranFunctionId"
+	parameterActions       = "actions"
+	parameterTransactionID = "transactionId"
+	parameterAccepted      = "ranFunctionsAccepted"
+	parameterRejected      = "ranFunctionsRejected"
+	parameterEventTrigger  = "eventTriggerSize"
+)
+
+// Auditor appends the E2 setup, subscription and control procedures of an E2 node with a RIC to an audit log
+type Auditor struct {
+	node       string
+	controller string
+	log        *audit.Log
+}
+
+// New creates an auditor of the procedures of the given E2 node with the RIC at the given address
+func New(node model.Node, controller string, log *audit.Log) *Auditor {
+	return &Auditor{
+		node:       fmt.Sprintf("%d", node.GnbID),
+		controller: controller,
+		log:        log,
+	}
+}
+
+// WrapClientConn returns an E2 channel auditing the procedures initiated by the E2 node
+func (a *Auditor) WrapClientConn(conn e2.ClientConn) e2.ClientConn {
+	return &clientConn{
+		ClientConn: conn,
+		auditor:    a,
+	}
+}
+
+// WrapHandler returns an E2 handler auditing the procedures initiated by the RIC
+func (a *Auditor) WrapHandler(handler e2.ClientInterface) e2.ClientInterface {
+	return &clientHandler{
+		ClientInterface: handler,
+		auditor:         a,
+	}
+}
+
+// record appends the outcome of a procedure to the log; the cause of an unsuccessful outcome is taken from the
+// failure message by the given function, if any
+func (a *Auditor) record(initiator string, procedure string, start time.Time, parameters map[string]string, err error, failed bool, cause func() (*e2apies.Cause, error)) {
+	entry := audit.Entry{
+		Time:       start,
+		Node:       a.node,
+		Controller: a.controller,
+		Initiator:  initiator,
+		Procedure:  procedure,
+		Parameters: parameters,
+		Outcome:    audit.OutcomeSuccess,
+		Duration:   time.Since(start),
+	}
+	switch {
+	case err != nil:
+		entry.Outcome = audit.OutcomeError
+		entry.Cause = err.Error()
+	case failed:
+		entry.Outcome = audit.OutcomeFailure
+		if cause != nil {
+			if c, err := cause(); err == nil {
+				entry.Cause = c.String()
+			}
+		}
+	}
+	a.log.Append(entry)
+}
+
+// requestParameters returns the parameters identifying the RIC request and RAN function of a procedure
+func requestParameters(requestorID *int32, instanceID *int32, ranFunctionID *int32) map[string]string {
+	parameters := make(map[string]string)
+	if requestorID != nil {
+		parameters[parameterRequestorID] = strconv.Itoa(int(*requestorID))
+	}
+	if instanceID != nil {
+		parameters[parameterInstanceID] = strconv.Itoa(int(*instanceID))
+	}
+	if ranFunctionID != nil {
+		parameters[parameterRanFunctionID] = strconv.Itoa(int(*ranFunctionID))
+	}
+	return parameters
+}
+
+type clientConn struct {
+	e2.ClientConn
+	auditor *Auditor
+}
+
+func (c *clientConn) E2Setup(ctx context.Context, request *e2appducontents.E2SetupRequest) (*e2appducontents.E2SetupResponse, *e2appducontents.E2SetupFailure, error) {
+	start := time.Now()
+	response, failure, err := c.ClientConn.E2Setup(ctx, request)
+	parameters := make(map[string]string)
+	if response != nil {
+		if transactionID, err := setuputils.GetTransactionID(response); err == nil {
+			parameters[parameterTransactionID] = strconv.Itoa(int(transactionID))
+		}
+		parameters[parameterAccepted] = strconv.Itoa(len(setuputils.GetRanFunctionsAccepted(response)))
+		parameters[parameterRejected] = strconv.Itoa(len(setuputils.GetRanFunctionsRejected(response)))
+	} else if failure != nil {
+		if transactionID, err := setuputils.GetFailureTransactionID(failure); err == nil {
+			parameters[parameterTransactionID] = strconv.Itoa(int(transactionID))
+		}
+	}
+	c.auditor.record(audit.InitiatorNode, "E2Setup", start, parameters, err, failure != nil, func() (*e2apies.Cause, error) {
+		return setuputils.GetFailureCause(failure)
+	})
+	return response, failure, err
+}
+
+type clientHandler struct {
+	e2.ClientInterface
+	auditor *Auditor
+}
+
+func (h *clientHandler) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (*e2appducontents.E2ConnectionUpdateAcknowledge, *e2appducontents.E2ConnectionUpdateFailure, error) {
+	start := time.Now()
+	response, failure, err := h.ClientInterface.E2ConnectionUpdate(ctx, request)
+	h.auditor.record(audit.InitiatorRIC, "E2ConnectionUpdate", start, nil, err, failure != nil, nil)
+	return response, failure, err
+}
+
+func (h *clientHandler) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+	start := time.Now()
+	requestorID, _ := subutils.GetRequesterID(request)
+	instanceID, _ := subutils.GetRicInstanceID(request)
+	ranFunctionID, _ := subutils.GetRanFunctionID(request)
+	parameters := requestParameters(requestorID, instanceID, ranFunctionID)
+	parameters[parameterActions] = strconv.Itoa(len(subutils.GetRicActionToBeSetupList(request)))
+	parameters[parameterEventTrigger] = strconv.Itoa(len(subutils.GetRicEventTriggerDefinition(request)))
+
+	response, failure, err := h.ClientInterface.RICSubscription(ctx, request)
+	h.auditor.record(audit.InitiatorRIC, "RICSubscription", start, parameters, err, failure != nil, func() (*e2apies.Cause, error) {
+		return subutils.GetFailureCause(failure)
+	})
+	return response, failure, err
+}
+
+func (h *clientHandler) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (*e2appducontents.RicsubscriptionDeleteResponse, *e2appducontents.RicsubscriptionDeleteFailure, error) {
+	start := time.Now()
+	requestorID, _ := subdeleteutils.GetRequesterID(request)
+	instanceID, _ := subdeleteutils.GetRicInstanceID(request)
+	ranFunctionID, _ := subdeleteutils.GetRanFunctionID(request)
+	parameters := requestParameters(requestorID, instanceID, ranFunctionID)
+
+	response, failure, err := h.ClientInterface.RICSubscriptionDelete(ctx, request)
+	h.auditor.record(audit.InitiatorRIC, "RICSubscriptionDelete", start, parameters, err, failure != nil, func() (*e2apies.Cause, error) {
+		return subdeleteutils.GetFailureCause(failure)
+	})
+	return response, failure, err
+}
+
+func (h *clientHandler) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (*e2appducontents.RiccontrolAcknowledge, *e2appducontents.RiccontrolFailure, error) {
+	start := time.Now()
+	requestorID, _ := controlutils.GetRequesterID(request)
+	instanceID, _ := controlutils.GetRicInstanceID(request)
+	ranFunctionID, _ := controlutils.GetRanFunctionID(request)
+	parameters := requestParameters(requestorID, instanceID, ranFunctionID)
+
+	response, failure, err := h.ClientInterface.RICControl(ctx, request)
+	h.auditor.record(audit.InitiatorRIC, "RICControl", start, parameters, err, failure != nil, func() (*e2apies.Cause, error) {
+		return controlutils.GetFailureCause(failure)
+	})
+	return response, failure, err
+}
@@ -6,26 +6,29 @@ package connection
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"time"
 
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdubuilder"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 	e2apcommondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
 
-	"github.com/onosproject/ran-simulator/pkg/servicemodel/kpm2"
-
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 
 	connectionsetupfaileditem "github.com/onosproject/ran-simulator/pkg/utils/e2ap/connectionupdate/connectionSetupFailedItemie"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 
 	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/transactions"
 
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 
 	"github.com/onosproject/ran-simulator/pkg/store/connections"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 
 	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/connectionupdate/connectionUpdateitemie"
 
@@ -33,14 +36,10 @@ import (
 
 	"github.com/cenkalti/backoff"
 
-	"github.com/onosproject/ran-simulator/pkg/servicemodel/kpm"
-	"github.com/onosproject/ran-simulator/pkg/servicemodel/mho"
-	"github.com/onosproject/ran-simulator/pkg/servicemodel/rc"
 	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
 
-	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/setup"
 
@@ -51,6 +50,7 @@ import (
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 )
 
 var log = logging.GetLogger("e2agent", "connection")
@@ -75,7 +75,14 @@ type e2Connection struct {
 	registry        *registry.ServiceModelRegistry
 	subStore        *subscriptions.Subscriptions
 	connectionStore connections.Store
+	nodeStore       nodes.Store
 	ricAddress      addressing.RICAddress
+	tlsConfig       *tls.Config
+	transactions    *transactions.Transactions
+	logPrefix       string
+	// ctx is cancelled once the connection is closed, so that it stops attempting to connect to the RIC
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // SetClient sets E2 client
@@ -95,6 +102,10 @@ func NewE2Connection(opts ...InstanceOption) E2Connection {
 	for _, option := range opts {
 		option(instanceOptions)
 	}
+	if instanceOptions.transactions == nil {
+		instanceOptions.transactions = transactions.NewTransactions()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	return &e2Connection{
 		model:           instanceOptions.model,
 		node:            instanceOptions.node,
@@ -102,14 +113,20 @@ func NewE2Connection(opts ...InstanceOption) E2Connection {
 		subStore:        instanceOptions.subStore,
 		ricAddress:      instanceOptions.ricAddress,
 		connectionStore: instanceOptions.connectionStore,
+		nodeStore:       instanceOptions.nodeStore,
 		client:          instanceOptions.e2Client,
+		tlsConfig:       instanceOptions.tlsConfig,
+		transactions:    instanceOptions.transactions,
+		logPrefix:       logfields.Node(instanceOptions.node.GnbID),
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 
 }
 
 // E2ConnectionUpdate implements E2 connection update procedure
 func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (response *e2appducontents.E2ConnectionUpdateAcknowledge, failure *e2appducontents.E2ConnectionUpdateFailure, err error) {
-	log.Info("Received Connection Update request %v", request)
+	log.Infof("%s: Received Connection Update request %v", e.logPrefix, request)
 	connectionUpdateItemIes := make([]*e2appducontents.E2ConnectionUpdateItemIes, 0)
 	connectionSetupFailedItemIes := make([]*e2appducontents.E2ConnectionSetupFailedItemIes, 0)
 
@@ -152,7 +169,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 	//  then the E2 Node shall, if supported, use it to establish additional TNL Association(s) and configure
 	// for use for RIC services and/or E2 support functions according to the TNL Association Usage IE in the message.
 	if ies44 != nil {
-		log.Debugf("Adding new connections: %+v", ies44.GetValue())
+		log.Debugf("%s: Adding new connections: %+v", e.logPrefix, ies44.GetValue())
 		connectionUpdateItems := ies44.GetValue()
 		for _, connectionUpdateItem := range connectionUpdateItems {
 			tnlInfo := connectionUpdateItem.GetValue().GetE2Curi().GetTnlInformation()
@@ -160,7 +177,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 			// TODO handle tnlUsage
 
 			ricAddress = e.getRICAddress(tnlInfo)
-			log.Debugf("RIC and IP and Port information: %v:%v", ricAddress.IPAddress, ricAddress.Port)
+			log.Debugf("%s: RIC and IP and Port information: %v:%v", e.logPrefix, ricAddress.IPAddress, ricAddress.Port)
 
 			if ricAddress.IPAddress == nil {
 				cause := &e2apies.Cause{
@@ -182,7 +199,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 			connectionID := connections.NewConnectionID(ricAddress.IPAddress.String(), ricAddress.Port)
 			_, err := e.connectionStore.Get(ctx, connectionID)
 			if err == nil {
-				log.Debugf("Connection %s does exist", connectionID)
+				log.Debugf("%s: Connection %s does exist", e.logPrefix, connectionID)
 				continue
 			}
 
@@ -217,7 +234,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 
 	// remove connections
 	if ies46 != nil {
-		log.Debugf("Removing connections: %+v", ies46)
+		log.Debugf("%s: Removing connections: %+v", e.logPrefix, ies46)
 		connectionUpdateRemoveItems := ies46.GetValue()
 		for _, connectionUpdateRemoveItem := range connectionUpdateRemoveItems {
 			tnlInfo := connectionUpdateRemoveItem.GetValue().GetE2Curi().GetTnlInformation()
@@ -240,7 +257,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 			connection, err := e.connectionStore.Get(ctx, connectionID)
 
 			if err != nil {
-				log.Warn(err)
+				log.Warnf("%s: %v", e.logPrefix, err)
 				if !errors.IsNotFound(err) {
 					cause := &e2apies.Cause{
 						Cause: &e2apies.Cause_Protocol{
@@ -263,7 +280,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 				connection.Status.State = connections.Disconnecting
 				err = e.connectionStore.Update(ctx, connection)
 				if err != nil {
-					log.Warn(err)
+					log.Warnf("%s: %v", e.logPrefix, err)
 					cause := &e2apies.Cause{
 						Cause: &e2apies.Cause_Protocol{
 							Protocol: e2apies.CauseProtocol_CAUSE_PROTOCOL_UNSPECIFIED,
@@ -281,7 +298,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 	}
 	// TODO modifying connections
 	if ies45 != nil {
-		log.Debug("Modifying connections")
+		log.Debugf("%s: Modifying connections", e.logPrefix)
 	}
 
 	// After successful update of E2 interface connection(s), the E2 Node shall reply with the E2 CONNECTION UPDATE ACKNOWLEDGE message to inform
@@ -291,7 +308,7 @@ func (e *e2Connection) E2ConnectionUpdate(ctx context.Context, request *e2appduc
 		connectionupdate.WithConnectionSetupFailedItemIes(connectionSetupFailedItemIes),
 		connectionupdate.WithTransactionID(trID)).
 		BuildConnectionUpdateAcknowledge()
-	log.Infof("Sending Connection Update Ack: %+v", ack)
+	log.Infof("%s: Sending Connection Update Ack: %+v", e.logPrefix, ack)
 	return ack, nil, nil
 }
 
@@ -302,10 +319,10 @@ func (e *e2Connection) RICControl(ctx context.Context, request *e2appducontents.
 	}
 	ranFuncID := registry.RanFunctionID(*rfID)
 
-	log.Debugf("Received Control Request %+v for ran function %d", request, ranFuncID)
+	log.Debugf("%s: Received Control Request %+v for ran function %d", e.logPrefix, request, ranFuncID)
 	sm, err := e.registry.GetServiceModel(ranFuncID)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		// TODO If the target E2 Node receives a RIC CONTROL REQUEST message
 		//  which contains a RAN Function ID IE that was not previously announced as a s
 		//  supported RAN function in the E2 Setup procedure or the RIC Service Update procedure,
@@ -314,32 +331,62 @@ func (e *e2Connection) RICControl(ctx context.Context, request *e2appducontents.
 
 		return nil, nil, err
 	}
-	switch sm.RanFunctionID {
-	case registry.Rcpre2:
-		client := sm.Client.(*rc.Client)
-		response, failure, err = client.RICControl(ctx, request)
-	case registry.Mho:
-		client := sm.Client.(*mho.Mho)
-		response, failure, err = client.RICControl(ctx, request)
+	// Requests are routed by the RAN function ID advertised in the E2 setup
+	var smResponse *e2appducontents.RiccontrolAcknowledge
+	var smFailure *e2appducontents.RiccontrolFailure
+	err = runWithGuardTimer(ctx, e.node.Timers.ControlAck, func(ctx context.Context) error {
+		var err error
+		smResponse, smFailure, err = sm.Client.RICControl(ctx, request)
+		return err
+	})
+	if errors.IsTimeout(err) {
+		log.Warnf("%s: RIC control request for ran function %d is not acknowledged in time: %v", e.logPrefix, ranFuncID, err)
+		return e.controlTimerExpired(request)
 	}
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return response, failure, err
+	return smResponse, smFailure, nil
 }
 
-func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
-	rfID, err := subutils.GetRanFunctionID(request)
+// controlTimerExpired builds the failure sent when a RIC control request is not completed before the control guard timer expires
+func (e *e2Connection) controlTimerExpired(request *e2appducontents.RiccontrolRequest) (*e2appducontents.RiccontrolAcknowledge, *e2appducontents.RiccontrolFailure, error) {
+	reqID, err := controlutils.GetRequesterID(request)
 	if err != nil {
 		return nil, nil, err
 	}
-	registeredRanFuncID := registry.RanFunctionID(*rfID)
-	log.Debugf("Received Subscription Request %v for ran function %d", request, registeredRanFuncID)
-	sm, err := e.registry.GetServiceModel(registeredRanFuncID)
+	ranFuncID, err := controlutils.GetRanFunctionID(request)
 	if err != nil {
 		return nil, nil, err
 	}
+	ricInstanceID, err := controlutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	cause := &e2apies.Cause{
+		Cause: &e2apies.Cause_RicRequest{
+			RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_TIMER_EXPIRED,
+		},
+	}
+	failure, err := controlutils.NewControl(
+		controlutils.WithRanFuncID(*ranFuncID),
+		controlutils.WithRequestID(*reqID),
+		controlutils.WithRicInstanceID(*ricInstanceID),
+		controlutils.WithCause(cause)).BuildControlFailure()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, failure, nil
+}
+
+func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
+	rfID, err := subutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	registeredRanFuncID := registry.RanFunctionID(*rfID)
+	log.Debugf("%s: Received Subscription Request %v for ran function %d", e.logPrefix, request, registeredRanFuncID)
 	rrID, err := subutils.GetRequesterID(request)
 	if err != nil {
 		return nil, nil, err
@@ -363,8 +410,9 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 	if err != nil {
 		return nil, nil, err
 	}
+	sm, err := e.registry.GetServiceModel(registeredRanFuncID)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		// If the target E2 Node receives a RIC SUBSCRIPTION REQUEST
 		//  message which contains a RAN Function ID IE that was not previously
 		//  announced as a supported RAN function in the E2 Setup procedure or
@@ -389,7 +437,7 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 	}
 	subscription, err := subscriptions.NewSubscription(id, request, e.client)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
@@ -408,13 +456,19 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 	}
 	err = e.subStore.Add(subscription)
 	if err != nil {
-		log.Warn(err)
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
 			},
 		}
+		// A subscription with the same RIC request ID and RAN function ID is
+		// already active, so it is rejected as a duplicate
+		if errors.IsAlreadyExists(err) {
+			cause.Cause = &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_DUPLICATE_ACTION,
+			}
+		}
 		subscription := subutils.NewSubscription(
 			subutils.WithRequestID(*reqID),
 			subutils.WithRanFuncID(*ranFuncID),
@@ -427,25 +481,31 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 		return nil, failure, nil
 	}
 
-	// TODO - Assumes ono-to-one mapping between ran function and server model
-	switch sm.RanFunctionID {
-	case registry.Kpm:
-		client := sm.Client.(*kpm.Client)
-		response, failure, err = client.RICSubscription(ctx, request)
-	case registry.Rcpre2:
-		client := sm.Client.(*rc.Client)
-		response, failure, err = client.RICSubscription(ctx, request)
-	case registry.Kpm2:
-		client := sm.Client.(*kpm2.Client)
-		response, failure, err = client.RICSubscription(ctx, request)
-	case registry.Mho:
-		client := sm.Client.(*mho.Mho)
-		response, failure, err = client.RICSubscription(ctx, request)
+	// The node admits a limited number of subscriptions at once, like a real E2 node of finite capacity
+	if count, _ := e.subStore.Len(); e.node.Limits.ExceedsSubscriptions(count) {
+		log.Warnf("%s: Rejecting subscription %s: the node admits at most %d subscriptions", e.logPrefix, id, e.node.Limits.MaxSubscriptions)
+		_ = e.subStore.Remove(id)
+		return overloaded(*reqID, *ranFuncID, *ricInstanceID)
+	}
+
+	// The subscription routines of the node are over budget; the subscription is rejected if load shedding is enabled
+	if running := e.subStore.Running(); e.model.Monitor.ExceedsSubscriptionBudget(running + 1) {
+		if e.model.Monitor.Shed {
+			log.Warnf("%s: Rejecting subscription %s: %d running subscriptions exhaust the budget of %d", e.logPrefix, id, running, e.model.Monitor.SubscriptionBudget)
+			_ = e.subStore.Remove(id)
+			return overloaded(*reqID, *ranFuncID, *ricInstanceID)
+		}
+		log.Warnf("%s: Subscription %s exceeds the budget of %d running subscriptions", e.logPrefix, id, e.model.Monitor.SubscriptionBudget)
+	}
 
+	response, failure, err = sm.Client.RICSubscription(ctx, request)
+	if err != nil || failure != nil {
+		// the subscription is not active, so the RIC can retry it later
+		_ = e.subStore.Remove(id)
 	}
 	// Ric subscription is failed
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
@@ -466,6 +526,24 @@ func (e *e2Connection) RICSubscription(ctx context.Context, request *e2appducont
 	return response, failure, err
 }
 
+// overloaded builds the failure of a subscription rejected because the node is overloaded
+func overloaded(reqID int32, ranFuncID int32, ricInstanceID int32) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+	cause := &e2apies.Cause{
+		Cause: &e2apies.Cause_Misc{
+			Misc: e2apies.CauseMisc_CAUSE_MISC_CONTROL_PROCESSING_OVERLOAD,
+		},
+	}
+	failure, err := subutils.NewSubscription(
+		subutils.WithRequestID(reqID),
+		subutils.WithRanFuncID(ranFuncID),
+		subutils.WithRicInstanceID(ricInstanceID),
+		subutils.WithCause(cause)).BuildSubscriptionFailure()
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, failure, nil
+}
+
 func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
 	var ranFunctionID int32
 	for _, v := range request.GetProtocolIes() {
@@ -476,7 +554,7 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 	}
 
 	ranFuncID := registry.RanFunctionID(ranFunctionID)
-	log.Debugf("Received Subscription Delete Request %v for ran function ID %d", request, ranFuncID)
+	log.Debugf("%s: Received Subscription Delete Request %v for ran function ID %d", e.logPrefix, request, ranFuncID)
 
 	rrID, err := subdeleteutils.GetRequesterID(request)
 	if err != nil {
@@ -494,7 +572,7 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 	subID := subscriptions.NewID(*riID, *rrID, *rfID)
 	_, err = e.subStore.Get(subID)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		//  If the target E2 Node receives a RIC SUBSCRIPTION DELETE REQUEST
 		//  message containing RIC Request ID IE that is not known, the target
 		//  E2 Node shall send the RIC SUBSCRIPTION DELETE FAILURE message
@@ -533,7 +611,7 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 
 	sm, err := e.registry.GetServiceModel(ranFuncID)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", e.logPrefix, err)
 		//  If the target E2 Node receives a RIC SUBSCRIPTION DELETE REQUEST message contains a
 		//  RAN Function ID IE that was not previously announced as a supported RAN function
 		//  in the E2 Setup procedure or the RIC Service Update procedure, the target E2 Node
@@ -569,61 +647,72 @@ func (e *e2Connection) RICSubscriptionDelete(ctx context.Context, request *e2app
 		return nil, failure, nil
 	}
 
-	switch sm.RanFunctionID {
-	case registry.Kpm:
-		client := sm.Client.(*kpm.Client)
-		response, failure, err = client.RICSubscriptionDelete(ctx, request)
-	case registry.Rcpre2:
-		client := sm.Client.(*rc.Client)
-		response, failure, err = client.RICSubscriptionDelete(ctx, request)
-	case registry.Kpm2:
-		client := sm.Client.(*kpm2.Client)
-		response, failure, err = client.RICSubscriptionDelete(ctx, request)
-	case registry.Mho:
-		client := sm.Client.(*mho.Mho)
-		response, failure, err = client.RICSubscriptionDelete(ctx, request)
-
+	var smResponse *e2appducontents.RicsubscriptionDeleteResponse
+	var smFailure *e2appducontents.RicsubscriptionDeleteFailure
+	var smErr error
+	err = runWithGuardTimer(ctx, e.node.Timers.SubscriptionDelete, func(ctx context.Context) error {
+		smResponse, smFailure, smErr = sm.Client.RICSubscriptionDelete(ctx, request)
+		return smErr
+	})
+	if errors.IsTimeout(err) {
+		// The subscription is kept since the service model did not complete the delete procedure
+		log.Warnf("%s: RIC subscription delete request %v is not completed in time: %v", e.logPrefix, subID, err)
+		cause := &e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
+			},
+		}
+		subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
+			subdeleteutils.WithRanFuncID(*rfID),
+			subdeleteutils.WithRequestID(*rrID),
+			subdeleteutils.WithRicInstanceID(*riID),
+			subdeleteutils.WithCause(cause))
+		failure, err := subscriptionDelete.BuildSubscriptionDeleteFailure()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, failure, nil
 	}
 	// Ric subscription delete procedure is failed so we are not going to update subscriptions store
-	if err != nil {
-		log.Warn(err)
-		return response, failure, err
+	if smErr != nil {
+		log.Warnf("%s: %v", e.logPrefix, smErr)
+		return smResponse, smFailure, smErr
 	}
 
 	err = e.subStore.Remove(subID)
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", e.logPrefix, err)
 		return nil, nil, err
 	}
-	return response, failure, err
+	return smResponse, smFailure, nil
 }
 
 func (e *e2Connection) connectAndSetup() error {
-	log.Infof("E2 node %d is starting; attempting to connect", e.node.GnbID)
+	log.Infof("%s: E2 node %s is starting; attempting to connect", e.logPrefix, e.node.DisplayName())
 	b := newExpBackoff()
 
 	// Attempt to connect to the E2T controller; use exponential back-off retry
 	count := 0
 	connectNotify := func(err error, t time.Duration) {
 		count++
-		log.Infof("E2 node %d failed to connect; retry after %v; attempt %d", e.node.GnbID, b.GetElapsedTime(), count)
+		log.Infof("%s: E2 node %s failed to connect; retry after %v; attempt %d", e.logPrefix, e.node.DisplayName(), b.GetElapsedTime(), count)
 	}
 
-	err := backoff.RetryNotify(e.connect, b, connectNotify)
+	err := backoff.RetryNotify(e.connect, backoff.WithContext(b, e.ctx), connectNotify)
 	if err != nil {
 		return err
 	}
-	log.Infof("E2 node %d connected; attempting setup", e.node.GnbID)
+	log.Infof("%s: E2 node %s connected; attempting setup", e.logPrefix, e.node.DisplayName())
 
 	// Attempt to negotiate E2 setup procedure; use exponential back-off retry
 	count = 0
 	setupNotify := func(err error, t time.Duration) {
 		count++
-		log.Infof("E2 node %d failed setup procedure; retry after %v; attempt %d", e.node.GnbID, b.GetElapsedTime(), count)
+		log.Infof("%s: E2 node %s failed setup procedure; retry after %v; attempt %d", e.logPrefix, e.node.DisplayName(), b.GetElapsedTime(), count)
 	}
 
-	err = backoff.RetryNotify(e.setup, b, setupNotify)
-	log.Infof("E2 node %d completed connection setup", e.node.GnbID)
+	err = backoff.RetryNotify(e.setup, backoff.WithContext(b, e.ctx), setupNotify)
+	log.Infof("%s: E2 node %s completed connection setup", e.logPrefix, e.node.DisplayName())
 	return err
 
 }
@@ -633,10 +722,19 @@ func (e *e2Connection) Setup() error {
 	if err != nil {
 		return err
 	}
+	if e.ctx.Err() != nil {
+		_ = e.client.Close()
+		return errors.NewCanceled("E2 connection is closed")
+	}
 
+	// The connection is re-established once lost, unless it has been closed, e.g. by an agent which is stopped or
+	// aborted to simulate a restart and which boots as a new agent with a connection of its own
 	go func() {
 		<-e.client.Context().Done()
-		log.Warn("Context is cancelled, reconnecting...")
+		if e.ctx.Err() != nil {
+			return
+		}
+		log.Warnf("%s: Context is cancelled, reconnecting...", e.logPrefix)
 		err := e.Setup()
 		if err != nil {
 			return
@@ -649,10 +747,10 @@ func (e *e2Connection) Setup() error {
 
 func (e *e2Connection) connect() error {
 	addr := fmt.Sprintf("%s:%d", e.ricAddress.IPAddress.String(), e.ricAddress.Port)
-	log.Info("Connecting to E2T with IP address:", addr)
+	log.Infof("%s: Connecting to E2T with IP address: %s", e.logPrefix, addr)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	client, err := e2.Connect(ctx, addr,
+	client, err := Dial(ctx, addr, e.tlsConfig, e.node,
 		func(channel e2.ClientConn) e2.ClientInterface {
 			return e
 		},
@@ -667,7 +765,11 @@ func (e *e2Connection) connect() error {
 }
 
 func (e *e2Connection) setup() error {
-	plmnID := ransimtypes.NewUint24(uint32(e.model.PlmnID))
+	nodePlmnID, err := e.model.GetNodePlmnID(e.node)
+	if err != nil {
+		return err
+	}
+	plmnID := plmn.ToUint24(nodePlmnID)
 
 	configAdditionList := &e2appducontents.E2NodeComponentConfigAdditionList{
 		Value: make([]*e2appducontents.E2NodeComponentConfigAdditionItemIes, 0),
@@ -702,29 +804,67 @@ func (e *e2Connection) setup() error {
 		configAdditionList.Value = append(configAdditionList.Value, cui)
 	}
 
-	setupRequest := setup.NewSetupRequest(
-		setup.WithRanFunctions(e.registry.GetRanFunctions()),
-		setup.WithPlmnID(plmnID.Value()),
-		setup.WithE2NodeID(uint64(e.node.GnbID)),
-		setup.WithComponentConfigUpdateList(configAdditionList),
-		setup.WithTransactionID(int32(1)))
-
-	e2SetupRequest, err := setupRequest.Build()
-
-	if err != nil {
-		log.Error(err)
+	// All service models are advertised again; those rejected by the RIC are deactivated once it responds
+	e.registry.ActivateServiceModels()
+	ctx := context.Background()
+	if e.node.Timers.SetupResponse > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.node.Timers.SetupResponse)
+		defer cancel()
+	}
+	var e2SetupAck *e2appducontents.E2SetupResponse
+	var e2SetupFailure *e2appducontents.E2SetupFailure
+	err = e.transactions.Do(ctx, transactions.E2Setup, func(ctx context.Context, transactionID int32) (int32, error) {
+		setupRequest := setup.NewSetupRequest(
+			setup.WithRanFunctions(e.registry.GetRanFunctions()),
+			setup.WithPlmnID(plmnID.Value()),
+			setup.WithE2NodeID(uint64(e.node.GnbID)),
+			setup.WithE2NodeType(e.node.GetE2NodeType()),
+			setup.WithE2NodeIDLength(e.node.E2NodeID.Length),
+			setup.WithComponentConfigUpdateList(configAdditionList),
+			setup.WithTransactionID(transactionID))
+
+		e2SetupRequest, err := setupRequest.Build()
+		if err != nil {
+			return 0, err
+		}
+		e2SetupAck, e2SetupFailure, err = e.client.E2Setup(ctx, e2SetupRequest)
+		if err != nil {
+			return 0, err
+		} else if e2SetupFailure != nil {
+			return setup.GetFailureTransactionID(e2SetupFailure)
+		}
+		return setup.GetTransactionID(e2SetupAck)
+	})
+	if err != nil && ctx.Err() == context.DeadlineExceeded {
+		err := errors.NewTimeout("E2 setup response is not received within %v", e.node.Timers.SetupResponse)
+		log.Errorf("%s: %v", e.logPrefix, err)
 		return err
 	}
-	e2SetupAck, e2SetupFailure, err := e.client.E2Setup(context.Background(), e2SetupRequest)
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", e.logPrefix, err)
 		return errors.NewUnknown("E2 setup failed: %v", err)
-	} else if e2SetupFailure != nil {
-		err := errors.NewInvalid("E2 setup failed")
-		log.Error(err)
+	}
+	result := newSetupResult(e.node, plmnID.Value(), e2SetupAck, e2SetupFailure)
+	e.recordSetupResult(result)
+	if e2SetupFailure != nil {
+		err := errors.NewInvalid("E2 setup failed: %s", result.Cause)
+		log.Errorf("%s: %v", e.logPrefix, err)
 		return err
 	}
-	log.Infof("E2 Setup Ack is received:%+v", e2SetupAck)
+	for _, rejected := range result.Rejected {
+		log.Warnf("%s: RAN function %d is rejected by the RIC: %s; deactivating its service model", e.logPrefix, rejected.ID, rejected.Cause)
+		if err := e.registry.DeactivateServiceModel(registry.RanFunctionID(rejected.ID)); err != nil {
+			log.Warnf("%s: %v", e.logPrefix, err)
+		}
+	}
+	// The E2 setup is only retried if the node has no RAN function left to serve
+	if len(result.Rejected) > 0 && len(result.Accepted) == 0 {
+		err := errors.NewInvalid("E2 setup failed: all RAN functions are rejected by the RIC")
+		log.Errorf("%s: %v", e.logPrefix, err)
+		return err
+	}
+	log.Infof("%s: E2 Setup Ack is received:%+v", e.logPrefix, e2SetupAck)
 	// Add connection to the connection store
 	connectionID := connections.NewConnectionID(e.ricAddress.IPAddress.String(), e.ricAddress.Port)
 
@@ -745,9 +885,55 @@ func (e *e2Connection) setup() error {
 	return nil
 }
 
+// newSetupResult returns the outcome of the E2 setup procedure of the given node from the response or failure
+// of the RIC
+func newSetupResult(node model.Node, plmnID ransimtypes.Uint24, response *e2appducontents.E2SetupResponse, failure *e2appducontents.E2SetupFailure) *model.E2SetupResult {
+	result := &model.E2SetupResult{
+		Time:       time.Now(),
+		Successful: failure == nil,
+	}
+	if len(node.Controllers) > 0 {
+		result.Controller = node.Controllers[0]
+	}
+	if failure != nil {
+		if cause, err := setup.GetFailureCause(failure); err == nil {
+			result.Cause = cause.String()
+		}
+	} else {
+		result.GlobalE2NodeID = fmt.Sprintf("%06x:%x", plmnID.Uint32(), uint64(node.GnbID))
+		if ricID, err := setup.GetGlobalRicID(response); err == nil {
+			result.GlobalRicID = fmt.Sprintf("%x:%x", ricID.GetPLmnIdentity().GetValue(), ricID.GetRicId().GetValue())
+		}
+		for _, item := range setup.GetRanFunctionsAccepted(response) {
+			result.Accepted = append(result.Accepted, model.RanFunctionResult{
+				ID:       item.GetValue().GetRfId().GetRanFunctionId().GetValue(),
+				Revision: item.GetValue().GetRfId().GetRanFunctionRevision().GetValue(),
+			})
+		}
+		for _, item := range setup.GetRanFunctionsRejected(response) {
+			result.Rejected = append(result.Rejected, model.RanFunctionResult{
+				ID:    item.GetValue().GetRfIdci().GetRanFunctionId().GetValue(),
+				Cause: item.GetValue().GetRfIdci().GetCause().String(),
+			})
+		}
+	}
+	return result
+}
+
+// recordSetupResult records the outcome of the E2 setup procedure in the node store
+func (e *e2Connection) recordSetupResult(result *model.E2SetupResult) {
+	if e.nodeStore == nil {
+		return
+	}
+	if err := e.nodeStore.SetE2SetupResult(context.Background(), e.node.GnbID, result); err != nil {
+		log.Warnf("%s: %v", e.logPrefix, err)
+	}
+}
+
 func (e *e2Connection) Close() error {
 	connectionID := connections.NewConnectionID(e.ricAddress.IPAddress.String(), e.ricAddress.Port)
-	log.Debugf("Closing E2 connection with ID %d:", connectionID)
+	log.Debugf("%s: Closing E2 connection with ID %d:", e.logPrefix, connectionID)
+	e.cancel()
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -5,11 +5,15 @@
 package connection
 
 import (
+	"crypto/tls"
+
 	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
 	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/transactions"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
 	"github.com/onosproject/ran-simulator/pkg/store/connections"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 )
 
@@ -22,6 +26,9 @@ type InstanceOptions struct {
 	registry        *registry.ServiceModelRegistry
 	subStore        *subscriptions.Subscriptions
 	connectionStore connections.Store
+	nodeStore       nodes.Store
+	tlsConfig       *tls.Config
+	transactions    *transactions.Transactions
 }
 
 // InstanceOption instance option
@@ -75,3 +82,24 @@ func WithConnectionStore(connectionStore connections.Store) func(options *Instan
 		options.connectionStore = connectionStore
 	}
 }
+
+// WithNodeStore sets the node store recording the outcome of the E2 setup procedure
+func WithNodeStore(nodeStore nodes.Store) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.nodeStore = nodeStore
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to secure the E2 connection
+func WithTLSConfig(tlsConfig *tls.Config) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.tlsConfig = tlsConfig
+	}
+}
+
+// WithTransactions sets the transactions of the E2 node
+func WithTransactions(transactions *transactions.Transactions) func(options *InstanceOptions) {
+	return func(options *InstanceOptions) {
+		options.transactions = transactions
+	}
+}
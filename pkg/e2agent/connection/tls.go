@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/audit"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/auditor"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/netem"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/recorder"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/tracer"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/tracing"
+)
+
+// NewTLSConfig creates the TLS configuration used by the given node to connect to the given controller over TCP.
+// It returns nil if the controller does not require a secure transport. The client certificate
+// is taken from the node if present, otherwise from the controller or from the global model settings.
+func NewTLSConfig(m *model.Model, node model.Node, controller model.Controller) (*tls.Config, error) {
+	if !controller.TLS.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName:         controller.Address,
+		InsecureSkipVerify: controller.TLS.InsecureSkipVerify,
+	}
+
+	if controller.TLS.CACert != "" {
+		caCert, err := ioutil.ReadFile(controller.TLS.CACert)
+		if err != nil {
+			return nil, err
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(caCert) {
+			return nil, errors.NewInvalid("failed to parse CA certificate %s", controller.TLS.CACert)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	var clientTLS model.TLSConfig
+	switch {
+	case node.TLS.HasClientCert():
+		clientTLS = node.TLS
+	case controller.TLS.HasClientCert():
+		clientTLS = controller.TLS
+	case m != nil && m.TLS.HasClientCert():
+		clientTLS = m.TLS
+	}
+
+	if clientTLS.HasClientCert() {
+		cert, err := tls.LoadX509KeyPair(clientTLS.Cert, clientTLS.Key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// Dial opens an E2 client connection for the given node to the given address; the connection runs over SCTP, or over
+// TCP secured with TLS if a TLS configuration is given, since TLS is not supported over SCTP. The connection emulates
+// the network conditions of the node if enabled, records the exchanged messages if the node has a recording file,
+// traces the E2 procedures if tracing is enabled and appends the setup, subscription and control procedures to the
+// audit log
+func Dial(ctx context.Context, addr string, tlsConfig *tls.Config, node model.Node, handler func(channel e2.ClientConn) e2.ClientInterface) (e2.ClientConn, error) {
+	emulator := netem.NewEmulator(node.Netem)
+	var rec *recorder.Recorder
+	if node.Record != "" {
+		var err error
+		rec, err = recorder.Open(node.Record)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var trc *tracer.Tracer
+	if tracing.Enabled() {
+		trc = tracer.New(node)
+	}
+	adt := auditor.New(node, addr, audit.Default())
+
+	// The recorder is the closest to the transport so that the recorded timing includes the emulated conditions,
+	// and the tracer and the auditor the farthest so that the traced and audited latency includes them as well
+	conn, err := dial(ctx, addr, tlsConfig, func(channel e2.ClientConn) e2.ClientInterface {
+		h := handler(channel)
+		if emulator != nil {
+			h = emulator.WrapHandler(h)
+		}
+		if rec != nil {
+			h = rec.WrapHandler(h)
+		}
+		if trc != nil {
+			h = trc.WrapHandler(h)
+		}
+		return adt.WrapHandler(h)
+	})
+	if err != nil {
+		if rec != nil {
+			_ = rec.Close()
+		}
+		return nil, err
+	}
+	if rec != nil {
+		conn = rec.WrapClientConn(conn)
+	}
+	if emulator != nil {
+		conn = emulator.WrapClientConn(conn)
+	}
+	if trc != nil {
+		conn = trc.WrapClientConn(conn)
+	}
+	return adt.WrapClientConn(conn), nil
+}
+
+// dial connects over SCTP, or over TCP if a TLS configuration is given; the model loading ensures that the controllers
+// which enable TLS use the TCP transport
+func dial(ctx context.Context, addr string, tlsConfig *tls.Config, handler func(channel e2.ClientConn) e2.ClientInterface) (e2.ClientConn, error) {
+	if tlsConfig == nil {
+		return e2.Connect(ctx, addr, handler)
+	}
+
+	dialer := &tls.Dialer{
+		Config: tlsConfig,
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return e2.NewClientConn(conn, handler), nil
+}
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+// newServerCertificate returns a self-signed certificate for localhost, along with its PEM encoding
+func newServerCertificate(t *testing.T) (tls.Certificate, []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestDialTLS(t *testing.T) {
+	serverCert, serverCertPEM := newServerCertificate(t)
+	clientCAs := x509.NewCertPool()
+	assert.True(t, clientCAs.AppendCertsFromPEM([]byte(certs.OnfCaCrt)))
+	listener, err := tls.Listen("tcp", "localhost:0", &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	})
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	clientCerts := make(chan []*x509.Certificate, 2)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			tlsConn := conn.(*tls.Conn)
+			if err := tlsConn.Handshake(); err == nil {
+				clientCerts <- tlsConn.ConnectionState().PeerCertificates
+			}
+			_ = conn.Close()
+		}
+	}()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.crt")
+	certPath := filepath.Join(dir, "client.crt")
+	keyPath := filepath.Join(dir, "client.key")
+	assert.NoError(t, ioutil.WriteFile(caPath, serverCertPEM, 0600))
+	assert.NoError(t, ioutil.WriteFile(certPath, []byte(certs.DefaultClientCrt), 0600))
+	assert.NoError(t, ioutil.WriteFile(keyPath, []byte(certs.DefaultClientKey), 0600))
+
+	// The node presents the client certificate of the global settings and verifies the server with the CA of the
+	// controller
+	m := &model.Model{TLS: model.TLSConfig{Cert: certPath, Key: keyPath}}
+	controller := model.Controller{
+		Address:   "localhost",
+		Transport: model.TransportTCP,
+		TLS:       model.TLSConfig{Enabled: true, CACert: caPath},
+	}
+	tlsConfig, err := NewTLSConfig(m, model.Node{}, controller)
+	assert.NoError(t, err)
+	handler := func(channel e2.ClientConn) e2.ClientInterface {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := dial(ctx, listener.Addr().String(), tlsConfig, handler)
+	assert.NoError(t, err)
+	select {
+	case peerCerts := <-clientCerts:
+		assert.Len(t, peerCerts, 1)
+	case <-ctx.Done():
+		t.Fatal("the handshake did not complete")
+	}
+	assert.NoError(t, conn.Close())
+
+	// Servers whose certificate is not issued by the CA of the controller are rejected by the handshake
+	tlsConfig.RootCAs = x509.NewCertPool()
+	_, err = dial(ctx, listener.Addr().String(), tlsConfig, handler)
+	assert.Error(t, err)
+
+	// Controllers without TLS get no TLS configuration, and are connected to over SCTP
+	tlsConfig, err = NewTLSConfig(m, model.Node{}, model.Controller{Address: "localhost"})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+	_, err = NewTLSConfig(m, model.Node{}, model.Controller{TLS: model.TLSConfig{Enabled: true, CACert: filepath.Join(dir, "missing.crt")}})
+	assert.Error(t, err)
+}
@@ -5,6 +5,9 @@
 package connection
 
 import (
+	"context"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/ran-simulator/pkg/e2agent/addressing"
 
 	"time"
@@ -47,3 +50,22 @@ func newExpBackoff() *backoff.ExponentialBackOff {
 	b.MaxElapsedTime = 0
 	return b
 }
+
+// runWithGuardTimer runs the handler of an E2AP procedure with a context cancelled once the guard timer expires, and
+// waits for it to return; handlers abandon the procedure without applying it once their context is done. Fails with a
+// timeout error if the handler fails after the timer expired; a handler which completed is never reported as timed
+// out, since its effects are applied. A zero timer disables the guard.
+func runWithGuardTimer(ctx context.Context, timer time.Duration, handler func(ctx context.Context) error) error {
+	if timer <= 0 {
+		return handler(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timer)
+	defer cancel()
+	if err := handler(ctx); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.NewTimeout("guard timer %v expired: %v", timer, err)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package connection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWithGuardTimer(t *testing.T) {
+	ctx := context.Background()
+	completed := false
+	err := runWithGuardTimer(ctx, 0, func(ctx context.Context) error {
+		completed = true
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.True(t, completed)
+
+	err = runWithGuardTimer(ctx, time.Second, func(ctx context.Context) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// Errors of the handler are returned as is while the timer runs
+	err = runWithGuardTimer(ctx, time.Second, func(ctx context.Context) error {
+		return errors.NewInvalid("invalid request")
+	})
+	assert.True(t, errors.IsInvalid(err))
+
+	// Handlers abandoning the procedure once the timer expired time out, and are waited for
+	returned := false
+	err = runWithGuardTimer(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(10 * time.Millisecond)
+		returned = true
+		return ctx.Err()
+	})
+	assert.Error(t, err)
+	assert.True(t, errors.IsTimeout(err))
+	assert.True(t, returned)
+
+	// Handlers which applied the procedure after the timer expired completed it
+	err = runWithGuardTimer(ctx, 10*time.Millisecond, func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return nil
+	})
+	assert.NoError(t, err)
+}
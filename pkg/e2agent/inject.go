@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package e2agent
+
+import (
+	"context"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	indicationutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+)
+
+// Indication custom indication injected into a subscription, e.g. to test the handling of edge case payloads by an
+// xApp
+type Indication struct {
+	// ActionID REPORT action of the subscription the indication is sent to; all its REPORT actions if nil
+	ActionID *e2aptypes.RicActionID
+	Header   []byte
+	Message  []byte
+	// Encoded the header and message are encoded per the service model, e.g. in ASN.1, rather than in protobuf
+	Encoded bool
+}
+
+// InjectIndication sends the indication to the given subscription, once its protobuf header and message are encoded
+// in ASN.1 with the model plugin of the service model of the subscription. The indication is subject to neither the
+// indication rate caps nor to the suspension of the subscription.
+func (a *e2Agent) InjectIndication(ctx context.Context, id subscriptions.ID, indication Indication) error {
+	sub, err := a.subStore.Get(id)
+	if err != nil {
+		return err
+	}
+	sm, err := a.registry.GetServiceModel(registry.RanFunctionID(sub.FnID.GetValue()))
+	if err != nil {
+		return err
+	}
+	header, message := indication.Header, indication.Message
+	if !indication.Encoded {
+		if a.modelPlugins == nil {
+			return errors.NewUnavailable("no model plugin is loaded")
+		}
+		plugin, err := a.modelPlugins.GetPlugin(e2smtypes.OID(sm.OID))
+		if err != nil {
+			return errors.NewUnavailable("no model plugin is loaded for service model %s", sm.ModelName)
+		}
+		if header, err = plugin.IndicationHeaderProtoToASN1(indication.Header); err != nil {
+			return errors.NewInvalid("unable to encode %s indication header: %v", sm.ModelName, err)
+		}
+		if message, err = plugin.IndicationMessageProtoToASN1(indication.Message); err != nil {
+			return errors.NewInvalid("unable to encode %s indication message: %v", sm.ModelName, err)
+		}
+	}
+
+	actions := sub.ReportActions()
+	if indication.ActionID != nil {
+		found := false
+		for _, actionID := range actions {
+			found = found || actionID == *indication.ActionID
+		}
+		if !found {
+			return errors.NewNotFound("REPORT action %d of subscription %s not found", *indication.ActionID, id)
+		}
+		actions = []e2aptypes.RicActionID{*indication.ActionID}
+	}
+	if len(actions) == 0 {
+		return errors.NewInvalid("subscription %s has no REPORT action", id)
+	}
+	for _, actionID := range actions {
+		ricIndication, err := indicationutils.NewIndication(
+			indicationutils.WithRicInstanceID(sub.ReqID.GetRicInstanceId()),
+			indicationutils.WithRanFuncID(sub.FnID.GetValue()),
+			indicationutils.WithRequestID(sub.ReqID.GetRicRequestorId()),
+			indicationutils.WithRicActionID(int32(actionID)),
+			indicationutils.WithIndicationHeader(header),
+			indicationutils.WithIndicationMessage(message)).Build()
+		if err != nil {
+			return err
+		}
+		if err := sub.E2Channel.RICIndication(ctx, ricIndication); err != nil {
+			return errors.NewUnavailable("unable to send indication of subscription %s: %v", id, err)
+		}
+	}
+	log.Infof("Injected indication into subscription %s of node %d", id, a.node.GnbID)
+	return nil
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netem
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+var log = logging.GetLogger("e2agent", "netem")
+
+const indicationQueueSize = 1000
+
+// Emulator emulates the network conditions between an E2 node and the RIC
+type Emulator struct {
+	uplink   *Link // E2 node to RIC
+	downlink *Link // RIC to E2 node
+}
+
+// NewEmulator creates an emulator for the given network conditions; it returns nil if the emulation is disabled
+func NewEmulator(config model.NetemConfig) *Emulator {
+	if !config.IsEnabled() {
+		return nil
+	}
+	return &Emulator{
+		uplink:   NewLink(config),
+		downlink: NewLink(config),
+	}
+}
+
+// WrapClientConn returns an E2 channel delaying the messages the E2 node sends to the RIC
+func (e *Emulator) WrapClientConn(conn e2.ClientConn) e2.ClientConn {
+	c := &clientConn{
+		ClientConn:  conn,
+		uplink:      e.uplink,
+		downlink:    e.downlink,
+		indications: make(chan indication, indicationQueueSize),
+	}
+	go c.sendIndications()
+	return c
+}
+
+// WrapHandler returns an E2 handler delaying the requests received from the RIC and the responses sent back
+func (e *Emulator) WrapHandler(handler e2.ClientInterface) e2.ClientInterface {
+	return &clientHandler{
+		ClientInterface: handler,
+		uplink:          e.uplink,
+		downlink:        e.downlink,
+	}
+}
+
+type indication struct {
+	ctx     context.Context
+	request *e2appducontents.Ricindication
+	dueAt   time.Time
+}
+
+type clientConn struct {
+	e2.ClientConn
+	uplink      *Link
+	downlink    *Link
+	indications chan indication
+	mu          sync.RWMutex
+	err         error
+}
+
+// E2Setup delays the setup request and its response
+func (c *clientConn) E2Setup(ctx context.Context, request *e2appducontents.E2SetupRequest) (*e2appducontents.E2SetupResponse, *e2appducontents.E2SetupFailure, error) {
+	if err := c.uplink.Wait(ctx, request); err != nil {
+		return nil, nil, err
+	}
+	response, failure, err := c.ClientConn.E2Setup(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := c.downlink.Wait(ctx, response, failure); err != nil {
+		return nil, nil, err
+	}
+	return response, failure, nil
+}
+
+// RICIndication queues the indication to be sent once it is due; like on a real network, the sender
+// is not blocked while the indication is in flight
+func (c *clientConn) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	c.mu.RLock()
+	err := c.err
+	c.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	select {
+	case c.indications <- indication{ctx: ctx, request: request, dueAt: c.uplink.Schedule(time.Now(), size(request))}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.Context().Done():
+		return c.Context().Err()
+	}
+}
+
+func (c *clientConn) sendIndications() {
+	for {
+		select {
+		case ind := <-c.indications:
+			if err := waitUntil(c.Context(), ind.dueAt); err != nil {
+				return
+			}
+			// Indications of deleted subscriptions are dropped along with their context
+			if ind.ctx.Err() != nil {
+				continue
+			}
+			if err := c.ClientConn.RICIndication(ind.ctx, ind.request); err != nil {
+				log.Warn(err)
+				c.mu.Lock()
+				c.err = err
+				c.mu.Unlock()
+				return
+			}
+		case <-c.Context().Done():
+			return
+		}
+	}
+}
+
+type clientHandler struct {
+	e2.ClientInterface
+	uplink   *Link
+	downlink *Link
+}
+
+func (h *clientHandler) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (*e2appducontents.E2ConnectionUpdateAcknowledge, *e2appducontents.E2ConnectionUpdateFailure, error) {
+	if err := h.downlink.Wait(ctx, request); err != nil {
+		return nil, nil, err
+	}
+	response, failure, err := h.ClientInterface.E2ConnectionUpdate(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := h.uplink.Wait(ctx, response, failure); err != nil {
+		return nil, nil, err
+	}
+	return response, failure, nil
+}
+
+func (h *clientHandler) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (*e2appducontents.RiccontrolAcknowledge, *e2appducontents.RiccontrolFailure, error) {
+	if err := h.downlink.Wait(ctx, request); err != nil {
+		return nil, nil, err
+	}
+	response, failure, err := h.ClientInterface.RICControl(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := h.uplink.Wait(ctx, response, failure); err != nil {
+		return nil, nil, err
+	}
+	return response, failure, nil
+}
+
+func (h *clientHandler) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+	if err := h.downlink.Wait(ctx, request); err != nil {
+		return nil, nil, err
+	}
+	response, failure, err := h.ClientInterface.RICSubscription(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := h.uplink.Wait(ctx, response, failure); err != nil {
+		return nil, nil, err
+	}
+	return response, failure, nil
+}
+
+func (h *clientHandler) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (*e2appducontents.RicsubscriptionDeleteResponse, *e2appducontents.RicsubscriptionDeleteFailure, error) {
+	if err := h.downlink.Wait(ctx, request); err != nil {
+		return nil, nil, err
+	}
+	response, failure, err := h.ClientInterface.RICSubscriptionDelete(ctx, request)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := h.uplink.Wait(ctx, response, failure); err != nil {
+		return nil, nil, err
+	}
+	return response, failure, nil
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netem
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// Uniform jitter is drawn uniformly from [-jitter, +jitter]
+	Uniform = "uniform"
+	// Normal jitter is drawn from a normal distribution with the jitter as standard deviation
+	Normal = "normal"
+)
+
+// Link emulates one direction of a network link; messages are delivered in the order they are sent
+type Link struct {
+	config model.NetemConfig
+	mu     sync.Mutex
+	rand   *rand.Rand
+	sentAt time.Time // time at which the last message has left the link at the configured rate
+	dueAt  time.Time // time at which the last message is delivered
+}
+
+// NewLink creates a new emulated link
+func NewLink(config model.NetemConfig) *Link {
+	return &Link{
+		config: config,
+		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Schedule returns the time at which a message of the given size in bytes sent at the given time is delivered
+func (l *Link) Schedule(now time.Time, size int) time.Time {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Messages are queued behind each other while being transmitted at the link rate
+	sentAt := now
+	if l.sentAt.After(sentAt) {
+		sentAt = l.sentAt
+	}
+	if l.config.Bandwidth > 0 {
+		sentAt = sentAt.Add(time.Duration(size*8) * time.Second / time.Duration(l.config.Bandwidth))
+	}
+	l.sentAt = sentAt
+
+	// The jitter cannot reorder messages of the same connection
+	dueAt := sentAt.Add(l.latency())
+	if dueAt.Before(l.dueAt) {
+		dueAt = l.dueAt
+	}
+	l.dueAt = dueAt
+	return dueAt
+}
+
+// Wait blocks until the given messages sent now are delivered or the context is done
+func (l *Link) Wait(ctx context.Context, msgs ...proto.Message) error {
+	return waitUntil(ctx, l.Schedule(time.Now(), size(msgs...)))
+}
+
+func (l *Link) latency() time.Duration {
+	latency := l.config.Latency
+	if l.config.Jitter > 0 {
+		switch l.config.Distribution {
+		case Normal:
+			latency += time.Duration(l.rand.NormFloat64() * float64(l.config.Jitter))
+		default:
+			latency += time.Duration((2*l.rand.Float64() - 1) * float64(l.config.Jitter))
+		}
+	}
+	if latency < 0 {
+		return 0
+	}
+	return latency
+}
+
+func waitUntil(ctx context.Context, t time.Time) error {
+	timer := time.NewTimer(time.Until(t))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// size returns the encoded size of the given messages; the proto encoding is used as an estimate of the E2AP encoding
+func size(msgs ...proto.Message) int {
+	total := 0
+	for _, msg := range msgs {
+		if msg != nil && msg.ProtoReflect().IsValid() {
+			total += proto.Size(msg)
+		}
+	}
+	return total
+}
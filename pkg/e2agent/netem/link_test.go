@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package netem
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatency(t *testing.T) {
+	link := NewLink(model.NetemConfig{Latency: 10 * time.Millisecond})
+	now := time.Now()
+	assert.Equal(t, now.Add(10*time.Millisecond), link.Schedule(now, 100))
+	assert.Equal(t, now.Add(15*time.Millisecond), link.Schedule(now.Add(5*time.Millisecond), 100))
+}
+
+func TestBandwidth(t *testing.T) {
+	// 1000 bytes take 1ms at 8Mbps; back-to-back messages are queued behind each other
+	link := NewLink(model.NetemConfig{Latency: 10 * time.Millisecond, Bandwidth: 8000000})
+	now := time.Now()
+	assert.Equal(t, now.Add(11*time.Millisecond), link.Schedule(now, 1000))
+	assert.Equal(t, now.Add(12*time.Millisecond), link.Schedule(now, 1000))
+	assert.Equal(t, now.Add(20*time.Millisecond), link.Schedule(now.Add(9*time.Millisecond), 1000))
+}
+
+func TestJitter(t *testing.T) {
+	for _, distribution := range []string{Uniform, Normal} {
+		link := NewLink(model.NetemConfig{Latency: 10 * time.Millisecond, Jitter: 5 * time.Millisecond, Distribution: distribution})
+		now := time.Now()
+		last := now
+		for i := 0; i < 100; i++ {
+			dueAt := link.Schedule(now, 100)
+			// messages are never reordered nor delivered before they are sent
+			assert.False(t, dueAt.Before(last))
+			last = dueAt
+		}
+		if distribution == Uniform {
+			assert.True(t, last.Sub(now) <= 15*time.Millisecond)
+		}
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	assert.Nil(t, NewEmulator(model.NetemConfig{}))
+	assert.NotNil(t, NewEmulator(model.NetemConfig{Bandwidth: 1000}))
+}
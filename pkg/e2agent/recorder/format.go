@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"sync"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"google.golang.org/protobuf/proto"
+)
+
+// Direction direction of a recorded message as seen by the E2 node
+type Direction uint8
+
+const (
+	// Sent message sent by the E2 node to the RIC
+	Sent Direction = iota
+	// Received message received by the E2 node from the RIC
+	Received
+)
+
+// String returns the direction as string
+func (d Direction) String() string {
+	return [...]string{"Sent", "Received"}[d]
+}
+
+// MessageType type of a recorded E2AP message
+type MessageType uint8
+
+const (
+	// Unknown unknown message type
+	Unknown MessageType = iota
+	// E2SetupRequest E2 setup request
+	E2SetupRequest
+	// E2SetupResponse E2 setup response
+	E2SetupResponse
+	// E2SetupFailure E2 setup failure
+	E2SetupFailure
+	// RICIndication RIC indication
+	RICIndication
+	// RICSubscriptionRequest RIC subscription request
+	RICSubscriptionRequest
+	// RICSubscriptionResponse RIC subscription response
+	RICSubscriptionResponse
+	// RICSubscriptionFailure RIC subscription failure
+	RICSubscriptionFailure
+	// RICSubscriptionDeleteRequest RIC subscription delete request
+	RICSubscriptionDeleteRequest
+	// RICSubscriptionDeleteResponse RIC subscription delete response
+	RICSubscriptionDeleteResponse
+	// RICSubscriptionDeleteFailure RIC subscription delete failure
+	RICSubscriptionDeleteFailure
+	// RICControlRequest RIC control request
+	RICControlRequest
+	// RICControlAcknowledge RIC control acknowledge
+	RICControlAcknowledge
+	// RICControlFailure RIC control failure
+	RICControlFailure
+	// E2ConnectionUpdate E2 connection update
+	E2ConnectionUpdate
+	// E2ConnectionUpdateAcknowledge E2 connection update acknowledge
+	E2ConnectionUpdateAcknowledge
+	// E2ConnectionUpdateFailure E2 connection update failure
+	E2ConnectionUpdateFailure
+)
+
+// String returns the message type as string
+func (t MessageType) String() string {
+	return [...]string{"Unknown", "E2SetupRequest", "E2SetupResponse", "E2SetupFailure", "RICIndication",
+		"RICSubscriptionRequest", "RICSubscriptionResponse", "RICSubscriptionFailure",
+		"RICSubscriptionDeleteRequest", "RICSubscriptionDeleteResponse", "RICSubscriptionDeleteFailure",
+		"RICControlRequest", "RICControlAcknowledge", "RICControlFailure",
+		"E2ConnectionUpdate", "E2ConnectionUpdateAcknowledge", "E2ConnectionUpdateFailure"}[t]
+}
+
+// Record an E2AP message recorded at the given time
+type Record struct {
+	Time      time.Time
+	Direction Direction
+	Type      MessageType
+	Message   proto.Message
+}
+
+// NewRecord creates a record of the given message
+func NewRecord(t time.Time, direction Direction, msg proto.Message) *Record {
+	return &Record{
+		Time:      t,
+		Direction: direction,
+		Type:      messageType(msg),
+		Message:   msg,
+	}
+}
+
+func messageType(msg proto.Message) MessageType {
+	switch msg.(type) {
+	case *e2appducontents.E2SetupRequest:
+		return E2SetupRequest
+	case *e2appducontents.E2SetupResponse:
+		return E2SetupResponse
+	case *e2appducontents.E2SetupFailure:
+		return E2SetupFailure
+	case *e2appducontents.Ricindication:
+		return RICIndication
+	case *e2appducontents.RicsubscriptionRequest:
+		return RICSubscriptionRequest
+	case *e2appducontents.RicsubscriptionResponse:
+		return RICSubscriptionResponse
+	case *e2appducontents.RicsubscriptionFailure:
+		return RICSubscriptionFailure
+	case *e2appducontents.RicsubscriptionDeleteRequest:
+		return RICSubscriptionDeleteRequest
+	case *e2appducontents.RicsubscriptionDeleteResponse:
+		return RICSubscriptionDeleteResponse
+	case *e2appducontents.RicsubscriptionDeleteFailure:
+		return RICSubscriptionDeleteFailure
+	case *e2appducontents.RiccontrolRequest:
+		return RICControlRequest
+	case *e2appducontents.RiccontrolAcknowledge:
+		return RICControlAcknowledge
+	case *e2appducontents.RiccontrolFailure:
+		return RICControlFailure
+	case *e2appducontents.E2ConnectionUpdate:
+		return E2ConnectionUpdate
+	case *e2appducontents.E2ConnectionUpdateAcknowledge:
+		return E2ConnectionUpdateAcknowledge
+	case *e2appducontents.E2ConnectionUpdateFailure:
+		return E2ConnectionUpdateFailure
+	}
+	return Unknown
+}
+
+func newMessage(t MessageType) (proto.Message, error) {
+	switch t {
+	case E2SetupRequest:
+		return &e2appducontents.E2SetupRequest{}, nil
+	case E2SetupResponse:
+		return &e2appducontents.E2SetupResponse{}, nil
+	case E2SetupFailure:
+		return &e2appducontents.E2SetupFailure{}, nil
+	case RICIndication:
+		return &e2appducontents.Ricindication{}, nil
+	case RICSubscriptionRequest:
+		return &e2appducontents.RicsubscriptionRequest{}, nil
+	case RICSubscriptionResponse:
+		return &e2appducontents.RicsubscriptionResponse{}, nil
+	case RICSubscriptionFailure:
+		return &e2appducontents.RicsubscriptionFailure{}, nil
+	case RICSubscriptionDeleteRequest:
+		return &e2appducontents.RicsubscriptionDeleteRequest{}, nil
+	case RICSubscriptionDeleteResponse:
+		return &e2appducontents.RicsubscriptionDeleteResponse{}, nil
+	case RICSubscriptionDeleteFailure:
+		return &e2appducontents.RicsubscriptionDeleteFailure{}, nil
+	case RICControlRequest:
+		return &e2appducontents.RiccontrolRequest{}, nil
+	case RICControlAcknowledge:
+		return &e2appducontents.RiccontrolAcknowledge{}, nil
+	case RICControlFailure:
+		return &e2appducontents.RiccontrolFailure{}, nil
+	case E2ConnectionUpdate:
+		return &e2appducontents.E2ConnectionUpdate{}, nil
+	case E2ConnectionUpdateAcknowledge:
+		return &e2appducontents.E2ConnectionUpdateAcknowledge{}, nil
+	case E2ConnectionUpdateFailure:
+		return &e2appducontents.E2ConnectionUpdateFailure{}, nil
+	}
+	return nil, errors.NewInvalid("unknown message type %d", t)
+}
+
+// Each record is encoded as a fixed size header followed by the protobuf encoding of the message:
+// the record time in nanoseconds since the Unix epoch (8 bytes), the direction (1 byte),
+// the message type (1 byte) and the length of the encoded message (4 bytes), all in big endian order.
+const headerSize = 14
+
+// Writer writes records to a stream
+type Writer struct {
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+// NewWriter creates a new record writer
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w: bufio.NewWriter(w),
+	}
+}
+
+// Write writes the given record and flushes it to the underlying stream
+func (w *Writer) Write(record *Record) error {
+	if record.Type == Unknown {
+		return errors.NewInvalid("unknown message type %T", record.Message)
+	}
+	bytes, err := proto.Marshal(record.Message)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, headerSize)
+	binary.BigEndian.PutUint64(header[0:8], uint64(record.Time.UnixNano()))
+	header[8] = byte(record.Direction)
+	header[9] = byte(record.Type)
+	binary.BigEndian.PutUint32(header[10:14], uint32(len(bytes)))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.w.Write(bytes); err != nil {
+		return err
+	}
+	return w.w.Flush()
+}
+
+// Reader reads records from a stream
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader creates a new record reader
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		r: bufio.NewReader(r),
+	}
+}
+
+// Read reads the next record; it returns io.EOF at the end of the stream
+func (r *Reader) Read() (*Record, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r.r, header); err != nil {
+		return nil, err
+	}
+	bytes := make([]byte, binary.BigEndian.Uint32(header[10:14]))
+	if _, err := io.ReadFull(r.r, bytes); err != nil {
+		return nil, err
+	}
+	msg, err := newMessage(MessageType(header[9]))
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(bytes, msg); err != nil {
+		return nil, err
+	}
+	return &Record{
+		Time:      time.Unix(0, int64(binary.BigEndian.Uint64(header[0:8]))),
+		Direction: Direction(header[8]),
+		Type:      MessageType(header[9]),
+		Message:   msg,
+	}, nil
+}
+
+// ReadAll reads all records of the stream
+func ReadAll(r io.Reader) ([]*Record, error) {
+	reader := NewReader(r)
+	records := make([]*Record, 0)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		} else if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	indicationutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestWriteRead(t *testing.T) {
+	indication, err := indicationutils.NewIndication(
+		indicationutils.WithRicInstanceID(1),
+		indicationutils.WithRanFuncID(2),
+		indicationutils.WithRequestID(3),
+		indicationutils.WithIndicationHeader([]byte{0x01}),
+		indicationutils.WithIndicationMessage([]byte{0x02, 0x03})).Build()
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	writer := NewWriter(buf)
+	now := time.Now()
+	assert.NoError(t, writer.Write(NewRecord(now, Received, &e2appducontents.RicsubscriptionDeleteRequest{})))
+	assert.NoError(t, writer.Write(NewRecord(now.Add(time.Second), Sent, indication)))
+	assert.Error(t, writer.Write(NewRecord(now, Sent, &e2appducontents.RicactionToBeSetupItemIes{})))
+
+	records, err := ReadAll(buf)
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+	assert.Equal(t, RICSubscriptionDeleteRequest, records[0].Type)
+	assert.Equal(t, Received, records[0].Direction)
+	assert.Equal(t, now.UnixNano(), records[0].Time.UnixNano())
+	assert.Equal(t, RICIndication, records[1].Type)
+	assert.Equal(t, Sent, records[1].Direction)
+	assert.Equal(t, time.Second, records[1].Time.Sub(records[0].Time))
+	assert.True(t, proto.Equal(indication, records[1].Message))
+}
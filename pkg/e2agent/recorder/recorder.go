@@ -0,0 +1,127 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package recorder
+
+import (
+	"context"
+	"os"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+var log = logging.GetLogger("e2agent", "recorder")
+
+// Recorder records the E2AP messages exchanged between an E2 node and the RIC to a file
+type Recorder struct {
+	file   *os.File
+	writer *Writer
+}
+
+// Open opens the recording file at the given path; new records are appended to an existing recording
+func Open(path string) (*Recorder, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("Recording E2AP messages to %s", path)
+	return &Recorder{
+		file:   file,
+		writer: NewWriter(file),
+	}, nil
+}
+
+// Close closes the recording file
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+func (r *Recorder) record(direction Direction, msgs ...proto.Message) {
+	now := time.Now()
+	for _, msg := range msgs {
+		if msg == nil || !msg.ProtoReflect().IsValid() {
+			continue
+		}
+		if err := r.writer.Write(NewRecord(now, direction, msg)); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
+// WrapClientConn returns an E2 channel recording the messages sent to the RIC; the recording
+// file is closed along with the channel
+func (r *Recorder) WrapClientConn(conn e2.ClientConn) e2.ClientConn {
+	go func() {
+		<-conn.Context().Done()
+		if err := r.Close(); err != nil {
+			log.Warn(err)
+		}
+	}()
+	return &clientConn{
+		ClientConn: conn,
+		recorder:   r,
+	}
+}
+
+// WrapHandler returns an E2 handler recording the requests received from the RIC and the responses sent back
+func (r *Recorder) WrapHandler(handler e2.ClientInterface) e2.ClientInterface {
+	return &clientHandler{
+		ClientInterface: handler,
+		recorder:        r,
+	}
+}
+
+type clientConn struct {
+	e2.ClientConn
+	recorder *Recorder
+}
+
+func (c *clientConn) E2Setup(ctx context.Context, request *e2appducontents.E2SetupRequest) (*e2appducontents.E2SetupResponse, *e2appducontents.E2SetupFailure, error) {
+	c.recorder.record(Sent, request)
+	response, failure, err := c.ClientConn.E2Setup(ctx, request)
+	c.recorder.record(Received, response, failure)
+	return response, failure, err
+}
+
+func (c *clientConn) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	c.recorder.record(Sent, request)
+	return c.ClientConn.RICIndication(ctx, request)
+}
+
+type clientHandler struct {
+	e2.ClientInterface
+	recorder *Recorder
+}
+
+func (h *clientHandler) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (*e2appducontents.E2ConnectionUpdateAcknowledge, *e2appducontents.E2ConnectionUpdateFailure, error) {
+	h.recorder.record(Received, request)
+	response, failure, err := h.ClientInterface.E2ConnectionUpdate(ctx, request)
+	h.recorder.record(Sent, response, failure)
+	return response, failure, err
+}
+
+func (h *clientHandler) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (*e2appducontents.RiccontrolAcknowledge, *e2appducontents.RiccontrolFailure, error) {
+	h.recorder.record(Received, request)
+	response, failure, err := h.ClientInterface.RICControl(ctx, request)
+	h.recorder.record(Sent, response, failure)
+	return response, failure, err
+}
+
+func (h *clientHandler) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+	h.recorder.record(Received, request)
+	response, failure, err := h.ClientInterface.RICSubscription(ctx, request)
+	h.recorder.record(Sent, response, failure)
+	return response, failure, err
+}
+
+func (h *clientHandler) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (*e2appducontents.RicsubscriptionDeleteResponse, *e2appducontents.RicsubscriptionDeleteFailure, error) {
+	h.recorder.record(Received, request)
+	response, failure, err := h.ClientInterface.RICSubscriptionDelete(ctx, request)
+	h.recorder.record(Sent, response, failure)
+	return response, failure, err
+}
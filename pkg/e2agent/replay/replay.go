@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/e2agent/recorder"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+	"google.golang.org/protobuf/proto"
+)
+
+var log = logging.GetLogger("e2agent", "replay")
+
+// Replayer replays the indications of a recording against a RIC, independently of the simulation. The recorded
+// E2 setup request is used to connect to the RIC and each recorded indication is sent over the RIC subscription
+// currently active for its RAN function; indications of RAN functions the RIC has not subscribed to are skipped.
+// The replay starts as soon as the RIC creates its first subscription.
+type Replayer struct {
+	records    []*recorder.Record
+	speed      float64
+	mu         sync.RWMutex
+	subs       map[int32]*e2apies.RicrequestId
+	subscribed chan struct{}
+	once       sync.Once
+}
+
+// NewReplayer creates a replayer of the given records; the recorded timing is accelerated by the given speed factor
+// and indications are sent as fast as possible if the speed is zero
+func NewReplayer(records []*recorder.Record, speed float64) *Replayer {
+	return &Replayer{
+		records:    records,
+		speed:      speed,
+		subs:       make(map[int32]*e2apies.RicrequestId),
+		subscribed: make(chan struct{}),
+	}
+}
+
+// Run connects to the RIC at the given address and replays the recorded indications once
+func (r *Replayer) Run(ctx context.Context, addr string) error {
+	var setupRequest *e2appducontents.E2SetupRequest
+	indications := make([]*recorder.Record, 0)
+	for _, record := range r.records {
+		switch record.Type {
+		case recorder.E2SetupRequest:
+			if setupRequest == nil {
+				setupRequest = record.Message.(*e2appducontents.E2SetupRequest)
+			}
+		case recorder.RICIndication:
+			indications = append(indications, record)
+		}
+	}
+	if setupRequest == nil {
+		return errors.NewInvalid("recording does not contain an E2 setup request")
+	}
+
+	conn, err := e2.Connect(ctx, addr, func(channel e2.ClientConn) e2.ClientInterface {
+		return r
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, failure, err := conn.E2Setup(ctx, setupRequest)
+	if err != nil {
+		return err
+	} else if failure != nil {
+		return errors.NewInvalid("E2 setup failed: %v", failure)
+	}
+
+	select {
+	case <-r.subscribed:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-conn.Context().Done():
+		return errors.NewUnavailable("E2 connection closed before any subscription")
+	}
+	log.Infof("Replaying %d indications", len(indications))
+
+	start := time.Now()
+	for i, record := range indications {
+		if r.speed > 0 {
+			offset := time.Duration(float64(record.Time.Sub(indications[0].Time)) / r.speed)
+			select {
+			case <-time.After(time.Until(start.Add(offset))):
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-conn.Context().Done():
+				return errors.NewUnavailable("E2 connection closed after %d indications", i)
+			}
+		}
+		indication := proto.Clone(record.Message).(*e2appducontents.Ricindication)
+		if !r.rewrite(indication) {
+			continue
+		}
+		if err := conn.RICIndication(ctx, indication); err != nil {
+			return err
+		}
+	}
+	log.Infof("Replay completed in %v", time.Since(start))
+	return nil
+}
+
+// rewrite replaces the RIC request ID of the given indication with the one of the active subscription
+func (r *Replayer) rewrite(indication *e2appducontents.Ricindication) bool {
+	var ranFuncID int32 = -1
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRanfunctionID) {
+			ranFuncID = v.GetValue().GetRfId().GetValue()
+		}
+	}
+	r.mu.RLock()
+	reqID, ok := r.subs[ranFuncID]
+	r.mu.RUnlock()
+	if !ok {
+		log.Debugf("Skipping indication of RAN function %d without subscription", ranFuncID)
+		return false
+	}
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicrequestID) {
+			v.GetValue().GetRrId().RicRequestorId = reqID.RicRequestorId
+			v.GetValue().GetRrId().RicInstanceId = reqID.RicInstanceId
+		}
+	}
+	return true
+}
+
+// E2ConnectionUpdate is not supported while replaying
+func (r *Replayer) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (*e2appducontents.E2ConnectionUpdateAcknowledge, *e2appducontents.E2ConnectionUpdateFailure, error) {
+	return nil, nil, errors.NewNotSupported("E2 connection update is not supported while replaying")
+}
+
+// RICControl is not supported while replaying
+func (r *Replayer) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (*e2appducontents.RiccontrolAcknowledge, *e2appducontents.RiccontrolFailure, error) {
+	return nil, nil, errors.NewNotSupported("RIC control is not supported while replaying")
+}
+
+// RICSubscription accepts all actions of the subscription and replays the indications of its RAN function over it
+func (r *Replayer) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+	reqID, err := subutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ricActionsAccepted []*e2aptypes.RicActionID
+	for _, action := range subutils.GetRicActionToBeSetupList(request) {
+		actionID := e2aptypes.RicActionID(action.GetValue().GetRatbsi().GetRicActionId().GetValue())
+		ricActionsAccepted = append(ricActionsAccepted, &actionID)
+	}
+
+	r.mu.Lock()
+	r.subs[*ranFuncID] = &e2apies.RicrequestId{
+		RicRequestorId: *reqID,
+		RicInstanceId:  *ricInstanceID,
+	}
+	r.mu.Unlock()
+	r.once.Do(func() { close(r.subscribed) })
+	log.Infof("Replaying indications of RAN function %d to subscription %d", *ranFuncID, *reqID)
+
+	subscription := subutils.NewSubscription(
+		subutils.WithRequestID(*reqID),
+		subutils.WithRanFuncID(*ranFuncID),
+		subutils.WithRicInstanceID(*ricInstanceID),
+		subutils.WithActionsAccepted(ricActionsAccepted))
+	response, err := subscription.BuildSubscriptionResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// RICSubscriptionDelete stops replaying the indications of the RAN function of the subscription
+func (r *Replayer) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (*e2appducontents.RicsubscriptionDeleteResponse, *e2appducontents.RicsubscriptionDeleteFailure, error) {
+	reqID, err := subdeleteutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subdeleteutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subdeleteutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.mu.Lock()
+	delete(r.subs, *ranFuncID)
+	r.mu.Unlock()
+
+	subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
+		subdeleteutils.WithRequestID(*reqID),
+		subdeleteutils.WithRanFuncID(*ranFuncID),
+		subdeleteutils.WithRicInstanceID(*ricInstanceID))
+	response, err := subscriptionDelete.BuildSubscriptionDeleteResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+var _ e2.ClientInterface = &Replayer{}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2 "github.com/onosproject/onos-e2t/pkg/protocols/e2ap"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/tracing"
+	indicationutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+)
+
+var log = logging.GetLogger("e2agent", "tracer")
+
+// Span attributes identifying the E2 node and the subscription of the traced messages
+const (
+	attributeNode          = "e2.node"
+	attributeRequestorID   = "e2ap.ric_requestor_id"
+	attributeInstanceID    = "e2ap.ric_instance_id"
+	attributeRanFunctionID = "e2ap.ran_function_id"
+	attributeActionID      = "e2ap.ric_action_id"
+	attributeIndicationSN  = "e2ap.ric_indication_sn"
+)
+
+// subscriptionKey identifies a subscription by its RIC request ID and RAN function ID
+type subscriptionKey struct {
+	requestorID   int32
+	instanceID    int32
+	ranFunctionID int32
+}
+
+func (k subscriptionKey) attributes() []tracing.Attribute {
+	return []tracing.Attribute{
+		tracing.Int(attributeRequestorID, int64(k.requestorID)),
+		tracing.Int(attributeInstanceID, int64(k.instanceID)),
+		tracing.Int(attributeRanFunctionID, int64(k.ranFunctionID)),
+	}
+}
+
+// Tracer traces the E2 procedures of an E2 node; the indications of a subscription are traced as children of the span
+// of the subscription procedure, so that each subscription is traced from its creation to its deletion
+type Tracer struct {
+	node          string
+	mu            sync.RWMutex
+	subscriptions map[subscriptionKey]tracing.SpanContext
+}
+
+// New creates a tracer of the given E2 node
+func New(node model.Node) *Tracer {
+	return &Tracer{
+		node:          fmt.Sprintf("%d", node.GnbID),
+		subscriptions: make(map[subscriptionKey]tracing.SpanContext),
+	}
+}
+
+func (t *Tracer) start(ctx context.Context, name string, kind tracing.SpanKind, attributes ...tracing.Attribute) (context.Context, *tracing.Span) {
+	return tracing.Start(ctx, name, kind, append(attributes, tracing.String(attributeNode, t.node))...)
+}
+
+// WrapClientConn returns an E2 channel tracing the procedures initiated by the E2 node
+func (t *Tracer) WrapClientConn(conn e2.ClientConn) e2.ClientConn {
+	return &clientConn{
+		ClientConn: conn,
+		tracer:     t,
+	}
+}
+
+// WrapHandler returns an E2 handler tracing the procedures initiated by the RIC
+func (t *Tracer) WrapHandler(handler e2.ClientInterface) e2.ClientInterface {
+	return &clientHandler{
+		ClientInterface: handler,
+		tracer:          t,
+	}
+}
+
+// procedureError returns the error of a procedure, if either the procedure or its outcome is a failure
+func procedureError(err error, failed bool) error {
+	if err == nil && failed {
+		return errors.NewUnavailable("unsuccessful outcome")
+	}
+	return err
+}
+
+type clientConn struct {
+	e2.ClientConn
+	tracer *Tracer
+}
+
+func (c *clientConn) E2Setup(ctx context.Context, request *e2appducontents.E2SetupRequest) (*e2appducontents.E2SetupResponse, *e2appducontents.E2SetupFailure, error) {
+	ctx, span := c.tracer.start(ctx, "E2Setup", tracing.SpanKindClient)
+	response, failure, err := c.ClientConn.E2Setup(ctx, request)
+	span.End(procedureError(err, failure != nil))
+	return response, failure, err
+}
+
+// RICIndication traces the indication as a child of the span of its subscription, if known
+func (c *clientConn) RICIndication(ctx context.Context, request *e2appducontents.Ricindication) error {
+	var attributes []tracing.Attribute
+	requestorID, err1 := indicationutils.GetRequesterID(request)
+	instanceID, err2 := indicationutils.GetRicInstanceID(request)
+	ranFunctionID, err3 := indicationutils.GetRanFunctionID(request)
+	if err1 == nil && err2 == nil && err3 == nil {
+		key := subscriptionKey{requestorID: *requestorID, instanceID: *instanceID, ranFunctionID: *ranFunctionID}
+		if parent, ok := c.tracer.subscription(key); ok {
+			ctx = tracing.ContextWithSpanContext(ctx, parent)
+		}
+		attributes = key.attributes()
+	}
+	if actionID, err := indicationutils.GetRicActionID(request); err == nil {
+		attributes = append(attributes, tracing.Int(attributeActionID, int64(*actionID)))
+	}
+	if sn, err := indicationutils.GetRicIndicationSN(request); err == nil {
+		attributes = append(attributes, tracing.Int(attributeIndicationSN, int64(*sn)))
+	}
+
+	ctx, span := c.tracer.start(ctx, "RICIndication", tracing.SpanKindProducer, attributes...)
+	err := c.ClientConn.RICIndication(ctx, request)
+	span.End(err)
+	return err
+}
+
+func (t *Tracer) subscription(key subscriptionKey) (tracing.SpanContext, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	spanContext, ok := t.subscriptions[key]
+	return spanContext, ok
+}
+
+type clientHandler struct {
+	e2.ClientInterface
+	tracer *Tracer
+}
+
+func (h *clientHandler) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (*e2appducontents.E2ConnectionUpdateAcknowledge, *e2appducontents.E2ConnectionUpdateFailure, error) {
+	ctx, span := h.tracer.start(ctx, "E2ConnectionUpdate", tracing.SpanKindServer)
+	response, failure, err := h.ClientInterface.E2ConnectionUpdate(ctx, request)
+	span.End(procedureError(err, failure != nil))
+	return response, failure, err
+}
+
+func (h *clientHandler) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (*e2appducontents.RiccontrolAcknowledge, *e2appducontents.RiccontrolFailure, error) {
+	ctx, span := h.tracer.start(ctx, "RICControl", tracing.SpanKindServer)
+	response, failure, err := h.ClientInterface.RICControl(ctx, request)
+	span.End(procedureError(err, failure != nil))
+	return response, failure, err
+}
+
+// RICSubscription traces the subscription procedure; the span of a successful subscription becomes the parent of
+// the spans of its indications
+func (h *clientHandler) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+	var key *subscriptionKey
+	requestorID, err1 := subutils.GetRequesterID(request)
+	instanceID, err2 := subutils.GetRicInstanceID(request)
+	ranFunctionID, err3 := subutils.GetRanFunctionID(request)
+	if err1 == nil && err2 == nil && err3 == nil {
+		key = &subscriptionKey{requestorID: *requestorID, instanceID: *instanceID, ranFunctionID: *ranFunctionID}
+	}
+
+	var attributes []tracing.Attribute
+	if key != nil {
+		attributes = key.attributes()
+	}
+	ctx, span := h.tracer.start(ctx, "RICSubscription", tracing.SpanKindServer, attributes...)
+	// The subscription is registered before it is processed since the service models may start sending indications
+	// before responding
+	if key != nil {
+		h.tracer.mu.Lock()
+		h.tracer.subscriptions[*key] = span.Context()
+		h.tracer.mu.Unlock()
+	}
+	response, failure, err := h.ClientInterface.RICSubscription(ctx, request)
+	span.End(procedureError(err, failure != nil))
+
+	if key != nil {
+		if err != nil || failure != nil {
+			h.tracer.mu.Lock()
+			delete(h.tracer.subscriptions, *key)
+			h.tracer.mu.Unlock()
+		} else {
+			log.Infof("Tracing subscription %d:%d of RAN function %d of node %s in trace %s",
+				key.requestorID, key.instanceID, key.ranFunctionID, h.tracer.node, span.Context().TraceIDString())
+		}
+	}
+	return response, failure, err
+}
+
+// RICSubscriptionDelete traces the subscription delete procedure in the trace of the subscription, which ends with it
+func (h *clientHandler) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (*e2appducontents.RicsubscriptionDeleteResponse, *e2appducontents.RicsubscriptionDeleteFailure, error) {
+	var key *subscriptionKey
+	requestorID, err1 := subdeleteutils.GetRequesterID(request)
+	instanceID, err2 := subdeleteutils.GetRicInstanceID(request)
+	ranFunctionID, err3 := subdeleteutils.GetRanFunctionID(request)
+	if err1 == nil && err2 == nil && err3 == nil {
+		key = &subscriptionKey{requestorID: *requestorID, instanceID: *instanceID, ranFunctionID: *ranFunctionID}
+	}
+
+	var attributes []tracing.Attribute
+	if key != nil {
+		attributes = key.attributes()
+		if parent, ok := h.tracer.subscription(*key); ok {
+			ctx = tracing.ContextWithSpanContext(ctx, parent)
+		}
+	}
+	ctx, span := h.tracer.start(ctx, "RICSubscriptionDelete", tracing.SpanKindServer, attributes...)
+	response, failure, err := h.ClientInterface.RICSubscriptionDelete(ctx, request)
+	span.End(procedureError(err, failure != nil))
+
+	if key != nil && err == nil && failure == nil {
+		h.tracer.mu.Lock()
+		delete(h.tracer.subscriptions, *key)
+		h.tracer.mu.Unlock()
+	}
+	return response, failure, err
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transactions
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger("e2agent", "transactions")
+
+const (
+	// MaxID highest transaction ID; E2AP v2 transaction IDs range from 0 to 255
+	MaxID = 255
+	// DefaultTimeout time allowed to a procedure whose context has no deadline
+	DefaultTimeout = 30 * time.Second
+)
+
+// Procedure names of the E2AP procedures initiated by E2 nodes
+const (
+	E2Setup               = "E2Setup"
+	E2ConfigurationUpdate = "E2ConfigurationUpdate"
+)
+
+// Transactions allocates the transaction IDs of the E2AP procedures initiated by an E2 node and correlates the
+// responses of the RIC with the outstanding transactions
+type Transactions struct {
+	mu          sync.Mutex
+	next        int32
+	outstanding map[int32]string // procedure of each outstanding transaction
+}
+
+// NewTransactions creates the transactions of an E2 node
+func NewTransactions() *Transactions {
+	return &Transactions{
+		outstanding: make(map[int32]string),
+	}
+}
+
+// Begin allocates the ID of a new transaction of the procedure; IDs are allocated in turn and the IDs of the
+// outstanding transactions are not reused
+func (t *Transactions) Begin(procedure string) (int32, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i := 0; i <= MaxID; i++ {
+		id := t.next
+		t.next = (t.next + 1) % (MaxID + 1)
+		if _, ok := t.outstanding[id]; !ok {
+			t.outstanding[id] = procedure
+			return id, nil
+		}
+	}
+	return 0, errors.NewUnavailable("no transaction ID is available for %s", procedure)
+}
+
+// End completes the outstanding transaction of the procedure given the transaction ID of the response; it fails if
+// no such transaction is outstanding, e.g. if the response is a duplicate
+func (t *Transactions) End(id int32, procedure string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	outstanding, ok := t.outstanding[id]
+	if !ok {
+		return errors.NewNotFound("%s response with transaction ID %d matches no outstanding transaction", procedure, id)
+	}
+	if outstanding != procedure {
+		return errors.NewInvalid("%s response with transaction ID %d matches a %s transaction", procedure, id, outstanding)
+	}
+	delete(t.outstanding, id)
+	return nil
+}
+
+// Abort releases the transaction without response, e.g. when the procedure failed or timed out
+func (t *Transactions) Abort(id int32) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.outstanding, id)
+}
+
+// Len returns the number of outstanding transactions
+func (t *Transactions) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.outstanding)
+}
+
+// Do runs the procedure in a new transaction. The call sends the request with the given transaction ID and returns
+// the transaction ID of the response of the RIC, which must match it. The transaction is aborted if the call fails or
+// does not complete before the deadline of the context, or DefaultTimeout if the context has none.
+func (t *Transactions) Do(ctx context.Context, procedure string, call func(ctx context.Context, id int32) (int32, error)) error {
+	id, err := t.Begin(procedure)
+	if err != nil {
+		return err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, DefaultTimeout)
+		defer cancel()
+	}
+
+	log.Debugf("Starting %s transaction %d", procedure, id)
+	responseID, err := call(ctx, id)
+	if err != nil {
+		t.Abort(id)
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.NewTimeout("%s transaction %d timed out: %v", procedure, id, err)
+		}
+		return err
+	}
+	if responseID != id {
+		t.Abort(id)
+		return errors.NewInvalid("%s response has transaction ID %d instead of %d", procedure, responseID, id)
+	}
+	return t.End(id, procedure)
+}
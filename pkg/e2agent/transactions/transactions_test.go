@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package transactions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeginEnd(t *testing.T) {
+	transactions := NewTransactions()
+	id1, err := transactions.Begin(E2Setup)
+	assert.NoError(t, err)
+	id2, err := transactions.Begin(E2ConfigurationUpdate)
+	assert.NoError(t, err)
+	assert.NotEqual(t, id1, id2)
+	assert.Equal(t, 2, transactions.Len())
+
+	assert.Error(t, transactions.End(id1, E2ConfigurationUpdate))
+	assert.NoError(t, transactions.End(id1, E2Setup))
+	// Duplicate response
+	err = transactions.End(id1, E2Setup)
+	assert.True(t, errors.IsNotFound(err))
+
+	transactions.Abort(id2)
+	assert.Equal(t, 0, transactions.Len())
+}
+
+func TestIDsAreNotReused(t *testing.T) {
+	transactions := NewTransactions()
+	first, err := transactions.Begin(E2Setup)
+	assert.NoError(t, err)
+	for i := 0; i < MaxID; i++ {
+		id, err := transactions.Begin(E2ConfigurationUpdate)
+		assert.NoError(t, err)
+		assert.NotEqual(t, first, id)
+	}
+	_, err = transactions.Begin(E2ConfigurationUpdate)
+	assert.True(t, errors.IsUnavailable(err))
+
+	transactions.Abort(first)
+	id, err := transactions.Begin(E2ConfigurationUpdate)
+	assert.NoError(t, err)
+	assert.Equal(t, first, id)
+}
+
+func TestDo(t *testing.T) {
+	transactions := NewTransactions()
+	err := transactions.Do(context.Background(), E2Setup, func(ctx context.Context, id int32) (int32, error) {
+		return id, nil
+	})
+	assert.NoError(t, err)
+
+	err = transactions.Do(context.Background(), E2Setup, func(ctx context.Context, id int32) (int32, error) {
+		return id + 1, nil
+	})
+	assert.True(t, errors.IsInvalid(err))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = transactions.Do(ctx, E2ConfigurationUpdate, func(ctx context.Context, id int32) (int32, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	assert.True(t, errors.IsTimeout(err))
+	assert.Equal(t, 0, transactions.Len())
+}
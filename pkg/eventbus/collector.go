@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/audit"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+// Types of the events
+const (
+	Created = "Created"
+	Updated = "Updated"
+	Deleted = "Deleted"
+	// Executed type of the handover events, published as the serving cell of a UE changes
+	Executed = "Executed"
+	// TopologyChanged type of the cell events published as several cells are updated at once; the value of the
+	// event is the list of updated cells
+	TopologyChanged = "TopologyChanged"
+)
+
+// Handover value of the handover events
+type Handover struct {
+	IMSI   types.IMSI `json:"imsi"`
+	Source types.NCGI `json:"source"`
+	Target types.NCGI `json:"target"`
+}
+
+// Collector publishes the events of the stores and the subscription procedures audited by the E2 nodes to a bus
+type Collector struct {
+	mu        sync.Mutex
+	bus       *Bus
+	nodeStore nodes.Store
+	cellStore cells.Store
+	ueStore   ues.Store
+	serving   map[types.IMSI]types.NCGI
+	cancel    context.CancelFunc
+}
+
+// NewCollector creates a collector of the events of the given stores and audit log; the subscription events are
+// published as soon as the collector is created, the events of the stores once it is started
+func NewCollector(bus *Bus, nodeStore nodes.Store, cellStore cells.Store, ueStore ues.Store, auditLog *audit.Log) *Collector {
+	c := &Collector{
+		bus:       bus,
+		nodeStore: nodeStore,
+		cellStore: cellStore,
+		ueStore:   ueStore,
+		serving:   make(map[types.IMSI]types.NCGI),
+	}
+	auditLog.Listen(c.processAuditEntry)
+	return c
+}
+
+// Start starts watching the stores
+func (c *Collector) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	nodeCh := make(chan event.Event)
+	if err := c.nodeStore.Watch(ctx, nodeCh); err != nil {
+		cancel()
+		return err
+	}
+	cellCh := make(chan event.Event)
+	if err := c.cellStore.Watch(ctx, cellCh); err != nil {
+		cancel()
+		return err
+	}
+	ueCh := make(chan event.Event)
+	if err := c.ueStore.Watch(ctx, ueCh); err != nil {
+		cancel()
+		return err
+	}
+	c.cancel = cancel
+	go c.processNodeEvents(c.nodeStore, nodeCh)
+	go c.processCellEvents(c.cellStore, cellCh)
+	go c.processUEEvents(c.ueStore, ueCh)
+	return nil
+}
+
+// Stop stops watching the stores
+func (c *Collector) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cancel != nil {
+		c.cancel()
+		c.cancel = nil
+	}
+}
+
+// Reset makes the collector watch the given stores, which replace the previous ones
+func (c *Collector) Reset(nodeStore nodes.Store, cellStore cells.Store, ueStore ues.Store) error {
+	c.Stop()
+	c.mu.Lock()
+	c.nodeStore = nodeStore
+	c.cellStore = cellStore
+	c.ueStore = ueStore
+	c.serving = make(map[types.IMSI]types.NCGI)
+	c.mu.Unlock()
+	return c.Start()
+}
+
+// processNodeEvents publishes the node events; the nodes are encoded while the store is locked, since the store
+// updates them in place
+func (c *Collector) processNodeEvents(nodeStore nodes.Store, ch <-chan event.Event) {
+	for nodeEvent := range ch {
+		var eventType string
+		switch nodeEvent.Type {
+		case nodes.Created:
+			eventType = Created
+		case nodes.Updated:
+			eventType = Updated
+		case nodes.Deleted:
+			// Deleted nodes are no longer updated by the store
+			node := nodeEvent.Value.(*model.Node)
+			c.bus.Publish(TopicNode, Deleted, fmt.Sprintf("%d", node.GnbID), node)
+			continue
+		default:
+			continue
+		}
+		gnbID := nodeEvent.Key.(types.GnbID)
+		var data json.RawMessage
+		err := nodeStore.View(context.Background(), gnbID, func(node *model.Node) (err error) {
+			data, err = json.Marshal(node)
+			return err
+		})
+		c.publishJSON(TopicNode, eventType, fmt.Sprintf("%d", gnbID), data, err)
+	}
+}
+
+// processCellEvents publishes the cell events; the cells are encoded while the store is locked
+func (c *Collector) processCellEvents(cellStore cells.Store, ch <-chan event.Event) {
+	for cellEvent := range ch {
+		var eventType string
+		switch cellEvent.Type {
+		case cells.Created:
+			eventType = Created
+		case cells.Updated, cells.UpdatedNeighbors:
+			eventType = Updated
+		case cells.Deleted:
+			cell := cellEvent.Value.(*model.Cell)
+			c.bus.Publish(TopicCell, Deleted, fmt.Sprintf("%d", cell.NCGI), cell)
+			continue
+		case cells.TopologyChanged:
+			// The cells updated at once are published as a single event keyed by the ID of the update
+			list := make([]json.RawMessage, 0)
+			for _, cell := range cellEvent.Value.([]*model.Cell) {
+				err := cellStore.View(context.Background(), cell.NCGI, func(cell *model.Cell) error {
+					data, err := json.Marshal(cell)
+					if err == nil {
+						list = append(list, data)
+					}
+					return err
+				})
+				if err != nil && !errors.IsNotFound(err) {
+					log.Warnf("Unable to encode cell %d: %v", cell.NCGI, err)
+				}
+			}
+			c.bus.Publish(TopicCell, TopologyChanged, cellEvent.Key.(string), list)
+			continue
+		default:
+			continue
+		}
+		ncgi := cellEvent.Key.(types.NCGI)
+		var data json.RawMessage
+		err := cellStore.View(context.Background(), ncgi, func(cell *model.Cell) (err error) {
+			data, err = json.Marshal(cell)
+			return err
+		})
+		c.publishJSON(TopicCell, eventType, fmt.Sprintf("%d", ncgi), data, err)
+	}
+}
+
+// processUEEvents publishes the UE events, along with a handover event whenever the serving cell of a UE changes;
+// the UEs are encoded and their serving cell read while the store is locked
+func (c *Collector) processUEEvents(ueStore ues.Store, ch <-chan event.Event) {
+	for ueEvent := range ch {
+		imsi := ueEvent.Key.(types.IMSI)
+		key := fmt.Sprintf("%d", imsi)
+		if ueEvent.Type == ues.Deleted {
+			c.mu.Lock()
+			delete(c.serving, imsi)
+			c.mu.Unlock()
+			c.bus.Publish(TopicUE, Deleted, key, ueEvent.Value)
+			continue
+		} else if ueEvent.Type != ues.Created && ueEvent.Type != ues.Updated {
+			continue
+		}
+
+		var data json.RawMessage
+		var serving *types.NCGI
+		err := ueStore.View(context.Background(), imsi, func(ue *model.UE) (err error) {
+			if ue.Cell != nil {
+				ncgi := ue.Cell.NCGI
+				serving = &ncgi
+			}
+			data, err = json.Marshal(ue)
+			return err
+		})
+		if ueEvent.Type == ues.Created {
+			c.setServing(imsi, serving)
+			c.publishJSON(TopicUE, Created, key, data, err)
+			continue
+		}
+		if handover := c.setServing(imsi, serving); handover != nil {
+			c.bus.Publish(TopicHandover, Executed, key, handover)
+		}
+		c.publishJSON(TopicUE, Updated, key, data, err)
+	}
+}
+
+// publishJSON publishes the event with the value encoded from the store; the event of an entity deleted since is
+// published without a value, its deletion being published next
+func (c *Collector) publishJSON(topic string, eventType string, key string, data json.RawMessage, err error) {
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			log.Warnf("Unable to encode %s event %s of %s: %v", topic, eventType, key, err)
+		}
+		data = nil
+	}
+	c.bus.PublishJSON(topic, eventType, key, data)
+}
+
+// setServing records the serving cell of the UE and returns the handover of the UE if its serving cell changed
+func (c *Collector) setServing(imsi types.IMSI, serving *types.NCGI) *Handover {
+	if serving == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	source, ok := c.serving[imsi]
+	c.serving[imsi] = *serving
+	if !ok || source == *serving {
+		return nil
+	}
+	return &Handover{IMSI: imsi, Source: source, Target: *serving}
+}
+
+// processAuditEntry publishes the successful subscription procedures; subscriptions are keyed by node and
+// subscription request
+func (c *Collector) processAuditEntry(entry audit.Entry) {
+	if entry.Outcome != audit.OutcomeSuccess {
+		return
+	}
+	var eventType string
+	switch entry.Procedure {
+	case "RICSubscription":
+		eventType = Created
+	case "RICSubscriptionDelete":
+		eventType = Deleted
+	default:
+		return
+	}
+	c.bus.Publish(TopicSubscription, eventType, fmt.Sprintf("%s/%s-%s-%s", entry.Node,
+		entry.Parameters["ricInstanceId"], entry.Parameters["ricRequestorId"], entry.Parameters["ranFunctionId"]), entry)
+}
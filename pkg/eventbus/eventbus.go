@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package eventbus aggregates the events of the UEs, cells, nodes, subscriptions and handovers of the simulation into
+// a single ordered stream, so that external tools can consume one stream instead of watching every store
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger("eventbus")
+
+// Topics of the events
+const (
+	TopicUE           = "ue"
+	TopicCell         = "cell"
+	TopicNode         = "node"
+	TopicSubscription = "subscription"
+	TopicHandover     = "handover"
+)
+
+// Topics all topics of the events
+var Topics = []string{TopicUE, TopicCell, TopicNode, TopicSubscription, TopicHandover}
+
+// DefaultBuffer number of events buffered for each subscriber by default
+const DefaultBuffer = 1024
+
+// Event event of the simulation
+type Event struct {
+	Seq   uint64          `json:"seq"` // position of the event in the stream, starting at 1
+	Time  time.Time       `json:"time"`
+	Topic string          `json:"topic"`
+	Type  string          `json:"type"`
+	Key   string          `json:"key"`             // IMSI, NCGI, GnbID or subscription ID the event is about
+	Value json.RawMessage `json:"value,omitempty"` // state of the entity when the event is published
+}
+
+type subscriber struct {
+	topics map[string]bool
+	ch     chan Event
+}
+
+// Bus dispatches the published events to its subscribers, in the order they are published. Subscribers which do not
+// keep up are dropped rather than slowing down the simulation; their stream ends and they may subscribe again.
+type Bus struct {
+	mu          sync.Mutex
+	seq         uint64
+	next        uint64
+	buffer      int
+	subscribers map[uint64]*subscriber
+}
+
+// NewBus creates a bus buffering up to the given number of events for each subscriber
+func NewBus(buffer int) *Bus {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Bus{
+		buffer:      buffer,
+		subscribers: make(map[uint64]*subscriber),
+	}
+}
+
+// Publish assigns the next sequence number to the event of the given topic and dispatches it to the subscribers of
+// the topic; the value is encoded as JSON right away, so that later changes of the entity do not alter the event.
+// Values shared with the simulation must be encoded by their owner and published using PublishJSON instead.
+func (b *Bus) Publish(topic string, eventType string, key string, value interface{}) {
+	var data json.RawMessage
+	if value != nil {
+		var err error
+		if data, err = json.Marshal(value); err != nil {
+			log.Warnf("Unable to encode %s event %s of %s: %v", topic, eventType, key, err)
+		}
+	}
+	b.PublishJSON(topic, eventType, key, data)
+}
+
+// PublishJSON publishes the event of the given topic as Publish does, with a value already encoded as JSON
+func (b *Bus) PublishJSON(topic string, eventType string, key string, data json.RawMessage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.seq++
+	event := Event{
+		Seq:   b.seq,
+		Time:  time.Now(),
+		Topic: topic,
+		Type:  eventType,
+		Key:   key,
+		Value: data,
+	}
+	for id, sub := range b.subscribers {
+		if len(sub.topics) > 0 && !sub.topics[topic] {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			log.Warnf("Event subscriber %d does not keep up and is dropped", id)
+			b.remove(id)
+		}
+	}
+}
+
+// Subscribe returns a channel receiving the events of the given topics, or of all topics if none is given, published
+// from now on. The channel is closed once the context is done or the subscriber has fallen behind by more than the
+// buffer of the bus.
+func (b *Bus) Subscribe(ctx context.Context, topics ...string) <-chan Event {
+	sub := &subscriber{
+		topics: make(map[string]bool, len(topics)),
+		ch:     make(chan Event, b.buffer),
+	}
+	for _, topic := range topics {
+		sub.topics[topic] = true
+	}
+	b.mu.Lock()
+	b.next++
+	id := b.next
+	b.subscribers[id] = sub
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(id)
+	}()
+	return sub.ch
+}
+
+// Len returns the number of subscribers
+func (b *Bus) Len() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// remove closes the channel of the subscriber, unless it has already been removed; the bus must be locked
+func (b *Bus) remove(id uint64) {
+	if sub, ok := b.subscribers[id]; ok {
+		close(sub.ch)
+		delete(b.subscribers, id)
+	}
+}
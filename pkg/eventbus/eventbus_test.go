@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/audit"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublish(t *testing.T) {
+	bus := NewBus(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	all := bus.Subscribe(ctx)
+	handovers := bus.Subscribe(ctx, TopicHandover)
+	assert.Equal(t, 2, bus.Len())
+
+	bus.Publish(TopicUE, Created, "1", map[string]int{"imsi": 1})
+	bus.Publish(TopicHandover, Executed, "1", Handover{IMSI: 1, Source: 2, Target: 3})
+	bus.Publish(TopicCell, Updated, "2", nil)
+
+	var seqs []uint64
+	for i := 0; i < 3; i++ {
+		seqs = append(seqs, (<-all).Seq)
+	}
+	assert.Equal(t, []uint64{1, 2, 3}, seqs)
+	event := <-handovers
+	assert.Equal(t, uint64(2), event.Seq)
+	assert.Equal(t, TopicHandover, event.Topic)
+	assert.Equal(t, `{"imsi":1,"source":2,"target":3}`, string(event.Value))
+
+	cancel()
+	_, ok := <-all
+	assert.False(t, ok)
+	_, ok = <-handovers
+	assert.False(t, ok)
+	assert.Equal(t, 0, bus.Len())
+}
+
+func TestSlowSubscriber(t *testing.T) {
+	bus := NewBus(2)
+	ch := bus.Subscribe(context.Background())
+	for i := 0; i < 3; i++ {
+		bus.Publish(TopicNode, Updated, "1", nil)
+	}
+	assert.Equal(t, 0, bus.Len())
+	var count int
+	for range ch {
+		count++
+	}
+	assert.Equal(t, 2, count)
+}
+
+func TestCollector(t *testing.T) {
+	ctx := context.Background()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	ueStore := ues.NewUERegistry(1, cellStore, "random")
+	ue := ueStore.ListAllUEs(ctx)[0]
+
+	bus := NewBus(10)
+	ch := bus.Subscribe(ctx, TopicUE, TopicHandover)
+	collector := NewCollector(bus, nodeStore, cellStore, ueStore, audit.NewLog(10))
+	assert.NoError(t, collector.Start())
+	defer collector.Stop()
+
+	next := func() Event {
+		select {
+		case event := <-ch:
+			return event
+		case <-time.After(5 * time.Second):
+			t.Fatal("no event published")
+			return Event{}
+		}
+	}
+
+	// The published value is the state of the UE encoded from the store
+	assert.NoError(t, ueStore.UpdateCell(ctx, ue.IMSI, &model.UECell{NCGI: 84325717505}))
+	event := next()
+	assert.Equal(t, Updated, event.Type)
+	value := model.UE{}
+	assert.NoError(t, json.Unmarshal(event.Value, &value))
+	assert.Equal(t, ue.IMSI, value.IMSI)
+
+	assert.NoError(t, ueStore.UpdateCell(ctx, ue.IMSI, &model.UECell{NCGI: 84325717506}))
+	event = next()
+	assert.Equal(t, TopicHandover, event.Topic)
+	assert.JSONEq(t, `{"imsi":`+event.Key+`,"source":84325717505,"target":84325717506}`, string(event.Value))
+	event = next()
+	assert.Equal(t, TopicUE, event.Topic)
+	assert.Equal(t, Updated, event.Type)
+
+	// Deleted UEs are published as of their deletion
+	_, err := ueStore.Delete(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	event = next()
+	assert.Equal(t, Deleted, event.Type)
+	assert.NotEmpty(t, event.Value)
+}
@@ -0,0 +1,26 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package eventbus
+
+import (
+	gogotypes "github.com/gogo/protobuf/types"
+	eventsapi "github.com/onosproject/ran-simulator/api/events"
+)
+
+// Proto returns the event as the Event message of the events API
+func (e Event) Proto() (*eventsapi.Event, error) {
+	time, err := gogotypes.TimestampProto(e.Time)
+	if err != nil {
+		return nil, err
+	}
+	return &eventsapi.Event{
+		Seq:   e.Seq,
+		Time:  time,
+		Topic: e.Topic,
+		Type:  e.Type,
+		Key:   e.Key,
+		Value: e.Value,
+	}, nil
+}
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package export streams the UE, cell and handover events of the simulation to a NATS server or a Kafka cluster, so
+// that analytics pipelines and digital twins can ingest the simulation ground truth in real time
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/eventbus"
+)
+
+var log = logging.GetLogger("export")
+
+// Formats of the exported events
+const (
+	FormatJSON     = "json"
+	FormatProtobuf = "protobuf" // onos.ransim.events.Event messages, as streamed by the events API
+)
+
+// DefaultTopicPrefix prefix of the topics the events are published to by default
+const DefaultTopicPrefix = "ransim"
+
+const (
+	batchSize     = 256
+	flushInterval = time.Second
+)
+
+// Topics of the events which are exported
+var Topics = []string{eventbus.TopicUE, eventbus.TopicCell, eventbus.TopicHandover}
+
+// Message exported event
+type Message struct {
+	Key   string
+	Value []byte
+}
+
+// Publisher publishes messages to the topics of a broker
+type Publisher interface {
+	// Publish publishes the messages, in order, to the given topic
+	Publish(ctx context.Context, topic string, messages []Message) error
+	// Close closes the connections to the broker
+	Close() error
+}
+
+// NewPublisher returns the publisher of the broker at the given URL: nats://host:port for a NATS server or
+// kafka://host:port,... for the bootstrap brokers of a Kafka cluster. The nats+tls and kafka+tls schemes connect over
+// TLS, verifying the certificates of the brokers with the CA at the given path, or the system roots if none is given.
+// The user information of the URL, user:password or a NATS token, authenticates the connections; with SASL/PLAIN for
+// Kafka. Credentials are only sent over TLS.
+func NewPublisher(brokerURL string, caPath string) (Publisher, error) {
+	u, err := url.Parse(brokerURL)
+	if err != nil {
+		return nil, errors.NewInvalid("invalid broker URL %s: %v", brokerURL, err)
+	}
+	if u.Host == "" {
+		return nil, errors.NewInvalid("invalid broker URL %s: no host", brokerURL)
+	}
+	var tlsConfig *tls.Config
+	if strings.HasSuffix(u.Scheme, "+tls") {
+		if tlsConfig, err = newTLSConfig(caPath); err != nil {
+			return nil, err
+		}
+	} else if u.User != nil {
+		return nil, errors.NewInvalid("invalid broker URL %s: credentials are only sent over TLS", brokerURL)
+	}
+	password, hasPassword := u.User.Password()
+	switch strings.TrimSuffix(u.Scheme, "+tls") {
+	case "nats":
+		credentials := natsCredentials{user: u.User.Username(), password: password}
+		if u.User != nil && !hasPassword {
+			credentials = natsCredentials{token: u.User.Username()}
+		}
+		return newNATSPublisher(u.Host, credentials, tlsConfig), nil
+	case "kafka":
+		credentials := kafkaCredentials{user: u.User.Username(), password: password}
+		return newKafkaPublisher(strings.Split(u.Host, ","), credentials, tlsConfig), nil
+	default:
+		return nil, errors.NewInvalid("unsupported broker URL %s; nats://, nats+tls://, kafka:// and kafka+tls:// are supported", brokerURL)
+	}
+}
+
+// newTLSConfig returns the TLS configuration verifying the certificates of the brokers with the CA at the given path,
+// or the system roots if none is given
+func newTLSConfig(caPath string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caPath == "" {
+		return tlsConfig, nil
+	}
+	ca, err := ioutil.ReadFile(caPath)
+	if err != nil {
+		return nil, errors.NewInvalid("unable to read the CA certificate of the brokers: %v", err)
+	}
+	tlsConfig.RootCAs = x509.NewCertPool()
+	if !tlsConfig.RootCAs.AppendCertsFromPEM(ca) {
+		return nil, errors.NewInvalid("no certificate found in %s", caPath)
+	}
+	return tlsConfig, nil
+}
+
+// Exporter publishes the events of a bus to a broker in batches; a topic is used for each topic of the events, e.g.
+// ransim.handover. Events are dropped rather than slowing down the simulation if the broker cannot keep up.
+type Exporter struct {
+	publisher Publisher
+	format    string
+	prefix    string
+	cancel    context.CancelFunc
+	done      chan struct{}
+	mu        sync.Mutex
+}
+
+// NewExporter creates an exporter publishing the events in the given format to topics with the given prefix
+func NewExporter(publisher Publisher, format string, prefix string) (*Exporter, error) {
+	if format != FormatJSON && format != FormatProtobuf {
+		return nil, errors.NewInvalid("unsupported export format %s; json and protobuf are supported", format)
+	}
+	if prefix == "" {
+		prefix = DefaultTopicPrefix
+	}
+	return &Exporter{
+		publisher: publisher,
+		format:    format,
+		prefix:    prefix,
+	}, nil
+}
+
+// Start starts exporting the events of the given bus
+func (e *Exporter) Start(bus *eventbus.Bus) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+	go e.run(ctx, bus)
+}
+
+// Stop publishes the pending events, stops exporting and closes the publisher
+func (e *Exporter) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+	e.cancel = nil
+	if err := e.publisher.Close(); err != nil {
+		log.Warn(err)
+	}
+}
+
+func (e *Exporter) run(ctx context.Context, bus *eventbus.Bus) {
+	defer close(e.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batches := make(map[string][]Message)
+	count := 0
+	flush := func() {
+		for topic, batch := range batches {
+			if err := e.publisher.Publish(context.Background(), e.prefix+"."+topic, batch); err != nil {
+				log.Warnf("Failed to export %d %s events: %v", len(batch), topic, err)
+			}
+		}
+		batches = make(map[string][]Message)
+		count = 0
+	}
+
+	events := bus.Subscribe(ctx, Topics...)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				flush()
+				if ctx.Err() != nil {
+					return
+				}
+				// The broker did not keep up and the events in between are lost
+				log.Warn("Event export fell behind; events have been dropped")
+				events = bus.Subscribe(ctx, Topics...)
+				continue
+			}
+			value, err := e.encode(event)
+			if err != nil {
+				log.Warn(err)
+				continue
+			}
+			batches[event.Topic] = append(batches[event.Topic], Message{Key: event.Key, Value: value})
+			count++
+			if count >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// encode encodes the event in the format of the exporter
+func (e *Exporter) encode(event eventbus.Event) ([]byte, error) {
+	if e.format == FormatJSON {
+		return json.Marshal(event)
+	}
+	message, err := event.Proto()
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(message)
+}
@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"context"
+	"crypto/tls"
+	"sync"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const kafkaTimeout = 10 * time.Second
+
+// kafkaCredentials credentials the client authenticates with using SASL/PLAIN, if any
+type kafkaCredentials struct {
+	user     string
+	password string
+}
+
+// kafkaPublisher produces records to the topics of a Kafka cluster with the Kafka protocol; the producer is created
+// upon the first publish, so that the simulation starts even if the cluster is not reachable yet, and created again
+// upon the next publish once it failed
+type kafkaPublisher struct {
+	brokers  []string
+	config   *sarama.Config
+	mu       sync.Mutex
+	producer sarama.SyncProducer
+}
+
+// newKafkaPublisher creates a publisher to the cluster with the given bootstrap brokers; the connections use TLS if a
+// TLS configuration is given
+func newKafkaPublisher(brokers []string, credentials kafkaCredentials, tlsConfig *tls.Config) *kafkaPublisher {
+	config := sarama.NewConfig()
+	config.ClientID = "ran-simulator"
+	config.Version = sarama.V1_0_0_0
+	config.Net.DialTimeout = kafkaTimeout
+	config.Net.ReadTimeout = kafkaTimeout
+	config.Net.WriteTimeout = kafkaTimeout
+	config.Producer.RequiredAcks = sarama.WaitForAll
+	config.Producer.Return.Successes = true
+	// Records are hashed to the partitions by key, so that the events of an entity are kept in order
+	config.Producer.Partitioner = sarama.NewHashPartitioner
+	if tlsConfig != nil {
+		config.Net.TLS.Enable = true
+		config.Net.TLS.Config = tlsConfig
+	}
+	if credentials != (kafkaCredentials{}) {
+		config.Net.SASL.Enable = true
+		config.Net.SASL.Mechanism = sarama.SASLTypePlaintext
+		config.Net.SASL.User = credentials.user
+		config.Net.SASL.Password = credentials.password
+	}
+	return &kafkaPublisher{
+		brokers: brokers,
+		config:  config,
+	}
+}
+
+// Publish produces the messages to the topic, keyed by the key of their event, and waits for all the in-sync replicas
+// to acknowledge them
+func (p *kafkaPublisher) Publish(ctx context.Context, topic string, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.producer == nil {
+		producer, err := sarama.NewSyncProducer(p.brokers, p.config)
+		if err != nil {
+			return errors.NewUnavailable("unable to connect to Kafka brokers %v: %v", p.brokers, err)
+		}
+		p.producer = producer
+	}
+	records := make([]*sarama.ProducerMessage, 0, len(messages))
+	for _, message := range messages {
+		records = append(records, &sarama.ProducerMessage{
+			Topic: topic,
+			Key:   sarama.StringEncoder(message.Key),
+			Value: sarama.ByteEncoder(message.Value),
+		})
+	}
+	if err := p.producer.SendMessages(records); err != nil {
+		if producerErrs, ok := err.(sarama.ProducerErrors); ok && len(producerErrs) > 0 {
+			err = producerErrs[0].Err
+		}
+		_ = p.producer.Close()
+		p.producer = nil
+		return errors.NewUnavailable("unable to produce %d records to Kafka topic %s: %v", len(records), topic, err)
+	}
+	return nil
+}
+
+// Close closes the connections to the brokers, if any
+func (p *kafkaPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.producer == nil {
+		return nil
+	}
+	err := p.producer.Close()
+	p.producer = nil
+	return err
+}
@@ -0,0 +1,272 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	natsDialTimeout  = 5 * time.Second
+	natsWriteTimeout = 5 * time.Second
+	// natsFlushTimeout time allowed to the server to acknowledge the messages published before a PING with a PONG
+	natsFlushTimeout = 5 * time.Second
+)
+
+// natsCredentials credentials the client authenticates with; either a user and a password or a token
+type natsCredentials struct {
+	user     string
+	password string
+	token    string
+}
+
+// natsInfo fields of the INFO message of the server which are used by the client
+type natsInfo struct {
+	TLSRequired  bool `json:"tls_required"`
+	AuthRequired bool `json:"auth_required"`
+}
+
+// natsConnect CONNECT message of the client
+type natsConnect struct {
+	Verbose     bool   `json:"verbose"`
+	Pedantic    bool   `json:"pedantic"`
+	TLSRequired bool   `json:"tls_required"`
+	Name        string `json:"name"`
+	Lang        string `json:"lang"`
+	User        string `json:"user,omitempty"`
+	Password    string `json:"pass,omitempty"`
+	Token       string `json:"auth_token,omitempty"`
+}
+
+// natsConn connection to a server; the writes are serialized by its own lock, so that the keep-alive pings of the
+// server are answered while a batch is being published, and bounded by a deadline
+type natsConn struct {
+	conn    net.Conn
+	writeMu sync.Mutex
+	pongs   chan struct{}
+	errs    chan error
+	done    chan struct{}
+}
+
+func (c *natsConn) write(data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(natsWriteTimeout)); err != nil {
+		return err
+	}
+	_, err := c.conn.Write(data)
+	return err
+}
+
+// flush waits for the server to acknowledge the messages written before the last PING, returning the error the server
+// reported for them, if any
+func (c *natsConn) flush(ctx context.Context) error {
+	timer := time.NewTimer(natsFlushTimeout)
+	defer timer.Stop()
+	select {
+	case <-c.pongs:
+		return nil
+	case err := <-c.errs:
+		return err
+	case <-c.done:
+		return errors.NewUnavailable("connection to NATS server closed")
+	case <-timer.C:
+		return errors.NewTimeout("NATS server did not acknowledge the messages within %s", natsFlushTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// read answers the keep-alive pings of the server and dispatches its acknowledgements and errors until the connection
+// is closed
+func (c *natsConn) read(reader *bufio.Reader, address string) {
+	defer close(c.done)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			if err := c.write([]byte("PONG\r\n")); err != nil {
+				_ = c.conn.Close()
+				return
+			}
+		case strings.HasPrefix(line, "PONG"):
+			select {
+			case c.pongs <- struct{}{}:
+			default:
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			err := errors.NewUnavailable("NATS server %s: %s", address, strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+			select {
+			case c.errs <- err:
+			default:
+				log.Warn(err)
+			}
+		}
+	}
+}
+
+// natsPublisher publishes messages with the text protocol of NATS, see https://docs.nats.io/reference/reference-protocols/nats-protocol;
+// the connection is established upon the first publish and re-established upon the next publish once lost. Every
+// batch is followed by a PING, so that the errors the server reports for the batch fail its publish.
+type natsPublisher struct {
+	address     string
+	credentials natsCredentials
+	tlsConfig   *tls.Config
+	mu          sync.Mutex
+	conn        *natsConn
+}
+
+// newNATSPublisher creates a publisher to the server at the given address; the connection is upgraded to TLS if a
+// TLS configuration is given or the server requires it
+func newNATSPublisher(address string, credentials natsCredentials, tlsConfig *tls.Config) *natsPublisher {
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "4222")
+	}
+	return &natsPublisher{
+		address:     address,
+		credentials: credentials,
+		tlsConfig:   tlsConfig,
+	}
+}
+
+// connect connects to the server, reading its INFO message and upgrading the connection to TLS if needed before
+// sending the CONNECT message; the server must acknowledge the CONNECT message, which fails if the credentials are
+// rejected. The publisher must be locked.
+func (p *natsPublisher) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: natsDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", p.address)
+	if err != nil {
+		return errors.NewUnavailable("unable to connect to NATS server %s: %v", p.address, err)
+	}
+	_ = conn.SetDeadline(time.Now().Add(natsDialTimeout))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO") {
+		_ = conn.Close()
+		return errors.NewUnavailable("unexpected greeting from NATS server %s", p.address)
+	}
+	var info natsInfo
+	if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "INFO"))), &info); err != nil {
+		_ = conn.Close()
+		return errors.NewUnavailable("invalid INFO message from NATS server %s: %v", p.address, err)
+	}
+
+	if p.tlsConfig != nil || info.TLSRequired {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if p.tlsConfig != nil {
+			tlsConfig = p.tlsConfig.Clone()
+		}
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(p.address)
+		}
+		tlsConn := tls.Client(conn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			_ = conn.Close()
+			return errors.NewUnavailable("TLS handshake with NATS server %s failed: %v", p.address, err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	} else if p.credentials != (natsCredentials{}) {
+		_ = conn.Close()
+		return errors.NewInvalid("NATS server %s does not use TLS; credentials are not sent in the clear", p.address)
+	}
+	if info.AuthRequired && p.credentials == (natsCredentials{}) {
+		_ = conn.Close()
+		return errors.NewUnauthorized("NATS server %s requires credentials", p.address)
+	}
+
+	connect, err := json.Marshal(natsConnect{
+		TLSRequired: p.tlsConfig != nil || info.TLSRequired,
+		Name:        "ran-simulator",
+		Lang:        "go",
+		User:        p.credentials.user,
+		Password:    p.credentials.password,
+		Token:       p.credentials.token,
+	})
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if _, err := conn.Write([]byte("CONNECT " + string(connect) + "\r\nPING\r\n")); err != nil {
+		_ = conn.Close()
+		return errors.NewUnavailable("unable to connect to NATS server %s: %v", p.address, err)
+	}
+	line, err = reader.ReadString('\n')
+	if err != nil {
+		_ = conn.Close()
+		return errors.NewUnavailable("unable to connect to NATS server %s: %v", p.address, err)
+	}
+	if !strings.HasPrefix(line, "PONG") {
+		_ = conn.Close()
+		return errors.NewUnavailable("NATS server %s rejected the connection: %s", p.address, strings.TrimSpace(line))
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	p.conn = &natsConn{
+		conn:  conn,
+		pongs: make(chan struct{}, 1),
+		errs:  make(chan error, 1),
+		done:  make(chan struct{}),
+	}
+	go p.conn.read(reader, p.address)
+	log.Infof("Connected to NATS server %s", p.address)
+	return nil
+}
+
+// Publish publishes the messages to the subject named after the topic and waits for the server to acknowledge them;
+// the keys of the messages are not used
+func (p *natsPublisher) Publish(ctx context.Context, topic string, messages []Message) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return err
+		}
+	}
+	buf := &bytes.Buffer{}
+	for _, message := range messages {
+		fmt.Fprintf(buf, "PUB %s %d\r\n", topic, len(message.Value))
+		buf.Write(message.Value)
+		buf.WriteString("\r\n")
+	}
+	buf.WriteString("PING\r\n")
+	err := p.conn.write(buf.Bytes())
+	if err == nil {
+		err = p.conn.flush(ctx)
+	}
+	if err != nil {
+		// The state of the connection is unknown; the next publish connects again
+		_ = p.conn.conn.Close()
+		p.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection to the server, if any
+func (p *natsPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.conn.Close()
+	p.conn = nil
+	return err
+}
@@ -0,0 +1,301 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package groundtruth exposes the ground truth of the simulation which the RIC can not observe through E2, such as
+// the true position and best server of the UEs, so that localization and prediction xApps can be evaluated
+package groundtruth
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+const (
+	// sampleInterval period of the samples of the ground truth kept to answer delayed queries
+	sampleInterval = time.Second
+	// MaxDelay oldest ground truth which can be queried
+	MaxDelay = time.Minute
+)
+
+// Truth ground truth of a UE at a given time
+type Truth struct {
+	IMSI               types.IMSI         `json:"imsi"`
+	Time               time.Time          `json:"time"`
+	Location           model.Coordinate   `json:"location"`
+	Heading            uint32             `json:"heading"`
+	Speed              float64            `json:"speed"` // km/h
+	ServingNCGI        types.NCGI         `json:"servingNcgi,omitempty"`
+	BestServer         types.NCGI         `json:"bestServer,omitempty"`         // strongest cell at the location of the UE, free of measurement noise
+	BestServerStrength float64            `json:"bestServerStrength,omitempty"` // RSRP of the best server in dBm
+	Route              []model.Coordinate `json:"route,omitempty"`              // waypoints the UE travels through until its route turns back
+}
+
+// Options degrade the ground truth to emulate an imperfect oracle
+type Options struct {
+	Noise float64       // standard deviation in meters of the error added to the coordinates
+	Delay time.Duration // age of the ground truth, up to MaxDelay; sampled every second
+}
+
+// Oracle computes the ground truth of the UEs and keeps the samples of the last minute
+type Oracle struct {
+	mu         sync.RWMutex
+	cellStore  cells.Store
+	ueStore    ues.Store
+	routeStore routes.Store
+	history    map[types.IMSI][]*Truth
+	cancel     context.CancelFunc
+	done       chan struct{}
+}
+
+// NewOracle creates an oracle of the UEs of the given stores
+func NewOracle(cellStore cells.Store, ueStore ues.Store, routeStore routes.Store) *Oracle {
+	return &Oracle{
+		cellStore:  cellStore,
+		ueStore:    ueStore,
+		routeStore: routeStore,
+		history:    make(map[types.IMSI][]*Truth),
+	}
+}
+
+// Reset makes the oracle operate on the given stores, which replace the previous ones; the samples are discarded
+func (o *Oracle) Reset(cellStore cells.Store, ueStore ues.Store, routeStore routes.Store) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.cellStore = cellStore
+	o.ueStore = ueStore
+	o.routeStore = routeStore
+	o.history = make(map[types.IMSI][]*Truth)
+}
+
+// Start samples the ground truth every second until the oracle is stopped
+func (o *Oracle) Start() {
+	if o.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	o.cancel = cancel
+	o.done = make(chan struct{})
+	go o.run(ctx)
+}
+
+// Stop stops sampling the ground truth
+func (o *Oracle) Stop() {
+	if o.cancel == nil {
+		return
+	}
+	o.cancel()
+	<-o.done
+	o.cancel = nil
+}
+
+func (o *Oracle) run(ctx context.Context) {
+	defer close(o.done)
+	ticker := time.NewTicker(sampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			o.Sample(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Sample records the current ground truth of all UEs and discards the samples older than MaxDelay
+func (o *Oracle) Sample(ctx context.Context) {
+	truths := o.current(ctx)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	history := make(map[types.IMSI][]*Truth, len(truths))
+	for _, truth := range truths {
+		samples := o.history[truth.IMSI]
+		for len(samples) > 0 && truth.Time.Sub(samples[0].Time) > MaxDelay {
+			samples = samples[1:]
+		}
+		history[truth.IMSI] = append(samples, truth)
+	}
+	o.history = history
+}
+
+// Get returns the ground truth of a UE
+func (o *Oracle) Get(ctx context.Context, imsi types.IMSI, options Options) (*Truth, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	var truth *Truth
+	if options.Delay == 0 {
+		o.mu.RLock()
+		ue, err := o.ueStore.Get(ctx, imsi)
+		if err != nil {
+			o.mu.RUnlock()
+			return nil, err
+		}
+		cellList, _ := o.cellStore.List(ctx)
+		truth = o.truth(ctx, ue, cellList, time.Now())
+		o.mu.RUnlock()
+	} else {
+		truth = o.delayed(imsi, time.Now().Add(-options.Delay))
+		if truth == nil {
+			return nil, errors.NewNotFound("no ground truth of UE %d as of %s ago", imsi, options.Delay)
+		}
+	}
+	return options.apply(truth), nil
+}
+
+// List returns the ground truth of all UEs, in ascending order of IMSI; the UEs without ground truth as old as the
+// requested delay are left out
+func (o *Oracle) List(ctx context.Context, options Options) ([]*Truth, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+	var truths []*Truth
+	if options.Delay == 0 {
+		truths = o.current(ctx)
+	} else {
+		at := time.Now().Add(-options.Delay)
+		o.mu.RLock()
+		imsis := make([]types.IMSI, 0, len(o.history))
+		for imsi := range o.history {
+			imsis = append(imsis, imsi)
+		}
+		o.mu.RUnlock()
+		for _, imsi := range imsis {
+			if truth := o.delayed(imsi, at); truth != nil {
+				truths = append(truths, truth)
+			}
+		}
+	}
+
+	result := make([]*Truth, 0, len(truths))
+	for _, truth := range truths {
+		result = append(result, options.apply(truth))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].IMSI < result[j].IMSI })
+	return result, nil
+}
+
+// current returns the current ground truth of all UEs
+func (o *Oracle) current(ctx context.Context) []*Truth {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	now := time.Now()
+	cellList, _ := o.cellStore.List(ctx)
+	ueList := o.ueStore.ListAllUEs(ctx)
+	truths := make([]*Truth, 0, len(ueList))
+	for _, ue := range ueList {
+		truths = append(truths, o.truth(ctx, ue, cellList, now))
+	}
+	return truths
+}
+
+// delayed returns the latest sample of the ground truth of a UE taken at the given time or before, if any
+func (o *Oracle) delayed(imsi types.IMSI, at time.Time) *Truth {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	samples := o.history[imsi]
+	for i := len(samples) - 1; i >= 0; i-- {
+		if !samples[i].Time.After(at) {
+			return samples[i]
+		}
+	}
+	return nil
+}
+
+func (o *Oracle) truth(ctx context.Context, ue *model.UE, cellList []*model.Cell, now time.Time) *Truth {
+	truth := &Truth{
+		IMSI:     ue.IMSI,
+		Time:     now,
+		Location: ue.Location,
+		Heading:  ue.Heading,
+		Speed:    ue.Speed,
+	}
+	if ue.Cell != nil {
+		truth.ServingNCGI = ue.Cell.NCGI
+	}
+	truth.BestServer, truth.BestServerStrength = BestServer(ue.Location, cellList)
+	if route, err := o.routeStore.Get(ctx, ue.IMSI); err == nil {
+		truth.Route = Ahead(route)
+	}
+	return truth
+}
+
+// BestServer returns the cell, among those which have not failed, with the strongest signal at the given location,
+// along with its strength
+func BestServer(location model.Coordinate, cellList []*model.Cell) (types.NCGI, float64) {
+	var best types.NCGI
+	strongest := math.Inf(-1)
+	for _, cell := range cellList {
+		if cell.Failed {
+			continue
+		}
+		if strength := mobility.StrengthAtLocation(location, *cell); strength > strongest {
+			best, strongest = cell.NCGI, strength
+		}
+	}
+	if best == 0 {
+		return 0, 0
+	}
+	return best, strongest
+}
+
+// Ahead returns the waypoints of the route the UE travels through, in order, until the route turns back
+func Ahead(route *model.Route) []model.Coordinate {
+	var points []model.Coordinate
+	if route.Reverse {
+		for i := int(route.NextPoint); i >= 0 && i < len(route.Points); i-- {
+			points = append(points, *route.Points[i])
+		}
+		return points
+	}
+	for i := int(route.NextPoint); i < len(route.Points); i++ {
+		points = append(points, *route.Points[i])
+	}
+	return points
+}
+
+func (options Options) validate() error {
+	if options.Noise < 0 {
+		return errors.NewInvalid("noise must not be negative")
+	}
+	if options.Delay < 0 || options.Delay > MaxDelay {
+		return errors.NewInvalid("delay must be between 0 and %s", MaxDelay)
+	}
+	return nil
+}
+
+// apply returns a copy of the ground truth whose coordinates are moved by a random error, normally distributed
+// along both axes
+func (options Options) apply(truth *Truth) *Truth {
+	if options.Noise == 0 {
+		return truth
+	}
+	noisy := *truth
+	noisy.Location = options.displace(truth.Location)
+	noisy.Route = make([]model.Coordinate, 0, len(truth.Route))
+	for _, point := range truth.Route {
+		noisy.Route = append(noisy.Route, options.displace(point))
+	}
+	return &noisy
+}
+
+func (options Options) displace(c model.Coordinate) model.Coordinate {
+	north := rand.NormFloat64() * options.Noise
+	east := rand.NormFloat64() * options.Noise
+	bearing := math.Mod(math.Atan2(east, north)*180/math.Pi+360, 360)
+	return utils.TargetPoint(c, bearing, math.Hypot(north, east))
+}
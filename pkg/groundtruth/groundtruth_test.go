@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package groundtruth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/mobility"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestOracle(t *testing.T) (*Oracle, ues.Store, routes.Store) {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	cellStore := cells.NewCellRegistry(m.Cells, nodes.NewNodeRegistry(m.Nodes))
+	ueStore := ues.NewUERegistry(m.UECount, cellStore, "connected")
+	routeStore := routes.NewRouteRegistry()
+	return NewOracle(cellStore, ueStore, routeStore), ueStore, routeStore
+}
+
+func TestBestServer(t *testing.T) {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	cellList := make([]*model.Cell, 0, len(m.Cells))
+	for name := range m.Cells {
+		cell := m.Cells[name]
+		cellList = append(cellList, &cell)
+	}
+
+	location := cellList[0].Sector.Center
+	best, strength := BestServer(location, cellList)
+	for _, cell := range cellList {
+		assert.True(t, mobility.StrengthAtLocation(location, *cell) <= strength)
+	}
+
+	// The best server is replaced once it fails
+	for _, cell := range cellList {
+		cell.Failed = cell.NCGI == best
+	}
+	next, _ := BestServer(location, cellList)
+	assert.NotEqual(t, best, next)
+
+	best, strength = BestServer(location, nil)
+	assert.Equal(t, 0.0, strength)
+	assert.Zero(t, best)
+}
+
+func TestAhead(t *testing.T) {
+	points := []*model.Coordinate{{Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}, {Lat: 3, Lng: 3}}
+	assert.Equal(t, []model.Coordinate{{Lat: 2, Lng: 2}, {Lat: 3, Lng: 3}}, Ahead(&model.Route{Points: points, NextPoint: 1}))
+	assert.Equal(t, []model.Coordinate{{Lat: 2, Lng: 2}, {Lat: 1, Lng: 1}}, Ahead(&model.Route{Points: points, NextPoint: 1, Reverse: true}))
+}
+
+func TestOracle(t *testing.T) {
+	ctx := context.Background()
+	oracle, ueStore, routeStore := newTestOracle(t)
+	ue := ueStore.ListAllUEs(ctx)[0]
+	assert.NoError(t, routeStore.Add(ctx, &model.Route{
+		IMSI:      ue.IMSI,
+		Points:    []*model.Coordinate{&ue.Location, {Lat: ue.Location.Lat + 0.01, Lng: ue.Location.Lng}},
+		NextPoint: 1,
+	}))
+
+	truth, err := oracle.Get(ctx, ue.IMSI, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, ue.Location, truth.Location)
+	assert.Equal(t, ue.Cell.NCGI, truth.ServingNCGI)
+	assert.NotZero(t, truth.BestServer)
+	assert.Len(t, truth.Route, 1)
+
+	noisy, err := oracle.Get(ctx, ue.IMSI, Options{Noise: 10})
+	assert.NoError(t, err)
+	assert.NotEqual(t, truth.Location, noisy.Location)
+	assert.True(t, utils.Distance(truth.Location, noisy.Location) < 100)
+	assert.Equal(t, truth.BestServer, noisy.BestServer)
+
+	truths, err := oracle.List(ctx, Options{})
+	assert.NoError(t, err)
+	assert.Len(t, truths, ueStore.Len(ctx))
+
+	// Delayed ground truth is only available once sampled
+	_, err = oracle.Get(ctx, ue.IMSI, Options{Delay: time.Millisecond})
+	assert.True(t, errors.IsNotFound(err))
+	oracle.Sample(ctx)
+	time.Sleep(10 * time.Millisecond)
+	// The store updates the UE in place, so its sampled location is copied before it moves
+	before := ue.Location
+	assert.NoError(t, ueStore.MoveToCoordinate(ctx, ue.IMSI, model.Coordinate{Lat: before.Lat + 0.001, Lng: before.Lng}, 0))
+	delayed, err := oracle.Get(ctx, ue.IMSI, Options{Delay: 5 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, before, delayed.Location)
+	truths, err = oracle.List(ctx, Options{Delay: 5 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Len(t, truths, ueStore.Len(ctx))
+
+	_, err = oracle.Get(ctx, ue.IMSI, Options{Delay: 2 * MaxDelay})
+	assert.True(t, errors.IsInvalid(err))
+	_, err = oracle.Get(ctx, ue.IMSI, Options{Noise: -1})
+	assert.True(t, errors.IsInvalid(err))
+}
@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+)
+
+var log = logging.GetLogger("loadtest")
+
+const (
+	// pacingInterval interval at which the messages due at the configured rate are sent in a burst
+	pacingInterval = 10 * time.Millisecond
+	// reportInterval interval at which the statistics are published to the metrics store
+	reportInterval = time.Second
+)
+
+// Metric names of the load test statistics
+const (
+	SentMetric         = "loadtest.sent"
+	ErrorsMetric       = "loadtest.errors"
+	RateMetric         = "loadtest.rate"
+	LatencyMeanMetric  = "loadtest.latency.mean"
+	LatencyP50Metric   = "loadtest.latency.p50"
+	LatencyP90Metric   = "loadtest.latency.p90"
+	LatencyP99Metric   = "loadtest.latency.p99"
+	LatencyP9999Metric = "loadtest.latency.p99.99"
+	// LatencyBucketMetric is the format of the names of the cumulative latency histogram buckets
+	LatencyBucketMetric = "loadtest.latency.le.%v"
+)
+
+// Generator sends messages at a fixed rate and tracks the latency of each send
+type Generator struct {
+	rate      float64
+	send      func(ctx context.Context) error
+	histogram *Histogram
+	errors    uint64
+}
+
+// NewGenerator creates a generator calling the send function the given number of times per second
+func NewGenerator(rate float64, send func(ctx context.Context) error) *Generator {
+	return &Generator{
+		rate:      rate,
+		send:      send,
+		histogram: NewHistogram(DefaultBuckets),
+	}
+}
+
+// Histogram returns the send latency histogram
+func (g *Generator) Histogram() *Histogram {
+	return g.histogram
+}
+
+// Errors returns the number of failed sends
+func (g *Generator) Errors() uint64 {
+	return atomic.LoadUint64(&g.errors)
+}
+
+// Run sends messages until the context is done; a generator falling behind its rate catches up as fast as possible
+func (g *Generator) Run(ctx context.Context) {
+	ticker := time.NewTicker(pacingInterval)
+	defer ticker.Stop()
+	start := time.Now()
+	var sent uint64
+	for {
+		select {
+		case now := <-ticker.C:
+			due := uint64(g.rate * now.Sub(start).Seconds())
+			for ; sent < due; sent++ {
+				if ctx.Err() != nil {
+					return
+				}
+				sendStart := time.Now()
+				if err := g.send(ctx); err != nil {
+					atomic.AddUint64(&g.errors, 1)
+					log.Debug(err)
+					continue
+				}
+				g.histogram.Observe(time.Since(sendStart))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Report publishes the statistics of the generator as metrics of the given entity until the context is done
+func (g *Generator) Report(ctx context.Context, metricsStore metrics.Store, entityID uint64) {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+	lastCount := g.histogram.Count()
+	lastTime := time.Now()
+	for {
+		select {
+		case now := <-ticker.C:
+			count := g.histogram.Count()
+			rate := float64(count-lastCount) / now.Sub(lastTime).Seconds()
+			lastCount, lastTime = count, now
+			g.publish(ctx, metricsStore, entityID, rate)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (g *Generator) publish(ctx context.Context, metricsStore metrics.Store, entityID uint64, rate float64) {
+	h := g.histogram
+	values := map[string]interface{}{
+		SentMetric:         h.Count(),
+		ErrorsMetric:       g.Errors(),
+		RateMetric:         rate,
+		LatencyMeanMetric:  h.Mean().String(),
+		LatencyP50Metric:   h.Quantile(0.5).String(),
+		LatencyP90Metric:   h.Quantile(0.9).String(),
+		LatencyP99Metric:   h.Quantile(0.99).String(),
+		LatencyP9999Metric: h.Quantile(0.9999).String(),
+	}
+	h.mu.RLock()
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.buckets[i]
+		values[fmt.Sprintf(LatencyBucketMetric, bound)] = cumulative
+	}
+	h.mu.RUnlock()
+
+	for name, value := range values {
+		if err := metricsStore.Set(ctx, entityID, name, value); err != nil {
+			log.Warn(err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultBuckets upper bounds of the default latency histogram buckets
+var DefaultBuckets = []time.Duration{
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	250 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	2500 * time.Microsecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+}
+
+// Histogram latency histogram with fixed buckets; the last bucket counts the samples above the highest bound
+type Histogram struct {
+	mu      sync.RWMutex
+	bounds  []time.Duration
+	buckets []uint64
+	count   uint64
+	sum     time.Duration
+}
+
+// NewHistogram creates a histogram with the given ascending bucket bounds
+func NewHistogram(bounds []time.Duration) *Histogram {
+	return &Histogram{
+		bounds:  bounds,
+		buckets: make([]uint64, len(bounds)+1),
+	}
+}
+
+// Observe adds a sample to the histogram
+func (h *Histogram) Observe(d time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := 0
+	for i < len(h.bounds) && d > h.bounds[i] {
+		i++
+	}
+	h.buckets[i]++
+	h.count++
+	h.sum += d
+}
+
+// Count returns the number of samples
+func (h *Histogram) Count() uint64 {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.count
+}
+
+// Mean returns the mean of the samples
+func (h *Histogram) Mean() time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.count == 0 {
+		return 0
+	}
+	return h.sum / time.Duration(h.count)
+}
+
+// Quantile returns the upper bound of the bucket containing the given quantile of the samples;
+// the quantile is reported as the highest bound if it falls in the last bucket
+func (h *Histogram) Quantile(q float64) time.Duration {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.count == 0 || len(h.bounds) == 0 {
+		return 0
+	}
+	rank := uint64(q * float64(h.count))
+	var cumulative uint64
+	for i, bound := range h.bounds {
+		cumulative += h.buckets[i]
+		if cumulative > rank {
+			return bound
+		}
+	}
+	return h.bounds[len(h.bounds)-1]
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	h := NewHistogram([]time.Duration{time.Millisecond, 10 * time.Millisecond})
+	assert.Equal(t, time.Duration(0), h.Quantile(0.5))
+
+	for i := 0; i < 90; i++ {
+		h.Observe(500 * time.Microsecond)
+	}
+	for i := 0; i < 9; i++ {
+		h.Observe(5 * time.Millisecond)
+	}
+	h.Observe(time.Second)
+
+	assert.Equal(t, uint64(100), h.Count())
+	assert.Equal(t, time.Millisecond, h.Quantile(0.5))
+	assert.Equal(t, 10*time.Millisecond, h.Quantile(0.95))
+	assert.Equal(t, 10*time.Millisecond, h.Quantile(0.999))
+}
+
+func TestGenerator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	sent := 0
+	generator := NewGenerator(1000, func(ctx context.Context) error {
+		sent++
+		if sent%10 == 0 {
+			return fmt.Errorf("failed")
+		}
+		return nil
+	})
+	generator.Run(ctx)
+
+	// about 500 messages are sent in 500ms at 1000 messages per second
+	assert.InDelta(t, 500, sent, 100)
+	assert.Equal(t, uint64(sent/10), generator.Errors())
+	assert.Equal(t, uint64(sent-sent/10), generator.Histogram().Count())
+
+	store := metrics.NewMetricsStore()
+	generator.publish(context.Background(), store, 1, 1000)
+	value, ok := store.Get(context.Background(), 1, SentMetric)
+	assert.True(t, ok)
+	assert.Equal(t, generator.Histogram().Count(), value)
+	_, ok = store.Get(context.Background(), 1, fmt.Sprintf(LatencyBucketMetric, time.Millisecond))
+	assert.True(t, ok)
+}
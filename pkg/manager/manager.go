@@ -6,30 +6,87 @@ package manager
 
 import (
 	"context"
+	"fmt"
 	"github.com/onosproject/ran-simulator/pkg/mobility"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/onos-lib-go/pkg/northbound"
+	analysisapi "github.com/onosproject/ran-simulator/pkg/api/analysis"
+	auditapi "github.com/onosproject/ran-simulator/pkg/api/audit"
+	"github.com/onosproject/ran-simulator/pkg/api/auth"
 	cellapi "github.com/onosproject/ran-simulator/pkg/api/cells"
+	celltransactionapi "github.com/onosproject/ran-simulator/pkg/api/celltransactions"
+	controllerapi "github.com/onosproject/ran-simulator/pkg/api/controllers"
+	coordinationapi "github.com/onosproject/ran-simulator/pkg/api/coordination"
+	e2setupapi "github.com/onosproject/ran-simulator/pkg/api/e2setup"
+	eventsapi "github.com/onosproject/ran-simulator/pkg/api/events"
+	"github.com/onosproject/ran-simulator/pkg/api/feed"
+	"github.com/onosproject/ran-simulator/pkg/api/gateway"
+	groundtruthapi "github.com/onosproject/ran-simulator/pkg/api/groundtruth"
+	"github.com/onosproject/ran-simulator/pkg/api/health"
+	identityapi "github.com/onosproject/ran-simulator/pkg/api/identities"
+	indicationapi "github.com/onosproject/ran-simulator/pkg/api/indications"
+	interferenceapi "github.com/onosproject/ran-simulator/pkg/api/interference"
 	metricsapi "github.com/onosproject/ran-simulator/pkg/api/metrics"
 	modelapi "github.com/onosproject/ran-simulator/pkg/api/model"
+	monitorapi "github.com/onosproject/ran-simulator/pkg/api/monitor"
+	nameapi "github.com/onosproject/ran-simulator/pkg/api/names"
 	nodeapi "github.com/onosproject/ran-simulator/pkg/api/nodes"
+	outageapi "github.com/onosproject/ran-simulator/pkg/api/outages"
+	predictionapi "github.com/onosproject/ran-simulator/pkg/api/predictions"
+	restartapi "github.com/onosproject/ran-simulator/pkg/api/restarts"
 	routeapi "github.com/onosproject/ran-simulator/pkg/api/routes"
+	scalingapi "github.com/onosproject/ran-simulator/pkg/api/scaling"
+	"github.com/onosproject/ran-simulator/pkg/api/server"
+	suspensionapi "github.com/onosproject/ran-simulator/pkg/api/suspensions"
+	trackingareaapi "github.com/onosproject/ran-simulator/pkg/api/trackingareas"
 	"github.com/onosproject/ran-simulator/pkg/api/trafficsim"
+	transferapi "github.com/onosproject/ran-simulator/pkg/api/transfer"
+	uecontrolapi "github.com/onosproject/ran-simulator/pkg/api/uecontrol"
+	uegroupapi "github.com/onosproject/ran-simulator/pkg/api/uegroups"
 	ueapi "github.com/onosproject/ran-simulator/pkg/api/ues"
+	"github.com/onosproject/ran-simulator/pkg/audit"
+	"github.com/onosproject/ran-simulator/pkg/coordination"
 	"github.com/onosproject/ran-simulator/pkg/e2agent/agents"
+	"github.com/onosproject/ran-simulator/pkg/eventbus"
+	"github.com/onosproject/ran-simulator/pkg/export"
+	"github.com/onosproject/ran-simulator/pkg/groundtruth"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/monitor"
+	"github.com/onosproject/ran-simulator/pkg/outage"
+	"github.com/onosproject/ran-simulator/pkg/scaling"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/persistence"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/topo"
+	"github.com/onosproject/ran-simulator/pkg/tracing"
+	"github.com/onosproject/ran-simulator/pkg/utils/cacert"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 )
 
 var log = logging.GetLogger("manager")
 
+// coordinationTimeout time the shards wait for shard 0 to synchronize with it upon startup
+const coordinationTimeout = 30 * time.Second
+
 // Config is a manager configuration
 type Config struct {
 	CAPath              string
@@ -40,6 +97,24 @@ type Config struct {
 	ModelName           string
 	MetricName          string
 	HOLogic             string
+	RESTPort            int
+	FeedFrameRate       int
+	WatchModel          bool
+	PersistencePath     string
+	PersistenceInterval time.Duration
+	Shard               int      // shard of the instance; derived from the ordinal of the host name if negative
+	AuthEnabled         bool     // authenticate the northbound API requests
+	OperatorGroups      []string // groups of the users allowed to mutate the simulation when authentication is enabled
+	TransferPort        int      // port of the internal gRPC server through which the other shards hand UEs over
+	TopoAddress         string   // onos-topo service the nodes and cells of the model are imported from, if any
+	TopoExport          bool     // export the simulated nodes and cells to the onos-topo service
+	ASN1SelfCheck       bool     // decode back the encoded E2SM payloads and log the mismatches with their sources
+	OTLPEndpoint        string   // OTLP/HTTP endpoint of the collector the traces of the E2 procedures are exported to, if any
+	AuditLog            string   // JSON lines file the audit log of the E2 procedures is appended to, if any
+	ExportURL           string   // NATS server or Kafka brokers the UE, cell and handover events are exported to, if any
+	ExportCAPath        string   // CA certificate of the brokers the events are exported to over TLS; system roots if empty
+	ExportFormat        string   // encoding of the exported events, json or protobuf
+	ExportTopicPrefix   string   // prefix of the topics the events are exported to
 }
 
 // NewManager creates a new manager
@@ -53,11 +128,27 @@ func NewManager(config *Config) (*Manager, error) {
 		}
 	}
 
+	if config.ASN1SelfCheck {
+		log.Warn("ASN.1 self check is enabled; E2SM payloads are decoded back as they are encoded")
+		selfcheck.Enable(modelPluginRegistry)
+	}
+
+	if config.OTLPEndpoint != "" {
+		tracing.Configure(config.OTLPEndpoint, "ran-simulator")
+	}
+
+	if config.AuditLog != "" {
+		if err := audit.Default().Open(config.AuditLog); err != nil {
+			return nil, err
+		}
+	}
+
 	mgr := &Manager{
 		config:              *config,
 		agents:              nil,
 		model:               &model.Model{},
 		modelPluginRegistry: modelPluginRegistry,
+		health:              health.NewService(),
 	}
 
 	return mgr, nil
@@ -70,13 +161,44 @@ type Manager struct {
 	agents              *agents.E2Agents
 	model               *model.Model
 	modelPluginRegistry modelplugins.ModelRegistry
-	server              *northbound.Server
+	server              *server.Server
+	authorizer          *auth.Authorizer
+	gateway             *gateway.Gateway
+	health              *health.Service
 	nodeStore           nodes.Store
 	cellStore           cells.Store
 	ueStore             ues.Store
 	routeStore          routes.Store
 	metricsStore        metrics.Store
 	mobilityDriver      mobility.Driver
+	kv                  *persistence.KV
+	persister           *persistence.Persister
+	scaler              *scaling.Scaler
+	controllerHandler   *controllerapi.Handler
+	e2SetupHandler      *e2setupapi.Handler
+	ueGroupHandler      *uegroupapi.Handler
+	ueControlHandler    *uecontrolapi.Handler
+	interferenceHandler *interferenceapi.Handler
+	identityHandler     *identityapi.Handler
+	nameHandler         *nameapi.Handler
+	cellTxHandler       *celltransactionapi.Handler
+	trackingAreaHandler *trackingareaapi.Handler
+	restartHandler      *restartapi.Handler
+	suspensionHandler   *suspensionapi.Handler
+	indicationInjector  *indicationapi.Injector
+	bus                 *eventbus.Bus
+	collector           *eventbus.Collector
+	exporter            *export.Exporter
+	topoConn            *grpc.ClientConn
+	topoExporter        *topo.Exporter
+	monitor             *monitor.Monitor
+	outages             *outage.Scheduler
+	shard               uint
+	coordinator         *coordination.Coordinator
+	transferClient      *transferapi.Client
+	transferServer      *northbound.Server
+	oracle              *groundtruth.Oracle
+	modelMu             sync.Mutex // serializes the loading and reloading of the model
 }
 
 // Run starts the manager and the associated services
@@ -95,9 +217,74 @@ func (m *Manager) Start() error {
 		log.Error(err)
 		return err
 	}
+	if m.config.TopoAddress != "" {
+		err = m.importTopo(context.Background())
+		if err != nil {
+			log.Error(err)
+			return err
+		}
+	}
+	err = m.partitionModel(m.model)
+	if err != nil {
+		log.Error(err)
+		return err
+	}
 
 	m.initModelStores()
 	m.initMetricStore()
+	m.scaler = scaling.NewScaler(m.model, m.nodeStore, m.cellStore)
+	m.controllerHandler = controllerapi.NewHandler(m.model, m.nodeStore)
+	m.e2SetupHandler = e2setupapi.NewHandler(m.nodeStore)
+	m.ueGroupHandler = uegroupapi.NewHandler(m.ueStore, m.routeStore)
+	m.interferenceHandler = interferenceapi.NewHandler(m.cellStore)
+	m.identityHandler = identityapi.NewHandler(m.ueStore, m.routeStore)
+	m.nameHandler = nameapi.NewHandler(m.nodeStore, m.cellStore)
+	m.cellTxHandler = celltransactionapi.NewHandler(m.cellStore)
+	m.trackingAreaHandler = trackingareaapi.NewHandler(m.cellStore, m.ueStore)
+	m.restartHandler = restartapi.NewHandler()
+	m.suspensionHandler = suspensionapi.NewHandler()
+	m.indicationInjector = indicationapi.NewInjector()
+	m.monitor = monitor.NewMonitor(m.model.Monitor, m.metricsStore)
+	m.registerMonitorProbes()
+	m.outages = outage.NewScheduler(m.cellStore, m.model.Outages)
+	m.coordinator = coordination.NewCoordinator(m.model.Sharding, m.shard)
+	m.oracle = groundtruth.NewOracle(m.cellStore, m.ueStore, m.routeStore)
+	m.bus = eventbus.NewBus(eventbus.DefaultBuffer)
+	m.collector = eventbus.NewCollector(m.bus, m.nodeStore, m.cellStore, m.ueStore, audit.Default())
+	if err := m.collector.Start(); err != nil {
+		return err
+	}
+	if err := m.startExport(); err != nil {
+		return err
+	}
+
+	// Resume the persisted simulation state, if any
+	err = m.startPersistence(context.Background())
+	if err != nil {
+		return err
+	}
+
+	// Export the nodes and cells to onos-topo, including the restored ones
+	err = m.startTopoExport(context.Background())
+	if err != nil {
+		return err
+	}
+
+	m.mobilityDriver = mobility.NewMobilityDriver(m.cellStore, m.routeStore, m.ueStore, m.metricsStore, m.model.APIKey, m.config.HOLogic, m.model.UECountPerCell, m.model.Rrc, m.model.MeasurementNoise, m.model.DualConnectivity, m.model.CarrierAggregation, m.model.Handover, m.model.Rach, m.model.Interference, m.model.Uplink, m.model.Throughput, m.model.Scheduler, m.model.TrafficProfile, m.model.Regions, m.model.CellSearchRadius, m.model.RrcStateChangesDisabled, m.model.WayPointRoute)
+	m.ueControlHandler = uecontrolapi.NewHandler(m.ueStore, m.routeStore, m.mobilityDriver)
+
+	// UEs moving out of the cells of this shard are handed over to the cells of the other shards
+	if m.model.Sharding.IsPeered() {
+		tlsConfig, err := cacert.NewMutualTLSConfig(m.config.CAPath, m.config.KeyPath, m.config.CertPath)
+		if err != nil {
+			return err
+		}
+		m.transferClient = transferapi.NewClient(m.model.Sharding, tlsConfig)
+		m.mobilityDriver.SetRemoteCells(m.model.RemoteCells, m.transferClient.Transfer)
+		if err := m.startTransferServer(); err != nil {
+			return err
+		}
+	}
 
 	// Start gRPC server
 	err = m.startNorthboundServer()
@@ -105,26 +292,151 @@ func (m *Manager) Start() error {
 		return err
 	}
 
-	m.mobilityDriver = mobility.NewMobilityDriver(m.cellStore, m.routeStore, m.ueStore, m.model.APIKey, m.config.HOLogic, m.model.UECountPerCell, m.model.RrcStateChangesDisabled, m.model.WayPointRoute)
-	// TODO: Make initial speeds configurable
-	m.mobilityDriver.GenerateRoutes(context.Background(), 720000, 1080000, 20000, m.model.RouteEndPoints, m.model.DirectRoute)
+	// Start REST gateway
+	err = m.startGateway()
+	if err != nil {
+		return err
+	}
+
+	if m.model.LoadTest.IsEnabled() {
+		// Load tests send synthetic indications, so no routes are generated and the UEs are not moved around
+		log.Infof("Running in load test mode with %.1f indications per second", m.model.LoadTest.Rate)
+	} else {
+		// TODO: Make initial speeds configurable
+		m.mobilityDriver.GenerateRoutes(context.Background(), 720000, 1080000, 20000, m.model.RouteEndPoints, m.model.Regions, m.model.DirectRoute, m.model.Mobility)
+	}
 	m.mobilityDriver.Start(context.Background())
 
+	// The sharded instances share the start of the scenario, to which the outages are relative
+	m.coordinator.Start(coordinationTimeout)
+	m.outages.Start(m.coordinator.Epoch())
+	m.oracle.Start()
+
 	// Start E2 agents
 	err = m.startE2Agents()
 	if err != nil {
 		return err
 	}
+	m.monitor.Start()
 
+	// Add the clusters of nodes requested at startup; their agents are started as the nodes are added
+	if m.model.Scaling.Clusters > 0 {
+		if _, err := m.scaler.Scale(context.Background(), m.model.Scaling.Clusters); err != nil {
+			return err
+		}
+	}
+
+	// The model is loaded and all agents have completed their E2 setup; agents that lose their
+	// connection later keep retrying in the background without affecting readiness
+	m.health.SetReady(true)
+
+	if m.config.WatchModel {
+		m.watchModel()
+	}
 	return nil
 }
 
 // Close kills the channels and manager related objects
 func (m *Manager) Close() {
 	log.Info("Closing Manager")
+	m.health.SetReady(false)
 	m.stopE2Agents()
+	m.stopGateway()
 	m.stopNorthboundServer()
 	m.mobilityDriver.Stop()
+	m.stopPersistence()
+	m.stopTopoExport()
+	m.monitor.Stop()
+	m.outages.Stop()
+	m.coordinator.Stop()
+	if m.transferClient != nil {
+		m.transferClient.Close()
+	}
+	if m.transferServer != nil {
+		m.transferServer.Stop()
+	}
+	m.oracle.Stop()
+	m.collector.Stop()
+	m.stopExport()
+	tracing.Shutdown()
+	if err := audit.Default().Close(); err != nil {
+		log.Warn(err)
+	}
+}
+
+// Shutdown gracefully stops the simulator within the deadline of the given context: the UEs stop moving, the
+// subscriptions are cancelled and every node is removed from the RIC and disconnected, so that no half-open
+// association is left behind, before the simulation state is persisted
+func (m *Manager) Shutdown(ctx context.Context) error {
+	log.Info("Shutting down Manager")
+	m.health.SetReady(false)
+	if m.mobilityDriver != nil {
+		m.mobilityDriver.Stop()
+	}
+	var err error
+	if m.agents != nil {
+		if err = m.agents.Shutdown(ctx); err != nil {
+			log.Warn(err)
+		}
+	}
+	m.stopGateway()
+	if m.server != nil {
+		m.stopNorthboundServer()
+	}
+	m.stopPersistence()
+	m.stopTopoExport()
+	if m.monitor != nil {
+		m.monitor.Stop()
+	}
+	if m.outages != nil {
+		m.outages.Stop()
+	}
+	if m.coordinator != nil {
+		m.coordinator.Stop()
+	}
+	if m.transferClient != nil {
+		m.transferClient.Close()
+	}
+	if m.transferServer != nil {
+		m.transferServer.Stop()
+	}
+	if m.oracle != nil {
+		m.oracle.Stop()
+	}
+	if m.collector != nil {
+		m.collector.Stop()
+	}
+	m.stopExport()
+	tracing.Shutdown()
+	if err := audit.Default().Close(); err != nil {
+		log.Warn(err)
+	}
+	return err
+}
+
+// partitionModel keeps only the nodes owned by the shard of this instance if the model is sharded
+func (m *Manager) partitionModel(mdl *model.Model) error {
+	if !mdl.Sharding.IsEnabled() {
+		return nil
+	}
+	shard := m.config.Shard
+	if shard < 0 {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return err
+		}
+		// Instances deployed as a stateful set are named <name>-<ordinal>
+		shard, err = strconv.Atoi(hostname[strings.LastIndex(hostname, "-")+1:])
+		if err != nil {
+			return errors.NewInvalid("unable to derive the shard from host name %s", hostname)
+		}
+	}
+	if err := mdl.Partition(uint(shard)); err != nil {
+		return err
+	}
+	m.shard = uint(shard)
+	log.Infof("Simulating shard %d of %d with %d nodes, %d cells and %d UEs", shard, mdl.Sharding.Shards, len(mdl.Nodes), len(mdl.Cells), mdl.UECount)
+	return nil
 }
 
 func (m *Manager) initModelStores() {
@@ -135,7 +447,7 @@ func (m *Manager) initModelStores() {
 	m.cellStore = cells.NewCellRegistry(m.model.Cells, m.nodeStore)
 
 	// Create the UE registry primed with the specified number of UEs
-	m.ueStore = ues.NewUERegistry(m.model.UECount, m.cellStore, m.model.InitialRrcState)
+	m.ueStore = ues.NewUERegistry(m.model.UECount, m.cellStore, m.model.InitialRrcState, ues.WithPlacement(m.model.UEPlacement))
 
 	// Create an empty route registry
 	m.routeStore = routes.NewRouteRegistry()
@@ -146,24 +458,137 @@ func (m *Manager) initMetricStore() {
 	m.metricsStore = metrics.NewMetricsStore()
 }
 
+// startPersistence restores the persisted simulation state and starts persisting the stores if a persistence
+// path is configured; the state is only restored once, when the simulator starts
+func (m *Manager) startPersistence(ctx context.Context) error {
+	if m.config.PersistencePath == "" {
+		return nil
+	}
+	if m.kv == nil {
+		var err error
+		m.kv, err = persistence.OpenKV(m.config.PersistencePath)
+		if err != nil {
+			return err
+		}
+		m.persister = persistence.NewPersister(m.kv, m.config.PersistenceInterval, m.nodeStore, m.cellStore, m.ueStore, m.metricsStore)
+		restored, err := m.persister.Restore(ctx)
+		if err != nil {
+			return err
+		}
+		if restored {
+			log.Infof("Resuming the simulation state persisted in %s", m.config.PersistencePath)
+			m.syncModel(ctx)
+		}
+	} else {
+		m.persister = persistence.NewPersister(m.kv, m.config.PersistenceInterval, m.nodeStore, m.cellStore, m.ueStore, m.metricsStore)
+	}
+	return m.persister.Start()
+}
+
+// importTopo replaces the nodes and cells of the model with the E2 nodes and cells of onos-topo
+func (m *Manager) importTopo(ctx context.Context) error {
+	log.Infof("Importing the nodes and cells of onos-topo at %s", m.config.TopoAddress)
+	conn, err := topo.Connect(ctx, m.config.TopoAddress)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	return topo.Import(ctx, topoapi.NewTopoClient(conn), m.model)
+}
+
+// startTopoExport starts exporting the nodes and cells of the stores to onos-topo if enabled; the entities of the
+// previous stores which are no longer present are removed
+func (m *Manager) startTopoExport(ctx context.Context) error {
+	if !m.config.TopoExport {
+		return nil
+	}
+	if m.topoExporter == nil {
+		if m.config.TopoAddress == "" {
+			return errors.NewInvalid("no onos-topo address given to export the nodes and cells to")
+		}
+		conn, err := topo.Connect(ctx, m.config.TopoAddress)
+		if err != nil {
+			return err
+		}
+		m.topoConn = conn
+		m.topoExporter = topo.NewExporter(topoapi.NewTopoClient(conn))
+	}
+	return m.topoExporter.Start(m.nodeStore, m.cellStore)
+}
+
+func (m *Manager) stopTopoExport() {
+	if m.topoExporter != nil {
+		m.topoExporter.Stop()
+	}
+	if m.topoConn != nil {
+		if err := m.topoConn.Close(); err != nil {
+			log.Warn(err)
+		}
+		m.topoConn = nil
+		m.topoExporter = nil
+	}
+}
+
+// syncModel replaces the nodes and cells of the model with those of the stores, so that agents are created for
+// the restored nodes
+func (m *Manager) syncModel(ctx context.Context) {
+	nodeList, err := m.nodeStore.List(ctx)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	cellList, err := m.cellStore.List(ctx)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	m.model.Nodes = make(map[string]model.Node, len(nodeList))
+	for _, node := range nodeList {
+		m.model.Nodes[fmt.Sprintf("node%d", node.GnbID)] = *node
+	}
+	m.model.Cells = make(map[string]model.Cell, len(cellList))
+	for _, cell := range cellList {
+		m.model.Cells[fmt.Sprintf("cell%d", cell.NCGI)] = *cell
+	}
+}
+
+func (m *Manager) stopPersistence() {
+	if m.persister != nil {
+		m.persister.Stop()
+	}
+	if m.kv != nil {
+		if err := m.kv.Close(); err != nil {
+			log.Warn(err)
+		}
+	}
+}
+
 // startSouthboundServer starts the northbound gRPC server
 func (m *Manager) startNorthboundServer() error {
-	m.server = northbound.NewServer(northbound.NewServerCfg(
+	m.server = server.NewServer(northbound.NewServerCfg(
 		m.config.CAPath,
 		m.config.KeyPath,
 		m.config.CertPath,
 		int16(m.config.GRPCPort),
 		true,
-		northbound.SecurityConfig{}))
+		northbound.SecurityConfig{
+			AuthenticationEnabled: m.config.AuthEnabled,
+		}))
 
+	// Authenticated users may only mutate the simulation if they are operators
+	authorizer := auth.NewAuthorizer(m.config.AuthEnabled, m.config.OperatorGroups)
+	m.authorizer = authorizer
 	m.server.AddService(logging.Service{})
-	m.server.AddService(nodeapi.NewService(m.nodeStore, m.model.PlmnID))
-	m.server.AddService(cellapi.NewService(m.cellStore))
-	m.server.AddService(trafficsim.NewService(m.model, m.cellStore, m.ueStore))
-	m.server.AddService(metricsapi.NewService(m.metricsStore))
-	m.server.AddService(ueapi.NewService(m.ueStore))
-	m.server.AddService(routeapi.NewService(m.routeStore))
-	m.server.AddService(modelapi.NewService(m))
+	m.server.AddService(m.health)
+	m.server.AddService(nodeapi.NewService(m.nodeStore, m.model.PlmnID, authorizer))
+	m.server.AddService(cellapi.NewService(m.cellStore, authorizer))
+	m.server.AddService(trafficsim.NewService(m.model, m.cellStore, m.ueStore, authorizer))
+	m.server.AddService(metricsapi.NewService(m.metricsStore, authorizer))
+	m.server.AddService(ueapi.NewService(m.ueStore, authorizer))
+	m.server.AddService(routeapi.NewService(m.routeStore, authorizer))
+	m.server.AddService(modelapi.NewService(m, authorizer))
+	m.server.AddService(eventsapi.NewService(m.bus, authorizer))
+	m.server.AddService(indicationapi.NewService(m.indicationInjector, authorizer))
 
 	doneCh := make(chan error)
 	go func() {
@@ -178,6 +603,119 @@ func (m *Manager) startNorthboundServer() error {
 	return <-doneCh
 }
 
+// startTransferServer starts the internal gRPC server through which the other shards hand UEs over to this shard. The
+// shards carry no user token, so rather than being authenticated as the northbound API users are, they must present a
+// client certificate issued by the CA of the deployment.
+func (m *Manager) startTransferServer() error {
+	m.transferServer = northbound.NewServer(northbound.NewServerCfg(
+		m.config.CAPath,
+		m.config.KeyPath,
+		m.config.CertPath,
+		int16(m.config.TransferPort),
+		false,
+		northbound.SecurityConfig{}))
+	m.transferServer.AddService(transferapi.NewService(m.mobilityDriver, nil))
+
+	doneCh := make(chan error)
+	go func() {
+		err := m.transferServer.Serve(func(started string) {
+			log.Info("Started transfer server on ", started)
+			close(doneCh)
+		})
+		if err != nil {
+			doneCh <- err
+		}
+	}()
+	return <-doneCh
+}
+
+// startGateway starts the REST gateway for the northbound gRPC APIs if a REST port is configured
+func (m *Manager) startGateway() error {
+	if m.config.RESTPort == 0 {
+		return nil
+	}
+	// The gateway verifies the certificate of the northbound server against the CA the server trusts
+	clientTLSConfig, err := cacert.NewClientTLSConfig(m.config.CAPath)
+	if err != nil {
+		return err
+	}
+	// The gateway presents the certificate the northbound server is configured with
+	serverTLSConfig, err := cacert.NewServerTLSConfig(m.config.KeyPath, m.config.CertPath)
+	if err != nil {
+		return err
+	}
+	m.gateway, err = gateway.NewGateway(fmt.Sprintf("localhost:%d", m.config.GRPCPort), m.config.RESTPort,
+		clientTLSConfig, serverTLSConfig, m.authorizer)
+	if err != nil {
+		return err
+	}
+	m.gateway.Handle("/v1/feed", feed.NewFeed(m.cellStore, m.ueStore, m.config.FeedFrameRate))
+	predictionHandler := predictionapi.NewHandler(m.mobilityDriver.GetPredictionTracker(), m.cellStore, m.ueStore)
+	m.gateway.Handle(predictionapi.Prefix, predictionHandler)
+	m.gateway.Handle(predictionapi.Prefix+"/", predictionHandler)
+	m.gateway.Handle(analysisapi.CoveragePath, analysisapi.NewCoverageHandler(m.cellStore))
+	m.gateway.Handle(analysisapi.HeatmapPath, analysisapi.NewHeatmapHandler(m.cellStore))
+	m.gateway.Handle(scalingapi.Path, scalingapi.NewHandler(m.scaler))
+	m.gateway.Handle(controllerapi.Prefix, m.controllerHandler)
+	m.gateway.Handle(controllerapi.Prefix+"/", m.controllerHandler)
+	m.gateway.Handle(e2setupapi.Prefix, m.e2SetupHandler)
+	m.gateway.Handle(e2setupapi.Prefix+"/", m.e2SetupHandler)
+	m.gateway.Handle(uegroupapi.Prefix+"/", m.ueGroupHandler)
+	m.gateway.Handle(uecontrolapi.Prefix+"/", m.ueControlHandler)
+	m.gateway.Handle(monitorapi.Path, monitorapi.NewHandler(m.monitor))
+	m.gateway.Handle(coordinationapi.Path, coordinationapi.NewHandler(m.coordinator))
+	outageHandler := outageapi.NewHandler(m.outages)
+	m.gateway.Handle(outageapi.Prefix, outageHandler)
+	m.gateway.Handle(outageapi.Prefix+"/", outageHandler)
+	m.gateway.Handle(interferenceapi.Prefix, m.interferenceHandler)
+	m.gateway.Handle(interferenceapi.Prefix+"/", m.interferenceHandler)
+	m.gateway.Handle(identityapi.Path, m.identityHandler)
+	m.gateway.Handle(nameapi.Path, m.nameHandler)
+	m.gateway.Handle(celltransactionapi.Path, m.cellTxHandler)
+	m.gateway.Handle(trackingareaapi.Prefix, m.trackingAreaHandler)
+	m.gateway.Handle(trackingareaapi.Prefix+"/", m.trackingAreaHandler)
+	m.gateway.Handle(restartapi.Prefix+"/", m.restartHandler)
+	m.gateway.Handle(suspensionapi.Prefix, m.suspensionHandler)
+	m.gateway.Handle(suspensionapi.Prefix+"/", m.suspensionHandler)
+	m.gateway.Handle(auditapi.Path, auditapi.NewHandler(audit.Default()))
+	m.gateway.Handle(eventsapi.Path, eventsapi.NewHandler(m.bus))
+	groundtruthHandler := groundtruthapi.NewHandler(m.oracle)
+	m.gateway.Handle(groundtruthapi.Prefix, groundtruthHandler)
+	m.gateway.Handle(groundtruthapi.Prefix+"/", groundtruthHandler)
+	m.gateway.Start()
+	return nil
+}
+
+func (m *Manager) stopGateway() {
+	if m.gateway != nil {
+		m.gateway.Stop()
+	}
+}
+
+// startExport starts exporting the events of the simulation if a broker is configured
+func (m *Manager) startExport() error {
+	if m.config.ExportURL == "" {
+		return nil
+	}
+	publisher, err := export.NewPublisher(m.config.ExportURL, m.config.ExportCAPath)
+	if err != nil {
+		return err
+	}
+	m.exporter, err = export.NewExporter(publisher, m.config.ExportFormat, m.config.ExportTopicPrefix)
+	if err != nil {
+		return err
+	}
+	log.Infof("Exporting events to %s in %s", m.config.ExportURL, m.config.ExportFormat)
+	m.exporter.Start(m.bus)
+	return nil
+}
+
+func (m *Manager) stopExport() {
+	if m.exporter != nil {
+		m.exporter.Stop()
+	}
+}
+
 func (m *Manager) startE2Agents() error {
 	// Create the E2 agents for all simulated nodes and specified controllers
 	var err error
@@ -187,6 +725,9 @@ func (m *Manager) startE2Agents() error {
 		log.Error(err)
 		return err
 	}
+	m.restartHandler.Reset(m.agents)
+	m.suspensionHandler.Reset(m.agents)
+	m.indicationInjector.Reset(m.agents)
 	// Start the E2 agents
 	err = m.agents.Start()
 	if err != nil {
@@ -196,6 +737,35 @@ func (m *Manager) startE2Agents() error {
 	return nil
 }
 
+// registerMonitorProbes makes the monitor sample the sizes of the stores and the running subscriptions of the agents;
+// the stores are looked up upon each sample since loading a model replaces them
+func (m *Manager) registerMonitorProbes() {
+	m.monitor.Register("nodes", func(ctx context.Context) int {
+		n, _ := m.nodeStore.Len(ctx)
+		return n
+	})
+	m.monitor.Register("cells", func(ctx context.Context) int {
+		cellList, _ := m.cellStore.List(ctx)
+		return len(cellList)
+	})
+	m.monitor.Register("ues", func(ctx context.Context) int {
+		return m.ueStore.Len(ctx)
+	})
+	m.monitor.Register("routes", func(ctx context.Context) int {
+		return m.routeStore.Len(ctx)
+	})
+	m.monitor.Register("metrics", func(ctx context.Context) int {
+		entities, _ := m.metricsStore.ListEntities(ctx)
+		return len(entities)
+	})
+	m.monitor.RegisterNodes(func() map[types.GnbID]int {
+		if m.agents == nil {
+			return nil
+		}
+		return m.agents.RunningSubscriptions()
+	})
+}
+
 func (m *Manager) stopE2Agents() {
 	_ = m.agents.Stop()
 }
@@ -207,19 +777,57 @@ func (m *Manager) stopNorthboundServer() {
 // PauseAndClear pauses simulation and clears the model
 func (m *Manager) PauseAndClear(ctx context.Context) {
 	log.Info("Pausing RAN simulator...")
+	m.health.SetReady(false)
 	m.stopE2Agents()
 	m.nodeStore.Clear(ctx)
 	m.cellStore.Clear(ctx)
 	m.metricsStore.Clear(ctx)
+	m.mobilityDriver.GetPredictionTracker().Clear()
 }
 
 // LoadModel loads the new model into the simulator
 func (m *Manager) LoadModel(ctx context.Context, data []byte) error {
+	m.modelMu.Lock()
+	defer m.modelMu.Unlock()
 	m.model = &model.Model{}
 	if err := model.LoadConfigFromBytes(m.model, data); err != nil {
 		return err
 	}
+	if err := m.partitionModel(m.model); err != nil {
+		return err
+	}
 	m.initModelStores()
+	m.scaler.Reset(m.model, m.nodeStore, m.cellStore)
+	m.controllerHandler.Reset(m.model, m.nodeStore)
+	m.e2SetupHandler.Reset(m.nodeStore)
+	m.ueGroupHandler.Reset(m.ueStore, m.routeStore)
+	m.ueControlHandler.Reset(m.ueStore, m.routeStore)
+	m.interferenceHandler.Reset(m.cellStore)
+	m.identityHandler.Reset(m.ueStore, m.routeStore)
+	m.nameHandler.Reset(m.nodeStore, m.cellStore)
+	m.cellTxHandler.Reset(m.cellStore)
+	m.trackingAreaHandler.Reset(m.cellStore, m.ueStore)
+	m.monitor.Reset(m.model.Monitor)
+	m.outages.Reset(m.cellStore, m.model.Outages)
+	m.oracle.Reset(m.cellStore, m.ueStore, m.routeStore)
+	if err := m.collector.Reset(m.nodeStore, m.cellStore, m.ueStore); err != nil {
+		return err
+	}
+
+	// The loaded model replaces the persisted state
+	if m.persister != nil {
+		m.persister.Stop()
+		if err := m.startPersistence(ctx); err != nil {
+			return err
+		}
+	}
+	// The loaded model replaces the exported nodes and cells
+	if m.topoExporter != nil {
+		m.topoExporter.Stop()
+		if err := m.startTopoExport(ctx); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -229,6 +837,95 @@ func (m *Manager) LoadMetrics(ctx context.Context, name string, data []byte) err
 	return nil
 }
 
+// ReloadModel applies the differences between the running model and the given one without restarting the simulation
+func (m *Manager) ReloadModel(ctx context.Context, data []byte) error {
+	newModel := &model.Model{}
+	if err := model.LoadConfigFromBytes(newModel, data); err != nil {
+		return err
+	}
+	if err := m.partitionModel(newModel); err != nil {
+		return err
+	}
+
+	m.modelMu.Lock()
+	defer m.modelMu.Unlock()
+
+	// The PLMN of the simulation is part of the identity of its nodes, cells and UEs, which a reload does not change
+	if newModel.PlmnID != m.model.PlmnID || !reflect.DeepEqual(newModel.PlmnIDs, m.model.PlmnIDs) {
+		return errors.NewInvalid("the PLMN IDs of a running simulation can not be changed by a reload; load the model instead")
+	}
+
+	diff := model.Compare(m.model, newModel)
+	if diff.IsEmpty() {
+		log.Info("Reloaded model has no changes")
+		return nil
+	}
+	log.Infof("Reloading model: %d/%d/%d nodes added/removed/updated, %d/%d/%d cells added/removed/updated, %d controllers changed",
+		len(diff.AddedNodes), len(diff.RemovedNodes), len(diff.UpdatedNodes),
+		len(diff.AddedCells), len(diff.RemovedCells), len(diff.UpdatedCells), len(diff.ChangedControllers))
+
+	// Nodes are removed first and re-created last so that their agents are restarted using the new model
+	for _, node := range append(diff.RemovedNodes, diff.UpdatedNodes...) {
+		if _, err := m.nodeStore.Delete(ctx, node.GnbID); err != nil {
+			log.Warn(err)
+		}
+	}
+	for _, cell := range diff.RemovedCells {
+		if _, err := m.cellStore.Delete(ctx, cell.NCGI); err != nil {
+			log.Warn(err)
+		}
+	}
+	for _, cell := range diff.UpdatedCells {
+		cell := cell // avoids scopelint issue
+		if err := m.cellStore.Update(ctx, &cell); err != nil {
+			log.Warn(err)
+		}
+	}
+	for _, cell := range diff.AddedCells {
+		cell := cell // avoids scopelint issue
+		if err := m.cellStore.Add(ctx, &cell); err != nil {
+			log.Warn(err)
+		}
+	}
+
+	// The running agents read the model they were started with, which is never modified, so the new model replaces
+	// it rather than being copied over it; the agents of the re-created nodes are started with the new model
+	m.model = newModel
+	m.scaler.Reset(m.model, m.nodeStore, m.cellStore)
+	m.controllerHandler.Reset(m.model, m.nodeStore)
+	if m.agents != nil {
+		m.agents.SetModel(m.model)
+	}
+
+	for _, node := range append(diff.AddedNodes, diff.UpdatedNodes...) {
+		node := node // avoids scopelint issue
+		if err := m.nodeStore.Add(ctx, &node); err != nil {
+			log.Warn(err)
+		}
+	}
+	if diff.UECountChanged {
+		m.ueStore.SetUECount(ctx, diff.UECount)
+	}
+	return nil
+}
+
+// watchModel reloads the model whenever the model file changes
+func (m *Manager) watchModel() {
+	log.Infof("Watching model file %s for changes", viper.ConfigFileUsed())
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		log.Infof("Model file %s changed", e.Name)
+		data, err := ioutil.ReadFile(e.Name)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		if err := m.ReloadModel(context.Background(), data); err != nil {
+			log.Warn(err)
+		}
+	})
+	viper.WatchConfig()
+}
+
 // Resume resume the simulation
 func (m *Manager) Resume(ctx context.Context) {
 	log.Info("Resuming RAN simulator...")
@@ -238,5 +935,8 @@ func (m *Manager) Resume(ctx context.Context) {
 		m.stopNorthboundServer()
 		_ = m.startNorthboundServer()
 	}()
-	_ = m.startE2Agents()
+	if err := m.startE2Agents(); err != nil {
+		return
+	}
+	m.health.SetReady(true)
 }
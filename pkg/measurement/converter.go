@@ -100,14 +100,28 @@ func (c *measReportConverter) Convert(ctx context.Context, ue *model.UE) device.
 	}
 	sCell := device.NewCell(id.NewECGI(uint64(sCellInStore.NCGI)),
 		c.convertA3Offset(sCellInStore.MeasurementParams.EventA3Params.A3Offset),
-		c.convertHysteresis(sCellInStore.MeasurementParams.Hysteresis),
+		c.convertHysteresis(ue.MeasReport.GetHysteresis(sCellInStore.MeasurementParams.Hysteresis)),
 		c.convertQOffset(sCellInStore.MeasurementParams.PCellIndividualOffset),
 		c.convertQOffset(sCellInStore.MeasurementParams.FrequencyOffset),
 		c.convertTimeToTrigger(sCellInStore.MeasurementParams.TimeToTrigger))
 
+	// Event A3 compares the RSRQ of the cells instead of their RSRP if so configured for the UE
+	quantity := func(rsrp float64) float64 {
+		return rsrp
+	}
+	if ue.MeasReport.GetTriggerQuantity() == model.TriggerQuantityRSRQ {
+		rsrps := []float64{ue.Cell.Strength}
+		for _, ueCell := range ue.Cells {
+			rsrps = append(rsrps, ueCell.Strength)
+		}
+		quantity = func(rsrp float64) float64 {
+			return model.RSRQ(rsrp, rsrps)
+		}
+	}
+
 	var csCells []device.Cell
 	measurements := make(map[string]measurement.Measurement)
-	sCellMeas := measurement.NewMeasEventA3(id.NewECGI(uint64(sCellInStore.NCGI)), measurement.RSRP(ue.Cell.Strength))
+	sCellMeas := measurement.NewMeasEventA3(id.NewECGI(uint64(sCellInStore.NCGI)), measurement.RSRP(quantity(ue.Cell.Strength)))
 	measurements[sCellMeas.GetCellID().String()] = sCellMeas
 
 	for _, ueCell := range ue.Cells {
@@ -125,12 +139,12 @@ func (c *measReportConverter) Convert(ctx context.Context, ue *model.UE) device.
 
 		csCells = append(csCells, device.NewCell(id.NewECGI(uint64(tmpCellInStore.NCGI)),
 			c.convertA3Offset(tmpCellInStore.MeasurementParams.EventA3Params.A3Offset),
-			c.convertHysteresis(tmpCellInStore.MeasurementParams.Hysteresis),
+			c.convertHysteresis(ue.MeasReport.GetHysteresis(tmpCellInStore.MeasurementParams.Hysteresis)),
 			c.convertQOffset(csCellIndividualOffset),
 			c.convertQOffset(tmpCellInStore.MeasurementParams.FrequencyOffset),
 			c.convertTimeToTrigger(tmpCellInStore.MeasurementParams.TimeToTrigger)))
 
-		tmpCsCell := measurement.NewMeasEventA3(id.NewECGI(uint64(tmpCellInStore.NCGI)), measurement.RSRP(ueCell.Strength))
+		tmpCsCell := measurement.NewMeasEventA3(id.NewECGI(uint64(tmpCellInStore.NCGI)), measurement.RSRP(quantity(ueCell.Strength)))
 		measurements[tmpCsCell.GetCellID().String()] = tmpCsCell
 	}
 
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"math"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+const (
+	// defaultAntennaGain maximum directional gain of the 3GPP sector antenna in dBi
+	defaultAntennaGain = 8.0
+	// defaultAntennaHeight height in meters of antennas with no configured height
+	defaultAntennaHeight = 25.0
+	// ueHeight height in meters of the UE antennas
+	ueHeight = 1.5
+	// verticalBeamwidth vertical 3dB beamwidth of the 3GPP sector antenna in degrees
+	verticalBeamwidth = 65.0
+	// horizontalBeamwidth horizontal 3dB beamwidth in degrees of the 3GPP sector antenna covering a 120° sector
+	horizontalBeamwidth = 65.0
+	// maxAttenuation front-to-back ratio and side lobe level limit of the 3GPP sector antenna in dB
+	maxAttenuation = 30.0
+)
+
+// antennaHeight returns the height of the cell antenna above the UEs in meters
+func antennaHeight(cell model.Cell) float64 {
+	height := float64(cell.Sector.Height)
+	if height <= 0 {
+		height = defaultAntennaHeight
+	}
+	return height - ueHeight
+}
+
+// antennaGain returns the gain in dBi of the cell antenna towards the location, according to the sector antenna
+// pattern of 3GPP TR 38.901 table 7.3-1; the horizontal beamwidth is scaled with the arc of the sector and the
+// vertical pattern is steered down by the mechanical and electrical tilt
+func antennaGain(coord model.Coordinate, cell model.Cell) float64 {
+	maxGain := cell.Sector.Gain
+	if maxGain == 0 {
+		maxGain = defaultAntennaGain
+	}
+
+	// Horizontal offset from the azimuth of the sector, between -180° and 180°
+	bearing := utils.InitialBearing(cell.Sector.Center, coord)
+	phi := math.Mod(bearing-float64(cell.Sector.Azimuth)+540, 360) - 180
+	arc := float64(cell.Sector.Arc)
+	if arc <= 0 {
+		arc = 120
+	}
+	horizontal := -math.Min(12*math.Pow(phi/(horizontalBeamwidth*arc/120), 2), maxAttenuation)
+
+	// Vertical offset of the UE below the horizon from the tilted beam
+	elevation := math.Atan2(antennaHeight(cell), utils.Distance(cell.Sector.Center, coord)) * 180 / math.Pi
+	theta := elevation - float64(cell.Sector.Tilt+cell.Sector.ElectricalTilt)
+	vertical := -math.Min(12*math.Pow(theta/verticalBeamwidth, 2), maxAttenuation)
+
+	return maxGain - math.Min(-(horizontal+vertical), maxAttenuation)
+}
+
+// slantDistance returns the distance in km between the cell antenna and the UE at the location
+func slantDistance(coord model.Coordinate, cell model.Cell) float64 {
+	return math.Hypot(distanceKM(coord, cell), antennaHeight(cell)/1000)
+}
@@ -0,0 +1,49 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"testing"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func antennaCell(tilt int32) model.Cell {
+	return model.Cell{
+		TxPowerDB: 40,
+		Sector: model.Sector{
+			Center:         model.Coordinate{Lat: 52.0, Lng: 13.0},
+			Azimuth:        0,
+			Arc:            120,
+			Height:         30,
+			ElectricalTilt: tilt,
+			Antenna:        model.Antenna3GPP,
+		},
+	}
+}
+
+func TestAntennaGainPattern(t *testing.T) {
+	cell := antennaCell(0)
+	north := model.Coordinate{Lat: 52.1, Lng: 13.0}
+	south := model.Coordinate{Lat: 51.9, Lng: 13.0}
+	east := model.Coordinate{Lat: 52.0, Lng: 13.16}
+
+	// Far on boresight the gain is close to its maximum, behind the antenna it is limited by the front-to-back ratio
+	assert.InDelta(t, defaultAntennaGain, antennaGain(north, cell), 0.1)
+	assert.InDelta(t, defaultAntennaGain-maxAttenuation, antennaGain(south, cell), 0.1)
+	assert.Less(t, antennaGain(east, cell), antennaGain(north, cell))
+	assert.Greater(t, StrengthAtLocation(north, cell), StrengthAtLocation(south, cell))
+}
+
+func TestAntennaTilt(t *testing.T) {
+	near := model.Coordinate{Lat: 52.0015, Lng: 13.0}
+	far := model.Coordinate{Lat: 52.05, Lng: 13.0}
+
+	// Tilting the antenna down favors the UEs close to the cell over the UEs far from it
+	flat, tilted := antennaCell(0), antennaCell(10)
+	assert.Greater(t, StrengthAtLocation(near, tilted), StrengthAtLocation(near, flat))
+	assert.Less(t, StrengthAtLocation(far, tilted), StrengthAtLocation(far, flat))
+}
@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// updateCarriers updates the carriers aggregated by the UE from its serving node. Secondary cells are released once
+// they are too weak, on another node than the serving cell or the UE is no longer connected. If automatic carrier
+// aggregation is enabled, the strongest cells of the serving node on other carriers are configured as secondary cells.
+// The PRBs of each carrier are distributed across the UEs it serves by the scheduler, scaled by the load factor of the
+// traffic profile of the cell, and their throughput follows the SINR of the carrier.
+func (d *driver) updateCarriers(ctx context.Context, ue *model.UE, measured *measurements) {
+	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		if len(ue.Carriers) > 0 {
+			if err := d.ueStore.UpdateCarriers(ctx, ue.IMSI, nil); err != nil {
+				log.Warn(err)
+			}
+		}
+		return
+	}
+	primary, err := d.cellStore.Get(ctx, ue.Cell.NCGI)
+	if err != nil {
+		return
+	}
+
+	maxCarriers := d.carrierAggregation.GetMaxCarriers()
+	cells := map[types.NCGI]*model.Cell{primary.NCGI: primary}
+	frequencies := map[float64]bool{primary.CarrierFrequency(): true}
+	carriers := []*model.Carrier{{NCGI: primary.NCGI, Primary: true, Active: true, Strength: ue.Cell.Strength}}
+	for _, carrier := range ue.SecondaryCarriers() {
+		cell, err := d.cellStore.Get(ctx, carrier.NCGI)
+		if err != nil || cell.Failed || !sameNode(primary.NCGI, cell.NCGI) || frequencies[cell.CarrierFrequency()] || len(carriers) == maxCarriers {
+			continue
+		}
+		strength := d.noise.apply(ue, cell, StrengthAtLocation(ue.Location, *cell))
+		if math.IsNaN(strength) || strength < d.carrierAggregation.GetReleaseThreshold() {
+			continue
+		}
+		cells[cell.NCGI] = cell
+		frequencies[cell.CarrierFrequency()] = true
+		carriers = append(carriers, &model.Carrier{NCGI: cell.NCGI, Active: carrier.Active, Strength: strength})
+	}
+
+	if d.carrierAggregation.Enabled && len(carriers) < maxCarriers {
+		cellList, err := d.nearbyCells(ctx, ue)
+		if err != nil {
+			return
+		}
+		var candidates []*model.Carrier
+		for _, cell := range cellList {
+			if !sameNode(primary.NCGI, cell.NCGI) || frequencies[cell.CarrierFrequency()] {
+				continue
+			}
+			strength := d.noise.apply(ue, cell, StrengthAtLocation(ue.Location, *cell))
+			if strength >= d.carrierAggregation.GetAdditionThreshold() {
+				cells[cell.NCGI] = cell
+				candidates = append(candidates, &model.Carrier{NCGI: cell.NCGI, Active: true, Strength: strength})
+			}
+		}
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Strength > candidates[j].Strength })
+		for _, candidate := range candidates {
+			frequency := cells[candidate.NCGI].CarrierFrequency()
+			if len(carriers) == maxCarriers || frequencies[frequency] {
+				continue
+			}
+			log.Debugf("Adding secondary cell %d to UE %d", candidate.NCGI, ue.IMSI)
+			frequencies[frequency] = true
+			carriers = append(carriers, candidate)
+		}
+	}
+
+	now := clock.Now()
+	for _, carrier := range carriers {
+		if !carrier.Active {
+			continue
+		}
+		carrier.Sinr = d.sinr(measured, cells[carrier.NCGI], carrier.Strength)
+		carrier.PRBs = float64(cells[carrier.NCGI].PRBs()) * d.scheduler.share(ctx, ue.IMSI, carrier.NCGI, carrier.Sinr) * d.traffic.factor(cells[carrier.NCGI], now)
+		carrier.Throughput = model.Throughput(carrier.PRBs, d.throughput.SpectralEfficiency(carrier.Sinr))
+	}
+	if err := d.ueStore.UpdateCarriers(ctx, ue.IMSI, carriers); err != nil {
+		log.Warn(err)
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+)
+
+// watchCells refreshes the signal strength of all UEs whenever a cell is updated, e.g. when its transmission power
+// or tilt is changed via RC control, rather than waiting for the next move of the UEs. The measurements reported by
+// the connected UEs may then trigger handovers.
+func (d *driver) watchCells(ctx context.Context) {
+	ch := make(chan event.Event)
+	if err := d.cellStore.Watch(ctx, ch); err != nil {
+		log.Warn(err)
+		return
+	}
+	for cellEvent := range ch {
+		if cellEvent.Type != cells.Updated && cellEvent.Type != cells.TopologyChanged {
+			continue
+		}
+		// Updates of several cells at once, e.g. upon model reload, are applied in a single refresh
+		for pending := true; pending; {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return
+				}
+			default:
+				pending = false
+			}
+		}
+		log.Debugf("Refreshing the signal strength of the UEs after update %v", cellEvent.Key)
+		for _, ue := range d.ueStore.ListAllUEs(ctx) {
+			go d.refreshUE(ctx, ue.IMSI)
+		}
+	}
+}
+
+// refreshUE updates the signal strength of the UE at its current location and reports its measurements
+func (d *driver) refreshUE(ctx context.Context, imsi types.IMSI) {
+	if _, ok := d.ueMutex(imsi); !ok {
+		return
+	}
+	d.lockUE(imsi)
+	defer d.unlockUE(imsi)
+	d.updateUESignalStrength(ctx, imsi)
+	d.reportMeasurement(ctx, imsi)
+}
+
+// nearbyCells returns the cells measured by the UE: those within the cell search radius of the UE, if any, and its
+// serving cell, or all cells if no radius is set
+func (d *driver) nearbyCells(ctx context.Context, ue *model.UE) ([]*model.Cell, error) {
+	if d.cellSearchRadius <= 0 {
+		return d.cellStore.List(ctx)
+	}
+	cellList := d.cellStore.FindCellsNear(ctx, ue.Location, d.cellSearchRadius)
+	if ue.Cell == nil {
+		return cellList, nil
+	}
+	for _, cell := range cellList {
+		if cell.NCGI == ue.Cell.NCGI {
+			return cellList, nil
+		}
+	}
+	if serving, err := d.cellStore.Get(ctx, ue.Cell.NCGI); err == nil {
+		cellList = append(cellList, serving)
+	}
+	return cellList, nil
+}
@@ -18,7 +18,10 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/handover"
 	"github.com/onosproject/ran-simulator/pkg/measurement"
 	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/prediction"
+	"github.com/onosproject/ran-simulator/pkg/stats"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 	"github.com/onosproject/ran-simulator/pkg/utils"
@@ -35,8 +38,9 @@ type Driver interface {
 	// Stop stops the driving engine
 	Stop()
 
-	// GenerateRoutes generates routes for all UEs that currently do not have a route; remove routes with no UEs
-	GenerateRoutes(ctx context.Context, minSpeed uint32, maxSpeed uint32, speedStdDev uint32, routeEndPoints []model.RouteEndPoint, directRoute bool)
+	// GenerateRoutes generates routes for all UEs that currently do not have a route; remove routes with no UEs.
+	// If the mobility configuration has classes, the speed of each UE is that of the class it is assigned to
+	GenerateRoutes(ctx context.Context, minSpeed uint32, maxSpeed uint32, speedStdDev uint32, routeEndPoints []model.RouteEndPoint, regions []model.Region, directRoute bool, mobility model.MobilityConfig)
 
 	// GetMeasCtrl returns the Measurement Controller
 	GetMeasCtrl() measurement.MeasController
@@ -58,15 +62,35 @@ type Driver interface {
 
 	// AddRrcChan
 	AddRrcChan(ch chan model.UE)
+
+	// GetPredictionTracker returns the tracker scoring the next cells predicted for UEs against their handovers
+	GetPredictionTracker() prediction.Tracker
+
+	// Reevaluate immediately updates the signal strength, RRC state and measurement report of the UE, e.g. once
+	// it has been moved, rather than upon the next tick
+	Reevaluate(ctx context.Context, imsi types.IMSI)
+
+	// SetRemoteCells sets the cells owned by other simulator instances; UEs receiving one of them better than their
+	// serving cell are handed over to it with the given transfer function
+	SetRemoteCells(cells []model.RemoteCell, transfer TransferFunc)
+
+	// AdmitUE adds a UE handed over by another simulator instance, along with its route if any
+	AdmitUE(ctx context.Context, ue *model.UE, route *model.Route) error
 }
 
 type driver struct {
 	cellStore               cells.Store
 	routeStore              routes.Store
 	ueStore                 ues.Store
+	rrcStats                stats.RrcStats
+	hoStats                 stats.HoStats
+	rachStats               stats.RachStats
+	rach                    *randomAccess
+	predictions             prediction.Tracker
 	apiKey                  string
 	ticker                  *time.Ticker
 	done                    chan bool
+	cancelWatch             context.CancelFunc
 	stopLocalHO             chan bool
 	min                     *model.Coordinate
 	max                     *model.Coordinate
@@ -74,19 +98,49 @@ type driver struct {
 	hoCtrl                  handover.HOController
 	hoLogic                 string
 	rrcCtrl                 RrcCtrl
+	noise                   *measurementNoise
+	dualConnectivity        model.DualConnectivityConfig
+	carrierAggregation      model.CarrierAggregationConfig
+	handoverConfig          model.HandoverConfig
+	interference            model.InterferenceConfig
+	uplink                  model.UplinkConfig
+	throughput              model.ThroughputConfig
+	scheduler               *scheduler
+	traffic                 *trafficProfile
+	cellSearchRadius        float64
 	ueLock                  map[types.IMSI]*sync.Mutex
+	ueLockMu                sync.RWMutex // guards the UE locks against UEs admitted from other simulator instances
+	remoteCells             []model.RemoteCell
+	transfer                TransferFunc
+	handovers               sync.Map // IMSIs of the UEs with a handover in progress
+	lastHandovers           sync.Map // last handover of each UE, to detect ping-pongs and classify radio link failures
 	rrcStateChangesDisabled bool
 	wayPointRoute           bool
 }
 
 // NewMobilityDriver returns a driving engine capable of "driving" UEs along pre-specified routes
-func NewMobilityDriver(cellStore cells.Store, routeStore routes.Store, ueStore ues.Store, apiKey string, hoLogic string, ueCountPerCell uint, rrcStateChangesDisabled bool, wayPointRoute bool) Driver {
+func NewMobilityDriver(cellStore cells.Store, routeStore routes.Store, ueStore ues.Store, metricsStore metrics.Store, apiKey string, hoLogic string, ueCountPerCell uint, rrcConfig model.RrcConfig, noiseConfig model.MeasurementNoiseConfig, dualConnectivity model.DualConnectivityConfig, carrierAggregation model.CarrierAggregationConfig, handoverConfig model.HandoverConfig, rachConfig model.RachConfig, interference model.InterferenceConfig, uplink model.UplinkConfig, throughput model.ThroughputConfig, schedulerConfig model.SchedulerConfig, trafficProfile model.TrafficProfileConfig, regions []model.Region, cellSearchRadius float64, rrcStateChangesDisabled bool, wayPointRoute bool) Driver {
 	return &driver{
 		cellStore:               cellStore,
 		routeStore:              routeStore,
 		ueStore:                 ueStore,
+		rrcStats:                stats.NewRrcStats(metricsStore),
+		hoStats:                 stats.NewHoStats(metricsStore),
+		rachStats:               stats.NewRachStats(metricsStore),
+		rach:                    newRandomAccess(rachConfig),
+		predictions:             prediction.NewTracker(metricsStore),
 		hoLogic:                 hoLogic,
-		rrcCtrl:                 NewRrcCtrl(ueCountPerCell),
+		rrcCtrl:                 NewRrcCtrl(ueCountPerCell, rrcConfig),
+		noise:                   newMeasurementNoise(noiseConfig),
+		dualConnectivity:        dualConnectivity,
+		carrierAggregation:      carrierAggregation,
+		handoverConfig:          handoverConfig,
+		interference:            interference,
+		uplink:                  uplink,
+		throughput:              throughput,
+		scheduler:               newScheduler(ueStore, schedulerConfig, throughput),
+		traffic:                 newTrafficProfile(trafficProfile, regions),
+		cellSearchRadius:        cellSearchRadius,
 		rrcStateChangesDisabled: rrcStateChangesDisabled,
 		wayPointRoute:           wayPointRoute,
 	}
@@ -108,10 +162,12 @@ func (d *driver) Start(ctx context.Context) {
 		d.initializeUEPosition(ctx, route)
 	}
 
+	d.ueLockMu.Lock()
 	d.ueLock = make(map[types.IMSI]*sync.Mutex)
 	for _, ue := range d.ueStore.ListAllUEs(ctx) {
 		d.ueLock[ue.IMSI] = &sync.Mutex{}
 	}
+	d.ueLockMu.Unlock()
 
 	d.ticker = time.NewTicker(tickFrequency * tickUnit)
 	d.done = make(chan bool)
@@ -136,11 +192,19 @@ func (d *driver) Start(ctx context.Context) {
 		log.Warn("There is no handover logic - running measurement only")
 	}
 
+	// Refresh the signal strength of the UEs as soon as a cell is reconfigured
+	watchCtx, cancel := context.WithCancel(ctx)
+	d.cancelWatch = cancel
+	go d.watchCells(watchCtx)
+
 	go d.drive(ctx)
 }
 
 func (d *driver) Stop() {
 	log.Info("Driver stopping")
+	if d.cancelWatch != nil {
+		d.cancelWatch()
+	}
 	d.ticker.Stop()
 	d.done <- true
 }
@@ -157,6 +221,10 @@ func (d *driver) GetRrcCtrl() RrcCtrl {
 	return d.rrcCtrl
 }
 
+func (d *driver) GetPredictionTracker() prediction.Tracker {
+	return d.predictions
+}
+
 func (d *driver) SetHoLogic(hoLogic string) {
 	if d.hoLogic == "local" && hoLogic == "mho" {
 		log.Info("Stopping local HO")
@@ -172,16 +240,26 @@ func (d *driver) AddRrcChan(ch chan model.UE) {
 	d.addRrcChan(ch)
 }
 
+// ueMutex returns the lock of the UE, if any
+func (d *driver) ueMutex(imsi types.IMSI) (*sync.Mutex, bool) {
+	d.ueLockMu.RLock()
+	defer d.ueLockMu.RUnlock()
+	mu, ok := d.ueLock[imsi]
+	return mu, ok
+}
+
 func (d *driver) lockUE(imsi types.IMSI) {
-	d.ueLock[imsi].Lock()
+	mu, _ := d.ueMutex(imsi)
+	mu.Lock()
 }
 
 func (d *driver) unlockUE(imsi types.IMSI) {
-	if _, ok := d.ueLock[imsi]; !ok {
+	mu, ok := d.ueMutex(imsi)
+	if !ok {
 		log.Errorf("lock not found for IMSI %d", imsi)
 		return
 	}
-	d.ueLock[imsi].Unlock()
+	mu.Unlock()
 }
 
 func (d *driver) drive(ctx context.Context) {
@@ -203,15 +281,33 @@ func (d *driver) drive(ctx context.Context) {
 func (d *driver) processRoute(ctx context.Context, route *model.Route) {
 	d.lockUE(route.IMSI)
 	defer d.unlockUE(route.IMSI)
-	if route.NextPoint == 0 && !route.Reverse {
-		d.initializeUEPosition(ctx, route)
+	if !route.Paused {
+		if route.NextPoint == 0 && !route.Reverse {
+			d.initializeUEPosition(ctx, route)
+		}
+		d.updateUEPosition(ctx, route)
+	}
+	d.evaluateUE(ctx, route.IMSI)
+}
+
+func (d *driver) Reevaluate(ctx context.Context, imsi types.IMSI) {
+	d.lockUE(imsi)
+	defer d.unlockUE(imsi)
+	d.evaluateUE(ctx, imsi)
+}
+
+// evaluateUE updates the signal strength, RRC state and measurement report of the UE at its current location;
+// the UE must be locked
+func (d *driver) evaluateUE(ctx context.Context, imsi types.IMSI) {
+	d.updateUESignalStrength(ctx, imsi)
+	// UEs handed over to a cell of another simulator instance are no longer simulated here
+	if d.transferUE(ctx, imsi) {
+		return
 	}
-	d.updateUEPosition(ctx, route)
-	d.updateUESignalStrength(ctx, route.IMSI)
 	if !d.rrcStateChangesDisabled {
-		d.updateRrc(ctx, route.IMSI)
+		d.updateRrc(ctx, imsi)
 	}
-	d.reportMeasurement(ctx, route.IMSI)
+	d.reportMeasurement(ctx, imsi)
 }
 
 // Initializes UE positions to the start of its routes.
@@ -230,8 +326,9 @@ func (d *driver) updateUEPosition(ctx context.Context, route *model.Route) {
 	}
 
 	// Determine speed and heading
-	speed := float64(route.SpeedAvg) + rand.NormFloat64()*float64(route.SpeedStdDev)
+	speed := math.Max(float64(route.SpeedAvg)+rand.NormFloat64()*float64(route.SpeedStdDev), 0)
 	distanceDriven := (tickFrequency * speed) / 3600.0
+	_ = d.ueStore.SetMobility(ctx, route.IMSI, ue.Mobility, speed/1000)
 
 	// Determine bearing and distance to the next point
 	bearing := utils.InitialBearing(ue.Location, *route.Points[route.NextPoint])
@@ -267,8 +364,8 @@ func (d *driver) reportMeasurement(ctx context.Context, imsi types.IMSI) {
 		return
 	}
 
-	// Skip reporting measurement for IDLE UE
-	if ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_IDLE {
+	// Only RRC connected UEs report measurements; they are suspended during the interruption of a handover
+	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED || ue.Detached {
 		return
 	}
 
@@ -327,13 +424,97 @@ func (d *driver) Handover(ctx context.Context, imsi types.IMSI, tCell *model.UEC
 		return
 	}
 
-	d.cellStore.DecrementRrcConnectedCount(ctx, ue.Cell.NCGI)
+	if _, loaded := d.handovers.LoadOrStore(imsi, tCell.NCGI); loaded {
+		log.Infof("HO skipped for UE %d with a handover in progress", imsi)
+		return
+	}
+
+	if !d.handoverConfig.IsEnabled() {
+		defer d.handovers.Delete(imsi)
+		d.hoStats.Prepared(ctx, ue.Cell.NCGI, 0)
+		d.executeHandover(ctx, ue, tCell, time.Now())
+		return
+	}
+	go d.simulateHandover(ctx, imsi, tCell)
+}
+
+// simulateHandover prepares the handover of the UE to the target cell, while the UE is still served by its source
+// cell, and then executes it; the UE is detached from both cells during the interruption time at the end of the
+// execution
+func (d *driver) simulateHandover(ctx context.Context, imsi types.IMSI, tCell *model.UECell) {
+	defer d.handovers.Delete(imsi)
+	start := time.Now()
+	time.Sleep(d.handoverConfig.PreparationTime)
+
+	d.lockUE(imsi)
+	ue, err := d.ueStore.Get(ctx, imsi)
+	if err != nil || ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		log.Warnf("HO of UE %d cancelled during preparation", imsi)
+		d.unlockUE(imsi)
+		return
+	}
+	d.hoStats.Prepared(ctx, ue.Cell.NCGI, time.Since(start))
+	d.unlockUE(imsi)
+
+	start = time.Now()
+	interruption := d.handoverConfig.InterruptionTime
+	time.Sleep(d.handoverConfig.GetExecutionTime() - interruption)
+	if interruption > 0 {
+		d.lockUE(imsi)
+		err := d.ueStore.SetDetached(ctx, imsi, true)
+		d.unlockUE(imsi)
+		if err != nil {
+			log.Warn(err)
+			return
+		}
+		time.Sleep(interruption)
+	}
+
+	d.lockUE(imsi)
+	defer d.unlockUE(imsi)
+	if err := d.ueStore.SetDetached(ctx, imsi, false); err != nil {
+		log.Warn(err)
+		return
+	}
+	ue, err = d.ueStore.Get(ctx, imsi)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		log.Warnf("HO of UE %d cancelled during execution", imsi)
+		d.hoStats.Executed(ctx, ue.Cell.NCGI, false, 0, 0)
+		return
+	}
+	d.executeHandover(ctx, ue, tCell, start)
+}
+
+// executeHandover moves the UE to the target cell and records the execution of the handover that started at the
+// given time
+func (d *driver) executeHandover(ctx context.Context, ue *model.UE, tCell *model.UECell, start time.Time) {
+	imsi := ue.IMSI
+	sCellNCGI := ue.Cell.NCGI
+	if cell, err := d.cellStore.Get(ctx, tCell.NCGI); err != nil || cell.Failed {
+		d.hoStats.Executed(ctx, sCellNCGI, false, 0, 0)
+		d.handoverFailure(ctx, ue, tCell.NCGI, false)
+		return
+	}
+	if rand.Float64() < d.handoverConfig.FailureProbability {
+		d.hoStats.Executed(ctx, sCellNCGI, false, 0, 0)
+		d.handoverFailure(ctx, ue, tCell.NCGI, rand.Float64() < d.handoverConfig.DropProbability)
+		return
+	}
+
+	d.cellStore.DecrementRrcConnectedCount(ctx, sCellNCGI)
 	d.cellStore.IncrementRrcConnectedCount(ctx, tCell.NCGI)
 
-	err = d.ueStore.UpdateCell(ctx, imsi, tCell)
+	err := d.ueStore.UpdateCell(ctx, imsi, tCell)
 	if err != nil {
 		log.Warn("Unable to update UE %d cell info", imsi)
 	}
+	d.predictions.Handover(ctx, imsi, sCellNCGI, tCell.NCGI)
+	d.hoStats.Executed(ctx, sCellNCGI, true, time.Since(start), d.handoverConfig.InterruptionTime)
+	d.recordHandover(ctx, imsi, sCellNCGI, tCell.NCGI)
 
 	// after changing serving cell, calculate channel quality/signal strength again
 	d.updateUESignalStrength(ctx, imsi)
@@ -360,29 +541,106 @@ func (d *driver) updateUESignalStrength(ctx context.Context, imsi types.IMSI) {
 	}
 
 	// update RSRP from candidate serving cells
-	err = d.updateUESignalStrengthCandServCells(ctx, ue)
+	measured, err := d.updateUESignalStrengthCandServCells(ctx, ue)
 	if err != nil {
 		log.Warnf("For UE %v: %v", *ue, err)
 		return
 	}
+
+	// UEs served by a failed cell lose their connection and reselect a cell in service
+	if d.servingCellFailed(ctx, ue) {
+		d.reselectCell(ctx, ue)
+	}
+
+	// UEs losing the signal of their serving cell re-establish their connection on another cell
+	if d.radioLinkFailed(ue) {
+		d.recoverRadioLink(ctx, ue)
+	}
+
+	// the traffic of the UE is suspended during the interruption of a handover
+	if ue.Detached {
+		return
+	}
+
+	// register the UE in the tracking area of its serving cell
+	d.updateTrackingArea(ctx, ue)
+
+	// add, update or release the secondary cell
+	d.updateSecondaryCell(ctx, ue)
+
+	// update the aggregated carriers and their throughput
+	d.updateCarriers(ctx, ue, measured)
+}
+
+// lastHandover source and target cells and time of the last handover of a UE
+type lastHandover struct {
+	source types.NCGI
+	target types.NCGI
+	time   time.Time
+}
+
+// recordHandover counts a ping-pong if the UE handed back to the cell it had just left. With the configured
+// probability, the UE is then handed back to the source cell within the ping-pong window.
+func (d *driver) recordHandover(ctx context.Context, imsi types.IMSI, source types.NCGI, target types.NCGI) {
+	now := time.Now()
+	window := d.handoverConfig.GetPingPongWindow()
+	if v, ok := d.lastHandovers.Load(imsi); ok {
+		if last := v.(lastHandover); last.source == target && now.Sub(last.time) < window {
+			log.Infof("HO ping-pong of UE %d between cells %d and %d", imsi, target, source)
+			d.hoStats.PingPong(ctx, source)
+		}
+	}
+	d.lastHandovers.Store(imsi, lastHandover{source: source, target: target, time: now})
+
+	if rand.Float64() >= d.handoverConfig.PingPongProbability {
+		return
+	}
+	time.AfterFunc(time.Duration(rand.Int63n(int64(window))), func() {
+		ue, err := d.ueStore.Get(ctx, imsi)
+		if err != nil || ue.Cell.NCGI != target {
+			return
+		}
+		log.Debugf("Handing UE %d back to cell %d", imsi, source)
+		d.Handover(ctx, imsi, &model.UECell{ID: types.GnbID(source), NCGI: source})
+	})
+}
+
+// handoverFailure re-establishes the RRC connection of the UE on its serving cell, unless the failure drops the
+// connection; the connection drops as well if the serving cell is gone. A handover re-established on the source cell
+// was too early.
+func (d *driver) handoverFailure(ctx context.Context, ue *model.UE, target types.NCGI, drop bool) {
+	log.Warnf("HO failed for UE %d, re-establishing connection on cell %d", ue.IMSI, ue.Cell.NCGI)
+	d.rrcStats.ReEstablishmentAttempt(ctx, ue.Cell.NCGI, stats.ReEstabHOFail)
+	if _, err := d.cellStore.Get(ctx, ue.Cell.NCGI); err == nil && !drop {
+		d.hoStats.MroFailure(ctx, ue.Cell.NCGI, target, stats.HoTooEarly)
+		return
+	}
+
+	d.rrcStats.ConnectionDrop(ctx, ue.Cell.NCGI)
+	if _, err := d.setRrcState(ctx, ue, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE); err != nil {
+		log.Warn(err)
+	}
 }
 
-// UpdateUESignalStrengthCandServCells updates UE signal strength for serving and candidate cells
-func (d *driver) updateUESignalStrengthCandServCells(ctx context.Context, ue *model.UE) error {
-	cellList, err := d.cellStore.List(ctx)
+// UpdateUESignalStrengthCandServCells updates UE signal strength for serving and candidate cells, and returns the
+// signal strength of all cells
+func (d *driver) updateUESignalStrengthCandServCells(ctx context.Context, ue *model.UE) (*measurements, error) {
+	cellList, err := d.nearbyCells(ctx, ue)
 	if err != nil {
-		return fmt.Errorf("Unable to get all cells")
+		return nil, fmt.Errorf("Unable to get all cells")
 	}
 	var csCellList []*model.UECell
+	strengths := make(map[types.NCGI]float64, len(cellList))
 	for _, cell := range cellList {
-		rsrp := StrengthAtLocation(ue.Location, *cell)
+		rsrp := d.noise.apply(ue, cell, StrengthAtLocation(ue.Location, *cell))
 		if math.IsInf(rsrp, 0) {
 			rsrp = 0
 		}
 		if math.IsNaN(rsrp) {
 			continue
 		}
-		if ue.Cell.NCGI == cell.NCGI {
+		strengths[cell.NCGI] = rsrp
+		if ue.Cell.NCGI == cell.NCGI || cell.Failed {
 			continue
 		}
 		ueCell := &model.UECell{
@@ -390,14 +648,21 @@ func (d *driver) updateUESignalStrengthCandServCells(ctx context.Context, ue *mo
 			NCGI:     cell.NCGI,
 			Strength: rsrp,
 		}
-		csCellList = d.sortUECells(append(csCellList, ueCell), 3) // hardcoded: to be parameterized for the future
+		csCellList = d.sortUECells(append(csCellList, ueCell), ue.PlmnID, 3) // hardcoded: to be parameterized for the future
 	}
 	err = d.ueStore.UpdateCells(ctx, ue.IMSI, csCellList)
 	if err != nil {
 		log.Warn("Unable to update UE %d cells info", ue.IMSI)
 	}
 
-	return nil
+	// update the SINR of the serving cell, interfered by the co-channel cells
+	measured := &measurements{cells: cellList, strengths: strengths}
+	d.updateSinr(ctx, ue, measured)
+
+	// update the uplink transmission power, power headroom, SINR and throughput towards the serving cell
+	d.updateUplink(ctx, ue, measured)
+
+	return measured, nil
 }
 
 // UpdateUESignalStrengthServCell  updates UE signal strength for serving cell
@@ -407,7 +672,7 @@ func (d *driver) updateUESignalStrengthServCell(ctx context.Context, ue *model.U
 		return fmt.Errorf("Unable to find serving cell %d", ue.Cell.NCGI)
 	}
 
-	strength := StrengthAtLocation(ue.Location, *sCell)
+	strength := d.noise.apply(ue, sCell, StrengthAtLocation(ue.Location, *sCell))
 
 	if math.IsNaN(strength) {
 		strength = -999
@@ -415,6 +680,10 @@ func (d *driver) updateUESignalStrengthServCell(ctx context.Context, ue *model.U
 	if math.IsInf(strength, 0) {
 		strength = 0
 	}
+	// a failed cell provides no coverage
+	if sCell.Failed {
+		strength = -999
+	}
 
 	newUECell := &model.UECell{
 		ID:       ue.Cell.ID,
@@ -429,12 +698,16 @@ func (d *driver) updateUESignalStrengthServCell(ctx context.Context, ue *model.U
 	return nil
 }
 
-// SortUECells sorts ue cells
-func (d *driver) sortUECells(ueCells []*model.UECell, numAdjCells int) []*model.UECell {
+// homePlmnOffset offset in dB added to the strength of the cells of the UE home PLMN when ranking the candidate cells,
+// so that a roaming UE prefers its home PLMN unless a cell of another PLMN is received markedly better
+const homePlmnOffset = 6.0
+
+// SortUECells sorts ue cells; cells of the UE home PLMN are preferred over the cells of other PLMNs by an offset
+func (d *driver) sortUECells(ueCells []*model.UECell, homePlmnID types.PlmnID, numAdjCells int) []*model.UECell {
 	// bubble sort
 	for i := 0; i < len(ueCells)-1; i++ {
 		for j := 0; j < len(ueCells)-i-1; j++ {
-			if ueCells[j].Strength < ueCells[j+1].Strength {
+			if preferUECell(ueCells[j+1], ueCells[j], homePlmnID) {
 				ueCells[j], ueCells[j+1] = ueCells[j+1], ueCells[j]
 			}
 		}
@@ -445,6 +718,19 @@ func (d *driver) sortUECells(ueCells []*model.UECell, numAdjCells int) []*model.
 	return ueCells
 }
 
+// preferUECell returns true if cell a is preferred over cell b for a UE with the given home PLMN
+func preferUECell(a *model.UECell, b *model.UECell, homePlmnID types.PlmnID) bool {
+	return rankedStrength(a, homePlmnID) > rankedStrength(b, homePlmnID)
+}
+
+// rankedStrength returns the strength of the cell offset by the home PLMN offset if the cell is of the home PLMN
+func rankedStrength(cell *model.UECell, homePlmnID types.PlmnID) float64 {
+	if model.GetPlmnID(cell.NCGI) == homePlmnID {
+		return cell.Strength + homePlmnOffset
+	}
+	return cell.Strength
+}
+
 //GetHoLogic returns the HO Logic ("local" or "mho")
 func (d *driver) GetHoLogic() string {
 	return d.hoLogic
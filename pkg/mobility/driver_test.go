@@ -10,13 +10,19 @@ package mobility
 import (
 	"context"
 	"fmt"
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/stats"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"github.com/onosproject/ran-simulator/pkg/store/routes"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 	"github.com/stretchr/testify/assert"
+	"math"
+	"sync"
 	"testing"
 	"time"
 )
@@ -47,22 +53,31 @@ func TestDriver(t *testing.T) {
 	err = rs.Add(ctx, route)
 	assert.NoError(t, err)
 
-	driver := NewMobilityDriver(cs, rs, us, "", "local", 15, false, false)
+	driver := NewMobilityDriver(cs, rs, us, metrics.NewMetricsStore(), "", "local", 15, model.RrcConfig{}, model.MeasurementNoiseConfig{}, model.DualConnectivityConfig{}, model.CarrierAggregationConfig{}, model.HandoverConfig{}, model.RachConfig{}, model.InterferenceConfig{}, model.UplinkConfig{}, model.ThroughputConfig{}, model.SchedulerConfig{}, model.TrafficProfileConfig{}, nil, 0, false, false)
 	tickUnit = time.Millisecond // For testing
 	driver.Start(ctx)
 
-	c := 0
-	for e = range ch {
-		ue = e.Value.(*model.UE)
+	// The UE drives south to the second point, east to the last one and back west along the reversed route; the UE
+	// is updated several times per tick, so its progress is followed by its heading rather than by counting events
+	timeout := time.After(10 * time.Second)
+	for _, heading := range []uint32{180, 90, 270} {
+	wait:
+		for {
+			select {
+			case e = <-ch:
+				ue = e.Value.(*model.UE)
+				if ue.Heading == heading {
+					break wait
+				}
+			case <-timeout:
+				t.Fatalf("UE %d did not head %d in time; last seen at %v heading %d", ue.IMSI, heading, ue.Location, ue.Heading)
+			}
+		}
 		fmt.Printf("%v: %v\n", ue.Location, ue.Heading)
-		c = c + 1
-		if c > 10 {
-			assert.Equal(t, 50.0, ue.Location.Lat)
-			assert.Equal(t, 0.0, ue.Location.Lng)
-			assert.Equal(t, uint32(180), ue.Heading)
-			break
-		} else if c == 6 {
-			assert.Equal(t, uint32(270), ue.Heading)
+		if heading == 180 {
+			assert.InDelta(t, 0.0, ue.Location.Lng, 1e-9)
+		} else {
+			assert.InDelta(t, 50.0, ue.Location.Lat, 1e-6)
 		}
 	}
 
@@ -83,10 +98,18 @@ func TestRouteGeneration(t *testing.T) {
 	us.SetUECount(ctx, 100)
 	assert.Equal(t, 100, us.Len(ctx))
 
-	driver := NewMobilityDriver(cs, rs, us, "", "local", 15, false, false)
-	driver.GenerateRoutes(ctx, 30000, 160000, 20000, nil, false)
+	driver := NewMobilityDriver(cs, rs, us, metrics.NewMetricsStore(), "", "local", 15, model.RrcConfig{}, model.MeasurementNoiseConfig{}, model.DualConnectivityConfig{}, model.CarrierAggregationConfig{}, model.HandoverConfig{}, model.RachConfig{}, model.InterferenceConfig{}, model.UplinkConfig{}, model.ThroughputConfig{}, model.SchedulerConfig{}, model.TrafficProfileConfig{}, nil, 0, false, false)
+	driver.GenerateRoutes(ctx, 30000, 160000, 20000, nil, nil, false, model.MobilityConfig{})
 	assert.Equal(t, 100, rs.Len(ctx))
 
+	// The routes stay within the area spanned by the cells, widened by the margins
+	min := model.Coordinate{Lat: 90.0, Lng: 180.0}
+	max := model.Coordinate{Lat: -90.0, Lng: -180.0}
+	for _, cell := range m.Cells {
+		min.Lat, max.Lat = math.Min(cell.Sector.Center.Lat-latMargin, min.Lat), math.Max(cell.Sector.Center.Lat+latMargin, max.Lat)
+		min.Lng, max.Lng = math.Min(cell.Sector.Center.Lng-lngMargin, min.Lng), math.Max(cell.Sector.Center.Lng+lngMargin, max.Lng)
+	}
+
 	ch := make(chan event.Event)
 	err = us.Watch(ctx, ch, ues.WatchOptions{Replay: true})
 	assert.NoError(t, err)
@@ -94,17 +117,357 @@ func TestRouteGeneration(t *testing.T) {
 	tickUnit = time.Millisecond
 	driver.Start(ctx)
 
-	c := 0
-	for e := range ch {
-		ue := e.Value.(*model.UE)
-		//fmt.Printf("%v: %v\n", ue.Location, ue.Heading)
-		assert.True(t, 52.41 < ue.Location.Lat && ue.Location.Lat < 52.57, "UE latitude is out of range")
-		assert.True(t, 13.29 < ue.Location.Lng && ue.Location.Lng < 13.52, "UE longitude is out of range")
-		c = c + 1
-		if c > 500 {
-			break
+	timeout := time.After(10 * time.Second)
+	for c := 0; c <= 500; c++ {
+		select {
+		case e := <-ch:
+			ue := e.Value.(*model.UE)
+			//fmt.Printf("%v: %v\n", ue.Location, ue.Heading)
+			assert.True(t, min.Lat <= ue.Location.Lat && ue.Location.Lat <= max.Lat, "UE latitude %v is out of range", ue.Location.Lat)
+			assert.True(t, min.Lng <= ue.Location.Lng && ue.Location.Lng <= max.Lng, "UE longitude %v is out of range", ue.Location.Lng)
+		case <-timeout:
+			t.Fatalf("only %d UE events received in time", c)
 		}
 	}
 
 	driver.Stop()
 }
+
+// newHandoverDriver creates a driver, without starting it, for a connected UE along with a cell other than its
+// serving cell
+func newHandoverDriver(t *testing.T, handoverConfig model.HandoverConfig) (*driver, ues.Store, metrics.Store, *model.UE, types.NCGI) {
+	m := &model.Model{}
+	err := model.LoadConfig(m, "../model/test")
+	assert.NoError(t, err)
+	// The NCGIs of the test model do not follow the default split of the NCI between the gNB ID and the cell ID, which
+	// puts the cells of both nodes in the same derived tracking area; the cells of the second node get their own
+	for _, name := range []string{"cell3", "cell4"} {
+		cell := m.Cells[name]
+		cell.TAC = m.Cells["cell1"].TrackingArea() + 1
+		m.Cells[name] = cell
+	}
+
+	ns := nodes.NewNodeRegistry(m.Nodes)
+	cs := cells.NewCellRegistry(m.Cells, ns)
+	us := ues.NewUERegistry(1, cs, "connected")
+	rs := routes.NewRouteRegistry()
+	ms := metrics.NewMetricsStore()
+	ctx := context.TODO()
+
+	d := NewMobilityDriver(cs, rs, us, ms, "", "local", 15, model.RrcConfig{}, model.MeasurementNoiseConfig{}, model.DualConnectivityConfig{}, model.CarrierAggregationConfig{}, handoverConfig, model.RachConfig{}, model.InterferenceConfig{}, model.UplinkConfig{}, model.ThroughputConfig{}, model.SchedulerConfig{}, model.TrafficProfileConfig{}, nil, 0, false, false).(*driver)
+	ue := us.ListAllUEs(ctx)[0]
+	d.ueLock = map[types.IMSI]*sync.Mutex{ue.IMSI: {}}
+
+	cellList, err := cs.List(ctx)
+	assert.NoError(t, err)
+	for _, cell := range cellList {
+		if cell.NCGI != ue.Cell.NCGI {
+			return d, us, ms, ue, cell.NCGI
+		}
+	}
+	t.Fatal("no target cell")
+	return nil, nil, nil, nil, 0
+}
+
+func TestHandoverInterruption(t *testing.T) {
+	ctx := context.TODO()
+	d, us, ms, ue, target := newHandoverDriver(t, model.HandoverConfig{
+		PreparationTime:  10 * time.Millisecond,
+		ExecutionTime:    50 * time.Millisecond,
+		InterruptionTime: 40 * time.Millisecond,
+	})
+	source := ue.Cell.NCGI
+
+	d.Handover(ctx, ue.IMSI, &model.UECell{ID: types.GnbID(target), NCGI: target})
+	assert.Equal(t, source, ue.Cell.NCGI)
+	assert.False(t, ue.Detached)
+
+	assert.Eventually(t, func() bool {
+		ue, err := us.Get(ctx, ue.IMSI)
+		return err == nil && ue.Detached
+	}, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return stats.GetCounter(ctx, ms, source, stats.HoExeSucc) == 1
+	}, time.Second, time.Millisecond)
+
+	ue, err := us.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, target, ue.Cell.NCGI)
+	assert.False(t, ue.Detached)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.HoPrepAtt))
+	assert.Equal(t, uint64(40), stats.GetCounter(ctx, ms, source, stats.HoInterruptionTime))
+	assert.True(t, stats.GetCounter(ctx, ms, source, stats.HoExeTime) >= 50)
+}
+
+func TestHandoverFailure(t *testing.T) {
+	ctx := context.TODO()
+	d, _, ms, ue, target := newHandoverDriver(t, model.HandoverConfig{FailureProbability: 1})
+	source := ue.Cell.NCGI
+
+	// The UE falls back to its source cell
+	d.Handover(ctx, ue.IMSI, &model.UECell{ID: types.GnbID(target), NCGI: target})
+	assert.Equal(t, source, ue.Cell.NCGI)
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED, ue.RrcState)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.HoExeFail))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, string(stats.ReEstabHOFail)))
+
+	// The connection of the UE drops
+	d.handoverConfig.DropProbability = 1
+	d.Handover(ctx, ue.IMSI, &model.UECell{ID: types.GnbID(target), NCGI: target})
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE, ue.RrcState)
+	assert.Equal(t, uint64(2), stats.GetCounter(ctx, ms, source, stats.HoExeFail))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.RrcConnDrop))
+}
+
+func TestHandoverPingPong(t *testing.T) {
+	ctx := context.TODO()
+	d, us, ms, ue, target := newHandoverDriver(t, model.HandoverConfig{
+		PingPongProbability: 1,
+		PingPongWindow:      20 * time.Millisecond,
+	})
+	source := ue.Cell.NCGI
+
+	// Only the first handover is handed back
+	d.Handover(ctx, ue.IMSI, &model.UECell{ID: types.GnbID(target), NCGI: target})
+	d.handoverConfig.PingPongProbability = 0
+	assert.Eventually(t, func() bool {
+		return stats.GetCounter(ctx, ms, target, stats.HoPingPong) == 1
+	}, time.Second, time.Millisecond)
+
+	ue, err := us.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, source, ue.Cell.NCGI)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.HoExeSucc))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, target, stats.HoExeSucc))
+	assert.Equal(t, uint64(0), stats.GetCounter(ctx, ms, source, stats.HoPingPong))
+}
+
+func TestCellOutage(t *testing.T) {
+	ctx := context.TODO()
+	d, _, ms, ue, _ := newHandoverDriver(t, model.HandoverConfig{})
+	source := ue.Cell.NCGI
+
+	// The UE loses its connection and camps on another cell
+	assert.NoError(t, d.cellStore.SetFailed(ctx, source, true))
+	d.updateUESignalStrength(ctx, ue.IMSI)
+	assert.NotEqual(t, source, ue.Cell.NCGI)
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE, ue.RrcState)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.RrcConnDrop))
+	for _, cell := range ue.Cells {
+		assert.NotEqual(t, source, cell.NCGI)
+	}
+
+	// The UE can not access the failed cell
+	assert.False(t, d.accessCell(ctx, &model.UE{IMSI: ue.IMSI, Cell: &model.UECell{NCGI: source}}))
+}
+
+func TestUpdateSinr(t *testing.T) {
+	ctx := context.TODO()
+	d, _, _, ue, _ := newHandoverDriver(t, model.HandoverConfig{})
+	serving := &model.Cell{NCGI: ue.Cell.NCGI, Frequency: 3600}
+	coChannel := &model.Cell{NCGI: 1, Frequency: 3600}
+	otherChannel := &model.Cell{NCGI: 2, Frequency: 2100}
+	measured := &measurements{
+		cells:     []*model.Cell{serving, coChannel, otherChannel},
+		strengths: map[types.NCGI]float64{serving.NCGI: -80, coChannel.NCGI: -90, otherChannel.NCGI: -70},
+	}
+
+	d.updateSinr(ctx, ue, measured)
+	assert.InDelta(t, model.SINR(-80, []float64{-90}, -122.2), ue.Sinr, 1e-9)
+	assert.Equal(t, model.CQI(ue.Sinr), ue.Cqi)
+
+	// A failed cell does not interfere
+	coChannel.Failed = true
+	d.updateSinr(ctx, ue, measured)
+	assert.InDelta(t, 42.2, ue.Sinr, 1e-9)
+	assert.Equal(t, uint32(15), ue.Cqi)
+}
+
+func TestUpdateUplink(t *testing.T) {
+	ctx := context.TODO()
+	d, _, _, ue, _ := newHandoverDriver(t, model.HandoverConfig{})
+	serving := &model.Cell{NCGI: ue.Cell.NCGI, TxPowerDB: 40}
+	measured := &measurements{
+		cells:     []*model.Cell{serving},
+		strengths: map[types.NCGI]float64{serving.NCGI: -80},
+	}
+
+	d.updateUplink(ctx, ue, measured)
+	expected := model.UplinkConfig{}.Uplink(120, -122.2)
+	assert.Equal(t, expected.Sinr, ue.Uplink.Sinr)
+	assert.Equal(t, expected.PowerHeadroom, ue.Uplink.PowerHeadroom)
+	assert.Equal(t, model.Throughput(10, model.ThroughputConfig{}.UplinkSpectralEfficiency(expected.Sinr)), ue.Uplink.Throughput)
+	assert.True(t, ue.Uplink.Throughput > 0)
+
+	// Raising the noise floor of the cell degrades the uplink SINR and throughput
+	serving.Interference = 10
+	d.updateUplink(ctx, ue, measured)
+	assert.InDelta(t, expected.Sinr-10, ue.Uplink.Sinr, 1e-9)
+	assert.True(t, ue.Uplink.Throughput < model.Throughput(10, model.ThroughputConfig{}.UplinkSpectralEfficiency(expected.Sinr)))
+}
+
+func TestRadioLinkFailure(t *testing.T) {
+	ctx := context.TODO()
+	d, _, ms, ue, target := newHandoverDriver(t, model.HandoverConfig{RlfThreshold: -100})
+	source := ue.Cell.NCGI
+
+	// The serving cell fades long after the last handover
+	ue.Cell.Strength = -110
+	ue.Cells = []*model.UECell{{ID: types.GnbID(target), NCGI: target, Strength: -90}}
+	assert.True(t, d.radioLinkFailed(ue))
+	d.recoverRadioLink(ctx, ue)
+	assert.Equal(t, target, ue.Cell.NCGI)
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED, ue.RrcState)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, string(stats.HoTooLate)))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.PairCounter(stats.HoTooLate, target)))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, target, string(stats.ReEstabOther)))
+
+	// The target cell fades shortly after a handover and the UE goes back to the source cell
+	d.lastHandovers.Store(ue.IMSI, lastHandover{source: source, target: target, time: time.Now()})
+	ue.Cell.Strength = -110
+	ue.Cells = []*model.UECell{{ID: types.GnbID(source), NCGI: source, Strength: -90}}
+	d.recoverRadioLink(ctx, ue)
+	assert.Equal(t, source, ue.Cell.NCGI)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, string(stats.HoTooEarly)))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.PairCounter(stats.HoTooEarly, target)))
+
+	// Re-establishing on a third cell shortly after a handover
+	d.lastHandovers.Store(ue.IMSI, lastHandover{source: target, target: source, time: time.Now()})
+	d.classifyRadioLinkFailure(ctx, ue.IMSI, source, types.NCGI(1))
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, target, string(stats.HoWrongCell)))
+
+	// No cell left to re-establish on
+	ue.Cell.Strength = -110
+	ue.Cells = nil
+	d.recoverRadioLink(ctx, ue)
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE, ue.RrcState)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.RrcConnDrop))
+	assert.False(t, d.radioLinkFailed(ue))
+}
+
+func TestPaging(t *testing.T) {
+	ctx := context.TODO()
+	d, _, ms, ue, _ := newHandoverDriver(t, model.HandoverConfig{})
+	camped := ue.Cell.NCGI
+	area := d.pagingArea(ctx, d.trackingArea(ctx, camped))
+	assert.Len(t, area, 2)
+	for _, ncgi := range area {
+		assert.True(t, sameNode(camped, ncgi))
+	}
+
+	// The UE responds on its cell and connects
+	_, err := d.setRrcState(ctx, ue, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE)
+	assert.NoError(t, err)
+	connected, err := d.page(ctx, ue)
+	assert.NoError(t, err)
+	assert.True(t, connected)
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED, ue.RrcState)
+	for _, ncgi := range area {
+		assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, ncgi, stats.PagingAtt))
+		assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, ncgi, stats.PagingSucc))
+	}
+
+	// The UE is not reached through a failed cell, which does not page
+	_, err = d.setRrcState(ctx, ue, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE)
+	assert.NoError(t, err)
+	assert.NoError(t, d.cellStore.SetFailed(ctx, camped, true))
+	connected, err = d.page(ctx, ue)
+	assert.NoError(t, err)
+	assert.False(t, connected)
+	assert.Equal(t, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE, ue.RrcState)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, camped, stats.PagingAtt))
+	for _, ncgi := range area {
+		if ncgi != camped {
+			assert.Equal(t, uint64(2), stats.GetCounter(ctx, ms, ncgi, stats.PagingAtt))
+			assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, ncgi, stats.PagingFail))
+		}
+	}
+}
+
+func TestTrackingAreaUpdate(t *testing.T) {
+	ctx := context.TODO()
+	d, _, ms, ue, target := newHandoverDriver(t, model.HandoverConfig{})
+	source := ue.Cell.NCGI
+
+	// The first registration is not an update
+	d.updateTrackingArea(ctx, ue)
+	assert.Equal(t, d.trackingArea(ctx, source), ue.TAC)
+	assert.Equal(t, uint64(0), stats.GetCounter(ctx, ms, source, stats.RegMobilityUpdate))
+
+	// Moving to a cell of another tracking area updates the registration
+	cell, err := d.cellStore.Get(ctx, target)
+	assert.NoError(t, err)
+	cell.TAC = 7
+	assert.NoError(t, d.cellStore.Update(ctx, cell))
+	assert.NoError(t, d.ueStore.MoveToCell(ctx, ue.IMSI, target, -90))
+	d.updateTrackingArea(ctx, ue)
+	assert.Equal(t, uint32(7), ue.TAC)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, target, stats.RegMobilityUpdate))
+	assert.Equal(t, []types.NCGI{target}, d.pagingArea(ctx, 7))
+
+	// Staying in the tracking area does not
+	d.updateTrackingArea(ctx, ue)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, target, stats.RegMobilityUpdate))
+}
+
+func TestTransferUE(t *testing.T) {
+	ctx := context.TODO()
+	d, us, ms, ue, target := newHandoverDriver(t, model.HandoverConfig{})
+	source := ue.Cell.NCGI
+	remote, err := d.cellStore.Get(ctx, target)
+	assert.NoError(t, err)
+
+	// The UE at the center of the cell of another shard receives it better than its serving cell
+	assert.NoError(t, d.ueStore.MoveToCoordinate(ctx, ue.IMSI, remote.Sector.Center, 0))
+	ue.Cell.Strength = -200
+	var transferred *model.UE
+	d.SetRemoteCells([]model.RemoteCell{{Cell: *remote, Shard: 1}}, func(ctx context.Context, shard uint, ue *model.UE, route *model.Route, ncgi types.NCGI) error {
+		if shard != 1 || ncgi != target {
+			return fmt.Errorf("unexpected cell %d of shard %d", ncgi, shard)
+		}
+		transferred = ue
+		return nil
+	})
+	assert.True(t, d.transferUE(ctx, ue.IMSI))
+	assert.Equal(t, ue.IMSI, transferred.IMSI)
+	_, err = us.Get(ctx, ue.IMSI)
+	assert.Error(t, err)
+	cell, err := d.cellStore.Get(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), cell.RrcConnectedCount)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.HoExeSucc))
+
+	// The UE is admitted back on its source cell, once only
+	assert.NoError(t, d.AdmitUE(ctx, transferred, nil))
+	assert.Error(t, d.AdmitUE(ctx, transferred, nil))
+	cell, err = d.cellStore.Get(ctx, source)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), cell.RrcConnectedCount)
+	_, ok := d.ueMutex(ue.IMSI)
+	assert.True(t, ok)
+
+	// The UE stays if the other shard can not admit it
+	d.SetRemoteCells([]model.RemoteCell{{Cell: *remote, Shard: 1}}, func(ctx context.Context, shard uint, ue *model.UE, route *model.Route, ncgi types.NCGI) error {
+		return fmt.Errorf("shard %d is unreachable", shard)
+	})
+	assert.False(t, d.transferUE(ctx, ue.IMSI))
+	_, err = us.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), stats.GetCounter(ctx, ms, source, stats.HoExeFail))
+}
+
+func TestPreferUECell(t *testing.T) {
+	home := types.PlmnIDFromString("314628")
+	visited := types.PlmnIDFromString("315010")
+	homeCell := &model.UECell{NCGI: types.ToNCGI(home, types.ToNCI(144470, 1)), Strength: -90}
+	visitedCell := &model.UECell{NCGI: types.ToNCGI(visited, types.ToNCI(144471, 1)), Strength: -87}
+
+	// The home PLMN is preferred over a visited PLMN received slightly better...
+	assert.True(t, preferUECell(homeCell, visitedCell, home))
+	assert.False(t, preferUECell(visitedCell, homeCell, home))
+
+	// ...but not over a visited PLMN received better by more than the offset
+	visitedCell.Strength = homeCell.Strength + homePlmnOffset + 1
+	assert.True(t, preferUECell(visitedCell, homeCell, home))
+	assert.False(t, preferUECell(homeCell, visitedCell, home))
+}
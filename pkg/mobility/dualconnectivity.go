@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"math"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// updateSecondaryCell refreshes the signal strength of the secondary cell of the UE and releases the secondary node
+// if the UE is no longer connected, the secondary cell is gone or too weak, or the UE was handed over to the
+// secondary node. If automatic dual connectivity is enabled, the strongest candidate cell of another node is added
+// as secondary cell to connected UEs
+func (d *driver) updateSecondaryCell(ctx context.Context, ue *model.UE) {
+	if ue.SecondaryCell != nil {
+		strength := math.NaN()
+		if cell, err := d.cellStore.Get(ctx, ue.SecondaryCell.NCGI); err == nil && !cell.Failed {
+			strength = d.noise.apply(ue, cell, StrengthAtLocation(ue.Location, *cell))
+		}
+		if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED || math.IsNaN(strength) ||
+			strength < d.dualConnectivity.GetReleaseThreshold() || sameNode(ue.Cell.NCGI, ue.SecondaryCell.NCGI) {
+			log.Debugf("Releasing secondary cell %d of UE %d", ue.SecondaryCell.NCGI, ue.IMSI)
+			if err := d.ueStore.SetSecondaryCell(ctx, ue.IMSI, nil, 0); err != nil {
+				log.Warn(err)
+			}
+			return
+		}
+		secondaryCell := &model.UECell{
+			ID:       ue.SecondaryCell.ID,
+			NCGI:     ue.SecondaryCell.NCGI,
+			Strength: strength,
+		}
+		if err := d.ueStore.SetSecondaryCell(ctx, ue.IMSI, secondaryCell, ue.SplitRatio); err != nil {
+			log.Warn(err)
+		}
+		return
+	}
+
+	if !d.dualConnectivity.Enabled || ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		return
+	}
+	// Candidate cells are sorted by decreasing signal strength
+	for _, cell := range ue.Cells {
+		if sameNode(ue.Cell.NCGI, cell.NCGI) {
+			continue
+		}
+		if cell.Strength >= d.dualConnectivity.GetAdditionThreshold() {
+			log.Debugf("Adding secondary cell %d to UE %d", cell.NCGI, ue.IMSI)
+			secondaryCell := &model.UECell{
+				ID:       cell.ID,
+				NCGI:     cell.NCGI,
+				Strength: cell.Strength,
+			}
+			if err := d.ueStore.SetSecondaryCell(ctx, ue.IMSI, secondaryCell, d.dualConnectivity.GetSplitRatio()); err != nil {
+				log.Warn(err)
+			}
+		}
+		return
+	}
+}
+
+// sameNode returns true if both cells belong to the same node
+func sameNode(ncgi1 types.NCGI, ncgi2 types.NCGI) bool {
+	return types.GetGnbID(uint64(ncgi1)) == types.GetGnbID(uint64(ncgi2))
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// measurements RSRP measured by a UE from the cells
+type measurements struct {
+	cells     []*model.Cell
+	strengths map[types.NCGI]float64
+}
+
+// cell returns the cell with the given NCGI, if any
+func (m *measurements) cell(ncgi types.NCGI) *model.Cell {
+	for _, cell := range m.cells {
+		if cell.NCGI == ncgi {
+			return cell
+		}
+	}
+	return nil
+}
+
+// interferers returns the RSRP of the cells in service on the carrier frequency of the given cell, other than the cell
+func (m *measurements) interferers(cell *model.Cell) []float64 {
+	var interferers []float64
+	for _, other := range m.cells {
+		if other.NCGI == cell.NCGI || other.Failed || other.CarrierFrequency() != cell.CarrierFrequency() {
+			continue
+		}
+		if strength, ok := m.strengths[other.NCGI]; ok {
+			interferers = append(interferers, strength)
+		}
+	}
+	return interferers
+}
+
+// sinr returns the SINR of the given cell received by the UE with the given RSRP; the cells in service on the
+// carrier frequency of the cell interfere with it
+func (d *driver) sinr(m *measurements, cell *model.Cell, rsrp float64) float64 {
+	return model.SINR(rsrp, m.interferers(cell), d.interference.GetNoise(cell))
+}
+
+// updateSinr updates the SINR of the UE on its serving cell
+func (d *driver) updateSinr(ctx context.Context, ue *model.UE, m *measurements) {
+	serving := m.cell(ue.Cell.NCGI)
+	rsrp, ok := m.strengths[ue.Cell.NCGI]
+	if serving == nil || serving.Failed || !ok {
+		return
+	}
+
+	if err := d.ueStore.UpdateSinr(ctx, ue.IMSI, d.sinr(m, serving, rsrp)); err != nil {
+		log.Warn(err)
+	}
+}
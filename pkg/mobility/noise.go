@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// defaultShadowingDecorrelation distance in meters over which the shadowing decorrelates
+const defaultShadowingDecorrelation = 50.0
+
+// speedOfLight in meters per second
+const speedOfLight = 299792458.0
+
+// minFading floor of the fast fading in dB, avoiding infinite losses in deep fades
+const minFading = -40.0
+
+type linkKey struct {
+	imsi types.IMSI
+	ncgi types.NCGI
+}
+
+// linkNoise noise state of the link between a UE and a cell
+type linkNoise struct {
+	location  model.Coordinate
+	time      time.Time
+	shadowing float64 // dB
+	fadingI   float64 // in-phase component of the complex channel gain
+	fadingQ   float64 // quadrature component of the complex channel gain
+}
+
+// measurementNoise applies log-normal shadowing and Rayleigh fast fading to the RSRP measured by the UEs. The
+// shadowing of each link is correlated over the distance moved by the UE (Gudmundson model) and the fast fading over
+// time according to the Doppler shift of the UE speed (Jakes model).
+type measurementNoise struct {
+	mu     sync.Mutex
+	config model.MeasurementNoiseConfig
+	rand   *rand.Rand
+	links  map[linkKey]*linkNoise
+}
+
+func newMeasurementNoise(config model.MeasurementNoiseConfig) *measurementNoise {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if config.ShadowingDecorrelation <= 0 {
+		config.ShadowingDecorrelation = defaultShadowingDecorrelation
+	}
+	return &measurementNoise{
+		config: config,
+		rand:   rand.New(rand.NewSource(seed)),
+		links:  make(map[linkKey]*linkNoise),
+	}
+}
+
+// apply returns the RSRP measured by the UE on the cell with the noise of their link added
+func (n *measurementNoise) apply(ue *model.UE, cell *model.Cell, rsrp float64) float64 {
+	return n.applyAt(ue, cell, rsrp, time.Now())
+}
+
+func (n *measurementNoise) applyAt(ue *model.UE, cell *model.Cell, rsrp float64, now time.Time) float64 {
+	if !n.config.IsEnabled() || math.IsNaN(rsrp) || math.IsInf(rsrp, 0) {
+		return rsrp
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	key := linkKey{imsi: ue.IMSI, ncgi: cell.NCGI}
+	link, ok := n.links[key]
+	if !ok {
+		link = &linkNoise{
+			shadowing: n.rand.NormFloat64() * n.config.ShadowingStdDev,
+			fadingI:   n.rand.NormFloat64() / math.Sqrt2,
+			fadingQ:   n.rand.NormFloat64() / math.Sqrt2,
+		}
+		n.links[key] = link
+	} else {
+		rho := math.Exp(-utils.Distance(link.location, ue.Location) / n.config.ShadowingDecorrelation)
+		link.shadowing = rho*link.shadowing + math.Sqrt(1-rho*rho)*n.rand.NormFloat64()*n.config.ShadowingStdDev
+
+		// Speeds are in km/h
+		doppler := ue.Speed / 3.6 * cell.CarrierFrequency() * 1e6 / speedOfLight
+		rho = math.J0(2 * math.Pi * doppler * now.Sub(link.time).Seconds())
+		innovation := math.Sqrt(1-rho*rho) / math.Sqrt2
+		link.fadingI = rho*link.fadingI + innovation*n.rand.NormFloat64()
+		link.fadingQ = rho*link.fadingQ + innovation*n.rand.NormFloat64()
+	}
+	link.location = ue.Location
+	link.time = now
+
+	noise := link.shadowing
+	if n.config.FastFading {
+		noise += math.Max(10*math.Log10(link.fadingI*link.fadingI+link.fadingQ*link.fadingQ), minFading)
+	}
+	return rsrp + noise
+}
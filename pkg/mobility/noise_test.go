@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasurementNoiseDisabled(t *testing.T) {
+	noise := newMeasurementNoise(model.MeasurementNoiseConfig{})
+	ue := &model.UE{IMSI: 1, Location: model.Coordinate{Lat: 52.0, Lng: 13.0}}
+	assert.Equal(t, -80.0, noise.apply(ue, &model.Cell{NCGI: 17}, -80.0))
+}
+
+func TestMeasurementNoiseSeeded(t *testing.T) {
+	config := model.MeasurementNoiseConfig{ShadowingStdDev: 8, FastFading: true, Seed: 42}
+	n1, n2 := newMeasurementNoise(config), newMeasurementNoise(config)
+	ue := &model.UE{IMSI: 1, Location: model.Coordinate{Lat: 52.0, Lng: 13.0}, Speed: 60}
+	cell := &model.Cell{NCGI: 17}
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		ue.Location.Lat += 0.0001
+		now = now.Add(time.Second)
+		r1 := n1.applyAt(ue, cell, -80.0, now)
+		assert.Equal(t, r1, n2.applyAt(ue, cell, -80.0, now))
+		assert.False(t, math.IsInf(r1, 0))
+	}
+	assert.True(t, math.IsInf(n1.apply(ue, cell, math.Inf(-1)), -1))
+}
+
+func TestMeasurementNoiseStationary(t *testing.T) {
+	noise := newMeasurementNoise(model.MeasurementNoiseConfig{ShadowingStdDev: 8, FastFading: true, Seed: 7})
+	ue := &model.UE{IMSI: 1, Location: model.Coordinate{Lat: 52.0, Lng: 13.0}}
+	cell := &model.Cell{NCGI: 17}
+	now := time.Now()
+	first := noise.applyAt(ue, cell, -80.0, now)
+	for i := 1; i < 5; i++ {
+		assert.InDelta(t, first, noise.applyAt(ue, cell, -80.0, now.Add(time.Duration(i)*time.Second)), 1e-9)
+	}
+
+	// Links to different cells fade independently
+	assert.NotEqual(t, first, noise.applyAt(ue, &model.Cell{NCGI: 18}, -80.0, now))
+}
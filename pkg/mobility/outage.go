@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// servingCellFailed returns true if the serving cell of the UE is out of service
+func (d *driver) servingCellFailed(ctx context.Context, ue *model.UE) bool {
+	cell, err := d.cellStore.Get(ctx, ue.Cell.NCGI)
+	return err == nil && cell.Failed
+}
+
+// reselectCell handles the loss of coverage of a UE served by a failed cell: the RRC connection of the UE drops and
+// the UE camps on the strongest candidate cell in service. The UE stays on the failed cell, without service, if no
+// other cell covers it.
+func (d *driver) reselectCell(ctx context.Context, ue *model.UE) {
+	source := ue.Cell.NCGI
+	if ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		log.Warnf("UE %d lost its connection on failed cell %d", ue.IMSI, source)
+		d.rrcStats.ConnectionDrop(ctx, source)
+	}
+	if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_IDLE {
+		if _, err := d.setRrcState(ctx, ue, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE); err != nil {
+			log.Warn(err)
+			return
+		}
+	}
+	if len(ue.Cells) == 0 {
+		log.Debugf("UE %d finds no cell in service to replace failed cell %d", ue.IMSI, source)
+		return
+	}
+
+	target := ue.Cells[0]
+	d.cellStore.DecrementRrcIdleCount(ctx, source)
+	d.cellStore.IncrementRrcIdleCount(ctx, target.NCGI)
+	err := d.ueStore.UpdateCell(ctx, ue.IMSI, &model.UECell{
+		ID:       target.ID,
+		NCGI:     target.NCGI,
+		Strength: target.Strength,
+	})
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	d.ueStore.UpdateMaxUEsPerCell(ctx)
+	log.Infof("UE %d reselected cell %d after failure of cell %d", ue.IMSI, target.NCGI, source)
+}
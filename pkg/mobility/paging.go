@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// page pages the idle or inactive UE for the downlink traffic which arrived for it on all cells of the tracking area
+// it is registered in, and connects the UE if it responds; the paging is counted by each cell of the area
+func (d *driver) page(ctx context.Context, ue *model.UE) (bool, error) {
+	tac := ue.TAC
+	if tac == 0 {
+		tac = d.trackingArea(ctx, ue.Cell.NCGI)
+	}
+	area := d.pagingArea(ctx, tac)
+	responded := !ue.Detached && d.reachable(ue, area)
+	for _, ncgi := range area {
+		d.rrcStats.Paging(ctx, ncgi, responded)
+	}
+	if !responded {
+		log.Infof("UE %d did not respond to paging on %d cells of tracking area %d", ue.IMSI, len(area), tac)
+		return false, nil
+	}
+	return d.rrcConnected(ctx, ue, RrcStateChangeVariance)
+}
+
+// pagingArea returns the cells in service of the given tracking area, which page the UEs registered in it
+func (d *driver) pagingArea(ctx context.Context, tac uint32) []types.NCGI {
+	cells, err := d.cellStore.List(ctx)
+	if err != nil {
+		log.Warn(err)
+		return nil
+	}
+	area := make([]types.NCGI, 0)
+	for _, cell := range cells {
+		if cell.TrackingArea() == tac && !cell.Failed {
+			area = append(area, cell.NCGI)
+		}
+	}
+	return area
+}
+
+// reachable returns true if the UE camps on a cell of the paging area and, if a radio link failure threshold is set,
+// receives it above the threshold
+func (d *driver) reachable(ue *model.UE, area []types.NCGI) bool {
+	threshold := d.handoverConfig.RlfThreshold
+	if threshold != 0 && ue.Cell.Strength < threshold {
+		return false
+	}
+	for _, ncgi := range area {
+		if ncgi == ue.Cell.NCGI {
+			return true
+		}
+	}
+	return false
+}
+
+// trackingArea returns the tracking area of the cell, or 0 if the cell is unknown
+func (d *driver) trackingArea(ctx context.Context, ncgi types.NCGI) uint32 {
+	cell, err := d.cellStore.Get(ctx, ncgi)
+	if err != nil {
+		log.Warn(err)
+		return 0
+	}
+	return cell.TrackingArea()
+}
+
+// updateTrackingArea registers the UE in the tracking area of its serving cell; a UE entering a new tracking area
+// updates its registration, which is counted by the cell it entered the area through
+func (d *driver) updateTrackingArea(ctx context.Context, ue *model.UE) {
+	tac := d.trackingArea(ctx, ue.Cell.NCGI)
+	if tac == 0 || tac == ue.TAC {
+		return
+	}
+	if ue.TAC != 0 {
+		log.Infof("UE %d moved from tracking area %d to %d on cell %d, updating its registration", ue.IMSI, ue.TAC, tac, ue.Cell.NCGI)
+		d.rrcStats.RegistrationUpdate(ctx, ue.Cell.NCGI)
+	}
+	if err := d.ueStore.SetTrackingArea(ctx, ue.IMSI, tac); err != nil {
+		log.Warn(err)
+	}
+}
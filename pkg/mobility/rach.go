@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// randomAccess simulates the random access channel of the cells; the preambles transmitted on a cell within a second
+// beyond its capacity are likely to collide
+type randomAccess struct {
+	config model.RachConfig
+	random func() float64
+	mu     sync.Mutex
+	loads  map[types.NCGI]*rachLoad
+}
+
+// rachLoad number of preambles transmitted on a cell within a second
+type rachLoad struct {
+	second    int64
+	preambles int
+}
+
+func newRandomAccess(config model.RachConfig) *randomAccess {
+	return &randomAccess{
+		config: config,
+		random: rand.Float64,
+		loads:  make(map[types.NCGI]*rachLoad),
+	}
+}
+
+// attempt transmits the preambles of a UE on the cell until one of them is detected or the maximum number of
+// preamble transmissions is reached; it returns the number of transmitted preambles and true if the random access
+// succeeded
+func (r *randomAccess) attempt(cell *model.Cell, now time.Time) (int, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	load, ok := r.loads[cell.NCGI]
+	if !ok {
+		load = &rachLoad{}
+		r.loads[cell.NCGI] = load
+	}
+	if load.second != now.Unix() {
+		load.second = now.Unix()
+		load.preambles = 0
+	}
+
+	capacity := r.config.GetCapacity(cell)
+	for preambles := 1; preambles <= r.config.GetMaxPreambles(); preambles++ {
+		load.preambles++
+		detection := 1 - r.config.FailureProbability
+		if capacity > 0 {
+			detection *= math.Min(1, capacity/float64(load.preambles))
+		}
+		if r.random() < detection {
+			return preambles, true
+		}
+	}
+	return r.config.GetMaxPreambles(), false
+}
+
+// accessCell performs the random access of the UE on its serving cell and records it; it returns true if the random
+// access succeeded
+func (d *driver) accessCell(ctx context.Context, ue *model.UE) bool {
+	cell, err := d.cellStore.Get(ctx, ue.Cell.NCGI)
+	if err != nil {
+		log.Warn(err)
+		return false
+	}
+	// a failed cell detects no preambles
+	if cell.Failed {
+		return false
+	}
+	preambles, success := d.rach.attempt(cell, time.Now())
+	d.rachStats.RandomAccess(ctx, cell.NCGI, preambles, success)
+	if !success {
+		log.Debugf("Random access of UE %d failed on cell %d after %d preambles", ue.IMSI, cell.NCGI, preambles)
+	}
+	return success
+}
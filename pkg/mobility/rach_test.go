@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomAccess(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cell := &model.Cell{NCGI: 1}
+
+	// Unlimited capacity
+	r := newRandomAccess(model.RachConfig{})
+	r.random = func() float64 { return 0.99 }
+	preambles, success := r.attempt(cell, now)
+	assert.True(t, success)
+	assert.Equal(t, 1, preambles)
+
+	// Each preamble transmission fails
+	r = newRandomAccess(model.RachConfig{FailureProbability: 1, MaxPreambles: 3})
+	preambles, success = r.attempt(cell, now)
+	assert.False(t, success)
+	assert.Equal(t, 3, preambles)
+
+	// Beyond the capacity of the cell, the preambles are detected with a probability of capacity/load
+	r = newRandomAccess(model.RachConfig{Capacity: 2})
+	r.random = func() float64 { return 0.6 }
+	preambles, success = r.attempt(cell, now)
+	assert.True(t, success)
+	assert.Equal(t, 1, preambles)
+	preambles, success = r.attempt(cell, now)
+	assert.True(t, success)
+	assert.Equal(t, 1, preambles)
+	// 2/3 and then 2/4 of the preambles are detected
+	preambles, success = r.attempt(cell, now)
+	assert.True(t, success)
+	assert.Equal(t, 1, preambles)
+	preambles, success = r.attempt(cell, now)
+	assert.False(t, success)
+	assert.Equal(t, 4, preambles)
+
+	// The load is reset every second and the capacity of the cell overrides that of the configuration
+	preambles, success = r.attempt(cell, now.Add(time.Second))
+	assert.True(t, success)
+	assert.Equal(t, 1, preambles)
+	r.random = func() float64 { return 0.1 }
+	preambles, success = r.attempt(&model.Cell{NCGI: 2, RachCapacity: 0.5}, now)
+	assert.True(t, success)
+	assert.Equal(t, 1, preambles)
+}
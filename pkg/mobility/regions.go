@@ -0,0 +1,101 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package mobility
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// maxSamples maximum number of attempts at picking a random point or a straight path inside a region
+const maxSamples = 1000
+
+// regionTargets returns the number of UEs to place in each region according to its density; the targets are
+// scaled down proportionally if the given number of UEs does not suffice to populate all regions
+func regionTargets(regions []model.Region, ueCount int) []int {
+	wanted := make([]float64, len(regions))
+	var total float64
+	for i, region := range regions {
+		if len(region.Polygon) < 3 || region.Density <= 0 {
+			log.Warnf("Ignoring region %s without a valid polygon and density", region.Name)
+			continue
+		}
+		wanted[i] = region.Density * utils.PolygonArea(region.Polygon) / 1e6
+		total += wanted[i]
+	}
+	scale := 1.0
+	if total > float64(ueCount) {
+		log.Warnf("Regions need %.0f UEs but only %d are simulated; scaling down the densities", total, ueCount)
+		scale = float64(ueCount) / total
+	}
+	targets := make([]int, len(regions))
+	for i := range regions {
+		targets[i] = int(math.Floor(wanted[i] * scale))
+	}
+	return targets
+}
+
+// generateRegionRoute generates a random route for the UE that starts, ends and stays within the given region
+func (d *driver) generateRegionRoute(ctx context.Context, imsi types.IMSI, region model.Region, speedAvg uint32, speedStdDev uint32, directRoute bool) error {
+	start := randomPointInPolygon(region.Polygon)
+	end := start
+	for i := 0; i < maxSamples; i++ {
+		candidate := randomPointInPolygon(region.Polygon)
+		if segmentInPolygon(*start, *candidate, region.Polygon) {
+			end = candidate
+			break
+		}
+	}
+
+	points, err := randomRoute(start, end, directRoute)
+	if err != nil {
+		return err
+	}
+	// Random deviations may stray outside of the region near its edges
+	inside := make([]*model.Coordinate, 0, len(points))
+	for _, point := range points {
+		if utils.InPolygon(*point, region.Polygon) {
+			inside = append(inside, point)
+		}
+	}
+	if len(inside) < 2 {
+		inside = []*model.Coordinate{start, end}
+	}
+	log.Infof("Generated route for UE %d with %d points in region %s, start:%v, end:%v", imsi, len(inside), region.Name, start, end)
+	return d.addRoute(ctx, imsi, inside, speedAvg, speedStdDev)
+}
+
+// randomPointInPolygon returns a random coordinate inside the polygon, or its first vertex if none could be found
+func randomPointInPolygon(polygon []model.Coordinate) *model.Coordinate {
+	min, max := utils.BoundingBox(polygon)
+	for i := 0; i < maxSamples; i++ {
+		c := model.Coordinate{
+			Lat: rand.Float64()*(max.Lat-min.Lat) + min.Lat,
+			Lng: rand.Float64()*(max.Lng-min.Lng) + min.Lng,
+		}
+		if utils.InPolygon(c, polygon) {
+			return &c
+		}
+	}
+	c := polygon[0]
+	return &c
+}
+
+// segmentInPolygon returns true if the great-circle path between the two coordinates stays inside the polygon
+func segmentInPolygon(start model.Coordinate, end model.Coordinate, polygon []model.Coordinate) bool {
+	points := utils.Resample([]model.Coordinate{start, end}, routeStep)
+	for i := 1; i < len(points)-1; i++ {
+		if !utils.InPolygon(points[i], polygon) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/routes"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// stadium is an L-shaped region of roughly 1.1 square kilometers
+var stadium = model.Region{
+	Name: "stadium",
+	Polygon: []model.Coordinate{
+		{Lat: 52.50, Lng: 13.40}, {Lat: 52.50, Lng: 13.42}, {Lat: 52.505, Lng: 13.42},
+		{Lat: 52.505, Lng: 13.41}, {Lat: 52.51, Lng: 13.41}, {Lat: 52.51, Lng: 13.40},
+	},
+	Density: 100,
+}
+
+func TestRegionTargets(t *testing.T) {
+	invalid := model.Region{Name: "invalid", Polygon: stadium.Polygon[:2], Density: 100}
+	targets := regionTargets([]model.Region{stadium, invalid}, 1000)
+	assert.Equal(t, 0, targets[1])
+	assert.InDelta(t, 100*utils.PolygonArea(stadium.Polygon)/1e6, targets[0], 1)
+
+	// Densities are scaled down if there are not enough UEs
+	downtown := stadium
+	downtown.Density = 300
+	targets = regionTargets([]model.Region{stadium, downtown}, 100)
+	assert.LessOrEqual(t, targets[0]+targets[1], 100)
+	assert.InDelta(t, 25, targets[0], 1)
+	assert.InDelta(t, 75, targets[1], 1)
+}
+
+func TestRegionRoute(t *testing.T) {
+	ctx := context.TODO()
+	d := &driver{routeStore: routes.NewRouteRegistry()}
+	for imsi := types.IMSI(1); imsi <= 50; imsi++ {
+		assert.NoError(t, d.generateRegionRoute(ctx, imsi, stadium, 40000, 0, false))
+		route, err := d.routeStore.Get(ctx, imsi)
+		assert.NoError(t, err)
+		assert.True(t, len(route.Points) >= 2)
+		for _, point := range route.Points {
+			assert.True(t, utils.InPolygon(*point, stadium.Polygon), "route point is outside of the region")
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/stats"
+)
+
+// radioLinkFailed returns true if the serving cell of the connected UE is received below the radio link failure
+// threshold; the UE is not expected to receive its serving cell during the interruption of a handover
+func (d *driver) radioLinkFailed(ue *model.UE) bool {
+	threshold := d.handoverConfig.RlfThreshold
+	return threshold != 0 && ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED && !ue.Detached &&
+		ue.Cell.Strength < threshold
+}
+
+// recoverRadioLink re-establishes the connection of the UE whose radio link failed on the strongest cell it receives
+// above the radio link failure threshold, and classifies the failure; the connection drops if there is no such cell
+func (d *driver) recoverRadioLink(ctx context.Context, ue *model.UE) {
+	serving := ue.Cell.NCGI
+	var target *model.UECell
+	for _, cell := range ue.Cells {
+		if cell.Strength >= d.handoverConfig.RlfThreshold && (target == nil || cell.Strength > target.Strength) {
+			target = cell
+		}
+	}
+
+	if target == nil {
+		log.Warnf("UE %d lost its radio link on cell %d with no other cell to re-establish its connection on", ue.IMSI, serving)
+		d.lastHandovers.Delete(ue.IMSI)
+		d.rrcStats.ConnectionDrop(ctx, serving)
+		if _, err := d.setRrcState(ctx, ue, e2sm_mho.Rrcstatus_RRCSTATUS_IDLE); err != nil {
+			log.Warn(err)
+		}
+		return
+	}
+
+	log.Infof("UE %d lost its radio link on cell %d, re-establishing connection on cell %d", ue.IMSI, serving, target.NCGI)
+	d.rrcStats.ReEstablishmentAttempt(ctx, target.NCGI, stats.ReEstabOther)
+	d.classifyRadioLinkFailure(ctx, ue.IMSI, serving, target.NCGI)
+
+	d.cellStore.DecrementRrcConnectedCount(ctx, serving)
+	d.cellStore.IncrementRrcConnectedCount(ctx, target.NCGI)
+	err := d.ueStore.UpdateCell(ctx, ue.IMSI, &model.UECell{
+		ID:       target.ID,
+		NCGI:     target.NCGI,
+		Strength: target.Strength,
+	})
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	d.ueStore.UpdateMaxUEsPerCell(ctx)
+}
+
+// classifyRadioLinkFailure records the handover problem revealed by a radio link failure on the serving cell of a UE
+// which re-established its connection on another cell, per 3GPP TS 38.300: the last handover of the UE was too early
+// if it failed shortly after it and re-established on the source cell, or to the wrong cell if it re-established on
+// a third cell. Otherwise, the UE should have been handed over to the cell it re-established on earlier.
+func (d *driver) classifyRadioLinkFailure(ctx context.Context, imsi types.IMSI, serving types.NCGI, reestablished types.NCGI) {
+	if v, ok := d.lastHandovers.LoadAndDelete(imsi); ok {
+		if last := v.(lastHandover); last.target == serving && time.Since(last.time) < d.handoverConfig.GetMroWindow() {
+			if reestablished == last.source {
+				log.Infof("HO of UE %d from cell %d to cell %d was too early", imsi, last.source, serving)
+				d.hoStats.MroFailure(ctx, last.source, serving, stats.HoTooEarly)
+			} else {
+				log.Infof("HO of UE %d from cell %d to cell %d was to the wrong cell", imsi, last.source, serving)
+				d.hoStats.MroFailure(ctx, last.source, serving, stats.HoWrongCell)
+			}
+			return
+		}
+	}
+	log.Infof("HO of UE %d from cell %d to cell %d was too late", imsi, serving, reestablished)
+	d.hoStats.MroFailure(ctx, serving, reestablished, stats.HoTooLate)
+}
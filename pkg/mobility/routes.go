@@ -19,20 +19,41 @@ import (
 )
 
 const googleAPIKeyMinLen = 38
-const stepsPerDecimalDegree = 500
+
+// routeStep distance in meters between the points of generated routes
+const routeStep = 200.0
 
 const latMargin = 0.04 // ~ 4.4km at equator; ~3.1km at 45
 const lngMargin = 0.01 // ~ 4.4km
 
 var routeEndPointIndex = 0
 
-func (d *driver) GenerateRoutes(ctx context.Context, minSpeed uint32, maxSpeed uint32, speedStdDev uint32, routeEndPoints []model.RouteEndPoint, directRoute bool) {
+func (d *driver) GenerateRoutes(ctx context.Context, minSpeed uint32, maxSpeed uint32, speedStdDev uint32, routeEndPoints []model.RouteEndPoint, regions []model.Region, directRoute bool, mobility model.MobilityConfig) {
 	d.establishArea(ctx)
 	log.Infof("Generating routes in area min=%v; max=%v\n", d.min, d.max)
-	for _, ue := range d.ueStore.ListAllUEs(ctx) {
+	ues := d.ueStore.ListAllUEs(ctx)
+	targets := regionTargets(regions, len(ues))
+	region := 0
+	for _, ue := range ues {
 		_, err := d.routeStore.Get(ctx, ue.IMSI)
 		if err != nil {
-			err = d.generateRoute(ctx, ue.IMSI, uint32(rand.Intn(int(maxSpeed-minSpeed))), speedStdDev, routeEndPoints, directRoute)
+			for region < len(targets) && targets[region] == 0 {
+				region++
+			}
+			speedAvg, speedDev := uint32(rand.Intn(int(maxSpeed-minSpeed))), speedStdDev
+			if mobility.IsEnabled() {
+				// Route speeds are in meters per hour
+				class := mobility.SelectClass(rand.Float64())
+				speedAvg = uint32(class.Speed() * 1000)
+				speedDev = uint32(class.Speed() * 1000 * mobility.GetSpeedDeviation())
+				_ = d.ueStore.SetMobility(ctx, ue.IMSI, class, class.Speed())
+			}
+			if region < len(targets) {
+				targets[region]--
+				err = d.generateRegionRoute(ctx, ue.IMSI, regions[region], speedAvg, speedDev, directRoute)
+			} else {
+				err = d.generateRoute(ctx, ue.IMSI, speedAvg, speedDev, routeEndPoints, directRoute)
+			}
 			if err != nil {
 				log.Warnf("Unable to generate route for %d, %v", ue.IMSI, err)
 			}
@@ -90,7 +111,10 @@ func (d *driver) generateRoute(ctx context.Context, imsi types.IMSI, speedAvg ui
 	if err != nil {
 		return err
 	}
+	return d.addRoute(ctx, imsi, points, speedAvg, speedStdDev)
+}
 
+func (d *driver) addRoute(ctx context.Context, imsi types.IMSI, points []*model.Coordinate, speedAvg uint32, speedStdDev uint32) error {
 	route := &model.Route{
 		IMSI:        imsi,
 		Points:      points,
@@ -148,25 +172,15 @@ func googleRoute(startLoc *model.Coordinate, endLoc *model.Coordinate, apiKey st
 }
 
 func randomRoute(startLoc *model.Coordinate, endLoc *model.Coordinate, directRoute bool) ([]*model.Coordinate, error) {
-	routeWidth := endLoc.Lng - startLoc.Lng
-	routeHeight := endLoc.Lat - startLoc.Lat
-
-	directLength := math.Hypot(routeWidth, routeHeight)
-	// Try to have a step evey 1/stepsPerDecimalDegree of a decimal degree
-	points := make([]*model.Coordinate, int(math.Floor(directLength*stepsPerDecimalDegree)))
-
-	for i := range points {
-		randFactor := (rand.Float64() - 0.5) / stepsPerDecimalDegree
-		if i == 0 || directRoute {
-			randFactor = 0.0
-		}
-		deltaX := routeWidth*float64(i)/float64(len(points)) + randFactor
-		deltaY := routeHeight*float64(i)/float64(len(points)) + randFactor
-
-		points[i] = &model.Coordinate{
-			Lng: startLoc.Lng + deltaX,
-			Lat: startLoc.Lat + deltaY,
+	// Try to have a step every routeStep meters along the great circle, deviating randomly by up to half a step
+	steps := utils.Resample([]model.Coordinate{*startLoc, *endLoc}, routeStep)
+	points := make([]*model.Coordinate, 0, len(steps))
+	for i, step := range steps[:len(steps)-1] {
+		point := step
+		if i > 0 && !directRoute {
+			point = utils.TargetPoint(step, rand.Float64()*360, rand.Float64()*routeStep/2)
 		}
+		points = append(points, &point)
 	}
 	points = append(points, endLoc)
 
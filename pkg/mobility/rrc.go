@@ -8,7 +8,9 @@ import (
 	"context"
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/clock"
 	"github.com/onosproject/ran-simulator/pkg/model"
+	"math"
 	"math/rand"
 )
 
@@ -25,15 +27,17 @@ var UeCountPerCellDefault uint = 15
 type RrcCtrl struct {
 	rrcUpdateChan  chan model.UE
 	ueCountPerCell uint
+	config         model.RrcConfig
 }
 
 // NewRrcCtrl returns a new RRC Controller
-func NewRrcCtrl(ueCountPerCell uint) RrcCtrl {
+func NewRrcCtrl(ueCountPerCell uint, config model.RrcConfig) RrcCtrl {
 	if ueCountPerCell == 0 {
 		ueCountPerCell = UeCountPerCellDefault
 	}
 	return RrcCtrl{
 		ueCountPerCell: ueCountPerCell,
+		config:         config,
 	}
 }
 
@@ -47,7 +51,7 @@ func (d *driver) totalUeCount(ctx context.Context, ncgi types.NCGI) uint {
 		log.Error(err)
 		return 0
 	}
-	return uint(cell.RrcConnectedCount + cell.RrcIdleCount)
+	return uint(cell.RrcConnectedCount + cell.RrcInactiveCount + cell.RrcIdleCount)
 }
 
 func (d *driver) connectedUeCount(ctx context.Context, ncgi types.NCGI) uint {
@@ -59,9 +63,26 @@ func (d *driver) connectedUeCount(ctx context.Context, ncgi types.NCGI) uint {
 	return uint(cell.RrcConnectedCount)
 }
 
+// targetUeCount returns the number of RRC connected UEs targeted in the cell, scaled by the load factor of the
+// traffic profile of the cell
+func (d *driver) targetUeCount(ctx context.Context, ncgi types.NCGI) uint {
+	return uint(math.Round(float64(d.rrcCtrl.ueCountPerCell) * d.loadFactor(ctx, ncgi)))
+}
+
 func (d *driver) updateRrc(ctx context.Context, imsi types.IMSI) {
 	var rrcStateChanged bool
 
+	if d.rrcCtrl.config.IsTimed() {
+		ue, err := d.ueStore.Get(ctx, imsi)
+		if err != nil {
+			log.Error(err)
+			return
+		}
+		rrcStateChanged, err = d.updateTimedRrc(ctx, ue)
+		d.notifyRrc(ue, rrcStateChanged, err)
+		return
+	}
+
 	if rand.Float64() < RrcStateChangeProbability {
 		ue, err := d.ueStore.Get(ctx, imsi)
 		if err != nil {
@@ -70,31 +91,74 @@ func (d *driver) updateRrc(ctx context.Context, imsi types.IMSI) {
 		}
 
 		if ue.RrcState == mho.Rrcstatus_RRCSTATUS_IDLE {
-			rrcStateChanged, err = d.rrcConnected(ctx, imsi, RrcStateChangeVariance)
+			rrcStateChanged, err = d.rrcConnected(ctx, ue, RrcStateChangeVariance)
 		} else if ue.RrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED {
-			rrcStateChanged, err = d.rrcIdle(ctx, imsi, RrcStateChangeVariance)
+			rrcStateChanged, err = d.rrcIdle(ctx, ue, RrcStateChangeVariance)
 		} else { // Ignore mho.Rrcstatus_RRCSTATUS_INACTIVE
 			return
 		}
+		d.notifyRrc(ue, rrcStateChanged, err)
+	}
+}
 
-		if err == nil && d.hoLogic != "local" && rrcStateChanged && d.rrcCtrl.rrcUpdateChan != nil {
-			// TODO - check subscription for RRC state changes
-			d.rrcCtrl.rrcUpdateChan <- *ue
+// updateTimedRrc moves the UE through the RRC state machine; connected UEs are released to inactive and then
+// to idle by the configured timers, while idle and inactive UEs are paged upon the arrival of downlink traffic, as
+// often as the load factor of the traffic profile of their cell allows
+func (d *driver) updateTimedRrc(ctx context.Context, ue *model.UE) (bool, error) {
+	config := d.rrcCtrl.config
+	elapsed := clock.Now().Sub(ue.RrcStateTime)
+	pagingProbability := config.PagingProbability * d.loadFactor(ctx, ue.Cell.NCGI)
+
+	switch ue.RrcState {
+	case mho.Rrcstatus_RRCSTATUS_CONNECTED:
+		if config.InactivityTimer > 0 {
+			if elapsed >= config.InactivityTimer {
+				return d.setRrcState(ctx, ue, mho.Rrcstatus_RRCSTATUS_INACTIVE)
+			}
+		} else if config.IdleTimer > 0 && elapsed >= config.IdleTimer {
+			return d.setRrcState(ctx, ue, mho.Rrcstatus_RRCSTATUS_IDLE)
+		}
+	case mho.Rrcstatus_RRCSTATUS_INACTIVE:
+		if config.IdleTimer > 0 && elapsed >= config.IdleTimer {
+			return d.setRrcState(ctx, ue, mho.Rrcstatus_RRCSTATUS_IDLE)
+		}
+		if rand.Float64() < pagingProbability {
+			return d.page(ctx, ue)
+		}
+	case mho.Rrcstatus_RRCSTATUS_IDLE:
+		if rand.Float64() < pagingProbability {
+			return d.page(ctx, ue)
 		}
 	}
+	return false, nil
 }
 
-func (d *driver) rrcIdle(ctx context.Context, imsi types.IMSI, p float64) (bool, error) {
-	var rrcStateChanged = false
+func (d *driver) notifyRrc(ue *model.UE, rrcStateChanged bool, err error) {
+	if err == nil && d.hoLogic != "local" && rrcStateChanged && d.rrcCtrl.rrcUpdateChan != nil {
+		// TODO - check subscription for RRC state changes
+		d.rrcCtrl.rrcUpdateChan <- *ue
+	}
+}
 
-	ue, err := d.ueStore.Get(ctx, imsi)
+func (d *driver) setRrcState(ctx context.Context, ue *model.UE, rrcState mho.Rrcstatus) (bool, error) {
+	log.Infof("RRC state change imsi:%d from %v to %v", ue.IMSI, ue.RrcState, rrcState)
+	err := d.ueStore.UpdateRrcState(ctx, ue.IMSI, rrcState)
 	if err != nil {
 		return false, err
 	}
+	if rrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED {
+		d.ueStore.UpdateMaxUEsPerCell(ctx)
+	}
+	return true, nil
+}
+
+func (d *driver) rrcIdle(ctx context.Context, ue *model.UE, p float64) (bool, error) {
+	var rrcStateChanged = false
+	target := d.targetUeCount(ctx, ue.Cell.NCGI)
 
-	if d.totalUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
+	if d.totalUeCount(ctx, ue.Cell.NCGI) > target {
 		r := rand.Float64()
-		if d.connectedUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
+		if d.connectedUeCount(ctx, ue.Cell.NCGI) > target {
 			if r < p {
 				rrcStateChanged = true
 			}
@@ -108,27 +172,24 @@ func (d *driver) rrcIdle(ctx context.Context, imsi types.IMSI, p float64) (bool,
 	}
 
 	if rrcStateChanged {
-		log.Infof("RRC state change imsi:%d from CONNECTED to IDLE", imsi)
-		ue.RrcState = mho.Rrcstatus_RRCSTATUS_IDLE
-		d.cellStore.IncrementRrcIdleCount(ctx, ue.Cell.NCGI)
-		d.cellStore.DecrementRrcConnectedCount(ctx, ue.Cell.NCGI)
+		return d.setRrcState(ctx, ue, mho.Rrcstatus_RRCSTATUS_IDLE)
 	}
-
-	return rrcStateChanged, err
-
+	return false, nil
 }
 
-func (d *driver) rrcConnected(ctx context.Context, imsi types.IMSI, p float64) (bool, error) {
+// rrcConnected admits the idle or inactive UE, depending on the load of its serving cell, once it has accessed the
+// cell on the random access channel
+func (d *driver) rrcConnected(ctx context.Context, ue *model.UE, p float64) (bool, error) {
 	var rrcStateChanged = false
 
-	ue, err := d.ueStore.Get(ctx, imsi)
-	if err != nil {
-		return false, err
+	if !d.accessCell(ctx, ue) {
+		return false, nil
 	}
+	target := d.targetUeCount(ctx, ue.Cell.NCGI)
 
-	if d.totalUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
+	if d.totalUeCount(ctx, ue.Cell.NCGI) > target {
 		r := rand.Float64()
-		if d.connectedUeCount(ctx, ue.Cell.NCGI) > d.rrcCtrl.ueCountPerCell {
+		if d.connectedUeCount(ctx, ue.Cell.NCGI) > target {
 			if r < 1-p {
 				rrcStateChanged = true
 			}
@@ -141,13 +202,9 @@ func (d *driver) rrcConnected(ctx context.Context, imsi types.IMSI, p float64) (
 		rrcStateChanged = true
 	}
 
+	d.rrcStats.ConnectionAttempt(ctx, ue.Cell.NCGI, rrcStateChanged)
 	if rrcStateChanged {
-		log.Infof("RRC state change imsi:%d from IDLE to CONNECTED", imsi)
-		ue.RrcState = mho.Rrcstatus_RRCSTATUS_CONNECTED
-		d.cellStore.IncrementRrcConnectedCount(ctx, ue.Cell.NCGI)
-		d.cellStore.DecrementRrcIdleCount(ctx, ue.Cell.NCGI)
+		return d.setRrcState(ctx, ue, mho.Rrcstatus_RRCSTATUS_CONNECTED)
 	}
-
-	return rrcStateChanged, err
-
+	return false, nil
 }
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+// carrierKey a UE on the carrier of a cell
+type carrierKey struct {
+	imsi types.IMSI
+	ncgi types.NCGI
+}
+
+// scheduler distributes the downlink PRBs of the cells across the UEs using their carriers, according to the
+// scheduling policy
+type scheduler struct {
+	ueStore    ues.Store
+	config     model.SchedulerConfig
+	throughput model.ThroughputConfig
+	mu         sync.Mutex
+	averages   map[carrierKey]float64 // average spectral efficiency of each UE on each carrier, for the pf policy
+}
+
+func newScheduler(ueStore ues.Store, config model.SchedulerConfig, throughput model.ThroughputConfig) *scheduler {
+	return &scheduler{
+		ueStore:    ueStore,
+		config:     config,
+		throughput: throughput,
+		averages:   make(map[carrierKey]float64),
+	}
+}
+
+// share returns the fraction of the PRBs of the cell allocated to the UE receiving the carrier of the cell with the
+// given SINR; the other users of the carrier are scheduled according to their latest SINR
+func (s *scheduler) share(ctx context.Context, imsi types.IMSI, ncgi types.NCGI, sinr float64) float64 {
+	users := s.ueStore.ActiveCarriersPerCell(ctx, uint64(ncgi))
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	metric := s.metric(carrierKey{imsi: imsi, ncgi: ncgi}, sinr, true)
+	others := make([]float64, 0, len(users))
+	for other, carrier := range users {
+		if other != imsi {
+			others = append(others, s.metric(carrierKey{imsi: other, ncgi: ncgi}, carrier.Sinr, false))
+		}
+	}
+	return s.config.Share(metric, others)
+}
+
+// metric returns the scheduling metric of the UE on the carrier, updating the average spectral efficiency of the UE
+// if requested
+func (s *scheduler) metric(key carrierKey, sinr float64, update bool) float64 {
+	switch s.config.GetPolicy() {
+	case model.SchedulerMaxCQI:
+		return float64(model.CQI(sinr))
+	case model.SchedulerProportionalFair:
+		efficiency := s.throughput.SpectralEfficiency(sinr)
+		average, ok := s.averages[key]
+		if !ok {
+			average = efficiency
+		}
+		if update {
+			averaging := s.config.GetAveraging()
+			average = (1-averaging)*average + averaging*efficiency
+			s.averages[key] = average
+		}
+		if average == 0 {
+			return 0
+		}
+		return efficiency / average
+	}
+	return 0
+}
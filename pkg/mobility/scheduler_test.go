@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+const schedulerNCGI = types.NCGI(123001)
+
+// newSchedulerUEs creates two connected UEs using the same carrier, received with a SINR of 20 dB and 0 dB
+func newSchedulerUEs(t *testing.T) (ues.Store, types.IMSI, types.IMSI) {
+	ctx := context.Background()
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	us := ues.NewUERegistry(2, cells.NewCellRegistry(m.Cells, nodes.NewNodeRegistry(m.Nodes)), "connected")
+	ueList := us.ListAllUEs(ctx)
+	for i, sinr := range []float64{20, 0} {
+		err := us.UpdateCarriers(ctx, ueList[i].IMSI, []*model.Carrier{{NCGI: schedulerNCGI, Active: true, Sinr: sinr}})
+		assert.NoError(t, err)
+	}
+	return us, ueList[0].IMSI, ueList[1].IMSI
+}
+
+func TestScheduler(t *testing.T) {
+	ctx := context.Background()
+	us, strong, weak := newSchedulerUEs(t)
+
+	rr := newScheduler(us, model.SchedulerConfig{}, model.ThroughputConfig{})
+	assert.Equal(t, 0.5, rr.share(ctx, strong, schedulerNCGI, 20))
+	assert.Equal(t, 0.5, rr.share(ctx, weak, schedulerNCGI, 0))
+	// A UE which does not use the carrier yet is counted among its users
+	assert.InDelta(t, 1.0/3, rr.share(ctx, 1, schedulerNCGI, 10), 1e-9)
+
+	maxCQI := newScheduler(us, model.SchedulerConfig{Policy: model.SchedulerMaxCQI}, model.ThroughputConfig{})
+	assert.Equal(t, 1.0, maxCQI.share(ctx, strong, schedulerNCGI, 20))
+	assert.Equal(t, 0.0, maxCQI.share(ctx, weak, schedulerNCGI, 0))
+
+	// The UEs get equal shares as long as their channel is steady, and more when it improves
+	pf := newScheduler(us, model.SchedulerConfig{Policy: model.SchedulerProportionalFair}, model.ThroughputConfig{})
+	assert.InDelta(t, 0.5, pf.share(ctx, strong, schedulerNCGI, 20), 1e-9)
+	assert.InDelta(t, 0.5, pf.share(ctx, weak, schedulerNCGI, 0), 1e-9)
+	assert.True(t, pf.share(ctx, weak, schedulerNCGI, 10) > 0.5)
+}
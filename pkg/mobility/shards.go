@@ -0,0 +1,139 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// remoteHysteresis margin in dB by which a UE must receive a cell of another simulator instance better than its
+// serving cell to be handed over to it, so that UEs on the border between instances do not bounce between them
+const remoteHysteresis = 3.0
+
+// TransferFunc hands the UE, along with its route if any, over to the target cell owned by the given shard; the UE
+// is removed from this instance once the function returns without error
+type TransferFunc func(ctx context.Context, shard uint, ue *model.UE, route *model.Route, target types.NCGI) error
+
+func (d *driver) SetRemoteCells(cells []model.RemoteCell, transfer TransferFunc) {
+	d.remoteCells = cells
+	d.transfer = transfer
+}
+
+// bestRemoteCell returns the cell of another shard received best by the UE, if it is received better than the
+// serving cell by the hysteresis margin; cells beyond the cell search radius are not measured
+func (d *driver) bestRemoteCell(ue *model.UE) (*model.RemoteCell, float64) {
+	var best *model.RemoteCell
+	strength := ue.Cell.Strength + remoteHysteresis
+	for i := range d.remoteCells {
+		cell := &d.remoteCells[i]
+		if d.cellSearchRadius > 0 && utils.Distance(ue.Location, cell.Sector.Center) > d.cellSearchRadius {
+			continue
+		}
+		if rsrp := StrengthAtLocation(ue.Location, cell.Cell); rsrp > strength {
+			best, strength = cell, rsrp
+		}
+	}
+	return best, strength
+}
+
+// transferUE hands the UE over to the cell of another shard it receives best, if any, and removes the UE from this
+// instance once the other instance admitted it; returns true if the UE was handed over. The UE must be locked.
+func (d *driver) transferUE(ctx context.Context, imsi types.IMSI) bool {
+	if d.transfer == nil || len(d.remoteCells) == 0 {
+		return false
+	}
+	ue, err := d.ueStore.Get(ctx, imsi)
+	if err != nil || ue.Cell == nil || ue.Detached {
+		return false
+	}
+	if _, ok := d.handovers.Load(imsi); ok {
+		return false
+	}
+	target, strength := d.bestRemoteCell(ue)
+	if target == nil {
+		return false
+	}
+
+	route, err := d.routeStore.Get(ctx, imsi)
+	if err != nil {
+		route = nil
+	}
+	source := ue.Cell.NCGI
+	connected := ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED
+	start := time.Now()
+	err = d.transfer(ctx, target.Shard, ue, route, target.NCGI)
+	if connected {
+		// The transfer of the UE context to the other instance stands for both the preparation and the execution
+		latency := time.Since(start)
+		d.hoStats.Prepared(ctx, source, latency)
+		d.hoStats.Executed(ctx, source, err == nil, latency, 0)
+	}
+	if err != nil {
+		log.Warnf("Unable to hand UE %d over to cell %d of shard %d: %v", imsi, target.NCGI, target.Shard, err)
+		return false
+	}
+	d.releaseUE(ctx, ue)
+	log.Infof("UE %d handed over from cell %d to cell %d of shard %d received at %.2f dBm", imsi, source, target.NCGI, target.Shard, strength)
+	return true
+}
+
+// releaseUE removes the UE handed over to another shard along with its route, and uncounts it on its serving cell
+func (d *driver) releaseUE(ctx context.Context, ue *model.UE) {
+	_, _ = d.routeStore.Delete(ctx, ue.IMSI)
+	if _, err := d.ueStore.Delete(ctx, ue.IMSI); err != nil {
+		log.Warn(err)
+		return
+	}
+	switch ue.RrcState {
+	case e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED:
+		d.cellStore.DecrementRrcConnectedCount(ctx, ue.Cell.NCGI)
+	case e2sm_mho.Rrcstatus_RRCSTATUS_INACTIVE:
+		d.cellStore.DecrementRrcInactiveCount(ctx, ue.Cell.NCGI)
+	case e2sm_mho.Rrcstatus_RRCSTATUS_IDLE:
+		d.cellStore.DecrementRrcIdleCount(ctx, ue.Cell.NCGI)
+	}
+	d.lastHandovers.Delete(ue.IMSI)
+	d.ueStore.UpdateMaxUEsPerCell(ctx)
+}
+
+func (d *driver) AdmitUE(ctx context.Context, ue *model.UE, route *model.Route) error {
+	if ue.Cell == nil {
+		return errors.NewInvalid("UE %d has no serving cell", ue.IMSI)
+	}
+	if _, err := d.cellStore.Get(ctx, ue.Cell.NCGI); err != nil {
+		return err
+	}
+
+	d.ueLockMu.Lock()
+	if d.ueLock == nil {
+		d.ueLock = make(map[types.IMSI]*sync.Mutex)
+	}
+	if _, ok := d.ueLock[ue.IMSI]; !ok {
+		d.ueLock[ue.IMSI] = &sync.Mutex{}
+	}
+	d.ueLockMu.Unlock()
+
+	// The route is added last so that the UE is not driven before it is added
+	if err := d.ueStore.Add(ctx, ue); err != nil {
+		return err
+	}
+	if route != nil {
+		if err := d.routeStore.Add(ctx, route); err != nil {
+			d.releaseUE(ctx, ue)
+			return err
+		}
+	}
+	d.ueStore.UpdateMaxUEsPerCell(ctx)
+	log.Infof("UE %d admitted on cell %d from another shard", ue.IMSI, ue.Cell.NCGI)
+	return nil
+}
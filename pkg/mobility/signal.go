@@ -15,6 +15,9 @@ const powerFactor = 0.001
 
 // StrengthAtLocation returns the signal strength at location relative to the specified cell.
 func StrengthAtLocation(coord model.Coordinate, cell model.Cell) float64 {
+	if cell.Sector.Antenna == model.Antenna3GPP {
+		return cell.TxPowerDB + antennaGain(coord, cell) - freeSpacePathLoss(slantDistance(coord, cell), cell.CarrierFrequency())
+	}
 	distAtt := distanceAttenuation(coord, cell)
 	angleAtt := angleAttenuation(coord, cell)
 	pathLoss := getPathLoss(coord, cell)
@@ -28,9 +31,7 @@ func StrengthAtLocation(coord model.Coordinate, cell model.Cell) float64 {
 // https://en.wikipedia.org/wiki/Sector_antenna
 // https://en.wikipedia.org/wiki/Steradian
 func distanceAttenuation(coord model.Coordinate, cell model.Cell) float64 {
-	latDist := coord.Lat - cell.Sector.Center.Lat
-	realLngDist := (coord.Lng - cell.Sector.Center.Lng) / utils.AspectRatio(cell.Sector.Center.Lat)
-	r := math.Hypot(latDist, realLngDist)
+	r := utils.Distance(cell.Sector.Center, coord) / utils.MetersPerDegree
 	gain := 120.0 / float64(cell.Sector.Arc)
 	return 10 * math.Log10(gain*math.Sqrt(powerFactor/r))
 }
@@ -41,9 +42,9 @@ func distanceAttenuation(coord model.Coordinate, cell model.Cell) float64 {
 // https://en.wikipedia.org/wiki/Radiation_pattern
 // https://en.wikipedia.org/wiki/Sector_antenna
 func angleAttenuation(coord model.Coordinate, cell model.Cell) float64 {
-	azRads := utils.AzimuthToRads(float64(cell.Sector.Azimuth))
-	pointRads := math.Atan2(coord.Lat-cell.Sector.Center.Lat, coord.Lng-cell.Sector.Center.Lng)
-	angularOffset := math.Abs(azRads - pointRads)
+	// Offset from the azimuth of the sector, between 0 and π
+	bearing := utils.InitialBearing(cell.Sector.Center, coord)
+	angularOffset := math.Abs(math.Mod(bearing-float64(cell.Sector.Azimuth)+540, 360)-180) * math.Pi / 180
 	angleScaling := float64(cell.Sector.Arc) / 120.0 // Compensate for narrower beams
 
 	// We just use a simple linear formula 0 => no loss
@@ -58,19 +59,16 @@ func getPathLoss(coord model.Coordinate, cell model.Cell) float64 {
 }
 
 func getFreeSpacePathLoss(coord model.Coordinate, cell model.Cell) float64 {
-	distanceKM := getEuclianDistanceFromGPS(coord, cell)
-	// Assuming we're using CBRS frequency 3.6 GHz
-	// 92.45 is the constant value of 20 * log10(4*pi / c) in Kilometer scale
-	pathLoss := 20*math.Log10(distanceKM) + 20*math.Log10(3.6) + 92.45
+	return freeSpacePathLoss(distanceKM(coord, cell), cell.CarrierFrequency())
+}
+
+func freeSpacePathLoss(distanceKM float64, frequencyMHz float64) float64 {
+	// 92.45 is the constant value of 20 * log10(4*pi / c) in Kilometer scale, with the frequency in GHz
+	pathLoss := 20*math.Log10(distanceKM) + 20*math.Log10(frequencyMHz/1000) + 92.45
 	return pathLoss
 }
 
-func getEuclianDistanceFromGPS(coord model.Coordinate, cell model.Cell) float64 {
-	earthRadius := 6378.137
-	dLat := coord.Lat*math.Pi/180 - cell.Sector.Center.Lat*math.Pi/180
-	dLng := coord.Lng*math.Pi/180 - cell.Sector.Center.Lng*math.Pi/180
-	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(coord.Lat*math.Pi/180)*math.Cos(cell.Sector.Center.Lat*math.Pi/180)*
-		math.Sin(dLng/2)*math.Sin(dLng/2)
-	c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-	return earthRadius * c
+// distanceKM returns the great-circle distance in km between the cell site and the location
+func distanceKM(coord model.Coordinate, cell model.Cell) float64 {
+	return utils.Distance(cell.Sector.Center, coord) / 1000
 }
@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// regionCurve traffic curve of the region a cell lies in, along with the location of the cell it was resolved for
+type regionCurve struct {
+	center model.Coordinate
+	curve  model.TrafficCurve
+}
+
+// trafficProfile modulates the load of the cells over the simulated time of the day, following the curve of the
+// cell, of the region it lies in or of the model
+type trafficProfile struct {
+	config   model.TrafficProfileConfig
+	regions  []model.Region
+	location *time.Location
+	mu       sync.Mutex
+	curves   map[types.NCGI]regionCurve
+}
+
+func newTrafficProfile(config model.TrafficProfileConfig, regions []model.Region) *trafficProfile {
+	return &trafficProfile{
+		config:   config,
+		regions:  regions,
+		location: config.GetLocation(),
+		curves:   make(map[types.NCGI]regionCurve),
+	}
+}
+
+// factor returns the load factor of the cell at the given time
+func (p *trafficProfile) factor(cell *model.Cell, at time.Time) float64 {
+	return p.curve(cell).Factor(model.SinceMidnight(at.In(p.location)))
+}
+
+func (p *trafficProfile) curve(cell *model.Cell) model.TrafficCurve {
+	if len(cell.TrafficProfile) > 0 {
+		return cell.TrafficProfile
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if resolved, ok := p.curves[cell.NCGI]; ok && resolved.center == cell.Sector.Center {
+		return resolved.curve
+	}
+	curve := p.config.Curve
+	for _, region := range p.regions {
+		if len(region.TrafficProfile) > 0 && utils.InPolygon(cell.Sector.Center, region.Polygon) {
+			curve = region.TrafficProfile
+			break
+		}
+	}
+	p.curves[cell.NCGI] = regionCurve{center: cell.Sector.Center, curve: curve}
+	return curve
+}
+
+// loadFactor returns the current load factor of the cell; 1 if the cell is unknown
+func (d *driver) loadFactor(ctx context.Context, ncgi types.NCGI) float64 {
+	cell, err := d.cellStore.Get(ctx, ncgi)
+	if err != nil {
+		return 1
+	}
+	return d.traffic.factor(cell, clock.Now())
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficProfile(t *testing.T) {
+	region := model.Region{
+		Name:           "downtown",
+		Polygon:        []model.Coordinate{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 1}, {Lat: 1, Lng: 0}},
+		TrafficProfile: model.TrafficCurve{0.5},
+	}
+	profile := newTrafficProfile(model.TrafficProfileConfig{Curve: model.TrafficCurve{0.2, 1}}, []model.Region{region})
+	noon := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	outside := &model.Cell{NCGI: 1, Sector: model.Sector{Center: model.Coordinate{Lat: 2, Lng: 2}}}
+	assert.InDelta(t, 1, profile.factor(outside, noon), 1e-9)
+	assert.InDelta(t, 0.6, profile.factor(outside, noon.Add(-6*time.Hour)), 1e-9)
+
+	inside := &model.Cell{NCGI: 2, Sector: model.Sector{Center: model.Coordinate{Lat: 0.5, Lng: 0.5}}}
+	assert.InDelta(t, 0.5, profile.factor(inside, noon), 1e-9)
+
+	// The curve of the cell overrides the curve of its region, which is resolved again once the cell moves
+	inside.TrafficProfile = model.TrafficCurve{0.1}
+	assert.InDelta(t, 0.1, profile.factor(inside, noon), 1e-9)
+	inside.TrafficProfile = nil
+	inside.Sector.Center = outside.Sector.Center
+	assert.InDelta(t, 1, profile.factor(inside, noon), 1e-9)
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mobility
+
+import (
+	"context"
+
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// updateUplink updates the uplink transmission power, power headroom, SINR and throughput of the UE; the path loss to
+// the serving cell is the difference between the transmission power of the cell and the RSRP measured by the UE. Only
+// the connected UEs, whose traffic is not suspended by a handover, have uplink throughput.
+func (d *driver) updateUplink(ctx context.Context, ue *model.UE, m *measurements) {
+	serving := m.cell(ue.Cell.NCGI)
+	rsrp, ok := m.strengths[ue.Cell.NCGI]
+	if serving == nil || serving.Failed || !ok {
+		return
+	}
+
+	uplink := d.uplink.Uplink(serving.TxPowerDB-rsrp, d.interference.GetNoise(serving))
+	if ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED && !ue.Detached {
+		uplink.Throughput = model.Throughput(float64(d.uplink.GetPRBs()), d.throughput.UplinkSpectralEfficiency(uplink.Sinr))
+	}
+	if err := d.ueStore.UpdateUplink(ctx, ue.IMSI, uplink); err != nil {
+		log.Warn(err)
+	}
+}
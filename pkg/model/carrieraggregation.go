@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// PRBBandwidth bandwidth of a PRB in kHz; 12 subcarriers at 30 kHz subcarrier spacing
+const PRBBandwidth = 360.0
+
+const (
+	defaultMaxCarriers    = 2
+	defaultCellBandwidth  = 20   // MHz
+	transmissionBandwidth = 0.95 // share of the channel bandwidth not used by guard bands
+)
+
+// maxPRBs maximum transmission bandwidth in PRBs of the NR channel bandwidths in MHz, at 30 kHz subcarrier spacing;
+// 3GPP TS 38.101-1 table 5.3.2-1
+var maxPRBs = map[uint32]uint32{
+	5: 11, 10: 24, 15: 38, 20: 51, 25: 65, 30: 78, 40: 106, 50: 133, 60: 162, 70: 189, 80: 217, 90: 245, 100: 273,
+}
+
+// CarrierAggregationConfig automatic configuration of the secondary cells of the UEs; secondary cells can also be
+// activated and deactivated via RC control, regardless of this configuration
+type CarrierAggregationConfig struct {
+	Enabled           bool    `mapstructure:"enabled" yaml:"enabled"`                     // configure secondary cells automatically
+	MaxCarriers       uint    `mapstructure:"maxCarriers" yaml:"maxCarriers"`             // maximum number of aggregated carriers, including the primary cell; 2 by default
+	AdditionThreshold float64 `mapstructure:"additionThreshold" yaml:"additionThreshold"` // RSRP above which a secondary cell is configured; -100 dBm by default
+	ReleaseThreshold  float64 `mapstructure:"releaseThreshold" yaml:"releaseThreshold"`   // RSRP below which a secondary cell is released; -110 dBm by default
+}
+
+// GetMaxCarriers returns the maximum number of carriers aggregated by a UE, including its primary cell
+func (c CarrierAggregationConfig) GetMaxCarriers() int {
+	if c.MaxCarriers > 0 {
+		return int(c.MaxCarriers)
+	}
+	return defaultMaxCarriers
+}
+
+// GetAdditionThreshold returns the RSRP of a cell of the serving node above which it is configured as secondary cell
+func (c CarrierAggregationConfig) GetAdditionThreshold() float64 {
+	if c.AdditionThreshold != 0 {
+		return c.AdditionThreshold
+	}
+	return defaultAdditionThreshold
+}
+
+// GetReleaseThreshold returns the RSRP of a secondary cell below which it is released
+func (c CarrierAggregationConfig) GetReleaseThreshold() float64 {
+	if c.ReleaseThreshold != 0 {
+		return c.ReleaseThreshold
+	}
+	return defaultReleaseThreshold
+}
+
+// Carrier a carrier aggregated by a UE, along with the PRBs allocated to the UE and the resulting throughput
+type Carrier struct {
+	NCGI       types.NCGI
+	Primary    bool    // the carrier of the primary, serving, cell
+	Active     bool    // the carrier carries traffic; secondary cells can be configured but deactivated
+	Strength   float64 // RSRP in dBm
+	Sinr       float64 // SINR in dB
+	PRBs       float64 // downlink PRBs allocated to the UE
+	Throughput float64 // downlink throughput in kbps
+}
+
+// GetBandwidth returns the carrier bandwidth of the cell in MHz
+func (c Cell) GetBandwidth() uint32 {
+	if c.Bandwidth == 0 {
+		return defaultCellBandwidth
+	}
+	return c.Bandwidth
+}
+
+// PRBs returns the number of downlink PRBs of the cell carrier, at 30 kHz subcarrier spacing
+func (c Cell) PRBs() uint32 {
+	bandwidth := c.GetBandwidth()
+	if prbs, ok := maxPRBs[bandwidth]; ok {
+		return prbs
+	}
+	return uint32(float64(bandwidth) * 1000 * transmissionBandwidth / PRBBandwidth)
+}
+
+// Throughput returns the downlink throughput of the UE in kbps, over all of its active carriers
+func (ue *UE) Throughput() float64 {
+	throughput := 0.0
+	for _, carrier := range ue.Carriers {
+		if carrier.Active {
+			throughput += carrier.Throughput
+		}
+	}
+	return throughput
+}
+
+// SecondaryCarriers returns the configured secondary cells of the UE
+func (ue *UE) SecondaryCarriers() []*Carrier {
+	carriers := make([]*Carrier, 0, len(ue.Carriers))
+	for _, carrier := range ue.Carriers {
+		if !carrier.Primary {
+			carriers = append(carriers, carrier)
+		}
+	}
+	return carriers
+}
@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCellPRBs(t *testing.T) {
+	assert.Equal(t, uint32(51), Cell{}.PRBs())
+	assert.Equal(t, uint32(273), Cell{Bandwidth: 100}.PRBs())
+	assert.Equal(t, uint32(31), Cell{Bandwidth: 12}.PRBs())
+}
+
+func TestUEThroughput(t *testing.T) {
+	ue := &UE{Carriers: []*Carrier{
+		{NCGI: 1, Primary: true, Active: true, Throughput: 1000},
+		{NCGI: 2, Active: true, Throughput: 500},
+		{NCGI: 3, Throughput: 250},
+	}}
+	assert.Equal(t, 1500.0, ue.Throughput())
+	assert.Len(t, ue.SecondaryCarriers(), 2)
+	assert.Equal(t, 2, CarrierAggregationConfig{}.GetMaxCarriers())
+}
@@ -0,0 +1,188 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"math"
+	"sort"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Controller selection policies
+const (
+	// RoundRobinPolicy assigns the nodes to the controllers in turn
+	RoundRobinPolicy = "roundrobin"
+	// NearestPolicy assigns each node to the controller nearest to its cells
+	NearestPolicy = "nearest"
+	// WeightedPolicy assigns the nodes to the controllers in proportion to their weights
+	WeightedPolicy = "weighted"
+)
+
+// Transports of the E2 connections
+const (
+	// TransportSCTP SCTP, as specified by E2AP
+	TransportSCTP = "sctp"
+	// TransportTCP TCP, which the connections secured with TLS use
+	TransportTCP = "tcp"
+)
+
+// validateControllers checks the transport of each controller is known and agrees with its TLS settings: the E2
+// connections secured with TLS run over TCP rather than SCTP, which the controller must state explicitly
+func (m *Model) validateControllers() error {
+	for name, controller := range m.Controllers {
+		switch controller.Transport {
+		case "", TransportSCTP:
+			if controller.TLS.Enabled {
+				return errors.NewInvalid("controller %s enables TLS, which is only supported over TCP; set its transport to %s", name, TransportTCP)
+			}
+		case TransportTCP:
+			if !controller.TLS.Enabled {
+				return errors.NewInvalid("controller %s uses the %s transport, which is only supported with TLS", name, TransportTCP)
+			}
+		default:
+			return errors.NewInvalid("controller %s has unknown transport %s; %s and %s are supported", name, controller.Transport, TransportSCTP, TransportTCP)
+		}
+	}
+	return nil
+}
+
+// ControllerSelectionConfig policy selecting the controller of each node that lists no controllers
+type ControllerSelectionConfig struct {
+	Policy string `mapstructure:"policy" yaml:"policy"` // roundrobin (default), nearest or weighted
+}
+
+// ControllerSelector selects a controller for each node according to the controller selection policy of the model;
+// nodes are assigned deterministically, so the instances sharing a sharded model agree on the assignment
+type ControllerSelector struct {
+	policy      string
+	names       []string
+	controllers []Controller
+	next        int
+	current     []int
+}
+
+// NewControllerSelector creates a controller selector for the controllers of the given model
+func NewControllerSelector(m *Model) (*ControllerSelector, error) {
+	policy := m.ControllerSelection.Policy
+	switch policy {
+	case "":
+		policy = RoundRobinPolicy
+	case RoundRobinPolicy, NearestPolicy, WeightedPolicy:
+	default:
+		return nil, errors.NewInvalid("unknown controller selection policy %s", policy)
+	}
+
+	names := make([]string, 0, len(m.Controllers))
+	for name := range m.Controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	controllers := make([]Controller, 0, len(names))
+	for _, name := range names {
+		controllers = append(controllers, m.Controllers[name])
+	}
+	return &ControllerSelector{
+		policy:      policy,
+		names:       names,
+		controllers: controllers,
+		current:     make([]int, len(names)),
+	}, nil
+}
+
+// Select returns the name of the controller selected for a node with the given cells, or an empty string if the
+// model has no controllers
+func (s *ControllerSelector) Select(cells []Cell) string {
+	if len(s.names) == 0 {
+		return ""
+	}
+	switch s.policy {
+	case NearestPolicy:
+		// Nodes without cells have no location and are assigned in turn
+		if len(cells) > 0 {
+			return s.names[s.nearest(cells)]
+		}
+	case WeightedPolicy:
+		return s.names[s.weighted()]
+	}
+	i := s.next
+	s.next = (s.next + 1) % len(s.names)
+	return s.names[i]
+}
+
+// nearest returns the index of the controller nearest to the center of the given cells
+func (s *ControllerSelector) nearest(cells []Cell) int {
+	var center Coordinate
+	for _, cell := range cells {
+		center.Lat += cell.Sector.Center.Lat / float64(len(cells))
+		center.Lng += cell.Sector.Center.Lng / float64(len(cells))
+	}
+	best, bestDistance := 0, math.Inf(1)
+	for i, controller := range s.controllers {
+		// Equirectangular approximation; only the order of the distances matters
+		x := (controller.Location.Lng - center.Lng) * math.Cos((controller.Location.Lat+center.Lat)*math.Pi/360)
+		y := controller.Location.Lat - center.Lat
+		if distance := x*x + y*y; distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	return best
+}
+
+// weighted returns the index of the next controller using smooth weighted round-robin, which interleaves the
+// controllers rather than assigning consecutive nodes to the same controller; a zero weight counts as one
+func (s *ControllerSelector) weighted() int {
+	best, total := 0, 0
+	for i, controller := range s.controllers {
+		weight := int(controller.Weight)
+		if weight == 0 {
+			weight = 1
+		}
+		s.current[i] += weight
+		total += weight
+		if s.current[i] > s.current[best] {
+			best = i
+		}
+	}
+	s.current[best] -= total
+	return best
+}
+
+// AssignControllers assigns each node that lists no controllers to the controller selected by the controller
+// selection policy; nodes are visited in the order of their names
+func (m *Model) AssignControllers() error {
+	selector, err := NewControllerSelector(m)
+	if err != nil {
+		return err
+	}
+	cells := make(map[types.NCGI]Cell, len(m.Cells))
+	for _, cell := range m.Cells {
+		cells[cell.NCGI] = cell
+	}
+
+	names := make([]string, 0, len(m.Nodes))
+	for name := range m.Nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		node := m.Nodes[name]
+		if len(node.Controllers) > 0 {
+			continue
+		}
+		nodeCells := make([]Cell, 0, len(node.Cells))
+		for _, ncgi := range node.Cells {
+			if cell, ok := cells[ncgi]; ok {
+				nodeCells = append(nodeCells, cell)
+			}
+		}
+		if controller := selector.Select(nodeCells); controller != "" {
+			node.Controllers = []string{controller}
+			m.Nodes[name] = node
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func controllerModel(policy string) *Model {
+	m := &Model{
+		Nodes: make(map[string]Node),
+		Cells: make(map[string]Cell),
+		Controllers: map[string]Controller{
+			"e2t-1": {Address: "onos-e2t-1", Location: Coordinate{Lat: 52.0, Lng: 13.0}, Weight: 3},
+			"e2t-2": {Address: "onos-e2t-2", Location: Coordinate{Lat: 48.0, Lng: 11.0}, Weight: 1},
+		},
+		ControllerSelection: ControllerSelectionConfig{Policy: policy},
+	}
+	for i := 1; i <= 8; i++ {
+		ncgi := types.NCGI(i)
+		lat := 52.0
+		if i > 4 {
+			lat = 48.0
+		}
+		m.Nodes[string(rune('a'+i))] = Node{GnbID: types.GnbID(i), Cells: []types.NCGI{ncgi}}
+		m.Cells[string(rune('a'+i))] = Cell{NCGI: ncgi, Sector: Sector{Center: Coordinate{Lat: lat, Lng: 12.0}}}
+	}
+	return m
+}
+
+func assignedControllers(t *testing.T, m *Model) map[types.GnbID]string {
+	assert.NoError(t, m.AssignControllers())
+	assigned := make(map[types.GnbID]string)
+	for _, node := range m.Nodes {
+		assert.Len(t, node.Controllers, 1)
+		assigned[node.GnbID] = node.Controllers[0]
+	}
+	return assigned
+}
+
+func countControllers(assigned map[types.GnbID]string) map[string]int {
+	counts := make(map[string]int)
+	for _, controller := range assigned {
+		counts[controller]++
+	}
+	return counts
+}
+
+func TestRoundRobinControllers(t *testing.T) {
+	assigned := assignedControllers(t, controllerModel(""))
+	assert.Equal(t, map[string]int{"e2t-1": 4, "e2t-2": 4}, countControllers(assigned))
+	assert.Equal(t, "e2t-1", assigned[1])
+	assert.Equal(t, "e2t-2", assigned[2])
+	assert.Equal(t, assigned, assignedControllers(t, controllerModel(RoundRobinPolicy)))
+}
+
+func TestNearestControllers(t *testing.T) {
+	assigned := assignedControllers(t, controllerModel(NearestPolicy))
+	for gnbID, controller := range assigned {
+		if gnbID <= 4 {
+			assert.Equal(t, "e2t-1", controller)
+		} else {
+			assert.Equal(t, "e2t-2", controller)
+		}
+	}
+}
+
+func TestWeightedControllers(t *testing.T) {
+	assigned := assignedControllers(t, controllerModel(WeightedPolicy))
+	assert.Equal(t, map[string]int{"e2t-1": 6, "e2t-2": 2}, countControllers(assigned))
+}
+
+func TestAssignedControllersKept(t *testing.T) {
+	m := controllerModel(WeightedPolicy)
+	node := m.Nodes["b"]
+	node.Controllers = []string{"e2t-2", "e2t-1"}
+	m.Nodes["b"] = node
+	assert.NoError(t, m.AssignControllers())
+	assert.Equal(t, []string{"e2t-2", "e2t-1"}, m.Nodes["b"].Controllers)
+}
+
+func TestUnknownControllerPolicy(t *testing.T) {
+	m := controllerModel("random")
+	assert.Error(t, m.AssignControllers())
+}
+
+func TestValidateControllers(t *testing.T) {
+	m := controllerModel(RoundRobinPolicy)
+	assert.NoError(t, m.validateControllers())
+
+	// TLS is only supported over TCP, which the controller must state
+	m.Controllers["e2t-1"] = Controller{Address: "onos-e2t-1", TLS: TLSConfig{Enabled: true}}
+	assert.Error(t, m.validateControllers())
+	m.Controllers["e2t-1"] = Controller{Address: "onos-e2t-1", Transport: TransportSCTP, TLS: TLSConfig{Enabled: true}}
+	assert.Error(t, m.validateControllers())
+	m.Controllers["e2t-1"] = Controller{Address: "onos-e2t-1", Transport: TransportTCP, TLS: TLSConfig{Enabled: true}}
+	assert.NoError(t, m.validateControllers())
+
+	m.Controllers["e2t-1"] = Controller{Address: "onos-e2t-1", Transport: TransportTCP}
+	assert.Error(t, m.validateControllers())
+	m.Controllers["e2t-1"] = Controller{Address: "onos-e2t-1", Transport: "quic"}
+	assert.Error(t, m.validateControllers())
+}
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"math"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// EarthRadius radius of the Earth in meters, which all the geographic computations of the simulator are based on
+const EarthRadius = 6378100
+
+// MetersPerDegree length in meters of a degree of latitude, or of longitude at the equator
+const MetersPerDegree = EarthRadius * math.Pi / 180
+
+// FromRelative returns the location x meters east and y meters north of the given center. The offsets are projected
+// onto the plane tangent to the center, which is accurate for topologies spanning a few tens of kilometers.
+func FromRelative(center Coordinate, x float64, y float64) Coordinate {
+	return Coordinate{
+		Lat: center.Lat + y/MetersPerDegree,
+		Lng: center.Lng + x/(MetersPerDegree*math.Cos(center.Lat*math.Pi/180)),
+	}
+}
+
+// ToRelative returns the offsets in meters east and north of the given center of the location; it is the inverse of
+// FromRelative
+func ToRelative(center Coordinate, c Coordinate) (x float64, y float64) {
+	return (c.Lng - center.Lng) * MetersPerDegree * math.Cos(center.Lat*math.Pi/180), (c.Lat - center.Lat) * MetersPerDegree
+}
+
+// resolveCoordinates converts the locations of a model given in the relative coordinate system to latitude and
+// longitude around the map center, after which the model is in the geographic coordinate system
+func (m *Model) resolveCoordinates() error {
+	switch m.MapLayout.GetCoordinateSystem() {
+	case Geographic:
+		return m.forEachCoordinate(func(c *Coordinate) error {
+			if c.X != 0 || c.Y != 0 {
+				return errors.NewInvalid("location x:%g y:%g is relative but the coordinate system is %s", c.X, c.Y, Geographic)
+			}
+			return nil
+		})
+	case Relative:
+		center := m.MapLayout.Center
+		if err := m.forEachCoordinate(func(c *Coordinate) error {
+			*c = FromRelative(center, c.X, c.Y)
+			return nil
+		}); err != nil {
+			return err
+		}
+		m.MapLayout.CoordinateSystem = Geographic
+		return nil
+	default:
+		return errors.NewInvalid("unknown coordinate system %s; %s and %s are supported", m.MapLayout.CoordinateSystem, Geographic, Relative)
+	}
+}
+
+// forEachCoordinate calls the given function with each location of the model but the map center
+func (m *Model) forEachCoordinate(f func(c *Coordinate) error) error {
+	for name, cell := range m.Cells {
+		if err := f(&cell.Sector.Center); err != nil {
+			return err
+		}
+		m.Cells[name] = cell
+	}
+	for name, controller := range m.Controllers {
+		if err := f(&controller.Location); err != nil {
+			return err
+		}
+		m.Controllers[name] = controller
+	}
+	for i := range m.RouteEndPoints {
+		if err := f(&m.RouteEndPoints[i].Start); err != nil {
+			return err
+		}
+		if err := f(&m.RouteEndPoints[i].End); err != nil {
+			return err
+		}
+	}
+	for i := range m.Regions {
+		for j := range m.Regions[i].Polygon {
+			if err := f(&m.Regions[i].Polygon[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeCoordinates(t *testing.T) {
+	center := Coordinate{Lat: 52.52, Lng: 13.405}
+	c := FromRelative(center, 1000, -500)
+	assert.True(t, c.Lng > center.Lng)
+	assert.True(t, c.Lat < center.Lat)
+	x, y := ToRelative(center, c)
+	assert.InDelta(t, 1000, x, 1e-6)
+	assert.InDelta(t, -500, y, 1e-6)
+	// A kilometer north is about 0.009 degrees of latitude anywhere
+	assert.InDelta(t, 0.009, FromRelative(center, 0, 1000).Lat-center.Lat, 0.0001)
+	assert.Equal(t, center, FromRelative(center, 0, 0))
+}
+
+func TestResolveCoordinates(t *testing.T) {
+	m := &Model{
+		MapLayout: MapLayout{Center: Coordinate{Lat: 40.7, Lng: -74}, CoordinateSystem: Relative},
+		Cells: map[string]Cell{
+			"cell1": {Sector: Sector{Center: Coordinate{X: 250, Y: 250}}},
+		},
+		RouteEndPoints: []RouteEndPoint{{Start: Coordinate{X: -1000}, End: Coordinate{Y: 1000}}},
+		Regions:        []Region{{Polygon: []Coordinate{{X: 1}, {Y: 1}, {X: 1, Y: 1}}}},
+	}
+	assert.NoError(t, m.resolveCoordinates())
+	assert.Equal(t, Geographic, m.MapLayout.GetCoordinateSystem())
+	cell := m.Cells["cell1"].Sector.Center
+	assert.Equal(t, 0.0, cell.X)
+	x, y := ToRelative(m.MapLayout.Center, cell)
+	assert.InDelta(t, 250, x, 1e-6)
+	assert.InDelta(t, 250, y, 1e-6)
+	assert.True(t, m.RouteEndPoints[0].Start.Lng < -74)
+	assert.Equal(t, 40.7, m.RouteEndPoints[0].Start.Lat)
+	assert.True(t, m.Regions[0].Polygon[2].Lat > 40.7 && m.Regions[0].Polygon[2].Lng > -74)
+
+	// Resolving again leaves the geographic coordinates as they are
+	assert.NoError(t, m.resolveCoordinates())
+	assert.Equal(t, cell, m.Cells["cell1"].Sector.Center)
+
+	m = &Model{Cells: map[string]Cell{"cell1": {Sector: Sector{Center: Coordinate{X: 250}}}}}
+	assert.Error(t, m.resolveCoordinates())
+	m = &Model{MapLayout: MapLayout{CoordinateSystem: "utm"}}
+	assert.Error(t, m.resolveCoordinates())
+}
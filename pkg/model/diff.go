@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+// Diff describes the changes between two models
+type Diff struct {
+	AddedNodes     []Node
+	RemovedNodes   []Node
+	UpdatedNodes   []Node
+	AddedCells     []Cell
+	RemovedCells   []Cell
+	UpdatedCells   []Cell
+	UECountChanged bool
+	UECount        uint
+	// ChangedControllers names of the controllers added, removed or changed; the nodes connecting to them are updated
+	ChangedControllers []string
+}
+
+// IsEmpty returns true if there are no changes
+func (d *Diff) IsEmpty() bool {
+	return len(d.AddedNodes) == 0 && len(d.RemovedNodes) == 0 && len(d.UpdatedNodes) == 0 &&
+		len(d.AddedCells) == 0 && len(d.RemovedCells) == 0 && len(d.UpdatedCells) == 0 && !d.UECountChanged &&
+		len(d.ChangedControllers) == 0
+}
+
+// Compare computes the changes required to turn the old model into the new one; nodes are
+// matched by their GnbID and cells by their NCGI regardless of their names in the model, and controllers by their
+// name. The nodes connecting to a changed controller are updated, so that their agents connect to it anew.
+func Compare(oldModel *Model, newModel *Model) *Diff {
+	diff := &Diff{}
+
+	changedControllers := make(map[string]bool)
+	for name, controller := range newModel.Controllers {
+		if oldController, ok := oldModel.Controllers[name]; !ok || !reflect.DeepEqual(oldController, controller) {
+			changedControllers[name] = true
+		}
+	}
+	for name := range oldModel.Controllers {
+		if _, ok := newModel.Controllers[name]; !ok {
+			changedControllers[name] = true
+		}
+	}
+	for name := range changedControllers {
+		diff.ChangedControllers = append(diff.ChangedControllers, name)
+	}
+	sort.Strings(diff.ChangedControllers)
+
+	oldNodes := make(map[types.GnbID]Node, len(oldModel.Nodes))
+	for _, node := range oldModel.Nodes {
+		oldNodes[node.GnbID] = node
+	}
+	newNodes := make(map[types.GnbID]Node, len(newModel.Nodes))
+	for _, node := range newModel.Nodes {
+		newNodes[node.GnbID] = node
+		if oldNode, ok := oldNodes[node.GnbID]; !ok {
+			diff.AddedNodes = append(diff.AddedNodes, node)
+		} else if !nodesEqual(oldNode, node) || connectsTo(node, changedControllers) {
+			diff.UpdatedNodes = append(diff.UpdatedNodes, node)
+		}
+	}
+	for gnbID, node := range oldNodes {
+		if _, ok := newNodes[gnbID]; !ok {
+			diff.RemovedNodes = append(diff.RemovedNodes, node)
+		}
+	}
+
+	oldCells := make(map[types.NCGI]Cell, len(oldModel.Cells))
+	for _, cell := range oldModel.Cells {
+		oldCells[cell.NCGI] = cell
+	}
+	newCells := make(map[types.NCGI]Cell, len(newModel.Cells))
+	for _, cell := range newModel.Cells {
+		newCells[cell.NCGI] = cell
+		if oldCell, ok := oldCells[cell.NCGI]; !ok {
+			diff.AddedCells = append(diff.AddedCells, cell)
+		} else if !cellsEqual(oldCell, cell) {
+			diff.UpdatedCells = append(diff.UpdatedCells, cell)
+		}
+	}
+	for ncgi, cell := range oldCells {
+		if _, ok := newCells[ncgi]; !ok {
+			diff.RemovedCells = append(diff.RemovedCells, cell)
+		}
+	}
+
+	if oldModel.UECount != newModel.UECount {
+		diff.UECountChanged = true
+		diff.UECount = newModel.UECount
+	}
+	return diff
+}
+
+// connectsTo returns true if the given node connects to any of the given controllers
+func connectsTo(node Node, controllers map[string]bool) bool {
+	for _, controller := range node.Controllers {
+		if controllers[controller] {
+			return true
+		}
+	}
+	return false
+}
+
+// nodesEqual compares the configured attributes of two nodes ignoring their runtime status
+func nodesEqual(a Node, b Node) bool {
+	a.Status, b.Status = "", ""
+	return reflect.DeepEqual(a, b)
+}
+
+// cellsEqual compares the configured attributes of two cells ignoring their runtime counters
+func cellsEqual(a Cell, b Cell) bool {
+	a.RrcIdleCount, a.RrcConnectedCount, a.RrcInactiveCount = 0, 0, 0
+	b.RrcIdleCount, b.RrcConnectedCount, b.RrcInactiveCount = 0, 0, 0
+	return reflect.DeepEqual(a, b)
+}
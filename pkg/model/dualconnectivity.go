@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+)
+
+const (
+	defaultAdditionThreshold = -100.0
+	defaultReleaseThreshold  = -110.0
+	defaultSplitRatio        = 0.5
+)
+
+// DualConnectivityConfig automatic addition and release of secondary nodes; secondary nodes can also be added and
+// released via RC control, regardless of this configuration
+type DualConnectivityConfig struct {
+	Enabled           bool    `mapstructure:"enabled" yaml:"enabled"`                     // add secondary nodes automatically
+	AdditionThreshold float64 `mapstructure:"additionThreshold" yaml:"additionThreshold"` // RSRP above which a secondary node is added; -100 dBm by default
+	ReleaseThreshold  float64 `mapstructure:"releaseThreshold" yaml:"releaseThreshold"`   // RSRP below which a secondary node is released; -110 dBm by default
+	SplitRatio        float64 `mapstructure:"splitRatio" yaml:"splitRatio"`               // share of the traffic carried by the secondary node; 0.5 by default
+}
+
+// GetAdditionThreshold returns the RSRP of the best cell of another node above which it is added as secondary node
+func (c DualConnectivityConfig) GetAdditionThreshold() float64 {
+	if c.AdditionThreshold != 0 {
+		return c.AdditionThreshold
+	}
+	return defaultAdditionThreshold
+}
+
+// GetReleaseThreshold returns the RSRP of the secondary cell below which the secondary node is released
+func (c DualConnectivityConfig) GetReleaseThreshold() float64 {
+	if c.ReleaseThreshold != 0 {
+		return c.ReleaseThreshold
+	}
+	return defaultReleaseThreshold
+}
+
+// GetSplitRatio returns the share of the traffic of the split bearer carried by the secondary node
+func (c DualConnectivityConfig) GetSplitRatio() float64 {
+	if c.SplitRatio > 0 && c.SplitRatio <= 1 {
+		return c.SplitRatio
+	}
+	return defaultSplitRatio
+}
+
+// IsDualConnected returns true if the UE has a secondary cell group
+func (ue *UE) IsDualConnected() bool {
+	return ue.SecondaryCell != nil
+}
+
+// TrafficShare returns the share of the traffic of the UE carried by the cell; the traffic of dual connected UEs is
+// split between their serving cell and their secondary cell
+func (ue *UE) TrafficShare(ncgi types.NCGI) float64 {
+	switch {
+	case ue.SecondaryCell != nil && ue.SecondaryCell.NCGI == ncgi:
+		return ue.SplitRatio
+	case ue.Cell != nil && ue.Cell.NCGI == ncgi && ue.SecondaryCell != nil:
+		return 1 - ue.SplitRatio
+	case ue.Cell != nil && ue.Cell.NCGI == ncgi:
+		return 1
+	}
+	return 0
+}
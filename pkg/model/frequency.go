@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+// DefaultCarrierFrequency frequency in MHz of the cells with neither a frequency nor an NR-ARFCN; the CBRS band
+const DefaultCarrierFrequency = 3600.0
+
+// nrRaster global frequency raster of 3GPP TS 38.104 section 5.4.2.1
+type nrRaster struct {
+	maxArfcn uint32  // last NR-ARFCN of the range
+	step     float64 // granularity in kHz
+	offset   float64 // frequency offset in MHz
+	offsetN  uint32  // NR-ARFCN offset
+}
+
+var nrRasters = []nrRaster{
+	{maxArfcn: 599999, step: 5, offset: 0, offsetN: 0},
+	{maxArfcn: 2016666, step: 15, offset: 3000, offsetN: 600000},
+	{maxArfcn: 3279165, step: 60, offset: 24250.08, offsetN: 2016667},
+}
+
+// NrArfcnToFrequency returns the frequency in MHz of the NR-ARFCN; zero if the NR-ARFCN is out of range
+func NrArfcnToFrequency(arfcn uint32) float64 {
+	for _, raster := range nrRasters {
+		if arfcn <= raster.maxArfcn {
+			return raster.offset + raster.step*float64(arfcn-raster.offsetN)/1000
+		}
+	}
+	return 0
+}
+
+// CarrierFrequency returns the carrier frequency of the cell in MHz
+func (c Cell) CarrierFrequency() float64 {
+	if c.Frequency > 0 {
+		return c.Frequency
+	}
+	if frequency := NrArfcnToFrequency(c.Arfcn); c.Arfcn > 0 && frequency > 0 {
+		return frequency
+	}
+	return DefaultCarrierFrequency
+}
@@ -0,0 +1,24 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNrArfcnToFrequency(t *testing.T) {
+	assert.InDelta(t, 1842.5, NrArfcnToFrequency(368500), 1e-6)
+	assert.InDelta(t, 3600.0, NrArfcnToFrequency(640000), 1e-6)
+	assert.InDelta(t, 28000.08, NrArfcnToFrequency(2079167), 1e-6)
+	assert.Equal(t, 0.0, NrArfcnToFrequency(3279166))
+}
+
+func TestCarrierFrequency(t *testing.T) {
+	assert.Equal(t, DefaultCarrierFrequency, Cell{}.CarrierFrequency())
+	assert.InDelta(t, 3500.0, Cell{Arfcn: 633333}.CarrierFrequency(), 0.01)
+	assert.Equal(t, 2600.0, Cell{Arfcn: 633333, Frequency: 2600}.CarrierFrequency())
+}
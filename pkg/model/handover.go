@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "time"
+
+const (
+	defaultPingPongWindow = 5 * time.Second
+	defaultMroWindow      = 5 * time.Second
+)
+
+// HandoverConfig latencies and failure modes of the handovers; handovers are instantaneous and always succeed if
+// none is set
+type HandoverConfig struct {
+	PreparationTime     time.Duration `mapstructure:"preparationTime" yaml:"preparationTime"`         // from the decision to the handover command; the UE stays on the source cell
+	ExecutionTime       time.Duration `mapstructure:"executionTime" yaml:"executionTime"`             // from the handover command to the completion on the target cell
+	InterruptionTime    time.Duration `mapstructure:"interruptionTime" yaml:"interruptionTime"`       // end of the execution during which the UE is detached from both cells
+	FailureProbability  float64       `mapstructure:"failureProbability" yaml:"failureProbability"`   // chance of a handover execution failing
+	DropProbability     float64       `mapstructure:"dropProbability" yaml:"dropProbability"`         // chance of a failed handover dropping the connection instead of falling back to the source cell
+	PingPongProbability float64       `mapstructure:"pingPongProbability" yaml:"pingPongProbability"` // chance of a UE handing back to its source cell within the ping-pong window
+	PingPongWindow      time.Duration `mapstructure:"pingPongWindow" yaml:"pingPongWindow"`           // handing back to the source cell within the window is a ping-pong; 5s by default
+	RlfThreshold        float64       `mapstructure:"rlfThreshold" yaml:"rlfThreshold"`               // RSRP in dBm of the serving cell below which the radio link of a connected UE fails; none if 0
+	MroWindow           time.Duration `mapstructure:"mroWindow" yaml:"mroWindow"`                     // a radio link failure within the window after a handover is blamed on the handover; 5s by default
+}
+
+// IsEnabled returns true if the handovers take time
+func (c HandoverConfig) IsEnabled() bool {
+	return c.PreparationTime > 0 || c.ExecutionTime > 0 || c.InterruptionTime > 0
+}
+
+// GetExecutionTime returns the execution time of the handovers, which includes the interruption time
+func (c HandoverConfig) GetExecutionTime() time.Duration {
+	if c.ExecutionTime < c.InterruptionTime {
+		return c.InterruptionTime
+	}
+	return c.ExecutionTime
+}
+
+// GetPingPongWindow returns the time after a handover within which handing back to the source cell is a ping-pong
+func (c HandoverConfig) GetPingPongWindow() time.Duration {
+	if c.PingPongWindow > 0 {
+		return c.PingPongWindow
+	}
+	return defaultPingPongWindow
+}
+
+// GetMroWindow returns the time after a handover within which a radio link failure makes the handover too early or to
+// the wrong cell
+func (c HandoverConfig) GetMroWindow() time.Duration {
+	if c.MroWindow > 0 {
+		return c.MroWindow
+	}
+	return defaultMroWindow
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandoverConfig(t *testing.T) {
+	assert.False(t, HandoverConfig{}.IsEnabled())
+
+	c := HandoverConfig{PreparationTime: 20 * time.Millisecond}
+	assert.True(t, c.IsEnabled())
+	assert.Equal(t, time.Duration(0), c.GetExecutionTime())
+
+	c = HandoverConfig{ExecutionTime: 50 * time.Millisecond, InterruptionTime: 30 * time.Millisecond}
+	assert.Equal(t, 50*time.Millisecond, c.GetExecutionTime())
+
+	c = HandoverConfig{InterruptionTime: 30 * time.Millisecond}
+	assert.True(t, c.IsEnabled())
+	assert.Equal(t, 30*time.Millisecond, c.GetExecutionTime())
+}
+
+func TestPingPongWindow(t *testing.T) {
+	assert.Equal(t, 5*time.Second, HandoverConfig{}.GetPingPongWindow())
+	assert.Equal(t, 2*time.Second, HandoverConfig{PingPongWindow: 2 * time.Second}.GetPingPongWindow())
+}
+
+func TestMroWindow(t *testing.T) {
+	assert.Equal(t, 5*time.Second, HandoverConfig{}.GetMroWindow())
+	assert.Equal(t, 2*time.Second, HandoverConfig{MroWindow: 2 * time.Second}.GetMroWindow())
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "math"
+
+// defaultNoiseFloor thermal noise in dBm received on a 30 kHz subcarrier, with a 7 dB UE noise figure
+const defaultNoiseFloor = -122.2
+
+// cqiThresholds minimum SINR in dB of each CQI of the 64QAM CQI table, 3GPP TS 38.214 table 5.2.2.1-2, at a block
+// error rate of 10%; the SINR below the first threshold is out of range, CQI 0
+var cqiThresholds = [...]float64{-6.7, -4.7, -2.3, 0.2, 2.4, 4.3, 5.9, 8.1, 10.3, 11.7, 14.1, 16.3, 18.7, 21.0, 22.7}
+
+// InterferenceConfig parameters of the SINR of the UEs
+type InterferenceConfig struct {
+	NoiseFloor float64 `mapstructure:"noiseFloor" yaml:"noiseFloor"` // noise power in dBm per resource element; -122.2 dBm by default
+}
+
+// GetNoise returns the noise in dBm per resource element experienced by the UEs served by the cell: the noise floor
+// raised by the external interference of the cell
+func (c InterferenceConfig) GetNoise(cell *Cell) float64 {
+	noise := defaultNoiseFloor
+	if c.NoiseFloor != 0 {
+		noise = c.NoiseFloor
+	}
+	if cell != nil {
+		noise += cell.Interference
+	}
+	return noise
+}
+
+// SINR returns the SINR in dB of a cell given its RSRP, the RSRP of the co-channel cells interfering with it and the
+// noise, all per resource element
+func SINR(rsrp float64, interferers []float64, noise float64) float64 {
+	power := math.Pow(10, noise/10)
+	for _, p := range interferers {
+		power += math.Pow(10, p/10)
+	}
+	return rsrp - 10*math.Log10(power)
+}
+
+// CQI returns the wideband channel quality indicator, from 0 to 15, reported by a UE at the given SINR in dB
+func CQI(sinr float64) uint32 {
+	cqi := uint32(0)
+	for _, threshold := range cqiThresholds {
+		if sinr < threshold {
+			break
+		}
+		cqi++
+	}
+	return cqi
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterferenceConfig(t *testing.T) {
+	assert.Equal(t, -122.2, InterferenceConfig{}.GetNoise(nil))
+	c := InterferenceConfig{NoiseFloor: -120}
+	assert.Equal(t, -120.0, c.GetNoise(&Cell{}))
+	assert.Equal(t, -110.0, c.GetNoise(&Cell{Interference: 10}))
+}
+
+func TestSINR(t *testing.T) {
+	// Without interferers, the SINR is the signal over the noise
+	assert.InDelta(t, 30.0, SINR(-90, nil, -120), 1e-9)
+	// An interferer as strong as the signal caps the SINR at 0 dB
+	assert.InDelta(t, 0.0, SINR(-90, []float64{-90}, -200), 1e-6)
+	assert.True(t, SINR(-90, []float64{-100, -105}, -120) < SINR(-90, []float64{-100}, -120))
+}
+
+func TestCQI(t *testing.T) {
+	assert.Equal(t, uint32(0), CQI(-10))
+	assert.Equal(t, uint32(1), CQI(-6.7))
+	assert.Equal(t, uint32(4), CQI(1))
+	assert.Equal(t, uint32(15), CQI(30))
+	for sinr := -10.0; sinr < 30; sinr++ {
+		assert.True(t, CQI(sinr+1) >= CQI(sinr))
+	}
+}
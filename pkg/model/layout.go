@@ -6,10 +6,28 @@ package model
 
 // MapLayout represents information required for geo-map visualizations
 type MapLayout struct {
-	Center         Coordinate `mapstructure:"center"`
-	Zoom           float32    `mapstructure:"zoom"`
-	LocationsScale float32    `mapstructure:"locationsScale"`
-	FadeMap        bool       `mapstructure:"fade"`
-	ShowRoutes     bool       `mapstructure:"showRoutes"`
-	ShowPower      bool       `mapstructure:"showPower"`
+	Center           Coordinate `mapstructure:"center"`
+	Zoom             float32    `mapstructure:"zoom"`
+	LocationsScale   float32    `mapstructure:"locationsScale"`
+	FadeMap          bool       `mapstructure:"fade"`
+	ShowRoutes       bool       `mapstructure:"showRoutes"`
+	ShowPower        bool       `mapstructure:"showPower"`
+	CoordinateSystem string     `mapstructure:"coordinateSystem"` // system the locations of the model are given in; geographic by default
+}
+
+// Coordinate systems of the locations of a model
+const (
+	// Geographic locations are given by their latitude and longitude
+	Geographic = "geographic"
+	// Relative locations are given by their offsets in meters east and north of the map center, so that the same
+	// topology can be laid out around any map center
+	Relative = "relative"
+)
+
+// GetCoordinateSystem returns the coordinate system of the locations of the model
+func (l MapLayout) GetCoordinateSystem() string {
+	if l.CoordinateSystem == "" {
+		return Geographic
+	}
+	return l.CoordinateSystem
 }
@@ -8,6 +8,7 @@ import (
 	"bytes"
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 	"github.com/spf13/viper"
 )
 
@@ -42,9 +43,12 @@ func LoadConfig(model *Model, configname string) error {
 	}
 
 	err = viper.Unmarshal(model)
+	if err != nil {
+		return err
+	}
 
-	// Convert the MCC-MNC format into numeric PLMNID
-	model.PlmnID = types.PlmnIDFromString(model.Plmn)
+	// Convert the MCC-MNC format into numeric PLMNIDs
+	err = initPlmnIDs(model)
 
 	// initialize neighbor's Ocn value - for mlb/handover
 	for k, v := range model.Cells {
@@ -54,8 +58,42 @@ func LoadConfig(model *Model, configname string) error {
 		}
 		model.Cells[k] = v
 	}
+	if err != nil {
+		return err
+	}
+	if err := model.Mobility.Validate(); err != nil {
+		return err
+	}
+	if err := model.Scheduler.Validate(); err != nil {
+		return err
+	}
+	if err := model.UEPlacement.Validate(); err != nil {
+		return err
+	}
+	if err := model.validateTrafficProfiles(); err != nil {
+		return err
+	}
+	if err := model.resolveCoordinates(); err != nil {
+		return err
+	}
+	if err := model.resolveNames(); err != nil {
+		return err
+	}
+	if err := model.validateTrackingAreas(); err != nil {
+		return err
+	}
+	if err := model.validateControllers(); err != nil {
+		return err
+	}
+	if err := model.validateReportStyles(); err != nil {
+		return err
+	}
+	if err := validateRATs(model); err != nil {
+		return err
+	}
 
-	return err
+	// Assign the nodes that list no controllers using the controller selection policy
+	return model.AssignControllers()
 }
 
 // Load the model configuration.
@@ -74,9 +112,12 @@ func LoadConfigFromBytes(model *Model, modelData []byte) error {
 	}
 
 	err = viper.Unmarshal(model)
+	if err != nil {
+		return err
+	}
 
-	// Convert the MCC-MNC format into numeric PLMNID
-	model.PlmnID = types.PlmnIDFromString(model.Plmn)
+	// Convert the MCC-MNC format into numeric PLMNIDs
+	err = initPlmnIDs(model)
 
 	// initialize neighbor's Ocn value - for mlb/handover
 	for k, v := range model.Cells {
@@ -87,5 +128,64 @@ func LoadConfigFromBytes(model *Model, modelData []byte) error {
 		model.Cells[k] = v
 	}
 	log.Infof("routeEndPoints: %v", model.RouteEndPoints)
-	return err
+	if err != nil {
+		return err
+	}
+	if err := model.Mobility.Validate(); err != nil {
+		return err
+	}
+	if err := model.Scheduler.Validate(); err != nil {
+		return err
+	}
+	if err := model.UEPlacement.Validate(); err != nil {
+		return err
+	}
+	if err := model.validateTrafficProfiles(); err != nil {
+		return err
+	}
+	if err := model.resolveCoordinates(); err != nil {
+		return err
+	}
+	if err := model.resolveNames(); err != nil {
+		return err
+	}
+	if err := model.validateTrackingAreas(); err != nil {
+		return err
+	}
+	if err := model.validateControllers(); err != nil {
+		return err
+	}
+	if err := model.validateReportStyles(); err != nil {
+		return err
+	}
+
+	// Assign the nodes that list no controllers using the controller selection policy
+	return model.AssignControllers()
+}
+
+// initPlmnIDs derives the numeric primary and served PLMN IDs from their MCC-MNC form
+func initPlmnIDs(model *Model) error {
+	if model.Plmn != "" {
+		plmnID, err := plmn.Parse(model.Plmn)
+		if err != nil {
+			return err
+		}
+		model.PlmnID = plmnID
+	}
+	model.PlmnIDs = []types.PlmnID{model.PlmnID}
+	for _, additionalPlmn := range model.AdditionalPlmns {
+		plmnID, err := plmn.Parse(additionalPlmn)
+		if err != nil {
+			return err
+		}
+		if !model.IsServedPlmn(plmnID) {
+			model.PlmnIDs = append(model.PlmnIDs, plmnID)
+		}
+	}
+	for _, node := range model.Nodes {
+		if _, err := model.GetNodePlmnID(node); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Measurement report trigger quantities
+const (
+	TriggerQuantityRSRP = "rsrp"
+	TriggerQuantityRSRQ = "rsrq"
+)
+
+const (
+	// resourceElementsPerRB number of subcarriers of a resource block over which the RSSI is measured
+	resourceElementsPerRB = 12
+	minRSRQ               = -19.5
+	maxRSRQ               = -3.0
+)
+
+// MeasReportConfig measurement reporting configuration of a UE, set via RC control; the parameters which are not set
+// fall back to the reporting period of the MHO subscriptions and to the measurement parameters of the cells
+type MeasReportConfig struct {
+	ReportInterval  int32  // interval of the periodic measurement reports in ms
+	Hysteresis      *int32 // hysteresis of event A3, in the unit of the cell measurement parameters
+	TriggerQuantity string // quantity evaluated by event A3; "rsrp" or "rsrq"
+}
+
+// GetTriggerQuantity returns the quantity evaluated by event A3; RSRP by default
+func (c MeasReportConfig) GetTriggerQuantity() string {
+	if c.TriggerQuantity != "" {
+		return c.TriggerQuantity
+	}
+	return TriggerQuantityRSRP
+}
+
+// GetHysteresis returns the hysteresis of event A3 of the UE, or the given hysteresis of the cell if not set
+func (c MeasReportConfig) GetHysteresis(cellHysteresis int32) int32 {
+	if c.Hysteresis != nil {
+		return *c.Hysteresis
+	}
+	return cellHysteresis
+}
+
+// Set sets the named parameter of the configuration from its string value; "interval", "hysteresis" and "trigger"
+// are supported
+func (c *MeasReportConfig) Set(name string, value string) error {
+	switch name {
+	case "interval":
+		interval, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || interval < 0 {
+			return errors.NewInvalid("invalid report interval %s", value)
+		}
+		c.ReportInterval = int32(interval)
+	case "hysteresis":
+		hysteresis, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || hysteresis < 0 {
+			return errors.NewInvalid("invalid hysteresis %s", value)
+		}
+		h := int32(hysteresis)
+		c.Hysteresis = &h
+	case "trigger":
+		if value != TriggerQuantityRSRP && value != TriggerQuantityRSRQ {
+			return errors.NewInvalid("unsupported trigger quantity %s", value)
+		}
+		c.TriggerQuantity = value
+	default:
+		return errors.NewInvalid("unknown measurement report parameter %s", name)
+	}
+	return nil
+}
+
+// RSRQ returns the RSRQ in dB of a cell given its RSRP and the RSRP of all the cells measured by the UE, including
+// that one; the RSSI is approximated by the power received from these cells over the resource elements of a resource
+// block
+func RSRQ(rsrp float64, rsrps []float64) float64 {
+	rssi := 0.0
+	for _, p := range rsrps {
+		rssi += resourceElementsPerRB * math.Pow(10, p/10)
+	}
+	if rssi == 0 {
+		return minRSRQ
+	}
+	return math.Max(minRSRQ, math.Min(maxRSRQ, rsrp-10*math.Log10(rssi)))
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasReportConfig(t *testing.T) {
+	config := MeasReportConfig{}
+	assert.Equal(t, TriggerQuantityRSRP, config.GetTriggerQuantity())
+	assert.Equal(t, int32(2), config.GetHysteresis(2))
+
+	assert.NoError(t, config.Set("interval", "480"))
+	assert.NoError(t, config.Set("hysteresis", "0"))
+	assert.NoError(t, config.Set("trigger", "rsrq"))
+	assert.Equal(t, int32(480), config.ReportInterval)
+	assert.Equal(t, int32(0), config.GetHysteresis(2))
+	assert.Equal(t, TriggerQuantityRSRQ, config.GetTriggerQuantity())
+
+	assert.Error(t, config.Set("interval", "-1"))
+	assert.Error(t, config.Set("hysteresis", "high"))
+	assert.Error(t, config.Set("trigger", "sinr"))
+	assert.Error(t, config.Set("offset", "1"))
+}
+
+func TestRSRQ(t *testing.T) {
+	assert.InDelta(t, -10.79, RSRQ(-90, []float64{-90}), 0.01)
+	assert.InDelta(t, -13.80, RSRQ(-90, []float64{-90, -90}), 0.01)
+	assert.Equal(t, -19.5, RSRQ(-120, []float64{-120, -80}))
+	assert.Equal(t, -19.5, RSRQ(-90, nil))
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"sort"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// MobilityClass class of UE mobility determining the nominal speed of the UE
+type MobilityClass string
+
+const (
+	// Stationary UEs do not move
+	Stationary MobilityClass = "stationary"
+	// Pedestrian UEs move at 3 km/h
+	Pedestrian MobilityClass = "pedestrian"
+	// Vehicular UEs move at 60 km/h
+	Vehicular MobilityClass = "vehicular"
+	// HighSpeed UEs, e.g. on high-speed trains, move at 300 km/h
+	HighSpeed MobilityClass = "highspeed"
+)
+
+var classSpeeds = map[MobilityClass]float64{
+	Stationary: 0,
+	Pedestrian: 3,
+	Vehicular:  60,
+	HighSpeed:  300,
+}
+
+// Speed returns the nominal speed of the mobility class in km/h
+func (c MobilityClass) Speed() float64 {
+	return classSpeeds[c]
+}
+
+// IsValid returns true if the mobility class is known
+func (c MobilityClass) IsValid() bool {
+	_, ok := classSpeeds[c]
+	return ok
+}
+
+// MobilityConfig distribution of the UEs across mobility classes; if no classes are given, the speed of each UE
+// is drawn at random and UEs have no mobility class
+type MobilityConfig struct {
+	Classes        map[MobilityClass]float64 `mapstructure:"classes" yaml:"classes"`               // relative share of the UEs in each class
+	SpeedDeviation float64                   `mapstructure:"speedDeviation" yaml:"speedDeviation"` // standard deviation of the speed relative to the nominal speed; 0.1 by default
+}
+
+const defaultSpeedDeviation = 0.1
+
+// IsEnabled returns true if the UEs are distributed across mobility classes
+func (c MobilityConfig) IsEnabled() bool {
+	return len(c.Classes) > 0
+}
+
+// Validate checks all mobility classes are known and their shares are not negative
+func (c MobilityConfig) Validate() error {
+	for class, share := range c.Classes {
+		if !class.IsValid() {
+			return errors.NewInvalid("unknown mobility class %s", class)
+		}
+		if share < 0 {
+			return errors.NewInvalid("share of mobility class %s is negative", class)
+		}
+	}
+	return nil
+}
+
+// GetSpeedDeviation returns the standard deviation of the speed relative to the nominal speed
+func (c MobilityConfig) GetSpeedDeviation() float64 {
+	if c.SpeedDeviation > 0 {
+		return c.SpeedDeviation
+	}
+	return defaultSpeedDeviation
+}
+
+// SelectClass returns the mobility class at the given point, between 0 and 1, of the cumulative distribution of
+// the classes; the classes are ordered by their nominal speed
+func (c MobilityConfig) SelectClass(p float64) MobilityClass {
+	classes := make([]MobilityClass, 0, len(c.Classes))
+	total := 0.0
+	for class, share := range c.Classes {
+		classes = append(classes, class)
+		total += share
+	}
+	if len(classes) == 0 {
+		return ""
+	}
+	sort.Slice(classes, func(i, j int) bool { return classes[i].Speed() < classes[j].Speed() })
+
+	cumulative := 0.0
+	for _, class := range classes {
+		cumulative += c.Classes[class] / total
+		if p < cumulative {
+			return class
+		}
+	}
+	return classes[len(classes)-1]
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectMobilityClass(t *testing.T) {
+	config := MobilityConfig{}
+	assert.False(t, config.IsEnabled())
+	assert.Equal(t, MobilityClass(""), config.SelectClass(0.5))
+
+	config = MobilityConfig{Classes: map[MobilityClass]float64{
+		HighSpeed:  1,
+		Pedestrian: 2,
+		Stationary: 1,
+	}}
+	assert.True(t, config.IsEnabled())
+	assert.NoError(t, config.Validate())
+	assert.Equal(t, Stationary, config.SelectClass(0))
+	assert.Equal(t, Pedestrian, config.SelectClass(0.25))
+	assert.Equal(t, Pedestrian, config.SelectClass(0.7))
+	assert.Equal(t, HighSpeed, config.SelectClass(0.75))
+	assert.Equal(t, HighSpeed, config.SelectClass(1))
+	assert.Equal(t, 0.1, config.GetSpeedDeviation())
+
+	assert.Equal(t, 0.0, Stationary.Speed())
+	assert.Equal(t, 3.0, Pedestrian.Speed())
+	assert.Equal(t, 60.0, Vehicular.Speed())
+	assert.Equal(t, 300.0, HighSpeed.Speed())
+}
+
+func TestInvalidMobilityConfig(t *testing.T) {
+	config := MobilityConfig{Classes: map[MobilityClass]float64{"teleporting": 1}}
+	assert.Error(t, config.Validate())
+	config = MobilityConfig{Classes: map[MobilityClass]float64{Vehicular: -1}}
+	assert.Error(t, config.Validate())
+}
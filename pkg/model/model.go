@@ -5,51 +5,97 @@
 package model
 
 import (
+	"time"
+
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
 	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 )
 
 // Model simulation model
 type Model struct {
-	MapLayout               MapLayout               `mapstructure:"layout" yaml:"layout"`
-	RouteEndPoints          []RouteEndPoint         `mapstructure:"routeEndPoints" yaml:"routeEndPoints"`
-	WayPointRoute           bool                    `mapstructure:"wayPointRoute" yaml:"wayPointRoute"`
-	DirectRoute             bool                    `mapstructure:"directRoute" yaml:"directRoute"`
-	Nodes                   map[string]Node         `mapstructure:"nodes" yaml:"nodes"`
-	Cells                   map[string]Cell         `mapstructure:"cells" yaml:"cells"`
-	Controllers             map[string]Controller   `mapstructure:"controllers" yaml:"controllers"`
-	ServiceModels           map[string]ServiceModel `mapstructure:"servicemodels" yaml:"servicemodels"`
-	RrcStateChangesDisabled bool                    `mapstructure:"RrcStateChangesDisabled" yaml:"RrcStateChangesDisabled"`
-	InitialRrcState         string                  `mapstructure:"initialRrcState" yaml:"initialRrcState"`
-	UECount                 uint                    `mapstructure:"ueCount" yaml:"ueCount"`
-	UECountPerCell          uint                    `mapstructure:"ueCountPerCell" yaml:"ueCountPerCell"`
-	Plmn                    string                  `mapstructure:"plmnID" yaml:"plmnID"`
-	PlmnID                  types.PlmnID            `mapstructure:"plmnNumber" yaml:"plmnNumber"` // overridden and derived post-load from "Plmn" field
-	APIKey                  string                  `mapstructure:"apiKey" yaml:"apiKey"`         // Google Maps API key (optional)
+	MapLayout               MapLayout                 `mapstructure:"layout" yaml:"layout"`
+	RouteEndPoints          []RouteEndPoint           `mapstructure:"routeEndPoints" yaml:"routeEndPoints"`
+	Regions                 []Region                  `mapstructure:"regions" yaml:"regions"`
+	WayPointRoute           bool                      `mapstructure:"wayPointRoute" yaml:"wayPointRoute"`
+	DirectRoute             bool                      `mapstructure:"directRoute" yaml:"directRoute"`
+	Nodes                   map[string]Node           `mapstructure:"nodes" yaml:"nodes"`
+	Cells                   map[string]Cell           `mapstructure:"cells" yaml:"cells"`
+	Controllers             map[string]Controller     `mapstructure:"controllers" yaml:"controllers"`
+	ServiceModels           map[string]ServiceModel   `mapstructure:"servicemodels" yaml:"servicemodels"`
+	RrcStateChangesDisabled bool                      `mapstructure:"RrcStateChangesDisabled" yaml:"RrcStateChangesDisabled"`
+	InitialRrcState         string                    `mapstructure:"initialRrcState" yaml:"initialRrcState"`
+	Rrc                     RrcConfig                 `mapstructure:"rrc" yaml:"rrc"`
+	LoadTest                LoadTestConfig            `mapstructure:"loadTest" yaml:"loadTest"`
+	Monitor                 MonitorConfig             `mapstructure:"monitor" yaml:"monitor"`
+	Outages                 []Outage                  `mapstructure:"outages" yaml:"outages"`
+	Sharding                ShardingConfig            `mapstructure:"sharding" yaml:"sharding"`
+	RemoteCells             []RemoteCell              `mapstructure:"-" yaml:"-"` // cells of the other shards, derived upon partitioning
+	Scaling                 ScalingConfig             `mapstructure:"scaling" yaml:"scaling"`
+	ControllerSelection     ControllerSelectionConfig `mapstructure:"controllerSelection" yaml:"controllerSelection"`
+	Mobility                MobilityConfig            `mapstructure:"mobility" yaml:"mobility"`
+	MeasurementNoise        MeasurementNoiseConfig    `mapstructure:"measurementNoise" yaml:"measurementNoise"`
+	DualConnectivity        DualConnectivityConfig    `mapstructure:"dualConnectivity" yaml:"dualConnectivity"`
+	CarrierAggregation      CarrierAggregationConfig  `mapstructure:"carrierAggregation" yaml:"carrierAggregation"`
+	Handover                HandoverConfig            `mapstructure:"handover" yaml:"handover"`
+	Rach                    RachConfig                `mapstructure:"rach" yaml:"rach"`
+	Interference            InterferenceConfig        `mapstructure:"interference" yaml:"interference"`
+	Uplink                  UplinkConfig              `mapstructure:"uplink" yaml:"uplink"`
+	Throughput              ThroughputConfig          `mapstructure:"throughput" yaml:"throughput"`
+	Scheduler               SchedulerConfig           `mapstructure:"scheduler" yaml:"scheduler"`
+	CellSearchRadius        float64                   `mapstructure:"cellSearchRadius" yaml:"cellSearchRadius"` // meters; cells farther from a UE are not measured by it; all cells are measured if 0
+	UEPlacement             UEPlacementConfig         `mapstructure:"uePlacement" yaml:"uePlacement"`
+	TrafficProfile          TrafficProfileConfig      `mapstructure:"trafficProfile" yaml:"trafficProfile"`
+	UECount                 uint                      `mapstructure:"ueCount" yaml:"ueCount"`
+	UECountPerCell          uint                      `mapstructure:"ueCountPerCell" yaml:"ueCountPerCell"`
+	Plmn                    string                    `mapstructure:"plmnID" yaml:"plmnID"`
+	PlmnID                  types.PlmnID              `mapstructure:"plmnNumber" yaml:"plmnNumber"` // overridden and derived post-load from "Plmn" field
+	AdditionalPlmns         []string                  `mapstructure:"additionalPlmnIDs" yaml:"additionalPlmnIDs"`
+	PlmnIDs                 []types.PlmnID            `mapstructure:"plmnNumbers" yaml:"plmnNumbers"` // derived post-load from "Plmn" and "AdditionalPlmns" fields
+	APIKey                  string                    `mapstructure:"apiKey" yaml:"apiKey"`           // Google Maps API key (optional)
+	TLS                     TLSConfig                 `mapstructure:"tls" yaml:"tls"`                 // Global client certificates (optional)
 }
 
 // Coordinate represents a geographical location
 type Coordinate struct {
 	Lat float64 `mapstructure:"lat"`
 	Lng float64 `mapstructure:"lng"`
+	// X and Y are the offsets in meters east and north of the map center of a location given in the relative
+	// coordinate system; they are converted to latitude and longitude when the model is loaded
+	X float64 `mapstructure:"x" yaml:"x,omitempty"`
+	Y float64 `mapstructure:"y" yaml:"y,omitempty"`
 }
 
 // Sector represents a 2D arc emanating from a location
 type Sector struct {
-	Center  Coordinate `mapstructure:"center"`
-	Azimuth int32      `mapstructure:"azimuth"`
-	Arc     int32      `mapstructure:"arc"`
-	Tilt    int32      `mapstructure:"tilt"`
-	Height  int32      `mapstructure:"height"`
+	Center         Coordinate `mapstructure:"center"`
+	Azimuth        int32      `mapstructure:"azimuth"`
+	Arc            int32      `mapstructure:"arc"`
+	Tilt           int32      `mapstructure:"tilt"`           // mechanical downtilt in degrees
+	ElectricalTilt int32      `mapstructure:"electricalTilt"` // electrical downtilt in degrees, adjustable via RC control
+	Height         int32      `mapstructure:"height"`         // antenna height in meters
+	Antenna        string     `mapstructure:"antenna"`        // gain pattern of the antenna; the default approximates the sector beam
+	Gain           float64    `mapstructure:"gain"`           // maximum antenna gain in dBi of the 3GPP pattern; 8 dBi by default
 }
 
+// Antenna3GPP sector antenna gain pattern of 3GPP TR 38.901, taking the antenna height and tilt into account
+const Antenna3GPP = "3gpp"
+
 // RouteEndPoint ...
 type RouteEndPoint struct {
 	Start Coordinate `mapstructure:"start"`
 	End   Coordinate `mapstructure:"end"`
 }
 
+// Region geo-fenced area populated with a target density of UEs which spawn and move within its bounds
+type Region struct {
+	Name           string       `mapstructure:"name"`
+	Polygon        []Coordinate `mapstructure:"polygon"`        // vertices of the area; the polygon is closed implicitly
+	Density        float64      `mapstructure:"density"`        // target number of UEs per square kilometer
+	TrafficProfile TrafficCurve `mapstructure:"trafficProfile"` // load factors over the day of the cells in the region
+}
+
 // Route represents a series of points for tracking movement of user-equipment
 type Route struct {
 	IMSI        types.IMSI
@@ -59,22 +105,188 @@ type Route struct {
 	SpeedStdDev uint32
 	Reverse     bool
 	NextPoint   uint32
+	Paused      bool // the UE stays where it is until the route is resumed
 }
 
 // Node e2 node
 type Node struct {
-	GnbID         types.GnbID  `mapstructure:"gnbid"`
-	Controllers   []string     `mapstructure:"controllers"`
-	ServiceModels []string     `mapstructure:"servicemodels"`
-	Cells         []types.NCGI `mapstructure:"cells"`
-	Status        string       `mapstructure:"status"`
+	GnbID         types.GnbID       `mapstructure:"gnbid"`
+	Name          string            `mapstructure:"name" yaml:"name,omitempty"` // optional friendly name, e.g. Tower-3; the key of the node in the model by default
+	Plmn          string            `mapstructure:"plmnID"`                     // optional; defaults to the model primary PLMN
+	Controllers   []string          `mapstructure:"controllers"`
+	ServiceModels []string          `mapstructure:"servicemodels"`
+	Cells         []types.NCGI      `mapstructure:"cells"`
+	Status        string            `mapstructure:"status"`
+	TLS           TLSConfig         `mapstructure:"tls"`
+	Timers        E2Timers          `mapstructure:"timers"`
+	Netem         NetemConfig       `mapstructure:"netem"`
+	Indications   IndicationLimit   `mapstructure:"indications"`
+	Limits        ResourceLimits    `mapstructure:"limits"`
+	Record        string            `mapstructure:"record"`       // optional file recording the E2AP messages of the node
+	Labels        map[string]string `mapstructure:"labels"`       // optional labels, e.g. used to assign the node to a shard
+	E2NodeID      E2NodeIDConfig    `mapstructure:"e2NodeID"`     // optional type and length of the global E2 node ID
+	RAT           string            `mapstructure:"rat"`          // optional nr or eutra; follows the E2 node type by default
+	ReportStyles  []ReportStyle     `mapstructure:"reportStyles"` // optional KPM report styles replacing those of the service model
+	E2Setup       *E2SetupResult    `mapstructure:"-" yaml:"-"`   // outcome of the latest E2 setup procedure of the node
+}
+
+// E2SetupResult outcome of an E2 setup procedure of a node
+type E2SetupResult struct {
+	Controller     string              `json:"controller"`               // controller the E2 setup was attempted with
+	Time           time.Time           `json:"time"`                     // time the outcome was received
+	Successful     bool                `json:"successful"`               // false if the RIC responded with an E2 setup failure
+	Cause          string              `json:"cause,omitempty"`          // cause of the E2 setup failure
+	GlobalE2NodeID string              `json:"globalE2NodeId,omitempty"` // global E2 node ID acknowledged by the RIC
+	GlobalRicID    string              `json:"globalRicId,omitempty"`    // global RIC ID of the responding RIC
+	Accepted       []RanFunctionResult `json:"accepted,omitempty"`       // RAN functions accepted by the RIC
+	Rejected       []RanFunctionResult `json:"rejected,omitempty"`       // RAN functions rejected by the RIC
+}
+
+// RanFunctionResult RAN function accepted or rejected by the RIC in an E2 setup procedure
+type RanFunctionResult struct {
+	ID       int32  `json:"id"`
+	Revision int32  `json:"revision,omitempty"`
+	Cause    string `json:"cause,omitempty"` // cause of the rejection
+}
+
+// E2NodeIDConfig type of the E2 node and length of its ID in the global E2 node ID; the node is a gNB with a 28 bit
+// gNB ID by default
+type E2NodeIDConfig struct {
+	Type   string `mapstructure:"type"`   // gnb, en-gnb, ng-enb or enb
+	Length uint32 `mapstructure:"length"` // length of the ID in bits; 22 to 32 for gNBs, 18, 20, 21 or 28 for eNBs
+}
+
+// E2Timers E2AP procedure guard timers of a node; a zero value disables the timer
+type E2Timers struct {
+	SetupResponse      time.Duration `mapstructure:"setupResponse"`      // wait for the E2 setup response
+	ControlAck         time.Duration `mapstructure:"controlAck"`         // complete a RIC control request
+	SubscriptionDelete time.Duration `mapstructure:"subscriptionDelete"` // complete a RIC subscription delete request
+}
+
+// NetemConfig emulated network conditions on the E2 connection of a node; a zero value disables the emulation
+type NetemConfig struct {
+	Latency      time.Duration `mapstructure:"latency"`      // fixed one-way latency of each message
+	Jitter       time.Duration `mapstructure:"jitter"`       // deviation added to the fixed latency
+	Distribution string        `mapstructure:"distribution"` // distribution of the jitter; uniform (default) or normal
+	Bandwidth    uint64        `mapstructure:"bandwidth"`    // rate of each direction in bits per second; zero is unlimited
+}
+
+// IsEnabled returns true if any network condition is emulated
+func (c NetemConfig) IsEnabled() bool {
+	return c.Latency > 0 || c.Jitter > 0 || c.Bandwidth > 0
+}
+
+// IndicationLimit cap on the rate of indications of each subscription of a node; a zero value disables the cap
+type IndicationLimit struct {
+	MaxRate   float64 `mapstructure:"maxRate"`   // indications per second of each subscription
+	Burst     int     `mapstructure:"burst"`     // indications which can be sent at once; 1 by default
+	Aggregate bool    `mapstructure:"aggregate"` // hold back the KPM v2 measurement periods over the cap instead of dropping them
+}
+
+// ResourceLimits caps on the resources of a node, modeling the finite capacity of a real E2 node; a zero value
+// disables the cap
+type ResourceLimits struct {
+	MaxSubscriptions  int     `mapstructure:"maxSubscriptions"`  // subscriptions admitted at once
+	MaxIndicationRate float64 `mapstructure:"maxIndicationRate"` // indications per second of all subscriptions of the node
+	IndicationBurst   int     `mapstructure:"indicationBurst"`   // indications of the node which can be sent at once; 1 by default
+}
+
+// ExceedsSubscriptions returns true if the given number of subscriptions exceeds the subscription cap of the node
+func (l ResourceLimits) ExceedsSubscriptions(subscriptions int) bool {
+	return l.MaxSubscriptions > 0 && subscriptions > l.MaxSubscriptions
 }
 
 // Controller E2T endpoint information
 type Controller struct {
-	ID      string `mapstructure:"id"`
-	Address string `mapstructure:"address"`
-	Port    int    `mapstructure:"port"`
+	ID       string     `mapstructure:"id"`
+	Address  string     `mapstructure:"address"`
+	Port     int        `mapstructure:"port"`
+	TLS      TLSConfig  `mapstructure:"tls"`
+	Location Coordinate `mapstructure:"location"` // optional; used by the nearest controller selection policy
+	Weight   uint       `mapstructure:"weight"`   // optional; share of nodes given by the weighted selection policy
+	// Transport sctp, the default, or tcp; TLS is only supported over TCP, so secure controllers must use tcp
+	Transport string `mapstructure:"transport"`
+}
+
+// TLSConfig secure transport settings for E2 connections
+type TLSConfig struct {
+	Enabled            bool   `mapstructure:"enabled" yaml:"enabled"`
+	CACert             string `mapstructure:"caCert" yaml:"caCert"`
+	Cert               string `mapstructure:"cert" yaml:"cert"`
+	Key                string `mapstructure:"key" yaml:"key"`
+	InsecureSkipVerify bool   `mapstructure:"insecureSkipVerify" yaml:"insecureSkipVerify"`
+}
+
+// HasClientCert returns true if a client certificate and key are configured
+func (c TLSConfig) HasClientCert() bool {
+	return c.Cert != "" && c.Key != ""
+}
+
+// RrcConfig RRC state machine parameters; if none are set, the RRC states change at random
+type RrcConfig struct {
+	InactivityTimer   time.Duration `mapstructure:"inactivityTimer" yaml:"inactivityTimer"`     // CONNECTED to INACTIVE
+	IdleTimer         time.Duration `mapstructure:"idleTimer" yaml:"idleTimer"`                 // INACTIVE (or CONNECTED without inactivity timer) to IDLE
+	PagingProbability float64       `mapstructure:"pagingProbability" yaml:"pagingProbability"` // chance of paging an IDLE or INACTIVE UE per update
+}
+
+// IsTimed returns true if the RRC state transitions are driven by timers and paging
+func (c RrcConfig) IsTimed() bool {
+	return c.InactivityTimer > 0 || c.IdleTimer > 0 || c.PagingProbability > 0
+}
+
+// MeasurementNoiseConfig noise applied to the RSRP measured by the UEs; if none is set, the measurements follow the
+// path loss exactly
+type MeasurementNoiseConfig struct {
+	ShadowingStdDev        float64 `mapstructure:"shadowingStdDev" yaml:"shadowingStdDev"`               // standard deviation of the log-normal shadowing in dB
+	ShadowingDecorrelation float64 `mapstructure:"shadowingDecorrelation" yaml:"shadowingDecorrelation"` // distance in meters over which the shadowing decorrelates; 50m by default
+	FastFading             bool    `mapstructure:"fastFading" yaml:"fastFading"`                         // apply Rayleigh fast fading, correlated according to the UE speed
+	Seed                   int64   `mapstructure:"seed" yaml:"seed"`                                     // seed of the noise; a random seed is used if not set
+}
+
+// IsEnabled returns true if noise is applied to the RSRP measurements
+func (c MeasurementNoiseConfig) IsEnabled() bool {
+	return c.ShadowingStdDev > 0 || c.FastFading
+}
+
+// LoadTestConfig synthetic indication load generated instead of the simulated mobility and RF conditions
+type LoadTestConfig struct {
+	Rate float64 `mapstructure:"rate" yaml:"rate"` // aggregate number of KPM indications per second across all nodes
+}
+
+// MonitorConfig resource budgets of the simulation checked by the self-health monitor; a zero budget is not checked
+type MonitorConfig struct {
+	Interval           time.Duration `mapstructure:"interval" yaml:"interval" json:"interval"`                               // sampling period; 10s by default
+	GoroutineBudget    int           `mapstructure:"goroutineBudget" yaml:"goroutineBudget" json:"goroutineBudget"`          // goroutines of the whole process
+	SubscriptionBudget int           `mapstructure:"subscriptionBudget" yaml:"subscriptionBudget" json:"subscriptionBudget"` // running subscription routines of each node
+	Shed               bool          `mapstructure:"shed" yaml:"shed" json:"shed"`                                           // reject the subscriptions of the nodes over budget
+}
+
+// ExceedsSubscriptionBudget returns true if the given number of running subscription routines of a node exceeds the
+// subscription budget
+func (c MonitorConfig) ExceedsSubscriptionBudget(running int) bool {
+	return c.SubscriptionBudget > 0 && running > c.SubscriptionBudget
+}
+
+// Outage scheduled outage of a cell; the cell provides no coverage from the start of the outage, relative to the
+// start of the simulation, until it recovers at the end of the outage
+type Outage struct {
+	NCGI     types.NCGI    `mapstructure:"ncgi" yaml:"ncgi"`
+	Start    time.Duration `mapstructure:"start" yaml:"start"`
+	Duration time.Duration `mapstructure:"duration" yaml:"duration"` // the cell does not recover if not set
+}
+
+// ScalingConfig template of the honeycomb clusters of nodes added when the topology is scaled at runtime
+type ScalingConfig struct {
+	Clusters        uint    `mapstructure:"clusters" yaml:"clusters"`               // number of clusters added at startup
+	Towers          uint    `mapstructure:"towers" yaml:"towers"`                   // towers of each cluster; each tower is a node
+	SectorsPerTower uint    `mapstructure:"sectorsPerTower" yaml:"sectorsPerTower"` // cells of each node
+	Pitch           float32 `mapstructure:"pitch" yaml:"pitch"`                     // distance between towers in degrees
+	Spacing         float64 `mapstructure:"spacing" yaml:"spacing"`                 // distance between cluster centers in degrees
+}
+
+// IsEnabled returns true if the simulator runs in load test mode
+func (c LoadTestConfig) IsEnabled() bool {
+	return c.Rate > 0
 }
 
 // MeasurementParams has measurement parameters
@@ -96,6 +308,9 @@ type EventA3Params struct {
 // Cell represents a section of coverage
 type Cell struct {
 	NCGI              types.NCGI        `mapstructure:"ncgi"`
+	Name              string            `mapstructure:"name" yaml:"name,omitempty"`     // optional friendly name, e.g. Tower-3/Sector-B; the key of the cell in the model by default
+	Labels            map[string]string `mapstructure:"labels" yaml:"labels,omitempty"` // optional labels, e.g. the site or the vendor of the cell
+	TAC               uint32            `mapstructure:"tac" yaml:"tac,omitempty"`       // optional 24 bit tracking area code; see TrackingArea
 	Sector            Sector            `mapstructure:"sector"`
 	Color             string            `mapstructure:"color"`
 	MaxUEs            uint32            `mapstructure:"maxUEs"`
@@ -104,9 +319,18 @@ type Cell struct {
 	MeasurementParams MeasurementParams `mapstructure:"measurementParams"`
 	PCI               uint32            `mapstructure:"pci"`
 	Earfcn            uint32            `mapstructure:"earfcn"`
+	Arfcn             uint32            `mapstructure:"arfcn"`     // NR-ARFCN of the carrier
+	Frequency         float64           `mapstructure:"frequency"` // carrier frequency in MHz; derived from the NR-ARFCN if not set
+	Bandwidth         uint32            `mapstructure:"bandwidth"` // carrier bandwidth in MHz
 	CellType          types.CellType    `mapstructure:"cellType"`
+	RachCapacity      float64           `mapstructure:"rachCapacity"`   // preambles per second detected without collisions; overrides the RACH capacity of the model
+	Interference      float64           `mapstructure:"interference"`   // rise in dB of the noise floor of the UEs served by the cell, due to interferers outside of the simulation
+	Weight            *float64          `mapstructure:"weight"`         // load weight of the cell for the placement of the UEs; 1 by default
+	TrafficProfile    TrafficCurve      `mapstructure:"trafficProfile"` // load factors over the day of the cell
+	Failed            bool              // the cell is out of service and provides no coverage
 	RrcIdleCount      uint32
 	RrcConnectedCount uint32
+	RrcInactiveCount  uint32
 }
 
 // UEType represents type of user-equipment
@@ -122,23 +346,147 @@ type UECell struct {
 // UE represents user-equipment, i.e. phone, IoT device, etc.
 type UE struct {
 	IMSI     types.IMSI
+	PlmnID   types.PlmnID // home PLMN
 	Type     UEType
 	RrcState e2sm_mho.Rrcstatus
 	Location Coordinate
 	Heading  uint32
+	Speed    float64       // current speed in km/h
+	Mobility MobilityClass // optional mobility class determining the nominal speed
+
+	Cell          *UECell
+	CRNTI         types.CRNTI
+	Cells         []*UECell
+	SecondaryCell *UECell    // primary cell of the secondary cell group on another node, if the UE is dual connected
+	SplitRatio    float64    // share of the traffic of the split bearer carried by the secondary cell group
+	Carriers      []*Carrier // carriers aggregated from the serving node; the primary cell and the secondary cells
 
-	Cell  *UECell
-	CRNTI types.CRNTI
-	Cells []*UECell
+	MeasReport MeasReportConfig // measurement reporting configuration of the UE, set via RC control
+	Detached   bool             // detached from both its source and target cells during the interruption of a handover
+	Sinr       float64          // SINR in dB of the serving cell, interfered by the co-channel cells
+	Cqi        uint32           // wideband CQI derived from the SINR
+	Uplink     Uplink           // uplink transmission power, power headroom and SINR
 
-	IsAdmitted bool
+	IsAdmitted   bool
+	RrcStateTime time.Time // time of the last RRC state transition
+	TAC          uint32    // tracking area the UE is registered in; 0 until its first registration
+}
+
+// GetPlmnID extracts the PLMN ID from the given NCGI
+func GetPlmnID(ncgi types.NCGI) types.PlmnID {
+	return types.PlmnID(uint64(ncgi) >> 36)
+}
+
+// IsRoaming returns true if the UE is served by a cell outside of its home PLMN
+func (ue *UE) IsRoaming() bool {
+	if ue.Cell == nil || ue.PlmnID == 0 {
+		return false
+	}
+	return GetPlmnID(ue.Cell.NCGI) != ue.PlmnID
 }
 
 // ServiceModel service model information
 type ServiceModel struct {
-	ID          int    `mapstructure:"id"`
-	Description string `mapstructure:"description"`
-	Version     string `mapstructure:"version"`
+	ID          int       `mapstructure:"id"`
+	Description string    `mapstructure:"description"`
+	Version     string    `mapstructure:"version"`
+	KPM         KPMConfig `mapstructure:"kpm"`
+	MHO         MHOConfig `mapstructure:"mho"`
+	RC          RCConfig  `mapstructure:"rc"`
+	NI          NIConfig  `mapstructure:"ni"`
+}
+
+// ReportStyle RIC report style advertised in the RAN function description
+type ReportStyle struct {
+	Type          int32  `mapstructure:"type"`
+	Name          string `mapstructure:"name"`
+	ActionFormat  int32  `mapstructure:"actionFormat"`  // action definition format; defaults to format 1
+	HeaderFormat  int32  `mapstructure:"headerFormat"`  // indication header format; defaults to format 1
+	MessageFormat int32  `mapstructure:"messageFormat"` // indication message format; defaults to format 1
+}
+
+// KPM report modes
+const (
+	KPMReportPeriodic = "periodic"
+	KPMReportOnChange = "onChange"
+)
+
+// KPMConfig KPM service model parameters
+type KPMConfig struct {
+	ReportStyles   []ReportStyle `mapstructure:"reportStyles"`   // defaults to the periodic report style
+	Measurements   []string      `mapstructure:"measurements"`   // defaults to all supported measurements
+	ReportMode     string        `mapstructure:"reportMode"`     // "periodic" or "onChange"; defaults to periodic (KPM v2 only)
+	ChangeDelta    float64       `mapstructure:"changeDelta"`    // change of a measurement above which an on change report is sent
+	IndicationSize int           `mapstructure:"indicationSize"` // bytes the indication messages are padded to with synthetic per-UE measurements (KPM v2 only)
+}
+
+// IsOnChange returns true if indications are sent when the measurements change rather than periodically
+func (c KPMConfig) IsOnChange() bool {
+	return c.ReportMode == KPMReportOnChange
+}
+
+// MHOConfig MHO service model parameters
+type MHOConfig struct {
+	MinReportInterval  int32    `mapstructure:"minReportInterval"`  // lower bound of periodic report intervals in ms
+	RsrpThreshold      *float64 `mapstructure:"rsrpThreshold"`      // neighbor cells below the threshold are not reported
+	MaxNeighbors       int      `mapstructure:"maxNeighbors"`       // maximum number of reported neighbor cells
+	CallProcessTimeout int32    `mapstructure:"callProcessTimeout"` // ms a handover suspended by an insert indication waits for the RIC control
+}
+
+// GetCallProcessTimeout returns the time a handover suspended by an insert indication waits for the RIC control
+func (c MHOConfig) GetCallProcessTimeout() time.Duration {
+	if c.CallProcessTimeout <= 0 {
+		return 5 * time.Second
+	}
+	return time.Duration(c.CallProcessTimeout) * time.Millisecond
+}
+
+// RCConfig RC service model parameters
+type RCConfig struct {
+	Capabilities []string `mapstructure:"capabilities"` // "report" and/or "control"; defaults to both
+}
+
+// HasCapability returns true if the RC service model is configured with the given capability
+func (c RCConfig) HasCapability(capability string) bool {
+	if len(c.Capabilities) == 0 {
+		return true
+	}
+	for _, configured := range c.Capabilities {
+		if configured == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// Network interfaces whose captured messages are replayed by the NI service model
+const (
+	NIInterfaceNGAP = "ngap"
+	NIInterfaceXnAP = "xnap"
+	NIInterfaceS1AP = "s1ap"
+)
+
+// NIConfig NI service model parameters
+type NIConfig struct {
+	Description string      `mapstructure:"description"` // hex ASN.1 encoded RAN function description; defaults to none
+	Interval    int32       `mapstructure:"interval"`    // ms between two replayed messages
+	Messages    []NIMessage `mapstructure:"messages"`    // captured messages replayed in turn to each subscription
+}
+
+// GetInterval returns the interval between two messages replayed to a subscription
+func (c NIConfig) GetInterval() time.Duration {
+	if c.Interval <= 0 {
+		return time.Second
+	}
+	return time.Duration(c.Interval) * time.Millisecond
+}
+
+// NIMessage network interface message sample, e.g. taken from a trace of a real node, as carried by an E2SM-NI
+// indication
+type NIMessage struct {
+	Interface string `mapstructure:"interface"` // "ngap", "xnap" or "s1ap"
+	Header    string `mapstructure:"header"`    // hex ASN.1 encoded E2SM-NI indication header
+	Message   string `mapstructure:"message"`   // hex ASN.1 encoded E2SM-NI indication message carrying the captured PDU
 }
 
 // GetServiceModel gets a service model based on a given name.
@@ -149,6 +497,48 @@ func (m *Model) GetServiceModel(name string) (ServiceModel, error) {
 	return ServiceModel{}, errors.New(errors.NotFound, "the service model not found")
 }
 
+// GetNodeServiceModel gets the configuration of the service model with the given ID exposed by the given node
+func (m *Model) GetNodeServiceModel(node Node, id int) (ServiceModel, error) {
+	for _, name := range node.ServiceModels {
+		if sm, ok := m.ServiceModels[name]; ok && sm.ID == id {
+			return sm, nil
+		}
+	}
+	return ServiceModel{}, errors.New(errors.NotFound, "the service model not found")
+}
+
+// GetNodePlmnID gets the PLMN ID of the given node; defaults to the primary PLMN ID of the model
+func (m *Model) GetNodePlmnID(node Node) (types.PlmnID, error) {
+	if node.Plmn == "" {
+		return m.PlmnID, nil
+	}
+	plmnID, err := plmn.Parse(node.Plmn)
+	if err != nil {
+		return 0, errors.NewInvalid("node %d: %v", node.GnbID, err)
+	}
+	return plmnID, nil
+}
+
+// GetCellPlmnID gets the PLMN ID of the cell with the given NCGI; defaults to the primary PLMN ID of the model
+// if the NCGI does not encode one of the served PLMNs
+func (m *Model) GetCellPlmnID(ncgi types.NCGI) types.PlmnID {
+	plmnID := GetPlmnID(ncgi)
+	if m.IsServedPlmn(plmnID) {
+		return plmnID
+	}
+	return m.PlmnID
+}
+
+// IsServedPlmn returns true if the given PLMN ID is one of the PLMNs served by the model
+func (m *Model) IsServedPlmn(plmnID types.PlmnID) bool {
+	for _, id := range m.PlmnIDs {
+		if id == plmnID {
+			return true
+		}
+	}
+	return false
+}
+
 // GetController gets a controller by a given name
 func (m *Model) GetController(name string) (Controller, error) {
 	if controller, ok := m.Controllers[name]; ok {
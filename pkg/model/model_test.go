@@ -24,6 +24,16 @@ func TestModel(t *testing.T) {
 	assert.Equal(t, "1.0.0", model.ServiceModels["kpm"].Version)
 	assert.Equal(t, 3, model.ServiceModels["rc"].ID)
 	assert.Equal(t, 2, model.ServiceModels["ni"].ID)
+	assert.Equal(t, "Periodic Report", model.ServiceModels["kpm"].KPM.ReportStyles[0].Name)
+	assert.True(t, model.ServiceModels["rc"].RC.HasCapability("report"))
+	assert.False(t, model.ServiceModels["rc"].RC.HasCapability("control"))
+	assert.True(t, model.ServiceModels["kpm"].RC.HasCapability("control"))
+
+	rc, err := model.GetNodeServiceModel(model.Nodes["node1"], 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "RC service model", rc.Description)
+	_, err = model.GetNodeServiceModel(model.Nodes["node2"], 3)
+	assert.Error(t, err)
 	assert.Equal(t, uint(12), model.UECount)
 	assert.Equal(t, "314628", model.Plmn)
 	assert.Equal(t, types.PlmnID(0x138426), model.PlmnID)
@@ -35,3 +45,90 @@ func TestModel(t *testing.T) {
 	assert.Equal(t, true, model.MapLayout.FadeMap)
 	assert.Equal(t, 45.0, model.MapLayout.Center.Lat)
 }
+
+func TestCompare(t *testing.T) {
+	old := &Model{}
+	err := LoadConfig(old, "test")
+	assert.NoError(t, err)
+
+	diff := Compare(old, old)
+	assert.True(t, diff.IsEmpty())
+
+	updated := &Model{
+		Nodes:       make(map[string]Node),
+		Cells:       make(map[string]Cell),
+		Controllers: make(map[string]Controller),
+	}
+	for name, controller := range old.Controllers {
+		updated.Controllers[name] = controller
+	}
+	for name, node := range old.Nodes {
+		updated.Nodes[name] = node
+	}
+	for name, cell := range old.Cells {
+		updated.Cells[name] = cell
+	}
+	updated.UECount = old.UECount + 10
+
+	delete(updated.Nodes, "node2")
+	updated.Nodes["node3"] = Node{GnbID: 144472}
+	cell1 := updated.Cells["cell1"]
+	cell1.TxPowerDB = 20
+	updated.Cells["cell1"] = cell1
+	delete(updated.Cells, "cell4")
+
+	diff = Compare(old, updated)
+	assert.False(t, diff.IsEmpty())
+	assert.Len(t, diff.AddedNodes, 1)
+	assert.Equal(t, types.GnbID(144472), diff.AddedNodes[0].GnbID)
+	assert.Len(t, diff.RemovedNodes, 1)
+	assert.Equal(t, types.GnbID(144471), diff.RemovedNodes[0].GnbID)
+	assert.Len(t, diff.UpdatedNodes, 0)
+	assert.Len(t, diff.AddedCells, 0)
+	assert.Len(t, diff.UpdatedCells, 1)
+	assert.Equal(t, 20.0, diff.UpdatedCells[0].TxPowerDB)
+	assert.Len(t, diff.RemovedCells, 1)
+	assert.True(t, diff.UECountChanged)
+	assert.Equal(t, uint(22), diff.UECount)
+	assert.Len(t, diff.ChangedControllers, 0)
+
+	// The nodes connecting to a changed controller are updated
+	controller1 := updated.Controllers["controller1"]
+	controller1.Port = 36422
+	updated.Controllers["controller1"] = controller1
+	diff = Compare(old, updated)
+	assert.Equal(t, []string{"controller1"}, diff.ChangedControllers)
+	assert.Len(t, diff.UpdatedNodes, 1)
+	assert.Equal(t, types.GnbID(144470), diff.UpdatedNodes[0].GnbID)
+}
+
+func TestPlmnIDs(t *testing.T) {
+	m := &Model{Plmn: "314628", AdditionalPlmns: []string{"315010", "314628"}}
+	assert.NoError(t, initPlmnIDs(m))
+	primary := types.PlmnIDFromString("314628")
+	secondary := types.PlmnIDFromString("315010")
+	assert.Equal(t, []types.PlmnID{primary, secondary}, m.PlmnIDs)
+	assert.True(t, m.IsServedPlmn(secondary))
+
+	ncgi := types.ToNCGI(secondary, types.ToNCI(144470, 1))
+	assert.Equal(t, secondary, GetPlmnID(ncgi))
+	assert.Equal(t, secondary, m.GetCellPlmnID(ncgi))
+	assert.Equal(t, primary, m.GetCellPlmnID(types.NCGI(84325717761)))
+
+	nodePlmnID, err := m.GetNodePlmnID(Node{})
+	assert.NoError(t, err)
+	assert.Equal(t, primary, nodePlmnID)
+	nodePlmnID, err = m.GetNodePlmnID(Node{Plmn: "315010"})
+	assert.NoError(t, err)
+	assert.Equal(t, secondary, nodePlmnID)
+	_, err = m.GetNodePlmnID(Node{Plmn: "31501x"})
+	assert.Error(t, err)
+	m.Nodes = map[string]Node{"node1": {Plmn: "31501x"}}
+	assert.Error(t, initPlmnIDs(m))
+	m.Nodes = nil
+
+	ue := &UE{PlmnID: primary, Cell: &UECell{NCGI: ncgi}}
+	assert.True(t, ue.IsRoaming())
+	ue.PlmnID = secondary
+	assert.False(t, ue.IsRoaming())
+}
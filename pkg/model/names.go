@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// DisplayName returns the name of the node followed by its gNB ID, e.g. "Tower-3 (5153)", for logs and UIs
+func (n *Node) DisplayName() string {
+	if n.Name == "" {
+		return fmt.Sprintf("%d", n.GnbID)
+	}
+	return fmt.Sprintf("%s (%d)", n.Name, n.GnbID)
+}
+
+// DisplayName returns the name of the cell followed by its NCGI, e.g. "Tower-3/Sector-B (21458294227473)", for logs
+// and UIs
+func (c *Cell) DisplayName() string {
+	if c.Name == "" {
+		return fmt.Sprintf("%d", c.NCGI)
+	}
+	return fmt.Sprintf("%s (%d)", c.Name, c.NCGI)
+}
+
+// resolveNames names the nodes and cells of the model without a name after their key in the model, and makes sure
+// that names are unique among the nodes and among the cells so that they can be looked up by name
+func (m *Model) resolveNames() error {
+	nodeKeys := make([]string, 0, len(m.Nodes))
+	for key := range m.Nodes {
+		nodeKeys = append(nodeKeys, key)
+	}
+	sort.Strings(nodeKeys)
+	nodeNames := make(map[string]string, len(m.Nodes))
+	for _, key := range nodeKeys {
+		node := m.Nodes[key]
+		if node.Name == "" {
+			node.Name = key
+			m.Nodes[key] = node
+		}
+		if other, ok := nodeNames[node.Name]; ok {
+			return errors.NewInvalid("nodes %s and %s are both named %s", other, key, node.Name)
+		}
+		nodeNames[node.Name] = key
+	}
+
+	cellKeys := make([]string, 0, len(m.Cells))
+	for key := range m.Cells {
+		cellKeys = append(cellKeys, key)
+	}
+	sort.Strings(cellKeys)
+	cellNames := make(map[string]string, len(m.Cells))
+	for _, key := range cellKeys {
+		cell := m.Cells[key]
+		if cell.Name == "" {
+			cell.Name = key
+			m.Cells[key] = cell
+		}
+		if other, ok := cellNames[cell.Name]; ok {
+			return errors.NewInvalid("cells %s and %s are both named %s", other, key, cell.Name)
+		}
+		cellNames[cell.Name] = key
+	}
+	return nil
+}
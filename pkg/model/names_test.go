@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveNames(t *testing.T) {
+	m := &Model{
+		Nodes: map[string]Node{
+			"node1": {GnbID: 5153, Name: "Tower-1"},
+			"node2": {GnbID: 5154},
+		},
+		Cells: map[string]Cell{
+			"cell1": {NCGI: 21458294227473, Name: "Tower-1/Sector-A"},
+			"cell2": {NCGI: 21458294227474},
+		},
+	}
+	assert.NoError(t, m.resolveNames())
+	assert.Equal(t, "Tower-1", m.Nodes["node1"].Name)
+	assert.Equal(t, "node2", m.Nodes["node2"].Name)
+	assert.Equal(t, "cell2", m.Cells["cell2"].Name)
+
+	node := m.Nodes["node1"]
+	assert.Equal(t, "Tower-1 (5153)", node.DisplayName())
+	cell := m.Cells["cell1"]
+	assert.Equal(t, "Tower-1/Sector-A (21458294227473)", cell.DisplayName())
+	assert.Equal(t, "5155", (&Node{GnbID: 5155}).DisplayName())
+
+	// Names must be unique among the cells, even if one of them defaults to the key of another cell
+	m.Cells["cell3"] = Cell{NCGI: 21458294227475, Name: "cell2"}
+	assert.Error(t, m.resolveNames())
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "github.com/onosproject/onos-lib-go/pkg/errors"
+
+// Weightings of the cells the UEs created by the simulator are placed in
+const (
+	UEPlacementUniform = "uniform" // every cell is equally likely
+	UEPlacementArea    = "area"    // cells are weighted by the area of their sector footprint
+	UEPlacementWeight  = "weight"  // cells are weighted by their configured weight
+)
+
+const defaultUEPlacementRadius = 1000.0
+
+// UEPlacementConfig placement of the UEs created by the simulator, in a random cell and at a random location within
+// the sector footprint of the cell
+type UEPlacementConfig struct {
+	Weighting string  `mapstructure:"weighting" yaml:"weighting"` // uniform, area or weight; uniform by default
+	Radius    float64 `mapstructure:"radius" yaml:"radius"`       // largest radius of the sector footprints in meters; 1000 by default
+}
+
+// Validate checks the weighting is known
+func (c UEPlacementConfig) Validate() error {
+	switch c.Weighting {
+	case "", UEPlacementUniform, UEPlacementArea, UEPlacementWeight:
+	default:
+		return errors.NewInvalid("unknown UE placement weighting %s", c.Weighting)
+	}
+	if c.Radius < 0 {
+		return errors.NewInvalid("UE placement radius must not be negative")
+	}
+	return nil
+}
+
+// GetWeighting returns the weighting of the cells
+func (c UEPlacementConfig) GetWeighting() string {
+	if c.Weighting != "" {
+		return c.Weighting
+	}
+	return UEPlacementUniform
+}
+
+// GetRadius returns the largest radius of the sector footprints in meters
+func (c UEPlacementConfig) GetRadius() float64 {
+	if c.Radius > 0 {
+		return c.Radius
+	}
+	return defaultUEPlacementRadius
+}
+
+// GetWeight returns the weight of the cell for the placement of the UEs; 1 if not configured
+func (c Cell) GetWeight() float64 {
+	if c.Weight != nil {
+		return *c.Weight
+	}
+	return 1
+}
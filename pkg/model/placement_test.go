@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUEPlacementConfig(t *testing.T) {
+	assert.NoError(t, UEPlacementConfig{}.Validate())
+	assert.NoError(t, UEPlacementConfig{Weighting: UEPlacementArea, Radius: 500}.Validate())
+	assert.Error(t, UEPlacementConfig{Weighting: "population"}.Validate())
+	assert.Error(t, UEPlacementConfig{Radius: -1}.Validate())
+
+	assert.Equal(t, UEPlacementUniform, UEPlacementConfig{}.GetWeighting())
+	assert.Equal(t, 1000.0, UEPlacementConfig{}.GetRadius())
+
+	weight := 0.0
+	assert.Equal(t, 1.0, Cell{}.GetWeight())
+	assert.Equal(t, 0.0, Cell{Weight: &weight}.GetWeight())
+}
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+const defaultRachMaxPreambles = 4
+
+// RachConfig random access of the UEs moving from idle or inactive to connected
+type RachConfig struct {
+	Capacity           float64 `mapstructure:"capacity" yaml:"capacity"`                     // preambles per second each cell detects without collisions; unlimited if not set
+	FailureProbability float64 `mapstructure:"failureProbability" yaml:"failureProbability"` // chance of a preamble transmission failing regardless of the load
+	MaxPreambles       int     `mapstructure:"maxPreambles" yaml:"maxPreambles"`             // preamble transmissions before the random access fails; 4 by default
+}
+
+// GetCapacity returns the RACH capacity of the cell in preambles per second, overriding the capacity of the
+// configuration if set; zero is unlimited
+func (c RachConfig) GetCapacity(cell *Cell) float64 {
+	if cell != nil && cell.RachCapacity > 0 {
+		return cell.RachCapacity
+	}
+	return c.Capacity
+}
+
+// GetMaxPreambles returns the maximum number of preamble transmissions of a random access
+func (c RachConfig) GetMaxPreambles() int {
+	if c.MaxPreambles > 0 {
+		return c.MaxPreambles
+	}
+	return defaultRachMaxPreambles
+}
@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRachConfig(t *testing.T) {
+	c := RachConfig{Capacity: 50}
+	assert.Equal(t, 50.0, c.GetCapacity(nil))
+	assert.Equal(t, 50.0, c.GetCapacity(&Cell{}))
+	assert.Equal(t, 20.0, c.GetCapacity(&Cell{RachCapacity: 20}))
+	assert.Equal(t, 0.0, RachConfig{}.GetCapacity(&Cell{}))
+
+	assert.Equal(t, 4, c.GetMaxPreambles())
+	assert.Equal(t, 10, RachConfig{MaxPreambles: 10}.GetMaxPreambles())
+}
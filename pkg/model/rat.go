@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Radio access technologies of the cells of a node
+const (
+	RatNR    = "nr"
+	RatEUTRA = "eutra"
+)
+
+// E2 node types of the global E2 node ID
+const (
+	gnbNodeType   = "gnb"
+	enbNodeType   = "enb"
+	ngEnbNodeType = "ng-enb"
+)
+
+// Lengths in bits of the E-UTRAN cell identity and of the eNB IDs; 3GPP TS 36.413
+const (
+	eciLength          = 28
+	defaultEnbIDLength = 20
+)
+
+// GetRAT returns the radio access technology of the cells of the node; if not set, the RAT follows the type of the E2
+// node, E-UTRA for eNBs and ng-eNBs and NR otherwise
+func (n Node) GetRAT() string {
+	if n.RAT != "" {
+		return n.RAT
+	}
+	switch n.E2NodeID.Type {
+	case enbNodeType, ngEnbNodeType:
+		return RatEUTRA
+	default:
+		return RatNR
+	}
+}
+
+// IsEUTRA returns true if the cells of the node are E-UTRA (4G/LTE) cells
+func (n Node) IsEUTRA() bool {
+	return n.GetRAT() == RatEUTRA
+}
+
+// GetE2NodeType returns the type of the E2 node; if not set, E-UTRA nodes are eNBs and NR nodes are gNBs
+func (n Node) GetE2NodeType() string {
+	if n.E2NodeID.Type != "" {
+		return n.E2NodeID.Type
+	}
+	if n.IsEUTRA() {
+		return enbNodeType
+	}
+	return gnbNodeType
+}
+
+// ECI returns the 28 bit E-UTRAN cell identity of a cell of an E-UTRA node: the eNB ID of the node followed by the
+// least significant bits of the cell ID, 8 bits for the 20 bit macro eNB IDs
+func (n Node) ECI(ncgi types.NCGI) uint32 {
+	length := n.E2NodeID.Length
+	if length == 0 || length > eciLength {
+		length = defaultEnbIDLength
+	}
+	cellIDLength := eciLength - length
+	cellID := uint64(types.GetCellID(uint64(ncgi))) & (1<<cellIDLength - 1)
+	return uint32((uint64(n.GnbID)&(1<<length-1))<<cellIDLength | cellID)
+}
+
+// validateRATs checks the radio access technologies of the nodes agree with the types of their E2 nodes
+func validateRATs(model *Model) error {
+	for name, node := range model.Nodes {
+		switch node.RAT {
+		case "", RatNR, RatEUTRA:
+		default:
+			return errors.NewInvalid("node %s has unknown RAT %s", name, node.RAT)
+		}
+		isEUTRANodeType := node.E2NodeID.Type == enbNodeType || node.E2NodeID.Type == ngEnbNodeType
+		if node.RAT != "" && node.E2NodeID.Type != "" && node.IsEUTRA() != isEUTRANodeType {
+			return errors.NewInvalid("node %s of RAT %s can not be an E2 node of type %s", name, node.RAT, node.E2NodeID.Type)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeRAT(t *testing.T) {
+	gnb := Node{GnbID: 144470}
+	assert.Equal(t, RatNR, gnb.GetRAT())
+	assert.False(t, gnb.IsEUTRA())
+	assert.Equal(t, "gnb", gnb.GetE2NodeType())
+
+	ngEnb := Node{GnbID: 5153, E2NodeID: E2NodeIDConfig{Type: "ng-enb"}}
+	assert.Equal(t, RatEUTRA, ngEnb.GetRAT())
+	assert.Equal(t, "ng-enb", ngEnb.GetE2NodeType())
+
+	enb := Node{GnbID: 5153, RAT: RatEUTRA}
+	assert.True(t, enb.IsEUTRA())
+	assert.Equal(t, "enb", enb.GetE2NodeType())
+	assert.Equal(t, uint32(5153<<8|2), enb.ECI(types.ToNCGI(314628, types.ToNCI(5153, 2))))
+
+	enb.E2NodeID.Length = 28
+	assert.Equal(t, uint32(5153), enb.ECI(types.ToNCGI(314628, types.ToNCI(5153, 2))))
+}
+
+func TestValidateRATs(t *testing.T) {
+	m := &Model{Nodes: map[string]Node{
+		"node1": {RAT: RatEUTRA, E2NodeID: E2NodeIDConfig{Type: "ng-enb"}},
+		"node2": {E2NodeID: E2NodeIDConfig{Type: "enb"}},
+	}}
+	assert.NoError(t, validateRATs(m))
+
+	m.Nodes["node2"] = Node{RAT: RatNR, E2NodeID: E2NodeIDConfig{Type: "enb"}}
+	assert.Error(t, validateRATs(m))
+	m.Nodes["node2"] = Node{RAT: "umts"}
+	assert.Error(t, validateRATs(m))
+}
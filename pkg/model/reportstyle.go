@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Formats of the KPM report styles the indications are encoded in; zero stands for the default format 1
+const (
+	// ReportFormatCell format of the action definitions and indication headers, and of the indication messages
+	// reporting the measurements of a cell
+	ReportFormatCell = 1
+	// ReportFormatUE format of the indication messages reporting the measurements of each UE served by a cell
+	ReportFormatUE = 2
+)
+
+// validate checks the style has a type, and advertises only the formats the indications are encoded in
+func (s ReportStyle) validate() error {
+	if s.Type <= 0 {
+		return errors.NewInvalid("report style %q has invalid type %d", s.Name, s.Type)
+	}
+	if s.ActionFormat != 0 && s.ActionFormat != ReportFormatCell {
+		return errors.NewInvalid("report style %d has unsupported action definition format %d; only format %d is supported",
+			s.Type, s.ActionFormat, ReportFormatCell)
+	}
+	if s.HeaderFormat != 0 && s.HeaderFormat != ReportFormatCell {
+		return errors.NewInvalid("report style %d has unsupported indication header format %d; only format %d is supported",
+			s.Type, s.HeaderFormat, ReportFormatCell)
+	}
+	if s.MessageFormat != 0 && s.MessageFormat != ReportFormatCell && s.MessageFormat != ReportFormatUE {
+		return errors.NewInvalid("report style %d has unsupported indication message format %d; only formats %d and %d are supported",
+			s.Type, s.MessageFormat, ReportFormatCell, ReportFormatUE)
+	}
+	return nil
+}
+
+// validateReportStyles checks the report styles of the service models and of the nodes; the types of the styles of a
+// list must be unique, since the subscriptions refer to the styles by type
+func (m *Model) validateReportStyles() error {
+	for name, sm := range m.ServiceModels {
+		if err := validateReportStyles(sm.KPM.ReportStyles); err != nil {
+			return errors.NewInvalid("service model %s: %v", name, err)
+		}
+	}
+	for name, node := range m.Nodes {
+		if err := validateReportStyles(node.ReportStyles); err != nil {
+			return errors.NewInvalid("node %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func validateReportStyles(styles []ReportStyle) error {
+	types := make(map[int32]bool, len(styles))
+	for _, style := range styles {
+		if err := style.validate(); err != nil {
+			return err
+		}
+		if types[style.Type] {
+			return errors.NewInvalid("report style %d is defined more than once", style.Type)
+		}
+		types[style.Type] = true
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateReportStyles(t *testing.T) {
+	m := &Model{
+		ServiceModels: map[string]ServiceModel{
+			"kpm2": {KPM: KPMConfig{ReportStyles: []ReportStyle{{Type: 1, Name: "Periodic Report"}}}},
+		},
+		Nodes: map[string]Node{
+			"node1": {ReportStyles: []ReportStyle{
+				{Type: 1, Name: "Periodic Report", ActionFormat: 1, HeaderFormat: 1, MessageFormat: 1},
+				{Type: 2, Name: "UE-level Periodic Report", MessageFormat: ReportFormatUE},
+			}},
+		},
+	}
+	assert.NoError(t, m.validateReportStyles())
+
+	// Formats the indications are not encoded in are rejected
+	m.Nodes["node1"] = Node{ReportStyles: []ReportStyle{{Type: 2, Name: "O-CU-UP Measurement Report", ActionFormat: 2}}}
+	assert.Error(t, m.validateReportStyles())
+	m.Nodes["node1"] = Node{ReportStyles: []ReportStyle{{Type: 1, Name: "Periodic Report", HeaderFormat: 2}}}
+	assert.Error(t, m.validateReportStyles())
+	m.Nodes["node1"] = Node{ReportStyles: []ReportStyle{{Type: 1, Name: "Periodic Report", MessageFormat: 3}}}
+	assert.Error(t, m.validateReportStyles())
+
+	// Styles must have a type, unique within their list
+	m.Nodes["node1"] = Node{ReportStyles: []ReportStyle{{Name: "Periodic Report"}}}
+	assert.Error(t, m.validateReportStyles())
+	m.Nodes["node1"] = Node{}
+	m.ServiceModels["kpm2"] = ServiceModel{KPM: KPMConfig{ReportStyles: []ReportStyle{{Type: 1}, {Type: 1}}}}
+	assert.Error(t, m.validateReportStyles())
+}
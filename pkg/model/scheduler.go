@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "github.com/onosproject/onos-lib-go/pkg/errors"
+
+// Scheduling policies distributing the PRBs of a cell across its users
+const (
+	SchedulerRoundRobin       = "rr"     // equal shares
+	SchedulerProportionalFair = "pf"     // shares proportional to the rate of the users relative to their average rate
+	SchedulerMaxCQI           = "maxcqi" // all PRBs to the users with the highest CQI
+)
+
+const defaultSchedulerAveraging = 0.1
+
+// SchedulerConfig scheduling of the downlink PRBs of the cells
+type SchedulerConfig struct {
+	Policy    string  `mapstructure:"policy" yaml:"policy"`       // rr, pf or maxcqi; rr by default
+	Averaging float64 `mapstructure:"averaging" yaml:"averaging"` // weight of the latest rate in the average rates of the pf policy; 0.1 by default
+}
+
+// Validate checks the scheduling policy is known
+func (c SchedulerConfig) Validate() error {
+	switch c.Policy {
+	case "", SchedulerRoundRobin, SchedulerProportionalFair, SchedulerMaxCQI:
+	default:
+		return errors.NewInvalid("unknown scheduling policy %s", c.Policy)
+	}
+	if c.Averaging < 0 || c.Averaging > 1 {
+		return errors.NewInvalid("scheduler averaging must be between 0 and 1")
+	}
+	return nil
+}
+
+// GetPolicy returns the scheduling policy
+func (c SchedulerConfig) GetPolicy() string {
+	if c.Policy != "" {
+		return c.Policy
+	}
+	return SchedulerRoundRobin
+}
+
+// GetAveraging returns the weight of the latest rate in the average rates of the proportional fair policy
+func (c SchedulerConfig) GetAveraging() float64 {
+	if c.Averaging > 0 {
+		return c.Averaging
+	}
+	return defaultSchedulerAveraging
+}
+
+// Share returns the fraction of the PRBs of a cell allocated to a user given its scheduling metric and the metrics of
+// the other users of the cell: its CQI for the max-CQI policy and its rate over its average rate for the proportional
+// fair policy. The PRBs are shared equally if no user has a positive metric.
+func (c SchedulerConfig) Share(metric float64, others []float64) float64 {
+	switch c.GetPolicy() {
+	case SchedulerMaxCQI:
+		count := 1
+		for _, other := range others {
+			if other > metric {
+				return 0
+			}
+			if other == metric {
+				count++
+			}
+		}
+		return 1 / float64(count)
+	case SchedulerProportionalFair:
+		total := metric
+		for _, other := range others {
+			total += other
+		}
+		if total > 0 {
+			return metric / total
+		}
+	}
+	return 1 / float64(len(others)+1)
+}
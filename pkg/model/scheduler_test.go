@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedulerConfig(t *testing.T) {
+	assert.NoError(t, SchedulerConfig{}.Validate())
+	assert.NoError(t, SchedulerConfig{Policy: SchedulerProportionalFair, Averaging: 0.5}.Validate())
+	assert.Error(t, SchedulerConfig{Policy: "edf"}.Validate())
+	assert.Error(t, SchedulerConfig{Averaging: 2}.Validate())
+
+	assert.Equal(t, SchedulerRoundRobin, SchedulerConfig{}.GetPolicy())
+	assert.Equal(t, 0.1, SchedulerConfig{}.GetAveraging())
+}
+
+func TestShare(t *testing.T) {
+	rr := SchedulerConfig{}
+	assert.Equal(t, 1.0, rr.Share(7, nil))
+	assert.Equal(t, 0.25, rr.Share(7, []float64{15, 3, 0}))
+
+	maxCQI := SchedulerConfig{Policy: SchedulerMaxCQI}
+	assert.Equal(t, 0.0, maxCQI.Share(7, []float64{15, 3}))
+	assert.Equal(t, 1.0, maxCQI.Share(15, []float64{7, 3}))
+	assert.Equal(t, 0.5, maxCQI.Share(15, []float64{15, 3}))
+
+	pf := SchedulerConfig{Policy: SchedulerProportionalFair}
+	assert.Equal(t, 0.5, pf.Share(1, []float64{1}))
+	assert.InDelta(t, 0.6, pf.Share(1.5, []float64{1}), 1e-9)
+	assert.Equal(t, 0.5, pf.Share(0, []float64{0}))
+}
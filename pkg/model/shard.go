@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const defaultSyncInterval = 10 * time.Second
+
+// ShardingConfig partitioning of the nodes of a shared model across several simulator instances
+type ShardingConfig struct {
+	Shards       uint          `mapstructure:"shards" yaml:"shards"`             // number of simulator instances; sharding is disabled below two
+	Label        string        `mapstructure:"label" yaml:"label"`               // node label whose value selects the shard; the GnbID is used if not set
+	Coordinator  string        `mapstructure:"coordinator" yaml:"coordinator"`   // host:port of the REST gateway of shard 0, which the other shards synchronize with
+	SyncInterval time.Duration `mapstructure:"syncInterval" yaml:"syncInterval"` // interval between synchronizations with shard 0; 10s by default
+	Peers        string        `mapstructure:"peers" yaml:"peers"`               // address of the transfer server of the other shards, with %d standing for the shard; UEs are handed over across shards if set
+}
+
+// RemoteCell cell owned by another shard, to which the UEs of this shard may be handed over
+type RemoteCell struct {
+	Cell
+	Shard uint
+}
+
+// IsEnabled returns true if the nodes are partitioned across several simulator instances
+func (c ShardingConfig) IsEnabled() bool {
+	return c.Shards > 1
+}
+
+// IsCoordinated returns true if the simulator instances synchronize their clocks and scenarios with shard 0
+func (c ShardingConfig) IsCoordinated() bool {
+	return c.IsEnabled() && c.Coordinator != ""
+}
+
+// IsPeered returns true if the UEs are handed over to the cells of the other shards
+func (c ShardingConfig) IsPeered() bool {
+	return c.IsEnabled() && c.Peers != ""
+}
+
+// PeerAddress returns the gRPC address of the given shard
+func (c ShardingConfig) PeerAddress(shard uint) string {
+	return fmt.Sprintf(c.Peers, shard)
+}
+
+// GetSyncInterval returns the interval between the synchronizations with shard 0
+func (c ShardingConfig) GetSyncInterval() time.Duration {
+	if c.SyncInterval > 0 {
+		return c.SyncInterval
+	}
+	return defaultSyncInterval
+}
+
+// ShardOf returns the shard owning the given node; nodes are assigned by hashing the value of the sharding label,
+// or the GnbID if the node does not have the label, so that nodes sharing a label value are owned by the same shard
+func (c ShardingConfig) ShardOf(node Node) uint {
+	if !c.IsEnabled() {
+		return 0
+	}
+	key, ok := node.Labels[c.Label]
+	if c.Label == "" || !ok {
+		key = strconv.FormatUint(uint64(node.GnbID), 10)
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return uint(h.Sum32()) % c.Shards
+}
+
+// Partition removes the nodes not owned by the given shard from the model, along with their cells, and scales the
+// number of UEs down to the share of the shard; the neighbor relations to the cells of other shards are retained. If
+// the shards are peered, the cells of the other shards are kept as remote cells.
+func (m *Model) Partition(shard uint) error {
+	if !m.Sharding.IsEnabled() {
+		return nil
+	}
+	if shard >= m.Sharding.Shards {
+		return errors.NewInvalid("shard %d is out of range; the model has %d shards", shard, m.Sharding.Shards)
+	}
+
+	cellShards := make(map[types.NCGI]uint)
+	for name, node := range m.Nodes {
+		nodeShard := m.Sharding.ShardOf(node)
+		for _, ncgi := range node.Cells {
+			cellShards[ncgi] = nodeShard
+		}
+		if nodeShard != shard {
+			delete(m.Nodes, name)
+		}
+	}
+	m.RemoteCells = nil
+	for name, cell := range m.Cells {
+		cellShard, ok := cellShards[cell.NCGI]
+		if ok && cellShard == shard {
+			continue
+		}
+		delete(m.Cells, name)
+		if ok && m.Sharding.IsPeered() {
+			m.RemoteCells = append(m.RemoteCells, RemoteCell{Cell: cell, Shard: cellShard})
+		}
+	}
+
+	// The remainder of the UEs is spread over the first shards
+	ueCount := m.UECount / m.Sharding.Shards
+	if shard < m.UECount%m.Sharding.Shards {
+		ueCount++
+	}
+	m.UECount = ueCount
+	return nil
+}
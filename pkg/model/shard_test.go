@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func shardedModel(sharding ShardingConfig) *Model {
+	m := &Model{
+		Nodes:    make(map[string]Node),
+		Cells:    make(map[string]Cell),
+		UECount:  101,
+		Sharding: sharding,
+	}
+	for i := 1; i <= 20; i++ {
+		ncgi := types.NCGI(i)
+		region := "east"
+		if i%2 == 0 {
+			region = "west"
+		}
+		m.Nodes[string(rune('a'+i))] = Node{GnbID: types.GnbID(i), Cells: []types.NCGI{ncgi}, Labels: map[string]string{"region": region}}
+		m.Cells[string(rune('a'+i))] = Cell{NCGI: ncgi}
+	}
+	return m
+}
+
+func TestPartition(t *testing.T) {
+	sharding := ShardingConfig{Shards: 3}
+	nodes, cells, ues := 0, 0, uint(0)
+	owner := make(map[types.GnbID]uint)
+	for shard := uint(0); shard < sharding.Shards; shard++ {
+		m := shardedModel(sharding)
+		assert.NoError(t, m.Partition(shard))
+		for _, node := range m.Nodes {
+			owner[node.GnbID] = shard
+			for _, ncgi := range node.Cells {
+				found := false
+				for _, cell := range m.Cells {
+					found = found || cell.NCGI == ncgi
+				}
+				assert.True(t, found, "cell of an owned node is missing")
+			}
+		}
+		nodes += len(m.Nodes)
+		cells += len(m.Cells)
+		ues += m.UECount
+	}
+	// Each node, cell and UE is owned by exactly one shard
+	assert.Equal(t, 20, nodes)
+	assert.Equal(t, 20, len(owner))
+	assert.Equal(t, 20, cells)
+	assert.Equal(t, uint(101), ues)
+
+	assert.Error(t, shardedModel(sharding).Partition(3))
+	m := shardedModel(ShardingConfig{})
+	assert.NoError(t, m.Partition(0))
+	assert.Equal(t, 20, len(m.Nodes))
+}
+
+func TestShardOfLabel(t *testing.T) {
+	sharding := ShardingConfig{Shards: 4, Label: "region"}
+	m := shardedModel(sharding)
+	for _, node := range m.Nodes {
+		peer := m.Nodes[string(rune('a'+2-int(node.GnbID)%2))]
+		assert.Equal(t, sharding.ShardOf(peer), sharding.ShardOf(node))
+	}
+}
+
+func TestPartitionRemoteCells(t *testing.T) {
+	sharding := ShardingConfig{Shards: 3, Peers: "ran-simulator-%d.ran-simulator:5150"}
+	assert.True(t, sharding.IsPeered())
+	assert.Equal(t, "ran-simulator-2.ran-simulator:5150", sharding.PeerAddress(2))
+	for shard := uint(0); shard < sharding.Shards; shard++ {
+		m := shardedModel(sharding)
+		assert.NoError(t, m.Partition(shard))
+		// Every cell is either owned or remote, and remote cells are owned by other shards
+		assert.Equal(t, 20, len(m.Cells)+len(m.RemoteCells))
+		for _, remote := range m.RemoteCells {
+			assert.NotEqual(t, shard, remote.Shard)
+			for _, cell := range m.Cells {
+				assert.NotEqual(t, cell.NCGI, remote.NCGI)
+			}
+		}
+	}
+
+	m := shardedModel(ShardingConfig{Shards: 3})
+	assert.NoError(t, m.Partition(0))
+	assert.Empty(t, m.RemoteCells)
+}
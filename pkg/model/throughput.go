@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "math"
+
+// Defaults of the truncated Shannon mapping
+const (
+	defaultAttenuation                 = 1.0
+	defaultMinSinr                     = -10.0
+	defaultMaxSpectralEfficiency       = 7.4063 // highest CQI of the 256QAM CQI table
+	defaultMaxUplinkSpectralEfficiency = 5.5547 // highest CQI of the 64QAM CQI table
+)
+
+// ThroughputConfig truncated Shannon mapping of the SINR of the UEs to their spectral efficiency, 3GPP TR 36.942
+// annex A.2
+type ThroughputConfig struct {
+	Attenuation                 float64  `mapstructure:"attenuation" yaml:"attenuation"`                                 // fraction of the Shannon capacity achieved; 1 by default
+	MinSinr                     *float64 `mapstructure:"minSinr" yaml:"minSinr"`                                         // SINR in dB below which nothing gets through; -10 dB by default
+	MaxSpectralEfficiency       float64  `mapstructure:"maxSpectralEfficiency" yaml:"maxSpectralEfficiency"`             // downlink cap in bps/Hz; 7.4063 by default
+	MaxUplinkSpectralEfficiency float64  `mapstructure:"maxUplinkSpectralEfficiency" yaml:"maxUplinkSpectralEfficiency"` // uplink cap in bps/Hz; 5.5547 by default
+}
+
+// GetAttenuation returns the fraction of the Shannon capacity achieved
+func (c ThroughputConfig) GetAttenuation() float64 {
+	if c.Attenuation > 0 {
+		return c.Attenuation
+	}
+	return defaultAttenuation
+}
+
+// GetMinSinr returns the SINR in dB below which nothing gets through
+func (c ThroughputConfig) GetMinSinr() float64 {
+	if c.MinSinr != nil {
+		return *c.MinSinr
+	}
+	return defaultMinSinr
+}
+
+// GetMaxSpectralEfficiency returns the downlink spectral efficiency cap in bps/Hz
+func (c ThroughputConfig) GetMaxSpectralEfficiency() float64 {
+	if c.MaxSpectralEfficiency > 0 {
+		return c.MaxSpectralEfficiency
+	}
+	return defaultMaxSpectralEfficiency
+}
+
+// GetMaxUplinkSpectralEfficiency returns the uplink spectral efficiency cap in bps/Hz
+func (c ThroughputConfig) GetMaxUplinkSpectralEfficiency() float64 {
+	if c.MaxUplinkSpectralEfficiency > 0 {
+		return c.MaxUplinkSpectralEfficiency
+	}
+	return defaultMaxUplinkSpectralEfficiency
+}
+
+// SpectralEfficiency returns the downlink spectral efficiency in bps/Hz achieved at the given SINR in dB
+func (c ThroughputConfig) SpectralEfficiency(sinr float64) float64 {
+	return c.spectralEfficiency(sinr, c.GetMaxSpectralEfficiency())
+}
+
+// UplinkSpectralEfficiency returns the uplink spectral efficiency in bps/Hz achieved at the given SINR in dB
+func (c ThroughputConfig) UplinkSpectralEfficiency(sinr float64) float64 {
+	return c.spectralEfficiency(sinr, c.GetMaxUplinkSpectralEfficiency())
+}
+
+func (c ThroughputConfig) spectralEfficiency(sinr float64, max float64) float64 {
+	if sinr < c.GetMinSinr() {
+		return 0
+	}
+	return math.Min(c.GetAttenuation()*math.Log2(1+math.Pow(10, sinr/10)), max)
+}
+
+// Throughput returns the throughput in kbps of the given PRBs at the given spectral efficiency in bps/Hz
+func Throughput(prbs float64, spectralEfficiency float64) float64 {
+	return prbs * PRBBandwidth * spectralEfficiency
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThroughputConfig(t *testing.T) {
+	c := ThroughputConfig{}
+	assert.Equal(t, 1.0, c.GetAttenuation())
+	assert.Equal(t, -10.0, c.GetMinSinr())
+	assert.Equal(t, 7.4063, c.GetMaxSpectralEfficiency())
+	assert.Equal(t, 5.5547, c.GetMaxUplinkSpectralEfficiency())
+	minSinr := 0.0
+	assert.Equal(t, 0.0, ThroughputConfig{MinSinr: &minSinr}.GetMinSinr())
+}
+
+func TestSpectralEfficiency(t *testing.T) {
+	c := ThroughputConfig{}
+	assert.Equal(t, 0.0, c.SpectralEfficiency(-11))
+	assert.InDelta(t, 1.0, c.SpectralEfficiency(0), 1e-9)
+	assert.Equal(t, 7.4063, c.SpectralEfficiency(40))
+	assert.Equal(t, 5.5547, c.UplinkSpectralEfficiency(40))
+
+	c.Attenuation = 0.6
+	assert.InDelta(t, 0.6, c.SpectralEfficiency(0), 1e-9)
+
+	assert.Equal(t, 3600.0, Throughput(10, 1))
+}
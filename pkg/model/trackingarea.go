@@ -0,0 +1,35 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// maxTAC largest 24 bit 5GS tracking area code
+const maxTAC = 1<<24 - 1
+
+// TrackingArea returns the tracking area code of the cell; the cells without a tracking area code form a tracking
+// area per node, coded by the least significant 24 bits of the gNB ID of the node
+func (c Cell) TrackingArea() uint32 {
+	if c.TAC != 0 {
+		return c.TAC
+	}
+	if tac := uint32(types.GetGnbID(uint64(c.NCGI))) & maxTAC; tac != 0 {
+		return tac
+	}
+	return maxTAC
+}
+
+// validateTrackingAreas checks the tracking area codes of the cells fit in 24 bits
+func (m *Model) validateTrackingAreas() error {
+	for name, cell := range m.Cells {
+		if cell.TAC > maxTAC {
+			return errors.NewInvalid("cell %s has invalid tracking area code %d; the maximum is %d", name, cell.TAC, maxTAC)
+		}
+	}
+	return nil
+}
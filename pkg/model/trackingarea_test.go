@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackingArea(t *testing.T) {
+	ncgi := types.ToNCGI(314628, types.ToNCI(144470, 1))
+	assert.Equal(t, uint32(144470), Cell{NCGI: ncgi}.TrackingArea())
+	assert.Equal(t, uint32(7), Cell{NCGI: ncgi, TAC: 7}.TrackingArea())
+
+	m := &Model{Cells: map[string]Cell{"cell1": {NCGI: ncgi, TAC: 7}}}
+	assert.NoError(t, m.validateTrackingAreas())
+	m.Cells["cell1"] = Cell{NCGI: ncgi, TAC: 1 << 24}
+	assert.Error(t, m.validateTrackingAreas())
+}
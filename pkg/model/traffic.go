@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// TrafficCurve load factors, from 0 to 1, at evenly spaced times of the day starting at midnight, e.g. 24 hourly
+// values; the load factor between two of them is interpolated linearly
+type TrafficCurve []float64
+
+// Validate checks the load factors are between 0 and 1
+func (c TrafficCurve) Validate() error {
+	for _, factor := range c {
+		if factor < 0 || factor > 1 || math.IsNaN(factor) {
+			return errors.NewInvalid("traffic load factors must be between 0 and 1")
+		}
+	}
+	return nil
+}
+
+// Factor returns the load factor at the given time of the day; 1 if the curve is empty
+func (c TrafficCurve) Factor(sinceMidnight time.Duration) float64 {
+	if len(c) == 0 {
+		return 1
+	}
+	position := sinceMidnight.Hours() / 24 * float64(len(c))
+	i := int(math.Floor(position)) % len(c)
+	fraction := position - math.Floor(position)
+	return c[i] + (c[(i+1)%len(c)]-c[i])*fraction
+}
+
+// TrafficProfileConfig diurnal load profile modulating the number of connected UEs and the PRBs they use over the
+// simulated time of the day; the curve of a cell overrides the curve of the region its sector center lies in, which
+// overrides the curve of the model
+type TrafficProfileConfig struct {
+	Curve    TrafficCurve `mapstructure:"curve" yaml:"curve"`       // load factors over the day; the load is constant if empty
+	TimeZone string       `mapstructure:"timeZone" yaml:"timeZone"` // IANA time zone of the curves, e.g. Europe/Rome; UTC by default
+}
+
+// Validate checks the load factors and the time zone
+func (c TrafficProfileConfig) Validate() error {
+	if err := c.Curve.Validate(); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(c.TimeZone); err != nil {
+		return errors.NewInvalid("unknown time zone %s", c.TimeZone)
+	}
+	return nil
+}
+
+// GetLocation returns the time zone of the curves
+func (c TrafficProfileConfig) GetLocation() *time.Location {
+	location, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// SinceMidnight returns the time elapsed since the midnight preceding the given time, in its time zone
+func SinceMidnight(t time.Time) time.Duration {
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return t.Sub(midnight)
+}
+
+// validateTrafficProfiles checks the traffic profile of the model and the curves of its regions and cells
+func (m *Model) validateTrafficProfiles() error {
+	if err := m.TrafficProfile.Validate(); err != nil {
+		return err
+	}
+	for _, region := range m.Regions {
+		if err := region.TrafficProfile.Validate(); err != nil {
+			return errors.NewInvalid("region %s: %s", region.Name, err.Error())
+		}
+	}
+	for name, cell := range m.Cells {
+		if err := cell.TrafficProfile.Validate(); err != nil {
+			return errors.NewInvalid("cell %s: %s", name, err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrafficCurve(t *testing.T) {
+	assert.Equal(t, 1.0, TrafficCurve{}.Factor(3*time.Hour))
+
+	// Four values spaced six hours apart
+	curve := TrafficCurve{0.2, 0.6, 1, 0.4}
+	assert.NoError(t, curve.Validate())
+	assert.InDelta(t, 0.2, curve.Factor(0), 1e-9)
+	assert.InDelta(t, 0.4, curve.Factor(3*time.Hour), 1e-9)
+	assert.InDelta(t, 1, curve.Factor(12*time.Hour), 1e-9)
+	// The curve wraps around midnight
+	assert.InDelta(t, 0.3, curve.Factor(21*time.Hour), 1e-9)
+
+	assert.Error(t, TrafficCurve{0.5, 1.5}.Validate())
+	assert.Error(t, TrafficCurve{-0.1}.Validate())
+}
+
+func TestTrafficProfileConfig(t *testing.T) {
+	assert.NoError(t, TrafficProfileConfig{}.Validate())
+	assert.Error(t, TrafficProfileConfig{TimeZone: "Mars/Olympus_Mons"}.Validate())
+	assert.Equal(t, time.UTC, TrafficProfileConfig{}.GetLocation())
+
+	at := time.Date(2021, 6, 1, 13, 30, 0, 0, time.UTC)
+	assert.Equal(t, 13*time.Hour+30*time.Minute, SinceMidnight(at))
+}
@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import "math"
+
+// Defaults of the uplink power control
+const (
+	defaultMaxTxPower = 23.0 // power class 3
+	defaultP0         = -90.0
+	defaultAlpha      = 0.8
+	defaultUplinkPRBs = 10
+)
+
+// subcarriersPerRB number of subcarriers of a resource block
+const subcarriersPerRB = 12
+
+// UplinkConfig open loop power control of the uplink transmissions of the UEs, 3GPP TS 38.213 clause 7.1
+type UplinkConfig struct {
+	MaxTxPower float64 `mapstructure:"maxTxPower" yaml:"maxTxPower"` // maximum transmission power of the UEs in dBm; 23 dBm by default
+	P0         float64 `mapstructure:"p0" yaml:"p0"`                 // target received power in dBm per PRB; -90 dBm by default
+	Alpha      float64 `mapstructure:"alpha" yaml:"alpha"`           // fraction of the path loss compensated by the UEs; 0.8 by default
+	PRBs       uint32  `mapstructure:"prbs" yaml:"prbs"`             // uplink PRBs allocated to each UE; 10 by default
+}
+
+// Uplink uplink quantities of a UE towards its serving cell
+type Uplink struct {
+	TxPower       float64 // transmission power in dBm
+	PowerHeadroom float64 // power headroom in dB; negative if the UE is power limited
+	Sinr          float64 // SINR in dB received by the serving cell
+	Throughput    float64 // uplink throughput in kbps
+}
+
+// GetMaxTxPower returns the maximum transmission power of the UEs in dBm
+func (c UplinkConfig) GetMaxTxPower() float64 {
+	if c.MaxTxPower != 0 {
+		return c.MaxTxPower
+	}
+	return defaultMaxTxPower
+}
+
+// GetP0 returns the target received power in dBm per PRB
+func (c UplinkConfig) GetP0() float64 {
+	if c.P0 != 0 {
+		return c.P0
+	}
+	return defaultP0
+}
+
+// GetAlpha returns the fraction of the path loss compensated by the UEs
+func (c UplinkConfig) GetAlpha() float64 {
+	if c.Alpha > 0 {
+		return math.Min(c.Alpha, 1)
+	}
+	return defaultAlpha
+}
+
+// GetPRBs returns the number of uplink PRBs allocated to each UE
+func (c UplinkConfig) GetPRBs() uint32 {
+	if c.PRBs > 0 {
+		return c.PRBs
+	}
+	return defaultUplinkPRBs
+}
+
+// Uplink returns the uplink quantities of a UE given the path loss to its serving cell and the noise, in dBm per
+// resource element, received by the cell
+func (c UplinkConfig) Uplink(pathLoss float64, noise float64) Uplink {
+	prbs := 10 * math.Log10(float64(c.GetPRBs()))
+	power := c.GetP0() + prbs + c.GetAlpha()*pathLoss
+	txPower := math.Min(c.GetMaxTxPower(), power)
+	received := txPower - prbs - 10*math.Log10(subcarriersPerRB) - pathLoss
+	return Uplink{
+		TxPower:       txPower,
+		PowerHeadroom: c.GetMaxTxPower() - power,
+		Sinr:          received - noise,
+	}
+}
@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUplinkConfig(t *testing.T) {
+	c := UplinkConfig{}
+	assert.Equal(t, 23.0, c.GetMaxTxPower())
+	assert.Equal(t, -90.0, c.GetP0())
+	assert.Equal(t, 0.8, c.GetAlpha())
+	assert.Equal(t, uint32(10), c.GetPRBs())
+	assert.Equal(t, 1.0, UplinkConfig{Alpha: 2}.GetAlpha())
+}
+
+func TestUplink(t *testing.T) {
+	c := UplinkConfig{P0: -100, Alpha: 1, PRBs: 1}
+
+	// Full path loss compensation: the cell receives the target power
+	near := c.Uplink(80, -122.2)
+	assert.InDelta(t, -20.0, near.TxPower, 1e-9)
+	assert.InDelta(t, 43.0, near.PowerHeadroom, 1e-9)
+	assert.InDelta(t, 22.2-10.79, near.Sinr, 0.01)
+
+	// Beyond the maximum power, the headroom is negative and the SINR drops
+	far := c.Uplink(130, -122.2)
+	assert.Equal(t, 23.0, far.TxPower)
+	assert.InDelta(t, -7.0, far.PowerHeadroom, 1e-9)
+	assert.True(t, far.Sinr < near.Sinr)
+}
@@ -0,0 +1,194 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/watcher"
+)
+
+var log = logging.GetLogger("monitor")
+
+// defaultInterval default sampling period of the monitor
+const defaultInterval = 10 * time.Second
+
+// SubscriptionsMetric name of the node metric holding the number of running subscription routines of the node
+const SubscriptionsMetric = "monitor.subscriptions"
+
+// Probe returns the size of a resource of the simulation, e.g. the number of entries of a store
+type Probe func(ctx context.Context) int
+
+// NodeProbe returns the number of running subscription routines of each node
+type NodeProbe func() map[types.GnbID]int
+
+// Snapshot resource usage of the simulation sampled by the monitor
+type Snapshot struct {
+	Time          time.Time           `json:"time"`
+	Goroutines    int                 `json:"goroutines"`         // goroutines of the whole process
+	Tickers       int64               `json:"tickers"`            // active reporting tickers
+	PendingEvents int64               `json:"pendingEvents"`      // store events not yet delivered to their watchers
+	Stores        map[string]int      `json:"stores"`             // number of entries of each store
+	Subscriptions map[types.GnbID]int `json:"subscriptions"`      // running subscription routines of each node
+	Budgets       model.MonitorConfig `json:"budgets"`            // budgets the sample is checked against
+	Warnings      []string            `json:"warnings,omitempty"` // budgets exceeded at the time of the sample
+}
+
+// Monitor periodically samples the goroutines, tickers, pending store events, store sizes and per-node subscription
+// routines of the simulation, publishes the per-node counts as node metrics and warns when the budgets of the model
+// are exceeded, so that resource exhaustion during scale tests does not go unnoticed
+type Monitor struct {
+	mu          sync.RWMutex
+	config      model.MonitorConfig
+	metricStore metrics.Store
+	probes      map[string]Probe
+	nodeProbe   NodeProbe
+	last        *Snapshot
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// NewMonitor creates a monitor checking the given budgets and publishing the node metrics to the given store
+func NewMonitor(config model.MonitorConfig, metricStore metrics.Store) *Monitor {
+	return &Monitor{
+		config:      config,
+		metricStore: metricStore,
+		probes:      make(map[string]Probe),
+	}
+}
+
+// Reset makes the monitor check the given budgets, e.g. those of a newly loaded model
+func (m *Monitor) Reset(config model.MonitorConfig) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.config = config
+}
+
+// Register adds a probe sampling the size of the named store
+func (m *Monitor) Register(name string, probe Probe) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.probes[name] = probe
+}
+
+// RegisterNodes sets the probe sampling the running subscription routines of the nodes
+func (m *Monitor) RegisterNodes(probe NodeProbe) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodeProbe = probe
+}
+
+// Start samples the resources periodically until the monitor is stopped
+func (m *Monitor) Start() {
+	if m.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done = make(chan struct{})
+	go m.run(ctx)
+}
+
+// Stop stops sampling the resources
+func (m *Monitor) Stop() {
+	if m.cancel == nil {
+		return
+	}
+	m.cancel()
+	<-m.done
+	m.cancel = nil
+}
+
+func (m *Monitor) run(ctx context.Context) {
+	defer close(m.done)
+	m.mu.RLock()
+	interval := m.config.Interval
+	m.mu.RUnlock()
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.Sample(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Last returns the latest sample, or a new sample if none has been taken yet
+func (m *Monitor) Last(ctx context.Context) *Snapshot {
+	m.mu.RLock()
+	last := m.last
+	m.mu.RUnlock()
+	if last != nil {
+		return last
+	}
+	return m.Sample(ctx)
+}
+
+// Sample samples the resources of the simulation, checks them against the budgets and publishes the running
+// subscription routines of each node as node metrics
+func (m *Monitor) Sample(ctx context.Context) *Snapshot {
+	m.mu.RLock()
+	config := m.config
+	probes := make(map[string]Probe, len(m.probes))
+	for name, probe := range m.probes {
+		probes[name] = probe
+	}
+	nodeProbe := m.nodeProbe
+	m.mu.RUnlock()
+
+	snapshot := &Snapshot{
+		Time:          time.Now(),
+		Goroutines:    runtime.NumGoroutine(),
+		Tickers:       Tickers(),
+		PendingEvents: watcher.Pending(),
+		Stores:        make(map[string]int, len(probes)),
+		Subscriptions: make(map[types.GnbID]int),
+		Budgets:       config,
+	}
+	for name, probe := range probes {
+		snapshot.Stores[name] = probe(ctx)
+	}
+	if nodeProbe != nil {
+		snapshot.Subscriptions = nodeProbe()
+	}
+
+	if config.GoroutineBudget > 0 && snapshot.Goroutines > config.GoroutineBudget {
+		snapshot.Warnings = append(snapshot.Warnings, fmt.Sprintf("%d goroutines exceed the budget of %d",
+			snapshot.Goroutines, config.GoroutineBudget))
+	}
+	for gnbID, running := range snapshot.Subscriptions {
+		if config.ExceedsSubscriptionBudget(running) {
+			snapshot.Warnings = append(snapshot.Warnings, fmt.Sprintf("%d subscription routines of node %d exceed the budget of %d",
+				running, gnbID, config.SubscriptionBudget))
+		}
+		if m.metricStore != nil {
+			if err := m.metricStore.Set(ctx, uint64(gnbID), SubscriptionsMetric, running); err != nil {
+				log.Warn(err)
+			}
+		}
+	}
+	for _, warning := range snapshot.Warnings {
+		log.Warn(warning)
+	}
+
+	m.mu.Lock()
+	m.last = snapshot
+	m.mu.Unlock()
+	return snapshot
+}
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSample(t *testing.T) {
+	ctx := context.Background()
+	metricStore := metrics.NewMetricsStore()
+	monitor := NewMonitor(model.MonitorConfig{SubscriptionBudget: 2}, metricStore)
+	monitor.Register("ues", func(ctx context.Context) int {
+		return 42
+	})
+	monitor.RegisterNodes(func() map[types.GnbID]int {
+		return map[types.GnbID]int{144470: 1, 144471: 3}
+	})
+
+	snapshot := monitor.Sample(ctx)
+	assert.True(t, snapshot.Goroutines > 0)
+	assert.Equal(t, 42, snapshot.Stores["ues"])
+	assert.Equal(t, 3, snapshot.Subscriptions[144471])
+	assert.Len(t, snapshot.Warnings, 1)
+	assert.Same(t, snapshot, monitor.Last(ctx))
+
+	value, ok := metricStore.Get(ctx, 144471, SubscriptionsMetric)
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+
+	// Budgets are not checked unless set
+	monitor.Reset(model.MonitorConfig{})
+	assert.Empty(t, monitor.Sample(ctx).Warnings)
+	monitor.Reset(model.MonitorConfig{GoroutineBudget: 1})
+	assert.Len(t, monitor.Sample(ctx).Warnings, 1)
+}
+
+func TestTickers(t *testing.T) {
+	count := Tickers()
+	ticker := NewTicker(time.Hour)
+	assert.Equal(t, count+1, Tickers())
+	ticker.Stop()
+	ticker.Stop()
+	assert.Equal(t, count, Tickers())
+}
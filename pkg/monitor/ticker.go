@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package monitor
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tickers number of tickers created by NewTicker and not stopped yet
+var tickers int64
+
+// Ticker is a time.Ticker accounted for by the monitor until it is stopped
+type Ticker struct {
+	*time.Ticker
+	once sync.Once
+}
+
+// NewTicker creates a ticker with the given period, like time.NewTicker, and counts it as active until it is stopped
+func NewTicker(d time.Duration) *Ticker {
+	atomic.AddInt64(&tickers, 1)
+	return &Ticker{
+		Ticker: time.NewTicker(d),
+	}
+}
+
+// Stop stops the ticker; it may be called more than once
+func (t *Ticker) Stop() {
+	t.Ticker.Stop()
+	t.once.Do(func() {
+		atomic.AddInt64(&tickers, -1)
+	})
+}
+
+// Tickers returns the number of active tickers
+func Tickers() int64 {
+	return atomic.LoadInt64(&tickers)
+}
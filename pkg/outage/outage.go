@@ -0,0 +1,167 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package outage
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+)
+
+var log = logging.GetLogger("outage")
+
+// Status outage of a failed cell
+type Status struct {
+	NCGI     types.NCGI `json:"ncgi"`
+	Since    time.Time  `json:"since"`
+	Recovery *time.Time `json:"recovery,omitempty"` // the cell does not recover on its own if not set
+}
+
+// Scheduler puts cells out of service and back in service, either on request or as scheduled by the outages of the
+// model, so that self-healing and coverage hole detection applications can be exercised
+type Scheduler struct {
+	mu        sync.Mutex
+	cellStore cells.Store
+	outages   []model.Outage
+	failed    map[types.NCGI]*Status
+	timers    []*time.Timer
+}
+
+// NewScheduler creates a scheduler of the given outages; the outages are scheduled once the scheduler is started
+func NewScheduler(cellStore cells.Store, outages []model.Outage) *Scheduler {
+	return &Scheduler{
+		cellStore: cellStore,
+		outages:   outages,
+		failed:    make(map[types.NCGI]*Status),
+	}
+}
+
+// Start schedules the outages of the model relative to the given start of the scenario on the simulation clock, so
+// that instances sharing the start of the scenario put their cells out of service at the same time. The outages
+// which have already started, e.g. on an instance joining late, begin at once for the rest of their duration, and
+// those which have already ended are skipped.
+func (s *Scheduler) Start(epoch time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := clock.Now()
+	for _, outage := range s.outages {
+		ncgi, delay, duration := outage.NCGI, epoch.Add(outage.Start).Sub(now), outage.Duration
+		if delay < 0 {
+			if duration > 0 && duration <= -delay {
+				log.Infof("Skipping outage of cell %d which has ended", ncgi)
+				continue
+			}
+			if duration > 0 {
+				duration += delay
+			}
+			delay = 0
+		}
+		log.Infof("Scheduling outage of cell %d in %v for %v", ncgi, delay, duration)
+		s.timers = append(s.timers, time.AfterFunc(delay, func() {
+			if err := s.Fail(context.Background(), ncgi, duration); err != nil {
+				log.Warn(err)
+			}
+		}))
+	}
+}
+
+// Stop cancels the scheduled outages and recoveries; failed cells remain out of service
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, timer := range s.timers {
+		timer.Stop()
+	}
+	s.timers = nil
+}
+
+// Reset cancels the scheduled outages and schedules the outages of the given model on the given cell store, which
+// replaces the previous one, relative to now
+func (s *Scheduler) Reset(cellStore cells.Store, outages []model.Outage) {
+	s.Stop()
+	s.mu.Lock()
+	s.cellStore = cellStore
+	s.outages = outages
+	s.failed = make(map[types.NCGI]*Status)
+	s.mu.Unlock()
+	s.Start(clock.Now())
+}
+
+// Fail puts the cell out of service; the cell recovers after the given duration, unless it is zero
+func (s *Scheduler) Fail(ctx context.Context, ncgi types.NCGI, duration time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.cellStore.SetFailed(ctx, ncgi, true); err != nil {
+		return err
+	}
+	status := &Status{NCGI: ncgi, Since: time.Now()}
+	if previous, ok := s.failed[ncgi]; ok {
+		status.Since = previous.Since
+	}
+	if duration > 0 {
+		recovery := time.Now().Add(duration)
+		status.Recovery = &recovery
+		s.timers = append(s.timers, time.AfterFunc(duration, func() {
+			s.recoverAt(ncgi, recovery)
+		}))
+	}
+	s.failed[ncgi] = status
+	if cell, err := s.cellStore.Get(ctx, ncgi); err == nil {
+		log.Infof("Cell %s is out of service", cell.DisplayName())
+	}
+	return nil
+}
+
+// recoverAt recovers the cell unless its outage has been changed since the recovery was scheduled
+func (s *Scheduler) recoverAt(ncgi types.NCGI, recovery time.Time) {
+	s.mu.Lock()
+	status, ok := s.failed[ncgi]
+	s.mu.Unlock()
+	if !ok || status.Recovery == nil || !status.Recovery.Equal(recovery) {
+		return
+	}
+	if err := s.Recover(context.Background(), ncgi); err != nil {
+		log.Warn(err)
+	}
+}
+
+// Recover puts the cell back in service
+func (s *Scheduler) Recover(ctx context.Context, ncgi types.NCGI) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cell, err := s.cellStore.Get(ctx, ncgi)
+	if err != nil {
+		return err
+	}
+	if !cell.Failed {
+		return errors.NewInvalid("cell %d is in service", ncgi)
+	}
+	if err := s.cellStore.SetFailed(ctx, ncgi, false); err != nil {
+		return err
+	}
+	delete(s.failed, ncgi)
+	log.Infof("Cell %s is back in service", cell.DisplayName())
+	return nil
+}
+
+// List returns the outages of the failed cells, ordered by NCGI
+func (s *Scheduler) List(ctx context.Context) []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make([]Status, 0, len(s.failed))
+	for _, status := range s.failed {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].NCGI < statuses[j].NCGI })
+	return statuses
+}
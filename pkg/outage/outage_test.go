@@ -0,0 +1,85 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package outage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+const ncgi = types.NCGI(84325717505)
+
+func newCellStore(t *testing.T) cells.Store {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	return cells.NewCellRegistry(m.Cells, nodes.NewNodeRegistry(m.Nodes))
+}
+
+func failed(t *testing.T, cellStore cells.Store) bool {
+	cell, err := cellStore.Get(context.Background(), ncgi)
+	assert.NoError(t, err)
+	return cell.Failed
+}
+
+func TestFailRecover(t *testing.T) {
+	ctx := context.Background()
+	cellStore := newCellStore(t)
+	scheduler := NewScheduler(cellStore, nil)
+
+	assert.NoError(t, scheduler.Fail(ctx, ncgi, 0))
+	assert.True(t, failed(t, cellStore))
+	statuses := scheduler.List(ctx)
+	assert.Len(t, statuses, 1)
+	assert.Equal(t, ncgi, statuses[0].NCGI)
+	assert.Nil(t, statuses[0].Recovery)
+
+	assert.NoError(t, scheduler.Recover(ctx, ncgi))
+	assert.False(t, failed(t, cellStore))
+	assert.Empty(t, scheduler.List(ctx))
+	assert.Error(t, scheduler.Recover(ctx, ncgi))
+	assert.Error(t, scheduler.Fail(ctx, 1, 0))
+}
+
+func TestScheduledOutage(t *testing.T) {
+	cellStore := newCellStore(t)
+	scheduler := NewScheduler(cellStore, []model.Outage{
+		{NCGI: ncgi, Start: 10 * time.Millisecond, Duration: 50 * time.Millisecond},
+	})
+	scheduler.Start(time.Now())
+	defer scheduler.Stop()
+
+	assert.Eventually(t, func() bool {
+		return failed(t, cellStore)
+	}, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return !failed(t, cellStore)
+	}, time.Second, time.Millisecond)
+}
+
+func TestLateOutage(t *testing.T) {
+	ctx := context.Background()
+	cellStore := newCellStore(t)
+	scheduler := NewScheduler(cellStore, []model.Outage{
+		{NCGI: ncgi, Start: time.Second, Duration: time.Minute},
+		{NCGI: 1, Start: time.Second, Duration: time.Second},
+	})
+	// The scenario started before the scheduler: the first outage is under way and the second one has ended
+	scheduler.Start(time.Now().Add(-10 * time.Second))
+	defer scheduler.Stop()
+
+	assert.Eventually(t, func() bool {
+		return failed(t, cellStore)
+	}, time.Second, time.Millisecond)
+	statuses := scheduler.List(ctx)
+	assert.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Recovery.Before(time.Now().Add(51*time.Second)))
+}
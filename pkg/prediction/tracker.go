@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prediction
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+)
+
+var log = liblog.GetLogger("prediction")
+
+// Names of the per-cell prediction metrics kept in the metrics store; predictions are accounted to the cell
+// the UE is handed over from
+const (
+	// HitsMetric number of handovers to the predicted cell
+	HitsMetric = "Prediction.Hits"
+	// MissesMetric number of handovers to a cell other than the predicted one
+	MissesMetric = "Prediction.Misses"
+	// AccuracyMetric ratio of the hits to all scored predictions
+	AccuracyMetric = "Prediction.Accuracy"
+)
+
+// Prediction next cell predicted for a UE
+type Prediction struct {
+	IMSI types.IMSI `json:"imsi"`
+	NCGI types.NCGI `json:"ncgi"`
+	Time time.Time  `json:"time"`
+}
+
+// Stats overall prediction statistics
+type Stats struct {
+	Hits     uint64  `json:"hits"`
+	Misses   uint64  `json:"misses"`
+	Pending  int     `json:"pending"`
+	Accuracy float64 `json:"accuracy"`
+}
+
+// Tracker tracks the next cells predicted for UEs by external components and scores them against the actual handovers
+type Tracker interface {
+	// Set registers the next cell predicted for the given UE, replacing any pending prediction
+	Set(imsi types.IMSI, ncgi types.NCGI) *Prediction
+
+	// Get returns the pending prediction of the given UE
+	Get(imsi types.IMSI) (*Prediction, error)
+
+	// Delete withdraws the pending prediction of the given UE
+	Delete(imsi types.IMSI) (*Prediction, error)
+
+	// List returns all pending predictions
+	List() []*Prediction
+
+	// Handover scores the pending prediction of the UE against its handover from the source to the target cell;
+	// the prediction is consumed by the handover
+	Handover(ctx context.Context, imsi types.IMSI, source types.NCGI, target types.NCGI)
+
+	// Stats returns the overall prediction statistics
+	Stats() Stats
+
+	// Clear withdraws all pending predictions and resets the statistics
+	Clear()
+}
+
+type tracker struct {
+	mu           sync.RWMutex
+	predictions  map[types.IMSI]*Prediction
+	hits         uint64
+	misses       uint64
+	metricsStore metrics.Store
+}
+
+// NewTracker creates a prediction tracker reporting its per-cell metrics to the given metrics store
+func NewTracker(metricsStore metrics.Store) Tracker {
+	return &tracker{
+		predictions:  make(map[types.IMSI]*Prediction),
+		metricsStore: metricsStore,
+	}
+}
+
+func (t *tracker) Set(imsi types.IMSI, ncgi types.NCGI) *Prediction {
+	prediction := &Prediction{
+		IMSI: imsi,
+		NCGI: ncgi,
+		Time: time.Now(),
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.predictions[imsi] = prediction
+	return prediction
+}
+
+func (t *tracker) Get(imsi types.IMSI) (*Prediction, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if prediction, ok := t.predictions[imsi]; ok {
+		return prediction, nil
+	}
+	return nil, errors.NewNotFound("no prediction pending for UE %d", imsi)
+}
+
+func (t *tracker) Delete(imsi types.IMSI) (*Prediction, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if prediction, ok := t.predictions[imsi]; ok {
+		delete(t.predictions, imsi)
+		return prediction, nil
+	}
+	return nil, errors.NewNotFound("no prediction pending for UE %d", imsi)
+}
+
+func (t *tracker) List() []*Prediction {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	list := make([]*Prediction, 0, len(t.predictions))
+	for _, prediction := range t.predictions {
+		list = append(list, prediction)
+	}
+	return list
+}
+
+func (t *tracker) Handover(ctx context.Context, imsi types.IMSI, source types.NCGI, target types.NCGI) {
+	t.mu.Lock()
+	prediction, ok := t.predictions[imsi]
+	if !ok {
+		t.mu.Unlock()
+		return
+	}
+	delete(t.predictions, imsi)
+	hit := prediction.NCGI == target
+	if hit {
+		t.hits++
+	} else {
+		t.misses++
+	}
+	t.mu.Unlock()
+
+	log.Debugf("Prediction of UE %d %s: predicted %d, handed over from %d to %d", imsi, outcome(hit), prediction.NCGI, source, target)
+	if hit {
+		t.increment(ctx, source, HitsMetric)
+	} else {
+		t.increment(ctx, source, MissesMetric)
+	}
+	hits := counter(ctx, t.metricsStore, source, HitsMetric)
+	misses := counter(ctx, t.metricsStore, source, MissesMetric)
+	if err := t.metricsStore.Set(ctx, uint64(source), AccuracyMetric, float64(hits)/float64(hits+misses)); err != nil {
+		log.Warn(err)
+	}
+}
+
+func (t *tracker) Stats() Stats {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	stats := Stats{
+		Hits:    t.hits,
+		Misses:  t.misses,
+		Pending: len(t.predictions),
+	}
+	if t.hits+t.misses > 0 {
+		stats.Accuracy = float64(t.hits) / float64(t.hits+t.misses)
+	}
+	return stats
+}
+
+func (t *tracker) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.predictions = make(map[types.IMSI]*Prediction)
+	t.hits = 0
+	t.misses = 0
+}
+
+func (t *tracker) increment(ctx context.Context, ncgi types.NCGI, name string) {
+	if _, err := t.metricsStore.Increment(ctx, uint64(ncgi), name); err != nil {
+		log.Warn(err)
+	}
+}
+
+func counter(ctx context.Context, metricsStore metrics.Store, ncgi types.NCGI, name string) uint64 {
+	if value, ok := metricsStore.Get(ctx, uint64(ncgi), name); ok {
+		if count, ok := value.(uint64); ok {
+			return count
+		}
+	}
+	return 0
+}
+
+func outcome(hit bool) string {
+	if hit {
+		return "hit"
+	}
+	return "missed"
+}
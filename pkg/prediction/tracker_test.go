@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package prediction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker(t *testing.T) {
+	ctx := context.Background()
+	metricsStore := metrics.NewMetricsStore()
+	tracker := NewTracker(metricsStore)
+	source, target, other := types.NCGI(0x1), types.NCGI(0x2), types.NCGI(0x3)
+
+	tracker.Set(1, target)
+	tracker.Set(2, other)
+	tracker.Set(3, target)
+	assert.Len(t, tracker.List(), 3)
+	prediction, err := tracker.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, target, prediction.NCGI)
+
+	// Handovers of UEs without predictions are not scored
+	tracker.Handover(ctx, 4, source, target)
+	assert.Equal(t, Stats{Pending: 3}, tracker.Stats())
+
+	tracker.Handover(ctx, 1, source, target)
+	tracker.Handover(ctx, 2, source, target)
+	_, err = tracker.Get(1)
+	assert.True(t, errors.IsNotFound(err))
+
+	// A consumed prediction is not scored again
+	tracker.Handover(ctx, 1, target, source)
+	assert.Equal(t, Stats{Hits: 1, Misses: 1, Pending: 1, Accuracy: 0.5}, tracker.Stats())
+
+	hits, _ := metricsStore.Get(ctx, uint64(source), HitsMetric)
+	assert.Equal(t, uint64(1), hits)
+	misses, _ := metricsStore.Get(ctx, uint64(source), MissesMetric)
+	assert.Equal(t, uint64(1), misses)
+	accuracy, _ := metricsStore.Get(ctx, uint64(source), AccuracyMetric)
+	assert.Equal(t, 0.5, accuracy)
+
+	_, err = tracker.Delete(3)
+	assert.NoError(t, err)
+	_, err = tracker.Delete(3)
+	assert.True(t, errors.IsNotFound(err))
+
+	tracker.Set(5, target)
+	tracker.Clear()
+	assert.Equal(t, Stats{}, tracker.Stats())
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scaling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/onosproject/ran-simulator/pkg/utils/honeycomb"
+)
+
+var log = liblog.GetLogger("scaling")
+
+// Defaults of the cluster template, matching those of the honeycomb topology generator
+const (
+	defaultTowers          = 7
+	defaultSectorsPerTower = 3
+	defaultPitch           = 0.02
+	maxNeighborDistance    = 8000.0
+	maxNeighbors           = 5
+	maxPCI                 = 503
+	maxCollisions          = 8
+	earfcnStart            = 42
+	deformScale            = 0.01
+)
+
+// MaxClusters maximum number of clusters that may be added
+const MaxClusters = 1000
+
+var cellTypes = []string{"FEMTO", "ENTERPRISE", "OUTDOOR_SMALL", "MACRO"}
+
+// Status number of added clusters along with their nodes and cells
+type Status struct {
+	Clusters uint `json:"clusters"`
+	Nodes    int  `json:"nodes"`
+	Cells    int  `json:"cells"`
+}
+
+type cluster struct {
+	nodes []types.GnbID
+	cells []types.NCGI
+}
+
+// Scaler grows and shrinks the simulated topology at runtime by adding and removing clusters of nodes generated
+// from the honeycomb template of the model. Clusters are laid out eastward of the map center and their nodes are
+// served by all service models of the model and by the controller selected by the controller selection policy of
+// the model; agents are started and stopped by the node events.
+type Scaler struct {
+	mu        sync.Mutex
+	model     *model.Model
+	nodeStore nodes.Store
+	cellStore cells.Store
+	clusters  []cluster
+	selector  *model.ControllerSelector
+}
+
+// NewScaler creates a scaler adding clusters to the given stores
+func NewScaler(m *model.Model, nodeStore nodes.Store, cellStore cells.Store) *Scaler {
+	return &Scaler{
+		model:     m,
+		nodeStore: nodeStore,
+		cellStore: cellStore,
+	}
+}
+
+// Reset forgets the added clusters and makes the scaler add clusters to the given model and stores, which replace
+// those the clusters were added to
+func (s *Scaler) Reset(m *model.Model, nodeStore nodes.Store, cellStore cells.Store) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.model = m
+	s.nodeStore = nodeStore
+	s.cellStore = cellStore
+	s.clusters = nil
+	s.selector = nil
+}
+
+// Status returns the clusters currently added
+func (s *Scaler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status()
+}
+
+func (s *Scaler) status() Status {
+	status := Status{Clusters: uint(len(s.clusters))}
+	for _, c := range s.clusters {
+		status.Nodes += len(c.nodes)
+		status.Cells += len(c.cells)
+	}
+	return status
+}
+
+// Scale adds or removes clusters until the given number of clusters is reached; the most recently added clusters
+// are removed first
+func (s *Scaler) Scale(ctx context.Context, clusters uint) (Status, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if clusters > MaxClusters {
+		return s.status(), errors.NewInvalid("number of clusters %d exceeds the maximum of %d", clusters, MaxClusters)
+	}
+	for uint(len(s.clusters)) < clusters {
+		if err := s.addCluster(ctx); err != nil {
+			return s.status(), err
+		}
+	}
+	for uint(len(s.clusters)) > clusters {
+		if err := s.removeCluster(ctx); err != nil {
+			return s.status(), err
+		}
+	}
+	status := s.status()
+	log.Infof("Scaled to %d clusters with %d nodes and %d cells", status.Clusters, status.Nodes, status.Cells)
+	return status, nil
+}
+
+func (s *Scaler) template() model.ScalingConfig {
+	template := s.model.Scaling
+	if template.Towers == 0 {
+		template.Towers = defaultTowers
+	}
+	if template.SectorsPerTower == 0 {
+		template.SectorsPerTower = defaultSectorsPerTower
+	}
+	if template.Pitch == 0 {
+		template.Pitch = defaultPitch
+	}
+	if template.Spacing == 0 {
+		// Leaves about one tower pitch between the outer rings of adjacent clusters of up to 19 towers
+		template.Spacing = 6 * float64(template.Pitch)
+	}
+	return template
+}
+
+func (s *Scaler) addCluster(ctx context.Context) error {
+	template := s.template()
+	nodeList, err := s.nodeStore.List(ctx)
+	if err != nil {
+		return err
+	}
+	var maxGnbID types.GnbID
+	for _, node := range nodeList {
+		if node.GnbID > maxGnbID {
+			maxGnbID = node.GnbID
+		}
+	}
+
+	center := s.model.MapLayout.Center
+	center.Lng += float64(len(s.clusters)+1) * template.Spacing / utils.AspectRatio(center.Lat)
+	generated, err := honeycomb.GenerateHoneycombTopology(center, template.Towers, template.SectorsPerTower, s.model.PlmnID,
+		uint32(maxGnbID), template.Pitch, maxNeighborDistance, maxNeighbors, nil, nil, false,
+		0, maxPCI, maxCollisions, earfcnStart, cellTypes, deformScale)
+	if err != nil {
+		return err
+	}
+
+	if s.selector == nil {
+		s.selector, err = model.NewControllerSelector(s.model)
+		if err != nil {
+			return err
+		}
+	}
+	serviceModels := make([]string, 0, len(s.model.ServiceModels))
+	for name := range s.model.ServiceModels {
+		serviceModels = append(serviceModels, name)
+	}
+
+	// Cells are added first so that they are in place when the agents of their nodes start; the names of the
+	// generated nodes and cells are qualified by the cluster to keep them unique
+	prefix := fmt.Sprintf("Cluster-%d/", len(s.clusters)+1)
+	added := cluster{}
+	for _, cell := range generated.Cells {
+		cell := cell // avoids scopelint issue
+		cell.Name = prefix + cell.Name
+		if err := s.cellStore.Add(ctx, &cell); err != nil {
+			return err
+		}
+		added.cells = append(added.cells, cell.NCGI)
+	}
+	for _, node := range generated.Nodes {
+		node := node // avoids scopelint issue
+		node.Name = prefix + node.Name
+		nodeCells := make([]model.Cell, 0, len(node.Cells))
+		for _, cell := range generated.Cells {
+			for _, ncgi := range node.Cells {
+				if cell.NCGI == ncgi {
+					nodeCells = append(nodeCells, cell)
+				}
+			}
+		}
+		if controller := s.selector.Select(nodeCells); controller != "" {
+			node.Controllers = []string{controller}
+		}
+		node.ServiceModels = serviceModels
+		if err := s.nodeStore.Add(ctx, &node); err != nil {
+			return err
+		}
+		added.nodes = append(added.nodes, node.GnbID)
+	}
+	s.clusters = append(s.clusters, added)
+	return nil
+}
+
+func (s *Scaler) removeCluster(ctx context.Context) error {
+	removed := s.clusters[len(s.clusters)-1]
+	for _, gnbID := range removed.nodes {
+		if _, err := s.nodeStore.Delete(ctx, gnbID); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	for _, ncgi := range removed.cells {
+		if _, err := s.cellStore.Delete(ctx, ncgi); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	s.clusters = s.clusters[:len(s.clusters)-1]
+	return nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package scaling
+
+import (
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestScaler(t *testing.T) {
+	m := &model.Model{}
+	bytes, err := ioutil.ReadFile("../model/test.yaml")
+	assert.NoError(t, err)
+	err = yaml.Unmarshal(bytes, m)
+	assert.NoError(t, err)
+	m.Scaling = model.ScalingConfig{Towers: 3, SectorsPerTower: 2}
+	ctx := context.Background()
+
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	scaler := NewScaler(m, nodeStore, cellStore)
+	cellCount := func(ctx context.Context) (int, error) {
+		cellList, err := cellStore.List(ctx)
+		return len(cellList), err
+	}
+
+	status, err := scaler.Scale(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, Status{Clusters: 2, Nodes: 6, Cells: 12}, status)
+	assertLen(t, len(m.Nodes)+6, nodeStore.Len)
+	assertLen(t, len(m.Cells)+12, cellCount)
+
+	existing := make(map[types.GnbID]bool)
+	for _, node := range m.Nodes {
+		existing[node.GnbID] = true
+	}
+	nodeList, err := nodeStore.List(ctx)
+	assert.NoError(t, err)
+	for _, node := range nodeList {
+		if !existing[node.GnbID] {
+			assert.Len(t, node.Controllers, 1)
+			assert.Len(t, node.ServiceModels, len(m.ServiceModels))
+			assert.Len(t, node.Cells, 2)
+		}
+	}
+
+	status, err = scaler.Scale(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Status{Clusters: 1, Nodes: 3, Cells: 6}, status)
+	assertLen(t, len(m.Nodes)+3, nodeStore.Len)
+	assertLen(t, len(m.Cells)+6, cellCount)
+
+	status, err = scaler.Scale(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, Status{}, status)
+	assertLen(t, len(m.Nodes), nodeStore.Len)
+	assertLen(t, len(m.Cells), cellCount)
+
+	_, err = scaler.Scale(ctx, MaxClusters+1)
+	assert.Error(t, err)
+}
+
+func assertLen(t *testing.T, expected int, length func(ctx context.Context) (int, error)) {
+	n, err := length(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, n)
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package callprocess
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// DefaultTimeout time a call process waits for the RIC control referencing it
+const DefaultTimeout = 5 * time.Second
+
+// Registry holds the contexts of the call processes suspended by insert indications, such as pending handovers,
+// until a RIC control request referencing their call process ID resolves them or they time out
+type Registry struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	next    uint64
+	pending map[string]*callProcess
+}
+
+type callProcess struct {
+	context   interface{}
+	timer     *time.Timer
+	onTimeout func(context interface{})
+}
+
+// NewRegistry creates a registry of call processes expiring after the given timeout; the default timeout is used
+// if it is not positive
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{
+		timeout: timeout,
+		pending: make(map[string]*callProcess),
+	}
+}
+
+// Register starts a call process holding the given context and returns its ID, to be sent in the insert indication;
+// onTimeout, if not nil, is called with the context if the call process is not resolved before the timeout
+func (r *Registry) Register(context interface{}, onTimeout func(context interface{})) []byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.next++
+	id := make([]byte, 8)
+	binary.BigEndian.PutUint64(id, r.next)
+	key := string(id)
+	process := &callProcess{
+		context:   context,
+		onTimeout: onTimeout,
+	}
+	process.timer = time.AfterFunc(r.timeout, func() {
+		r.expire(key, process)
+	})
+	r.pending[key] = process
+	return id
+}
+
+// Resolve ends the call process with the given ID and returns its context; NotFound is returned if the call process
+// is unknown, was already resolved or has timed out
+func (r *Registry) Resolve(id []byte) (interface{}, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	process, ok := r.pending[string(id)]
+	if !ok {
+		return nil, errors.NewNotFound("call process %x not found", id)
+	}
+	process.timer.Stop()
+	delete(r.pending, string(id))
+	return process.context, nil
+}
+
+// Len returns the number of pending call processes
+func (r *Registry) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.pending)
+}
+
+// Clear drops all pending call processes without calling their timeout handlers
+func (r *Registry) Clear() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, process := range r.pending {
+		process.timer.Stop()
+		delete(r.pending, key)
+	}
+}
+
+func (r *Registry) expire(key string, process *callProcess) {
+	r.mu.Lock()
+	// The call process may have been resolved, or cleared, while the timer was firing
+	if r.pending[key] != process {
+		r.mu.Unlock()
+		return
+	}
+	delete(r.pending, key)
+	r.mu.Unlock()
+	if process.onTimeout != nil {
+		process.onTimeout(process.context)
+	}
+}
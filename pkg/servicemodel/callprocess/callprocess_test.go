@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package callprocess
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	r := NewRegistry(time.Minute)
+	first := r.Register("first", nil)
+	second := r.Register("second", nil)
+	assert.NotEqual(t, first, second)
+	assert.Equal(t, 2, r.Len())
+
+	context, err := r.Resolve(second)
+	assert.NoError(t, err)
+	assert.Equal(t, "second", context)
+	assert.Equal(t, 1, r.Len())
+
+	// A call process is resolved only once
+	_, err = r.Resolve(second)
+	assert.True(t, errors.IsNotFound(err))
+	_, err = r.Resolve([]byte{0x01})
+	assert.True(t, errors.IsNotFound(err))
+
+	r.Clear()
+	assert.Equal(t, 0, r.Len())
+	_, err = r.Resolve(first)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestTimeout(t *testing.T) {
+	r := NewRegistry(10 * time.Millisecond)
+	expired := make(chan interface{}, 1)
+	id := r.Register("pending", func(context interface{}) {
+		expired <- context
+	})
+
+	select {
+	case context := <-expired:
+		assert.Equal(t, "pending", context)
+	case <-time.After(time.Second):
+		t.Fatal("call process has not timed out")
+	}
+	assert.Equal(t, 0, r.Len())
+	_, err := r.Resolve(id)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Resolved call processes do not time out
+	id = r.Register("resolved", func(context interface{}) {
+		expired <- context
+	})
+	_, err = r.Resolve(id)
+	assert.NoError(t, err)
+	select {
+	case <-expired:
+		t.Fatal("resolved call process has timed out")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
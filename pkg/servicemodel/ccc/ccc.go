@@ -0,0 +1,346 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ccc implements the CCC (Cell Configuration and Control) service model, through which the RIC reads and
+// writes the configuration attributes of the cells of a node, i.e. their administrative state, bandwidth and power,
+// mapped onto the cell store.
+package ccc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/sdk"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+)
+
+var _ sdk.Model = &Client{}
+
+var log = logging.GetLogger("sm", "ccc")
+
+const (
+	modelName = "ORAN-E2SM-CCC"
+	version   = "v1"
+	modelOID  = "1.3.6.1.4.1.53148.1.1.2.4"
+)
+
+// Client CCC service model, served by the service model SDK
+type Client struct {
+	ServiceModel *registry.ServiceModel
+	logPrefix    string
+
+	mu sync.Mutex
+	// reported attributes of the cells last reported to each subscription with the on change event trigger
+	reported map[subscriptions.ID]map[ransimtypes.NCGI]CellAttributes
+}
+
+// NewServiceModel creates a new service model
+func NewServiceModel(node model.Node, model *model.Model, subStore *subscriptions.Subscriptions,
+	nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store) (registry.ServiceModel, error) {
+	cccSm := registry.ServiceModel{
+		RanFunctionID: registry.Ccc,
+		ModelName:     e2smtypes.ShortName(modelName),
+		Revision:      1,
+		OID:           modelOID,
+		Version:       version,
+		Node:          node,
+		Model:         model,
+		Subscriptions: subStore,
+		Nodes:         nodeStore,
+		UEs:           ueStore,
+		CellStore:     cellStore,
+	}
+	cccClient := &Client{
+		ServiceModel: &cccSm,
+		logPrefix:    logfields.Node(node.GnbID),
+		reported:     make(map[subscriptions.ID]map[ransimtypes.NCGI]CellAttributes),
+	}
+
+	// The configuration of the cells is reported periodically or upon its changes
+	cccSm.Client = sdk.NewClient(&cccSm, cccClient,
+		sdk.WithTriggers(trigger.Periodic, trigger.OnChange),
+		sdk.WithChanges(cccClient.changes))
+
+	description, err := json.Marshal(newRanFunctionDefinition(node))
+	if err != nil {
+		log.Error(err)
+		return registry.ServiceModel{}, err
+	}
+	cccSm.Description = description
+	return cccSm, nil
+}
+
+// newRanFunctionDefinition returns the RAN function description listing the attributes supported for each cell
+func newRanFunctionDefinition(node model.Node) *RanFunctionDefinition {
+	definition := &RanFunctionDefinition{
+		RanFunctionName: RanFunctionName{
+			RanFunctionShortName:   modelName,
+			RanFunctionE2SMOID:     modelOID,
+			RanFunctionDescription: "Cell Configuration and Control",
+			RanFunctionInstance:    1,
+		},
+		ListOfCellsForRANFunctionDefinition: make([]CellForRanFunctionDefinition, 0, len(node.Cells)),
+	}
+	for _, ncgi := range node.Cells {
+		definition.ListOfCellsForRANFunctionDefinition = append(definition.ListOfCellsForRANFunctionDefinition,
+			CellForRanFunctionDefinition{
+				CellGlobalID: newCellGlobalID(ncgi),
+				ListOfSupportedCellLevelRANConfigurationStructures: []SupportedStructure{
+					{
+						RanConfigurationStructureName: cellStructure,
+						ListOfSupportedAttributes: []SupportedAttribute{
+							{AttributeName: attributeAdministrativeState},
+							{AttributeName: attributeBSChannelBwDL},
+							{AttributeName: attributeConfiguredMaxTxPower},
+						},
+					},
+				},
+			})
+	}
+	return definition
+}
+
+// servesCell returns true if the cell is one of the cells of the node
+func (sm *Client) servesCell(ncgi ransimtypes.NCGI) bool {
+	for _, cell := range sm.ServiceModel.Node.Cells {
+		if cell == ncgi {
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeEventTrigger decodes the CCC event trigger definition; the configuration of the cells is reported
+// periodically with format 3 or upon its changes with format 2
+func (sm *Client) DecodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
+	eventTrigger := &EventTriggerDefinition{}
+	if err := json.Unmarshal(definition, eventTrigger); err != nil {
+		return nil, trigger.NewMalformed("cannot decode event trigger definition: %v", err)
+	}
+	switch format := eventTrigger.EventTriggerFormat; {
+	case format.EventTriggerFormat3 != nil:
+		return &trigger.Trigger{Type: trigger.Periodic, Period: format.EventTriggerFormat3.Period}, nil
+	case format.EventTriggerFormat2 != nil:
+		return &trigger.Trigger{Type: trigger.OnChange}, nil
+	}
+	return nil, trigger.NewUnsupported("only the cell level configuration change and periodic event triggers are supported")
+}
+
+// changes returns a channel receiving a value whenever a cell of the node is updated; the cells whose configuration
+// did not change since the last report of the subscription are filtered out of the indications
+func (sm *Client) changes(ctx context.Context, sub *subscriptions.Subscription) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	cellCh := make(chan event.Event)
+	if err := sm.ServiceModel.CellStore.Watch(ctx, cellCh); err != nil {
+		log.Warnf("%s: %v", sm.logPrefix, err)
+		close(ch)
+		return ch
+	}
+
+	// Changes are reported against the configuration of the cells upon the subscription
+	reported := make(map[ransimtypes.NCGI]CellAttributes)
+	for _, ncgi := range sm.ServiceModel.Node.Cells {
+		if cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi); err == nil {
+			reported[ncgi] = newCellAttributes(cell)
+		}
+	}
+	sm.mu.Lock()
+	sm.reported[sub.ID] = reported
+	sm.mu.Unlock()
+
+	go func() {
+		defer close(ch)
+		defer func() {
+			sm.mu.Lock()
+			delete(sm.reported, sub.ID)
+			sm.mu.Unlock()
+		}()
+		// The watch is closed along with the context of the subscription
+		for cellEvent := range cellCh {
+			if ncgi, ok := cellEvent.Key.(ransimtypes.NCGI); !ok || !sm.servesCell(ncgi) {
+				continue
+			}
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return ch
+}
+
+// BuildHeader builds the indication header of the report
+func (sm *Client) BuildHeader(ctx context.Context, report sdk.Report) ([]byte, error) {
+	header := &IndicationHeader{}
+	header.IndicationHeaderFormat.IndicationHeaderFormat1 = IndicationHeaderFormat1{
+		IndicationReason: indicationReasonPeriodic,
+		EventTime:        report.Time.UTC().Format(time.RFC3339Nano),
+	}
+	if report.Trigger.Type == trigger.OnChange {
+		header.IndicationHeaderFormat.IndicationHeaderFormat1.IndicationReason = indicationReasonUponChange
+	}
+	return json.Marshal(header)
+}
+
+// BuildMessage builds the indication message reporting the configuration of the cells of the node; on change
+// reports only carry the cells whose configuration changed since the last report, and are not sent if none did
+func (sm *Client) BuildMessage(ctx context.Context, report sdk.Report) ([]byte, error) {
+	onChange := report.Trigger.Type == trigger.OnChange
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	reported := sm.reported[report.Subscription.ID]
+
+	format2 := IndicationMessageFormat2{}
+	for _, ncgi := range sm.ServiceModel.Node.Cells {
+		cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
+		if err != nil {
+			continue
+		}
+		attributes := newCellAttributes(cell)
+		changeType := changeTypeNone
+		if onChange {
+			if previous, ok := reported[ncgi]; ok && previous.equal(attributes) {
+				continue
+			}
+			if reported != nil {
+				reported[ncgi] = attributes
+			}
+			changeType = changeTypeModification
+		}
+		format2.ListOfCellsReported = append(format2.ListOfCellsReported, CellReported{
+			CellGlobalID: newCellGlobalID(ncgi),
+			ListOfConfigurationStructuresReported: []ConfigurationStructureReported{
+				{
+					ChangeType:                    changeType,
+					RanConfigurationStructureName: cellStructure,
+					ValuesOfAttributes:            RanConfigurationStructure{RanConfigurationStructure: attributes},
+				},
+			},
+		})
+	}
+	if len(format2.ListOfCellsReported) == 0 {
+		return nil, nil
+	}
+	message := &IndicationMessage{}
+	message.IndicationMessageFormat.IndicationMessageFormat2 = format2
+	return json.Marshal(message)
+}
+
+// HandleControl applies the new values of the attributes of the cell configuration structures of the control
+// message, and reports the structures accepted and failed for each cell in the control outcome; a control header or
+// message which does not decode is invalid
+func (sm *Client) HandleControl(ctx context.Context, header []byte, message []byte) ([]byte, error) {
+	received := clock.Now()
+	controlHeader := &ControlHeader{}
+	if err := json.Unmarshal(header, controlHeader); err != nil {
+		return nil, errors.NewInvalid("unable to decode CCC control header: %v", err)
+	}
+	format1 := controlHeader.ControlHeaderFormat.ControlHeaderFormat1
+	if format1 == nil || format1.RicStyleType != controlStyleCellConfiguration {
+		return nil, errors.NewInvalid("only the cell configuration and control style %d is supported", controlStyleCellConfiguration)
+	}
+	controlMessage := &ControlMessage{}
+	if err := json.Unmarshal(message, controlMessage); err != nil {
+		return nil, errors.NewInvalid("unable to decode CCC control message: %v", err)
+	}
+	format2 := controlMessage.ControlMessageFormat.ControlMessageFormat2
+	if format2 == nil {
+		return nil, errors.NewInvalid("only the cell level control message format 2 is supported")
+	}
+
+	outcome := &ControlOutcome{}
+	outcome.ControlOutcomeFormat.ControlOutcomeFormat2 = ControlOutcomeFormat2{
+		ReceivedTimestamp:     received.UTC().Format(time.RFC3339Nano),
+		ListOfCellsControlled: make([]CellOutcome, 0, len(format2.ListOfCellsControlled)),
+	}
+	for _, controlled := range format2.ListOfCellsControlled {
+		outcome.ControlOutcomeFormat.ControlOutcomeFormat2.ListOfCellsControlled = append(
+			outcome.ControlOutcomeFormat.ControlOutcomeFormat2.ListOfCellsControlled, sm.controlCell(ctx, controlled))
+	}
+	return json.Marshal(outcome)
+}
+
+// controlCell applies the configuration structures controlled for a cell, and returns their outcome
+func (sm *Client) controlCell(ctx context.Context, controlled CellControlled) CellOutcome {
+	outcome := CellOutcome{
+		CellGlobalID:                                 controlled.CellGlobalID,
+		ListOfConfigurationStructuresAccepted:        make([]ConfigurationStructureAccepted, 0),
+		ListOfConfigurationStructuresFailedToControl: make([]ConfigurationStructureFailed, 0),
+	}
+	var cell *model.Cell
+	ncgi, err := controlled.CellGlobalID.NCGI()
+	if err == nil && sm.servesCell(ncgi) {
+		cell, err = sm.ServiceModel.CellStore.Get(ctx, ncgi)
+	}
+	if err != nil {
+		log.Warnf("%s: %v", sm.logPrefix, err)
+	}
+
+	for _, structure := range controlled.ListOfConfigurationStructures {
+		attributes := structure.NewValuesOfAttributes.RanConfigurationStructure
+		var cause string
+		if cell == nil {
+			cause = causeCellNotFound
+		} else if structure.RanConfigurationStructureName != cellStructure {
+			cause = causeStructureUnknown
+		} else if err := attributes.validate(); err != nil {
+			log.Warnf("%s: %v", sm.logPrefix, err)
+			cause = causeAttributeInvalid
+		} else if err := sm.setAttributes(ctx, cell, attributes); err != nil {
+			log.Warnf("%s: %v", sm.logPrefix, err)
+			cause = causeAttributeNotStored
+		}
+		if cause != "" {
+			outcome.ListOfConfigurationStructuresFailedToControl = append(outcome.ListOfConfigurationStructuresFailedToControl,
+				ConfigurationStructureFailed{
+					RanConfigurationStructureName: structure.RanConfigurationStructureName,
+					Cause:                         cause,
+				})
+			continue
+		}
+		outcome.ListOfConfigurationStructuresAccepted = append(outcome.ListOfConfigurationStructuresAccepted,
+			ConfigurationStructureAccepted{
+				RanConfigurationStructureName: structure.RanConfigurationStructureName,
+				AppliedTimestamp:              clock.Now().UTC().Format(time.RFC3339Nano),
+			})
+	}
+	return outcome
+}
+
+// setAttributes sets the attributes of the cell which are given; a locked cell is out of service
+func (sm *Client) setAttributes(ctx context.Context, cell *model.Cell, attributes CellAttributes) error {
+	if attributes.BSChannelBwDL != nil || attributes.ConfiguredMaxTxPower != nil {
+		if attributes.BSChannelBwDL != nil {
+			cell.Bandwidth = *attributes.BSChannelBwDL
+		}
+		if attributes.ConfiguredMaxTxPower != nil {
+			cell.TxPowerDB = *attributes.ConfiguredMaxTxPower
+		}
+		if err := sm.ServiceModel.CellStore.Update(ctx, cell); err != nil {
+			return err
+		}
+	}
+	if attributes.AdministrativeState != nil {
+		if err := sm.ServiceModel.CellStore.SetFailed(ctx, cell.NCGI, *attributes.AdministrativeState == administrativeStateLocked); err != nil {
+			return err
+		}
+	}
+	log.Infof("%s: Configuration of cell %d set to bandwidth %d MHz, power %.1f dB, failed %t", sm.logPrefix, cell.NCGI, cell.Bandwidth, cell.TxPowerDB, cell.Failed)
+	return nil
+}
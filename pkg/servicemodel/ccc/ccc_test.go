@@ -0,0 +1,156 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/sdk"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T) *Client {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	return &Client{
+		ServiceModel: &registry.ServiceModel{
+			Node:      m.Nodes["node1"],
+			Model:     m,
+			CellStore: cellStore,
+		},
+		logPrefix: logfields.Node(m.Nodes["node1"].GnbID),
+		reported:  make(map[subscriptions.ID]map[ransimtypes.NCGI]CellAttributes),
+	}
+}
+
+func TestCellGlobalID(t *testing.T) {
+	id := newCellGlobalID(84325717505)
+	ncgi, err := id.NCGI()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(84325717505), uint64(ncgi))
+	_, err = CellGlobalID{}.NCGI()
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestDecodeEventTrigger(t *testing.T) {
+	client := &Client{}
+	eventTrigger, err := client.DecodeEventTrigger([]byte(`{"eventTriggerFormat":{"eventTriggerFormat3":{"period":1000}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, &trigger.Trigger{Type: trigger.Periodic, Period: 1000}, eventTrigger)
+	eventTrigger, err = client.DecodeEventTrigger([]byte(`{"eventTriggerFormat":{"eventTriggerFormat2":{}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, trigger.OnChange, eventTrigger.Type)
+	_, err = client.DecodeEventTrigger([]byte(`{"eventTriggerFormat":{}}`))
+	assert.Error(t, err)
+	_, err = client.DecodeEventTrigger([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestControl(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	header := []byte(`{"controlHeaderFormat":{"controlHeaderFormat1":{"ricStyleType":2}}}`)
+	control := &ControlMessage{}
+	bandwidth := uint32(40)
+	power := 30.0
+	locked := administrativeStateLocked
+	invalid := uint32(42)
+	control.ControlMessageFormat.ControlMessageFormat2 = &ControlMessageFormat2{
+		ListOfCellsControlled: []CellControlled{
+			{
+				CellGlobalID: newCellGlobalID(84325717505),
+				ListOfConfigurationStructures: []ConfigurationStructure{
+					{
+						RanConfigurationStructureName: cellStructure,
+						NewValuesOfAttributes: RanConfigurationStructure{RanConfigurationStructure: CellAttributes{
+							AdministrativeState:  &locked,
+							BSChannelBwDL:        &bandwidth,
+							ConfiguredMaxTxPower: &power,
+						}},
+					},
+					{
+						RanConfigurationStructureName: cellStructure,
+						NewValuesOfAttributes: RanConfigurationStructure{RanConfigurationStructure: CellAttributes{
+							BSChannelBwDL: &invalid,
+						}},
+					},
+				},
+			},
+			{
+				// A cell of another node
+				CellGlobalID: newCellGlobalID(84325717761),
+				ListOfConfigurationStructures: []ConfigurationStructure{
+					{RanConfigurationStructureName: cellStructure},
+				},
+			},
+		},
+	}
+	message, err := json.Marshal(control)
+	assert.NoError(t, err)
+
+	outcomeBytes, err := client.HandleControl(ctx, header, message)
+	assert.NoError(t, err)
+	outcome := &ControlOutcome{}
+	assert.NoError(t, json.Unmarshal(outcomeBytes, outcome))
+	controlled := outcome.ControlOutcomeFormat.ControlOutcomeFormat2.ListOfCellsControlled
+	assert.Len(t, controlled, 2)
+	assert.Len(t, controlled[0].ListOfConfigurationStructuresAccepted, 1)
+	assert.Len(t, controlled[0].ListOfConfigurationStructuresFailedToControl, 1)
+	assert.Equal(t, causeAttributeInvalid, controlled[0].ListOfConfigurationStructuresFailedToControl[0].Cause)
+	assert.Equal(t, causeCellNotFound, controlled[1].ListOfConfigurationStructuresFailedToControl[0].Cause)
+
+	cell, err := client.ServiceModel.CellStore.Get(ctx, 84325717505)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(40), cell.Bandwidth)
+	assert.Equal(t, 30.0, cell.TxPowerDB)
+	assert.True(t, cell.Failed)
+
+	_, err = client.HandleControl(ctx, []byte(`{}`), message)
+	assert.True(t, errors.IsInvalid(err))
+	_, err = client.HandleControl(ctx, header, []byte(`not json`))
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestReportOnChange(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+	sub := &subscriptions.Subscription{ID: "1"}
+	client.reported[sub.ID] = make(map[ransimtypes.NCGI]CellAttributes)
+	report := sdk.Report{Subscription: sub, Trigger: &trigger.Trigger{Type: trigger.OnChange}, Time: time.Now()}
+
+	message, err := client.BuildMessage(ctx, report)
+	assert.NoError(t, err)
+	indication := &IndicationMessage{}
+	assert.NoError(t, json.Unmarshal(message, indication))
+	assert.Len(t, indication.IndicationMessageFormat.IndicationMessageFormat2.ListOfCellsReported, 2)
+
+	// Nothing changed since the last report
+	message, err = client.BuildMessage(ctx, report)
+	assert.NoError(t, err)
+	assert.Nil(t, message)
+
+	assert.NoError(t, client.ServiceModel.CellStore.SetFailed(ctx, 84325717506, true))
+	message, err = client.BuildMessage(ctx, report)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(message, indication))
+	reported := indication.IndicationMessageFormat.IndicationMessageFormat2.ListOfCellsReported
+	assert.Len(t, reported, 1)
+	assert.Equal(t, administrativeStateLocked,
+		*reported[0].ListOfConfigurationStructuresReported[0].ValuesOfAttributes.RanConfigurationStructure.AdministrativeState)
+}
@@ -0,0 +1,311 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ccc
+
+import (
+	"fmt"
+	"strconv"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// The E2SM-CCC payloads are encoded in JSON rather than ASN.1; the types below follow the O-RAN E2SM-CCC
+// definitions for the cell level configuration structures supported by the simulator.
+
+// cellStructure configuration structure of a cell
+const cellStructure = "O-NRCellDU"
+
+// Attributes of the cell configuration structure
+const (
+	attributeAdministrativeState  = "administrativeState"
+	attributeBSChannelBwDL        = "bSChannelBwDL"
+	attributeConfiguredMaxTxPower = "configuredMaxTxPower"
+)
+
+// Administrative states of a cell
+const (
+	administrativeStateLocked   = "LOCKED"
+	administrativeStateUnlocked = "UNLOCKED"
+)
+
+// controlStyleCellConfiguration RIC style of the control of the cell configuration structures
+const controlStyleCellConfiguration = 2
+
+// Reasons of an indication
+const (
+	indicationReasonPeriodic   = "periodic"
+	indicationReasonUponChange = "uponChange"
+)
+
+// Types of change of a reported configuration structure
+const (
+	changeTypeNone         = "none"
+	changeTypeModification = "modification"
+)
+
+// Causes of the failure to control a configuration structure
+const (
+	causeCellNotFound       = "cell not found"
+	causeStructureUnknown   = "configuration structure not supported"
+	causeAttributeInvalid   = "attribute value not valid"
+	causeAttributeNotStored = "attribute value not applied"
+)
+
+// nrBandwidths NR channel bandwidths in MHz
+var nrBandwidths = map[uint32]bool{
+	5: true, 10: true, 15: true, 20: true, 25: true, 30: true, 40: true, 50: true, 60: true, 70: true, 80: true,
+	90: true, 100: true, 200: true, 400: true,
+}
+
+// NRCGI NR cell global identity, whose PLMN identity and 36 bit NR cell identity are carried as hex strings
+type NRCGI struct {
+	PLMNIdentity   string `json:"pLMNIdentity"`
+	NRCellIdentity string `json:"nRCellIdentity"`
+}
+
+// CellGlobalID global identity of a cell
+type CellGlobalID struct {
+	NRCGI *NRCGI `json:"nRCGI,omitempty"`
+}
+
+// newCellGlobalID returns the global identity of the given cell
+func newCellGlobalID(ncgi ransimtypes.NCGI) CellGlobalID {
+	return CellGlobalID{
+		NRCGI: &NRCGI{
+			PLMNIdentity:   fmt.Sprintf("%06x", uint32(model.GetPlmnID(ncgi))),
+			NRCellIdentity: fmt.Sprintf("%09x", uint64(ransimtypes.GetNCI(ncgi))),
+		},
+	}
+}
+
+// NCGI returns the NCGI of the cell
+func (id CellGlobalID) NCGI() (ransimtypes.NCGI, error) {
+	if id.NRCGI == nil {
+		return 0, errors.NewInvalid("cell global ID has no NR CGI")
+	}
+	plmnID, err := strconv.ParseUint(id.NRCGI.PLMNIdentity, 16, 24)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid PLMN identity %s", id.NRCGI.PLMNIdentity)
+	}
+	nci, err := strconv.ParseUint(id.NRCGI.NRCellIdentity, 16, 36)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid NR cell identity %s", id.NRCGI.NRCellIdentity)
+	}
+	return ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci)), nil
+}
+
+// CellAttributes attributes of the O-NRCellDU configuration structure supported by the simulator; the attributes
+// which are not set are left unchanged by a control
+type CellAttributes struct {
+	AdministrativeState  *string  `json:"administrativeState,omitempty"`
+	BSChannelBwDL        *uint32  `json:"bSChannelBwDL,omitempty"`        // MHz
+	ConfiguredMaxTxPower *float64 `json:"configuredMaxTxPower,omitempty"` // dB
+}
+
+// newCellAttributes returns the attributes of the given cell; an out of service cell is locked
+func newCellAttributes(cell *model.Cell) CellAttributes {
+	state := administrativeStateUnlocked
+	if cell.Failed {
+		state = administrativeStateLocked
+	}
+	bandwidth := cell.GetBandwidth()
+	power := cell.TxPowerDB
+	return CellAttributes{
+		AdministrativeState:  &state,
+		BSChannelBwDL:        &bandwidth,
+		ConfiguredMaxTxPower: &power,
+	}
+}
+
+// equal returns true if the attributes have the same values
+func (a CellAttributes) equal(b CellAttributes) bool {
+	return equalStrings(a.AdministrativeState, b.AdministrativeState) &&
+		equalUint32s(a.BSChannelBwDL, b.BSChannelBwDL) &&
+		equalFloats(a.ConfiguredMaxTxPower, b.ConfiguredMaxTxPower)
+}
+
+func equalStrings(a, b *string) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func equalUint32s(a, b *uint32) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+func equalFloats(a, b *float64) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
+
+// validate returns an Invalid error if an attribute has an invalid value
+func (a CellAttributes) validate() error {
+	if a.AdministrativeState != nil && *a.AdministrativeState != administrativeStateLocked &&
+		*a.AdministrativeState != administrativeStateUnlocked {
+		return errors.NewInvalid("invalid %s %s", attributeAdministrativeState, *a.AdministrativeState)
+	}
+	if a.BSChannelBwDL != nil && !nrBandwidths[*a.BSChannelBwDL] {
+		return errors.NewInvalid("invalid %s %d MHz", attributeBSChannelBwDL, *a.BSChannelBwDL)
+	}
+	return nil
+}
+
+// RanConfigurationStructure values of the attributes of a configuration structure
+type RanConfigurationStructure struct {
+	RanConfigurationStructure CellAttributes `json:"ranConfigurationStructure"`
+}
+
+// SupportedAttribute attribute of a configuration structure supported by the RAN function
+type SupportedAttribute struct {
+	AttributeName string `json:"attributeName"`
+}
+
+// SupportedStructure configuration structure supported by the RAN function
+type SupportedStructure struct {
+	RanConfigurationStructureName string               `json:"ranConfigurationStructureName"`
+	ListOfSupportedAttributes     []SupportedAttribute `json:"listOfSupportedAttributes"`
+}
+
+// CellForRanFunctionDefinition configuration structures supported for a cell
+type CellForRanFunctionDefinition struct {
+	CellGlobalID                                       CellGlobalID         `json:"cellGlobalId"`
+	ListOfSupportedCellLevelRANConfigurationStructures []SupportedStructure `json:"listOfSupportedCellLevelRANConfigurationStructures"`
+}
+
+// RanFunctionName name of the RAN function
+type RanFunctionName struct {
+	RanFunctionShortName   string `json:"ranFunctionShortName"`
+	RanFunctionE2SMOID     string `json:"ranFunctionE2SMOID"`
+	RanFunctionDescription string `json:"ranFunctionDescription"`
+	RanFunctionInstance    int32  `json:"ranFunctionInstance"`
+}
+
+// RanFunctionDefinition RAN function description advertised in the E2 setup
+type RanFunctionDefinition struct {
+	RanFunctionName                     RanFunctionName                `json:"ranFunctionName"`
+	ListOfCellsForRANFunctionDefinition []CellForRanFunctionDefinition `json:"listOfCellsForRANFunctionDefinition"`
+}
+
+// EventTriggerFormat2 event trigger upon the change of the configuration of the cells
+type EventTriggerFormat2 struct{}
+
+// EventTriggerFormat3 periodic event trigger
+type EventTriggerFormat3 struct {
+	Period int64 `json:"period"` // ms
+}
+
+// EventTriggerDefinition event trigger definition of a subscription
+type EventTriggerDefinition struct {
+	EventTriggerFormat struct {
+		EventTriggerFormat2 *EventTriggerFormat2 `json:"eventTriggerFormat2,omitempty"`
+		EventTriggerFormat3 *EventTriggerFormat3 `json:"eventTriggerFormat3,omitempty"`
+	} `json:"eventTriggerFormat"`
+}
+
+// IndicationHeader indication header reporting the reason and time of the indication
+type IndicationHeader struct {
+	IndicationHeaderFormat struct {
+		IndicationHeaderFormat1 IndicationHeaderFormat1 `json:"indicationHeaderFormat1"`
+	} `json:"indicationHeaderFormat"`
+}
+
+// IndicationHeaderFormat1 format 1 of the indication header
+type IndicationHeaderFormat1 struct {
+	IndicationReason string `json:"indicationReason"`
+	EventTime        string `json:"eventTime"`
+}
+
+// ConfigurationStructureReported configuration structure reported for a cell
+type ConfigurationStructureReported struct {
+	ChangeType                    string                    `json:"changeType"`
+	RanConfigurationStructureName string                    `json:"ranConfigurationStructureName"`
+	ValuesOfAttributes            RanConfigurationStructure `json:"valuesOfAttributes"`
+}
+
+// CellReported configuration of a cell reported in an indication
+type CellReported struct {
+	CellGlobalID                          CellGlobalID                     `json:"cellGlobalId"`
+	ListOfConfigurationStructuresReported []ConfigurationStructureReported `json:"listOfConfigurationStructuresReported"`
+}
+
+// IndicationMessage indication message reporting the configuration of the cells
+type IndicationMessage struct {
+	IndicationMessageFormat struct {
+		IndicationMessageFormat2 IndicationMessageFormat2 `json:"indicationMessageFormat2"`
+	} `json:"indicationMessageFormat"`
+}
+
+// IndicationMessageFormat2 format 2 of the indication message, reporting cell level configuration structures
+type IndicationMessageFormat2 struct {
+	ListOfCellsReported []CellReported `json:"listOfCellsReported"`
+}
+
+// ControlHeader control header of a control request
+type ControlHeader struct {
+	ControlHeaderFormat struct {
+		ControlHeaderFormat1 *ControlHeaderFormat1 `json:"controlHeaderFormat1,omitempty"`
+	} `json:"controlHeaderFormat"`
+}
+
+// ControlHeaderFormat1 format 1 of the control header
+type ControlHeaderFormat1 struct {
+	RicStyleType int32 `json:"ricStyleType"`
+}
+
+// ConfigurationStructure new values of the attributes of a configuration structure of a cell
+type ConfigurationStructure struct {
+	RanConfigurationStructureName string                    `json:"ranConfigurationStructureName"`
+	NewValuesOfAttributes         RanConfigurationStructure `json:"newValuesOfAttributes"`
+}
+
+// CellControlled configuration structures of a cell controlled by a control request
+type CellControlled struct {
+	CellGlobalID                  CellGlobalID             `json:"cellGlobalId"`
+	ListOfConfigurationStructures []ConfigurationStructure `json:"listOfConfigurationStructures"`
+}
+
+// ControlMessage control message setting the configuration of cells
+type ControlMessage struct {
+	ControlMessageFormat struct {
+		ControlMessageFormat2 *ControlMessageFormat2 `json:"controlMessageFormat2,omitempty"`
+	} `json:"controlMessageFormat"`
+}
+
+// ControlMessageFormat2 format 2 of the control message, controlling cell level configuration structures
+type ControlMessageFormat2 struct {
+	ListOfCellsControlled []CellControlled `json:"listOfCellsControlled"`
+}
+
+// ConfigurationStructureAccepted configuration structure applied to a cell
+type ConfigurationStructureAccepted struct {
+	RanConfigurationStructureName string `json:"ranConfigurationStructureName"`
+	AppliedTimestamp              string `json:"appliedTimestamp"`
+}
+
+// ConfigurationStructureFailed configuration structure which could not be applied to a cell
+type ConfigurationStructureFailed struct {
+	RanConfigurationStructureName string `json:"ranConfigurationStructureName"`
+	Cause                         string `json:"cause"`
+}
+
+// CellOutcome outcome of the control of a cell
+type CellOutcome struct {
+	CellGlobalID                                 CellGlobalID                     `json:"cellGlobalId"`
+	ListOfConfigurationStructuresAccepted        []ConfigurationStructureAccepted `json:"listOfConfigurationStructuresAccepted"`
+	ListOfConfigurationStructuresFailedToControl []ConfigurationStructureFailed   `json:"listOfConfigurationStructuresFailedToControl"`
+}
+
+// ControlOutcome outcome of a control request
+type ControlOutcome struct {
+	ControlOutcomeFormat struct {
+		ControlOutcomeFormat2 ControlOutcomeFormat2 `json:"controlOutcomeFormat2"`
+	} `json:"controlOutcomeFormat"`
+}
+
+// ControlOutcomeFormat2 format 2 of the control outcome, reporting the outcome of each controlled cell
+type ControlOutcomeFormat2 struct {
+	ReceivedTimestamp     string        `json:"receivedTimestamp"`
+	ListOfCellsControlled []CellOutcome `json:"listOfCellsControlled"`
+}
@@ -6,10 +6,9 @@ package kpm
 
 import (
 	e2sm_kpm_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm/v1beta1/e2sm-kpm-ies"
-	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
-	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -38,33 +37,33 @@ func getReportPeriods() map[string]int32 {
 	}
 }
 
-// getReportPeriod extracts report period
-func (sm *Client) getReportPeriod(request *e2appducontents.RicsubscriptionRequest) (int32, error) {
+// DecodeEventTrigger decodes the KPM event trigger definition; KPM only supports periodic reports
+func (sm *Client) DecodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
 	modelPlugin, err := sm.getModelPlugin()
 	if err != nil {
-		log.Error(err)
-		return 0, err
+		log.Errorf("%s: %v", sm.logPrefix, err)
+		return nil, err
 	}
 
-	var eventTriggerAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
-			eventTriggerAsnBytes = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
-			break
-		}
-	}
-	eventTriggerProtoBytes, err := modelPlugin.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
+	eventTriggerProtoBytes, err := modelPlugin.EventTriggerDefinitionASN1toProto(definition)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	eventTriggerDefinition := &e2sm_kpm_ies.E2SmKpmEventTriggerDefinition{}
 	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	policyTests := eventTriggerDefinition.GetEventDefinitionFormat1().GetPolicyTestList()
+	if len(policyTests) == 0 {
+		return nil, trigger.NewMalformed("event trigger definition has no policy test")
+	}
+	reportPeriod := policyTests[0].ReportPeriodIe.Enum().String()
+	interval, ok := getReportPeriods()[reportPeriod]
+	if !ok {
+		return nil, trigger.NewUnsupported("report period %s is not supported", reportPeriod)
 	}
-	reportPeriod := eventTriggerDefinition.GetEventDefinitionFormat1().PolicyTestList[0].ReportPeriodIe.Enum().String()
-	interval := getReportPeriods()[reportPeriod]
-	return interval, nil
+	return &trigger.Trigger{Type: trigger.Periodic, Period: int64(interval)}, nil
 }
 
 func (sm *Client) getModelPlugin() (modelplugins.ServiceModel, error) {
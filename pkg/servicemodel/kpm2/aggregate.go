@@ -0,0 +1,83 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+)
+
+// maxAggregatedRecords maximum number of measurement records held back for a cell; the oldest ones are dropped
+// beyond it
+const maxAggregatedRecords = 1024
+
+// heldRecords measurement records of a cell held back by the indication rate cap of the node
+type heldRecords struct {
+	startTime time.Time // start of the first held granularity period
+	items     []*e2smkpmv2.MeasurementDataItem
+}
+
+// add appends the records of the granularity periods from the start time, dropping the oldest records over the limit
+func (h *heldRecords) add(items []*e2smkpmv2.MeasurementDataItem, startTime time.Time, granularity int64) {
+	if len(h.items) == 0 {
+		h.startTime = startTime
+	}
+	h.items = append(h.items, items...)
+	if dropped := len(h.items) - maxAggregatedRecords; dropped > 0 {
+		h.items = h.items[dropped:]
+		h.startTime = h.startTime.Add(time.Duration(int64(dropped)*granularity) * time.Millisecond)
+	}
+}
+
+// sendAggregatedIndicationFormat1 sends the indication of the given admitted action for the given cell if the
+// indication rate cap of the node allows it, reporting the records held back since the last indication along with
+// those of the reporting interval that just ended; the records are held back otherwise
+func (sm *Client) sendAggregatedIndicationFormat1(ctx context.Context, ncgi ransimtypes.NCGI,
+	subscription *subutils.Subscription,
+	actionID e2aptypes.RicActionID,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
+	interval int64,
+	held map[ransimtypes.NCGI]*heldRecords) error {
+	format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
+	if format1 == nil || format1.GetCellObjId().GetValue() != strconv.FormatUint(uint64(ncgi), 16) {
+		return nil
+	}
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return err
+	}
+
+	startTime := clock.Now().Add(-time.Duration(interval) * time.Millisecond)
+	measDataItems, err := sm.collectPeriods(ctx, ncgi, actionDefinition, interval)
+	if err != nil {
+		return err
+	}
+	records, ok := held[ncgi]
+	if !ok {
+		records = &heldRecords{}
+		held[ncgi] = records
+	}
+	records.add(measDataItems, startTime, format1.GetGranulPeriod().GetValue())
+	if !sm.ServiceModel.AllowIndication(ctx, sub) {
+		log.Debugf("%s: Holding back %d measurement records of cell %v", sm.logPrefix, len(records.items), ncgi)
+		return nil
+	}
+
+	ricIndication, err := sm.buildRicIndicationFormat1(ncgi, subscription, actionID, actionDefinition, records.items, records.startTime)
+	delete(held, ncgi)
+	if err != nil {
+		return err
+	}
+	return sm.ServiceModel.DeliverIndication(ctx, sub, ricIndication)
+}
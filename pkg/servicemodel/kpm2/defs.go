@@ -24,6 +24,62 @@ const (
 	RRCConnAvg
 	// RRCConnMax  the max number of users in RRC connected mode during each granularity period.
 	RRCConnMax
+	// DRBMeanActiveUeDl the mean number of users with downlink traffic on the cell; the users with a split bearer
+	// count for the share of their traffic carried by the cell
+	DRBMeanActiveUeDl
+	// DRBUEThpDl the mean downlink throughput in kbps of the users served by the cell, over all their aggregated carriers
+	DRBUEThpDl
+	// RRUPrbUsedDl the number of downlink PRBs of the cell allocated to the users
+	RRUPrbUsedDl
+	// MMHoPrepAttSum total number of handover preparation attempts from the cell
+	MMHoPrepAttSum
+	// MMHoExeAttSum total number of handover execution attempts from the cell
+	MMHoExeAttSum
+	// MMHoExeSuccSum total number of successful handover executions from the cell
+	MMHoExeSuccSum
+	// MMHoPrepTimeMean the mean handover preparation time in ms of the handovers from the cell
+	MMHoPrepTimeMean
+	// MMHoExeTimeMean the mean execution time in ms of the successful handovers from the cell
+	MMHoExeTimeMean
+	// MMHoInterruptionTimeMean the mean time in ms during which the UEs are detached from both cells of the successful
+	// handovers from the cell
+	MMHoInterruptionTimeMean
+	// MMHoExeFailSum total number of failed handover executions from the cell
+	MMHoExeFailSum
+	// MMHoPingPongSum total number of handovers from the cell back to the cell the UEs had just left
+	MMHoPingPongSum
+	// RACHAttSum total number of random access attempts on the cell
+	RACHAttSum
+	// RACHSuccSum total number of successful random accesses on the cell
+	RACHSuccSum
+	// RACHFailSum total number of random accesses on the cell which failed after the maximum number of preambles
+	RACHFailSum
+	// RACHPreamblesSum total number of preambles transmitted on the cell
+	RACHPreamblesSum
+	// CARRWBCQIMean the mean wideband CQI reported by the RRC connected users served by the cell, derived from their
+	// SINR; the mean of the wideband CQI distribution of 3GPP TS 28.552
+	CARRWBCQIMean
+	// L1MPHRMean the mean power headroom in dB reported by the RRC connected users served by the cell
+	L1MPHRMean
+	// L1MULTxPowerMean the mean uplink transmission power in dBm of the RRC connected users served by the cell
+	L1MULTxPowerMean
+	// L1MULSINRMean the mean uplink SINR in dB received by the cell from the RRC connected users it serves
+	L1MULSINRMean
+	// DRBUEThpUl the mean uplink throughput in kbps of the RRC connected users served by the cell
+	DRBUEThpUl
+	// MMHoTooEarlySum total number of handovers from the cell which were too early, the radio link failing during or
+	// shortly after the handover and the UEs re-establishing their connection on the cell
+	MMHoTooEarlySum
+	// MMHoTooLateSum total number of handovers from the cell which were too late, the radio link failing on the cell
+	// and the UEs re-establishing their connection on another cell
+	MMHoTooLateSum
+	// MMHoWrongCellSum total number of handovers from the cell to the wrong cell, the radio link failing shortly after
+	// the handover and the UEs re-establishing their connection on a third cell
+	MMHoWrongCellSum
+	// PAGAttSum total number of pagings of idle or inactive UEs broadcast by the cell
+	PAGAttSum
+	// PAGFailSum total number of pagings broadcast by the cell to which the UEs did not respond
+	PAGFailSum
 )
 
 func (m MeasTypeName) String() string {
@@ -34,7 +90,32 @@ func (m MeasTypeName) String() string {
 		"RRC.ConnReEstabAtt.HOFail",
 		"RRC.ConnReEstabAtt.Other",
 		"RRC.Conn.Avg",
-		"RRC.Conn.Max"}[m]
+		"RRC.Conn.Max",
+		"DRB.MeanActiveUeDl",
+		"DRB.UEThpDl",
+		"RRU.PrbUsedDl",
+		"MM.HoPrepAtt.Sum",
+		"MM.HoExeAtt.Sum",
+		"MM.HoExeSucc.Sum",
+		"MM.HoPrepTime.Mean",
+		"MM.HoExeTime.Mean",
+		"MM.HoInterruptionTime.Mean",
+		"MM.HoExeFail.Sum",
+		"MM.HoPingPong.Sum",
+		"RACH.Att.Sum",
+		"RACH.Succ.Sum",
+		"RACH.Fail.Sum",
+		"RACH.Preambles.Sum",
+		"CARR.WBCQI.Mean",
+		"L1M.PHR.Mean",
+		"L1M.ULTxPower.Mean",
+		"L1M.ULSINR.Mean",
+		"DRB.UEThpUl",
+		"MM.HoTooEarly.Sum",
+		"MM.HoTooLate.Sum",
+		"MM.HoWrongCell.Sum",
+		"PAG.Att.Sum",
+		"PAG.Fail.Sum"}[m]
 }
 
 // MeasType meas type
@@ -76,4 +157,121 @@ var measTypes = []MeasType{
 		measTypeName: RRCConnMax,
 		measTypeID:   8,
 	},
+	{
+		measTypeName: DRBMeanActiveUeDl,
+		measTypeID:   9,
+	},
+	{
+		measTypeName: DRBUEThpDl,
+		measTypeID:   10,
+	},
+	{
+		measTypeName: RRUPrbUsedDl,
+		measTypeID:   11,
+	},
+	{
+		measTypeName: MMHoPrepAttSum,
+		measTypeID:   12,
+	},
+	{
+		measTypeName: MMHoExeAttSum,
+		measTypeID:   13,
+	},
+	{
+		measTypeName: MMHoExeSuccSum,
+		measTypeID:   14,
+	},
+	{
+		measTypeName: MMHoPrepTimeMean,
+		measTypeID:   15,
+	},
+	{
+		measTypeName: MMHoExeTimeMean,
+		measTypeID:   16,
+	},
+	{
+		measTypeName: MMHoInterruptionTimeMean,
+		measTypeID:   17,
+	},
+	{
+		measTypeName: MMHoExeFailSum,
+		measTypeID:   18,
+	},
+	{
+		measTypeName: MMHoPingPongSum,
+		measTypeID:   19,
+	},
+	{
+		measTypeName: RACHAttSum,
+		measTypeID:   20,
+	},
+	{
+		measTypeName: RACHSuccSum,
+		measTypeID:   21,
+	},
+	{
+		measTypeName: RACHFailSum,
+		measTypeID:   22,
+	},
+	{
+		measTypeName: RACHPreamblesSum,
+		measTypeID:   23,
+	},
+	{
+		measTypeName: CARRWBCQIMean,
+		measTypeID:   24,
+	},
+	{
+		measTypeName: L1MPHRMean,
+		measTypeID:   25,
+	},
+	{
+		measTypeName: L1MULTxPowerMean,
+		measTypeID:   26,
+	},
+	{
+		measTypeName: L1MULSINRMean,
+		measTypeID:   27,
+	},
+	{
+		measTypeName: DRBUEThpUl,
+		measTypeID:   28,
+	},
+	{
+		measTypeName: MMHoTooEarlySum,
+		measTypeID:   29,
+	},
+	{
+		measTypeName: MMHoTooLateSum,
+		measTypeID:   30,
+	},
+	{
+		measTypeName: MMHoWrongCellSum,
+		measTypeID:   31,
+	},
+	{
+		measTypeName: PAGAttSum,
+		measTypeID:   32,
+	},
+	{
+		measTypeName: PAGFailSum,
+		measTypeID:   33,
+	},
+}
+
+// getMeasTypes returns the supported measurement types with the given names; all if no names are given
+func getMeasTypes(names []string) []MeasType {
+	if len(names) == 0 {
+		return measTypes
+	}
+	result := make([]MeasType, 0, len(names))
+	for _, measType := range measTypes {
+		for _, name := range names {
+			if measType.measTypeName.String() == name {
+				result = append(result, measType)
+				break
+			}
+		}
+	}
+	return result
 }
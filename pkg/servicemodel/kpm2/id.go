@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/id/cellglobalid"
+	kpm2eNBID "github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/id/enbid"
+	kpm2gNBID "github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/id/gnbid"
+)
+
+// newGlobalKpmNodeID builds the KPM node ID of the node following its RAT: the 20 bit macro eNB ID of E-UTRA nodes
+// and the 22 bit gNB ID of NR nodes
+func newGlobalKpmNodeID(node model.Node, plmnID *ransimtypes.Uint24) (*e2smkpmv2.GlobalKpmnodeId, error) {
+	if node.IsEUTRA() {
+		eNBID := &asn1.BitString{
+			Value: utils.Uint64ToBitString(uint64(node.GnbID), 20),
+			Len:   20,
+		}
+		return kpm2eNBID.NewGlobalENBID(
+			kpm2eNBID.WithPlmnID(plmnID.Value()),
+			kpm2eNBID.WithMacroENBID(eNBID)).Build()
+	}
+
+	gNBID := &asn1.BitString{
+		Value: utils.Uint64ToBitString(uint64(node.GnbID), 22),
+		Len:   22,
+	}
+	return kpm2gNBID.NewGlobalGNBID(
+		kpm2gNBID.WithPlmnID(plmnID.Value()),
+		kpm2gNBID.WithGNBIDChoice(gNBID)).Build()
+}
+
+// newCellGlobalID builds the cell global ID of a cell of the node following its RAT: the ECGI, with a 28 bit E-UTRAN
+// cell identity, of the cells of E-UTRA nodes and the NCGI, with a 36 bit NR cell identity, of the cells of NR nodes
+func newCellGlobalID(node model.Node, plmnID *ransimtypes.Uint24, ncgi ransimtypes.NCGI) (*e2smkpmv2.CellGlobalId, error) {
+	if node.IsEUTRA() {
+		ecibs := &asn1.BitString{
+			Value: utils.Uint64ToBitString(uint64(node.ECI(ncgi)), 28),
+			Len:   28,
+		}
+		return cellglobalid.NewGlobalEUTRACGIID(cellglobalid.WithEUTRAPlmnID(plmnID),
+			cellglobalid.WithEUTRACellID(ecibs)).
+			Build()
+	}
+
+	nci := ransimtypes.GetNCI(ncgi)
+	ncibs := &asn1.BitString{
+		Value: utils.Uint64ToBitString(uint64(nci), 36),
+		Len:   36,
+	}
+	return cellglobalid.NewGlobalNRCGIID(cellglobalid.WithPlmnID(plmnID),
+		cellglobalid.WithNRCellID(ncibs)).
+		Build()
+}
@@ -6,15 +6,13 @@ package kpm2
 
 import (
 	"context"
-	"encoding/binary"
+	"math"
 	"strconv"
+	"sync"
 	"time"
 
-	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
-
-	"github.com/onosproject/ran-simulator/pkg/utils"
-
-	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/id/cellglobalid"
+	"github.com/onosproject/ran-simulator/pkg/loadtest"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/measobjectitem"
 
@@ -23,10 +21,11 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/ranfuncdescription"
 
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/nodeitem"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
 
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/measurments"
 
-	kpm2gNBID "github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/id/gnbid"
 	kpm2IndicationHeader "github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/indication"
 	kpm2MessageFormat1 "github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/indication/messageformat1"
 
@@ -39,15 +38,21 @@ import (
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"github.com/onosproject/onos-lib-go/pkg/errors"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/clock"
 	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/monitor"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/stats"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	"github.com/onosproject/ran-simulator/pkg/store/ues"
 	e2apIndicationUtils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -75,9 +80,15 @@ const (
 	vendorName         string = "ONF"
 )
 
+var defaultReportStyles = []model.ReportStyle{{Type: ricStyleType, Name: ricStyleName}}
+
 // Client kpm service model client
 type Client struct {
 	ServiceModel *registry.ServiceModel
+	measTypes    []MeasType
+	config       model.KPMConfig
+	reportStyles []model.ReportStyle
+	logPrefix    string
 }
 
 // E2ConnectionUpdate implements connection update procedure
@@ -87,7 +98,8 @@ func (sm *Client) E2ConnectionUpdate(ctx context.Context, request *e2appduconten
 
 // NewServiceModel creates a new service model
 func NewServiceModel(node model.Node, model *model.Model,
-	subStore *subscriptions.Subscriptions, nodeStore nodes.Store, ueStore ues.Store) (registry.ServiceModel, error) {
+	subStore *subscriptions.Subscriptions, nodeStore nodes.Store, ueStore ues.Store, cellStore cells.Store,
+	metricStore metrics.Store) (registry.ServiceModel, error) {
 	kpmSm := registry.ServiceModel{
 		RanFunctionID: registry.Kpm2,
 		ModelName:     ranFunctionShortName,
@@ -99,27 +111,30 @@ func NewServiceModel(node model.Node, model *model.Model,
 		Subscriptions: subStore,
 		Nodes:         nodeStore,
 		UEs:           ueStore,
+		CellStore:     cellStore,
+		MetricStore:   metricStore,
 	}
+	// Falls back to the default parameters if the node does not configure any
+	smConfig, _ := model.GetNodeServiceModel(node, int(registry.Kpm2))
 	kpmClient := &Client{
 		ServiceModel: &kpmSm,
+		measTypes:    getMeasTypes(smConfig.KPM.Measurements),
+		config:       smConfig.KPM,
+		logPrefix:    logfields.Node(node.GnbID),
 	}
 
 	kpmSm.Client = kpmClient
 
-	plmnID := ransimtypes.NewUint24(uint32(kpmSm.Model.PlmnID))
+	nodePlmnID, err := kpmSm.Model.GetNodePlmnID(node)
+	if err != nil {
+		return registry.ServiceModel{}, err
+	}
+	plmnID := plmn.ToUint24(nodePlmnID)
 
 	cells := node.Cells
 	cellMeasObjectItems := make([]*e2smkpmv2.CellMeasurementObjectItem, 0)
 	for _, cellNcgi := range cells {
-		nci := ransimtypes.GetNCI(cellNcgi)
-		ncibs := &asn1.BitString{
-			Value: utils.Uint64ToBitString(uint64(nci), 36),
-			Len:   36,
-		}
-		cellGlobalID, err := cellglobalid.
-			NewGlobalNRCGIID(cellglobalid.WithPlmnID(plmnID),
-				cellglobalid.WithNRCellID(ncibs)).
-			Build()
+		cellGlobalID, err := newCellGlobalID(node, plmn.ToUint24(kpmSm.Model.GetCellPlmnID(cellNcgi)), cellNcgi)
 		if err != nil {
 			return registry.ServiceModel{}, err
 		}
@@ -133,14 +148,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 	}
 
 	// Creates an indication header
-	gNBID := &asn1.BitString{
-		Value: utils.Uint64ToBitString(uint64(node.GnbID), 22),
-		Len:   22,
-	}
-
-	globalKPMNodeID, err := kpm2gNBID.NewGlobalGNBID(
-		kpm2gNBID.WithPlmnID(plmnID.Value()),
-		kpm2gNBID.WithGNBIDChoice(gNBID)).Build()
+	globalKPMNodeID, err := newGlobalKpmNodeID(node, plmnID)
 	if err != nil {
 		log.Error(err)
 		return registry.ServiceModel{}, err
@@ -163,7 +171,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 		Value: make([]*e2smkpmv2.MeasurementInfoActionItem, 0),
 	}
 
-	for _, measType := range measTypes {
+	for _, measType := range kpmClient.measTypes {
 		log.Debug("Measurement Name and ID:", measType.measTypeName, measType.measTypeID)
 		measInfoActionItem, _ := measurments.NewMeasurementInfoActionItem(
 			measurments.WithMeasTypeName(measType.measTypeName.String()),
@@ -173,17 +181,28 @@ func NewServiceModel(node model.Node, model *model.Model,
 
 	}
 
-	reportStyleItem := reportstyle.NewReportStyleItem(
-		reportstyle.WithRICStyleType(ricStyleType),
-		reportstyle.WithRICStyleName(ricStyleName),
-		reportstyle.WithRICFormatType(ricFormatType),
-		reportstyle.WithMeasInfoActionList(&measInfoActionList),
-		reportstyle.WithIndicationHdrFormatType(ricIndHdrFormat),
-		reportstyle.WithIndicationMsgFormatType(ricIndMsgFormat)).
-		Build()
+	// The report styles of the node replace those of the service model
+	reportStyles := node.ReportStyles
+	if len(reportStyles) == 0 {
+		reportStyles = smConfig.KPM.ReportStyles
+	}
+	if len(reportStyles) == 0 {
+		reportStyles = defaultReportStyles
+	}
+	kpmClient.reportStyles = reportStyles
 
 	ricReportStyleList := make([]*e2smkpmv2.RicReportStyleItem, 0)
-	ricReportStyleList = append(ricReportStyleList, reportStyleItem)
+	for _, style := range reportStyles {
+		reportStyleItem := reportstyle.NewReportStyleItem(
+			reportstyle.WithRICStyleType(style.Type),
+			reportstyle.WithRICStyleName(style.Name),
+			reportstyle.WithRICFormatType(formatOrDefault(style.ActionFormat, ricFormatType)),
+			reportstyle.WithMeasInfoActionList(&measInfoActionList),
+			reportstyle.WithIndicationHdrFormatType(formatOrDefault(style.HeaderFormat, ricIndHdrFormat)),
+			reportstyle.WithIndicationMsgFormatType(formatOrDefault(style.MessageFormat, ricIndMsgFormat))).
+			Build()
+		ricReportStyleList = append(ricReportStyleList, reportStyleItem)
+	}
 
 	ranFuncDescPdu, err := ranfuncdescription.NewRANFunctionDescription(
 		ranfuncdescription.WithRANFunctionShortName(ranFunctionShortName),
@@ -212,6 +231,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 		log.Error(err)
 		return registry.ServiceModel{}, err
 	}
+	selfcheck.Check(&kpm2ServiceModel, selfcheck.RanFuncDescription, ranFuncDescPdu, ranFuncDescBytes)
 	kpmSm.Description = ranFuncDescBytes
 	return kpmSm, nil
 }
@@ -223,25 +243,82 @@ func (sm *Client) collect(ctx context.Context,
 	measRecord := e2smkpmv2.MeasurementRecord{
 		Value: make([]*e2smkpmv2.MeasurementRecordItem, 0),
 	}
+	// A failed cell has no measurements to report
+	failed := false
+	if cell, err := sm.ServiceModel.CellStore.Get(ctx, cellNCGI); err == nil {
+		failed = cell.Failed
+	}
 
 	for _, measInfo := range measInfoList.Value {
-		for _, measType := range measTypes {
+		for _, measType := range sm.measTypes {
 			if measType.measTypeName.String() == measInfo.MeasType.GetMeasName().Value {
+				if failed {
+					measRecord.Value = append(measRecord.Value, measurments.NewMeasurementRecordItemNoValue())
+					continue
+				}
 				switch measType.measTypeName {
 				case RRCConnMax:
-					log.Debugf("Max number of UEs for Cell %v set for RRC Con Max: %v",
+					log.Debugf("%s: Max number of UEs for Cell %v set for RRC Con Max: %v", sm.logPrefix,
 						cellNCGI, int64(sm.ServiceModel.UEs.MaxUEsPerCell(ctx, uint64(cellNCGI))))
 					measRecordInteger := measurments.NewMeasurementRecordItemInteger(
 						measurments.WithIntegerValue(int64(sm.ServiceModel.UEs.MaxUEsPerCell(ctx, uint64(cellNCGI))))).
 						Build()
 					measRecord.Value = append(measRecord.Value, measRecordInteger)
 				case RRCConnAvg:
-					log.Debugf("Avg number of UEs for Cell %v set for RRC Con Max: %v",
-						cellNCGI, int64(sm.ServiceModel.UEs.LenPerCell(ctx, uint64(cellNCGI))))
+					log.Debugf("%s: Avg number of UEs for Cell %v set for RRC Con Avg: %v", sm.logPrefix,
+						cellNCGI, int64(sm.ServiceModel.UEs.ConnectedLenPerCell(ctx, uint64(cellNCGI))))
 					measRecordInteger := measurments.NewMeasurementRecordItemInteger(
-						measurments.WithIntegerValue(int64(sm.ServiceModel.UEs.LenPerCell(ctx, uint64(cellNCGI))))).
+						measurments.WithIntegerValue(int64(sm.ServiceModel.UEs.ConnectedLenPerCell(ctx, uint64(cellNCGI))))).
 						Build()
 					measRecord.Value = append(measRecord.Value, measRecordInteger)
+				case DRBMeanActiveUeDl:
+					measRecordReal := measurments.NewMeasurementRecordItemReal(
+						measurments.WithRealValue(sm.ServiceModel.UEs.TrafficLoadPerCell(ctx, uint64(cellNCGI)))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordReal)
+				case DRBUEThpDl:
+					measRecordReal := measurments.NewMeasurementRecordItemReal(
+						measurments.WithRealValue(sm.ServiceModel.UEs.ThroughputPerCell(ctx, uint64(cellNCGI)))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordReal)
+				case RRUPrbUsedDl:
+					measRecordInteger := measurments.NewMeasurementRecordItemInteger(
+						measurments.WithIntegerValue(int64(math.Round(sm.ServiceModel.UEs.PrbUsedPerCell(ctx, uint64(cellNCGI)))))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordInteger)
+				case RRCConnEstabAttSum, RRCConnEstabSuccSum, RRCConnReEstabAttSum,
+					RRCConnReEstabAttreconfigFail, RRCConnReEstabAttHOFail, RRCConnReEstabAttOther,
+					PAGAttSum, PAGFailSum:
+					// RRC connection statistics are cumulative counters kept by the RRC state machine
+					counter := stats.GetCounter(ctx, sm.ServiceModel.MetricStore, cellNCGI, measType.measTypeName.String())
+					measRecordInteger := measurments.NewMeasurementRecordItemInteger(
+						measurments.WithIntegerValue(int64(counter))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordInteger)
+				case MMHoPrepAttSum, MMHoExeAttSum, MMHoExeSuccSum, MMHoExeFailSum, MMHoPingPongSum,
+					MMHoTooEarlySum, MMHoTooLateSum, MMHoWrongCellSum,
+					RACHAttSum, RACHSuccSum, RACHFailSum, RACHPreamblesSum:
+					// handover and random access statistics are cumulative counters kept by the mobility driver
+					counter := stats.GetCounter(ctx, sm.ServiceModel.MetricStore, cellNCGI, measType.measTypeName.String())
+					measRecordInteger := measurments.NewMeasurementRecordItemInteger(
+						measurments.WithIntegerValue(int64(counter))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordInteger)
+				case CARRWBCQIMean:
+					measRecordReal := measurments.NewMeasurementRecordItemReal(
+						measurments.WithRealValue(sm.ServiceModel.UEs.CqiPerCell(ctx, uint64(cellNCGI)))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordReal)
+				case L1MPHRMean, L1MULTxPowerMean, L1MULSINRMean, DRBUEThpUl:
+					measRecordReal := measurments.NewMeasurementRecordItemReal(
+						measurments.WithRealValue(uplinkMean(sm.ServiceModel.UEs.UplinkPerCell(ctx, uint64(cellNCGI)), measType.measTypeName))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordReal)
+				case MMHoPrepTimeMean, MMHoExeTimeMean, MMHoInterruptionTimeMean:
+					measRecordReal := measurments.NewMeasurementRecordItemReal(
+						measurments.WithRealValue(hoMeanTime(ctx, sm.ServiceModel.MetricStore, cellNCGI, measType.measTypeName))).
+						Build()
+					measRecord.Value = append(measRecord.Value, measRecordReal)
 				default:
 					measRecordNoValue := measurments.NewMeasurementRecordItemNoValue()
 					measRecord.Value = append(measRecord.Value, measRecordNoValue)
@@ -259,74 +336,101 @@ func (sm *Client) collect(ctx context.Context,
 	return measDataItem, err
 }
 
-func (sm *Client) createIndicationMsgFormat1(ctx context.Context,
-	cellNCGI ransimtypes.NCGI, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition, interval int64) ([]byte, error) {
-	log.Debug("Create Indication message format 1 based on action defs for cell:", cellNCGI)
-	format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
-	measInfoList := format1.GetMeasInfoList()
-	measData := &e2smkpmv2.MeasurementData{
-		Value: make([]*e2smkpmv2.MeasurementDataItem, 0),
-	}
+// collectPeriods collects the measurements of the cell for each granularity period of the reporting interval
+func (sm *Client) collectPeriods(ctx context.Context, cellNCGI ransimtypes.NCGI,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition, interval int64) ([]*e2smkpmv2.MeasurementDataItem, error) {
 	granularity := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1().GetGranulPeriod().Value
 	numDataItems := int(interval / granularity)
 
+	measDataItems := make([]*e2smkpmv2.MeasurementDataItem, 0, numDataItems)
 	for i := 0; i < numDataItems; i++ {
 		measDataItem, err := sm.collect(ctx, actionDefinition, cellNCGI)
 		if err != nil {
-			log.Warn(err)
+			log.Warnf("%s: %v", sm.logPrefix, err)
 			return nil, err
 		}
+		measDataItems = append(measDataItems, measDataItem)
+	}
+	return measDataItems, nil
+}
 
-		measData.Value = append(measData.Value, measDataItem)
+func (sm *Client) createIndicationMsgFormat1(cellNCGI ransimtypes.NCGI, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
+	measDataItems []*e2smkpmv2.MeasurementDataItem, startTime time.Time) ([]byte, error) {
+	log.Debugf("%s: Create Indication message format 1 based on action defs for cell: %v", sm.logPrefix, cellNCGI)
+	format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
+	measInfoList := format1.GetMeasInfoList()
+	measData := &e2smkpmv2.MeasurementData{
+		Value: measDataItems,
 	}
+	granularity := format1.GetGranulPeriod().Value
 	subID := format1.SubscriptId.GetValue()
 
-	// Creating an indication message format 1
-	indicationMessage := kpm2MessageFormat1.NewIndicationMessage(
-		kpm2MessageFormat1.WithCellObjID(strconv.FormatUint(uint64(cellNCGI), 16)),
-		kpm2MessageFormat1.WithGranularity(uint32(granularity)), // TODO: check if this is a sensible conversion
-		kpm2MessageFormat1.WithSubscriptionID(subID),
-		kpm2MessageFormat1.WithMeasData(measData),
-		kpm2MessageFormat1.WithMeasInfoList(measInfoList))
+	// Creating an indication message format 1, padded with the given number of synthetic measurements
+	encode := func(count int) ([]byte, error) {
+		paddedMeasInfoList, paddedMeasData := measInfoList, measData
+		if count > 0 {
+			var err error
+			paddedMeasInfoList, err = padMeasInfoList(measInfoList, count)
+			if err != nil {
+				return nil, err
+			}
+			paddedMeasData = &e2smkpmv2.MeasurementData{
+				Value: padMeasData(measDataItems, count),
+			}
+		}
+		indicationMessage := kpm2MessageFormat1.NewIndicationMessage(
+			kpm2MessageFormat1.WithCellObjID(strconv.FormatUint(uint64(cellNCGI), 16)),
+			kpm2MessageFormat1.WithGranularity(uint32(granularity)), // TODO: check if this is a sensible conversion
+			kpm2MessageFormat1.WithSubscriptionID(subID),
+			kpm2MessageFormat1.WithMeasData(paddedMeasData),
+			kpm2MessageFormat1.WithMeasInfoList(paddedMeasInfoList),
+			kpm2MessageFormat1.WithCollectionStartTime(startTime))
+		if count == 0 {
+			log.Debugf("%s: Granularity periods reported for cell %v: %v", sm.logPrefix, cellNCGI, indicationMessage.GetGranularityPeriodTimes())
+		}
+		return indicationMessage.ToAsn1Bytes()
+	}
 
-	indicationMessageBytes, err := indicationMessage.ToAsn1Bytes()
+	indicationMessageBytes, err := encode(0)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		return nil, err
 	}
-
+	if sm.config.IndicationSize > len(indicationMessageBytes) {
+		// The unpadded message is sent if the padded one can not be encoded
+		paddedBytes, err := padMessage(sm.config.IndicationSize, encode)
+		if err != nil {
+			log.Warnf("%s: Unable to pad the indication message of cell %v to %d bytes: %v", sm.logPrefix, cellNCGI, sm.config.IndicationSize, err)
+			return indicationMessageBytes, nil
+		}
+		log.Debugf("%s: Padded the indication message of cell %v from %d to %d bytes", sm.logPrefix, cellNCGI, len(indicationMessageBytes), len(paddedBytes))
+		return paddedBytes, nil
+	}
 	return indicationMessageBytes, nil
 }
 
-func (sm *Client) createIndicationHeaderBytes(fileFormatVersion string) ([]byte, error) {
+func (sm *Client) createIndicationHeaderBytes(fileFormatVersion string, startTime time.Time) ([]byte, error) {
 	// Creates an indication header
-	plmnID := ransimtypes.NewUint24(uint32(sm.ServiceModel.Model.PlmnID))
-	gNBID := &asn1.BitString{
-		Value: utils.Uint64ToBitString(uint64(sm.ServiceModel.Node.GnbID), 22),
-		Len:   22,
+	nodePlmnID, err := sm.ServiceModel.Model.GetNodePlmnID(sm.ServiceModel.Node)
+	if err != nil {
+		return nil, err
 	}
-
-	kpmNodeID, err := kpm2gNBID.NewGlobalGNBID(
-		kpm2gNBID.WithPlmnID(plmnID.Value()),
-		kpm2gNBID.WithGNBIDChoice(gNBID)).Build()
-
+	kpmNodeID, err := newGlobalKpmNodeID(sm.ServiceModel.Node, plmn.ToUint24(nodePlmnID))
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		return nil, err
 	}
-	timestamp := make([]byte, 4)
-	binary.BigEndian.PutUint32(timestamp, uint32(time.Now().Unix()))
 	header := kpm2IndicationHeader.NewIndicationHeader(
 		kpm2IndicationHeader.WithGlobalKpmNodeID(kpmNodeID),
 		kpm2IndicationHeader.WithFileFormatVersion(fileFormatVersion),
 		kpm2IndicationHeader.WithSenderName(senderName),
 		kpm2IndicationHeader.WithSenderType(senderType),
 		kpm2IndicationHeader.WithVendorName(vendorName),
-		kpm2IndicationHeader.WithTimeStamp(timestamp))
+		kpm2IndicationHeader.WithCollectionStartTime(startTime))
 
 	indicationHeaderAsn1Bytes, err := header.ToAsn1Bytes()
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		return nil, err
 	}
 
@@ -336,7 +440,8 @@ func (sm *Client) createIndicationHeaderBytes(fileFormatVersion string) ([]byte,
 
 func (sm *Client) sendRicIndicationFormat1(ctx context.Context, ncgi ransimtypes.NCGI,
 	subscription *subutils.Subscription,
-	actionDefinitions []*e2smkpmv2.E2SmKpmActionDefinition,
+	actionID e2aptypes.RicActionID,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
 	interval int64) error {
 	// Creates and sends indication message format 1
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
@@ -345,91 +450,213 @@ func (sm *Client) sendRicIndicationFormat1(ctx context.Context, ncgi ransimtypes
 		return err
 	}
 
-	indicationHeaderBytes, err := sm.createIndicationHeaderBytes(fileFormatVersion1)
+	ricIndication, err := sm.createRicIndicationFormat1(ctx, ncgi, subscription, actionID, actionDefinition, interval)
 	if err != nil {
-		log.Warn(err)
 		return err
 	}
+	if ricIndication == nil {
+		return nil
+	}
+	return sm.ServiceModel.SendIndication(ctx, sub, ricIndication)
+}
 
-	for _, actionDefinition := range actionDefinitions {
-		format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
-		if format1 != nil {
-			cellObjectID := format1.GetCellObjId().Value
-			if cellObjectID == strconv.FormatUint(uint64(ncgi), 16) {
-				log.Debug("Sending indication message for Cell with ID:", cellObjectID)
-				indicationMessageBytes, err := sm.createIndicationMsgFormat1(ctx, ncgi, actionDefinition, interval)
-				if err != nil {
-					return err
-				}
+// createRicIndicationFormat1 creates the indication of the given admitted action for the given cell; it returns
+// no indication if the action definition does not refer to the cell
+func (sm *Client) createRicIndicationFormat1(ctx context.Context, ncgi ransimtypes.NCGI,
+	subscription *subutils.Subscription,
+	actionID e2aptypes.RicActionID,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
+	interval int64) (*e2appducontents.Ricindication, error) {
+	format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
+	if format1 == nil {
+		return nil, nil
+	}
+	cellObjectID := format1.GetCellObjId().Value
+	if cellObjectID != strconv.FormatUint(uint64(ncgi), 16) {
+		return nil, nil
+	}
+	if sm.isUELevel(actionDefinition) {
+		return sm.createRicIndicationFormat2(ctx, ncgi, subscription, actionID, actionDefinition, interval)
+	}
 
-				indication := e2apIndicationUtils.NewIndication(
-					e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
-					e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
-					e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
-					e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
-					e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
-
-				ricIndication, err := indication.Build()
-				if err != nil {
-					log.Error("creating indication message is failed for Cell with ID", ncgi, err)
-					return err
-				}
+	// The indication reports the granularity periods of the reporting interval that just ended
+	startTime := clock.Now().Add(-time.Duration(interval) * time.Millisecond)
+	measDataItems, err := sm.collectPeriods(ctx, ncgi, actionDefinition, interval)
+	if err != nil {
+		return nil, err
+	}
+	return sm.buildRicIndicationFormat1(ncgi, subscription, actionID, actionDefinition, measDataItems, startTime)
+}
 
-				err = sub.E2Channel.RICIndication(ctx, ricIndication)
-				if err != nil {
-					return err
-				}
-			}
-		}
+// buildRicIndicationFormat1 builds the indication of the given admitted action for the given cell reporting the
+// measurement records of the granularity periods from the start time
+func (sm *Client) buildRicIndicationFormat1(ncgi ransimtypes.NCGI,
+	subscription *subutils.Subscription,
+	actionID e2aptypes.RicActionID,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
+	measDataItems []*e2smkpmv2.MeasurementDataItem,
+	startTime time.Time) (*e2appducontents.Ricindication, error) {
+	indicationHeaderBytes, err := sm.createIndicationHeaderBytes(fileFormatVersion1, startTime)
+	if err != nil {
+		log.Warnf("%s: %v", sm.logPrefix, err)
+		return nil, err
 	}
 
-	return nil
+	log.Debugf("%s: Sending indication message for Cell with ID: %v", sm.logPrefix, ncgi)
+	indicationMessageBytes, err := sm.createIndicationMsgFormat1(ncgi, actionDefinition, measDataItems, startTime)
+	if err != nil {
+		return nil, err
+	}
+
+	indication := e2apIndicationUtils.NewIndication(
+		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+		e2apIndicationUtils.WithRicActionID(int32(actionID)),
+		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+
+	ricIndication, err := indication.Build()
+	if err != nil {
+		log.Errorf("%s: creating indication message is failed for Cell with ID %v: %v", sm.logPrefix, ncgi, err)
+		return nil, err
+	}
+	return ricIndication, nil
 }
 
+// sendRicIndication sends the indications of the action; if held is not nil, the measurement records over the
+// indication rate cap of the node are held back in it and aggregated into the next indication
 func (sm *Client) sendRicIndication(ctx context.Context,
-	subscription *subutils.Subscription, actionDefinitions []*e2smkpmv2.E2SmKpmActionDefinition, interval int64) error {
+	subscription *subutils.Subscription, actionID e2aptypes.RicActionID,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition, interval int64, held map[ransimtypes.NCGI]*heldRecords) error {
 	node := sm.ServiceModel.Node
-	// Creates and sends an indication message for each cell in the node that are also specified in Action Definition
+	// Creates and sends an indication message for each cell in the node that are also specified in Action Definition;
+	// a failure on a cell does not prevent reporting the other cells
 	for _, ncgi := range node.Cells {
-		err := sm.sendRicIndicationFormat1(ctx, ncgi, subscription, actionDefinitions, interval)
+		var err error
+		if held != nil && !sm.isUELevel(actionDefinition) {
+			err = sm.sendAggregatedIndicationFormat1(ctx, ncgi, subscription, actionID, actionDefinition, interval, held)
+		} else {
+			err = sm.sendRicIndicationFormat1(ctx, ncgi, subscription, actionID, actionDefinition, interval)
+		}
 		if err != nil {
-			log.Error(err)
-			return err
+			log.Errorf("%s: %v", sm.logPrefix, err)
 		}
 	}
 	return nil
 }
 
-func (sm *Client) reportIndication(ctx context.Context, interval int64, subscription *subutils.Subscription, actionDefinitions []*e2smkpmv2.E2SmKpmActionDefinition) error {
+// reportActions runs the reporting routine of each admitted action at the period derived from its action definition
+func (sm *Client) reportActions(ctx context.Context, reportPeriod int64, subscription *subutils.Subscription,
+	actionDefinitions map[e2aptypes.RicActionID]*e2smkpmv2.E2SmKpmActionDefinition) {
+	var wg sync.WaitGroup
+	for actionID, actionDefinition := range actionDefinitions {
+		wg.Add(1)
+		go func(actionID e2aptypes.RicActionID, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition) {
+			defer wg.Done()
+			interval := getActionReportPeriod(reportPeriod, actionDefinition)
+			_ = sm.reportIndication(ctx, interval, subscription, actionID, actionDefinition)
+		}(actionID, actionDefinition)
+	}
+	wg.Wait()
+}
+
+func (sm *Client) reportIndication(ctx context.Context, interval int64, subscription *subutils.Subscription,
+	actionID e2aptypes.RicActionID, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition) error {
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(sm.logPrefix, subID)
+	log.Debugf("%s: Starting report of action %d with interval %d ms", logPrefix, actionID, interval)
 
 	intervalDuration := time.Duration(interval)
 	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", logPrefix, err)
 		return err
 	}
-	sub.Ticker = time.NewTicker(intervalDuration * time.Millisecond)
+	var held map[ransimtypes.NCGI]*heldRecords
+	if sm.ServiceModel.Node.Indications.Aggregate {
+		held = make(map[ransimtypes.NCGI]*heldRecords)
+	}
+	ticker := monitor.NewTicker(intervalDuration * time.Millisecond)
+	defer ticker.Stop()
 
 	for {
 		select {
-		case <-sub.Ticker.C:
-			log.Debug("Sending Indication Report for subscription:", sub.ID)
-			err = sm.sendRicIndication(ctx, subscription, actionDefinitions, interval)
+		case <-ticker.C:
+			log.Debugf("%s: Sending Indication Report of action %d for subscription: %s", logPrefix, actionID, sub.ID)
+			err = sm.sendRicIndication(ctx, subscription, actionID, actionDefinition, interval, held)
 			if err != nil {
-				log.Error("creating indication message is failed", err)
+				log.Errorf("%s: creating indication message is failed: %v", logPrefix, err)
 				return err
 			}
 
+		case <-ctx.Done():
+			log.Debugf("%s: Subscription is deleted: %v", logPrefix, sub.ID)
+			return nil
+
 		case <-sub.E2Channel.Context().Done():
-			log.Debug("E2 channel context is done")
-			sub.Ticker.Stop()
+			log.Debugf("%s: E2 channel context is done", logPrefix)
 			return nil
 
 		}
 	}
 }
 
+// runLoadTest sends indications of the subscription encoded once upfront at the load test rate of the node,
+// bypassing the simulated mobility and RF conditions
+func (sm *Client) runLoadTest(ctx context.Context, interval int64, subscription *subutils.Subscription, actionDefinitions map[e2aptypes.RicActionID]*e2smkpmv2.E2SmKpmActionDefinition) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(sm.logPrefix, subID)
+
+	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		log.Warnf("%s: %v", logPrefix, err)
+		return err
+	}
+
+	ricIndications := make([]*e2appducontents.Ricindication, 0)
+	for actionID, actionDefinition := range actionDefinitions {
+		actionInterval := getActionReportPeriod(interval, actionDefinition)
+		for _, ncgi := range sm.ServiceModel.Node.Cells {
+			ricIndication, err := sm.createRicIndicationFormat1(ctx, ncgi, subscription, actionID, actionDefinition, actionInterval)
+			if err != nil {
+				log.Warnf("%s: %v", logPrefix, err)
+				return err
+			}
+			if ricIndication != nil {
+				ricIndications = append(ricIndications, ricIndication)
+			}
+		}
+	}
+	if len(ricIndications) == 0 {
+		log.Warnf("%s: No indications to send for subscription: %v", logPrefix, sub.ID)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-sub.E2Channel.Context().Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	// The aggregate rate is shared evenly by all nodes of the model
+	rate := sm.ServiceModel.Model.LoadTest.Rate / float64(len(sm.ServiceModel.Model.Nodes))
+	next := 0
+	generator := loadtest.NewGenerator(rate, func(ctx context.Context) error {
+		ricIndication := ricIndications[next%len(ricIndications)]
+		next++
+		return sub.E2Channel.RICIndication(ctx, ricIndication)
+	})
+	go generator.Report(ctx, sm.ServiceModel.MetricStore, uint64(sm.ServiceModel.Node.GnbID))
+	log.Infof("%s: Starting load test with %.1f indications per second", logPrefix, rate)
+	generator.Run(ctx)
+	return nil
+}
+
 // RICControl implements control handler for kpm service model
 func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
 	return nil, nil, errors.New(errors.NotSupported, "Control operation is not supported")
@@ -437,8 +664,9 @@ func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.Ricco
 
 // RICSubscription implements subscription handler for kpm service model
 func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
-	log.Infof("RIC Subscription request received for e2 node %d and service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	log.Infof("%s: RIC Subscription request received for e2 node %d and service model %s:", sm.logPrefix, sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
 	var ricActionsAccepted []*e2aptypes.RicActionID
+	var reportActions []e2aptypes.RicActionID
 	ricActionsNotAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
 	actionList := subutils.GetRicActionToBeSetupList(request)
 	reqID, err := subutils.GetRequesterID(request)
@@ -461,6 +689,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		// list of accepted actions
 		if actionType == e2apies.RicactionType_RICACTION_TYPE_REPORT {
 			ricActionsAccepted = append(ricActionsAccepted, &actionID)
+			reportActions = append(reportActions, actionID)
 		}
 		// kpm service model does not support INSERT and POLICY actions and
 		// should be added into the list of not admitted actions
@@ -477,7 +706,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 
 	// At least one required action must be accepted otherwise sends a subscription failure response
 	if len(ricActionsAccepted) == 0 {
-		log.Warn("no action is accepted")
+		log.Warnf("%s: no action is accepted", sm.logPrefix)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
@@ -495,14 +724,10 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		return nil, subscriptionFailure, nil
 	}
 
-	reportInterval, err := sm.getReportPeriod(request)
+	eventTrigger, err := trigger.DecodeRequest(request, sm.decodeEventTrigger, trigger.Periodic, trigger.OnChange)
 	if err != nil {
-		log.Warn(err)
-		cause := &e2apies.Cause{
-			Cause: &e2apies.Cause_RicRequest{
-				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
-			},
-		}
+		log.Warnf("%s: %v", sm.logPrefix, err)
+		cause := trigger.GetCause(err)
 		subscription := subutils.NewSubscription(
 			subutils.WithRequestID(*reqID),
 			subutils.WithRanFuncID(*ranFuncID),
@@ -510,7 +735,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 			subutils.WithCause(cause))
 		subscriptionFailure, err := subscription.BuildSubscriptionFailure()
 		if err != nil {
-			log.Warn(err)
+			log.Warnf("%s: %v", sm.logPrefix, err)
 			return nil, subscriptionFailure, nil
 		}
 		return nil, subscriptionFailure, nil
@@ -518,7 +743,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 
 	actionDefinitions, err := sm.getActionDefinition(actionList, ricActionsAccepted)
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
@@ -531,7 +756,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 			subutils.WithCause(cause))
 		subscriptionFailure, err := subscription.BuildSubscriptionFailure()
 		if err != nil {
-			log.Warn(err)
+			log.Warnf("%s: %v", sm.logPrefix, err)
 			return nil, subscriptionFailure, nil
 		}
 		return nil, subscriptionFailure, nil
@@ -545,7 +770,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		subutils.WithActionsNotAdmitted(ricActionsNotAdmitted))
 	subscriptionResponse, err := subscription.BuildSubscriptionResponse()
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
@@ -558,26 +783,35 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 			subutils.WithCause(cause))
 		subscriptionFailure, err := subscription.BuildSubscriptionFailure()
 		if err != nil {
-			log.Warn(err)
+			log.Warnf("%s: %v", sm.logPrefix, err)
 			return nil, subscriptionFailure, nil
 		}
 		return nil, subscriptionFailure, nil
 	}
-	go func() {
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-		err := sm.reportIndication(ctx, reportInterval, subscription, actionDefinitions)
-		if err != nil {
+	sub, err := sm.ServiceModel.Subscriptions.Get(subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID))
+	if err != nil {
+		return nil, nil, err
+	}
+	sub.AdmitReportActions(reportActions...)
+	reportInterval := eventTrigger.Period
+	sub.Start(func(ctx context.Context) {
+		if sm.ServiceModel.Model.LoadTest.IsEnabled() {
+			_ = sm.runLoadTest(ctx, reportInterval, subscription, actionDefinitions)
 			return
 		}
-	}()
+		if eventTrigger.Type == trigger.OnChange {
+			_ = sm.reportActionsOnChange(ctx, subscription, actionDefinitions)
+			return
+		}
+		sm.reportActions(ctx, reportInterval, subscription, actionDefinitions)
+	})
 	return subscriptionResponse, nil, nil
 
 }
 
 // RICSubscriptionDelete implements subscription delete handler for kpm service model
 func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
-	log.Infof("RIC subscription delete request is received for e2 node %d and  service model %s:", sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
+	log.Infof("%s: RIC subscription delete request is received for e2 node %d and  service model %s:", sm.logPrefix, sm.ServiceModel.Node.GnbID, sm.ServiceModel.ModelName)
 	reqID, err := subdeleteutils.GetRequesterID(request)
 	if err != nil {
 		return nil, nil, err
@@ -603,9 +837,10 @@ func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducon
 	if err != nil {
 		return nil, nil, err
 	}
-	// Stops the goroutine sending the indication messages
-	if sub.Ticker != nil {
-		sub.Ticker.Stop()
+	// Stops the goroutine sending the indication messages before confirming the delete
+	err = sub.Stop(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
 	return subDeleteResponse, nil, nil
 }
@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	"context"
+	"math"
+	"strconv"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+)
+
+// reportKey identifies the measurements reported by an action for a cell
+type reportKey struct {
+	actionID e2aptypes.RicActionID
+	ncgi     ransimtypes.NCGI
+}
+
+// reportActionsOnChange sends an indication of an admitted action for a cell whenever one of its measurements changed
+// by more than the configured delta since the last indication. Changes are checked upon the UE and metric store
+// events instead of periodically.
+func (sm *Client) reportActionsOnChange(ctx context.Context, subscription *subutils.Subscription,
+	actionDefinitions map[e2aptypes.RicActionID]*e2smkpmv2.E2SmKpmActionDefinition) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(sm.logPrefix, subID)
+	log.Debugf("%s: Starting on change report with delta %v", logPrefix, sm.config.ChangeDelta)
+	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		log.Warnf("%s: %v", logPrefix, err)
+		return err
+	}
+
+	// The watches are cancelled along with the subscription
+	ueCh := make(chan event.Event)
+	err = sm.ServiceModel.UEs.Watch(ctx, ueCh)
+	if err != nil {
+		return err
+	}
+	metricCh := make(chan event.Event)
+	err = sm.ServiceModel.MetricStore.Watch(ctx, metricCh)
+	if err != nil {
+		return err
+	}
+
+	// Sends the first indications
+	reported := make(map[reportKey][]float64)
+	sm.reportChanges(ctx, subscription, actionDefinitions, reported)
+
+	for {
+		select {
+		case _, ok := <-ueCh:
+			if !ok {
+				return nil
+			}
+		case _, ok := <-metricCh:
+			if !ok {
+				return nil
+			}
+		case <-sub.E2Channel.Context().Done():
+			log.Debugf("%s: E2 channel context is done", logPrefix)
+			return nil
+		}
+		// Bursts of events, e.g. all UEs moving, are checked at once
+		if !drainEvents(ueCh, metricCh) {
+			return nil
+		}
+		sm.reportChanges(ctx, subscription, actionDefinitions, reported)
+	}
+}
+
+// reportChanges sends the indications of the cells whose measurements changed since they were last reported
+func (sm *Client) reportChanges(ctx context.Context, subscription *subutils.Subscription,
+	actionDefinitions map[e2aptypes.RicActionID]*e2smkpmv2.E2SmKpmActionDefinition, reported map[reportKey][]float64) {
+	for actionID, actionDefinition := range actionDefinitions {
+		format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
+		if format1 == nil || format1.GetGranulPeriod().GetValue() <= 0 {
+			continue
+		}
+		granularity := format1.GetGranulPeriod().GetValue()
+		for _, ncgi := range sm.ServiceModel.Node.Cells {
+			if format1.GetCellObjId().GetValue() != strconv.FormatUint(uint64(ncgi), 16) {
+				continue
+			}
+			measDataItem, err := sm.collect(ctx, actionDefinition, ncgi)
+			if err != nil {
+				log.Warnf("%s: %v", sm.logPrefix, err)
+				continue
+			}
+			key := reportKey{actionID: actionID, ncgi: ncgi}
+			values := recordValues(measDataItem)
+			if previous, ok := reported[key]; ok && !hasChanged(previous, values, sm.config.ChangeDelta) {
+				continue
+			}
+			// Each indication reports a single granularity period
+			err = sm.sendRicIndicationFormat1(ctx, ncgi, subscription, actionID, actionDefinition, granularity)
+			if err != nil {
+				log.Errorf("%s: %v", sm.logPrefix, err)
+				continue
+			}
+			reported[key] = values
+		}
+	}
+}
+
+// drainEvents discards the pending events of the channels; it returns false if a channel is closed
+func drainEvents(channels ...<-chan event.Event) bool {
+	for _, ch := range channels {
+		for pending := true; pending; {
+			select {
+			case _, ok := <-ch:
+				if !ok {
+					return false
+				}
+			default:
+				pending = false
+			}
+		}
+	}
+	return true
+}
+
+// recordValues returns the values of the measurement record; measurements without value are reported as NaN
+func recordValues(measDataItem *e2smkpmv2.MeasurementDataItem) []float64 {
+	items := measDataItem.GetMeasRecord().GetValue()
+	values := make([]float64, 0, len(items))
+	for _, item := range items {
+		switch value := item.GetMeasurementRecordItem().(type) {
+		case *e2smkpmv2.MeasurementRecordItem_Integer:
+			values = append(values, float64(value.Integer))
+		case *e2smkpmv2.MeasurementRecordItem_Real:
+			values = append(values, value.Real)
+		default:
+			values = append(values, math.NaN())
+		}
+	}
+	return values
+}
+
+// hasChanged returns true if any of the measurements changed by more than the delta
+func hasChanged(previous []float64, current []float64, delta float64) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+	for i := range current {
+		if math.IsNaN(previous[i]) != math.IsNaN(current[i]) || math.Abs(current[i]-previous[i]) > delta {
+			return true
+		}
+	}
+	return false
+}
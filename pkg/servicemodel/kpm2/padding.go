@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	"fmt"
+
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/measurments"
+)
+
+// paddingMeasName prefix of the names of the synthetic per-UE measurements padding the indication messages
+const paddingMeasName = "RANSim.Padding.UE"
+
+// maxPaddingMeasurements upper bound of the number of padding measurements, given by the maxnoofMeasurementInfo
+// ASN.1 limit of the measurement info list
+const maxPaddingMeasurements = 65535
+
+// padMessage encodes a message padded with as many measurements as needed to reach the target size in bytes;
+// the encode function encodes the message padded with the given number of measurements. It fails if the message
+// can not be encoded within the ASN.1 limits.
+func padMessage(target int, encode func(count int) ([]byte, error)) ([]byte, error) {
+	message, err := encode(0)
+	if err != nil || len(message) >= target {
+		return message, err
+	}
+	padded, err := encode(1)
+	if err != nil {
+		return nil, errors.NewInvalid("unable to encode a padded indication message: %v", err)
+	}
+	// The size of each measurement is estimated from the first one; the estimate is refined until the target is hit
+	size := len(padded) - len(message)
+	if size <= 0 {
+		size = 1
+	}
+	count := 1
+	for len(padded) < target {
+		count += (target - len(padded) + size - 1) / size
+		if count > maxPaddingMeasurements {
+			return nil, errors.NewInvalid("padding an indication message to %d bytes exceeds the ASN.1 limit of %d measurements",
+				target, maxPaddingMeasurements)
+		}
+		padded, err = encode(count)
+		if err != nil {
+			return nil, errors.NewInvalid("unable to encode an indication message padded with %d measurements: %v", count, err)
+		}
+	}
+	return padded, nil
+}
+
+// padMeasInfoList appends the given number of padding measurements to the measurement info list; they take the
+// labels of the first measurement
+func padMeasInfoList(measInfoList *e2smkpmv2.MeasurementInfoList, count int) (*e2smkpmv2.MeasurementInfoList, error) {
+	items := make([]*e2smkpmv2.MeasurementInfoItem, 0, len(measInfoList.GetValue())+count)
+	items = append(items, measInfoList.GetValue()...)
+	var labelInfoList *e2smkpmv2.LabelInfoList
+	if len(items) > 0 {
+		labelInfoList = items[0].GetLabelInfoList()
+	}
+	for i := 0; i < count; i++ {
+		measType, err := measurments.NewMeasurementTypeMeasName(
+			measurments.WithMeasurementName(fmt.Sprintf("%s.%d", paddingMeasName, i+1))).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		item, err := measurments.NewMeasurementInfoItem(
+			measurments.WithMeasType(measType),
+			measurments.WithLabelInfoList(labelInfoList)).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return &e2smkpmv2.MeasurementInfoList{Value: items}, nil
+}
+
+// padMeasData appends a record item to the measurement records of each granularity period for each of the given
+// number of padding measurements
+func padMeasData(measDataItems []*e2smkpmv2.MeasurementDataItem, count int) []*e2smkpmv2.MeasurementDataItem {
+	padded := make([]*e2smkpmv2.MeasurementDataItem, 0, len(measDataItems))
+	for _, measDataItem := range measDataItems {
+		records := make([]*e2smkpmv2.MeasurementRecordItem, 0, len(measDataItem.GetMeasRecord().GetValue())+count)
+		records = append(records, measDataItem.GetMeasRecord().GetValue()...)
+		for i := 0; i < count; i++ {
+			records = append(records, measurments.NewMeasurementRecordItemInteger(
+				measurments.WithIntegerValue(int64(i+1))).
+				Build())
+		}
+		padded = append(padded, &e2smkpmv2.MeasurementDataItem{
+			MeasRecord:     &e2smkpmv2.MeasurementRecord{Value: records},
+			IncompleteFlag: measDataItem.IncompleteFlag,
+		})
+	}
+	return padded
+}
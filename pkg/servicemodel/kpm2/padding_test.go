@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	"testing"
+
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// encodeFixed encodes messages of 100 bytes plus 7 bytes per padding measurement
+func encodeFixed(count int) ([]byte, error) {
+	return make([]byte, 100+7*count), nil
+}
+
+func TestPadMessage(t *testing.T) {
+	message, err := padMessage(50, encodeFixed)
+	assert.NoError(t, err)
+	assert.Len(t, message, 100)
+
+	message, err = padMessage(1000, encodeFixed)
+	assert.NoError(t, err)
+	assert.Len(t, message, 1003)
+
+	// Over the ASN.1 limit of the number of measurements
+	_, err = padMessage(100+7*(maxPaddingMeasurements+1), encodeFixed)
+	assert.True(t, errors.IsInvalid(err))
+
+	// Encoding failure of the padded message
+	_, err = padMessage(1000, func(count int) ([]byte, error) {
+		if count > 10 {
+			return nil, errors.NewInvalid("too large")
+		}
+		return encodeFixed(count)
+	})
+	assert.True(t, errors.IsInvalid(err))
+}
+
+func TestPadMeasurements(t *testing.T) {
+	flag := e2smkpmv2.IncompleteFlag_INCOMPLETE_FLAG_TRUE
+	measInfoList := &e2smkpmv2.MeasurementInfoList{
+		Value: []*e2smkpmv2.MeasurementInfoItem{{LabelInfoList: &e2smkpmv2.LabelInfoList{}}},
+	}
+	measDataItems := []*e2smkpmv2.MeasurementDataItem{
+		{MeasRecord: &e2smkpmv2.MeasurementRecord{Value: []*e2smkpmv2.MeasurementRecordItem{{}}}, IncompleteFlag: &flag},
+		{MeasRecord: &e2smkpmv2.MeasurementRecord{Value: []*e2smkpmv2.MeasurementRecordItem{{}}}, IncompleteFlag: &flag},
+	}
+
+	padded, err := padMeasInfoList(measInfoList, 3)
+	assert.NoError(t, err)
+	assert.Len(t, padded.Value, 4)
+	assert.Equal(t, paddingMeasName+".3", padded.Value[3].GetMeasType().GetMeasName().GetValue())
+	assert.Equal(t, measInfoList.Value[0].LabelInfoList, padded.Value[3].LabelInfoList)
+	assert.Len(t, measInfoList.Value, 1)
+
+	paddedData := padMeasData(measDataItems, 3)
+	assert.Len(t, paddedData, 2)
+	for _, item := range paddedData {
+		assert.Len(t, item.GetMeasRecord().GetValue(), 4)
+		assert.Equal(t, &flag, item.IncompleteFlag)
+	}
+	assert.Len(t, measDataItems[0].GetMeasRecord().GetValue(), 1)
+}
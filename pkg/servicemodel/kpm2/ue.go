@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smkpmv2sm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	e2apIndicationUtils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	kpm2MessageFormat2 "github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/indication/messageformat2"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/labelinfo"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/kpm2/measurments"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
+)
+
+// ricUEIndMsgFormat indication message format of the UE-level report styles, which carries the measurements of each
+// UE served by the cell in its measurement data
+const ricUEIndMsgFormat = model.ReportFormatUE
+
+// isUELevel returns true if the action is of a report style of the node whose indication message format is the
+// UE-level format; the actions of the other styles report the measurements of the cell
+func (sm *Client) isUELevel(actionDefinition *e2smkpmv2.E2SmKpmActionDefinition) bool {
+	styleType := actionDefinition.GetRicStyleType().GetValue()
+	for _, style := range sm.reportStyles {
+		if style.Type == styleType {
+			return style.MessageFormat == ricUEIndMsgFormat
+		}
+	}
+	return false
+}
+
+// servedUEs returns the RRC connected UEs served by the cell, in ascending order of IMSI
+func (sm *Client) servedUEs(ctx context.Context, ncgi ransimtypes.NCGI) []*model.UE {
+	served := make([]*model.UE, 0)
+	for _, ue := range sm.ServiceModel.UEs.ListUEs(ctx, ncgi) {
+		if ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED && !ue.Detached {
+			served = append(served, ue)
+		}
+	}
+	sort.Slice(served, func(i, j int) bool { return served[i].IMSI < served[j].IMSI })
+	return served
+}
+
+// collectUEs collects the measurements of the action definition for each UE served by the cell; the UEs are
+// identified by their IMSI in the returned list, and the measurement data holds one item per UE in the same order
+func (sm *Client) collectUEs(ctx context.Context, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
+	ncgi ransimtypes.NCGI) (*e2smkpmv2.MeasurementCondUeidList, []*e2smkpmv2.MeasurementDataItem, error) {
+	measInfoList := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1().GetMeasInfoList()
+	served := sm.servedUEs(ctx, ncgi)
+
+	ueIDs := make([]*e2smkpmv2.MatchingUeidItem, 0, len(served))
+	measDataItems := make([]*e2smkpmv2.MeasurementDataItem, 0, len(served))
+	for _, ue := range served {
+		ueIDs = append(ueIDs, &e2smkpmv2.MatchingUeidItem{
+			UeId: &e2smkpmv2.UeIdentity{Value: []byte(strconv.FormatUint(uint64(ue.IMSI), 10))},
+		})
+		measRecord := &e2smkpmv2.MeasurementRecord{
+			Value: make([]*e2smkpmv2.MeasurementRecordItem, 0, len(measInfoList.GetValue())),
+		}
+		for _, measInfo := range measInfoList.GetValue() {
+			measRecord.Value = append(measRecord.Value, ueMeasurement(ue, ncgi, measInfo.GetMeasType().GetMeasName().GetValue()))
+		}
+		measDataItem, err := measurments.NewMeasurementDataItem(
+			measurments.WithMeasurementRecord(measRecord)).
+			Build()
+		if err != nil {
+			return nil, nil, err
+		}
+		measDataItems = append(measDataItems, measDataItem)
+	}
+
+	// All UEs served by the cell match the measurements, which are labelled with the PLMN of the cell
+	labelInfo, err := labelinfo.NewLabelInfo(labelinfo.WithPlmnID(*plmn.ToUint24(sm.ServiceModel.Model.GetCellPlmnID(ncgi))))
+	if err != nil {
+		return nil, nil, err
+	}
+	label, err := labelInfo.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	measCondUEList := &e2smkpmv2.MeasurementCondUeidList{
+		Value: make([]*e2smkpmv2.MeasurementCondUeidItem, 0, len(measInfoList.GetValue())),
+	}
+	for _, measInfo := range measInfoList.GetValue() {
+		measCondUEList.Value = append(measCondUEList.Value, &e2smkpmv2.MeasurementCondUeidItem{
+			MeasType: measInfo.GetMeasType(),
+			MatchingCond: &e2smkpmv2.MatchingCondList{
+				Value: []*e2smkpmv2.MatchingCondItem{{
+					MatchingCondItem: &e2smkpmv2.MatchingCondItem_MeasLabel{MeasLabel: label.GetMeasLabel()},
+				}},
+			},
+			MatchingUeidList: &e2smkpmv2.MatchingUeidList{Value: ueIDs},
+		})
+	}
+	return measCondUEList, measDataItems, nil
+}
+
+// ueMeasurement returns the measurement of the given type for the UE on the cell; the measurements which are not
+// measured per UE have no value
+func ueMeasurement(ue *model.UE, ncgi ransimtypes.NCGI, measName string) *e2smkpmv2.MeasurementRecordItem {
+	switch measName {
+	case DRBUEThpDl.String():
+		return measurments.NewMeasurementRecordItemReal(measurments.WithRealValue(ue.Throughput())).Build()
+	case RRUPrbUsedDl.String():
+		prbs := 0.0
+		for _, carrier := range ue.Carriers {
+			if carrier.Active && carrier.NCGI == ncgi {
+				prbs += carrier.PRBs
+			}
+		}
+		return measurments.NewMeasurementRecordItemInteger(measurments.WithIntegerValue(int64(math.Round(prbs)))).Build()
+	case CARRWBCQIMean.String():
+		return measurments.NewMeasurementRecordItemReal(measurments.WithRealValue(float64(ue.Cqi))).Build()
+	}
+	return measurments.NewMeasurementRecordItemNoValue()
+}
+
+// createRicIndicationFormat2 creates the UE-level indication of the given admitted action for the given cell,
+// reporting the measurements of each UE served by the cell at the end of the reporting interval
+func (sm *Client) createRicIndicationFormat2(ctx context.Context, ncgi ransimtypes.NCGI,
+	subscription *subutils.Subscription,
+	actionID e2aptypes.RicActionID,
+	actionDefinition *e2smkpmv2.E2SmKpmActionDefinition,
+	interval int64) (*e2appducontents.Ricindication, error) {
+	format1 := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1()
+	startTime := clock.Now().Add(-time.Duration(interval) * time.Millisecond)
+	measCondUEList, measDataItems, err := sm.collectUEs(ctx, actionDefinition, ncgi)
+	if err != nil {
+		return nil, err
+	}
+
+	indicationHeaderBytes, err := sm.createIndicationHeaderBytes(fileFormatVersion1, startTime)
+	if err != nil {
+		return nil, err
+	}
+	var kpm2ServiceModel e2smkpmv2sm.Kpm2ServiceModel
+	indicationMessageBytes, err := kpm2MessageFormat2.NewIndicationMessage(
+		kpm2MessageFormat2.WithCellObjID(strconv.FormatUint(uint64(ncgi), 16)),
+		kpm2MessageFormat2.WithGranularity(uint32(format1.GetGranulPeriod().GetValue())),
+		kpm2MessageFormat2.WithSubscriptionID(format1.GetSubscriptId().GetValue()),
+		kpm2MessageFormat2.WithMeasCondUEList(measCondUEList),
+		kpm2MessageFormat2.WithMeasData(&e2smkpmv2.MeasurementData{Value: measDataItems})).
+		ToAsn1Bytes(kpm2ServiceModel)
+	if err != nil {
+		log.Warnf("%s: %v", sm.logPrefix, err)
+		return nil, err
+	}
+	log.Debugf("%s: Sending UE-level indication message for cell %v reporting %d UEs", sm.logPrefix, ncgi, len(measDataItems))
+
+	indication := e2apIndicationUtils.NewIndication(
+		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+		e2apIndicationUtils.WithRicActionID(int32(actionID)),
+		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+	return indication.Build()
+}
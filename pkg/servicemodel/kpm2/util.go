@@ -5,16 +5,22 @@
 package kpm2
 
 import (
+	"context"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	e2smkpmv2sm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
 	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
-	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/stats"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
 	"google.golang.org/protobuf/proto"
 )
 
-func (sm *Client) getActionDefinition(actionList []*e2appducontents.RicactionToBeSetupItemIes, ricActionsAccepted []*e2aptypes.RicActionID) ([]*e2smkpmv2.E2SmKpmActionDefinition, error) {
-	var actionDefinitions []*e2smkpmv2.E2SmKpmActionDefinition
+func (sm *Client) getActionDefinition(actionList []*e2appducontents.RicactionToBeSetupItemIes, ricActionsAccepted []*e2aptypes.RicActionID) (map[e2aptypes.RicActionID]*e2smkpmv2.E2SmKpmActionDefinition, error) {
+	actionDefinitions := make(map[e2aptypes.RicActionID]*e2smkpmv2.E2SmKpmActionDefinition)
 	for _, action := range actionList {
 		for _, acceptedActionID := range ricActionsAccepted {
 			if action.GetValue().GetRatbsi().GetRicActionId().GetValue() == int32(*acceptedActionID) {
@@ -23,18 +29,18 @@ func (sm *Client) getActionDefinition(actionList []*e2appducontents.RicactionToB
 
 				actionDefinitionProtoBytes, err := kpm2ServiceModel.ActionDefinitionASN1toProto(actionDefinitionBytes)
 				if err != nil {
-					log.Warn(err)
+					log.Warnf("%s: %v", sm.logPrefix, err)
 					return nil, err
 				}
 
 				actionDefinition := &e2smkpmv2.E2SmKpmActionDefinition{}
 				err = proto.Unmarshal(actionDefinitionProtoBytes, actionDefinition)
 				if err != nil {
-					log.Warn(err)
+					log.Warnf("%s: %v", sm.logPrefix, err)
 					return nil, err
 				}
 
-				actionDefinitions = append(actionDefinitions, actionDefinition)
+				actionDefinitions[*acceptedActionID] = actionDefinition
 
 			}
 		}
@@ -42,25 +48,75 @@ func (sm *Client) getActionDefinition(actionList []*e2appducontents.RicactionToB
 	return actionDefinitions, nil
 }
 
-// getReportPeriod extracts report period
-func (sm *Client) getReportPeriod(request *e2appducontents.RicsubscriptionRequest) (int64, error) {
-	var eventTriggerAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
-			eventTriggerAsnBytes = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
-			break
-		}
-	}
+// decodeEventTrigger decodes the KPM event trigger definition; the reports are periodic unless the node is configured
+// to report on change
+func (sm *Client) decodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
 	var kpm2ServiceModel e2smkpmv2sm.Kpm2ServiceModel
-	eventTriggerProtoBytes, err := kpm2ServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
+	eventTriggerProtoBytes, err := kpm2ServiceModel.EventTriggerDefinitionASN1toProto(definition)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	eventTriggerDefinition := &e2smkpmv2.E2SmKpmEventTriggerDefinition{}
 	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	format1 := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1()
+	if format1 == nil {
+		return nil, trigger.NewUnsupported("only event trigger definition format 1 is supported")
+	}
+	if sm.config.IsOnChange() {
+		// The reporting period is only used by load tests
+		return &trigger.Trigger{Type: trigger.OnChange, Period: format1.GetReportingPeriod()}, nil
+	}
+	return &trigger.Trigger{Type: trigger.Periodic, Period: format1.GetReportingPeriod()}, nil
+}
+
+// getActionReportPeriod derives the report period of an action from the report period of the subscription, so that
+// each indication of the action reports a whole number of the granularity periods of its action definition
+func getActionReportPeriod(reportPeriod int64, actionDefinition *e2smkpmv2.E2SmKpmActionDefinition) int64 {
+	granularity := actionDefinition.GetActionDefinitionFormats().GetActionDefinitionFormat1().GetGranulPeriod().GetValue()
+	if granularity <= 0 {
+		return reportPeriod
+	}
+	if reportPeriod < granularity {
+		return granularity
+	}
+	return reportPeriod - reportPeriod%granularity
+}
+
+// hoMeanTime returns the mean handover time of the cell in ms of the given measurement type
+func hoMeanTime(ctx context.Context, metricStore metrics.Store, ncgi ransimtypes.NCGI, measTypeName MeasTypeName) float64 {
+	switch measTypeName {
+	case MMHoPrepTimeMean:
+		return stats.GetMeanTime(ctx, metricStore, ncgi, stats.HoPrepTime, stats.HoPrepAtt)
+	case MMHoExeTimeMean:
+		return stats.GetMeanTime(ctx, metricStore, ncgi, stats.HoExeTime, stats.HoExeSucc)
+	case MMHoInterruptionTimeMean:
+		return stats.GetMeanTime(ctx, metricStore, ncgi, stats.HoInterruptionTime, stats.HoExeSucc)
+	}
+	return 0
+}
+
+// uplinkMean returns the mean uplink quantity of the cell of the given measurement type
+func uplinkMean(uplink model.Uplink, measTypeName MeasTypeName) float64 {
+	switch measTypeName {
+	case L1MPHRMean:
+		return uplink.PowerHeadroom
+	case L1MULTxPowerMean:
+		return uplink.TxPower
+	case L1MULSINRMean:
+		return uplink.Sinr
+	case DRBUEThpUl:
+		return uplink.Throughput
+	}
+	return 0
+}
+
+// formatOrDefault returns the configured format of a report style, or the default format if none is configured
+func formatOrDefault(format int32, defaultFormat int32) int32 {
+	if format == 0 {
+		return defaultFormat
 	}
-	reportPeriod := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().GetReportingPeriod()
-	return reportPeriod, nil
+	return format
 }
@@ -0,0 +1,119 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package kpm2
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/stats"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func newActionDefinition(granularity int64) *e2smkpmv2.E2SmKpmActionDefinition {
+	return &e2smkpmv2.E2SmKpmActionDefinition{
+		ActionDefinitionFormats: &e2smkpmv2.ActionDefinitionFormats{
+			E2SmKpmActionDefinition: &e2smkpmv2.ActionDefinitionFormats_ActionDefinitionFormat1{
+				ActionDefinitionFormat1: &e2smkpmv2.E2SmKpmActionDefinitionFormat1{
+					GranulPeriod: &e2smkpmv2.GranularityPeriod{
+						Value: granularity,
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGetActionReportPeriod(t *testing.T) {
+	assert.Equal(t, int64(1000), getActionReportPeriod(1000, newActionDefinition(100)))
+	assert.Equal(t, int64(900), getActionReportPeriod(1000, newActionDefinition(300)))
+	assert.Equal(t, int64(2000), getActionReportPeriod(1000, newActionDefinition(2000)))
+	assert.Equal(t, int64(1000), getActionReportPeriod(1000, &e2smkpmv2.E2SmKpmActionDefinition{}))
+}
+
+func TestHasChanged(t *testing.T) {
+	assert.False(t, hasChanged([]float64{3, 10.5}, []float64{3, 10.5}, 0))
+	assert.True(t, hasChanged([]float64{3, 10.5}, []float64{4, 10.5}, 0))
+	assert.False(t, hasChanged([]float64{3, 10.5}, []float64{4, 11}, 1))
+	assert.True(t, hasChanged([]float64{3, 10.5}, []float64{3, 12}, 1))
+	assert.True(t, hasChanged([]float64{3}, []float64{3, 10.5}, 1))
+	assert.False(t, hasChanged([]float64{math.NaN()}, []float64{math.NaN()}, 0))
+	assert.True(t, hasChanged([]float64{math.NaN()}, []float64{0}, 0))
+}
+
+func TestRecordValues(t *testing.T) {
+	measDataItem := &e2smkpmv2.MeasurementDataItem{
+		MeasRecord: &e2smkpmv2.MeasurementRecord{
+			Value: []*e2smkpmv2.MeasurementRecordItem{
+				{MeasurementRecordItem: &e2smkpmv2.MeasurementRecordItem_Integer{Integer: 5}},
+				{MeasurementRecordItem: &e2smkpmv2.MeasurementRecordItem_Real{Real: 2.5}},
+				{MeasurementRecordItem: &e2smkpmv2.MeasurementRecordItem_NoValue{}},
+			},
+		},
+	}
+	values := recordValues(measDataItem)
+	assert.Len(t, values, 3)
+	assert.Equal(t, 5.0, values[0])
+	assert.Equal(t, 2.5, values[1])
+	assert.True(t, math.IsNaN(values[2]))
+}
+
+func TestHeldRecords(t *testing.T) {
+	start := time.Unix(1000, 0)
+	records := &heldRecords{}
+	records.add([]*e2smkpmv2.MeasurementDataItem{{}, {}}, start, 100)
+	records.add([]*e2smkpmv2.MeasurementDataItem{{}, {}}, start.Add(200*time.Millisecond), 100)
+	assert.Len(t, records.items, 4)
+	assert.Equal(t, start, records.startTime)
+
+	records.add(make([]*e2smkpmv2.MeasurementDataItem, maxAggregatedRecords), start.Add(400*time.Millisecond), 100)
+	assert.Len(t, records.items, maxAggregatedRecords)
+	assert.Equal(t, start.Add(400*time.Millisecond), records.startTime)
+}
+
+func TestHoMeanTime(t *testing.T) {
+	ctx := context.Background()
+	metricStore := metrics.NewMetricsStore()
+	ncgi := ransimtypes.NCGI(0x1234)
+	hoStats := stats.NewHoStats(metricStore)
+	hoStats.Prepared(ctx, ncgi, 20*time.Millisecond)
+	hoStats.Executed(ctx, ncgi, true, 60*time.Millisecond, 40*time.Millisecond)
+	hoStats.Executed(ctx, ncgi, false, 0, 0)
+
+	assert.Equal(t, 20.0, hoMeanTime(ctx, metricStore, ncgi, MMHoPrepTimeMean))
+	assert.Equal(t, 60.0, hoMeanTime(ctx, metricStore, ncgi, MMHoExeTimeMean))
+	assert.Equal(t, 40.0, hoMeanTime(ctx, metricStore, ncgi, MMHoInterruptionTimeMean))
+	assert.Equal(t, 0.0, hoMeanTime(ctx, metricStore, ncgi, RRUPrbUsedDl))
+	assert.Equal(t, "MM.HoExeSucc.Sum", MMHoExeSuccSum.String())
+	assert.Equal(t, stats.HoExeSucc, MMHoExeSuccSum.String())
+	assert.Equal(t, stats.HoExeFail, MMHoExeFailSum.String())
+	assert.Equal(t, stats.HoPingPong, MMHoPingPongSum.String())
+	assert.Equal(t, string(stats.HoTooEarly), MMHoTooEarlySum.String())
+	assert.Equal(t, string(stats.HoTooLate), MMHoTooLateSum.String())
+	assert.Equal(t, string(stats.HoWrongCell), MMHoWrongCellSum.String())
+	assert.Equal(t, stats.RachPreambles, RACHPreamblesSum.String())
+	assert.Equal(t, stats.PagingAtt, PAGAttSum.String())
+	assert.Equal(t, stats.PagingFail, PAGFailSum.String())
+}
+
+func TestUplinkMean(t *testing.T) {
+	uplink := model.Uplink{TxPower: 10, PowerHeadroom: 13, Sinr: 5, Throughput: 9000}
+	assert.Equal(t, 13.0, uplinkMean(uplink, L1MPHRMean))
+	assert.Equal(t, 10.0, uplinkMean(uplink, L1MULTxPowerMean))
+	assert.Equal(t, 5.0, uplinkMean(uplink, L1MULSINRMean))
+	assert.Equal(t, 9000.0, uplinkMean(uplink, DRBUEThpUl))
+	assert.Equal(t, 0.0, uplinkMean(uplink, RRCConnAvg))
+}
+
+func TestFormatOrDefault(t *testing.T) {
+	assert.Equal(t, int32(1), formatOrDefault(0, ricFormatType))
+	assert.Equal(t, int32(3), formatOrDefault(3, ricFormatType))
+}
@@ -10,37 +10,47 @@ import (
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 	"github.com/onosproject/rrm-son-lib/pkg/model/id"
 )
 
 func (m *Mho) processEventA3MeasReport(ctx context.Context, subscription *subutils.Subscription) {
-	log.Info("Start processing event a3 measurement report")
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
+	log.Infof("%s: Start processing event a3 measurement report", logPrefix)
 	sub, err := m.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", logPrefix, err)
 		return
 	}
 	for {
 		select {
 		case report := <-m.ServiceModel.A3Chan:
-			log.Debugf("received event a3 measurement report: %v", report)
-			log.Debugf("Send upon-rcv-meas-report indication for cell ecgi:%d, IMSI:%s",
+			log.Debugf("%s: received event a3 measurement report: %v", logPrefix, report)
+			log.Debugf("%s: Send upon-rcv-meas-report indication for cell ecgi:%d, IMSI:%s", logPrefix,
 				report.UE.GetSCell().GetID().GetID().(id.ECGI), report.UE.GetID().String())
 			ecgi := report.UE.GetSCell().GetID().GetID().(id.ECGI)
 			imsi := report.UE.GetID().GetID().(id.UEID).IMSI
 			ue, err := m.ServiceModel.UEs.Get(ctx, types.IMSI(imsi))
 			if err != nil {
-				log.Warn(err)
+				log.Warnf("%s: %v", logPrefix, err)
 				continue
 			}
-			err = m.sendRicIndicationFormat1(ctx, ransimtypes.NCGI(ecgi), ue, subscription)
+			err = m.sendRicIndicationFormat1(ctx, ransimtypes.NCGI(ecgi), ue, subscription, sub.ReportActions())
 			if err != nil {
-				log.Warn(err)
+				log.Warnf("%s: %v", logPrefix, err)
 				continue
 			}
+			if insertActions := sub.InsertActions(); len(insertActions) > 0 {
+				err = m.sendInsertIndication(ctx, ransimtypes.NCGI(ecgi), ue, subscription, insertActions)
+				if err != nil {
+					log.Warnf("%s: %v", logPrefix, err)
+					continue
+				}
+			}
+		case <-ctx.Done():
+			return
 		case <-sub.E2Channel.Context().Done():
-			sub.Ticker.Stop()
 			return
 		}
 	}
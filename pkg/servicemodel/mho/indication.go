@@ -9,6 +9,7 @@ import (
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	e2sm_v2_ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-v2-ies"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
@@ -18,22 +19,30 @@ import (
 	indHdr "github.com/onosproject/ran-simulator/pkg/utils/e2sm/mho/indication/header"
 	indMsgFmt1 "github.com/onosproject/ran-simulator/pkg/utils/e2sm/mho/indication/message_format1"
 	indMsgFmt2 "github.com/onosproject/ran-simulator/pkg/utils/e2sm/mho/indication/message_format2"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 )
 
-func (m *Mho) sendRicIndication(ctx context.Context, subscription *subutils.Subscription) error {
+// sendRicIndication sends a measurement report of each connected UE of the node for which due returns true
+func (m *Mho) sendRicIndication(ctx context.Context, subscription *subutils.Subscription, actionIDs []e2aptypes.RicActionID, due func(ue *model.UE) bool) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
 	node := m.ServiceModel.Node
 	// Creates and sends an indication message for each cell in the node
 	for _, ncgi := range node.Cells {
-		log.Debugf("Send MHO indications for cell ncgi:%d", ncgi)
+		log.Debugf("%s: Send MHO indications for cell ncgi:%d", logPrefix, ncgi)
 		for _, ue := range m.ServiceModel.UEs.ListUEs(ctx, ncgi) {
-			// Ignore idle UEs
-			if ue.RrcState == e2sm_mho.Rrcstatus_RRCSTATUS_IDLE {
+			// Ignore idle and inactive UEs
+			if ue.RrcState != e2sm_mho.Rrcstatus_RRCSTATUS_CONNECTED {
+				continue
+			}
+			if !due(ue) {
 				continue
 			}
-			log.Debugf("Send MHO indications for cell ncgi:%d, IMSI:%d", ncgi, ue.IMSI)
-			err := m.sendRicIndicationFormat1(ctx, ncgi, ue, subscription)
+			log.Debugf("%s: Send MHO indications for cell ncgi:%d, IMSI:%d", logPrefix, ncgi, ue.IMSI)
+			err := m.sendRicIndicationFormat1(ctx, ncgi, ue, subscription, actionIDs)
 			if err != nil {
-				log.Warn(err)
+				log.Warnf("%s: %v", logPrefix, err)
 				continue
 			}
 		}
@@ -41,8 +50,9 @@ func (m *Mho) sendRicIndication(ctx context.Context, subscription *subutils.Subs
 	return nil
 }
 
-func (m *Mho) sendRicIndicationFormat1(ctx context.Context, ncgi ransimtypes.NCGI, ue *model.UE, subscription *subutils.Subscription) error {
+func (m *Mho) sendRicIndicationFormat1(ctx context.Context, ncgi ransimtypes.NCGI, ue *model.UE, subscription *subutils.Subscription, actionIDs []e2aptypes.RicActionID) error {
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
 	sub, err := m.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
 		return err
@@ -61,28 +71,31 @@ func (m *Mho) sendRicIndicationFormat1(ctx context.Context, ncgi ransimtypes.NCG
 		return nil
 	}
 
-	indication := e2apIndicationUtils.NewIndication(
-		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
-		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
-		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
-		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
-		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+	for _, actionID := range actionIDs {
+		log.Debugf("%s: Send MHO indication of action %d for IMSI:%d", logPrefix, actionID, ue.IMSI)
+		indication := e2apIndicationUtils.NewIndication(
+			e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+			e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+			e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+			e2apIndicationUtils.WithRicActionID(int32(actionID)),
+			e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+			e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+
+		ricIndication, err := indication.Build()
+		if err != nil {
+			return err
+		}
 
-	ricIndication, err := indication.Build()
-	if err != nil {
-		return err
-	}
-
-	err = sub.E2Channel.RICIndication(ctx, ricIndication)
-	if err != nil {
-		return err
+		// Indications which cannot be sent are dropped and counted, the next ones are still sent
+		_ = m.ServiceModel.SendIndication(ctx, sub, ricIndication)
 	}
 
 	return nil
 }
 
-func (m *Mho) sendRicIndicationFormat2(ctx context.Context, ncgi ransimtypes.NCGI, ue *model.UE, subscription *subutils.Subscription) error {
+func (m *Mho) sendRicIndicationFormat2(ctx context.Context, ncgi ransimtypes.NCGI, ue *model.UE, subscription *subutils.Subscription, actionIDs []e2aptypes.RicActionID) error {
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
 	sub, err := m.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
 		return err
@@ -101,21 +114,23 @@ func (m *Mho) sendRicIndicationFormat2(ctx context.Context, ncgi ransimtypes.NCG
 		return nil
 	}
 
-	indication := e2apIndicationUtils.NewIndication(
-		e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
-		e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
-		e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
-		e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
-		e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
-
-	ricIndication, err := indication.Build()
-	if err != nil {
-		return err
-	}
+	for _, actionID := range actionIDs {
+		log.Debugf("%s: Send MHO indication of action %d for IMSI:%d", logPrefix, actionID, ue.IMSI)
+		indication := e2apIndicationUtils.NewIndication(
+			e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+			e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+			e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+			e2apIndicationUtils.WithRicActionID(int32(actionID)),
+			e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+			e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+
+		ricIndication, err := indication.Build()
+		if err != nil {
+			return err
+		}
 
-	err = sub.E2Channel.RICIndication(ctx, ricIndication)
-	if err != nil {
-		return err
+		// Indications which cannot be sent are dropped and counted, the next ones are still sent
+		_ = m.ServiceModel.SendIndication(ctx, sub, ricIndication)
 	}
 
 	return nil
@@ -124,7 +139,7 @@ func (m *Mho) sendRicIndicationFormat2(ctx context.Context, ncgi ransimtypes.NCG
 func (m *Mho) createIndicationHeaderBytes(ctx context.Context, ncgi ransimtypes.NCGI) ([]byte, error) {
 
 	cell, _ := m.ServiceModel.CellStore.Get(ctx, ncgi)
-	plmnID := ransimtypes.NewUint24(uint32(m.ServiceModel.Model.PlmnID))
+	plmnID := plmn.ToUint24(m.ServiceModel.Model.GetCellPlmnID(cell.NCGI))
 	ncgiTypeNCI := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(cell.NCGI)))
 
 	header := indHdr.NewIndicationHeader(
@@ -140,78 +155,38 @@ func (m *Mho) createIndicationHeaderBytes(ctx context.Context, ncgi ransimtypes.
 }
 
 func (m *Mho) createIndicationMsgFormat1(ue *model.UE) ([]byte, error) {
-	log.Debugf("Create MHO Indication message ueID: %d", ue.IMSI)
+	log.Debugf("%s: Create MHO Indication message ueID: %d", m.logPrefix, ue.IMSI)
 
-	plmnID := ransimtypes.NewUint24(uint32(m.ServiceModel.Model.PlmnID))
 	measReport := make([]*e2sm_mho.E2SmMhoMeasurementReportItem, 0)
 
 	if len(ue.Cells) == 0 {
-		log.Infof("no neighbor cells found for ueID:%d", ue.IMSI)
+		log.Infof("%s: no neighbor cells found for ueID:%d", m.logPrefix, ue.IMSI)
 		return nil, nil
 	}
 
-	nrCellIDTypeNCI := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(ue.Cell.NCGI)))
-
-	// add serving cell to measReport
-	measReport = append(measReport, &e2sm_mho.E2SmMhoMeasurementReportItem{
-		Cgi: &e2sm_v2_ies.Cgi{
-			Cgi: &e2sm_v2_ies.Cgi_NRCgi{
-				NRCgi: &e2sm_v2_ies.NrCgi{
-					PLmnidentity: &e2sm_v2_ies.PlmnIdentity{
-						Value: plmnID.ToBytes(),
-					},
-					NRcellIdentity: &e2sm_v2_ies.NrcellIdentity{
-						Value: &asn1.BitString{
-							Value: nrCellIDTypeNCI.Bytes(),
-							Len:   36,
-						},
-					},
-				},
-			},
-		},
-		Rsrp: &e2sm_mho.Rsrp{
-			Value: int32(ue.Cell.Strength),
-		},
-	})
+	// add serving cell and, for dual connected UEs, the secondary cell to measReport
+	measReport = append(measReport, m.measReportItem(ue.Cell))
+	if ue.SecondaryCell != nil {
+		measReport = append(measReport, m.measReportItem(ue.SecondaryCell))
+	}
 
-	for _, cell := range ue.Cells {
-		ncgiTypeNCI := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(cell.NCGI)))
-
-		measReport = append(measReport, &e2sm_mho.E2SmMhoMeasurementReportItem{
-			Cgi: &e2sm_v2_ies.Cgi{
-				Cgi: &e2sm_v2_ies.Cgi_NRCgi{
-					NRCgi: &e2sm_v2_ies.NrCgi{
-						PLmnidentity: &e2sm_v2_ies.PlmnIdentity{
-							Value: plmnID.ToBytes(),
-						},
-						NRcellIdentity: &e2sm_v2_ies.NrcellIdentity{
-							Value: &asn1.BitString{
-								Value: ncgiTypeNCI.Bytes(),
-								Len:   36,
-							},
-						},
-					},
-				},
-			},
-			Rsrp: &e2sm_mho.Rsrp{
-				Value: int32(cell.Strength),
-			},
-		})
+	for _, cell := range m.reportedNeighbors(ue) {
+		measReport = append(measReport, m.measReportItem(cell))
 	}
 
 	ueID := int64(ue.IMSI)
 
-	log.Debugf("MHO measurement report for ueID %s: %v", ueID, measReport)
+	log.Debugf("%s: MHO measurement report for ueID %s: %v", m.logPrefix, ueID, measReport)
 
 	indicationMessage := indMsgFmt1.NewIndicationMessage(
 		indMsgFmt1.WithUeID(ueID),
 		indMsgFmt1.WithMeasReport(measReport))
 
-	log.Debugf("MHO measurement report indication message for ueID %s: %v", ueID, indicationMessage)
+	log.Debugf("%s: MHO measurement report indication message for ueID %s: %v", m.logPrefix, ueID, indicationMessage)
 
 	indicationMessageBytes, err := indicationMessage.ToAsn1Bytes()
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", m.logPrefix, err)
 		return nil, err
 	}
 
@@ -219,7 +194,7 @@ func (m *Mho) createIndicationMsgFormat1(ue *model.UE) ([]byte, error) {
 }
 
 func (m *Mho) createIndicationMsgFormat2(ue *model.UE) ([]byte, error) {
-	log.Debugf("Create MHO RRC indication message ueID: %d", ue.IMSI)
+	log.Debugf("%s: Create MHO RRC indication message ueID: %d", m.logPrefix, ue.IMSI)
 
 	ueID := int64(ue.IMSI)
 
@@ -227,13 +202,57 @@ func (m *Mho) createIndicationMsgFormat2(ue *model.UE) ([]byte, error) {
 		indMsgFmt2.WithUeID(ueID),
 		indMsgFmt2.WithRrcStatus(ue.RrcState))
 
-	log.Debugf("MHO RRC state indication message for ueID %s: %v", ueID, indicationMessage)
+	log.Debugf("%s: MHO RRC state indication message for ueID %s: %v", m.logPrefix, ueID, indicationMessage)
 
 	indicationMessageBytes, err := indicationMessage.ToAsn1Bytes()
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", m.logPrefix, err)
 		return nil, err
 	}
 
 	return indicationMessageBytes, nil
 }
+
+// reportedNeighbors returns the neighbor cells of the UE to be included in measurement reports
+func (m *Mho) measReportItem(cell *model.UECell) *e2sm_mho.E2SmMhoMeasurementReportItem {
+	ncgiTypeNCI := utils.NewNCellIDWithUint64(uint64(ransimtypes.GetNCI(cell.NCGI)))
+	cellPlmnID := plmn.ToUint24(m.ServiceModel.Model.GetCellPlmnID(cell.NCGI))
+
+	return &e2sm_mho.E2SmMhoMeasurementReportItem{
+		Cgi: &e2sm_v2_ies.Cgi{
+			Cgi: &e2sm_v2_ies.Cgi_NRCgi{
+				NRCgi: &e2sm_v2_ies.NrCgi{
+					PLmnidentity: &e2sm_v2_ies.PlmnIdentity{
+						Value: cellPlmnID.ToBytes(),
+					},
+					NRcellIdentity: &e2sm_v2_ies.NrcellIdentity{
+						Value: &asn1.BitString{
+							Value: ncgiTypeNCI.Bytes(),
+							Len:   36,
+						},
+					},
+				},
+			},
+		},
+		Rsrp: &e2sm_mho.Rsrp{
+			Value: int32(cell.Strength),
+		},
+	}
+}
+
+func (m *Mho) reportedNeighbors(ue *model.UE) []*model.UECell {
+	cells := make([]*model.UECell, 0, len(ue.Cells))
+	for _, cell := range ue.Cells {
+		if ue.SecondaryCell != nil && cell.NCGI == ue.SecondaryCell.NCGI {
+			continue
+		}
+		if m.config.RsrpThreshold != nil && cell.Strength < *m.config.RsrpThreshold {
+			continue
+		}
+		if m.config.MaxNeighbors > 0 && len(cells) == m.config.MaxNeighbors {
+			break
+		}
+		cells = append(cells, cell)
+	}
+	return cells
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package mho
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	e2apIndicationUtils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+)
+
+// pendingHandover handover of a UE suspended by an insert indication until the RIC controls it
+type pendingHandover struct {
+	imsi types.IMSI
+	ncgi ransimtypes.NCGI
+}
+
+// sendInsertIndication suspends the handover of the UE and sends an insert indication, carrying the call process ID
+// of the suspended handover, for each of the given INSERT actions
+func (m *Mho) sendInsertIndication(ctx context.Context, ncgi ransimtypes.NCGI, ue *model.UE, subscription *subutils.Subscription, actionIDs []e2aptypes.RicActionID) error {
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
+	sub, err := m.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		return err
+	}
+
+	indicationHeaderBytes, err := m.createIndicationHeaderBytes(ctx, ncgi)
+	if err != nil {
+		return err
+	}
+
+	indicationMessageBytes, err := m.createIndicationMsgFormat1(ue)
+	if err != nil {
+		return err
+	}
+	if indicationMessageBytes == nil {
+		return nil
+	}
+
+	pending := &pendingHandover{
+		imsi: ue.IMSI,
+		ncgi: ncgi,
+	}
+	callProcessID := m.callProcesses.Register(pending, func(context interface{}) {
+		handover := context.(*pendingHandover)
+		log.Infof("%s: Handover of IMSI:%d from cell ncgi:%d is not controlled in time and is dropped", logPrefix, handover.imsi, handover.ncgi)
+	})
+
+	for _, actionID := range actionIDs {
+		log.Debugf("%s: Send MHO insert indication of action %d for IMSI:%d with call process ID %x", logPrefix, actionID, ue.IMSI, callProcessID)
+		indication := e2apIndicationUtils.NewIndication(
+			e2apIndicationUtils.WithRicInstanceID(subscription.GetRicInstanceID()),
+			e2apIndicationUtils.WithRanFuncID(subscription.GetRanFuncID()),
+			e2apIndicationUtils.WithRequestID(subscription.GetReqID()),
+			e2apIndicationUtils.WithRicActionID(int32(actionID)),
+			e2apIndicationUtils.WithIndicationType(e2apies.RicindicationType_RICINDICATION_TYPE_INSERT),
+			e2apIndicationUtils.WithCallProcessID(callProcessID),
+			e2apIndicationUtils.WithIndicationHeader(indicationHeaderBytes),
+			e2apIndicationUtils.WithIndicationMessage(indicationMessageBytes))
+
+		ricIndication, err := indication.Build()
+		if err != nil {
+			return err
+		}
+
+		// Indications which cannot be sent are dropped and counted; the handover still waits for the other actions
+		_ = m.ServiceModel.SendIndication(ctx, sub, ricIndication)
+	}
+	return nil
+}
+
+// resolveHandover returns the handover suspended by the insert indication of the given call process ID
+func (m *Mho) resolveHandover(callProcessID []byte) (*pendingHandover, error) {
+	context, err := m.callProcesses.Resolve(callProcessID)
+	if err != nil {
+		return nil, err
+	}
+	return context.(*pendingHandover), nil
+}
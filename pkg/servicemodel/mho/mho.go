@@ -9,6 +9,7 @@ import (
 	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
 	e2smmhosm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/servicemodel"
 	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 	"github.com/onosproject/rrm-son-lib/pkg/handover"
 
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
@@ -23,6 +24,7 @@ import (
 	"github.com/onosproject/ran-simulator/pkg/mobility"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/callprocess"
 	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
 	"github.com/onosproject/ran-simulator/pkg/store/metrics"
@@ -33,6 +35,8 @@ import (
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/mho/ranfundesc"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -43,6 +47,9 @@ type Mho struct {
 	ServiceModel   *registry.ServiceModel
 	rrcUpdateChan  chan model.UE
 	mobilityDriver mobility.Driver
+	config         model.MHOConfig
+	callProcesses  *callprocess.Registry
+	logPrefix      string
 }
 
 // NewServiceModel creates a new service model
@@ -71,11 +78,16 @@ func NewServiceModel(node model.Node, model *model.Model,
 
 	mho := &Mho{
 		ServiceModel: &mhoSm,
+		logPrefix:    logfields.Node(node.GnbID),
 	}
 
 	mhoSm.Client = mho
 
 	mho.mobilityDriver = mobilityDriver
+	if smConfig, err := model.GetNodeServiceModel(node, int(registry.Mho)); err == nil {
+		mho.config = smConfig.MHO
+	}
+	mho.callProcesses = callprocess.NewRegistry(mho.config.GetCallProcessTimeout())
 
 	var ranFunctionShortName = modelFullName
 	var ranFunctionE2SmOid = modelOID
@@ -131,6 +143,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 		log.Error(err)
 		return registry.ServiceModel{}, err
 	}
+	selfcheck.Check(&mhosm, selfcheck.RanFuncDescription, ranFuncDescPdu, ranFuncDescBytes)
 
 	mhoSm.Description = ranFuncDescBytes
 	return mhoSm, nil
@@ -143,9 +156,11 @@ func (m *Mho) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E
 
 // RICSubscription implements subscription handler for MHO service model
 func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
-	log.Infof("Ric Subscription Request is received for service model %v and e2 node with ID:%d", m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
-	log.Debugf("MHO subscription, request: %v", request)
+	log.Infof("%s: Ric Subscription Request is received for service model %v and e2 node with ID:%d", m.logPrefix, m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
+	log.Debugf("%s: MHO subscription, request: %v", m.logPrefix, request)
 	var ricActionsAccepted []*e2aptypes.RicActionID
+	var reportActions []e2aptypes.RicActionID
+	var insertActions []e2aptypes.RicActionID
 	ricActionsNotAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
 	actionList := subutils.GetRicActionToBeSetupList(request)
 	reqID, err := subutils.GetRequesterID(request)
@@ -161,13 +176,13 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 		return nil, nil, err
 	}
 
-	log.Debugf("MHO subscription, action list: %v", actionList)
-	log.Debugf("MHO subscription, requester id: %v", reqID)
-	log.Debugf("MHO subscription, ran func id: %v", ranFuncID)
-	log.Debugf("MHO subscription, ric instance id: %v", ricInstanceID)
+	log.Debugf("%s: MHO subscription, action list: %v", m.logPrefix, actionList)
+	log.Debugf("%s: MHO subscription, requester id: %v", m.logPrefix, reqID)
+	log.Debugf("%s: MHO subscription, ran func id: %v", m.logPrefix, ranFuncID)
+	log.Debugf("%s: MHO subscription, ric instance id: %v", m.logPrefix, ricInstanceID)
 
 	for _, action := range actionList {
-		log.Debugf("MHO subscription action: %v", action)
+		log.Debugf("%s: MHO subscription action: %v", m.logPrefix, action)
 		actionID := e2aptypes.RicActionID(action.GetValue().GetRatbsi().GetRicActionId().GetValue())
 		actionType := action.GetValue().GetRatbsi().GetRicActionType()
 		// mho service model supports report and insert action and should be added to the
@@ -176,6 +191,14 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 			actionType == e2apies.RicactionType_RICACTION_TYPE_INSERT {
 			ricActionsAccepted = append(ricActionsAccepted, &actionID)
 		}
+		// report indications are sent for report actions, insert indications suspending the handover of the UE
+		// for insert actions
+		if actionType == e2apies.RicactionType_RICACTION_TYPE_REPORT {
+			reportActions = append(reportActions, actionID)
+		}
+		if actionType == e2apies.RicactionType_RICACTION_TYPE_INSERT {
+			insertActions = append(insertActions, actionID)
+		}
 		// mho service model does not support POLICY actions and
 		// should be added into the list of not admitted actions
 		if actionType == e2apies.RicactionType_RICACTION_TYPE_POLICY {
@@ -190,7 +213,7 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 
 	// At least one required action must be accepted otherwise sends a subscription failure response
 	if len(ricActionsAccepted) == 0 {
-		log.Warn("no action is accepted")
+		log.Warnf("%s: no action is accepted", m.logPrefix)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
@@ -208,14 +231,10 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 		return nil, subscriptionFailure, nil
 	}
 
-	eventTriggerType, err := m.getEventTriggerType(request)
+	eventTrigger, err := trigger.DecodeRequest(request, m.decodeEventTrigger, trigger.Periodic, trigger.Threshold, trigger.OnChange)
 	if err != nil {
-		log.Warn(err)
-		cause := &e2apies.Cause{
-			Cause: &e2apies.Cause_RicRequest{
-				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
-			},
-		}
+		log.Warnf("%s: %v", m.logPrefix, err)
+		cause := trigger.GetCause(err)
 		subscription := subutils.NewSubscription(
 			subutils.WithRequestID(*reqID),
 			subutils.WithRanFuncID(*ranFuncID),
@@ -236,7 +255,7 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 		subutils.WithActionsNotAdmitted(ricActionsNotAdmitted))
 	response, err = subscription.BuildSubscriptionResponse()
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", m.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
@@ -254,51 +273,46 @@ func (m *Mho) RICSubscription(ctx context.Context, request *e2appducontents.Rics
 		return nil, subscriptionFailure, nil
 	}
 
-	log.Debugf("MHO subscription event trigger type: %v", eventTriggerType)
-	switch eventTriggerType {
-	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_PERIODIC:
-		log.Infof("Received periodic report subscription request")
-		go func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			interval, err := m.getReportPeriod(request)
-			if err != nil {
-				log.Error(err)
-				return
-			}
-			m.reportPeriodicIndication(ctx, interval, subscription)
-		}()
-	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT:
-		log.Infof("Received MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT subscription request")
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
+	sub, err := m.ServiceModel.Subscriptions.Get(subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID))
+	if err != nil {
+		return nil, nil, err
+	}
+	sub.AdmitReportActions(reportActions...)
+	sub.AdmitInsertActions(insertActions...)
+
+	log.Debugf("%s: MHO subscription event trigger type: %v", m.logPrefix, eventTrigger.Type)
+	switch eventTrigger.Type {
+	case trigger.Periodic:
+		log.Infof("%s: Received periodic report subscription request", m.logPrefix)
+		sub.Start(func(ctx context.Context) {
+			m.reportPeriodicActions(ctx, int32(eventTrigger.Period), subscription, sub.ReportActions())
+		})
+	case trigger.Threshold:
+		log.Infof("%s: Received MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT subscription request", m.logPrefix)
 		if m.mobilityDriver.GetHoLogic() == "local" {
 			m.mobilityDriver.SetHoLogic("mho")
 		}
 
-		go m.processEventA3MeasReport(ctx, subscription)
-
-	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS:
-		log.Infof("Received MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS subscription request")
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
+		sub.Start(func(ctx context.Context) {
+			m.processEventA3MeasReport(ctx, subscription)
+		})
 
+	case trigger.OnChange:
+		log.Infof("%s: Received MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS subscription request", m.logPrefix)
 		m.rrcUpdateChan = make(chan model.UE)
-		go m.processRrcUpdate(ctx, subscription)
+		sub.Start(func(ctx context.Context) {
+			m.processRrcUpdate(ctx, subscription)
+		})
 		m.mobilityDriver.AddRrcChan(m.rrcUpdateChan)
-
-	default:
-		log.Errorf("MHO subscription failed, invalid event trigger type: %v", eventTriggerType)
 	}
 
-	log.Debug("MHO subscription response: %v", response)
+	log.Debugf("%s: MHO subscription response: %v", m.logPrefix, response)
 	return response, nil, nil
 }
 
 // RICSubscriptionDelete implements subscription delete handler for MHO service model
 func (m *Mho) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
-	log.Infof("Ric subscription delete request is received for service model %v and e2 node with ID: %d", m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
+	log.Infof("%s: Ric subscription delete request is received for service model %v and e2 node with ID: %d", m.logPrefix, m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
 	reqID, err := subdeleteutils.GetRequesterID(request)
 	if err != nil {
 		return nil, nil, err
@@ -316,19 +330,6 @@ func (m *Mho) RICSubscriptionDelete(ctx context.Context, request *e2appducontent
 	if err != nil {
 		return nil, nil, err
 	}
-	eventTriggerAsnBytes := sub.Details.RicEventTriggerDefinition.Value
-
-	var mhoServiceModel e2smmhosm.MhoServiceModel
-	eventTriggerProtoBytes, err := mhoServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
-	if err != nil {
-		return nil, nil, err
-	}
-	eventTriggerDefinition := &e2sm_mho.E2SmMhoEventTriggerDefinition{}
-	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
-	if err != nil {
-		return nil, nil, err
-	}
-	eventTriggerType := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().TriggerType
 	subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
 		subdeleteutils.WithRequestID(*reqID),
 		subdeleteutils.WithRanFuncID(*ranFuncID),
@@ -338,38 +339,87 @@ func (m *Mho) RICSubscriptionDelete(ctx context.Context, request *e2appducontent
 		return nil, nil, err
 	}
 
-	switch eventTriggerType {
-	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_PERIODIC:
-		log.Debug("Stopping the periodic report subscription")
-		sub.Ticker.Stop()
+	// Stops the goroutine sending the indication messages before confirming the delete
+	err = sub.Stop(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
-
 	return response, nil, nil
 }
 
 // RICControl implements control handler for MHO service model
 func (m *Mho) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
-	log.Infof("Control Request is received for service model %v and e2 node ID: %d", m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
+	log.Infof("%s: Control Request is received for service model %v and e2 node ID: %d", m.logPrefix, m.ServiceModel.ModelName, m.ServiceModel.Node.GnbID)
 
-	controlHeader, err := m.getControlHeader(request)
+	reqID, err := controlutils.GetRequesterID(request)
 	if err != nil {
-		log.Error(err)
 		return nil, nil, err
 	}
-	// TODO - check MHO command
-	log.Debugf("MHO control header: %v", controlHeader)
-
-	controlMessage, err := m.getControlMessage(request)
+	ranFuncID, err := controlutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := controlutils.GetRicInstanceID(request)
 	if err != nil {
-		log.Error(err)
 		return nil, nil, err
 	}
-	log.Debugf("MHO control message: %v", controlMessage)
 
-	go func() {
+	// A control header or message which does not decode per the service model is rejected as an invalid message
+	controlHeader, err := m.getControlHeader(request)
+	var controlMessage *e2sm_mho.E2SmMhoControlMessage
+	if err == nil {
+		controlMessage, err = m.getControlMessage(request)
+	}
+	if err != nil {
+		log.Warnf("%s: %v", m.logPrefix, err)
+		failure, err = controlutils.NewControl(
+			controlutils.WithRanFuncID(*ranFuncID),
+			controlutils.WithRequestID(*reqID),
+			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithRicCallProcessID(controlutils.GetRicCallProcessID(request)),
+			controlutils.WithCause(controlutils.NewCause(err))).BuildControlFailure()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, failure, nil
+	}
+	// TODO - check MHO command
+	log.Debugf("%s: MHO control header: %v", m.logPrefix, controlHeader)
+	log.Debugf("%s: MHO control message: %v", m.logPrefix, controlMessage)
+
+	// ToDo - should be reconsidered (not locked on GNb and AmfNGap)
+	imsi := types.IMSI(controlMessage.GetControlMessageFormat1().GetUedId().GetGNbUeid().GetAmfUeNgapId().GetValue())
+
+	// A control referencing the call process of an insert indication applies to the handover it suspended
+	callProcessID := controlutils.GetRicCallProcessID(request)
+	if callProcessID != nil {
+		pending, err := m.resolveHandover(callProcessID)
+		if err != nil {
+			log.Warnf("%s: %v", m.logPrefix, err)
+			cause := &e2apies.Cause{
+				Cause: &e2apies.Cause_RicRequest{
+					RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_RIC_CALL_PROCESS_ID_INVALID,
+				},
+			}
+			failure, err := controlutils.NewControl(
+				controlutils.WithRanFuncID(*ranFuncID),
+				controlutils.WithRequestID(*reqID),
+				controlutils.WithRicInstanceID(*ricInstanceID),
+				controlutils.WithRicCallProcessID(callProcessID),
+				controlutils.WithCause(cause)).BuildControlFailure()
+			if err != nil {
+				return nil, nil, err
+			}
+			return nil, failure, nil
+		}
+		imsi = pending.imsi
+	}
 
-		// ToDo - should be reconsidered (not locked on GNb and AmfNGap)
-		imsi := controlMessage.GetControlMessageFormat1().GetUedId().GetGNbUeid().GetAmfUeNgapId().GetValue()
+	// No handover is triggered by a control whose guard timer expired while it was being resolved
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	go func() {
 
 		plmnIDBytes := controlMessage.GetControlMessageFormat1().GetTargetCgi().GetNRCgi().GetPLmnidentity().GetValue()
 		plmnID := ransimtypes.Uint24ToUint32(plmnIDBytes)
@@ -379,27 +429,17 @@ func (m *Mho) RICControl(ctx context.Context, request *e2appducontents.Riccontro
 			ID:   types.GnbID(tCellNcgi),
 			NCGI: tCellNcgi,
 		}
-		m.mobilityDriver.Handover(ctx, types.IMSI(imsi), tCell)
+		// The handover outlives the procedure, whose context is cancelled once it is acknowledged
+		m.mobilityDriver.Handover(context.Background(), imsi, tCell)
 	}()
 
-	reqID, err := controlutils.GetRequesterID(request)
-	if err != nil {
-		return nil, nil, err
-	}
-	ranFuncID, err := controlutils.GetRanFunctionID(request)
-	if err != nil {
-		return nil, nil, err
-	}
-	ricInstanceID, err := controlutils.GetRicInstanceID(request)
-	if err != nil {
-		return nil, nil, err
-	}
 	response, err = controlutils.NewControl(
 		controlutils.WithRanFuncID(*ranFuncID),
 		controlutils.WithRequestID(*reqID),
-		controlutils.WithRicInstanceID(*ricInstanceID)).BuildControlAcknowledge()
+		controlutils.WithRicInstanceID(*ricInstanceID),
+		controlutils.WithRicCallProcessID(callProcessID)).BuildControlAcknowledge()
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", m.logPrefix, err)
 		return nil, nil, err
 	}
 	return response, nil, nil
@@ -6,32 +6,75 @@ package mho
 
 import (
 	"context"
+	"sync"
+	"time"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/monitor"
 	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
-	"time"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 )
 
-func (m *Mho) reportPeriodicIndication(ctx context.Context, interval int32, subscription *subutils.Subscription) {
-	log.Debugf("Starting periodic report with interval %d ms", interval)
+// reportPeriodicActions runs a periodic report for each admitted report action of the subscription; E2SM-MHO action
+// definitions carry no reporting period, so all of them use the reporting period of the event trigger
+func (m *Mho) reportPeriodicActions(ctx context.Context, interval int32, subscription *subutils.Subscription, actionIDs []e2aptypes.RicActionID) {
+	var wg sync.WaitGroup
+	for _, actionID := range actionIDs {
+		wg.Add(1)
+		go func(actionID e2aptypes.RicActionID) {
+			defer wg.Done()
+			m.reportPeriodicIndication(ctx, interval, subscription, actionID)
+		}(actionID)
+	}
+	wg.Wait()
+}
+
+func (m *Mho) reportPeriodicIndication(ctx context.Context, interval int32, subscription *subutils.Subscription, actionID e2aptypes.RicActionID) {
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
+	log.Debugf("%s: Starting periodic report of action %d with interval %d ms", logPrefix, actionID, interval)
 	intervalDuration := time.Duration(interval)
 	sub, err := m.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
 		return
 	}
-	sub.Ticker = time.NewTicker(intervalDuration * time.Millisecond)
+	// Ticks of the last report of each UE, to honour the report intervals of the UEs
+	tick := int64(0)
+	lastTicks := make(map[ransimtypes.IMSI]int64)
+	due := func(ue *model.UE) bool {
+		if last, ok := lastTicks[ue.IMSI]; ok && !reportDue(ue.MeasReport.ReportInterval, interval, tick-last) {
+			return false
+		}
+		lastTicks[ue.IMSI] = tick
+		return true
+	}
+
+	ticker := monitor.NewTicker(intervalDuration * time.Millisecond)
+	defer ticker.Stop()
 	for {
 		select {
-		case <-sub.Ticker.C:
-			log.Debug("Sending periodic indication report for subscription:", sub.ID)
-			err = m.sendRicIndication(ctx, subscription)
+		case <-ticker.C:
+			tick++
+			log.Debugf("%s: Sending periodic indication report for subscription: %v", logPrefix, sub.ID)
+			err = m.sendRicIndication(ctx, subscription, []e2aptypes.RicActionID{actionID}, due)
 			if err != nil {
-				log.Error("Failure sending indication message: ", err)
+				log.Errorf("%s: Failure sending indication message: %v", logPrefix, err)
 			}
 
+		case <-ctx.Done():
+			return
+
 		case <-sub.E2Channel.Context().Done():
-			sub.Ticker.Stop()
 			return
 		}
 	}
 }
+
+// reportDue returns true if the periodic report of a UE is due, given the number of reporting periods since its last
+// report; the report interval of the UE, if set via RC control, is rounded up to a multiple of the reporting period
+func reportDue(reportInterval int32, period int32, periods int64) bool {
+	return reportInterval <= 0 || periods*int64(period) >= int64(reportInterval)
+}
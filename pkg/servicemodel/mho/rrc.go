@@ -6,23 +6,43 @@ package mho
 
 import (
 	"context"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 )
 
 func (m *Mho) processRrcUpdate(ctx context.Context, subscription *subutils.Subscription) {
-	log.Info("Start processing RRC updates")
-	for update := range m.rrcUpdateChan {
-		log.Debugf("Received RRC Update, IMSI:%v, GnbID:%v, NCGI:%v", update.IMSI, update.Cell.ID, update.Cell.NCGI)
+	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(m.logPrefix, subID)
+	log.Infof("%s: Start processing RRC updates", logPrefix)
+	sub, err := m.ServiceModel.Subscriptions.Get(subID)
+	if err != nil {
+		log.Errorf("%s: %v", logPrefix, err)
+		return
+	}
+	rrcUpdateChan := m.rrcUpdateChan
+	// Stops the mobility driver from publishing RRC updates nobody is reading anymore
+	defer m.mobilityDriver.AddRrcChan(nil)
+	for {
+		select {
+		case update, ok := <-rrcUpdateChan:
+			if !ok {
+				return
+			}
+			log.Debugf("%s: Received RRC Update, IMSI:%v, GnbID:%v, NCGI:%v", logPrefix, update.IMSI, update.Cell.ID, update.Cell.NCGI)
 
-		ue, err := m.ServiceModel.UEs.Get(ctx, update.IMSI)
-		if err != nil {
-			log.Warn(err)
-			continue
-		}
-		err = m.sendRicIndicationFormat2(ctx, update.Cell.NCGI, ue, subscription)
-		if err != nil {
-			log.Warn(err)
-			continue
+			ue, err := m.ServiceModel.UEs.Get(ctx, update.IMSI)
+			if err != nil {
+				log.Warnf("%s: %v", logPrefix, err)
+				continue
+			}
+			err = m.sendRicIndicationFormat2(ctx, update.Cell.NCGI, ue, subscription, sub.ReportActions())
+			if err != nil {
+				log.Warnf("%s: %v", logPrefix, err)
+				continue
+			}
+		case <-ctx.Done():
+			return
 		}
 	}
 }
@@ -5,109 +5,85 @@
 package mho
 
 import (
-	"fmt"
 	e2smmhosm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/servicemodel"
 	e2sm_mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
-	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
 	"google.golang.org/protobuf/proto"
 )
 
+// getControlMessage decodes the MHO control message of the request; a control message which is missing, does not
+// decode or lacks the NR CGI of the target cell is invalid
 func (m *Mho) getControlMessage(request *e2appducontents.RiccontrolRequest) (*e2sm_mho.E2SmMhoControlMessage, error) {
 	var mhoServiceModel e2smmhosm.MhoServiceModel
-	var controlMessageAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRiccontrolMessage) {
-			controlMessageAsnBytes = v.GetValue().GetRch().GetValue()
-			break
-		}
+	controlMessageAsnBytes := controlutils.GetRicControlMessage(request)
+	if len(controlMessageAsnBytes) == 0 {
+		return nil, errors.NewInvalid("MHO control message is missing")
 	}
-
 	controlMessageProtoBytes, err := mhoServiceModel.ControlMessageASN1toProto(controlMessageAsnBytes)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode MHO control message: %v", err)
 	}
 	controlMessage := &e2sm_mho.E2SmMhoControlMessage{}
 	err = proto.Unmarshal(controlMessageProtoBytes, controlMessage)
-
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode MHO control message: %v", err)
+	}
+	nrCgi := controlMessage.GetControlMessageFormat1().GetTargetCgi().GetNRCgi()
+	if nrCgi.GetPLmnidentity() == nil || nrCgi.GetNRcellIdentity().GetValue() == nil {
+		return nil, errors.NewInvalid("MHO control message has no target NR CGI")
 	}
 	return controlMessage, nil
 }
 
+// getControlHeader decodes the MHO control header of the request; a control header which is missing or does not
+// decode is invalid
 func (m *Mho) getControlHeader(request *e2appducontents.RiccontrolRequest) (*e2sm_mho.E2SmMhoControlHeader, error) {
 	var mhoServiceModel e2smmhosm.MhoServiceModel
-	var controlHeaderAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRiccontrolHeader) {
-			controlHeaderAsnBytes = v.GetValue().GetRch().GetValue()
-			break
-		}
+	controlHeaderAsnBytes := controlutils.GetRicControlHeader(request)
+	if len(controlHeaderAsnBytes) == 0 {
+		return nil, errors.NewInvalid("MHO control header is missing")
 	}
-
 	controlHeaderProtoBytes, err := mhoServiceModel.ControlHeaderASN1toProto(controlHeaderAsnBytes)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode MHO control header: %v", err)
 	}
 	controlHeader := &e2sm_mho.E2SmMhoControlHeader{}
 	err = proto.Unmarshal(controlHeaderProtoBytes, controlHeader)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode MHO control header: %v", err)
 	}
-
 	return controlHeader, nil
 }
 
-// getEventTriggerType extracts event trigger type
-func (m *Mho) getEventTriggerType(request *e2appducontents.RicsubscriptionRequest) (e2sm_mho.MhoTriggerType, error) {
-	var eventTriggerAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
-			eventTriggerAsnBytes = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
-			break
-		}
-	}
-
+// decodeEventTrigger decodes the MHO event trigger definition; measurement reports are reported when the A3 event
+// threshold is crossed
+func (m *Mho) decodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
 	var mhoServiceModel e2smmhosm.MhoServiceModel
-	eventTriggerProtoBytes, err := mhoServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
+	eventTriggerProtoBytes, err := mhoServiceModel.EventTriggerDefinitionASN1toProto(definition)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	eventTriggerDefinition := &e2sm_mho.E2SmMhoEventTriggerDefinition{}
 	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
-	eventTriggerType := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().TriggerType
-	return eventTriggerType, nil
-}
-
-// getReportPeriod extracts report period
-func (m *Mho) getReportPeriod(request *e2appducontents.RicsubscriptionRequest) (int32, error) {
-	var eventTriggerAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
-			eventTriggerAsnBytes = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
-			break
+	format1 := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1()
+	switch format1.GetTriggerType() {
+	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_PERIODIC:
+		rp := format1.GetReportingPeriodMs()
+		if rp > 0 && rp < m.config.MinReportInterval {
+			log.Infof("%s: Reporting period %d ms is below the configured minimum; using %d ms", m.logPrefix, rp, m.config.MinReportInterval)
+			rp = m.config.MinReportInterval
 		}
+		return &trigger.Trigger{Type: trigger.Periodic, Period: int64(rp)}, nil
+	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_RCV_MEAS_REPORT:
+		return &trigger.Trigger{Type: trigger.Threshold}, nil
+	case e2sm_mho.MhoTriggerType_MHO_TRIGGER_TYPE_UPON_CHANGE_RRC_STATUS:
+		return &trigger.Trigger{Type: trigger.OnChange}, nil
 	}
-
-	var mhoServiceModel e2smmhosm.MhoServiceModel
-	eventTriggerProtoBytes, err := mhoServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
-	if err != nil {
-		return 0, err
-	}
-	eventTriggerDefinition := &e2sm_mho.E2SmMhoEventTriggerDefinition{}
-	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
-	if err != nil {
-		return 0, err
-	}
-	reportPeriod := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().ReportingPeriodMs
-	if reportPeriod == nil {
-		return 0, fmt.Errorf("no reporting period was set, obtained %v", reportPeriod)
-	}
-	rp := *reportPeriod
-
-	return rp, nil
+	return nil, trigger.NewUnsupported("event trigger type %v is not supported", format1.GetTriggerType())
 }
@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ni implements a stub of the NI (Network Interface) service model which replays network interface messages
+// captured elsewhere, e.g. NGAP, XnAP or S1AP messages of a real node, as the indications of its subscriptions, so
+// that xApps consuming network interface traces can be tested without a core network.
+package ni
+
+import (
+	"context"
+	"encoding/hex"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/sdk"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+)
+
+var _ sdk.Model = &Client{}
+
+var log = logging.GetLogger("sm", "ni")
+
+const (
+	modelName = "ORAN-E2SM-NI"
+	version   = "v1"
+	modelOID  = "1.3.6.1.4.1.53148.1.1.2.1"
+)
+
+// sample captured message replayed as an indication
+type sample struct {
+	iface   string
+	header  []byte
+	message []byte
+}
+
+// Client NI service model, served by the service model SDK
+type Client struct {
+	ServiceModel *registry.ServiceModel
+	config       model.NIConfig
+	samples      []sample
+	logPrefix    string
+}
+
+// NewServiceModel creates a new service model replaying the messages configured for the NI service model
+func NewServiceModel(node model.Node, model *model.Model, modelPluginRegistry modelplugins.ModelRegistry,
+	subStore *subscriptions.Subscriptions, nodeStore nodes.Store, ueStore ues.Store) (registry.ServiceModel, error) {
+	niSm := registry.ServiceModel{
+		RanFunctionID:       registry.Ni,
+		ModelName:           e2smtypes.ShortName(modelName),
+		Revision:            1,
+		OID:                 modelOID,
+		Version:             version,
+		ModelPluginRegistry: modelPluginRegistry,
+		Node:                node,
+		Model:               model,
+		Subscriptions:       subStore,
+		Nodes:               nodeStore,
+		UEs:                 ueStore,
+	}
+	smConfig, _ := model.GetNodeServiceModel(node, int(registry.Ni))
+	samples, err := newSamples(smConfig.NI)
+	if err != nil {
+		log.Error(err)
+		return registry.ServiceModel{}, err
+	}
+	description, err := hex.DecodeString(smConfig.NI.Description)
+	if err != nil {
+		return registry.ServiceModel{}, errors.NewInvalid("invalid NI RAN function description: %v", err)
+	}
+	niClient := &Client{
+		ServiceModel: &niSm,
+		config:       smConfig.NI,
+		samples:      samples,
+		logPrefix:    logfields.Node(node.GnbID),
+	}
+	if len(samples) == 0 {
+		log.Warnf("%s: No message is configured for the NI service model; its subscriptions are not reported", niClient.logPrefix)
+	}
+
+	// The captured messages are replayed periodically to the REPORT actions
+	niSm.Client = sdk.NewClient(&niSm, niClient)
+	niSm.Description = description
+	return niSm, nil
+}
+
+// newSamples decodes the configured messages
+func newSamples(config model.NIConfig) ([]sample, error) {
+	samples := make([]sample, 0, len(config.Messages))
+	for i, message := range config.Messages {
+		switch message.Interface {
+		case model.NIInterfaceNGAP, model.NIInterfaceXnAP, model.NIInterfaceS1AP:
+		default:
+			return nil, errors.NewInvalid("NI message %d has an unknown interface %q", i, message.Interface)
+		}
+		s := sample{iface: message.Interface}
+		var err error
+		if s.header, err = hex.DecodeString(message.Header); err != nil || len(s.header) == 0 {
+			return nil, errors.NewInvalid("NI message %d has no valid indication header", i)
+		}
+		if s.message, err = hex.DecodeString(message.Message); err != nil || len(s.message) == 0 {
+			return nil, errors.NewInvalid("NI message %d has no valid indication message", i)
+		}
+		samples = append(samples, s)
+	}
+	return samples, nil
+}
+
+// sample returns the message replayed by the given report; each subscription replays the messages in turn from the
+// first one
+func (sm *Client) sample(report sdk.Report) *sample {
+	if len(sm.samples) == 0 || report.SN < 1 {
+		return nil
+	}
+	return &sm.samples[(report.SN-1)%int64(len(sm.samples))]
+}
+
+// DecodeEventTrigger accepts the event trigger definitions decoded by the NI model plugin, if it is loaded; the
+// messages are replayed at the configured interval whatever the interface and message type the trigger refers to
+func (sm *Client) DecodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
+	if len(definition) == 0 {
+		return nil, trigger.NewMalformed("event trigger definition is missing")
+	}
+	if modelPlugin, err := sm.ServiceModel.ModelPluginRegistry.GetPlugin(modelOID); err == nil {
+		if _, err := modelPlugin.EventTriggerDefinitionASN1toProto(definition); err != nil {
+			return nil, trigger.NewMalformed("cannot decode event trigger definition: %v", err)
+		}
+	}
+	return &trigger.Trigger{Type: trigger.Periodic, Period: sm.config.GetInterval().Milliseconds()}, nil
+}
+
+// BuildHeader returns the indication header of the message replayed by the report
+func (sm *Client) BuildHeader(ctx context.Context, report sdk.Report) ([]byte, error) {
+	if s := sm.sample(report); s != nil {
+		return s.header, nil
+	}
+	return nil, nil
+}
+
+// BuildMessage returns the indication message of the message replayed by the report; no indication is sent if no
+// message is configured
+func (sm *Client) BuildMessage(ctx context.Context, report sdk.Report) ([]byte, error) {
+	s := sm.sample(report)
+	if s == nil {
+		return nil, nil
+	}
+	log.Debugf("%s: Replaying %s message %d to subscription %s", sm.logPrefix, s.iface, (report.SN-1)%int64(len(sm.samples)), report.Subscription.ID)
+	return s.message, nil
+}
+
+// HandleControl rejects the control requests, which are not supported by the NI service model stub
+func (sm *Client) HandleControl(ctx context.Context, header []byte, message []byte) ([]byte, error) {
+	return nil, errors.NewNotSupported("Control operation is not supported")
+}
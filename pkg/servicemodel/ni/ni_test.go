@@ -0,0 +1,37 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ni
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/sdk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSamples(t *testing.T) {
+	samples, err := newSamples(model.NIConfig{
+		Messages: []model.NIMessage{
+			{Interface: model.NIInterfaceNGAP, Header: "0a0b", Message: "0102"},
+			{Interface: model.NIInterfaceS1AP, Header: "0c0d", Message: "0304"},
+		},
+	})
+	assert.NoError(t, err)
+	client := &Client{samples: samples}
+
+	assert.Nil(t, client.sample(sdk.Report{SN: 0}))
+	assert.Equal(t, []byte{0x01, 0x02}, client.sample(sdk.Report{SN: 1}).message)
+	assert.Equal(t, []byte{0x0c, 0x0d}, client.sample(sdk.Report{SN: 2}).header)
+	assert.Equal(t, model.NIInterfaceNGAP, client.sample(sdk.Report{SN: 3}).iface)
+
+	_, err = newSamples(model.NIConfig{Messages: []model.NIMessage{{Interface: "f1ap", Header: "0a", Message: "01"}}})
+	assert.True(t, errors.IsInvalid(err))
+	_, err = newSamples(model.NIConfig{Messages: []model.NIMessage{{Interface: model.NIInterfaceXnAP, Header: "zz", Message: "01"}}})
+	assert.True(t, errors.IsInvalid(err))
+	_, err = newSamples(model.NIConfig{Messages: []model.NIMessage{{Interface: model.NIInterfaceXnAP, Header: "0a"}}})
+	assert.True(t, errors.IsInvalid(err))
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
 
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/rc/ranfundesc"
 
@@ -33,6 +34,8 @@ import (
 	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
 
 	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
 
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
@@ -41,6 +44,7 @@ import (
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/pdubuilder"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/monitor"
 	"google.golang.org/protobuf/proto"
 
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
@@ -58,29 +62,42 @@ var log = logging.GetLogger("sm", "rc")
 // Client rc service model client
 type Client struct {
 	ServiceModel *registry.ServiceModel
+	config       model.RCConfig
+	logPrefix    string
 }
 
+const (
+	// reportCapability allows RIC subscriptions for PCI and NRT reports
+	reportCapability = "report"
+	// controlCapability allows RIC control requests
+	controlCapability = "control"
+)
+
 func (sm *Client) reportPeriodicIndication(ctx context.Context, interval uint32, subscription *subutils.Subscription) error {
-	log.Debugf("Starting periodic report with interval %d ms", interval)
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(sm.logPrefix, subID)
+	log.Debugf("%s: Starting periodic report with interval %d ms", logPrefix, interval)
 	intervalDuration := time.Duration(interval)
 	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
 		return err
 	}
-	sub.Ticker = time.NewTicker(intervalDuration * time.Millisecond)
+	ticker := monitor.NewTicker(intervalDuration * time.Millisecond)
+	defer ticker.Stop()
 	for {
 		select {
-		case <-sub.Ticker.C:
-			log.Debug("Sending periodic indication report for subscription:", sub.ID)
+		case <-ticker.C:
+			log.Debugf("%s: Sending periodic indication report for subscription: %v", logPrefix, sub.ID)
 			err = sm.sendRicIndication(ctx, subscription)
 			if err != nil {
-				log.Error("creating indication message is failed", err)
+				log.Errorf("%s: creating indication message is failed: %v", logPrefix, err)
 				return err
 			}
 
+		case <-ctx.Done():
+			return nil
+
 		case <-sub.E2Channel.Context().Done():
-			sub.Ticker.Stop()
 			return nil
 		}
 	}
@@ -94,32 +111,36 @@ func (sm *Client) sendRicIndication(ctx context.Context, subscription *subutils.
 	}
 
 	node := sm.ServiceModel.Node
-	// Creates and sends an indication message for each cell in the node
+	// Creates and sends an indication message for each cell in service in the node and each admitted report action
 	for _, ncgi := range node.Cells {
-		ricIndication, err := sm.createRicIndication(ctx, ncgi, subscription)
-		if err != nil {
-			log.Error(err)
-			return err
+		if sm.cellFailed(ctx, ncgi) {
+			continue
 		}
-		err = sub.E2Channel.RICIndication(ctx, ricIndication)
-		if err != nil {
-			log.Error(err)
-			return err
+		for _, actionID := range sub.ReportActions() {
+			ricIndication, err := sm.createRicIndication(ctx, ncgi, subscription, actionID)
+			if err != nil {
+				log.Errorf("%s: %v", sm.logPrefix, err)
+				return err
+			}
+			// Indications which cannot be sent are dropped and counted, the next ones are still sent
+			_ = sm.ServiceModel.SendIndication(ctx, sub, ricIndication)
 		}
 	}
 	return nil
 }
 
 func (sm *Client) reportIndicationOnChange(ctx context.Context, subscription *subutils.Subscription) error {
-	log.Debugf("Sending report indication on change from node: %d", sm.ServiceModel.Node.GnbID)
 	subID := subscriptions.NewID(subscription.GetRicInstanceID(), subscription.GetReqID(), subscription.GetRanFuncID())
+	logPrefix := logfields.Subscription(sm.logPrefix, subID)
+	log.Debugf("%s: Sending report indication on change from node: %d", logPrefix, sm.ServiceModel.Node.GnbID)
 	sub, err := sm.ServiceModel.Subscriptions.Get(subID)
 	if err != nil {
 		return err
 	}
 	cellEventCh := make(chan event.Event)
 	nodeCells := sm.ServiceModel.Node.Cells
-	err = sm.ServiceModel.CellStore.Watch(context.Background(), cellEventCh)
+	// The watch is cancelled along with the subscription
+	err = sm.ServiceModel.CellStore.Watch(ctx, cellEventCh)
 	if err != nil {
 		return err
 	}
@@ -132,8 +153,11 @@ func (sm *Client) reportIndicationOnChange(ctx context.Context, subscription *su
 
 	for {
 		select {
-		case cellEvent := <-cellEventCh:
-			log.Debug("Received cell event:", cellEvent)
+		case cellEvent, ok := <-cellEventCh:
+			if !ok {
+				return nil
+			}
+			log.Debugf("%s: Received cell event: %v", logPrefix, cellEvent)
 			cellEventType := cellEvent.Type.(cells.CellEvent)
 			if cellEventType == cells.UpdatedNeighbors || cellEventType == cells.Updated {
 				cell := cellEvent.Value.(*model.Cell)
@@ -141,14 +165,23 @@ func (sm *Client) reportIndicationOnChange(ctx context.Context, subscription *su
 					if nodeCell == cell.NCGI {
 						err = sm.sendRicIndication(ctx, subscription)
 						if err != nil {
-							log.Error(err)
+							log.Errorf("%s: %v", logPrefix, err)
 						}
 					}
 				}
+			} else if cellEventType == cells.TopologyChanged && containsCell(nodeCells, cellEvent.Value.([]*model.Cell)) {
+				// A single indication reports all the cells of the node updated at once
+				err = sm.sendRicIndication(ctx, subscription)
+				if err != nil {
+					log.Errorf("%s: %v", logPrefix, err)
+				}
 			}
 
+		case <-ctx.Done():
+			return nil
+
 		case <-sub.E2Channel.Context().Done():
-			log.Debug("E2 channel context is done")
+			log.Debugf("%s: E2 channel context is done", logPrefix)
 			return nil
 		}
 	}
@@ -178,6 +211,10 @@ func NewServiceModel(node model.Node, model *model.Model,
 
 	rcClient := &Client{
 		ServiceModel: &rcSm,
+		logPrefix:    logfields.Node(node.GnbID),
+	}
+	if smConfig, err := model.GetNodeServiceModel(node, int(registry.Rcpre2)); err == nil {
+		rcClient.config = smConfig.RC
 	}
 
 	rcSm.Client = rcClient
@@ -199,9 +236,11 @@ func NewServiceModel(node model.Node, model *model.Model,
 	ricEventTriggerStyleList = append(ricEventTriggerStyleList, ricEventTriggerItem1)
 
 	ricReportStyleList := make([]*e2smrcpreies.RicReportStyleList, 0)
-	ricReportStyleItem1 := pdubuilder.CreateRicReportStyleItem(ricReportStyleType, ricReportStyleName, ricIndicationHeaderFormatType,
-		ricIndicationMessageFormatType)
-	ricReportStyleList = append(ricReportStyleList, ricReportStyleItem1)
+	if rcClient.config.HasCapability(reportCapability) {
+		ricReportStyleItem1 := pdubuilder.CreateRicReportStyleItem(ricReportStyleType, ricReportStyleName, ricIndicationHeaderFormatType,
+			ricIndicationMessageFormatType)
+		ricReportStyleList = append(ricReportStyleList, ricReportStyleItem1)
+	}
 
 	ranFuncDescPdu, err := ranfundesc.NewRANFunctionDescription(
 		ranfundesc.WithRANFunctionDescription(ranFunctionDescription),
@@ -230,6 +269,7 @@ func NewServiceModel(node model.Node, model *model.Model,
 		log.Error(err)
 		return registry.ServiceModel{}, err
 	}
+	selfcheck.Check(&rcsm, selfcheck.RanFuncDescription, ranFuncDescPdu, ranFuncDescBytes)
 
 	rcSm.Description = ranFuncDescBytes
 	return rcSm, nil
@@ -242,7 +282,10 @@ func (sm *Client) E2ConnectionUpdate(ctx context.Context, request *e2appduconten
 
 // RICControl implements control handler for RC service model
 func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
-	log.Infof("Control Request is received for service model %v and e2 node ID: %d", sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	log.Infof("%s: Control Request is received for service model %v and e2 node ID: %d", sm.logPrefix, sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	if !sm.config.HasCapability(controlCapability) {
+		return nil, nil, errors.NewNotSupported("control is not supported by the service model of e2 node %d", sm.ServiceModel.Node.GnbID)
+	}
 	reqID, err := controlutils.GetRequesterID(request)
 	if err != nil {
 		return nil, nil, err
@@ -256,42 +299,55 @@ func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.Ricco
 		return nil, nil, err
 	}
 
-	controlMessage, err := sm.getControlMessage(request)
-	if err != nil {
-		log.Error(err)
-		return nil, nil, err
-	}
-
+	// A control header or message which does not decode per the service model is rejected as an invalid message
 	controlHeader, err := sm.getControlHeader(request)
+	var controlMessage *e2smrcpreies.E2SmRcPreControlMessage
+	if err == nil {
+		controlMessage, err = sm.getControlMessage(request)
+	}
 	if err != nil {
-		log.Error(err)
-		return nil, nil, err
+		log.Warnf("%s: %v", sm.logPrefix, err)
+		failure, err = controlutils.NewControl(
+			controlutils.WithRanFuncID(*ranFuncID),
+			controlutils.WithRequestID(*reqID),
+			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(controlutils.NewCause(err))).BuildControlFailure()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, failure, nil
 	}
 
-	log.Debugf("RC control header: %v", controlHeader)
-	log.Debugf("RC control message: %v", controlMessage)
+	log.Debugf("%s: RC control header: %v", sm.logPrefix, controlHeader)
+	log.Debugf("%s: RC control message: %v", sm.logPrefix, controlMessage)
 
 	plmnIDBytes := controlHeader.GetControlHeaderFormat1().Cgi.GetNrCgi().PLmnIdentity.Value
 	nci := utils.BitStringToUint64(controlHeader.GetControlHeaderFormat1().GetCgi().GetNrCgi().NRcellIdentity.Value.GetValue(), 36)
 	plmnID := ransimtypes.Uint24ToUint32(plmnIDBytes)
-	log.Debugf("NCI is %d and PLMN ID is %d", nci, plmnID)
+	log.Debugf("%s: NCI is %d and PLMN ID is %d", sm.logPrefix, nci, plmnID)
 
 	ncgi := ransimtypes.ToNCGI(ransimtypes.PlmnID(plmnID), ransimtypes.NCI(nci))
 	parameterName := controlMessage.GetControlMessage().ParameterType.RanParameterName.Value
 	parameterID := controlMessage.GetControlMessage().ParameterType.RanParameterId.Value
 	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
 	if err != nil {
-		log.Debugf("Ran parameter for entity %d not found", ncgi)
+		log.Debugf("%s: Ran parameter for entity %d not found", sm.logPrefix, ncgi)
 		outcomeAsn1Bytes, err := controloutcome.NewControlOutcome(
 			controloutcome.WithRanParameterID(parameterID)).
 			ToAsn1Bytes()
 		if err != nil {
 			return nil, nil, err
 		}
+		cause := &e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
+			},
+		}
 		failure, err = controlutils.NewControl(
 			controlutils.WithRanFuncID(*ranFuncID),
 			controlutils.WithRequestID(*reqID),
 			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(cause),
 			controlutils.WithRicControlOutcome(outcomeAsn1Bytes)).BuildControlFailure()
 		if err != nil {
 			return nil, nil, err
@@ -308,21 +364,40 @@ func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.Ricco
 	case e2smrcpreies.RanparameterType_RANPARAMETER_TYPE_PRINTABLE_STRING:
 		parameterValue = controlMessage.GetControlMessage().GetParameterVal().GetValuePrtS()
 	}
+	// The control is abandoned without being applied once the guard timer of the procedure expired
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
 	setPCI(parameterName, parameterValue, cell)
+	setTilt(parameterName, parameterValue, cell)
+	setTxPower(parameterName, parameterValue, cell)
+	sm.setSecondaryCell(ctx, parameterName, parameterValue, cell)
+	sm.setCarrierActive(ctx, parameterName, parameterValue, cell)
 	sm.setHandoverOcn(ctx, parameterName, parameterValue, cell)
+	sm.setMeasReportConfig(ctx, parameterName, parameterValue)
 
-	err = sm.ServiceModel.CellStore.Update(ctx, cell)
+	err = setCellIndividualOffset(parameterName, parameterValue, cell)
+	if err == nil {
+		err = sm.ServiceModel.CellStore.Update(ctx, cell)
+	}
 	if err != nil {
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		outcomeAsn1Bytes, err := controloutcome.NewControlOutcome(
 			controloutcome.WithRanParameterID(parameterID)).
 			ToAsn1Bytes()
 		if err != nil {
 			return nil, nil, err
 		}
+		cause := &e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
+			},
+		}
 		failure, err = controlutils.NewControl(
 			controlutils.WithRanFuncID(*ranFuncID),
 			controlutils.WithRequestID(*reqID),
 			controlutils.WithRicInstanceID(*ricInstanceID),
+			controlutils.WithCause(cause),
 			controlutils.WithRicControlOutcome(outcomeAsn1Bytes)).BuildControlFailure()
 		if err != nil {
 			return nil, nil, err
@@ -350,8 +425,12 @@ func (sm *Client) RICControl(ctx context.Context, request *e2appducontents.Ricco
 
 // RICSubscription implements subscription handler for RC service model
 func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
-	log.Infof("Ric Subscription Request is received for service model %v and e2 node with ID:%d", sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	log.Infof("%s: Ric Subscription Request is received for service model %v and e2 node with ID:%d", sm.logPrefix, sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	if !sm.config.HasCapability(reportCapability) {
+		return nil, nil, errors.NewNotSupported("report is not supported by the service model of e2 node %d", sm.ServiceModel.Node.GnbID)
+	}
 	var ricActionsAccepted []*e2aptypes.RicActionID
+	var reportActions []e2aptypes.RicActionID
 	ricActionsNotAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
 	actionList := subutils.GetRicActionToBeSetupList(request)
 	reqID, err := subutils.GetRequesterID(request)
@@ -376,6 +455,10 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 			actionType == e2apies.RicactionType_RICACTION_TYPE_INSERT {
 			ricActionsAccepted = append(ricActionsAccepted, &actionID)
 		}
+		// indications are only sent for report actions
+		if actionType == e2apies.RicactionType_RICACTION_TYPE_REPORT {
+			reportActions = append(reportActions, actionID)
+		}
 		// rc service model does not support POLICY actions and
 		// should be added into the list of not admitted actions
 		if actionType == e2apies.RicactionType_RICACTION_TYPE_POLICY {
@@ -407,14 +490,10 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		return nil, subscriptionFailure, nil
 	}
 
-	eventTriggerType, err := sm.getEventTriggerType(request)
+	eventTrigger, err := trigger.DecodeRequest(request, decodeEventTrigger, trigger.OnChange, trigger.Periodic)
 	if err != nil {
-		log.Warn(err)
-		cause := &e2apies.Cause{
-			Cause: &e2apies.Cause_RicRequest{
-				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
-			},
-		}
+		log.Warnf("%s: %v", sm.logPrefix, err)
+		cause := trigger.GetCause(err)
 		subscription := subutils.NewSubscription(
 			subutils.WithRequestID(*reqID),
 			subutils.WithRanFuncID(*ranFuncID),
@@ -436,7 +515,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 
 	response, err = subscription.BuildSubscriptionResponse()
 	if err != nil {
-		log.Warn(err)
+		log.Warnf("%s: %v", sm.logPrefix, err)
 		cause := &e2apies.Cause{
 			Cause: &e2apies.Cause_RicRequest{
 				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
@@ -454,33 +533,29 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 		return nil, subscriptionFailure, nil
 	}
 
-	switch eventTriggerType {
-	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_UPON_CHANGE:
-		log.Debug("Received on change report subscription request")
-		go func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			err = sm.reportIndicationOnChange(ctx, subscription)
-			if err != nil {
-				return
-			}
-		}()
-	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_PERIODIC:
-		log.Debug("Received periodic report subscription request")
-		go func() {
-			ctx, cancel := context.WithCancel(context.Background())
-			defer cancel()
-			interval, err := sm.getReportPeriod(request)
+	sub, err := sm.ServiceModel.Subscriptions.Get(subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID))
+	if err != nil {
+		return nil, nil, err
+	}
+	sub.AdmitReportActions(reportActions...)
+
+	switch eventTrigger.Type {
+	case trigger.OnChange:
+		log.Debugf("%s: Received on change report subscription request", sm.logPrefix)
+		sub.Start(func(ctx context.Context) {
+			err := sm.reportIndicationOnChange(ctx, subscription)
 			if err != nil {
-				log.Error(err)
 				return
 			}
-			err = sm.reportPeriodicIndication(ctx, interval, subscription)
+		})
+	case trigger.Periodic:
+		log.Debugf("%s: Received periodic report subscription request", sm.logPrefix)
+		sub.Start(func(ctx context.Context) {
+			err := sm.reportPeriodicIndication(ctx, uint32(eventTrigger.Period), subscription)
 			if err != nil {
 				return
 			}
-		}()
-
+		})
 	}
 
 	return response, nil, nil
@@ -488,7 +563,7 @@ func (sm *Client) RICSubscription(ctx context.Context, request *e2appducontents.
 
 // RICSubscriptionDelete implements subscription delete handler for RC service model
 func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
-	log.Infof("Ric subscription delete request is received for service model %v and e2 node with ID: %d", sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
+	log.Infof("%s: Ric subscription delete request is received for service model %v and e2 node with ID: %d", sm.logPrefix, sm.ServiceModel.ModelName, sm.ServiceModel.Node.GnbID)
 	reqID, err := subdeleteutils.GetRequesterID(request)
 	if err != nil {
 		return nil, nil, err
@@ -506,19 +581,6 @@ func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducon
 	if err != nil {
 		return nil, nil, err
 	}
-	eventTriggerAsnBytes := sub.Details.RicEventTriggerDefinition.Value
-
-	var rcPreServiceModel e2smrcpresm.RcPreServiceModel
-	eventTriggerProtoBytes, err := rcPreServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
-	if err != nil {
-		return nil, nil, err
-	}
-	eventTriggerDefinition := &e2smrcpreies.E2SmRcPreEventTriggerDefinition{}
-	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
-	if err != nil {
-		return nil, nil, err
-	}
-	eventTriggerType := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().TriggerType
 	subscriptionDelete := subdeleteutils.NewSubscriptionDelete(
 		subdeleteutils.WithRequestID(*reqID),
 		subdeleteutils.WithRanFuncID(*ranFuncID),
@@ -528,13 +590,10 @@ func (sm *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducon
 		return nil, nil, err
 	}
 
-	switch eventTriggerType {
-	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_PERIODIC:
-		log.Debug("Stopping the periodic report subscription")
-		sub.Ticker.Stop()
-	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_UPON_CHANGE:
-		// TODO stop on change event trigger
+	// Stops the goroutine sending the indication messages before confirming the delete
+	err = sub.Stop(ctx)
+	if err != nil {
+		return nil, nil, err
 	}
-
 	return response, nil, nil
 }
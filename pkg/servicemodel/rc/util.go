@@ -6,99 +6,106 @@ package rc
 
 import (
 	"context"
-	"fmt"
+	"strconv"
+	"strings"
+
 	e2smrcpresm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/servicemodel"
-	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 
 	meastype "github.com/onosproject/rrm-son-lib/pkg/model/measurement/type"
 
 	"github.com/onosproject/ran-simulator/pkg/model"
 
+	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
 	indicationutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
 	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
 	rcindicationhdr "github.com/onosproject/ran-simulator/pkg/utils/e2sm/rc/indication/header"
 	rcindicationmsg "github.com/onosproject/ran-simulator/pkg/utils/e2sm/rc/indication/message"
 	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/rc/nrt"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/onosproject/ran-simulator/pkg/utils/plmn"
 
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	e2smrcpreies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"google.golang.org/protobuf/proto"
 )
 
+// getControlMessage decodes the RC-PRE control message of the request; a control message which is missing, does not
+// decode or lacks the RAN parameter to set is invalid
 func (sm *Client) getControlMessage(request *e2appducontents.RiccontrolRequest) (*e2smrcpreies.E2SmRcPreControlMessage, error) {
 	var rcPreServiceModel e2smrcpresm.RcPreServiceModel
-	var controlMessageAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRiccontrolMessage) {
-			controlMessageAsnBytes = v.GetValue().GetRcm().GetValue()
-			break
-		}
+	controlMessageAsnBytes := controlutils.GetRicControlMessage(request)
+	if len(controlMessageAsnBytes) == 0 {
+		return nil, errors.NewInvalid("RC-PRE control message is missing")
 	}
 	controlMessageProtoBytes, err := rcPreServiceModel.ControlMessageASN1toProto(controlMessageAsnBytes)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode RC-PRE control message: %v", err)
 	}
 	controlMessage := &e2smrcpreies.E2SmRcPreControlMessage{}
 	err = proto.Unmarshal(controlMessageProtoBytes, controlMessage)
-
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode RC-PRE control message: %v", err)
+	}
+	parameterType := controlMessage.GetControlMessage().GetParameterType()
+	if parameterType.GetRanParameterName() == nil || parameterType.GetRanParameterId() == nil ||
+		controlMessage.GetControlMessage().GetParameterVal() == nil {
+		return nil, errors.NewInvalid("RC-PRE control message has no RAN parameter")
 	}
 	return controlMessage, nil
 }
 
+// getControlHeader decodes the RC-PRE control header of the request; a control header which is missing, does not
+// decode or lacks the NR CGI of the controlled cell is invalid
 func (sm *Client) getControlHeader(request *e2appducontents.RiccontrolRequest) (*e2smrcpreies.E2SmRcPreControlHeader, error) {
 	var rcPreServiceModel e2smrcpresm.RcPreServiceModel
-	var controlHeaderAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRiccontrolHeader) {
-			controlHeaderAsnBytes = v.GetValue().GetRch().GetValue()
-			break
-		}
+	controlHeaderAsnBytes := controlutils.GetRicControlHeader(request)
+	if len(controlHeaderAsnBytes) == 0 {
+		return nil, errors.NewInvalid("RC-PRE control header is missing")
 	}
 	controlHeaderProtoBytes, err := rcPreServiceModel.ControlHeaderASN1toProto(controlHeaderAsnBytes)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode RC-PRE control header: %v", err)
 	}
 	controlHeader := &e2smrcpreies.E2SmRcPreControlHeader{}
 	err = proto.Unmarshal(controlHeaderProtoBytes, controlHeader)
 	if err != nil {
-		return nil, err
+		return nil, errors.NewInvalid("unable to decode RC-PRE control header: %v", err)
+	}
+	nrCgi := controlHeader.GetControlHeaderFormat1().GetCgi().GetNrCgi()
+	if nrCgi.GetPLmnIdentity() == nil || nrCgi.GetNRcellIdentity().GetValue() == nil {
+		return nil, errors.NewInvalid("RC-PRE control header has no NR CGI")
 	}
-
 	return controlHeader, nil
 }
 
-// getEventTriggerType extracts event trigger type
-func (sm *Client) getEventTriggerType(request *e2appducontents.RicsubscriptionRequest) (e2smrcpreies.RcPreTriggerType, error) {
-	var eventTriggerAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
-			eventTriggerAsnBytes = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
-			break
-		}
-	}
-
+// decodeEventTrigger decodes the RC-PRE event trigger definition
+func decodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
 	var rcPreServiceModel e2smrcpresm.RcPreServiceModel
-	eventTriggerProtoBytes, err := rcPreServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
+	eventTriggerProtoBytes, err := rcPreServiceModel.EventTriggerDefinitionASN1toProto(definition)
 	if err != nil {
-		return -1, err
+		return nil, err
 	}
 	eventTriggerDefinition := &e2smrcpreies.E2SmRcPreEventTriggerDefinition{}
 	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
 	if err != nil {
-		return -1, err
+		return nil, err
+	}
+	format1 := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1()
+	switch format1.GetTriggerType() {
+	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_UPON_CHANGE:
+		return &trigger.Trigger{Type: trigger.OnChange}, nil
+	case e2smrcpreies.RcPreTriggerType_RC_PRE_TRIGGER_TYPE_PERIODIC:
+		return &trigger.Trigger{Type: trigger.Periodic, Period: int64(format1.GetReportingPeriodMs())}, nil
 	}
-	eventTriggerType := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().TriggerType
-	return eventTriggerType, nil
+	return nil, trigger.NewUnsupported("event trigger type %v is not supported", format1.GetTriggerType())
 }
 
-func (sm *Client) getPlmnID() ransimtypes.Uint24 {
-	plmnIDUint24 := ransimtypes.Uint24{}
-	plmnIDUint24.Set(uint32(sm.ServiceModel.Model.PlmnID))
-	return plmnIDUint24
+func (sm *Client) getPlmnID(ncgi ransimtypes.NCGI) ransimtypes.Uint24 {
+	return *plmn.ToUint24(sm.ServiceModel.Model.GetCellPlmnID(ncgi))
 }
 
 func (sm *Client) toCellSizeEnum(cellSize string) e2smrcpreies.CellSize {
@@ -134,47 +141,32 @@ func (sm *Client) getEARFCN(ctx context.Context, ncgi ransimtypes.NCGI) (int32,
 	return int32(cell.Earfcn), nil
 }
 
-func (sm *Client) getCellSize(ctx context.Context, ncgi ransimtypes.NCGI) (string, error) {
+func (sm *Client) getNrArfcn(ctx context.Context, ncgi ransimtypes.NCGI) (int32, error) {
 	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
 	if err != nil {
-		return "", err
+		return 0, err
 	}
-	return cell.CellType.String(), nil
+
+	return int32(cell.Arfcn), nil
 }
 
-// getReportPeriod extracts report period
-func (sm *Client) getReportPeriod(request *e2appducontents.RicsubscriptionRequest) (uint32, error) {
-	var eventTriggerAsnBytes []byte
-	for _, v := range request.GetProtocolIes() {
-		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
-			eventTriggerAsnBytes = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
-			break
-		}
-	}
+// cellFailed returns true if the cell is out of service
+func (sm *Client) cellFailed(ctx context.Context, ncgi ransimtypes.NCGI) bool {
+	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
+	return err == nil && cell.Failed
+}
 
-	var rcPreServiceModel e2smrcpresm.RcPreServiceModel
-	eventTriggerProtoBytes, err := rcPreServiceModel.EventTriggerDefinitionASN1toProto(eventTriggerAsnBytes)
-	if err != nil {
-		return 0, err
-	}
-	eventTriggerDefinition := &e2smrcpreies.E2SmRcPreEventTriggerDefinition{}
-	err = proto.Unmarshal(eventTriggerProtoBytes, eventTriggerDefinition)
+func (sm *Client) getCellSize(ctx context.Context, ncgi ransimtypes.NCGI) (string, error) {
+	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
 	if err != nil {
-		return 0, err
-	}
-	reportPeriod := eventTriggerDefinition.GetEventDefinitionFormats().GetEventDefinitionFormat1().ReportingPeriodMs
-
-	if reportPeriod == nil {
-		return 0, fmt.Errorf("no reporting period was set, obtained %v", reportPeriod)
+		return "", err
 	}
-	rp := uint32(*reportPeriod)
-
-	return rp, nil
+	return cell.CellType.String(), nil
 }
 
-// createRicIndication creates ric indication  for each cell in the node
-func (sm *Client) createRicIndication(ctx context.Context, ncgi ransimtypes.NCGI, subscription *subutils.Subscription) (*e2appducontents.Ricindication, error) {
-	plmnID := sm.getPlmnID()
+// createRicIndication creates ric indication of the given report action for a cell in the node
+func (sm *Client) createRicIndication(ctx context.Context, ncgi ransimtypes.NCGI, subscription *subutils.Subscription, actionID e2aptypes.RicActionID) (*e2appducontents.Ricindication, error) {
+	plmnID := sm.getPlmnID(ncgi)
 	var neighbourList []*e2smrcpreies.Nrt
 	neighbourList = make([]*e2smrcpreies.Nrt, 0)
 	cell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
@@ -183,39 +175,53 @@ func (sm *Client) createRicIndication(ctx context.Context, ncgi ransimtypes.NCGI
 	}
 	cellPci, err := sm.getCellPCI(ctx, ncgi)
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", sm.logPrefix, err)
 		return nil, err
 	}
 	earfcn, err := sm.getEARFCN(ctx, ncgi)
 	if err != nil {
 		return nil, err
 	}
+	nrArfcn, err := sm.getNrArfcn(ctx, ncgi)
+	if err != nil {
+		return nil, err
+	}
 
 	cellSize, err := sm.getCellSize(ctx, ncgi)
 	if err != nil {
 		return nil, err
 	}
 	for _, neighbourNcgi := range cell.Neighbors {
+		// failed cells are not reported as neighbours
+		if sm.cellFailed(ctx, neighbourNcgi) {
+			continue
+		}
 		neighbourCellPci, err := sm.getCellPCI(ctx, neighbourNcgi)
 		if err != nil {
-			log.Error(err)
+			log.Errorf("%s: %v", sm.logPrefix, err)
 			return nil, err
 		}
 		neighbourEarfcn, err := sm.getEARFCN(ctx, neighbourNcgi)
 		if err != nil {
 			return nil, err
 		}
+		neighbourNrArfcn, err := sm.getNrArfcn(ctx, neighbourNcgi)
+		if err != nil {
+			return nil, err
+		}
 		neighbourCellSize, err := sm.getCellSize(ctx, neighbourNcgi)
 		if err != nil {
 			return nil, err
 		}
 		neighbourEci := ransimtypes.GetNCI(neighbourNcgi)
+		neighbourPlmnID := sm.getPlmnID(neighbourNcgi)
 		neighbour, err := nrt.NewNeighbour(
 			nrt.WithPci(neighbourCellPci),
 			nrt.WithNrcellIdentity(uint64(neighbourEci)),
 			nrt.WithEarfcn(neighbourEarfcn),
+			nrt.WithNrArfcn(neighbourNrArfcn),
 			nrt.WithCellSize(sm.toCellSizeEnum(neighbourCellSize)),
-			nrt.WithPlmnID(plmnID.Value())).Build()
+			nrt.WithPlmnID(neighbourPlmnID.Value())).Build()
 		if err == nil {
 			neighbourList = append(neighbourList, neighbour)
 		}
@@ -231,18 +237,19 @@ func (sm *Client) createRicIndication(ctx context.Context, ncgi ransimtypes.NCGI
 	message := rcindicationmsg.NewIndicationMessage(rcindicationmsg.WithPlmnID(plmnID.Value()),
 		rcindicationmsg.WithCellSize(sm.toCellSizeEnum(cellSize)),
 		rcindicationmsg.WithEarfcn(earfcn),
+		rcindicationmsg.WithNrArfcn(nrArfcn),
 		rcindicationmsg.WithPci(cellPci),
 		rcindicationmsg.WithNeighbours(neighbourList))
 
 	indicationHeaderAsn1Bytes, err := header.ToAsn1Bytes()
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", sm.logPrefix, err)
 		return nil, err
 	}
 
 	indicationMessageAsn1Bytes, err := message.ToAsn1Bytes()
 	if err != nil {
-		log.Error(err)
+		log.Errorf("%s: %v", sm.logPrefix, err)
 		return nil, err
 	}
 
@@ -251,12 +258,13 @@ func (sm *Client) createRicIndication(ctx context.Context, ncgi ransimtypes.NCGI
 		indicationutils.WithRicInstanceID(subscription.GetRicInstanceID()),
 		indicationutils.WithRanFuncID(subscription.GetRanFuncID()),
 		indicationutils.WithRequestID(subscription.GetReqID()),
+		indicationutils.WithRicActionID(int32(actionID)),
 		indicationutils.WithIndicationHeader(indicationHeaderAsn1Bytes),
 		indicationutils.WithIndicationMessage(indicationMessageAsn1Bytes))
 
 	ricIndication, err := indication.Build()
 	if err != nil {
-		log.Error("creating indication message is failed", err)
+		log.Errorf("%s: creating indication message is failed: %v", sm.logPrefix, err)
 		return nil, err
 	}
 	return ricIndication, nil
@@ -277,6 +285,174 @@ func setPCI(parameterName string, parameterValue interface{}, cell *model.Cell)
 	}
 }
 
+func setTilt(parameterName string, parameterValue interface{}, cell *model.Cell) {
+	if parameterName == "tilt" {
+		switch parameterValue := parameterValue.(type) {
+		case int32:
+			cell.Sector.ElectricalTilt = parameterValue
+		case uint32:
+			cell.Sector.ElectricalTilt = int32(parameterValue)
+		case int64:
+			cell.Sector.ElectricalTilt = int32(parameterValue)
+		case uint64:
+			cell.Sector.ElectricalTilt = int32(parameterValue)
+		}
+	}
+}
+
+// setTxPower sets the transmission power of the cell in dB; the mobility driver refreshes the signal strength of the
+// UEs as soon as the cell is updated
+func setTxPower(parameterName string, parameterValue interface{}, cell *model.Cell) {
+	if parameterName == "tx_power" {
+		switch parameterValue := parameterValue.(type) {
+		case int32:
+			cell.TxPowerDB = float64(parameterValue)
+		case uint32:
+			cell.TxPowerDB = float64(parameterValue)
+		case int64:
+			cell.TxPowerDB = float64(parameterValue)
+		case uint64:
+			cell.TxPowerDB = float64(parameterValue)
+		}
+	}
+}
+
+// setSecondaryCell adds the cell as secondary cell of the UE whose IMSI is the value of the scg_add parameter, or
+// releases the secondary cell of the UE whose IMSI is the value of the scg_release parameter
+func (sm *Client) setSecondaryCell(ctx context.Context, parameterName string, parameterValue interface{}, cell *model.Cell) {
+	if parameterName != "scg_add" && parameterName != "scg_release" {
+		return
+	}
+	imsi := toIMSI(parameterValue)
+	ue, err := sm.ServiceModel.UEs.Get(ctx, imsi)
+	if err != nil {
+		log.Errorf("%s: UE (%v) is not in UE store", sm.logPrefix, imsi)
+		return
+	}
+
+	if parameterName == "scg_release" {
+		if err := sm.ServiceModel.UEs.SetSecondaryCell(ctx, imsi, nil, 0); err != nil {
+			log.Errorf("%s: %v", sm.logPrefix, err)
+		}
+		return
+	}
+	if ransimtypes.GetGnbID(uint64(ue.Cell.NCGI)) == ransimtypes.GetGnbID(uint64(cell.NCGI)) {
+		log.Errorf("%s: the cell NCGI (%v) is on the serving node of UE (%v)", sm.logPrefix, cell.NCGI, imsi)
+		return
+	}
+	secondaryCell := &model.UECell{
+		ID:   ransimtypes.GnbID(cell.NCGI),
+		NCGI: cell.NCGI,
+	}
+	// The signal strength of the secondary cell is measured on the next mobility update of the UE
+	if err := sm.ServiceModel.UEs.SetSecondaryCell(ctx, imsi, secondaryCell, sm.ServiceModel.Model.DualConnectivity.GetSplitRatio()); err != nil {
+		log.Errorf("%s: %v", sm.logPrefix, err)
+	}
+}
+
+// setCarrierActive activates the cell as secondary cell of the UE whose IMSI is the value of the scell_activate
+// parameter, or deactivates it for the UE whose IMSI is the value of the scell_deactivate parameter
+func (sm *Client) setCarrierActive(ctx context.Context, parameterName string, parameterValue interface{}, cell *model.Cell) {
+	if parameterName != "scell_activate" && parameterName != "scell_deactivate" {
+		return
+	}
+	imsi := toIMSI(parameterValue)
+	ue, err := sm.ServiceModel.UEs.Get(ctx, imsi)
+	if err != nil {
+		log.Errorf("%s: UE (%v) is not in UE store", sm.logPrefix, imsi)
+		return
+	}
+
+	if parameterName == "scell_activate" {
+		if ransimtypes.GetGnbID(uint64(ue.Cell.NCGI)) != ransimtypes.GetGnbID(uint64(cell.NCGI)) {
+			log.Errorf("%s: the cell NCGI (%v) is not on the serving node of UE (%v)", sm.logPrefix, cell.NCGI, imsi)
+			return
+		}
+		active := 1
+		for _, carrier := range ue.SecondaryCarriers() {
+			if carrier.Active && carrier.NCGI != cell.NCGI {
+				active++
+			}
+		}
+		if active >= sm.ServiceModel.Model.CarrierAggregation.GetMaxCarriers() {
+			log.Errorf("%s: UE (%v) already aggregates %d carriers", sm.logPrefix, imsi, active)
+			return
+		}
+	}
+	// The PRBs and throughput of the carrier are accounted on the next mobility update of the UE
+	if err := sm.ServiceModel.UEs.SetCarrierActive(ctx, imsi, cell.NCGI, parameterName == "scell_activate"); err != nil {
+		log.Errorf("%s: %v", sm.logPrefix, err)
+	}
+}
+
+// setMeasReportConfig sets the measurement reporting configuration of a UE from the value of the meas_report
+// parameter, a comma separated list of key=value pairs such as "imsi=1234,interval=480,hysteresis=2,trigger=rsrq";
+// the parameters which are not given are left unchanged. The configuration of the UE whose IMSI is the value of the
+// meas_report_reset parameter is cleared.
+func (sm *Client) setMeasReportConfig(ctx context.Context, parameterName string, parameterValue interface{}) {
+	if parameterName == "meas_report_reset" {
+		if err := sm.ServiceModel.UEs.SetMeasReportConfig(ctx, toIMSI(parameterValue), model.MeasReportConfig{}); err != nil {
+			log.Errorf("%s: %v", sm.logPrefix, err)
+		}
+		return
+	}
+	if parameterName != "meas_report" {
+		return
+	}
+	value, ok := parameterValue.(string)
+	if !ok {
+		log.Errorf("%s: the meas_report parameter must be a printable string: %v", sm.logPrefix, parameterValue)
+		return
+	}
+
+	params := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		kv := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(kv) != 2 {
+			log.Errorf("%s: invalid measurement report parameter %s", sm.logPrefix, pair)
+			return
+		}
+		params[kv[0]] = kv[1]
+	}
+	imsi, err := strconv.ParseUint(params["imsi"], 10, 64)
+	if err != nil {
+		log.Errorf("%s: invalid IMSI %s", sm.logPrefix, params["imsi"])
+		return
+	}
+	delete(params, "imsi")
+	ue, err := sm.ServiceModel.UEs.Get(ctx, ransimtypes.IMSI(imsi))
+	if err != nil {
+		log.Errorf("%s: UE (%v) is not in UE store", sm.logPrefix, imsi)
+		return
+	}
+
+	config := ue.MeasReport
+	for name, value := range params {
+		if err := config.Set(name, value); err != nil {
+			log.Errorf("%s: %v", sm.logPrefix, err)
+			return
+		}
+	}
+	if err := sm.ServiceModel.UEs.SetMeasReportConfig(ctx, ue.IMSI, config); err != nil {
+		log.Errorf("%s: %v", sm.logPrefix, err)
+	}
+}
+
+// toIMSI returns the IMSI given as value of a RAN parameter
+func toIMSI(parameterValue interface{}) ransimtypes.IMSI {
+	switch parameterValue := parameterValue.(type) {
+	case int32:
+		return ransimtypes.IMSI(parameterValue)
+	case uint32:
+		return ransimtypes.IMSI(parameterValue)
+	case int64:
+		return ransimtypes.IMSI(parameterValue)
+	case uint64:
+		return ransimtypes.IMSI(parameterValue)
+	}
+	return 0
+}
+
 func (sm *Client) setHandoverOcn(ctx context.Context, parameterName string, parameterValue interface{}, cell *model.Cell) {
 	var ocnRc meastype.QOffsetRange
 	nCellNCGI := cell.NCGI
@@ -299,15 +475,77 @@ func (sm *Client) setHandoverOcn(ctx context.Context, parameterName string, para
 			}
 			sCell, err := sm.ServiceModel.CellStore.Get(ctx, ncgi)
 			if err != nil {
-				log.Errorf("NCGI (%v) is not in cell store")
+				log.Errorf("%s: NCGI (%v) is not in cell store", sm.logPrefix)
 			}
 			if _, ok := sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI]; !ok {
-				log.Errorf("the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", nCellNCGI, ncgi)
+				log.Errorf("%s: the cell NCGI (%v) is not a neighbor of the cell NCGI (%v)", sm.logPrefix, nCellNCGI, ncgi)
 				continue
 			}
-			log.Debugf("Cell (%v) Ocn in the cell (%v) is set from %v to %v", cell.NCGI, ncgi, sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI], ocnRc.GetValue().(int))
+			log.Debugf("%s: Cell (%v) Ocn in the cell (%v) is set from %v to %v", sm.logPrefix, cell.NCGI, ncgi, sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI], ocnRc.GetValue().(int))
 			sCell.MeasurementParams.NCellIndividualOffsets[nCellNCGI] = int32(ocnRc.GetValue().(int))
 		}
 	}
 
 }
+
+// cioParameterPrefix prefix of the name of the RAN parameter setting the cell individual offset of a neighbor of the
+// cell of the control header, followed by the NCGI of the neighbor, e.g. cio:21458294227474
+const cioParameterPrefix = "cio:"
+
+// maxCellIndividualOffset bound of the cell individual offsets in dB, as given by the Q-OffsetRange of 3GPP TS 38.331
+const maxCellIndividualOffset = 24
+
+// setCellIndividualOffset sets the cell individual offset in dB which the cell applies to the neighbor given by the
+// name of the RAN parameter in the event A3 evaluation of its UEs, so that load balancing xApps can shift the UEs of
+// a pair of cells by tuning its offset
+func setCellIndividualOffset(parameterName string, parameterValue interface{}, cell *model.Cell) error {
+	if !strings.HasPrefix(parameterName, cioParameterPrefix) {
+		return nil
+	}
+	neighbor, err := strconv.ParseUint(strings.TrimPrefix(parameterName, cioParameterPrefix), 10, 64)
+	if err != nil {
+		return errors.NewInvalid("invalid neighbor in RAN parameter %s", parameterName)
+	}
+	if !isNeighbor(cell, ransimtypes.NCGI(neighbor)) {
+		return errors.NewInvalid("cell %d is not a neighbor of cell %d", neighbor, cell.NCGI)
+	}
+	var offset int64
+	switch parameterValue := parameterValue.(type) {
+	case int32:
+		offset = int64(parameterValue)
+	case int64:
+		offset = parameterValue
+	default:
+		return errors.NewInvalid("invalid cell individual offset %v", parameterValue)
+	}
+	if offset < -maxCellIndividualOffset || offset > maxCellIndividualOffset {
+		return errors.NewInvalid("cell individual offset %d dB is out of range", offset)
+	}
+	if cell.MeasurementParams.NCellIndividualOffsets == nil {
+		cell.MeasurementParams.NCellIndividualOffsets = make(map[ransimtypes.NCGI]int32)
+	}
+	cell.MeasurementParams.NCellIndividualOffsets[ransimtypes.NCGI(neighbor)] = int32(offset)
+	return nil
+}
+
+func isNeighbor(cell *model.Cell, ncgi ransimtypes.NCGI) bool {
+	for _, neighbor := range cell.Neighbors {
+		if neighbor == ncgi {
+			return true
+		}
+	}
+	_, ok := cell.MeasurementParams.NCellIndividualOffsets[ncgi]
+	return ok
+}
+
+// containsCell returns true if any of the given cells is one of the cells of the node
+func containsCell(nodeCells []ransimtypes.NCGI, cells []*model.Cell) bool {
+	for _, cell := range cells {
+		for _, ncgi := range nodeCells {
+			if cell.NCGI == ncgi {
+				return true
+			}
+		}
+	}
+	return false
+}
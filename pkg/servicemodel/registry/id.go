@@ -25,4 +25,15 @@ const (
 	Kpm2
 	// MHO
 	Mho
+	// Ccc
+	Ccc
 )
+
+// MaxRanFunctionID the largest RAN function ID allowed by E2AP
+const MaxRanFunctionID RanFunctionID = 4095
+
+// SupportsEUTRA returns true if the service model can be exposed by E-UTRA nodes, e.g. to replay S1AP messages; the
+// other service models describe NR cells only
+func (id RanFunctionID) SupportsEUTRA() bool {
+	return id == Kpm2 || id == Ni
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+)
+
+// Metric names of the indication delivery counters of each node
+const (
+	IndicationRetriesMetric   = "e2.indications.retries"
+	IndicationFailedMetric    = "e2.indications.failed"
+	IndicationThrottledMetric = "e2.indications.throttled"
+	IndicationSuspendedMetric = "e2.indications.suspended"
+)
+
+const (
+	// indicationTimeout time allowed to each attempt to send an indication
+	indicationTimeout = 5 * time.Second
+	// maxIndicationAttempts number of attempts to send an indication before it is dropped
+	maxIndicationAttempts = 3
+	// indicationBackoff base delay before retrying to send an indication; it doubles with each retry
+	indicationBackoff = 100 * time.Millisecond
+)
+
+// SendIndication sends an indication of the subscription, unless the indication rate cap of the node is hit in which
+// case the indication is dropped. Each attempt times out after a few seconds, so that a stalled E2 connection applies
+// backpressure instead of blocking the report routine forever, and failed attempts are retried after a jittered
+// backoff. Retries and dropped indications are counted on the node; the report routine is expected to carry on with
+// its next indication when an error is returned.
+func (sm *ServiceModel) SendIndication(ctx context.Context, sub *subscriptions.Subscription, indication *e2appducontents.Ricindication) error {
+	if !sm.AllowIndication(ctx, sub) {
+		return errors.NewUnavailable("indication rate of subscription %s exceeds the cap of the node", sub.ID)
+	}
+	return sm.DeliverIndication(ctx, sub, indication)
+}
+
+// AllowIndication returns true if an indication of the subscription can be sent under both the indication rate cap
+// of each subscription of the node and the indication rate cap of the whole node, and counts the indications held
+// back otherwise
+func (sm *ServiceModel) AllowIndication(ctx context.Context, sub *subscriptions.Subscription) bool {
+	limiter := sub.Limiter(sm.Node.Indications.MaxRate, sm.Node.Indications.Burst)
+	if limiter == nil || limiter.Allow() {
+		if sm.Subscriptions == nil {
+			return true
+		}
+		nodeLimiter := sm.Subscriptions.Limiter(sm.Node.Limits.MaxIndicationRate, sm.Node.Limits.IndicationBurst)
+		if nodeLimiter == nil || nodeLimiter.Allow() {
+			return true
+		}
+	}
+	sm.incrementMetric(ctx, IndicationThrottledMetric, 1)
+	return false
+}
+
+// DeliverIndication sends an indication of the subscription already allowed by AllowIndication, with the same retries
+// as SendIndication. The indications of a suspended subscription are dropped without error, and counted on the node.
+func (sm *ServiceModel) DeliverIndication(ctx context.Context, sub *subscriptions.Subscription, indication *e2appducontents.Ricindication) error {
+	if sub.IsSuspended() {
+		sm.incrementMetric(ctx, IndicationSuspendedMetric, 1)
+		return nil
+	}
+	attempts, err := retry(ctx, sub.E2Channel.Context().Done(), func(ctx context.Context) error {
+		return sub.E2Channel.RICIndication(ctx, indication)
+	})
+	if attempts > 1 {
+		sm.incrementMetric(ctx, IndicationRetriesMetric, attempts-1)
+	}
+	if err != nil {
+		sm.incrementMetric(ctx, IndicationFailedMetric, 1)
+		log.Warnf("Dropping indication of subscription %s after %d attempts: %v", sub.ID, attempts, err)
+	}
+	return err
+}
+
+func (sm *ServiceModel) incrementMetric(ctx context.Context, name string, count int) {
+	if sm.MetricStore == nil {
+		return
+	}
+	for i := 0; i < count; i++ {
+		if _, err := sm.MetricStore.Increment(ctx, uint64(sm.Node.GnbID), name); err != nil {
+			log.Warn(err)
+			return
+		}
+	}
+}
+
+// retry calls send until it succeeds, the attempts are exhausted or either the context or the channel is done; it
+// returns the number of attempts
+func retry(ctx context.Context, done <-chan struct{}, send func(ctx context.Context) error) (int, error) {
+	var err error
+	backoff := indicationBackoff
+	for attempt := 1; ; attempt++ {
+		sendCtx, cancel := context.WithTimeout(ctx, indicationTimeout)
+		err = send(sendCtx)
+		cancel()
+		if err == nil || attempt == maxIndicationAttempts {
+			return attempt, err
+		}
+
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff)))
+		backoff *= 2
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, err
+		case <-done:
+			timer.Stop()
+			return attempt, err
+		}
+	}
+}
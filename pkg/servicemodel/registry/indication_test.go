@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetry(t *testing.T) {
+	ctx := context.Background()
+	done := make(chan struct{})
+
+	calls := 0
+	attempts, err := retry(ctx, done, func(ctx context.Context) error {
+		calls++
+		if calls < 2 {
+			return errors.NewUnavailable("busy")
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+
+	calls = 0
+	attempts, err = retry(ctx, done, func(ctx context.Context) error {
+		calls++
+		return errors.NewUnavailable("down")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, maxIndicationAttempts, attempts)
+	assert.Equal(t, maxIndicationAttempts, calls)
+
+	close(done)
+	attempts, err = retry(ctx, done, func(ctx context.Context) error {
+		return errors.NewUnavailable("closed")
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -37,6 +37,7 @@ type ServiceModelRegistry struct {
 	mu            sync.RWMutex
 	serviceModels map[RanFunctionID]ServiceModel
 	ranFunctions  e2aptypes.RanFunctions
+	deactivated   map[RanFunctionID]bool
 }
 
 // ServiceModel service model
@@ -64,17 +65,30 @@ func NewServiceModelRegistry() *ServiceModelRegistry {
 	return &ServiceModelRegistry{
 		serviceModels: make(map[RanFunctionID]ServiceModel),
 		ranFunctions:  make(map[e2aptypes.RanFunctionID]e2aptypes.RanFunctionItem),
+		deactivated:   make(map[RanFunctionID]bool),
 	}
 }
 
-// RegisterServiceModel registers a service model
+// RegisterServiceModel registers a service model; the well-known RAN function ID of the service model is
+// advertised unless it is already taken by another service model, e.g. another version of the same service model,
+// in which case a free RAN function ID is assigned to it
 func (s *ServiceModelRegistry) RegisterServiceModel(sm ServiceModel) error {
-	log.Info("Register Service Model:", sm.ModelName, ":", sm.RanFunctionID)
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	for _, registered := range s.serviceModels {
+		if registered.OID == sm.OID {
+			return errors.New(errors.AlreadyExists, "the service model already registered")
+		}
+	}
+
 	if _, exists := s.serviceModels[sm.RanFunctionID]; exists {
-		return errors.New(errors.AlreadyExists, "the service model already registered")
+		ranFuncID, err := s.allocateRanFunctionID()
+		if err != nil {
+			return err
+		}
+		sm.RanFunctionID = ranFuncID
 	}
+	log.Info("Register Service Model:", sm.ModelName, ":", sm.RanFunctionID)
 
 	ranFuncID := e2aptypes.RanFunctionID(sm.RanFunctionID)
 	s.ranFunctions[ranFuncID] = e2aptypes.RanFunctionItem{
@@ -87,10 +101,42 @@ func (s *ServiceModelRegistry) RegisterServiceModel(sm ServiceModel) error {
 	return nil
 }
 
+// allocateRanFunctionID returns the lowest RAN function ID that is not in use
+func (s *ServiceModelRegistry) allocateRanFunctionID() (RanFunctionID, error) {
+	for id := Internal + 1; id <= MaxRanFunctionID; id++ {
+		if _, exists := s.serviceModels[id]; !exists {
+			return id, nil
+		}
+	}
+	return 0, errors.New(errors.Unavailable, "no RAN function ID is available")
+}
+
+// DeactivateServiceModel deactivates the service model of the given RAN function, e.g. because the RIC rejected
+// it in the E2 setup; a deactivated service model is still advertised but serves no requests
+func (s *ServiceModelRegistry) DeactivateServiceModel(id RanFunctionID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.serviceModels[id]; !ok {
+		return errors.New(errors.NotFound, "no service model is registered for ran function ID: ", id)
+	}
+	s.deactivated[id] = true
+	return nil
+}
+
+// ActivateServiceModels reactivates all deactivated service models
+func (s *ServiceModelRegistry) ActivateServiceModels() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deactivated = make(map[RanFunctionID]bool)
+}
+
 // GetServiceModel finds and initialize service model interface pointer
 func (s *ServiceModelRegistry) GetServiceModel(id RanFunctionID) (ServiceModel, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
+	if s.deactivated[id] {
+		return ServiceModel{}, errors.New(errors.Unavailable, "service model of ran function ID is deactivated: ", id)
+	}
 	sm, ok := s.serviceModels[id]
 	if ok {
 		return sm, nil
@@ -98,14 +144,20 @@ func (s *ServiceModelRegistry) GetServiceModel(id RanFunctionID) (ServiceModel,
 	return ServiceModel{}, errors.New(errors.Unknown, "no service model implementation exists for ran function ID: ", id)
 }
 
-// GetServiceModels get all of the registered service models
+// GetServiceModels get all of the registered service models keyed by their advertised RAN function IDs
 func (s *ServiceModelRegistry) GetServiceModels() map[RanFunctionID]ServiceModel {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.serviceModels
+	serviceModels := make(map[RanFunctionID]ServiceModel, len(s.serviceModels))
+	for id, sm := range s.serviceModels {
+		serviceModels[id] = sm
+	}
+	return serviceModels
 }
 
 // GetRanFunctions returns the list of registered ran functions
 func (s *ServiceModelRegistry) GetRanFunctions() e2aptypes.RanFunctions {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return s.ranFunctions
 }
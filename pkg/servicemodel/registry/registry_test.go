@@ -70,3 +70,73 @@ func TestRegisterServiceModel(t *testing.T) {
 	assert.Equal(t, len(ranFunctions), 1)
 
 }
+
+func TestRegisterMultipleVersions(t *testing.T) {
+	registry := NewServiceModelRegistry()
+	m := &mockServiceModel{
+		t: t,
+	}
+
+	kpmV1 := ServiceModel{
+		RanFunctionID: Kpm,
+		OID:           "1.3.6.1.4.1.53148.1.1.2.2",
+		Client:        m,
+	}
+	kpmV2 := ServiceModel{
+		RanFunctionID: Kpm,
+		OID:           "1.3.6.1.4.1.53148.1.2.2.2",
+		Client:        m,
+	}
+	assert.NoError(t, registry.RegisterServiceModel(kpmV1))
+	assert.NoError(t, registry.RegisterServiceModel(kpmV2))
+	assert.Error(t, registry.RegisterServiceModel(kpmV2))
+
+	serviceModels := registry.GetServiceModels()
+	assert.Len(t, serviceModels, 2)
+	assert.Equal(t, kpmV1.OID, serviceModels[Kpm].OID)
+
+	var kpmV2ID RanFunctionID
+	for id, sm := range serviceModels {
+		if sm.OID == kpmV2.OID {
+			kpmV2ID = id
+		}
+	}
+	assert.NotEqual(t, Kpm, kpmV2ID)
+	assert.Equal(t, kpmV2ID, serviceModels[kpmV2ID].RanFunctionID)
+
+	ranFunctions := registry.GetRanFunctions()
+	assert.Len(t, ranFunctions, 2)
+}
+
+func TestDeactivateServiceModel(t *testing.T) {
+	registry := NewServiceModelRegistry()
+	m := &mockServiceModel{
+		t: t,
+	}
+
+	kpm := ServiceModel{
+		RanFunctionID: Kpm,
+		OID:           "1.3.6.1.4.1.53148.1.2.2.2",
+		Client:        m,
+	}
+	rc := ServiceModel{
+		RanFunctionID: Rcpre2,
+		OID:           "1.3.6.1.4.1.53148.1.1.2.100",
+		Client:        m,
+	}
+	assert.NoError(t, registry.RegisterServiceModel(kpm))
+	assert.NoError(t, registry.RegisterServiceModel(rc))
+
+	assert.NoError(t, registry.DeactivateServiceModel(Rcpre2))
+	assert.Error(t, registry.DeactivateServiceModel(Mho))
+	_, err := registry.GetServiceModel(Rcpre2)
+	assert.Error(t, err)
+	_, err = registry.GetServiceModel(Kpm)
+	assert.NoError(t, err)
+	// Deactivated service models are still advertised
+	assert.Len(t, registry.GetRanFunctions(), 2)
+
+	registry.ActivateServiceModels()
+	_, err = registry.GetServiceModel(Rcpre2)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"context"
+
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	controlutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/control"
+)
+
+// RICControl applies the control request with the service model and acknowledges it along with the control outcome
+func (c *Client) RICControl(ctx context.Context, request *e2appducontents.RiccontrolRequest) (response *e2appducontents.RiccontrolAcknowledge, failure *e2appducontents.RiccontrolFailure, err error) {
+	log.Infof("%s: RIC Control request received for service model %s", c.logPrefix, c.ServiceModel.ModelName)
+	reqID, err := controlutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := controlutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := controlutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	options := []func(*controlutils.Control){
+		controlutils.WithRanFuncID(*ranFuncID),
+		controlutils.WithRequestID(*reqID),
+		controlutils.WithRicInstanceID(*ricInstanceID),
+		controlutils.WithRicCallProcessID(controlutils.GetRicCallProcessID(request)),
+	}
+
+	// Controls whose guard timer already expired are not handed to the model
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	outcome, err := c.model.HandleControl(ctx, controlutils.GetRicControlHeader(request), controlutils.GetRicControlMessage(request))
+	if errors.IsNotSupported(err) {
+		return nil, nil, err
+	}
+	if err != nil {
+		log.Warnf("%s: %v", c.logPrefix, err)
+		failure, err := controlutils.NewControl(append(options, controlutils.WithCause(controlutils.NewCause(err)))...).BuildControlFailure()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, failure, nil
+	}
+	if outcome != nil {
+		options = append(options, controlutils.WithRicControlOutcome(outcome))
+	}
+	response, err = controlutils.NewControl(options...).BuildControlAcknowledge()
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
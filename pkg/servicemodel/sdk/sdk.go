@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sdk implements the E2AP procedures shared by the service models, i.e. the admission of subscriptions, the
+// decoding of their event triggers, the report routines sending their indications, the deletion of subscriptions and
+// the handling of control requests. A new service model only encodes its own indications and decodes its own event
+// triggers and control requests by implementing Model.
+package sdk
+
+import (
+	"context"
+	"time"
+
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+)
+
+var log = logging.GetLogger("sm", "sdk")
+
+// Report report of a subscription for which an indication is sent to each of its REPORT actions
+type Report struct {
+	Subscription *subscriptions.Subscription
+	Trigger      *trigger.Trigger
+	Actions      []e2aptypes.RicActionID
+	Time         time.Time
+	// SN sequence number of the report within the subscription, starting from 1
+	SN int64
+}
+
+// Model is implemented by the service models built with the SDK
+type Model interface {
+	// DecodeEventTrigger decodes the ASN.1 encoded event trigger definition of a subscription
+	DecodeEventTrigger(definition []byte) (*trigger.Trigger, error)
+	// BuildHeader builds the ASN.1 encoded indication header of the report
+	BuildHeader(ctx context.Context, report Report) ([]byte, error)
+	// BuildMessage builds the ASN.1 encoded indication message of the report; no indication is sent if the message
+	// is nil
+	BuildMessage(ctx context.Context, report Report) ([]byte, error)
+	// HandleControl applies the ASN.1 encoded control header and message of a RIC control request and returns the
+	// ASN.1 encoded control outcome, if any. A NotSupported error leaves the request unanswered, an Invalid error is
+	// answered with the control-message-invalid cause and any other error with the unspecified cause.
+	HandleControl(ctx context.Context, header []byte, message []byte) ([]byte, error)
+}
+
+// ChangeSource returns a channel receiving a value whenever the subscription has something to report, i.e. the
+// reported information changed or a threshold was crossed; the channel may be closed once the context is done
+type ChangeSource func(ctx context.Context, sub *subscriptions.Subscription) <-chan struct{}
+
+// Option configures the client of a service model
+type Option func(*Client)
+
+// WithTriggers sets the event trigger types supported by the service model; only periodic triggers are supported
+// by default
+func WithTriggers(types ...trigger.Type) Option {
+	return func(c *Client) {
+		c.triggers = types
+	}
+}
+
+// WithChanges sets the source of the changes reported by the subscriptions with an on change or threshold event
+// trigger
+func WithChanges(changes ChangeSource) Option {
+	return func(c *Client) {
+		c.changes = changes
+	}
+}
+
+var _ servicemodel.Client = &Client{}
+
+// Client implements the E2AP procedures of a service model on top of its Model
+type Client struct {
+	ServiceModel *registry.ServiceModel
+	model        Model
+	triggers     []trigger.Type
+	changes      ChangeSource
+	logPrefix    string
+}
+
+// NewClient creates the client of the given service model; the client must be set as the Client of the service
+// model before it is registered
+func NewClient(sm *registry.ServiceModel, model Model, options ...Option) *Client {
+	client := &Client{
+		ServiceModel: sm,
+		model:        model,
+		triggers:     []trigger.Type{trigger.Periodic},
+		logPrefix:    logfields.Node(sm.Node.GnbID),
+	}
+	for _, option := range options {
+		option(client)
+	}
+	return client
+}
+
+// supports returns an error if the client cannot report on the given event trigger
+func (c *Client) supports(eventTrigger *trigger.Trigger) error {
+	if eventTrigger.Type != trigger.Periodic && c.changes == nil {
+		return trigger.NewUnsupported("%s event trigger has no source of changes", eventTrigger.Type)
+	}
+	return nil
+}
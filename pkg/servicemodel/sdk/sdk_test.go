@@ -0,0 +1,66 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/servicemodel/registry"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/stretchr/testify/assert"
+)
+
+type testModel struct{}
+
+func (m *testModel) DecodeEventTrigger(definition []byte) (*trigger.Trigger, error) {
+	return &trigger.Trigger{Type: trigger.Type(definition[0]), Period: 1000}, nil
+}
+
+func (m *testModel) BuildHeader(ctx context.Context, report Report) ([]byte, error) {
+	return []byte{0x01}, nil
+}
+
+func (m *testModel) BuildMessage(ctx context.Context, report Report) ([]byte, error) {
+	return []byte{byte(report.SN)}, nil
+}
+
+func (m *testModel) HandleControl(ctx context.Context, header []byte, message []byte) ([]byte, error) {
+	return nil, errors.NewNotSupported("control is not supported")
+}
+
+func TestAdmit(t *testing.T) {
+	reportActions, notAdmitted := admit([]action{
+		{id: 1, actionType: e2apies.RicactionType_RICACTION_TYPE_REPORT},
+		{id: 2, actionType: e2apies.RicactionType_RICACTION_TYPE_INSERT},
+		{id: 3, actionType: e2apies.RicactionType_RICACTION_TYPE_REPORT},
+		{id: 4, actionType: e2apies.RicactionType_RICACTION_TYPE_POLICY},
+	})
+	assert.Equal(t, []e2aptypes.RicActionID{1, 3}, reportActions)
+	assert.Len(t, notAdmitted, 2)
+	assert.Equal(t, e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED, notAdmitted[2].GetRicRequest())
+	assert.Contains(t, notAdmitted, e2aptypes.RicActionID(4))
+}
+
+func TestSupports(t *testing.T) {
+	sm := &registry.ServiceModel{}
+	client := NewClient(sm, &testModel{})
+	assert.Equal(t, []trigger.Type{trigger.Periodic}, client.triggers)
+	assert.NoError(t, client.supports(&trigger.Trigger{Type: trigger.Periodic}))
+	err := client.supports(&trigger.Trigger{Type: trigger.OnChange})
+	assert.Equal(t, e2apies.CauseProtocol_CAUSE_PROTOCOL_SEMANTIC_ERROR, trigger.GetCause(err).GetProtocol())
+
+	client = NewClient(sm, &testModel{},
+		WithTriggers(trigger.Periodic, trigger.OnChange),
+		WithChanges(func(ctx context.Context, sub *subscriptions.Subscription) <-chan struct{} {
+			return nil
+		}))
+	assert.Len(t, client.triggers, 2)
+	assert.NoError(t, client.supports(&trigger.Trigger{Type: trigger.OnChange}))
+}
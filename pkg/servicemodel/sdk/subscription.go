@@ -0,0 +1,240 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package sdk
+
+import (
+	"context"
+	"time"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/clock"
+	"github.com/onosproject/ran-simulator/pkg/monitor"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+	indicationutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/indication"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+	subdeleteutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscriptiondelete"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/trigger"
+	"github.com/onosproject/ran-simulator/pkg/utils/logfields"
+)
+
+// action action of a subscription request
+type action struct {
+	id         e2aptypes.RicActionID
+	actionType e2apies.RicactionType
+}
+
+func actionsOf(request *e2appducontents.RicsubscriptionRequest) []action {
+	var actions []action
+	for _, item := range subutils.GetRicActionToBeSetupList(request) {
+		actions = append(actions, action{
+			id:         e2aptypes.RicActionID(item.GetValue().GetRatbsi().GetRicActionId().GetValue()),
+			actionType: item.GetValue().GetRatbsi().GetRicActionType(),
+		})
+	}
+	return actions
+}
+
+// admit admits the REPORT actions; the INSERT and POLICY actions are not admitted
+func admit(actions []action) ([]e2aptypes.RicActionID, map[e2aptypes.RicActionID]*e2apies.Cause) {
+	var reportActions []e2aptypes.RicActionID
+	notAdmitted := make(map[e2aptypes.RicActionID]*e2apies.Cause)
+	for _, action := range actions {
+		if action.actionType == e2apies.RicactionType_RICACTION_TYPE_REPORT {
+			reportActions = append(reportActions, action.id)
+			continue
+		}
+		notAdmitted[action.id] = &e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+			},
+		}
+	}
+	return reportActions, notAdmitted
+}
+
+// RICSubscription admits the REPORT actions of the subscription and starts reporting on its event trigger
+func (c *Client) RICSubscription(ctx context.Context, request *e2appducontents.RicsubscriptionRequest) (response *e2appducontents.RicsubscriptionResponse, failure *e2appducontents.RicsubscriptionFailure, err error) {
+	log.Infof("%s: RIC Subscription request received for service model %s", c.logPrefix, c.ServiceModel.ModelName)
+	reqID, err := subutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	subscriptionFailure := func(cause *e2apies.Cause) (*e2appducontents.RicsubscriptionResponse, *e2appducontents.RicsubscriptionFailure, error) {
+		failure, err := subutils.NewSubscription(
+			subutils.WithRequestID(*reqID),
+			subutils.WithRanFuncID(*ranFuncID),
+			subutils.WithRicInstanceID(*ricInstanceID),
+			subutils.WithCause(cause)).BuildSubscriptionFailure()
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, failure, nil
+	}
+
+	// At least one action must be admitted
+	reportActions, notAdmitted := admit(actionsOf(request))
+	if len(reportActions) == 0 {
+		log.Warnf("%s: no action is accepted", c.logPrefix)
+		return subscriptionFailure(&e2apies.Cause{
+			Cause: &e2apies.Cause_RicRequest{
+				RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_ACTION_NOT_SUPPORTED,
+			},
+		})
+	}
+
+	eventTrigger, err := trigger.DecodeRequest(request, c.model.DecodeEventTrigger, c.triggers...)
+	if err == nil {
+		err = c.supports(eventTrigger)
+	}
+	if err != nil {
+		log.Warnf("%s: %v", c.logPrefix, err)
+		return subscriptionFailure(trigger.GetCause(err))
+	}
+
+	var accepted []*e2aptypes.RicActionID
+	for i := range reportActions {
+		accepted = append(accepted, &reportActions[i])
+	}
+	subscription := subutils.NewSubscription(
+		subutils.WithRequestID(*reqID),
+		subutils.WithRanFuncID(*ranFuncID),
+		subutils.WithRicInstanceID(*ricInstanceID),
+		subutils.WithActionsAccepted(accepted),
+		subutils.WithActionsNotAdmitted(notAdmitted))
+	response, err = subscription.BuildSubscriptionResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	sub, err := c.ServiceModel.Subscriptions.Get(subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID))
+	if err != nil {
+		return nil, nil, err
+	}
+	sub.AdmitReportActions(reportActions...)
+	sub.Start(func(ctx context.Context) {
+		c.report(ctx, sub, eventTrigger)
+	})
+	return response, nil, nil
+}
+
+// report sends the indications of the subscription upon its event trigger until the context or the E2 channel of
+// the subscription is done
+func (c *Client) report(ctx context.Context, sub *subscriptions.Subscription, eventTrigger *trigger.Trigger) {
+	logPrefix := logfields.Subscription(c.logPrefix, sub.ID)
+	log.Debugf("%s: Start reporting on %s event trigger", logPrefix, eventTrigger.Type)
+	var events <-chan struct{}
+	var ticks <-chan time.Time
+	if eventTrigger.Type == trigger.Periodic {
+		ticker := monitor.NewTicker(time.Duration(eventTrigger.Period) * time.Millisecond)
+		defer ticker.Stop()
+		ticks = ticker.C
+	} else {
+		events = c.changes(ctx, sub)
+	}
+
+	var sn int64
+	for {
+		select {
+		case <-ticks:
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-ctx.Done():
+			return
+		case <-sub.E2Channel.Context().Done():
+			return
+		}
+		sn++
+		c.sendIndications(ctx, logPrefix, Report{
+			Subscription: sub,
+			Trigger:      eventTrigger,
+			Actions:      sub.ReportActions(),
+			Time:         clock.Now(),
+			SN:           sn,
+		})
+	}
+}
+
+// sendIndications sends an indication of the report to each REPORT action of the subscription; reports which cannot
+// be encoded are logged and skipped
+func (c *Client) sendIndications(ctx context.Context, logPrefix string, report Report) {
+	header, err := c.model.BuildHeader(ctx, report)
+	if err != nil {
+		log.Warnf("%s: %v", logPrefix, err)
+		return
+	}
+	message, err := c.model.BuildMessage(ctx, report)
+	if err != nil {
+		log.Warnf("%s: %v", logPrefix, err)
+		return
+	}
+	if message == nil {
+		return
+	}
+	for _, actionID := range report.Actions {
+		indication, err := indicationutils.NewIndication(
+			indicationutils.WithRicInstanceID(report.Subscription.ReqID.GetRicInstanceId()),
+			indicationutils.WithRanFuncID(report.Subscription.FnID.GetValue()),
+			indicationutils.WithRequestID(report.Subscription.ReqID.GetRicRequestorId()),
+			indicationutils.WithRicActionID(int32(actionID)),
+			indicationutils.WithIndicationHeader(header),
+			indicationutils.WithIndicationMessage(message)).Build()
+		if err != nil {
+			log.Warnf("%s: %v", logPrefix, err)
+			return
+		}
+		// Indications which cannot be sent are dropped and counted, the next ones are still sent
+		_ = c.ServiceModel.SendIndication(ctx, report.Subscription, indication)
+	}
+}
+
+// RICSubscriptionDelete stops reporting on the subscription before confirming its deletion
+func (c *Client) RICSubscriptionDelete(ctx context.Context, request *e2appducontents.RicsubscriptionDeleteRequest) (response *e2appducontents.RicsubscriptionDeleteResponse, failure *e2appducontents.RicsubscriptionDeleteFailure, err error) {
+	log.Infof("%s: RIC subscription delete request received for service model %s", c.logPrefix, c.ServiceModel.ModelName)
+	reqID, err := subdeleteutils.GetRequesterID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranFuncID, err := subdeleteutils.GetRanFunctionID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	ricInstanceID, err := subdeleteutils.GetRicInstanceID(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	sub, err := c.ServiceModel.Subscriptions.Get(subscriptions.NewID(*ricInstanceID, *reqID, *ranFuncID))
+	if err != nil {
+		return nil, nil, err
+	}
+	response, err = subdeleteutils.NewSubscriptionDelete(
+		subdeleteutils.WithRequestID(*reqID),
+		subdeleteutils.WithRanFuncID(*ranFuncID),
+		subdeleteutils.WithRicInstanceID(*ricInstanceID)).BuildSubscriptionDeleteResponse()
+	if err != nil {
+		return nil, nil, err
+	}
+	// Stops the goroutine sending the indication messages before confirming the delete
+	if err := sub.Stop(ctx); err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// E2ConnectionUpdate is not supported by the service models
+func (c *Client) E2ConnectionUpdate(ctx context.Context, request *e2appducontents.E2ConnectionUpdate) (response *e2appducontents.E2ConnectionUpdateAcknowledge, failure *e2appducontents.E2ConnectionUpdateFailure, err error) {
+	return nil, nil, errors.NewNotSupported("E2 connection update is not supported")
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+)
+
+// Names of the per-cell handover counters kept in the metrics store; the handovers are counted by their source cell
+const (
+	// HoPrepAtt number of handover preparation attempts
+	HoPrepAtt = "MM.HoPrepAtt.Sum"
+	// HoExeAtt number of handover execution attempts
+	HoExeAtt = "MM.HoExeAtt.Sum"
+	// HoExeSucc number of successful handover executions
+	HoExeSucc = "MM.HoExeSucc.Sum"
+	// HoExeFail number of failed handover executions
+	HoExeFail = "MM.HoExeFail.Sum"
+	// HoPingPong number of handovers back to the source cell within the ping-pong window of a previous handover
+	HoPingPong = "MM.HoPingPong.Sum"
+	// HoPrepTime total handover preparation time in milliseconds
+	HoPrepTime = "MM.HoPrepTime.Sum"
+	// HoExeTime total execution time of the successful handovers in milliseconds
+	HoExeTime = "MM.HoExeTime.Sum"
+	// HoInterruptionTime total interruption time of the successful handovers in milliseconds
+	HoInterruptionTime = "MM.HoInterruptionTime.Sum"
+)
+
+// MroFailure type of a handover problem, classified per the mobility robustness optimization definitions of
+// 3GPP TS 38.300 from the radio link failures of the UEs and the cells they re-establish their connections on
+type MroFailure string
+
+const (
+	// HoTooEarly the radio link failed during or shortly after the handover, and the UE re-established its
+	// connection on the source cell
+	HoTooEarly MroFailure = "MM.HoTooEarly.Sum"
+	// HoTooLate the radio link failed on the serving cell long after the last handover of the UE, and the UE
+	// re-established its connection on another cell
+	HoTooLate MroFailure = "MM.HoTooLate.Sum"
+	// HoWrongCell the radio link failed shortly after the handover, and the UE re-established its connection on a
+	// cell other than the source and the target cells
+	HoWrongCell MroFailure = "MM.HoWrongCell.Sum"
+)
+
+// PairCounter returns the name of the counter of the handover problems of the given type from a cell to the given
+// target cell, e.g. MM.HoTooEarly.21458294227474
+func PairCounter(failure MroFailure, target types.NCGI) string {
+	return fmt.Sprintf("%s.%d", strings.TrimSuffix(string(failure), ".Sum"), target)
+}
+
+// HoStats records the handovers of cells and their latencies
+type HoStats interface {
+	// Prepared records a handover preparation attempt and the time it took
+	Prepared(ctx context.Context, ncgi types.NCGI, latency time.Duration)
+
+	// Executed records a handover execution attempt and, if it succeeded, the time it took and for how long the UE
+	// was detached from both cells
+	Executed(ctx context.Context, ncgi types.NCGI, success bool, latency time.Duration, interruption time.Duration)
+
+	// PingPong records a handover of a UE back to the cell it had just left
+	PingPong(ctx context.Context, ncgi types.NCGI)
+
+	// MroFailure records a handover problem from the source cell to the target cell, both per source cell and per
+	// pair of cells; the target cell of a too late handover is the cell the UE re-established its connection on
+	MroFailure(ctx context.Context, source types.NCGI, target types.NCGI, failure MroFailure)
+}
+
+type hoStats struct {
+	metricsStore metrics.Store
+}
+
+// NewHoStats creates handover statistics kept in the given metrics store
+func NewHoStats(metricsStore metrics.Store) HoStats {
+	return &hoStats{
+		metricsStore: metricsStore,
+	}
+}
+
+func (s *hoStats) Prepared(ctx context.Context, ncgi types.NCGI, latency time.Duration) {
+	s.increment(ctx, ncgi, HoPrepAtt)
+	s.add(ctx, ncgi, HoPrepTime, latency)
+}
+
+func (s *hoStats) Executed(ctx context.Context, ncgi types.NCGI, success bool, latency time.Duration, interruption time.Duration) {
+	s.increment(ctx, ncgi, HoExeAtt)
+	if !success {
+		s.increment(ctx, ncgi, HoExeFail)
+		return
+	}
+	s.increment(ctx, ncgi, HoExeSucc)
+	s.add(ctx, ncgi, HoExeTime, latency)
+	s.add(ctx, ncgi, HoInterruptionTime, interruption)
+}
+
+func (s *hoStats) PingPong(ctx context.Context, ncgi types.NCGI) {
+	s.increment(ctx, ncgi, HoPingPong)
+}
+
+func (s *hoStats) MroFailure(ctx context.Context, source types.NCGI, target types.NCGI, failure MroFailure) {
+	s.increment(ctx, source, string(failure))
+	s.increment(ctx, source, PairCounter(failure, target))
+}
+
+func (s *hoStats) increment(ctx context.Context, ncgi types.NCGI, name string) {
+	if _, err := s.metricsStore.Increment(ctx, uint64(ncgi), name); err != nil {
+		log.Warn(err)
+	}
+}
+
+func (s *hoStats) add(ctx context.Context, ncgi types.NCGI, name string, d time.Duration) {
+	if _, err := s.metricsStore.Add(ctx, uint64(ncgi), name, uint64(d.Milliseconds())); err != nil {
+		log.Warn(err)
+	}
+}
+
+// GetMeanTime returns the mean of the given handover time of the cell in milliseconds over the given handover count
+func GetMeanTime(ctx context.Context, metricsStore metrics.Store, ncgi types.NCGI, name string, count string) float64 {
+	n := GetCounter(ctx, metricsStore, ncgi, count)
+	if n == 0 {
+		return 0
+	}
+	return float64(GetCounter(ctx, metricsStore, ncgi, name)) / float64(n)
+}
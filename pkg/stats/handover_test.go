@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHoStats(t *testing.T) {
+	ctx := context.Background()
+	metricsStore := metrics.NewMetricsStore()
+	hoStats := NewHoStats(metricsStore)
+	ncgi := types.NCGI(0x1234)
+
+	hoStats.Prepared(ctx, ncgi, 20*time.Millisecond)
+	hoStats.Prepared(ctx, ncgi, 40*time.Millisecond)
+	hoStats.Executed(ctx, ncgi, true, 50*time.Millisecond, 30*time.Millisecond)
+	hoStats.Executed(ctx, ncgi, true, 70*time.Millisecond, 30*time.Millisecond)
+	hoStats.Executed(ctx, ncgi, false, 0, 0)
+	hoStats.PingPong(ctx, ncgi)
+
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, ncgi, HoPrepAtt))
+	assert.Equal(t, uint64(3), GetCounter(ctx, metricsStore, ncgi, HoExeAtt))
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, ncgi, HoExeSucc))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, HoExeFail))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, HoPingPong))
+	assert.Equal(t, 30.0, GetMeanTime(ctx, metricsStore, ncgi, HoPrepTime, HoPrepAtt))
+	assert.Equal(t, 60.0, GetMeanTime(ctx, metricsStore, ncgi, HoExeTime, HoExeSucc))
+	assert.Equal(t, 30.0, GetMeanTime(ctx, metricsStore, ncgi, HoInterruptionTime, HoExeSucc))
+	assert.Equal(t, 0.0, GetMeanTime(ctx, metricsStore, types.NCGI(0x4321), HoExeTime, HoExeSucc))
+}
+
+func TestMroFailure(t *testing.T) {
+	ctx := context.Background()
+	metricsStore := metrics.NewMetricsStore()
+	hoStats := NewHoStats(metricsStore)
+	source, target, other := types.NCGI(0x1234), types.NCGI(0x1235), types.NCGI(0x1236)
+
+	hoStats.MroFailure(ctx, source, target, HoTooEarly)
+	hoStats.MroFailure(ctx, source, target, HoTooEarly)
+	hoStats.MroFailure(ctx, source, other, HoTooLate)
+	hoStats.MroFailure(ctx, source, other, HoWrongCell)
+
+	assert.Equal(t, "MM.HoTooEarly.4661", PairCounter(HoTooEarly, target))
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, source, string(HoTooEarly)))
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, source, PairCounter(HoTooEarly, target)))
+	assert.Equal(t, uint64(0), GetCounter(ctx, metricsStore, source, PairCounter(HoTooEarly, other)))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, source, string(HoTooLate)))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, source, PairCounter(HoTooLate, other)))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, source, string(HoWrongCell)))
+	assert.Equal(t, uint64(0), GetCounter(ctx, metricsStore, target, string(HoTooEarly)))
+}
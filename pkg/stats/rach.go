@@ -0,0 +1,57 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+)
+
+// Names of the per-cell random access counters kept in the metrics store
+const (
+	// RachAtt number of random access attempts
+	RachAtt = "RACH.Att.Sum"
+	// RachSucc number of successful random accesses
+	RachSucc = "RACH.Succ.Sum"
+	// RachFail number of random accesses which failed after the maximum number of preamble transmissions
+	RachFail = "RACH.Fail.Sum"
+	// RachPreambles number of preamble transmissions
+	RachPreambles = "RACH.Preambles.Sum"
+)
+
+// RachStats records the random accesses of cells
+type RachStats interface {
+	// RandomAccess records a random access attempt, the number of preambles it transmitted and its outcome
+	RandomAccess(ctx context.Context, ncgi types.NCGI, preambles int, success bool)
+}
+
+type rachStats struct {
+	metricsStore metrics.Store
+}
+
+// NewRachStats creates random access statistics kept in the given metrics store
+func NewRachStats(metricsStore metrics.Store) RachStats {
+	return &rachStats{
+		metricsStore: metricsStore,
+	}
+}
+
+func (s *rachStats) RandomAccess(ctx context.Context, ncgi types.NCGI, preambles int, success bool) {
+	s.add(ctx, ncgi, RachAtt, 1)
+	s.add(ctx, ncgi, RachPreambles, uint64(preambles))
+	if success {
+		s.add(ctx, ncgi, RachSucc, 1)
+	} else {
+		s.add(ctx, ncgi, RachFail, 1)
+	}
+}
+
+func (s *rachStats) add(ctx context.Context, ncgi types.NCGI, name string, delta uint64) {
+	if _, err := s.metricsStore.Add(ctx, uint64(ncgi), name, delta); err != nil {
+		log.Warn(err)
+	}
+}
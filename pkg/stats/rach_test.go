@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRachStats(t *testing.T) {
+	ctx := context.Background()
+	metricsStore := metrics.NewMetricsStore()
+	rachStats := NewRachStats(metricsStore)
+	ncgi := types.NCGI(0x1234)
+
+	rachStats.RandomAccess(ctx, ncgi, 1, true)
+	rachStats.RandomAccess(ctx, ncgi, 3, true)
+	rachStats.RandomAccess(ctx, ncgi, 4, false)
+
+	assert.Equal(t, uint64(3), GetCounter(ctx, metricsStore, ncgi, RachAtt))
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, ncgi, RachSucc))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, RachFail))
+	assert.Equal(t, uint64(8), GetCounter(ctx, metricsStore, ncgi, RachPreambles))
+}
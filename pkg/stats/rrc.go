@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+)
+
+var log = liblog.GetLogger("stats", "rrc")
+
+// Names of the per-cell RRC connection counters kept in the metrics store
+const (
+	// RrcConnEstabAtt number of RRC connection establishment attempts
+	RrcConnEstabAtt = "RRC.ConnEstabAtt.Sum"
+	// RrcConnEstabSucc number of successful RRC connection establishments
+	RrcConnEstabSucc = "RRC.ConnEstabSucc.Sum"
+	// RrcConnEstabFail number of failed RRC connection establishments
+	RrcConnEstabFail = "RRC.ConnEstabFail.Sum"
+	// RrcConnReEstabAtt number of RRC connection re-establishment attempts
+	RrcConnReEstabAtt = "RRC.ConnReEstabAtt.Sum"
+	// RrcConnDrop number of dropped RRC connections
+	RrcConnDrop = "RRC.ConnDrop.Sum"
+	// PagingAtt number of pagings of idle or inactive UEs broadcast by the cell
+	PagingAtt = "PAG.Att.Sum"
+	// PagingSucc number of pagings broadcast by the cell to which the UEs responded
+	PagingSucc = "PAG.Succ.Sum"
+	// PagingFail number of pagings broadcast by the cell to which the UEs did not respond
+	PagingFail = "PAG.Fail.Sum"
+	// RegMobilityUpdate number of registration updates of the UEs entering a new tracking area through the cell
+	RegMobilityUpdate = "RM.RegMobUpd.Sum"
+)
+
+// ReEstabCause cause of an RRC connection re-establishment
+type ReEstabCause string
+
+const (
+	// ReEstabReconfigFail re-establishment due to a reconfiguration failure
+	ReEstabReconfigFail ReEstabCause = "RRC.ConnReEstabAtt.reconfigFail"
+	// ReEstabHOFail re-establishment due to a handover failure
+	ReEstabHOFail ReEstabCause = "RRC.ConnReEstabAtt.HOFail"
+	// ReEstabOther re-establishment due to other reasons
+	ReEstabOther ReEstabCause = "RRC.ConnReEstabAtt.Other"
+)
+
+// RrcStats records the RRC connection events of cells
+type RrcStats interface {
+	// ConnectionAttempt records an RRC connection establishment attempt and its outcome
+	ConnectionAttempt(ctx context.Context, ncgi types.NCGI, success bool)
+
+	// ReEstablishmentAttempt records an RRC connection re-establishment attempt
+	ReEstablishmentAttempt(ctx context.Context, ncgi types.NCGI, cause ReEstabCause)
+
+	// ConnectionDrop records an abnormally released RRC connection
+	ConnectionDrop(ctx context.Context, ncgi types.NCGI)
+
+	// Paging records the paging of a UE broadcast by the cell and whether the UE responded
+	Paging(ctx context.Context, ncgi types.NCGI, success bool)
+
+	// RegistrationUpdate records the registration update of a UE which entered a new tracking area through the cell
+	RegistrationUpdate(ctx context.Context, ncgi types.NCGI)
+}
+
+type rrcStats struct {
+	metricsStore metrics.Store
+}
+
+// NewRrcStats creates RRC connection statistics kept in the given metrics store
+func NewRrcStats(metricsStore metrics.Store) RrcStats {
+	return &rrcStats{
+		metricsStore: metricsStore,
+	}
+}
+
+func (s *rrcStats) ConnectionAttempt(ctx context.Context, ncgi types.NCGI, success bool) {
+	s.increment(ctx, ncgi, RrcConnEstabAtt)
+	if success {
+		s.increment(ctx, ncgi, RrcConnEstabSucc)
+	} else {
+		s.increment(ctx, ncgi, RrcConnEstabFail)
+	}
+}
+
+func (s *rrcStats) ReEstablishmentAttempt(ctx context.Context, ncgi types.NCGI, cause ReEstabCause) {
+	s.increment(ctx, ncgi, RrcConnReEstabAtt)
+	s.increment(ctx, ncgi, string(cause))
+}
+
+func (s *rrcStats) ConnectionDrop(ctx context.Context, ncgi types.NCGI) {
+	s.increment(ctx, ncgi, RrcConnDrop)
+}
+
+func (s *rrcStats) Paging(ctx context.Context, ncgi types.NCGI, success bool) {
+	s.increment(ctx, ncgi, PagingAtt)
+	if success {
+		s.increment(ctx, ncgi, PagingSucc)
+	} else {
+		s.increment(ctx, ncgi, PagingFail)
+	}
+}
+
+func (s *rrcStats) RegistrationUpdate(ctx context.Context, ncgi types.NCGI) {
+	s.increment(ctx, ncgi, RegMobilityUpdate)
+}
+
+func (s *rrcStats) increment(ctx context.Context, ncgi types.NCGI, name string) {
+	if _, err := s.metricsStore.Increment(ctx, uint64(ncgi), name); err != nil {
+		log.Warn(err)
+	}
+}
+
+// GetCounter returns the value of the given RRC counter of the cell
+func GetCounter(ctx context.Context, metricsStore metrics.Store, ncgi types.NCGI, name string) uint64 {
+	if v, ok := metricsStore.Get(ctx, uint64(ncgi), name); ok {
+		if counter, ok := v.(uint64); ok {
+			return counter
+		}
+	}
+	return 0
+}
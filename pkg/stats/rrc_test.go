@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package stats
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRrcStats(t *testing.T) {
+	ctx := context.Background()
+	metricsStore := metrics.NewMetricsStore()
+	rrcStats := NewRrcStats(metricsStore)
+	ncgi := types.NCGI(0x1234)
+
+	rrcStats.ConnectionAttempt(ctx, ncgi, true)
+	rrcStats.ConnectionAttempt(ctx, ncgi, true)
+	rrcStats.ConnectionAttempt(ctx, ncgi, false)
+	rrcStats.ReEstablishmentAttempt(ctx, ncgi, ReEstabHOFail)
+	rrcStats.ConnectionDrop(ctx, ncgi)
+
+	assert.Equal(t, uint64(3), GetCounter(ctx, metricsStore, ncgi, RrcConnEstabAtt))
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, ncgi, RrcConnEstabSucc))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, RrcConnEstabFail))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, RrcConnReEstabAtt))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, string(ReEstabHOFail)))
+	assert.Equal(t, uint64(0), GetCounter(ctx, metricsStore, ncgi, string(ReEstabOther)))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, RrcConnDrop))
+	assert.Equal(t, uint64(0), GetCounter(ctx, metricsStore, types.NCGI(0x4321), RrcConnDrop))
+
+	rrcStats.Paging(ctx, ncgi, true)
+	rrcStats.Paging(ctx, ncgi, false)
+	rrcStats.Paging(ctx, ncgi, false)
+	assert.Equal(t, uint64(3), GetCounter(ctx, metricsStore, ncgi, PagingAtt))
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, PagingSucc))
+	assert.Equal(t, uint64(2), GetCounter(ctx, metricsStore, ncgi, PagingFail))
+
+	rrcStats.RegistrationUpdate(ctx, ncgi)
+	assert.Equal(t, uint64(1), GetCounter(ctx, metricsStore, ncgi, RegMobilityUpdate))
+}
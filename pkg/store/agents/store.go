@@ -69,7 +69,13 @@ func (e *E2Agents) Remove(id types.GnbID) error {
 
 // List list e2 agents
 func (e *E2Agents) List() (map[types.GnbID]e2agent.E2Agent, error) {
-	return e.agents, nil
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	agents := make(map[types.GnbID]e2agent.E2Agent, len(e.agents))
+	for id, agent := range e.agents {
+		agents[id] = agent
+	}
+	return agents, nil
 }
 
 // Store e2 agents store interface
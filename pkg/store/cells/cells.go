@@ -6,8 +6,10 @@ package cells
 
 import (
 	"context"
+	"math"
 	"math/rand"
 	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/google/uuid"
@@ -21,6 +23,7 @@ import (
 	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/utils"
 )
 
 var log = liblog.GetLogger("store", "cells")
@@ -33,9 +36,21 @@ type Store interface {
 	// Get retrieves the cell with the specified NCGI
 	Get(ctx context.Context, ncgi types.NCGI) (*model.Cell, error)
 
+	// View calls the given function with the cell with the specified ID while holding the store lock, so that the
+	// function observes the cell consistently with the concurrent updates; the function must neither retain the
+	// cell nor call the store
+	View(ctx context.Context, ncgi types.NCGI, f func(cell *model.Cell) error) error
+
 	// Update updates the cell
 	Update(ctx context.Context, Cell *model.Cell) error
 
+	// UpdateCells updates the given cells at once, with a single TopologyChanged event keyed by the given ID; either
+	// all cells are updated or none is
+	UpdateCells(ctx context.Context, id string, cells []*model.Cell) error
+
+	// SetFailed puts the cell with the specified NCGI out of service or back in service
+	SetFailed(ctx context.Context, ncgi types.NCGI, failed bool) error
+
 	// Delete deletes the cell with the specified NCGI
 	Delete(ctx context.Context, ncgi types.NCGI) (*model.Cell, error)
 
@@ -57,9 +72,27 @@ type Store interface {
 	// DecrementRrcConnectedCount increments
 	DecrementRrcConnectedCount(ctx context.Context, ncgi types.NCGI)
 
+	// IncrementRrcInactiveCount increments the number of RRC inactive UEs of the cell
+	IncrementRrcInactiveCount(ctx context.Context, ncgi types.NCGI)
+
+	// DecrementRrcInactiveCount decrements the number of RRC inactive UEs of the cell
+	DecrementRrcInactiveCount(ctx context.Context, ncgi types.NCGI)
+
+	// FindCellsNear returns the cells whose sector center lies within the given radius in meters of the coordinate,
+	// nearest first
+	FindCellsNear(ctx context.Context, coord model.Coordinate, radius float64) []*model.Cell
+
+	// StrongestCells returns the k cells in service within the given radius in meters of the coordinate with the
+	// strongest signal there, strongest first, as given by the strength function
+	StrongestCells(ctx context.Context, coord model.Coordinate, radius float64, k int, strength StrengthFunc) []*model.Cell
+
 	// GetRandomCell retrieves a random cell from the registry
 	GetRandomCell() (*model.Cell, error)
 
+	// GetWeightedRandomCell retrieves a random cell from the registry, with a probability proportional to its weight
+	// as given by the weight function; cells with no positive weight are never retrieved
+	GetWeightedRandomCell(weight WeightFunc) (*model.Cell, error)
+
 	// Load add all cells from the specified cell map; no events will be generated
 	Load(ctx context.Context, nodes map[string]model.Cell)
 
@@ -67,6 +100,12 @@ type Store interface {
 	Clear(ctx context.Context)
 }
 
+// StrengthFunc returns the strength in dBm of the signal of the cell at the given coordinate
+type StrengthFunc func(coord model.Coordinate, cell model.Cell) float64
+
+// WeightFunc returns the weight of a cell
+type WeightFunc func(cell *model.Cell) float64
+
 // WatchOptions allows tailoring the WatchCells behaviour
 type WatchOptions struct {
 	Replay  bool
@@ -76,6 +115,7 @@ type WatchOptions struct {
 type store struct {
 	mu        sync.RWMutex
 	cells     map[types.NCGI]*model.Cell
+	index     *gridIndex
 	nodeStore nodes.Store
 	watchers  *watcher.Watchers
 }
@@ -87,6 +127,7 @@ func NewCellRegistry(cells map[string]model.Cell, nodeStore nodes.Store) Store {
 	reg := &store{
 		mu:        sync.RWMutex{},
 		cells:     make(map[types.NCGI]*model.Cell),
+		index:     newGridIndex(),
 		nodeStore: nodeStore,
 		watchers:  watchers,
 	}
@@ -105,6 +146,7 @@ func (s *store) Load(ctx context.Context, cells map[string]model.Cell) {
 	for _, c := range cells {
 		cell := c // avoids scopelint issue
 		s.cells[cell.NCGI] = &cell
+		s.index.add(cell.NCGI, cell.Sector.Center)
 	}
 }
 
@@ -115,6 +157,7 @@ func (s *store) Clear(ctx context.Context) {
 	for id := range s.cells {
 		delete(s.cells, id)
 	}
+	s.index.clear()
 }
 
 // Add adds a cell
@@ -126,6 +169,7 @@ func (s *store) Add(ctx context.Context, cell *model.Cell) error {
 	}
 
 	s.cells[cell.NCGI] = cell
+	s.index.add(cell.NCGI, cell.Sector.Center)
 	cellEvent := event.Event{
 		Key:   cell.NCGI,
 		Value: cell,
@@ -146,12 +190,23 @@ func (s *store) Get(ctx context.Context, ncgi types.NCGI) (*model.Cell, error) {
 	return nil, errors.New(errors.NotFound, "cell not found")
 }
 
+// View calls the given function with the cell while holding the read lock
+func (s *store) View(ctx context.Context, ncgi types.NCGI, f func(cell *model.Cell) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if cell, ok := s.cells[ncgi]; ok {
+		return f(cell)
+	}
+	return errors.New(errors.NotFound, "cell not found")
+}
+
 // Update updates a cell
 func (s *store) Update(ctx context.Context, cell *model.Cell) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if prevCell, ok := s.cells[cell.NCGI]; ok {
 		s.cells[cell.NCGI] = cell
+		s.index.add(cell.NCGI, cell.Sector.Center)
 		prevNeighbors := prevCell.Neighbors
 		equalNeighborsResult := equalNeighbors(prevNeighbors, cell.Neighbors)
 		if !equalNeighborsResult {
@@ -175,12 +230,67 @@ func (s *store) Update(ctx context.Context, cell *model.Cell) error {
 	return errors.New(errors.NotFound, "cell not found")
 }
 
+// UpdateCells updates several cells at once; none is updated unless all of them exist
+func (s *store) UpdateCells(ctx context.Context, id string, cells []*model.Cell) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, cell := range cells {
+		if _, ok := s.cells[cell.NCGI]; !ok {
+			return errors.NewNotFound("cell %d not found", cell.NCGI)
+		}
+	}
+	for _, cell := range cells {
+		s.cells[cell.NCGI] = cell
+		s.index.add(cell.NCGI, cell.Sector.Center)
+	}
+	s.watchers.Send(event.Event{
+		Key:   id,
+		Value: cells,
+		Type:  TopologyChanged,
+	})
+	return nil
+}
+
+// SetFailed puts a cell out of service or back in service; the cells listing it as neighbor are notified as
+// their neighbors in service change
+func (s *store) SetFailed(ctx context.Context, ncgi types.NCGI, failed bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cell, ok := s.cells[ncgi]
+	if !ok {
+		return errors.New(errors.NotFound, "cell not found")
+	}
+	if cell.Failed == failed {
+		return nil
+	}
+	cell.Failed = failed
+	s.watchers.Send(event.Event{
+		Key:   cell.NCGI,
+		Value: cell,
+		Type:  Updated,
+	})
+	for _, other := range s.cells {
+		for _, neighbor := range other.Neighbors {
+			if neighbor == ncgi {
+				s.watchers.Send(event.Event{
+					Key:   other.NCGI,
+					Value: other,
+					Type:  UpdatedNeighbors,
+				})
+				break
+			}
+		}
+	}
+	return nil
+}
+
 // Delete deletes a cell
 func (s *store) Delete(ctx context.Context, ncgi types.NCGI) (*model.Cell, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if cell, ok := s.cells[ncgi]; ok {
 		delete(s.cells, ncgi)
+		s.index.remove(ncgi)
 		deleteEvent := event.Event{
 			Key:   cell.NCGI,
 			Value: cell,
@@ -239,12 +349,91 @@ func (s *store) List(ctx context.Context) ([]*model.Cell, error) {
 	return list, nil
 }
 
+// FindCellsNear returns the cells within the radius of the coordinate, nearest first
+func (s *store) FindCellsNear(ctx context.Context, coord model.Coordinate, radius float64) []*model.Cell {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.findCellsNear(coord, radius)
+}
+
+func (s *store) findCellsNear(coord model.Coordinate, radius float64) []*model.Cell {
+	var near []*model.Cell
+	distances := make(map[types.NCGI]float64)
+	for _, ncgi := range s.index.candidates(coord, radius) {
+		cell := s.cells[ncgi]
+		if distance := utils.Distance(coord, cell.Sector.Center); distance <= radius {
+			near = append(near, cell)
+			distances[ncgi] = distance
+		}
+	}
+	sort.Slice(near, func(i, j int) bool {
+		if distances[near[i].NCGI] != distances[near[j].NCGI] {
+			return distances[near[i].NCGI] < distances[near[j].NCGI]
+		}
+		return near[i].NCGI < near[j].NCGI
+	})
+	return near
+}
+
+// StrongestCells returns the k cells in service within the radius of the coordinate with the strongest signal there
+func (s *store) StrongestCells(ctx context.Context, coord model.Coordinate, radius float64, k int, strength StrengthFunc) []*model.Cell {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var strongest []*model.Cell
+	strengths := make(map[types.NCGI]float64)
+	for _, cell := range s.findCellsNear(coord, radius) {
+		if cell.Failed {
+			continue
+		}
+		rsrp := strength(coord, *cell)
+		if math.IsNaN(rsrp) {
+			continue
+		}
+		strongest = append(strongest, cell)
+		strengths[cell.NCGI] = rsrp
+	}
+	sort.SliceStable(strongest, func(i, j int) bool {
+		return strengths[strongest[i].NCGI] > strengths[strongest[j].NCGI]
+	})
+	if len(strongest) > k {
+		strongest = strongest[:k]
+	}
+	return strongest
+}
+
 func (s *store) GetRandomCell() (*model.Cell, error) {
 	keys := reflect.ValueOf(s.cells).MapKeys()
 	ncgi := types.NCGI(keys[rand.Intn(len(keys))].Uint())
 	return s.cells[ncgi], nil
 }
 
+// GetWeightedRandomCell retrieves a random cell with a probability proportional to its weight
+func (s *store) GetWeightedRandomCell(weight WeightFunc) (*model.Cell, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	cellList := make([]*model.Cell, 0, len(s.cells))
+	weights := make([]float64, 0, len(s.cells))
+	total := 0.0
+	for _, cell := range s.cells {
+		if w := weight(cell); w > 0 {
+			cellList = append(cellList, cell)
+			weights = append(weights, w)
+			total += w
+		}
+	}
+	if len(cellList) == 0 {
+		return nil, errors.New(errors.NotFound, "no cell with a positive weight")
+	}
+	r := rand.Float64() * total
+	for i, w := range weights {
+		if r < w {
+			return cellList[i], nil
+		}
+		r -= w
+	}
+	return cellList[len(cellList)-1], nil
+}
+
 // IncrementRrcIdleCount
 func (s *store) IncrementRrcIdleCount(ctx context.Context, ncgi types.NCGI) {
 	s.mu.RLock()
@@ -276,3 +465,19 @@ func (s *store) DecrementRrcConnectedCount(ctx context.Context, ncgi types.NCGI)
 		s.cells[ncgi].RrcConnectedCount--
 	}
 }
+
+// IncrementRrcInactiveCount
+func (s *store) IncrementRrcInactiveCount(ctx context.Context, ncgi types.NCGI) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.cells[ncgi].RrcInactiveCount++
+}
+
+// DecrementRrcInactiveCount
+func (s *store) DecrementRrcInactiveCount(ctx context.Context, ncgi types.NCGI) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.cells[ncgi].RrcInactiveCount != 0 {
+		s.cells[ncgi].RrcInactiveCount--
+	}
+}
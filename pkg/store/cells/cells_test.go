@@ -61,3 +61,100 @@ func TestCells(t *testing.T) {
 	ids, _ := cellStore.List(ctx)
 	assert.Equal(t, 0, len(ids), "should be empty")
 }
+
+func TestSetFailed(t *testing.T) {
+	ctx := context.Background()
+	m := model.Model{}
+	bytes, err := ioutil.ReadFile("../../model/test.yaml")
+	assert.NoError(t, err)
+	assert.NoError(t, yaml.Unmarshal(bytes, &m))
+	cellStore := NewCellRegistry(m.Cells, nodes.NewNodeRegistry(m.Nodes))
+	neighbor, err := cellStore.Get(ctx, 84325717506)
+	assert.NoError(t, err)
+	neighbor.Neighbors = []types.NCGI{84325717505}
+
+	ch := make(chan event.Event)
+	assert.NoError(t, cellStore.Watch(ctx, ch))
+	assert.NoError(t, cellStore.SetFailed(ctx, 84325717505, true))
+	cell, err := cellStore.Get(ctx, 84325717505)
+	assert.NoError(t, err)
+	assert.True(t, cell.Failed)
+
+	// Events are delivered in any order
+	events := make(map[CellEvent]types.NCGI)
+	for i := 0; i < 2; i++ {
+		cellEvent := <-ch
+		events[cellEvent.Type.(CellEvent)] = cellEvent.Key.(types.NCGI)
+	}
+	assert.Equal(t, types.NCGI(84325717505), events[Updated])
+	assert.Equal(t, types.NCGI(84325717506), events[UpdatedNeighbors])
+
+	// Setting the same state again generates no event
+	assert.NoError(t, cellStore.SetFailed(ctx, 84325717505, true))
+	assert.NoError(t, cellStore.SetFailed(ctx, 84325717505, false))
+	assert.False(t, cell.Failed)
+	<-ch
+	<-ch
+	assert.Error(t, cellStore.SetFailed(ctx, 1, true))
+}
+
+func TestFindCellsNear(t *testing.T) {
+	ctx := context.Background()
+	cellStore := NewCellRegistry(map[string]model.Cell{}, nodes.NewNodeRegistry(map[string]model.Node{}))
+	origin := model.Coordinate{Lat: 46, Lng: 29}
+	for i, lat := range []float64{46.005, 46.001, 46.02, 47} {
+		assert.NoError(t, cellStore.Add(ctx, &model.Cell{
+			NCGI:   types.NCGI(i + 1),
+			Sector: model.Sector{Center: model.Coordinate{Lat: lat, Lng: 29}, Arc: 360},
+		}))
+	}
+
+	near := cellStore.FindCellsNear(ctx, origin, 3000)
+	assert.Len(t, near, 3)
+	assert.Equal(t, types.NCGI(2), near[0].NCGI)
+	assert.Equal(t, types.NCGI(1), near[1].NCGI)
+	assert.Equal(t, types.NCGI(3), near[2].NCGI)
+	assert.Len(t, cellStore.FindCellsNear(ctx, origin, 200000), 4)
+	assert.Len(t, cellStore.FindCellsNear(ctx, origin, 10), 0)
+
+	// Moved cells are re-indexed and deleted ones are no longer found
+	cell, err := cellStore.Get(ctx, 4)
+	assert.NoError(t, err)
+	moved := *cell
+	moved.Sector.Center = origin
+	assert.NoError(t, cellStore.Update(ctx, &moved))
+	near = cellStore.FindCellsNear(ctx, origin, 3000)
+	assert.Len(t, near, 4)
+	assert.Equal(t, types.NCGI(4), near[0].NCGI)
+
+	_, err = cellStore.Delete(ctx, 4)
+	assert.NoError(t, err)
+	assert.Len(t, cellStore.FindCellsNear(ctx, origin, 200000), 3)
+
+	cellStore.Clear(ctx)
+	assert.Len(t, cellStore.FindCellsNear(ctx, origin, 200000), 0)
+}
+
+func TestStrongestCells(t *testing.T) {
+	ctx := context.Background()
+	cellStore := NewCellRegistry(map[string]model.Cell{}, nodes.NewNodeRegistry(map[string]model.Node{}))
+	origin := model.Coordinate{Lat: 46, Lng: 29}
+	for i, lat := range []float64{46.005, 46.001, 46.02, 46.003} {
+		assert.NoError(t, cellStore.Add(ctx, &model.Cell{
+			NCGI:   types.NCGI(i + 1),
+			Sector: model.Sector{Center: model.Coordinate{Lat: lat, Lng: 29}, Arc: 360},
+		}))
+	}
+	assert.NoError(t, cellStore.SetFailed(ctx, 2, true))
+
+	// The strength decreases with the latitude, so that the southernmost cells are the strongest
+	strength := func(coord model.Coordinate, cell model.Cell) float64 {
+		return -cell.Sector.Center.Lat
+	}
+	strongest := cellStore.StrongestCells(ctx, origin, 5000, 2, strength)
+	assert.Len(t, strongest, 2)
+	assert.Equal(t, types.NCGI(4), strongest[0].NCGI)
+	assert.Equal(t, types.NCGI(1), strongest[1].NCGI)
+	assert.Len(t, cellStore.StrongestCells(ctx, origin, 5000, 10, strength), 3)
+	assert.Len(t, cellStore.StrongestCells(ctx, origin, 400, 10, strength), 1)
+}
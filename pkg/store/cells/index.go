@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cells
+
+import (
+	"math"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// gridDegrees size in degrees of the squares of the grid the cells are indexed by; about 1 km of latitude
+const gridDegrees = 0.01
+
+// square square of the grid, identified by the latitude and longitude of its south-west corner in grid units
+type square struct {
+	lat int32
+	lng int32
+}
+
+func squareOf(c model.Coordinate) square {
+	return square{
+		lat: int32(math.Floor(c.Lat / gridDegrees)),
+		lng: int32(math.Floor(c.Lng / gridDegrees)),
+	}
+}
+
+// gridIndex indexes the cells by the square of the grid their sector center lies in, so that the cells near a
+// location are found without scanning all cells
+type gridIndex struct {
+	squares   map[square]map[types.NCGI]struct{}
+	locations map[types.NCGI]model.Coordinate
+}
+
+func newGridIndex() *gridIndex {
+	return &gridIndex{
+		squares:   make(map[square]map[types.NCGI]struct{}),
+		locations: make(map[types.NCGI]model.Coordinate),
+	}
+}
+
+// add indexes the cell at the given location, replacing its previous location if any
+func (g *gridIndex) add(ncgi types.NCGI, location model.Coordinate) {
+	if previous, ok := g.locations[ncgi]; ok {
+		if previous == location {
+			return
+		}
+		g.remove(ncgi)
+	}
+	key := squareOf(location)
+	if _, ok := g.squares[key]; !ok {
+		g.squares[key] = make(map[types.NCGI]struct{})
+	}
+	g.squares[key][ncgi] = struct{}{}
+	g.locations[ncgi] = location
+}
+
+func (g *gridIndex) remove(ncgi types.NCGI) {
+	location, ok := g.locations[ncgi]
+	if !ok {
+		return
+	}
+	key := squareOf(location)
+	delete(g.squares[key], ncgi)
+	if len(g.squares[key]) == 0 {
+		delete(g.squares, key)
+	}
+	delete(g.locations, ncgi)
+}
+
+func (g *gridIndex) clear() {
+	g.squares = make(map[square]map[types.NCGI]struct{})
+	g.locations = make(map[types.NCGI]model.Coordinate)
+}
+
+// candidates returns the cells lying in the squares overlapping the bounding box of the circle of the given radius in
+// meters around the location; all cells are returned if the box spans more squares than there are cells
+func (g *gridIndex) candidates(location model.Coordinate, radius float64) []types.NCGI {
+	latSpan := radius / utils.MetersPerDegree
+	lngSpan := radius / (utils.MetersPerDegree * math.Max(math.Cos(location.Lat*math.Pi/180), 0.01))
+	min := squareOf(model.Coordinate{Lat: location.Lat - latSpan, Lng: location.Lng - lngSpan})
+	max := squareOf(model.Coordinate{Lat: location.Lat + latSpan, Lng: location.Lng + lngSpan})
+
+	var ncgis []types.NCGI
+	if float64(max.lat-min.lat+1)*float64(max.lng-min.lng+1) > float64(len(g.squares)) {
+		for ncgi := range g.locations {
+			ncgis = append(ncgis, ncgi)
+		}
+		return ncgis
+	}
+	for lat := min.lat; lat <= max.lat; lat++ {
+		for lng := min.lng; lng <= max.lng; lng++ {
+			for ncgi := range g.squares[square{lat: lat, lng: lng}] {
+				ncgis = append(ncgis, ncgi)
+			}
+		}
+	}
+	return ncgis
+}
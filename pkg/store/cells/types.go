@@ -18,8 +18,11 @@ const (
 	UpdatedNeighbors
 	// Deleted deleted cell event
 	Deleted
+	// TopologyChanged several cells updated at once event; the key of the event is the ID of the update and its
+	// value the updated cells
+	TopologyChanged
 )
 
 func (e CellEvent) String() string {
-	return [...]string{"None", "Created", "Updated", "Deleted"}[e]
+	return [...]string{"None", "Created", "Updated", "UpdatedNeighbors", "Deleted", "TopologyChanged"}[e]
 }
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
 	"github.com/onosproject/ran-simulator/pkg/store/event"
 	"github.com/onosproject/ran-simulator/pkg/store/watcher"
@@ -24,6 +25,12 @@ type Store interface {
 	// Set applies the specified metric value on the given entity
 	Set(ctx context.Context, entityID uint64, name string, value interface{}) error
 
+	// Increment increments the specified counter metric on the given entity and returns its new value
+	Increment(ctx context.Context, entityID uint64, name string) (uint64, error)
+
+	// Add adds the given amount to the specified counter metric on the given entity and returns its new value
+	Add(ctx context.Context, entityID uint64, name string, delta uint64) (uint64, error)
+
 	// Get retrieves the specified metric value on the given entity
 	Get(ctx context.Context, entityID uint64, name string) (interface{}, bool)
 
@@ -118,6 +125,30 @@ func (s *store) Set(ctx context.Context, entityID uint64, name string, value int
 	return nil
 }
 
+// Increment increments the specified counter metric on the given entity and returns its new value
+func (s *store) Increment(ctx context.Context, entityID uint64, name string) (uint64, error) {
+	return s.Add(ctx, entityID, name, 1)
+}
+
+// Add adds the given amount to the specified counter metric on the given entity and returns its new value
+func (s *store) Add(ctx context.Context, entityID uint64, name string, delta uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	k := key(entityID, name)
+	var value uint64
+	if v, ok := s.metrics[k]; ok {
+		counter, ok := v.(uint64)
+		if !ok {
+			return 0, errors.New(errors.Invalid, "metric %s is not a counter", name)
+		}
+		value = counter
+	}
+	value += delta
+	s.metrics[k] = value
+	s.watchers.Send(metricEvent(k, value, Updated))
+	return value, nil
+}
+
 // Get retrieves the specified metric value on the given entity
 func (s *store) Get(ctx context.Context, entityID uint64, name string) (interface{}, bool) {
 	s.mu.RLock()
@@ -84,3 +84,29 @@ func TestMetrics(t *testing.T) {
 
 	ctx.Done()
 }
+
+func TestIncrement(t *testing.T) {
+	store := NewMetricsStore()
+	ctx := context.Background()
+
+	v, err := store.Increment(ctx, 123, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), v)
+	v, err = store.Increment(ctx, 123, "foo")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), v)
+
+	m, ok := store.Get(ctx, 123, "foo")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), m)
+
+	v, err = store.Add(ctx, 123, "foo", 40)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), v)
+
+	_ = store.Set(ctx, 123, "bar", 3.14)
+	_, err = store.Increment(ctx, 123, "bar")
+	assert.Error(t, err)
+	_, err = store.Add(ctx, 123, "bar", 2)
+	assert.Error(t, err)
+}
@@ -30,6 +30,11 @@ type Store interface {
 	// Get retrieves the node with the specified GnbID
 	Get(ctx context.Context, gnbID types.GnbID) (*model.Node, error)
 
+	// View calls the given function with the node with the specified ID while holding the store lock, so that the
+	// function observes the node consistently with the concurrent updates; the function must neither retain the
+	// node nor call the store
+	View(ctx context.Context, gnbID types.GnbID, f func(node *model.Node) error) error
+
 	// Update updates the node
 	Update(ctx context.Context, node *model.Node) error
 
@@ -48,6 +53,9 @@ type Store interface {
 	// SetsStatus changes the E2 node agent status value
 	SetStatus(ctx context.Context, gnbID types.GnbID, status string) error
 
+	// SetE2SetupResult records the outcome of the latest E2 setup procedure of the node
+	SetE2SetupResult(ctx context.Context, gnbID types.GnbID, result *model.E2SetupResult) error
+
 	// PruneCell  the node that has the specified cell
 	PruneCell(ctx context.Context, ncgi types.NCGI) error
 
@@ -138,6 +146,16 @@ func (s *store) Get(ctx context.Context, gnbID types.GnbID) (*model.Node, error)
 	return nil, errors.New(errors.NotFound, "node not found")
 }
 
+// View calls the given function with the node while holding the read lock
+func (s *store) View(ctx context.Context, gnbID types.GnbID, f func(node *model.Node) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if node, ok := s.nodes[gnbID]; ok {
+		return f(node)
+	}
+	return errors.New(errors.NotFound, "node not found")
+}
+
 // Update updates a node
 func (s *store) Update(ctx context.Context, node *model.Node) error {
 	log.Debugf("Updating node with ID:%d", node.GnbID)
@@ -189,6 +207,23 @@ func (s *store) SetStatus(ctx context.Context, gnbID types.GnbID, status string)
 	return errors.New(errors.NotFound, "node not found")
 }
 
+// SetE2SetupResult records the outcome of the latest E2 setup procedure of a node
+func (s *store) SetE2SetupResult(ctx context.Context, gnbID types.GnbID, result *model.E2SetupResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if node, ok := s.nodes[gnbID]; ok {
+		node.E2Setup = result
+		updateEvent := event.Event{
+			Key:   node.GnbID,
+			Value: node,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+	return errors.New(errors.NotFound, "node not found")
+}
+
 // Delete deletes a node
 func (s *store) Delete(ctx context.Context, gnbID types.GnbID) (*model.Node, error) {
 	log.Debugf("Deleting node %d:", gnbID)
@@ -58,6 +58,22 @@ func TestNodes(t *testing.T) {
 	node1, err = nodeStore.Get(ctx, node1GnbID)
 	assert.NoError(t, err)
 	assert.Equal(t, node1.GnbID, node1GnbID)
+
+	result := &model.E2SetupResult{
+		Controller: "controller1",
+		Successful: true,
+		Accepted:   []model.RanFunctionResult{{ID: 2, Revision: 1}},
+	}
+	err = nodeStore.SetE2SetupResult(ctx, node1GnbID, result)
+	assert.NoError(t, err)
+	nodeEvent = <-ch
+	assert.Equal(t, Updated, nodeEvent.Type.(NodeEvent))
+	node1, err = nodeStore.Get(ctx, node1GnbID)
+	assert.NoError(t, err)
+	assert.Equal(t, result, node1.E2Setup)
+	err = nodeStore.SetE2SetupResult(ctx, 4321, result)
+	assert.Error(t, err)
+
 	_, err = nodeStore.Delete(ctx, node1GnbID)
 	assert.NoError(t, err)
 	nodeEvent = <-ch
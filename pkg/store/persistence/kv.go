@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package persistence
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	opPut    byte = 1
+	opDelete byte = 2
+)
+
+// Each log record is encoded as the operation (1 byte), the bucket length (2 bytes), the key length (2 bytes)
+// and the value length (4 bytes) in big endian order, followed by the bucket, the key and the value
+const recordHeaderSize = 9
+
+// minCompactionSize size below which the log is never compacted
+const minCompactionSize = 1 << 20
+
+// KV embedded key-value store; the entries are kept in memory and their changes are appended to a log file,
+// which is replayed when the store is opened and compacted once it grows to twice the size of the live entries
+type KV struct {
+	mu       sync.RWMutex
+	path     string
+	file     *os.File
+	writer   *bufio.Writer
+	buckets  map[string]map[string][]byte
+	liveSize int64
+	logSize  int64
+}
+
+// OpenKV opens the store kept in the given file, creating it if needed
+func OpenKV(path string) (*KV, error) {
+	kv := &KV{
+		path:    path,
+		buckets: make(map[string]map[string][]byte),
+	}
+	if err := kv.replay(); err != nil {
+		return nil, err
+	}
+	if err := kv.compact(); err != nil {
+		return nil, err
+	}
+	return kv, nil
+}
+
+// replay applies the operations of the log; a record truncated by a crash ends the log
+func (kv *KV) replay() error {
+	file, err := os.Open(kv.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, recordHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err == io.EOF {
+			return nil
+		} else if err != nil {
+			log.Warnf("Ignoring truncated record at the end of %s", kv.path)
+			return nil
+		}
+		bucketLen := binary.BigEndian.Uint16(header[1:3])
+		keyLen := binary.BigEndian.Uint16(header[3:5])
+		valueLen := binary.BigEndian.Uint32(header[5:9])
+		data := make([]byte, int(bucketLen)+int(keyLen)+int(valueLen))
+		if _, err := io.ReadFull(reader, data); err != nil {
+			log.Warnf("Ignoring truncated record at the end of %s", kv.path)
+			return nil
+		}
+		bucket := string(data[:bucketLen])
+		key := string(data[bucketLen : bucketLen+keyLen])
+		switch header[0] {
+		case opPut:
+			kv.apply(bucket, key, data[bucketLen+keyLen:])
+		case opDelete:
+			kv.apply(bucket, key, nil)
+		default:
+			return errors.NewInvalid("invalid operation %d in %s", header[0], kv.path)
+		}
+	}
+}
+
+// apply updates the in-memory entries; a nil value deletes the entry
+func (kv *KV) apply(bucket string, key string, value []byte) {
+	entries, ok := kv.buckets[bucket]
+	if !ok {
+		entries = make(map[string][]byte)
+		kv.buckets[bucket] = entries
+	}
+	if old, ok := entries[key]; ok {
+		kv.liveSize -= entrySize(bucket, key, old)
+		delete(entries, key)
+	}
+	if value != nil {
+		entries[key] = value
+		kv.liveSize += entrySize(bucket, key, value)
+	}
+}
+
+// compact rewrites the log with the live entries only and reopens it for appending. The live entries are written
+// to a temporary file which replaces the log once complete; the log is reopened whatever the outcome, so that the
+// store never appends to a closed file, and the store is closed if the log can not be reopened.
+func (kv *KV) compact() error {
+	tmpPath := kv.path + ".tmp"
+	if err := kv.writeEntries(tmpPath); err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	if kv.file != nil {
+		// The records still buffered are superseded by the compacted log
+		if err := kv.writer.Flush(); err != nil {
+			log.Warn(err)
+		}
+		if err := kv.file.Close(); err != nil {
+			log.Warn(err)
+		}
+		kv.file, kv.writer = nil, nil
+	}
+	renameErr := os.Rename(tmpPath, kv.path)
+	file, err := os.OpenFile(kv.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	kv.file = file
+	kv.writer = bufio.NewWriter(file)
+	if renameErr != nil {
+		_ = os.Remove(tmpPath)
+		return renameErr
+	}
+	kv.logSize = kv.liveSize
+	return nil
+}
+
+// writeEntries writes the live entries to the given file and syncs it
+func (kv *KV) writeEntries(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	for bucket, entries := range kv.buckets {
+		for key, value := range entries {
+			if err := writeRecord(writer, opPut, bucket, key, value); err != nil {
+				file.Close()
+				return err
+			}
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Put sets the value of the given key of the bucket
+func (kv *KV) Put(bucket string, key string, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	return kv.write(opPut, bucket, key, value)
+}
+
+// Delete removes the given key of the bucket
+func (kv *KV) Delete(bucket string, key string) error {
+	return kv.write(opDelete, bucket, key, nil)
+}
+
+func (kv *KV) write(op byte, bucket string, key string, value []byte) error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if kv.file == nil {
+		return errors.NewUnavailable("store %s is closed", kv.path)
+	}
+	if err := writeRecord(kv.writer, op, bucket, key, value); err != nil {
+		return err
+	}
+	kv.apply(bucket, key, value)
+	kv.logSize += entrySize(bucket, key, value)
+	if kv.logSize > minCompactionSize && kv.logSize > 2*kv.liveSize {
+		return kv.compact()
+	}
+	return nil
+}
+
+// List returns a copy of the entries of the given bucket
+func (kv *KV) List(bucket string) map[string][]byte {
+	kv.mu.RLock()
+	defer kv.mu.RUnlock()
+	entries := make(map[string][]byte, len(kv.buckets[bucket]))
+	for key, value := range kv.buckets[bucket] {
+		entries[key] = value
+	}
+	return entries
+}
+
+// Sync flushes the appended changes to stable storage
+func (kv *KV) Sync() error {
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if kv.file == nil {
+		return nil
+	}
+	if err := kv.writer.Flush(); err != nil {
+		return err
+	}
+	return kv.file.Sync()
+}
+
+// Close flushes the appended changes and closes the log file
+func (kv *KV) Close() error {
+	if err := kv.Sync(); err != nil {
+		return err
+	}
+	kv.mu.Lock()
+	defer kv.mu.Unlock()
+	if kv.file == nil {
+		return nil
+	}
+	err := kv.file.Close()
+	kv.file = nil
+	return err
+}
+
+func writeRecord(w io.Writer, op byte, bucket string, key string, value []byte) error {
+	if len(bucket) > 0xffff || len(key) > 0xffff {
+		return errors.NewInvalid("bucket or key too long")
+	}
+	header := make([]byte, recordHeaderSize)
+	header[0] = op
+	binary.BigEndian.PutUint16(header[1:3], uint16(len(bucket)))
+	binary.BigEndian.PutUint16(header[3:5], uint16(len(key)))
+	binary.BigEndian.PutUint32(header[5:9], uint32(len(value)))
+	for _, data := range [][]byte{header, []byte(bucket), []byte(key), value} {
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func entrySize(bucket string, key string, value []byte) int64 {
+	return int64(recordHeaderSize + len(bucket) + len(key) + len(value))
+}
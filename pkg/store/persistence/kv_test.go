@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package persistence
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKV(t *testing.T) {
+	dir, err := os.MkdirTemp("", "kv")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state")
+
+	kv, err := OpenKV(path)
+	assert.NoError(t, err)
+	assert.NoError(t, kv.Put("a", "1", []byte("one")))
+	assert.NoError(t, kv.Put("a", "2", []byte("two")))
+	assert.NoError(t, kv.Put("a", "1", []byte("uno")))
+	assert.NoError(t, kv.Put("b", "1", []byte{}))
+	assert.NoError(t, kv.Delete("a", "2"))
+	assert.NoError(t, kv.Close())
+	assert.Error(t, kv.Put("a", "3", []byte("three")))
+
+	// Simulates a crash while appending a record
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	assert.NoError(t, err)
+	_, err = file.Write([]byte{opPut, 0, 1})
+	assert.NoError(t, err)
+	assert.NoError(t, file.Close())
+
+	kv, err = OpenKV(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"1": []byte("uno")}, kv.List("a"))
+	assert.Equal(t, map[string][]byte{"1": {}}, kv.List("b"))
+	assert.Len(t, kv.List("c"), 0)
+	assert.NoError(t, kv.Close())
+
+	// The log is compacted when opened
+	info, err := os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, entrySize("a", "1", []byte("uno"))+entrySize("b", "1", nil), info.Size())
+}
+
+func TestKVCompactionFailure(t *testing.T) {
+	dir, err := os.MkdirTemp("", "kv")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state")
+
+	kv, err := OpenKV(path)
+	assert.NoError(t, err)
+	assert.NoError(t, kv.Put("a", "1", []byte("one")))
+
+	// The compacted log can not be written over a directory; the store keeps appending to the log
+	assert.NoError(t, os.Mkdir(path+".tmp", 0755))
+	assert.Error(t, kv.compact())
+	assert.NoError(t, kv.Put("a", "2", []byte("two")))
+	assert.NoError(t, kv.Sync())
+	assert.NoError(t, kv.Close())
+
+	kv, err = OpenKV(path)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"1": []byte("one"), "2": []byte("two")}, kv.List("a"))
+	assert.NoError(t, kv.Close())
+}
@@ -0,0 +1,374 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	liblog "github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+)
+
+var log = liblog.GetLogger("store", "persistence")
+
+// Buckets of the persisted entries
+const (
+	nodesBucket   = "nodes"
+	cellsBucket   = "cells"
+	uesBucket     = "ues"
+	metricsBucket = "metrics"
+)
+
+// DefaultInterval default interval at which the changes are written to the persistent store
+const DefaultInterval = 5 * time.Second
+
+// Persister keeps the contents of the node, cell, UE and metrics stores in a persistent key-value store, so that
+// a restarted simulator can resume the same topology and UE population. The stores are watched for changes and
+// the entries changed since the last write are written at a fixed interval, which coalesces the frequent UE updates.
+type Persister struct {
+	kv           *KV
+	interval     time.Duration
+	nodeStore    nodes.Store
+	cellStore    cells.Store
+	ueStore      ues.Store
+	metricsStore metrics.Store
+
+	mu           sync.Mutex
+	dirtyNodes   map[types.GnbID]bool
+	dirtyCells   map[types.NCGI]bool
+	dirtyUEs     map[types.IMSI]bool
+	dirtyMetrics map[metrics.Key]bool
+	cancel       context.CancelFunc
+	done         chan struct{}
+}
+
+// NewPersister creates a persister of the given stores backed by the given key-value store
+func NewPersister(kv *KV, interval time.Duration, nodeStore nodes.Store, cellStore cells.Store, ueStore ues.Store, metricsStore metrics.Store) *Persister {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Persister{
+		kv:           kv,
+		interval:     interval,
+		nodeStore:    nodeStore,
+		cellStore:    cellStore,
+		ueStore:      ueStore,
+		metricsStore: metricsStore,
+		dirtyNodes:   make(map[types.GnbID]bool),
+		dirtyCells:   make(map[types.NCGI]bool),
+		dirtyUEs:     make(map[types.IMSI]bool),
+		dirtyMetrics: make(map[metrics.Key]bool),
+	}
+}
+
+// Restore replaces the contents of the stores with the persisted entries; it returns false and leaves the stores
+// untouched if nothing has been persisted yet
+func (p *Persister) Restore(ctx context.Context) (bool, error) {
+	nodeEntries := p.kv.List(nodesBucket)
+	cellEntries := p.kv.List(cellsBucket)
+	if len(nodeEntries) == 0 && len(cellEntries) == 0 {
+		return false, nil
+	}
+
+	nodeMap := make(map[string]model.Node, len(nodeEntries))
+	for key, value := range nodeEntries {
+		node := model.Node{}
+		if err := decode(value, &node); err != nil {
+			return false, err
+		}
+		nodeMap[key] = node
+	}
+	cellMap := make(map[string]model.Cell, len(cellEntries))
+	for key, value := range cellEntries {
+		cell := model.Cell{}
+		if err := decode(value, &cell); err != nil {
+			return false, err
+		}
+		cellMap[key] = cell
+	}
+	ueList := make([]*model.UE, 0)
+	for _, value := range p.kv.List(uesBucket) {
+		ue := &model.UE{}
+		if err := decode(value, ue); err != nil {
+			return false, err
+		}
+		ueList = append(ueList, ue)
+	}
+
+	p.nodeStore.Clear(ctx)
+	p.nodeStore.Load(ctx, nodeMap)
+	p.cellStore.Clear(ctx)
+	p.cellStore.Load(ctx, cellMap)
+	p.ueStore.Clear(ctx)
+	p.ueStore.Load(ctx, ueList)
+	p.metricsStore.Clear(ctx)
+	for key, value := range p.kv.List(metricsBucket) {
+		metric := metricValue{}
+		if err := decode(value, &metric); err != nil {
+			return false, err
+		}
+		entityID, name, err := parseMetricKey(key)
+		if err != nil {
+			return false, err
+		}
+		if err := p.metricsStore.Set(ctx, entityID, name, metric.Value); err != nil {
+			return false, err
+		}
+	}
+	log.Infof("Restored %d nodes, %d cells and %d UEs", len(nodeMap), len(cellMap), len(ueList))
+	return true, nil
+}
+
+// Start starts watching the stores and writing their changes; the current contents of the stores are written first
+// and persisted entries no longer present in the stores are removed
+func (p *Persister) Start() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done = make(chan struct{})
+
+	nodeCh := make(chan event.Event)
+	if err := p.nodeStore.Watch(ctx, nodeCh, nodes.WatchOptions{}); err != nil {
+		cancel()
+		return err
+	}
+	cellCh := make(chan event.Event)
+	if err := p.cellStore.Watch(ctx, cellCh, cells.WatchOptions{}); err != nil {
+		cancel()
+		return err
+	}
+	ueCh := make(chan event.Event)
+	if err := p.ueStore.Watch(ctx, ueCh, ues.WatchOptions{}); err != nil {
+		cancel()
+		return err
+	}
+	metricCh := make(chan event.Event)
+	if err := p.metricsStore.Watch(ctx, metricCh); err != nil {
+		cancel()
+		return err
+	}
+	p.markAll(ctx)
+	p.markPersisted()
+
+	go p.watch(nodeCh, func(e event.Event) { p.dirtyNodes[e.Key.(types.GnbID)] = true })
+	go p.watch(cellCh, func(e event.Event) {
+		if e.Type == cells.TopologyChanged {
+			for _, cell := range e.Value.([]*model.Cell) {
+				p.dirtyCells[cell.NCGI] = true
+			}
+			return
+		}
+		p.dirtyCells[e.Key.(types.NCGI)] = true
+	})
+	go p.watch(ueCh, func(e event.Event) { p.dirtyUEs[e.Key.(types.IMSI)] = true })
+	go p.watch(metricCh, func(e event.Event) { p.dirtyMetrics[e.Key.(metrics.Key)] = true })
+	go p.run(ctx)
+	return nil
+}
+
+// Stop stops watching the stores and writes the pending changes
+func (p *Persister) Stop() {
+	if p.cancel == nil {
+		return
+	}
+	p.cancel()
+	<-p.done
+	p.cancel = nil
+}
+
+func (p *Persister) watch(ch <-chan event.Event, mark func(e event.Event)) {
+	for e := range ch {
+		p.mu.Lock()
+		mark(e)
+		p.mu.Unlock()
+	}
+}
+
+// markAll marks all entries of the stores
+func (p *Persister) markAll(ctx context.Context) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if nodeList, err := p.nodeStore.List(ctx); err == nil {
+		for _, node := range nodeList {
+			p.dirtyNodes[node.GnbID] = true
+		}
+	} else {
+		log.Warn(err)
+	}
+	if cellList, err := p.cellStore.List(ctx); err == nil {
+		for _, cell := range cellList {
+			p.dirtyCells[cell.NCGI] = true
+		}
+	} else {
+		log.Warn(err)
+	}
+	for _, ue := range p.ueStore.ListAllUEs(ctx) {
+		p.dirtyUEs[ue.IMSI] = true
+	}
+	entities, err := p.metricsStore.ListEntities(ctx)
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	for _, entityID := range entities {
+		values, err := p.metricsStore.List(ctx, entityID)
+		if err != nil {
+			log.Warn(err)
+			continue
+		}
+		for name := range values {
+			p.dirtyMetrics[metrics.Key{EntityID: entityID, Name: name}] = true
+		}
+	}
+}
+
+// markPersisted marks all persisted entries, so that those no longer present in the stores are removed
+func (p *Persister) markPersisted() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for bucket := range map[string]bool{nodesBucket: true, cellsBucket: true, uesBucket: true} {
+		for key := range p.kv.List(bucket) {
+			id, err := strconv.ParseUint(key, 10, 64)
+			if err != nil {
+				log.Warnf("Invalid key %s/%s", bucket, key)
+				continue
+			}
+			switch bucket {
+			case nodesBucket:
+				p.dirtyNodes[types.GnbID(id)] = true
+			case cellsBucket:
+				p.dirtyCells[types.NCGI(id)] = true
+			case uesBucket:
+				p.dirtyUEs[types.IMSI(id)] = true
+			}
+		}
+	}
+	for key := range p.kv.List(metricsBucket) {
+		entityID, name, err := parseMetricKey(key)
+		if err != nil {
+			log.Warnf("Invalid key %s/%s", metricsBucket, key)
+			continue
+		}
+		p.dirtyMetrics[metrics.Key{EntityID: entityID, Name: name}] = true
+	}
+}
+
+func (p *Persister) run(ctx context.Context) {
+	defer close(p.done)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.flush(context.Background())
+		case <-ctx.Done():
+			p.flush(context.Background())
+			return
+		}
+	}
+}
+
+// flush writes the current values of the entries changed since the last flush
+func (p *Persister) flush(ctx context.Context) {
+	p.mu.Lock()
+	dirtyNodes, dirtyCells, dirtyUEs, dirtyMetrics := p.dirtyNodes, p.dirtyCells, p.dirtyUEs, p.dirtyMetrics
+	p.dirtyNodes = make(map[types.GnbID]bool)
+	p.dirtyCells = make(map[types.NCGI]bool)
+	p.dirtyUEs = make(map[types.IMSI]bool)
+	p.dirtyMetrics = make(map[metrics.Key]bool)
+	p.mu.Unlock()
+
+	// The entries are encoded while the stores hold their lock, since they are updated in place
+	var data []byte
+	for gnbID := range dirtyNodes {
+		err := p.nodeStore.View(ctx, gnbID, func(node *model.Node) (err error) {
+			data, err = encode(node)
+			return err
+		})
+		p.write(nodesBucket, strconv.FormatUint(uint64(gnbID), 10), data, err)
+	}
+	for ncgi := range dirtyCells {
+		err := p.cellStore.View(ctx, ncgi, func(cell *model.Cell) (err error) {
+			data, err = encode(cell)
+			return err
+		})
+		p.write(cellsBucket, strconv.FormatUint(uint64(ncgi), 10), data, err)
+	}
+	for imsi := range dirtyUEs {
+		err := p.ueStore.View(ctx, imsi, func(ue *model.UE) (err error) {
+			data, err = encode(ue)
+			return err
+		})
+		p.write(uesBucket, strconv.FormatUint(uint64(imsi), 10), data, err)
+	}
+	for key := range dirtyMetrics {
+		err := errors.NewNotFound("metric not found")
+		if value, ok := p.metricsStore.Get(ctx, key.EntityID, key.Name); ok {
+			data, err = encode(&metricValue{Value: value})
+		}
+		p.write(metricsBucket, metricKey(key.EntityID, key.Name), data, err)
+	}
+	if err := p.kv.Sync(); err != nil {
+		log.Warn(err)
+	}
+}
+
+// write puts the encoded value of the entry, or deletes the entry if the error denotes that it no longer exists
+func (p *Persister) write(bucket string, key string, data []byte, err error) {
+	if errors.IsNotFound(err) {
+		if err := p.kv.Delete(bucket, key); err != nil {
+			log.Warn(err)
+		}
+		return
+	} else if err != nil {
+		log.Warnf("Unable to encode %s/%s: %v", bucket, key, err)
+		return
+	}
+	if err := p.kv.Put(bucket, key, data); err != nil {
+		log.Warn(err)
+	}
+}
+
+// metricValue wraps metric values so that their dynamic type is preserved
+type metricValue struct {
+	Value interface{}
+}
+
+func metricKey(entityID uint64, name string) string {
+	return strconv.FormatUint(entityID, 10) + "/" + name
+}
+
+func parseMetricKey(key string) (uint64, string, error) {
+	i := strings.Index(key, "/")
+	if i < 0 {
+		return 0, "", strconv.ErrSyntax
+	}
+	entityID, err := strconv.ParseUint(key[:i], 10, 64)
+	return entityID, key[i+1:], err
+}
+
+func encode(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte, value interface{}) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+}
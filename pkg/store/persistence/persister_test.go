@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package persistence
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/metrics"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/store/ues"
+	"github.com/stretchr/testify/assert"
+)
+
+type stores struct {
+	nodes   nodes.Store
+	cells   cells.Store
+	ues     ues.Store
+	metrics metrics.Store
+}
+
+func newStores(t *testing.T, ueCount uint) stores {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	return stores{
+		nodes:   nodeStore,
+		cells:   cellStore,
+		ues:     ues.NewUERegistry(ueCount, cellStore, "random"),
+		metrics: metrics.NewMetricsStore(),
+	}
+}
+
+func TestPersister(t *testing.T) {
+	ctx := context.Background()
+	dir, err := os.MkdirTemp("", "persister")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	kv, err := OpenKV(filepath.Join(dir, "state"))
+	assert.NoError(t, err)
+	original := newStores(t, 10)
+	persister := NewPersister(kv, time.Hour, original.nodes, original.cells, original.ues, original.metrics)
+	restored, err := persister.Restore(ctx)
+	assert.NoError(t, err)
+	assert.False(t, restored)
+	assert.NoError(t, original.metrics.Set(ctx, 1, "name", "value"))
+	_, err = original.metrics.Increment(ctx, 1, "counter")
+	assert.NoError(t, err)
+	assert.NoError(t, persister.Start())
+
+	// Changes are written when the persister is stopped
+	ue := original.ues.ListAllUEs(ctx)[0]
+	assert.NoError(t, original.ues.MoveToCoordinate(ctx, ue.IMSI, model.Coordinate{Lat: 1, Lng: 2}, 90))
+	time.Sleep(100 * time.Millisecond)
+	persister.Stop()
+	assert.NoError(t, kv.Close())
+
+	kv, err = OpenKV(filepath.Join(dir, "state"))
+	assert.NoError(t, err)
+	defer kv.Close()
+	resumed := newStores(t, 3)
+	persister = NewPersister(kv, time.Hour, resumed.nodes, resumed.cells, resumed.ues, resumed.metrics)
+	restored, err = persister.Restore(ctx)
+	assert.NoError(t, err)
+	assert.True(t, restored)
+
+	assert.Equal(t, 10, resumed.ues.Len(ctx))
+	resumedUE, err := resumed.ues.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, model.Coordinate{Lat: 1, Lng: 2}, resumedUE.Location)
+	assert.Equal(t, ue.Cell.NCGI, resumedUE.Cell.NCGI)
+
+	originalNodes, _ := original.nodes.List(ctx)
+	resumedNodes, _ := resumed.nodes.List(ctx)
+	assert.Equal(t, len(originalNodes), len(resumedNodes))
+	originalCells, _ := original.cells.List(ctx)
+	resumedCells, _ := resumed.cells.List(ctx)
+	assert.Equal(t, len(originalCells), len(resumedCells))
+
+	value, ok := resumed.metrics.Get(ctx, 1, "name")
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+	counter, ok := resumed.metrics.Get(ctx, 1, "counter")
+	assert.True(t, ok)
+	assert.Equal(t, uint64(1), counter)
+}
@@ -38,6 +38,9 @@ type Store interface {
 	// Advance advances to the next waypoint in the specified direction, reversing at route end-points
 	Advance(ctx context.Context, imsi types.IMSI) error
 
+	// Pause pauses or resumes the movement of the UE along the specified route
+	Pause(ctx context.Context, imsi types.IMSI, paused bool) error
+
 	// Delete destroy the specified UE route
 	Delete(ctx context.Context, imsi types.IMSI) (*model.Route, error)
 
@@ -166,6 +169,22 @@ func (s *store) Advance(ctx context.Context, imsi types.IMSI) error {
 	return errors.New(errors.NotFound, "route not found")
 }
 
+func (s *store) Pause(ctx context.Context, imsi types.IMSI, paused bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if route, ok := s.routes[imsi]; ok {
+		route.Paused = paused
+		updateEvent := event.Event{
+			Key:   imsi,
+			Value: route,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+	return errors.New(errors.NotFound, "route not found")
+}
+
 // Delete deletes a UE based on a given imsi
 func (s *store) Delete(ctx context.Context, imsi types.IMSI) (*model.Route, error) {
 	s.mu.Lock()
@@ -113,6 +113,33 @@ func TestRouteAdvance(t *testing.T) {
 	validate(t, routes, r.IMSI, 1, false)
 }
 
+func TestRoutePause(t *testing.T) {
+	ctx := context.Background()
+	routes := NewRouteRegistry()
+
+	r := &model.Route{
+		IMSI:   123456789,
+		Points: []*model.Coordinate{{Lat: 1, Lng: 2}, {Lat: 2, Lng: 1}},
+	}
+	err := routes.Add(ctx, r)
+	assert.NoError(t, err)
+
+	err = routes.Pause(ctx, r.IMSI, true)
+	assert.NoError(t, err)
+	r1, err := routes.Get(ctx, r.IMSI)
+	assert.NoError(t, err)
+	assert.True(t, r1.Paused)
+
+	err = routes.Pause(ctx, r.IMSI, false)
+	assert.NoError(t, err)
+	r1, err = routes.Get(ctx, r.IMSI)
+	assert.NoError(t, err)
+	assert.False(t, r1.Paused)
+
+	err = routes.Pause(ctx, 1, true)
+	assert.Error(t, err)
+}
+
 func validate(t *testing.T, store Store, imsi types.IMSI, n uint32, rev bool) {
 	r, err := store.Get(context.Background(), imsi)
 	assert.NoError(t, err)
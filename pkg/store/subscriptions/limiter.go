@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package subscriptions
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter caps the rate of indications of a subscription with a token bucket
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second
+	burst  float64 // capacity of the bucket
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates a limiter allowing rate indications per second on average and up to burst indications at once;
+// the bucket is initially full
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+	}
+}
+
+// Allow takes a token from the bucket; it returns false if the bucket is empty, i.e. the rate cap is hit
+func (l *Limiter) Allow() bool {
+	return l.allow(time.Now())
+}
+
+func (l *Limiter) allow(now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.last.IsZero() {
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens < 1 {
+		return false
+	}
+	l.tokens--
+	return true
+}
+
+// Limiter returns the indication rate limiter of the subscription, created with the given rate and burst upon the
+// first call; it returns nil if the rate is not capped
+func (s *Subscription) Limiter(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limiter == nil {
+		s.limiter = NewLimiter(rate, burst)
+	}
+	return s.limiter
+}
+
+// Limiter returns the rate limiter of the indications of all subscriptions of the node, created with the given rate
+// and burst upon the first call; it returns nil if the rate is not capped
+func (s *Subscriptions) Limiter(rate float64, burst int) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.limiter == nil {
+		s.limiter = NewLimiter(rate, burst)
+	}
+	return s.limiter
+}
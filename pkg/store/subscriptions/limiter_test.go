@@ -0,0 +1,48 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package subscriptions
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	limiter := NewLimiter(2, 2)
+	now := time.Now()
+	assert.True(t, limiter.allow(now))
+	assert.True(t, limiter.allow(now))
+	assert.False(t, limiter.allow(now))
+
+	// A token is added every 500 ms
+	now = now.Add(400 * time.Millisecond)
+	assert.False(t, limiter.allow(now))
+	now = now.Add(200 * time.Millisecond)
+	assert.True(t, limiter.allow(now))
+
+	// The bucket does not hold more than the burst
+	now = now.Add(10 * time.Second)
+	assert.True(t, limiter.allow(now))
+	assert.True(t, limiter.allow(now))
+	assert.False(t, limiter.allow(now))
+}
+
+func TestSubscriptionLimiter(t *testing.T) {
+	sub := &Subscription{ID: "sub1"}
+	assert.Nil(t, sub.Limiter(0, 1))
+	limiter := sub.Limiter(10, 1)
+	assert.NotNil(t, limiter)
+	assert.Same(t, limiter, sub.Limiter(10, 1))
+}
+
+func TestNodeLimiter(t *testing.T) {
+	subStore := NewStore()
+	assert.Nil(t, subStore.Limiter(0, 1))
+	limiter := subStore.Limiter(10, 1)
+	assert.NotNil(t, limiter)
+	assert.Same(t, limiter, subStore.Limiter(10, 1))
+}
@@ -5,6 +5,7 @@
 package subscriptions
 
 import (
+	"context"
 	"fmt"
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
 	"sync"
@@ -16,6 +17,7 @@ import (
 
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 )
 
 // ID is an alias for string subscription ID
@@ -28,7 +30,134 @@ type Subscription struct {
 	FnID      *e2apies.RanfunctionId
 	Details   *e2appducontents.RicsubscriptionDetails
 	E2Channel e2ap.ClientConn
-	Ticker    *time.Ticker
+
+	mu            sync.Mutex
+	cancel        context.CancelFunc
+	done          chan struct{}
+	reportActions []e2aptypes.RicActionID
+	insertActions []e2aptypes.RicActionID
+	limiter       *Limiter
+	suspended     bool
+	resumeTime    time.Time
+}
+
+// AdmitReportActions records the REPORT actions admitted for the subscription; the service model sends a stream
+// of indications carrying the RIC action ID of each of them
+func (s *Subscription) AdmitReportActions(actionIDs ...e2aptypes.RicActionID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reportActions = append(s.reportActions, actionIDs...)
+}
+
+// ReportActions returns the IDs of the REPORT actions admitted for the subscription
+func (s *Subscription) ReportActions() []e2aptypes.RicActionID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]e2aptypes.RicActionID(nil), s.reportActions...)
+}
+
+// AdmitInsertActions records the INSERT actions admitted for the subscription; the service model suspends the
+// call processes they are triggered by until the RIC controls them
+func (s *Subscription) AdmitInsertActions(actionIDs ...e2aptypes.RicActionID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.insertActions = append(s.insertActions, actionIDs...)
+}
+
+// InsertActions returns the IDs of the INSERT actions admitted for the subscription
+func (s *Subscription) InsertActions() []e2aptypes.RicActionID {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]e2aptypes.RicActionID(nil), s.insertActions...)
+}
+
+// Start runs the reporting routine of the subscription in a new goroutine; the routine must return
+// as soon as the given context is done
+func (s *Subscription) Start(report func(ctx context.Context)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	s.cancel = cancel
+	s.done = done
+	go func() {
+		defer close(done)
+		report(ctx)
+	}()
+}
+
+// Stop cancels the reporting routine of the subscription and waits until it has stopped sending indications
+func (s *Subscription) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.New(errors.Timeout, "reporting of subscription %s has not stopped", s.ID)
+	}
+}
+
+// IsRunning returns true if the reporting routine of the subscription has been started and has not returned yet
+func (s *Subscription) IsRunning() bool {
+	s.mu.Lock()
+	done := s.done
+	s.mu.Unlock()
+	if done == nil {
+		return false
+	}
+	select {
+	case <-done:
+		return false
+	default:
+		return true
+	}
+}
+
+// Suspend withholds the indications of the subscription, without deleting it, for the given duration or until it is
+// resumed if the duration is 0
+func (s *Subscription) Suspend(duration time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.suspended = true
+	s.resumeTime = time.Time{}
+	if duration > 0 {
+		s.resumeTime = time.Now().Add(duration)
+	}
+}
+
+// Resume resumes the delivery of the indications of the subscription
+func (s *Subscription) Resume() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.suspended = false
+	s.resumeTime = time.Time{}
+}
+
+// Suspension returns true if the indications of the subscription are withheld, along with the time they resume if
+// the suspension is timed
+func (s *Subscription) Suspension() (bool, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.suspended && !s.resumeTime.IsZero() && !time.Now().Before(s.resumeTime) {
+		s.suspended = false
+		s.resumeTime = time.Time{}
+	}
+	return s.suspended, s.resumeTime
+}
+
+// IsSuspended returns true if the indications of the subscription are withheld
+func (s *Subscription) IsSuspended() bool {
+	suspended, _ := s.Suspension()
+	return suspended
 }
 
 // NewID returns the locally unique ID for the specified subscription add/delete request
@@ -79,7 +208,7 @@ func NewStore() *Subscriptions {
 
 // Store store interface
 type Store interface {
-	// Add   adds the specified subscription
+	// Add   adds the specified subscription; it fails with AlreadyExists error if the subscription is a duplicate
 	Add(subscription *Subscription) error
 	// Remove removes the specified subscription
 	Remove(id ID) error
@@ -95,13 +224,29 @@ type Store interface {
 type Subscriptions struct {
 	subscriptions map[ID]*Subscription
 	mu            sync.RWMutex
+	limiter       *Limiter
 }
 
 // Len number of subscriptions
 func (s *Subscriptions) Len() (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	return len(s.subscriptions), nil
 }
 
+// Running number of subscriptions whose reporting routine is running
+func (s *Subscriptions) Running() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	running := 0
+	for _, sub := range s.subscriptions {
+		if sub.IsRunning() {
+			running++
+		}
+	}
+	return running
+}
+
 // Add adds the specified subscription
 func (s *Subscriptions) Add(sub *Subscription) error {
 	s.mu.Lock()
@@ -109,6 +254,9 @@ func (s *Subscriptions) Add(sub *Subscription) error {
 	if sub.ID == "" {
 		return errors.New(errors.Invalid, "Subscription ID cannot be empty")
 	}
+	if _, ok := s.subscriptions[sub.ID]; ok {
+		return errors.New(errors.AlreadyExists, "subscription %s already exists", sub.ID)
+	}
 	s.subscriptions[sub.ID] = sub
 	return nil
 }
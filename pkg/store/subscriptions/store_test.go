@@ -5,9 +5,13 @@
 package subscriptions
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -69,3 +73,104 @@ func TestSubscriptions(t *testing.T) {
 	assert.Equal(t, 1, len(subscriptionList))
 
 }
+
+func TestSubscriptionStartStop(t *testing.T) {
+	ctx := context.Background()
+	sub := &Subscription{ID: "sub1"}
+	assert.NoError(t, sub.Stop(ctx))
+
+	stopped := false
+	sub.Start(func(ctx context.Context) {
+		<-ctx.Done()
+		stopped = true
+	})
+	assert.NoError(t, sub.Stop(ctx))
+	assert.True(t, stopped)
+
+	stuck := &Subscription{ID: "sub2"}
+	release := make(chan struct{})
+	defer close(release)
+	stuck.Start(func(ctx context.Context) {
+		<-release
+	})
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Error(t, stuck.Stop(timeoutCtx))
+}
+
+func TestDuplicateSubscription(t *testing.T) {
+	subStore := NewStore()
+	id := NewID(1, 2, 3)
+	err := subStore.Add(&Subscription{ID: id})
+	assert.NoError(t, err)
+
+	err = subStore.Add(&Subscription{ID: id})
+	assert.True(t, errors.IsAlreadyExists(err))
+	numSubs, err := subStore.Len()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numSubs)
+
+	// resubscription is accepted once the subscription is deleted
+	err = subStore.Remove(id)
+	assert.NoError(t, err)
+	err = subStore.Add(&Subscription{ID: id})
+	assert.NoError(t, err)
+}
+
+func TestReportActions(t *testing.T) {
+	sub := &Subscription{ID: "sub1"}
+	assert.Empty(t, sub.ReportActions())
+
+	sub.AdmitReportActions(1, 4)
+	assert.Equal(t, []e2aptypes.RicActionID{1, 4}, sub.ReportActions())
+	assert.Empty(t, sub.InsertActions())
+
+	sub.AdmitInsertActions(2)
+	assert.Equal(t, []e2aptypes.RicActionID{2}, sub.InsertActions())
+	assert.Equal(t, []e2aptypes.RicActionID{1, 4}, sub.ReportActions())
+}
+
+func TestRunningSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	subStore := NewStore()
+	sub1 := &Subscription{ID: "sub1"}
+	sub2 := &Subscription{ID: "sub2"}
+	assert.NoError(t, subStore.Add(sub1))
+	assert.NoError(t, subStore.Add(sub2))
+	assert.Equal(t, 0, subStore.Running())
+
+	report := func(ctx context.Context) {
+		<-ctx.Done()
+	}
+	sub1.Start(report)
+	sub2.Start(report)
+	assert.True(t, sub1.IsRunning())
+	assert.Equal(t, 2, subStore.Running())
+
+	assert.NoError(t, sub1.Stop(ctx))
+	assert.False(t, sub1.IsRunning())
+	assert.Equal(t, 1, subStore.Running())
+	assert.NoError(t, sub2.Stop(ctx))
+	assert.Equal(t, 0, subStore.Running())
+}
+
+func TestSuspension(t *testing.T) {
+	sub := &Subscription{ID: "sub1"}
+	assert.False(t, sub.IsSuspended())
+
+	sub.Suspend(0)
+	suspended, resumeTime := sub.Suspension()
+	assert.True(t, suspended)
+	assert.True(t, resumeTime.IsZero())
+	sub.Resume()
+	assert.False(t, sub.IsSuspended())
+
+	// A timed suspension ends by itself
+	sub.Suspend(20 * time.Millisecond)
+	suspended, resumeTime = sub.Suspension()
+	assert.True(t, suspended)
+	assert.False(t, resumeTime.IsZero())
+	assert.Eventually(t, func() bool {
+		return !sub.IsSuspended()
+	}, time.Second, 5*time.Millisecond)
+}
@@ -0,0 +1,93 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ues
+
+import (
+	"context"
+	"math"
+	"math/rand"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+)
+
+// colocated distance in meters below which cells are considered to be on the same site
+const colocated = 1.0
+
+// placement places the UEs in random cells, weighted as configured, and at random locations within the sector
+// footprint of the cells. The footprint of a cell is the sector of its arc whose radius is half the distance to the
+// nearest other site, up to the configured radius.
+type placement struct {
+	config    model.UEPlacementConfig
+	cellStore cells.Store
+	radii     map[types.NCGI]float64
+}
+
+func newPlacement(ctx context.Context, config model.UEPlacementConfig, cellStore cells.Store) *placement {
+	p := &placement{
+		config:    config,
+		cellStore: cellStore,
+		radii:     make(map[types.NCGI]float64),
+	}
+	cellList, _ := cellStore.List(ctx)
+	for _, cell := range cellList {
+		p.radii[cell.NCGI] = p.footprintRadius(ctx, cell)
+	}
+	return p
+}
+
+// footprintRadius returns half the distance from the cell to the nearest other site, up to the configured radius
+func (p *placement) footprintRadius(ctx context.Context, cell *model.Cell) float64 {
+	radius := p.config.GetRadius()
+	for _, other := range p.cellStore.FindCellsNear(ctx, cell.Sector.Center, 2*radius) {
+		if distance := utils.Distance(cell.Sector.Center, other.Sector.Center); distance > colocated {
+			return math.Min(radius, distance/2)
+		}
+	}
+	return radius
+}
+
+// cell returns a random cell in service
+func (p *placement) cell() (*model.Cell, error) {
+	return p.cellStore.GetWeightedRandomCell(p.weight)
+}
+
+func (p *placement) weight(cell *model.Cell) float64 {
+	if cell.Failed {
+		return 0
+	}
+	switch p.config.GetWeighting() {
+	case model.UEPlacementArea:
+		radius, ok := p.radii[cell.NCGI]
+		if !ok {
+			radius = p.config.GetRadius()
+		}
+		return arc(cell) / 360 * math.Pi * radius * radius
+	case model.UEPlacementWeight:
+		return cell.GetWeight()
+	}
+	return 1
+}
+
+// location returns a location drawn uniformly from the sector footprint of the cell
+func (p *placement) location(cell *model.Cell) model.Coordinate {
+	radius, ok := p.radii[cell.NCGI]
+	if !ok {
+		radius = p.config.GetRadius()
+	}
+	bearing := float64(cell.Sector.Azimuth) + (rand.Float64()-0.5)*arc(cell)
+	bearing = math.Mod(bearing+360, 360)
+	return utils.TargetPoint(cell.Sector.Center, bearing, radius*math.Sqrt(rand.Float64()))
+}
+
+// arc returns the width in degrees of the sector of the cell; cells with no arc are omnidirectional
+func arc(cell *model.Cell) float64 {
+	if cell.Sector.Arc <= 0 || cell.Sector.Arc > 360 {
+		return 360
+	}
+	return float64(cell.Sector.Arc)
+}
@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package ues
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func placementCellStore(weight float64) cells.Store {
+	return cells.NewCellRegistry(map[string]model.Cell{
+		"omni": {
+			NCGI:   1,
+			Sector: model.Sector{Center: model.Coordinate{Lat: 46, Lng: 29}, Arc: 360},
+			Weight: &weight,
+		},
+		"sector": {
+			NCGI:   2,
+			Sector: model.Sector{Center: model.Coordinate{Lat: 46.009, Lng: 29}, Azimuth: 90, Arc: 60},
+		},
+	}, nodes.NewNodeRegistry(map[string]model.Node{}))
+}
+
+func TestPlacementWeight(t *testing.T) {
+	ctx := context.Background()
+	cellStore := placementCellStore(0)
+	ues := NewUERegistry(50, cellStore, "connected", WithPlacement(model.UEPlacementConfig{Weighting: model.UEPlacementWeight}))
+	sector, err := cellStore.Get(ctx, 2)
+	assert.NoError(t, err)
+	for _, ue := range ues.ListAllUEs(ctx) {
+		assert.Equal(t, types.NCGI(2), ue.Cell.NCGI)
+		// The sites are 1 km apart, so that the footprints have a radius of 500 m
+		assert.True(t, utils.Distance(sector.Sector.Center, ue.Location) <= 502)
+		bearing := utils.InitialBearing(sector.Sector.Center, ue.Location)
+		assert.True(t, bearing >= 59 && bearing <= 121, "bearing %f", bearing)
+	}
+}
+
+func TestPlacementArea(t *testing.T) {
+	ctx := context.Background()
+	ues := NewUERegistry(600, placementCellStore(1), "connected", WithPlacement(model.UEPlacementConfig{Weighting: model.UEPlacementArea}))
+	counts := make(map[types.NCGI]int)
+	for _, ue := range ues.ListAllUEs(ctx) {
+		counts[ue.Cell.NCGI]++
+	}
+	// The omnidirectional cell covers 6 times the area of the sector cell
+	assert.True(t, counts[1] > 3*counts[2], "counts %v", counts)
+	assert.True(t, counts[2] > 0)
+}
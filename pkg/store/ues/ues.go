@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	"github.com/google/uuid"
+	"github.com/onosproject/ran-simulator/pkg/clock"
 	"github.com/onosproject/ran-simulator/pkg/store/watcher"
 
 	"github.com/onosproject/ran-simulator/pkg/store/event"
@@ -40,6 +41,9 @@ type Store interface {
 	// LenPerCell returns the number of active UEs per cell
 	LenPerCell(ctx context.Context, cellNCGI uint64) int
 
+	// ConnectedLenPerCell returns the number of RRC connected UEs per cell
+	ConnectedLenPerCell(ctx context.Context, cellNCGI uint64) int
+
 	// MaxUEsPerCell returns the maximum number of active UEs per cell
 	MaxUEsPerCell(ctx context.Context, cellNCGI uint64) int
 
@@ -55,6 +59,11 @@ type Store interface {
 	// Get retrieves the UE with the specified IMSI
 	Get(ctx context.Context, imsi types.IMSI) (*model.UE, error)
 
+	// View calls the given function with the UE with the specified ID while holding the store lock, so that the
+	// function observes the UE consistently with the concurrent updates; the function must neither retain the
+	// UE nor call the store
+	View(ctx context.Context, imsi types.IMSI, f func(ue *model.UE) error) error
+
 	// Delete destroy the specified UE
 	Delete(ctx context.Context, imsi types.IMSI) (*model.UE, error)
 
@@ -64,12 +73,70 @@ type Store interface {
 	// MoveToCoordinate updates the UEs geo location and compass heading
 	MoveToCoordinate(ctx context.Context, imsi types.IMSI, location model.Coordinate, heading uint32) error
 
+	// SetMobility sets the mobility class and the current speed in km/h of the UE
+	SetMobility(ctx context.Context, imsi types.IMSI, class model.MobilityClass, speed float64) error
+
+	// SetType sets the type of the UE
+	SetType(ctx context.Context, imsi types.IMSI, ueType model.UEType) error
+
 	// UpdateCells updates the visible cells and their signal strength
 	UpdateCells(ctx context.Context, imsi types.IMSI, cells []*model.UECell) error
 
 	// UpdateCell updates the serving cell
 	UpdateCell(ctx context.Context, imsi types.IMSI, cell *model.UECell) error
 
+	// SetSecondaryCell adds a secondary cell group on another node to the UE, carrying the given share of its
+	// traffic; a nil cell releases the secondary cell group
+	SetSecondaryCell(ctx context.Context, imsi types.IMSI, cell *model.UECell, splitRatio float64) error
+
+	// TrafficLoadPerCell returns the number of RRC connected UEs served by the cell, weighted by the share of their
+	// traffic carried by the cell
+	TrafficLoadPerCell(ctx context.Context, cellNCGI uint64) float64
+
+	// UpdateCarriers updates the carriers aggregated by the UE
+	UpdateCarriers(ctx context.Context, imsi types.IMSI, carriers []*model.Carrier) error
+
+	// SetCarrierActive activates or deactivates the secondary cell of the UE; activating a cell that is not
+	// configured as secondary cell of the UE configures it
+	SetCarrierActive(ctx context.Context, imsi types.IMSI, ncgi types.NCGI, active bool) error
+
+	// CarrierUsersPerCell returns the number of RRC connected UEs with an active carrier on the cell
+	CarrierUsersPerCell(ctx context.Context, cellNCGI uint64) int
+
+	// ActiveCarriersPerCell returns the active carriers of the cell of the RRC connected UEs, which are not detached
+	ActiveCarriersPerCell(ctx context.Context, cellNCGI uint64) map[types.IMSI]model.Carrier
+
+	// PrbUsedPerCell returns the number of downlink PRBs of the cell allocated to the UEs
+	PrbUsedPerCell(ctx context.Context, cellNCGI uint64) float64
+
+	// ThroughputPerCell returns the mean downlink throughput in kbps of the RRC connected UEs served by the cell
+	ThroughputPerCell(ctx context.Context, cellNCGI uint64) float64
+
+	// UpdateSinr updates the SINR of the serving cell of the UE, along with the CQI derived from it
+	UpdateSinr(ctx context.Context, imsi types.IMSI, sinr float64) error
+
+	// CqiPerCell returns the mean wideband CQI of the RRC connected UEs served by the cell
+	CqiPerCell(ctx context.Context, cellNCGI uint64) float64
+
+	// UpdateUplink updates the uplink quantities of the UE
+	UpdateUplink(ctx context.Context, imsi types.IMSI, uplink model.Uplink) error
+
+	// UplinkPerCell returns the mean uplink quantities of the RRC connected UEs served by the cell
+	UplinkPerCell(ctx context.Context, cellNCGI uint64) model.Uplink
+
+	// SetDetached detaches the UE from its cells during the interruption of a handover, suspending its traffic, or
+	// attaches it back
+	SetDetached(ctx context.Context, imsi types.IMSI, detached bool) error
+
+	// SetMeasReportConfig sets the measurement reporting configuration of the UE
+	SetMeasReportConfig(ctx context.Context, imsi types.IMSI, config model.MeasReportConfig) error
+
+	// UpdateRrcState moves the UE to the given RRC state and updates the RRC counters of its serving cell
+	UpdateRrcState(ctx context.Context, imsi types.IMSI, rrcState mho.Rrcstatus) error
+
+	// SetTrackingArea registers the UE in the given tracking area
+	SetTrackingArea(ctx context.Context, imsi types.IMSI, tac uint32) error
+
 	// ListAllUEs returns an array of all UEs
 	ListAllUEs(ctx context.Context) []*model.UE
 
@@ -78,6 +145,15 @@ type Store interface {
 
 	// Watch watches the UE inventory events using the supplied channel
 	Watch(ctx context.Context, ch chan<- event.Event, options ...WatchOptions) error
+
+	// Add adds a UE, e.g. one handed over by another simulator instance, and counts it on its serving cell
+	Add(ctx context.Context, ue *model.UE) error
+
+	// Load adds all of the specified UEs; no events will be generated
+	Load(ctx context.Context, ues []*model.UE)
+
+	// Clear removes all UEs; no events will be generated
+	Clear(ctx context.Context)
 }
 
 // WatchOptions allows tailoring the WatchNodes behaviour
@@ -93,11 +169,22 @@ type store struct {
 	cellStore       cells.Store
 	watchers        *watcher.Watchers
 	initialRrcState string
+	placement       model.UEPlacementConfig
+}
+
+// Option UE registry option
+type Option func(*store)
+
+// WithPlacement sets the placement of the UEs created by the registry
+func WithPlacement(placement model.UEPlacementConfig) Option {
+	return func(s *store) {
+		s.placement = placement
+	}
 }
 
 // NewUERegistry creates a new user-equipment registry primed with the specified number of UEs to start.
 // UEs will be semi-randomly distributed between the specified cells
-func NewUERegistry(count uint, cellStore cells.Store, initialRrcState string) Store {
+func NewUERegistry(count uint, cellStore cells.Store, initialRrcState string, options ...Option) Store {
 	log.Infof("Creating registry from model with %d UEs", count)
 	watchers := watcher.NewWatchers()
 	store := &store{
@@ -108,6 +195,9 @@ func NewUERegistry(count uint, cellStore cells.Store, initialRrcState string) St
 		watchers:        watchers,
 		initialRrcState: initialRrcState,
 	}
+	for _, option := range options {
+		option(store)
+	}
 	ctx := context.Background()
 	store.CreateUEs(ctx, count)
 	log.Infof("Created registry primed with %d UEs", len(store.ues))
@@ -115,6 +205,44 @@ func NewUERegistry(count uint, cellStore cells.Store, initialRrcState string) St
 	return store
 }
 
+// Load adds all of the specified UEs; no events will be generated
+func (s *store) Load(ctx context.Context, ues []*model.UE) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ue := range ues {
+		s.ues[ue.IMSI] = ue
+	}
+}
+
+// Add adds a UE, e.g. one handed over by another simulator instance, and counts it on its serving cell
+func (s *store) Add(ctx context.Context, ue *model.UE) error {
+	if ue.Cell == nil {
+		return errors.NewInvalid("UE %d has no serving cell", ue.IMSI)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.ues[ue.IMSI]; ok {
+		return errors.NewAlreadyExists("UE %d already exists", ue.IMSI)
+	}
+	s.ues[ue.IMSI] = ue
+	s.updateRrcCount(ctx, ue.Cell.NCGI, ue.RrcState, true)
+	s.watchers.Send(event.Event{
+		Key:   ue.IMSI,
+		Value: ue,
+		Type:  Created,
+	})
+	return nil
+}
+
+// Clear removes all UEs; no events will be generated
+func (s *store) Clear(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for imsi := range s.ues {
+		delete(s.ues, imsi)
+	}
+}
+
 func (s *store) SetUECount(ctx context.Context, count uint) {
 	delta := len(s.ues) - int(count)
 	if delta < 0 {
@@ -140,6 +268,18 @@ func (s *store) LenPerCell(ctx context.Context, cellNCGI uint64) int {
 	return result
 }
 
+func (s *store) ConnectedLenPerCell(ctx context.Context, cellNCGI uint64) int {
+	result := 0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if uint64(ue.Cell.NCGI) == cellNCGI && ue.RrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED && !ue.Detached {
+			result++
+		}
+	}
+	return result
+}
+
 func (s *store) MaxUEsPerCell(ctx context.Context, cellNCGI uint64) int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -161,6 +301,10 @@ func (s *store) UpdateMaxUEsPerCell(ctx context.Context) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	for _, ue := range s.ues {
+		// Only RRC connected UEs are active
+		if ue.RrcState != mho.Rrcstatus_RRCSTATUS_CONNECTED {
+			continue
+		}
 		if _, ok := s.maxUEs[uint64(ue.Cell.NCGI)]; !ok {
 			cNumUEsMap[uint64(ue.Cell.NCGI)] = 1
 			continue
@@ -199,6 +343,7 @@ func randomBoolean() bool {
 }
 
 func (s *store) CreateUEs(ctx context.Context, count uint) {
+	placement := newPlacement(ctx, s.placement, s.cellStore)
 	s.mu.Lock()
 	for i := uint(0); i < count; i++ {
 		imsi := types.IMSI(rand.Int63n(maxIMSI-minIMSI) + minIMSI)
@@ -207,9 +352,10 @@ func (s *store) CreateUEs(ctx context.Context, count uint) {
 			imsi = types.IMSI(rand.Int63n(maxIMSI-minIMSI) + minIMSI)
 		}
 
-		randomCell, err := s.cellStore.GetRandomCell()
+		randomCell, err := placement.cell()
 		if err != nil {
 			log.Error(err)
+			break
 		}
 		ncgi := randomCell.NCGI
 		var rrcState mho.Rrcstatus
@@ -232,18 +378,20 @@ func (s *store) CreateUEs(ctx context.Context, count uint) {
 		}
 		ue := &model.UE{
 			IMSI:     imsi,
+			PlmnID:   model.GetPlmnID(ncgi), // UEs attach to their home network first
 			Type:     "phone",
-			Location: model.Coordinate{Lat: 0, Lng: 0},
+			Location: placement.location(randomCell),
 			Heading:  0,
 			Cell: &model.UECell{
 				ID:       types.GnbID(ncgi), // placeholder
 				NCGI:     ncgi,
 				Strength: rand.Float64() * 100,
 			},
-			CRNTI:      types.CRNTI(90125 + i),
-			Cells:      nil,
-			IsAdmitted: false,
-			RrcState:   rrcState,
+			CRNTI:        types.CRNTI(90125 + i),
+			Cells:        nil,
+			IsAdmitted:   rrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED,
+			RrcState:     rrcState,
+			RrcStateTime: clock.Now(),
 		}
 		s.ues[ue.IMSI] = ue
 	}
@@ -262,6 +410,16 @@ func (s *store) Get(ctx context.Context, imsi types.IMSI) (*model.UE, error) {
 	return nil, errors.New(errors.NotFound, "UE not found")
 }
 
+// View calls the given function with the UE while holding the read lock
+func (s *store) View(ctx context.Context, imsi types.IMSI, f func(ue *model.UE) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if ue, ok := s.ues[imsi]; ok {
+		return f(ue)
+	}
+	return errors.New(errors.NotFound, "UE not found")
+}
+
 // Delete deletes a UE based on a given imsi
 func (s *store) Delete(ctx context.Context, imsi types.IMSI) (*model.UE, error) {
 	s.mu.Lock()
@@ -323,6 +481,33 @@ func (s *store) MoveToCoordinate(ctx context.Context, imsi types.IMSI, location
 	return errors.New(errors.NotFound, "UE not found")
 }
 
+func (s *store) SetMobility(ctx context.Context, imsi types.IMSI, class model.MobilityClass, speed float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.Mobility = class
+		ue.Speed = speed
+		return nil
+	}
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) SetType(ctx context.Context, imsi types.IMSI, ueType model.UEType) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.Type = ueType
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+	return errors.New(errors.NotFound, "UE not found")
+}
+
 func (s *store) UpdateCells(ctx context.Context, imsi types.IMSI, cells []*model.UECell) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -356,6 +541,344 @@ func (s *store) UpdateCell(ctx context.Context, imsi types.IMSI, cell *model.UEC
 	return errors.New(errors.NotFound, "UE not found")
 }
 
+func (s *store) SetSecondaryCell(ctx context.Context, imsi types.IMSI, cell *model.UECell, splitRatio float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.SecondaryCell = cell
+		ue.SplitRatio = splitRatio
+		if cell == nil {
+			ue.SplitRatio = 0
+		}
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) TrafficLoadPerCell(ctx context.Context, cellNCGI uint64) float64 {
+	result := 0.0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if ue.RrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED && !ue.Detached {
+			result += ue.TrafficShare(types.NCGI(cellNCGI))
+		}
+	}
+	return result
+}
+
+func (s *store) UpdateCarriers(ctx context.Context, imsi types.IMSI, carriers []*model.Carrier) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.Carriers = carriers
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) SetCarrierActive(ctx context.Context, imsi types.IMSI, ncgi types.NCGI, active bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ue, ok := s.ues[imsi]
+	if !ok {
+		return errors.New(errors.NotFound, "UE not found")
+	}
+	var carrier *model.Carrier
+	for _, c := range ue.Carriers {
+		if c.NCGI == ncgi {
+			carrier = c
+		}
+	}
+	switch {
+	case carrier == nil && !active:
+		return errors.NewNotFound("cell %d is not a secondary cell of UE %d", ncgi, imsi)
+	case carrier == nil:
+		carrier = &model.Carrier{NCGI: ncgi}
+		ue.Carriers = append(ue.Carriers, carrier)
+	case carrier.Primary:
+		return errors.NewInvalid("cell %d is the primary cell of UE %d", ncgi, imsi)
+	}
+	carrier.Active = active
+	if !active {
+		carrier.PRBs = 0
+		carrier.Throughput = 0
+	}
+	updateEvent := event.Event{
+		Key:   ue.IMSI,
+		Value: ue,
+		Type:  Updated,
+	}
+	s.watchers.Send(updateEvent)
+	return nil
+}
+
+func (s *store) CarrierUsersPerCell(ctx context.Context, cellNCGI uint64) int {
+	result := 0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if ue.RrcState != mho.Rrcstatus_RRCSTATUS_CONNECTED || ue.Detached {
+			continue
+		}
+		if uint64(ue.Cell.NCGI) == cellNCGI {
+			result++
+			continue
+		}
+		for _, carrier := range ue.Carriers {
+			if carrier.Active && uint64(carrier.NCGI) == cellNCGI {
+				result++
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (s *store) ActiveCarriersPerCell(ctx context.Context, cellNCGI uint64) map[types.IMSI]model.Carrier {
+	result := make(map[types.IMSI]model.Carrier)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if ue.RrcState != mho.Rrcstatus_RRCSTATUS_CONNECTED || ue.Detached {
+			continue
+		}
+		for _, carrier := range ue.Carriers {
+			if carrier.Active && uint64(carrier.NCGI) == cellNCGI {
+				result[ue.IMSI] = *carrier
+				break
+			}
+		}
+	}
+	return result
+}
+
+func (s *store) PrbUsedPerCell(ctx context.Context, cellNCGI uint64) float64 {
+	result := 0.0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		for _, carrier := range ue.Carriers {
+			if carrier.Active && uint64(carrier.NCGI) == cellNCGI {
+				result += carrier.PRBs
+			}
+		}
+	}
+	return result
+}
+
+func (s *store) ThroughputPerCell(ctx context.Context, cellNCGI uint64) float64 {
+	throughput, count := 0.0, 0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if uint64(ue.Cell.NCGI) == cellNCGI && ue.RrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED {
+			throughput += ue.Throughput()
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return throughput / float64(count)
+}
+
+func (s *store) UpdateSinr(ctx context.Context, imsi types.IMSI, sinr float64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.Sinr = sinr
+		ue.Cqi = model.CQI(sinr)
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) CqiPerCell(ctx context.Context, cellNCGI uint64) float64 {
+	cqi, count := 0.0, 0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if uint64(ue.Cell.NCGI) == cellNCGI && ue.RrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED {
+			cqi += float64(ue.Cqi)
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return cqi / float64(count)
+}
+
+func (s *store) UpdateUplink(ctx context.Context, imsi types.IMSI, uplink model.Uplink) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.Uplink = uplink
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) UplinkPerCell(ctx context.Context, cellNCGI uint64) model.Uplink {
+	var result model.Uplink
+	count := 0
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ue := range s.ues {
+		if uint64(ue.Cell.NCGI) == cellNCGI && ue.RrcState == mho.Rrcstatus_RRCSTATUS_CONNECTED {
+			result.TxPower += ue.Uplink.TxPower
+			result.PowerHeadroom += ue.Uplink.PowerHeadroom
+			result.Sinr += ue.Uplink.Sinr
+			result.Throughput += ue.Uplink.Throughput
+			count++
+		}
+	}
+	if count == 0 {
+		return result
+	}
+	result.TxPower /= float64(count)
+	result.PowerHeadroom /= float64(count)
+	result.Sinr /= float64(count)
+	result.Throughput /= float64(count)
+	return result
+}
+
+func (s *store) SetTrackingArea(ctx context.Context, imsi types.IMSI, tac uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.TAC = tac
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) SetDetached(ctx context.Context, imsi types.IMSI, detached bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.Detached = detached
+		if detached {
+			for _, carrier := range ue.Carriers {
+				carrier.PRBs = 0
+				carrier.Throughput = 0
+			}
+		}
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) SetMeasReportConfig(ctx context.Context, imsi types.IMSI, config model.MeasReportConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ue, ok := s.ues[imsi]; ok {
+		ue.MeasReport = config
+		updateEvent := event.Event{
+			Key:   ue.IMSI,
+			Value: ue,
+			Type:  Updated,
+		}
+		s.watchers.Send(updateEvent)
+		return nil
+	}
+
+	return errors.New(errors.NotFound, "UE not found")
+}
+
+func (s *store) UpdateRrcState(ctx context.Context, imsi types.IMSI, rrcState mho.Rrcstatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ue, ok := s.ues[imsi]
+	if !ok {
+		return errors.New(errors.NotFound, "UE not found")
+	}
+	if ue.RrcState == rrcState {
+		return nil
+	}
+
+	s.updateRrcCount(ctx, ue.Cell.NCGI, ue.RrcState, false)
+	s.updateRrcCount(ctx, ue.Cell.NCGI, rrcState, true)
+	ue.RrcState = rrcState
+	ue.RrcStateTime = clock.Now()
+	// UEs in RRC inactive state keep their context in the RAN
+	ue.IsAdmitted = rrcState != mho.Rrcstatus_RRCSTATUS_IDLE
+
+	updateEvent := event.Event{
+		Key:   ue.IMSI,
+		Value: ue,
+		Type:  Updated,
+	}
+	s.watchers.Send(updateEvent)
+	return nil
+}
+
+func (s *store) updateRrcCount(ctx context.Context, ncgi types.NCGI, rrcState mho.Rrcstatus, increment bool) {
+	switch rrcState {
+	case mho.Rrcstatus_RRCSTATUS_IDLE:
+		if increment {
+			s.cellStore.IncrementRrcIdleCount(ctx, ncgi)
+		} else {
+			s.cellStore.DecrementRrcIdleCount(ctx, ncgi)
+		}
+	case mho.Rrcstatus_RRCSTATUS_CONNECTED:
+		if increment {
+			s.cellStore.IncrementRrcConnectedCount(ctx, ncgi)
+		} else {
+			s.cellStore.DecrementRrcConnectedCount(ctx, ncgi)
+		}
+	case mho.Rrcstatus_RRCSTATUS_INACTIVE:
+		if increment {
+			s.cellStore.IncrementRrcInactiveCount(ctx, ncgi)
+		} else {
+			s.cellStore.DecrementRrcInactiveCount(ctx, ncgi)
+		}
+	}
+}
+
 func (s *store) ListUEs(ctx context.Context, ncgi types.NCGI) []*model.UE {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
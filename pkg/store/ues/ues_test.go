@@ -11,8 +11,10 @@ import (
 	"testing"
 
 	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
 	"github.com/onosproject/ran-simulator/pkg/store/nodes"
 	"gopkg.in/yaml.v2"
 
@@ -130,3 +132,199 @@ func TestUpdateCells(t *testing.T) {
 	assert.Equal(t, 42.0, ue1.Cells[0].Strength)
 	assert.Equal(t, 6.28, ue1.Cells[1].Strength)
 }
+
+func TestUpdateRrcState(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+	ncgi := ue.Cell.NCGI
+	assert.True(t, ue.IsAdmitted)
+	assert.Equal(t, 1, ues.ConnectedLenPerCell(ctx, uint64(ncgi)))
+
+	err := ues.UpdateRrcState(ctx, ue.IMSI, mho.Rrcstatus_RRCSTATUS_INACTIVE)
+	assert.NoError(t, err)
+	assert.True(t, ue.IsAdmitted)
+	assert.Equal(t, 0, ues.ConnectedLenPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 1, ues.LenPerCell(ctx, uint64(ncgi)))
+	cell, err := cellStore.Get(ctx, ncgi)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), cell.RrcConnectedCount)
+	assert.Equal(t, uint32(1), cell.RrcInactiveCount)
+
+	err = ues.UpdateRrcState(ctx, ue.IMSI, mho.Rrcstatus_RRCSTATUS_IDLE)
+	assert.NoError(t, err)
+	assert.False(t, ue.IsAdmitted)
+	cell, err = cellStore.Get(ctx, ncgi)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(0), cell.RrcInactiveCount)
+	assert.Equal(t, uint32(1), cell.RrcIdleCount)
+
+	err = ues.UpdateRrcState(ctx, types.IMSI(1), mho.Rrcstatus_RRCSTATUS_CONNECTED)
+	assert.Error(t, err)
+}
+
+func TestSetSecondaryCell(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+	ncgi := ue.Cell.NCGI
+	assert.Equal(t, 1.0, ues.TrafficLoadPerCell(ctx, uint64(ncgi)))
+
+	err := ues.SetSecondaryCell(ctx, ue.IMSI, &model.UECell{NCGI: 123001, Strength: -90}, 0.75)
+	assert.NoError(t, err)
+	assert.True(t, ue.IsDualConnected())
+	assert.Equal(t, 0.25, ues.TrafficLoadPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 0.75, ues.TrafficLoadPerCell(ctx, 123001))
+
+	err = ues.SetSecondaryCell(ctx, ue.IMSI, nil, 0)
+	assert.NoError(t, err)
+	assert.False(t, ue.IsDualConnected())
+	assert.Equal(t, 1.0, ues.TrafficLoadPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 0.0, ues.TrafficLoadPerCell(ctx, 123001))
+
+	err = ues.SetSecondaryCell(ctx, types.IMSI(1), nil, 0)
+	assert.Error(t, err)
+}
+
+func TestCarriers(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+	ncgi := ue.Cell.NCGI
+	assert.Equal(t, 1, ues.CarrierUsersPerCell(ctx, uint64(ncgi)))
+
+	err := ues.UpdateCarriers(ctx, ue.IMSI, []*model.Carrier{
+		{NCGI: ncgi, Primary: true, Active: true, PRBs: 51, Throughput: 20000},
+		{NCGI: 123001, Active: true, PRBs: 273, Throughput: 100000},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 120000.0, ue.Throughput())
+	assert.Equal(t, 120000.0, ues.ThroughputPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 1, ues.CarrierUsersPerCell(ctx, 123001))
+	assert.Equal(t, 273.0, ues.PrbUsedPerCell(ctx, 123001))
+	assert.Equal(t, 273.0, ues.ActiveCarriersPerCell(ctx, 123001)[ue.IMSI].PRBs)
+
+	assert.NoError(t, ues.SetCarrierActive(ctx, ue.IMSI, 123001, false))
+	assert.Equal(t, 20000.0, ue.Throughput())
+	assert.Equal(t, 0, ues.CarrierUsersPerCell(ctx, 123001))
+	assert.Equal(t, 0.0, ues.PrbUsedPerCell(ctx, 123001))
+	assert.Empty(t, ues.ActiveCarriersPerCell(ctx, 123001))
+	assert.Len(t, ue.SecondaryCarriers(), 1)
+
+	assert.Error(t, ues.SetCarrierActive(ctx, ue.IMSI, ncgi, false))
+	assert.Error(t, ues.SetCarrierActive(ctx, ue.IMSI, 123002, false))
+	assert.NoError(t, ues.SetCarrierActive(ctx, ue.IMSI, 123002, true))
+	assert.Len(t, ue.SecondaryCarriers(), 2)
+}
+
+func TestAdd(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(0, cellStore, "connected")
+	ch := make(chan event.Event, 1)
+	assert.NoError(t, ues.Watch(ctx, ch))
+
+	ncgi := types.NCGI(84325717505)
+	ue := &model.UE{
+		IMSI:     types.IMSI(315010999999999),
+		RrcState: mho.Rrcstatus_RRCSTATUS_CONNECTED,
+		Cell:     &model.UECell{NCGI: ncgi},
+	}
+	assert.NoError(t, ues.Add(ctx, ue))
+	assert.Equal(t, Created, (<-ch).Type.(UeEvent))
+	assert.Equal(t, 1, ues.ConnectedLenPerCell(ctx, uint64(ncgi)))
+	cell, err := cellStore.Get(ctx, ncgi)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), cell.RrcConnectedCount)
+
+	assert.Error(t, ues.Add(ctx, ue))
+	assert.Error(t, ues.Add(ctx, &model.UE{IMSI: types.IMSI(1)}))
+}
+
+func TestSetDetached(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+	ncgi := ue.Cell.NCGI
+	err := ues.UpdateCarriers(ctx, ue.IMSI, []*model.Carrier{
+		{NCGI: ncgi, Primary: true, Active: true, PRBs: 51, Throughput: 20000},
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, ues.SetDetached(ctx, ue.IMSI, true))
+	assert.True(t, ue.Detached)
+	assert.Equal(t, 0.0, ue.Throughput())
+	assert.Equal(t, 0.0, ues.TrafficLoadPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 0, ues.CarrierUsersPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 0.0, ues.PrbUsedPerCell(ctx, uint64(ncgi)))
+
+	assert.NoError(t, ues.SetDetached(ctx, ue.IMSI, false))
+	assert.Equal(t, 1.0, ues.TrafficLoadPerCell(ctx, uint64(ncgi)))
+	assert.Error(t, ues.SetDetached(ctx, types.IMSI(1), true))
+}
+
+func TestUpdateSinr(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+	ncgi := ue.Cell.NCGI
+
+	assert.NoError(t, ues.UpdateSinr(ctx, ue.IMSI, 12))
+	assert.Equal(t, 12.0, ue.Sinr)
+	assert.Equal(t, uint32(10), ue.Cqi)
+	assert.Equal(t, 10.0, ues.CqiPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, 0.0, ues.CqiPerCell(ctx, 123001))
+	assert.Error(t, ues.UpdateSinr(ctx, types.IMSI(1), 12))
+}
+
+func TestUpdateUplink(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+	ncgi := ue.Cell.NCGI
+
+	uplink := model.Uplink{TxPower: 10, PowerHeadroom: 13, Sinr: 5, Throughput: 9000}
+	assert.NoError(t, ues.UpdateUplink(ctx, ue.IMSI, uplink))
+	assert.Equal(t, uplink, ue.Uplink)
+	assert.Equal(t, uplink, ues.UplinkPerCell(ctx, uint64(ncgi)))
+	assert.Equal(t, model.Uplink{}, ues.UplinkPerCell(ctx, 123001))
+	assert.Error(t, ues.UpdateUplink(ctx, types.IMSI(1), uplink))
+}
+
+func TestSetType(t *testing.T) {
+	ctx := context.Background()
+	ues := NewUERegistry(1, cellStore(t), "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+
+	assert.NoError(t, ues.SetType(ctx, ue.IMSI, "iot"))
+	ue, err := ues.Get(ctx, ue.IMSI)
+	assert.NoError(t, err)
+	assert.Equal(t, model.UEType("iot"), ue.Type)
+	assert.Error(t, ues.SetType(ctx, types.IMSI(1), "iot"))
+}
+
+func TestSetMeasReportConfig(t *testing.T) {
+	ctx := context.Background()
+	cellStore := cellStore(t)
+	ues := NewUERegistry(1, cellStore, "connected")
+	ue := ues.ListAllUEs(ctx)[0]
+
+	ch := make(chan event.Event)
+	err := ues.Watch(ctx, ch)
+	assert.NoError(t, err)
+
+	err = ues.SetMeasReportConfig(ctx, ue.IMSI, model.MeasReportConfig{ReportInterval: 480, TriggerQuantity: model.TriggerQuantityRSRQ})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(480), ue.MeasReport.ReportInterval)
+	updateEvent := <-ch
+	assert.Equal(t, Updated, updateEvent.Type)
+
+	err = ues.SetMeasReportConfig(ctx, types.IMSI(1), model.MeasReportConfig{})
+	assert.Error(t, err)
+}
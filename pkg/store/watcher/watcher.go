@@ -6,6 +6,7 @@ package watcher
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
@@ -34,10 +35,21 @@ func NewWatchers() *Watchers {
 	}
 }
 
+// pending number of events being delivered to the watchers of all stores
+var pending int64
+
+// Pending returns the number of events sent but not yet delivered to all watchers, across all stores; a growing
+// number denotes watchers which do not keep up with the changes
+func Pending() int64 {
+	return atomic.LoadInt64(&pending)
+}
+
 // Send sends an event for all registered watchers
 func (ws *Watchers) Send(event event.Event) {
 	ws.rm.RLock()
+	atomic.AddInt64(&pending, 1)
 	go func() {
+		defer atomic.AddInt64(&pending, -1)
 		for _, watcher := range ws.watchers {
 			watcher.ch <- event
 		}
@@ -0,0 +1,317 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package topo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/event"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+)
+
+// Exporter registers the simulated nodes and cells as E2 node and E2 cell entities of onos-topo, related by contains
+// and neighbors relations, and keeps them up to date as the nodes and cells change, so that the GUI and the RIC
+// components relying on onos-topo see the simulated RAN
+type Exporter struct {
+	client    topoapi.TopoClient
+	entities  map[topoapi.ID]bool                      // exported entities
+	relations map[topoapi.ID]map[topoapi.ID]topoapi.ID // exported relations of each source entity by target entity
+	cancel    context.CancelFunc
+	done      chan struct{}
+}
+
+// NewExporter creates an exporter to the given onos-topo client
+func NewExporter(client topoapi.TopoClient) *Exporter {
+	return &Exporter{
+		client:    client,
+		entities:  make(map[topoapi.ID]bool),
+		relations: make(map[topoapi.ID]map[topoapi.ID]topoapi.ID),
+	}
+}
+
+// Start exports the nodes and cells of the given stores and starts watching their changes; the entities exported
+// by a previous run which are no longer present in the stores, e.g. after a new model is loaded, are removed
+func (e *Exporter) Start(nodeStore nodes.Store, cellStore cells.Store) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.done = make(chan struct{})
+
+	nodeCh := make(chan event.Event)
+	if err := nodeStore.Watch(ctx, nodeCh, nodes.WatchOptions{}); err != nil {
+		cancel()
+		return err
+	}
+	cellCh := make(chan event.Event)
+	if err := cellStore.Watch(ctx, cellCh, cells.WatchOptions{}); err != nil {
+		cancel()
+		return err
+	}
+	nodeList, err := nodeStore.List(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+	cellList, err := cellStore.List(ctx)
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	// Relations are exported once both their source and target entities have been exported
+	stale := e.entities
+	e.entities = make(map[topoapi.ID]bool)
+	for _, node := range nodeList {
+		e.exportNode(ctx, node)
+	}
+	for _, cell := range cellList {
+		e.exportCell(ctx, cell)
+	}
+	for _, node := range nodeList {
+		e.exportContains(ctx, node)
+	}
+	for _, cell := range cellList {
+		e.exportNeighbors(ctx, cell)
+	}
+	for id := range stale {
+		if !e.entities[id] {
+			e.deleteEntity(ctx, id)
+		}
+	}
+	log.Infof("Exported %d nodes and %d cells to onos-topo", len(nodeList), len(cellList))
+
+	go e.watch(ctx, nodeCh, cellCh)
+	return nil
+}
+
+// Stop stops watching the changes of the nodes and cells; the exported entities are left in onos-topo
+func (e *Exporter) Stop() {
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	<-e.done
+	e.cancel = nil
+}
+
+func (e *Exporter) watch(ctx context.Context, nodeCh <-chan event.Event, cellCh <-chan event.Event) {
+	defer close(e.done)
+	for nodeCh != nil || cellCh != nil {
+		select {
+		case nodeEvent, ok := <-nodeCh:
+			if !ok {
+				nodeCh = nil
+				continue
+			}
+			node := nodeEvent.Value.(*model.Node)
+			if nodeEvent.Type == nodes.Deleted {
+				e.deleteNode(ctx, node)
+				continue
+			}
+			e.exportNode(ctx, node)
+			e.exportContains(ctx, node)
+		case cellEvent, ok := <-cellCh:
+			if !ok {
+				cellCh = nil
+				continue
+			}
+			if cellEvent.Type == cells.TopologyChanged {
+				for _, cell := range cellEvent.Value.([]*model.Cell) {
+					e.exportCell(ctx, cell)
+					e.exportNeighbors(ctx, cell)
+				}
+				continue
+			}
+			cell := cellEvent.Value.(*model.Cell)
+			switch cellEvent.Type {
+			case cells.Deleted:
+				e.deleteCell(ctx, cell)
+			case cells.UpdatedNeighbors:
+				// Followed by an update event
+			default:
+				e.exportCell(ctx, cell)
+				e.exportNeighbors(ctx, cell)
+			}
+		}
+	}
+}
+
+func (e *Exporter) exportNode(ctx context.Context, node *model.Node) {
+	object := newEntityObject(NodeID(node.GnbID), topoapi.E2NODE)
+	if err := object.SetAspect(&topoapi.E2Node{}); err != nil {
+		log.Warn(err)
+		return
+	}
+	if err := e.put(ctx, object); err != nil {
+		log.Warnf("Unable to export node %d: %v", node.GnbID, err)
+		return
+	}
+	e.entities[object.ID] = true
+}
+
+func (e *Exporter) exportCell(ctx context.Context, cell *model.Cell) {
+	object := newEntityObject(CellID(cell.NCGI), topoapi.E2CELL)
+	e2Cell := &topoapi.E2Cell{
+		CellObjectID: strconv.FormatUint(uint64(cell.NCGI), 16),
+		CellGlobalID: &topoapi.CellGlobalID{
+			Value: strconv.FormatUint(uint64(cell.NCGI), 16),
+			Type:  topoapi.CellGlobalIDType_NRCGI,
+		},
+		EARFCN:   cell.Earfcn,
+		PCI:      cell.PCI,
+		CellType: cell.CellType.String(),
+	}
+	for _, ncgi := range cell.Neighbors {
+		e2Cell.NeighborCellIDs = append(e2Cell.NeighborCellIDs, &topoapi.NeighborCellID{
+			CellGlobalID: &topoapi.CellGlobalID{
+				Value: strconv.FormatUint(uint64(ncgi), 16),
+				Type:  topoapi.CellGlobalIDType_NRCGI,
+			},
+		})
+	}
+	location := &topoapi.Location{
+		Lat: cell.Sector.Center.Lat,
+		Lng: cell.Sector.Center.Lng,
+	}
+	coverage := &topoapi.Coverage{
+		Height:   cell.Sector.Height,
+		ArcWidth: cell.Sector.Arc,
+		Azimuth:  cell.Sector.Azimuth,
+		Tilt:     cell.Sector.Tilt,
+	}
+	err := object.SetAspect(e2Cell)
+	if err == nil {
+		err = object.SetAspect(location)
+	}
+	if err == nil {
+		err = object.SetAspect(coverage)
+	}
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	if err := e.put(ctx, object); err != nil {
+		log.Warnf("Unable to export cell %d: %v", cell.NCGI, err)
+		return
+	}
+	e.entities[object.ID] = true
+}
+
+// exportContains exports the contains relations of the node to its cells
+func (e *Exporter) exportContains(ctx context.Context, node *model.Node) {
+	targets := make([]topoapi.ID, 0, len(node.Cells))
+	for _, ncgi := range node.Cells {
+		targets = append(targets, CellID(ncgi))
+	}
+	e.exportRelations(ctx, NodeID(node.GnbID), topoapi.CONTAINS, targets)
+}
+
+// exportNeighbors exports the neighbors relations of the cell to its neighbor cells
+func (e *Exporter) exportNeighbors(ctx context.Context, cell *model.Cell) {
+	targets := make([]topoapi.ID, 0, len(cell.Neighbors))
+	for _, ncgi := range cell.Neighbors {
+		targets = append(targets, CellID(ncgi))
+	}
+	e.exportRelations(ctx, CellID(cell.NCGI), topoapi.NEIGHBORS, targets)
+}
+
+// exportRelations exports the relations of the given kind from the source entity to the target entities and deletes
+// the relations previously exported to other entities
+func (e *Exporter) exportRelations(ctx context.Context, source topoapi.ID, kind topoapi.ID, targets []topoapi.ID) {
+	exported := e.relations[source]
+	relations := make(map[topoapi.ID]topoapi.ID, len(targets))
+	for _, target := range targets {
+		id := relationID(kind, source, target)
+		relations[target] = id
+		if _, ok := exported[target]; ok {
+			continue
+		}
+		object := &topoapi.Object{
+			ID:   id,
+			Type: topoapi.Object_RELATION,
+			Obj: &topoapi.Object_Relation{
+				Relation: &topoapi.Relation{
+					KindID:      kind,
+					SrcEntityID: source,
+					TgtEntityID: target,
+				},
+			},
+		}
+		if err := e.put(ctx, object); err != nil {
+			log.Warnf("Unable to export %s relation from %s to %s: %v", kind, source, target, err)
+			delete(relations, target)
+		}
+	}
+	for target, id := range exported {
+		if _, ok := relations[target]; !ok {
+			e.delete(ctx, id)
+		}
+	}
+	if len(relations) == 0 {
+		delete(e.relations, source)
+		return
+	}
+	e.relations[source] = relations
+}
+
+func (e *Exporter) deleteNode(ctx context.Context, node *model.Node) {
+	e.exportRelations(ctx, NodeID(node.GnbID), topoapi.CONTAINS, nil)
+	e.deleteEntity(ctx, NodeID(node.GnbID))
+}
+
+func (e *Exporter) deleteCell(ctx context.Context, cell *model.Cell) {
+	e.exportRelations(ctx, CellID(cell.NCGI), topoapi.NEIGHBORS, nil)
+	e.deleteEntity(ctx, CellID(cell.NCGI))
+}
+
+func (e *Exporter) deleteEntity(ctx context.Context, id topoapi.ID) {
+	for target, relationID := range e.relations[id] {
+		e.delete(ctx, relationID)
+		delete(e.relations[id], target)
+	}
+	delete(e.relations, id)
+	e.delete(ctx, id)
+	delete(e.entities, id)
+}
+
+// put creates the object or updates the existing one
+func (e *Exporter) put(ctx context.Context, object *topoapi.Object) error {
+	response, err := e.client.Get(ctx, &topoapi.GetRequest{ID: object.ID})
+	if err != nil {
+		if !errors.IsNotFound(errors.FromGRPC(err)) {
+			return err
+		}
+		_, err = e.client.Create(ctx, &topoapi.CreateRequest{Object: object})
+		return err
+	}
+	object.Revision = response.Object.Revision
+	_, err = e.client.Update(ctx, &topoapi.UpdateRequest{Object: object})
+	return err
+}
+
+// delete deletes the object if it exists
+func (e *Exporter) delete(ctx context.Context, id topoapi.ID) {
+	_, err := e.client.Delete(ctx, &topoapi.DeleteRequest{ID: id})
+	if err != nil && !errors.IsNotFound(errors.FromGRPC(err)) {
+		log.Warnf("Unable to delete %s: %v", id, err)
+	}
+}
+
+func relationID(kind topoapi.ID, source topoapi.ID, target topoapi.ID) topoapi.ID {
+	return topoapi.ID(fmt.Sprintf("%s/%s/%s", source, kind, target))
+}
+
+func newEntityObject(id topoapi.ID, kind topoapi.ID) *topoapi.Object {
+	return &topoapi.Object{
+		ID:   id,
+		Type: topoapi.Object_ENTITY,
+		Obj:  &topoapi.Object_Entity{Entity: &topoapi.Entity{KindID: kind}},
+	}
+}
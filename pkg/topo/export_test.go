@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package topo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/onosproject/ran-simulator/pkg/store/cells"
+	"github.com/onosproject/ran-simulator/pkg/store/nodes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExporter(t *testing.T) {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	nodeStore := nodes.NewNodeRegistry(m.Nodes)
+	cellStore := cells.NewCellRegistry(m.Cells, nodeStore)
+	ctx := context.Background()
+
+	client := newFakeTopoClient()
+	exporter := NewExporter(client)
+	assert.NoError(t, exporter.Start(nodeStore, cellStore))
+	defer exporter.Stop()
+
+	node, ok := client.get(NodeID(144470))
+	assert.True(t, ok)
+	assert.Equal(t, topoapi.ID(topoapi.E2NODE), node.GetEntity().GetKindID())
+
+	ncgi1, ncgi2 := types.NCGI(84325717505), types.NCGI(84325717506)
+	cell, ok := client.get(CellID(ncgi1))
+	assert.True(t, ok)
+	e2Cell := &topoapi.E2Cell{}
+	assert.NoError(t, cell.GetAspect(e2Cell))
+	assert.Equal(t, "13a2345601", e2Cell.GetCellGlobalID().GetValue())
+	location := &topoapi.Location{}
+	assert.NoError(t, cell.GetAspect(location))
+	assert.Equal(t, 46.0, location.Lat)
+	_, ok = client.get(relationID(topoapi.CONTAINS, NodeID(144470), CellID(ncgi2)))
+	assert.True(t, ok)
+
+	// The imported identities match those of the exported entities
+	imported := &model.Model{ServiceModels: m.ServiceModels}
+	assert.NoError(t, Import(ctx, client, imported))
+	assert.Len(t, imported.Nodes, len(m.Nodes))
+	assert.Len(t, imported.Cells, len(m.Cells))
+	assert.ElementsMatch(t, []types.NCGI{ncgi1, ncgi2}, imported.Nodes["node144470"].Cells)
+
+	updated, err := cellStore.Get(ctx, ncgi1)
+	assert.NoError(t, err)
+	updated.Neighbors = []types.NCGI{ncgi2}
+	updated.Sector.Azimuth = 45
+	assert.NoError(t, cellStore.Update(ctx, updated))
+	assert.Eventually(t, func() bool {
+		_, ok := client.get(relationID(topoapi.NEIGHBORS, CellID(ncgi1), CellID(ncgi2)))
+		return ok
+	}, time.Second, 10*time.Millisecond)
+	cell, _ = client.get(CellID(ncgi1))
+	coverage := &topoapi.Coverage{}
+	assert.NoError(t, cell.GetAspect(coverage))
+	assert.Equal(t, int32(45), coverage.Azimuth)
+
+	_, err = cellStore.Delete(ctx, ncgi2)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		_, cellFound := client.get(CellID(ncgi2))
+		_, relationFound := client.get(relationID(topoapi.CONTAINS, NodeID(144470), CellID(ncgi2)))
+		return !cellFound && !relationFound
+	}, time.Second, 10*time.Millisecond)
+}
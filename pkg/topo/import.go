@@ -0,0 +1,256 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package topo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+)
+
+// Parameters of the imported cells which onos-topo does not hold; same as those of generated models
+const (
+	defaultColor     = "green"
+	defaultMaxUEs    = 99999
+	defaultTxPowerDB = 11
+)
+
+// Import replaces the nodes and cells of the model with the E2 nodes and E2 cells listed by onos-topo. The gNB IDs of
+// the nodes are parsed from their URIs and the NCGIs of the cells from their global cell IDs, so that the simulated
+// nodes and cells keep the identities known to the rest of the platform. The parameters onos-topo does not hold,
+// e.g. the controllers of the nodes or the transmit power of the cells, are kept from the nodes and cells of the
+// model with the same identities, if any; the nodes with no controllers are then assigned using the controller
+// selection policy.
+func Import(ctx context.Context, client topoapi.TopoClient, m *model.Model) error {
+	response, err := client.List(ctx, &topoapi.ListRequest{})
+	if err != nil {
+		return err
+	}
+
+	nodes := make(map[topoapi.ID]*model.Node)
+	cells := make(map[topoapi.ID]*model.Cell)
+	var relations []*topoapi.Relation
+	for i := range response.Objects {
+		object := &response.Objects[i]
+		switch {
+		case object.GetRelation() != nil:
+			relations = append(relations, object.GetRelation())
+		case object.GetEntity().GetKindID() == topoapi.E2NODE:
+			node, err := newNode(object, m)
+			if err != nil {
+				return err
+			}
+			nodes[object.ID] = node
+		case object.GetEntity().GetKindID() == topoapi.E2CELL:
+			cell, err := newCell(object, m)
+			if err != nil {
+				return err
+			}
+			cells[object.ID] = cell
+		}
+	}
+	if len(nodes) == 0 {
+		return errors.NewNotFound("no E2 nodes found in onos-topo")
+	}
+
+	// Cells are assigned to the nodes containing them, or else to the node with their gNB ID
+	contained := make(map[topoapi.ID]bool)
+	for _, relation := range relations {
+		source, target := relation.SrcEntityID, relation.TgtEntityID
+		switch relation.KindID {
+		case topoapi.CONTAINS:
+			node, ok := nodes[source]
+			cell, found := cells[target]
+			if ok && found {
+				node.Cells = appendNCGI(node.Cells, cell.NCGI)
+				contained[target] = true
+			}
+		case topoapi.NEIGHBORS:
+			cell, ok := cells[source]
+			neighbor, found := cells[target]
+			if ok && found {
+				cell.Neighbors = appendNCGI(cell.Neighbors, neighbor.NCGI)
+			}
+		}
+	}
+	for id, cell := range cells {
+		if contained[id] {
+			continue
+		}
+		for _, node := range nodes {
+			if node.GnbID == types.GetGnbID(uint64(cell.NCGI)) {
+				node.Cells = appendNCGI(node.Cells, cell.NCGI)
+			}
+		}
+	}
+
+	m.Nodes = make(map[string]model.Node, len(nodes))
+	for _, node := range nodes {
+		m.Nodes[fmt.Sprintf("node%d", node.GnbID)] = *node
+	}
+	m.Cells = make(map[string]model.Cell, len(cells))
+	for _, cell := range cells {
+		offsets := make(map[types.NCGI]int32, len(cell.Neighbors))
+		for _, ncgi := range cell.Neighbors {
+			offsets[ncgi] = cell.MeasurementParams.NCellIndividualOffsets[ncgi]
+		}
+		cell.MeasurementParams.NCellIndividualOffsets = offsets
+		m.Cells[fmt.Sprintf("cell%d", cell.NCGI)] = *cell
+	}
+	log.Infof("Imported %d nodes and %d cells from onos-topo", len(m.Nodes), len(m.Cells))
+	return m.AssignControllers()
+}
+
+// newNode creates the node of an E2 node entity
+func newNode(object *topoapi.Object, m *model.Model) (*model.Node, error) {
+	gnbID, err := parseNodeID(object.ID)
+	if err != nil {
+		return nil, err
+	}
+	node := model.Node{}
+	for _, n := range m.Nodes {
+		if n.GnbID == gnbID {
+			node = n
+			break
+		}
+	}
+	node.GnbID = gnbID
+	node.Cells = nil
+	if len(node.ServiceModels) == 0 {
+		node.ServiceModels = serviceModels(object, m)
+	}
+	return &node, nil
+}
+
+// serviceModels returns the service models of the model implementing the RAN functions of the E2 node aspect of the
+// entity, or all service models of the model if the aspect lists none
+func serviceModels(object *topoapi.Object, m *model.Model) []string {
+	ranFunctionIDs := make(map[int]bool)
+	e2Node := &topoapi.E2Node{}
+	if err := object.GetAspect(e2Node); err == nil {
+		for _, sm := range e2Node.ServiceModels {
+			for _, id := range sm.RanFunctionIDs {
+				ranFunctionIDs[int(id)] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(m.ServiceModels))
+	for name, sm := range m.ServiceModels {
+		if len(ranFunctionIDs) == 0 || ranFunctionIDs[sm.ID] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newCell creates the cell of an E2 cell entity from its E2 cell, location and coverage aspects
+func newCell(object *topoapi.Object, m *model.Model) (*model.Cell, error) {
+	e2Cell := &topoapi.E2Cell{}
+	_ = object.GetAspect(e2Cell)
+	ncgi, err := parseCellID(object.ID, e2Cell.GetCellGlobalID(), m.PlmnID)
+	if err != nil {
+		return nil, err
+	}
+	cell := model.Cell{
+		Color:     defaultColor,
+		MaxUEs:    defaultMaxUEs,
+		TxPowerDB: defaultTxPowerDB,
+	}
+	for _, c := range m.Cells {
+		if c.NCGI == ncgi {
+			cell = c
+			break
+		}
+	}
+	cell.NCGI = ncgi
+	cell.Neighbors = nil
+
+	if e2Cell.EARFCN != 0 {
+		cell.Earfcn = e2Cell.EARFCN
+	}
+	if e2Cell.PCI != 0 {
+		cell.PCI = e2Cell.PCI
+	}
+	if cellType, ok := types.CellType_value[e2Cell.CellType]; ok {
+		cell.CellType = types.CellType(cellType)
+	}
+	for _, neighbor := range e2Cell.NeighborCellIDs {
+		neighborNCGI, err := strconv.ParseUint(neighbor.GetCellGlobalID().GetValue(), 16, 64)
+		if err != nil {
+			log.Warnf("Ignoring invalid neighbor %s of cell %s", neighbor.GetCellGlobalID().GetValue(), object.ID)
+			continue
+		}
+		cell.Neighbors = appendNCGI(cell.Neighbors, types.NCGI(neighborNCGI))
+	}
+
+	location := &topoapi.Location{}
+	if err := object.GetAspect(location); err == nil {
+		cell.Sector.Center = model.Coordinate{Lat: location.Lat, Lng: location.Lng}
+	}
+	coverage := &topoapi.Coverage{}
+	if err := object.GetAspect(coverage); err == nil {
+		cell.Sector.Arc = coverage.ArcWidth
+		cell.Sector.Azimuth = coverage.Azimuth
+		cell.Sector.Tilt = coverage.Tilt
+		cell.Sector.Height = coverage.Height
+	}
+	return &cell, nil
+}
+
+// parseNodeID parses the gNB ID making up the last element of the URI of an E2 node
+func parseNodeID(id topoapi.ID) (types.GnbID, error) {
+	elements := uriElements(id)
+	gnbID, err := strconv.ParseUint(elements[len(elements)-1], 16, 64)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid E2 node ID %s", id)
+	}
+	return types.GnbID(gnbID), nil
+}
+
+// parseCellID parses the NCGI of an E2 cell from its global cell ID in hexadecimal, or else from the gNB ID and cell
+// ID making up the last two elements of its URI
+func parseCellID(id topoapi.ID, globalID *topoapi.CellGlobalID, plmnID types.PlmnID) (types.NCGI, error) {
+	if globalID.GetValue() != "" {
+		ncgi, err := strconv.ParseUint(globalID.GetValue(), 16, 64)
+		if err != nil {
+			return 0, errors.NewInvalid("invalid global ID %s of E2 cell %s", globalID.GetValue(), id)
+		}
+		return types.NCGI(ncgi), nil
+	}
+	elements := uriElements(id)
+	if len(elements) < 2 {
+		return 0, errors.NewInvalid("invalid E2 cell ID %s", id)
+	}
+	gnbID, err := strconv.ParseUint(elements[len(elements)-2], 16, 64)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid E2 cell ID %s", id)
+	}
+	cellID, err := strconv.ParseUint(elements[len(elements)-1], 16, 8)
+	if err != nil {
+		return 0, errors.NewInvalid("invalid E2 cell ID %s", id)
+	}
+	return types.ToNCGI(plmnID, types.ToNCI(types.GnbID(gnbID), types.CellID(cellID))), nil
+}
+
+func uriElements(id topoapi.ID) []string {
+	return strings.Split(strings.TrimPrefix(string(id), e2Scheme), "/")
+}
+
+func appendNCGI(ncgis []types.NCGI, ncgi types.NCGI) []types.NCGI {
+	for _, n := range ncgis {
+		if n == ncgi {
+			return ncgis
+		}
+	}
+	return append(ncgis, ncgi)
+}
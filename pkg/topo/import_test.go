@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package topo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// fakeTopoClient keeps the objects in memory
+type fakeTopoClient struct {
+	topoapi.TopoClient
+	mu      sync.Mutex
+	objects map[topoapi.ID]topoapi.Object
+}
+
+func newFakeTopoClient(objects ...topoapi.Object) *fakeTopoClient {
+	client := &fakeTopoClient{
+		objects: make(map[topoapi.ID]topoapi.Object),
+	}
+	for _, object := range objects {
+		client.objects[object.ID] = object
+	}
+	return client
+}
+
+func (c *fakeTopoClient) List(ctx context.Context, request *topoapi.ListRequest, opts ...grpc.CallOption) (*topoapi.ListResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objects := make([]topoapi.Object, 0, len(c.objects))
+	for _, object := range c.objects {
+		objects = append(objects, object)
+	}
+	return &topoapi.ListResponse{Objects: objects}, nil
+}
+
+func (c *fakeTopoClient) Get(ctx context.Context, request *topoapi.GetRequest, opts ...grpc.CallOption) (*topoapi.GetResponse, error) {
+	object, ok := c.get(request.ID)
+	if !ok {
+		return nil, errors.Status(errors.NewNotFound("%s not found", request.ID)).Err()
+	}
+	return &topoapi.GetResponse{Object: &object}, nil
+}
+
+func (c *fakeTopoClient) Create(ctx context.Context, request *topoapi.CreateRequest, opts ...grpc.CallOption) (*topoapi.CreateResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[request.Object.ID] = *request.Object
+	return &topoapi.CreateResponse{Object: request.Object}, nil
+}
+
+func (c *fakeTopoClient) Update(ctx context.Context, request *topoapi.UpdateRequest, opts ...grpc.CallOption) (*topoapi.UpdateResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[request.Object.ID] = *request.Object
+	return &topoapi.UpdateResponse{Object: request.Object}, nil
+}
+
+func (c *fakeTopoClient) Delete(ctx context.Context, request *topoapi.DeleteRequest, opts ...grpc.CallOption) (*topoapi.DeleteResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.objects[request.ID]; !ok {
+		return nil, errors.Status(errors.NewNotFound("%s not found", request.ID)).Err()
+	}
+	delete(c.objects, request.ID)
+	return &topoapi.DeleteResponse{}, nil
+}
+
+func (c *fakeTopoClient) get(id topoapi.ID) (topoapi.Object, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, ok := c.objects[id]
+	return object, ok
+}
+
+func newEntity(id topoapi.ID, kind topoapi.ID) topoapi.Object {
+	return topoapi.Object{
+		ID:   id,
+		Type: topoapi.Object_ENTITY,
+		Obj:  &topoapi.Object_Entity{Entity: &topoapi.Entity{KindID: kind}},
+	}
+}
+
+func newRelation(kind topoapi.ID, source topoapi.ID, target topoapi.ID) topoapi.Object {
+	return topoapi.Object{
+		ID:   source + "-" + target,
+		Type: topoapi.Object_RELATION,
+		Obj:  &topoapi.Object_Relation{Relation: &topoapi.Relation{KindID: kind, SrcEntityID: source, TgtEntityID: target}},
+	}
+}
+
+func TestImport(t *testing.T) {
+	m := &model.Model{}
+	assert.NoError(t, model.LoadConfig(m, "../model/test"))
+	ncgi1 := types.ToNCGI(m.PlmnID, types.ToNCI(0x5153, 1))
+	ncgi2 := types.ToNCGI(m.PlmnID, types.ToNCI(0x5153, 2))
+
+	node := newEntity("e2:1/5153", topoapi.E2NODE)
+	assert.NoError(t, node.SetAspect(&topoapi.E2Node{
+		ServiceModels: map[string]*topoapi.ServiceModelInfo{
+			"1.3.6.1.4.1.53148.1.2.2.2": {Name: "ORAN-E2SM-KPM", RanFunctionIDs: []uint32{1}},
+		},
+	}))
+	cell := newEntity("e2:1/5153/1", topoapi.E2CELL)
+	assert.NoError(t, cell.SetAspect(&topoapi.E2Cell{
+		CellGlobalID: &topoapi.CellGlobalID{Value: strconv.FormatUint(uint64(ncgi1), 16), Type: topoapi.CellGlobalIDType_NRCGI},
+		EARFCN:       42,
+		PCI:          7,
+		CellType:     "MACRO",
+	}))
+	assert.NoError(t, cell.SetAspect(&topoapi.Location{Lat: 52.5, Lng: 13.4}))
+	assert.NoError(t, cell.SetAspect(&topoapi.Coverage{ArcWidth: 120, Azimuth: 90, Tilt: 5, Height: 30}))
+	client := newFakeTopoClient(
+		node,
+		cell,
+		// Cell with no global cell ID and no containing node
+		newEntity("e2:1/5153/2", topoapi.E2CELL),
+		newRelation(topoapi.CONTAINS, "e2:1/5153", "e2:1/5153/1"),
+		newRelation(topoapi.NEIGHBORS, "e2:1/5153/1", "e2:1/5153/2"))
+
+	assert.NoError(t, Import(context.Background(), client, m))
+	assert.Len(t, m.Nodes, 1)
+	assert.Len(t, m.Cells, 2)
+
+	n, ok := m.Nodes["node20819"]
+	assert.True(t, ok)
+	assert.Equal(t, types.GnbID(0x5153), n.GnbID)
+	assert.ElementsMatch(t, []types.NCGI{ncgi1, ncgi2}, n.Cells)
+	assert.Equal(t, []string{"kpm"}, n.ServiceModels)
+	assert.Len(t, n.Controllers, 1)
+
+	c, ok := m.Cells[fmt.Sprintf("cell%d", ncgi1)]
+	assert.True(t, ok)
+	assert.Equal(t, uint32(42), c.Earfcn)
+	assert.Equal(t, uint32(7), c.PCI)
+	assert.Equal(t, types.CellType_MACRO, c.CellType)
+	assert.Equal(t, model.Coordinate{Lat: 52.5, Lng: 13.4}, c.Sector.Center)
+	assert.Equal(t, int32(120), c.Sector.Arc)
+	assert.Equal(t, int32(90), c.Sector.Azimuth)
+	assert.Equal(t, []types.NCGI{ncgi2}, c.Neighbors)
+	assert.Contains(t, c.MeasurementParams.NCellIndividualOffsets, ncgi2)
+	assert.Equal(t, float64(defaultTxPowerDB), c.TxPowerDB)
+}
+
+func TestImportWithoutNodes(t *testing.T) {
+	err := Import(context.Background(), newFakeTopoClient(), &model.Model{})
+	assert.Error(t, err)
+}
+
+func TestParseIDs(t *testing.T) {
+	gnbID, err := parseNodeID("e2:1/5153")
+	assert.NoError(t, err)
+	assert.Equal(t, types.GnbID(0x5153), gnbID)
+	_, err = parseNodeID("e2:1/node")
+	assert.Error(t, err)
+
+	ncgi, err := parseCellID("e2:1/5153/2", nil, 314628)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ToNCGI(314628, types.ToNCI(0x5153, 2)), ncgi)
+	ncgi, err = parseCellID("e2:1/5153/2", &topoapi.CellGlobalID{Value: "13842601454c001"}, 314628)
+	assert.NoError(t, err)
+	assert.Equal(t, types.NCGI(0x13842601454c001), ncgi)
+	_, err = parseCellID("e2:1", nil, 314628)
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package topo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	topoapi "github.com/onosproject/onos-api/go/onos/topo"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/onos-ric-sdk-go/pkg/e2/creds"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+var log = logging.GetLogger("topo")
+
+// e2Scheme scheme of the URIs of the E2 nodes and cells, e.g. e2:1/5153 and e2:1/5153/1
+const e2Scheme = "e2:"
+
+// Connect opens a gRPC connection to the onos-topo service at the given address using the default client
+// credentials of the platform
+func Connect(ctx context.Context, address string) (*grpc.ClientConn, error) {
+	tlsConfig, err := creds.GetClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+	return grpc.DialContext(ctx, address, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+}
+
+// NodeID returns the ID of the E2 node entity of a node; the gNB ID can be parsed back by Import
+func NodeID(gnbID types.GnbID) topoapi.ID {
+	return topoapi.ID(fmt.Sprintf("%s1/%x", e2Scheme, gnbID))
+}
+
+// CellID returns the ID of the E2 cell entity of a cell
+func CellID(ncgi types.NCGI) topoapi.ID {
+	return topoapi.ID(fmt.Sprintf("%s1/%x/%x", e2Scheme, types.GetGnbID(uint64(ncgi)), types.GetCellID(uint64(ncgi))))
+}
@@ -0,0 +1,252 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	tracesPath    = "/v1/traces"
+	queueSize     = 2048
+	batchSize     = 512
+	flushInterval = 5 * time.Second
+	scopeName     = "github.com/onosproject/ran-simulator/pkg/tracing"
+)
+
+// exporter exports the ended spans in batches to the OTLP/HTTP endpoint of a collector using the JSON encoding;
+// spans are dropped rather than slowing down the simulation if the collector cannot keep up
+type exporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	spans       chan *spanData
+	done        chan struct{}
+	mu          sync.RWMutex
+	stopped     bool
+}
+
+type spanData struct {
+	span *Span
+	end  time.Time
+}
+
+func newExporter(endpoint string, serviceName string) *exporter {
+	e := &exporter{
+		url:         tracesURL(endpoint),
+		serviceName: serviceName,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		spans:       make(chan *spanData, queueSize),
+		done:        make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// tracesURL returns the URL of the traces of the given endpoint, adding the default OTLP path if it has none
+func tracesURL(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		u = &url.URL{Scheme: "http", Host: endpoint}
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = tracesPath
+	}
+	return u.String()
+}
+
+func (e *exporter) export(span *Span, end time.Time) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.stopped {
+		return
+	}
+	select {
+	case e.spans <- &spanData{span: span, end: end}:
+	default:
+		log.Debugf("Dropping span %s: export queue is full", span.name)
+	}
+}
+
+// stop exports the queued spans and stops the exporter
+func (e *exporter) stop() {
+	e.mu.Lock()
+	if e.stopped {
+		e.mu.Unlock()
+		return
+	}
+	e.stopped = true
+	close(e.spans)
+	e.mu.Unlock()
+	<-e.done
+}
+
+func (e *exporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []*spanData
+	for {
+		select {
+		case span, ok := <-e.spans:
+			if !ok {
+				e.flush(batch)
+				return
+			}
+			batch = append(batch, span)
+			if len(batch) >= batchSize {
+				e.flush(batch)
+				batch = nil
+			}
+		case <-ticker.C:
+			e.flush(batch)
+			batch = nil
+		}
+	}
+}
+
+func (e *exporter) flush(batch []*spanData) {
+	if len(batch) == 0 {
+		return
+	}
+	body, err := json.Marshal(e.request(batch))
+	if err != nil {
+		log.Warn(err)
+		return
+	}
+	resp, err := e.client.Post(e.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		log.Warnf("Failed to export %d spans: %s", len(batch), err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		log.Warnf("Failed to export %d spans: %s", len(batch), errors.NewUnavailable(resp.Status))
+	}
+}
+
+// OTLP/JSON request, see https://github.com/open-telemetry/opentelemetry-proto
+type exportRequest struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []keyValue `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+type span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []keyValue `json:"attributes,omitempty"`
+	Status            *status    `json:"status,omitempty"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+// statusCodeError OTLP status code of the failed spans
+const statusCodeError = 2
+
+type keyValue struct {
+	Key   string   `json:"key"`
+	Value anyValue `json:"value"`
+}
+
+type anyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+func (e *exporter) request(batch []*spanData) *exportRequest {
+	spans := make([]span, 0, len(batch))
+	for _, data := range batch {
+		spans = append(spans, newSpan(data))
+	}
+	return &exportRequest{
+		ResourceSpans: []resourceSpans{{
+			Resource: resource{
+				Attributes: []keyValue{newKeyValue(String("service.name", e.serviceName))},
+			},
+			ScopeSpans: []scopeSpans{{
+				Scope: scope{Name: scopeName},
+				Spans: spans,
+			}},
+		}},
+	}
+}
+
+func newSpan(data *spanData) span {
+	s := data.span
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := span{
+		TraceID:           hex.EncodeToString(s.context.TraceID[:]),
+		SpanID:            hex.EncodeToString(s.context.SpanID[:]),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(data.end.UnixNano(), 10),
+	}
+	if s.parentID != [8]byte{} {
+		result.ParentSpanID = hex.EncodeToString(s.parentID[:])
+	}
+	for _, attribute := range s.attributes {
+		result.Attributes = append(result.Attributes, newKeyValue(attribute))
+	}
+	if s.err != nil {
+		result.Status = &status{Code: statusCodeError, Message: s.err.Error()}
+	}
+	return result
+}
+
+func newKeyValue(attribute Attribute) keyValue {
+	kv := keyValue{Key: attribute.Key}
+	switch v := attribute.Value.(type) {
+	case string:
+		kv.Value.StringValue = &v
+	case int64:
+		i := strconv.FormatInt(v, 10)
+		kv.Value.IntValue = &i
+	case bool:
+		kv.Value.BoolValue = &v
+	case float64:
+		kv.Value.DoubleValue = &v
+	}
+	return kv
+}
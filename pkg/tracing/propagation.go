@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Headers of the W3C trace context, see https://www.w3.org/TR/trace-context/
+const (
+	TraceparentHeader = "traceparent"
+	TracestateHeader  = "tracestate"
+)
+
+const (
+	traceparentVersion = "00"
+	traceparentLength  = 55
+	flagSampled        = 0x01
+)
+
+// Carrier carries the headers of a trace context, e.g. an http.Header
+type Carrier interface {
+	Get(key string) string
+	Set(key string, value string)
+}
+
+// FormatTraceparent returns the traceparent header identifying the given span context
+func FormatTraceparent(spanContext SpanContext) string {
+	var flags byte
+	if spanContext.Sampled {
+		flags |= flagSampled
+	}
+	return fmt.Sprintf("%s-%s-%s-%02x", traceparentVersion, hex.EncodeToString(spanContext.TraceID[:]),
+		hex.EncodeToString(spanContext.SpanID[:]), flags)
+}
+
+// ParseTraceparent returns the span context identified by the given traceparent header; the fields appended by later
+// versions of the header are ignored
+func ParseTraceparent(traceparent string) (SpanContext, error) {
+	var spanContext SpanContext
+	if len(traceparent) < traceparentLength {
+		return spanContext, errors.NewInvalid("invalid traceparent %s", traceparent)
+	}
+	version := traceparent[:2]
+	if version == "ff" || !isLowerHex(version) ||
+		(version == traceparentVersion && len(traceparent) != traceparentLength) ||
+		(len(traceparent) > traceparentLength && traceparent[traceparentLength] != '-') {
+		return spanContext, errors.NewInvalid("invalid traceparent %s", traceparent)
+	}
+	fields := strings.Split(traceparent[:traceparentLength], "-")
+	if len(fields) != 4 || len(fields[1]) != 32 || len(fields[2]) != 16 || len(fields[3]) != 2 ||
+		!isLowerHex(fields[1]) || !isLowerHex(fields[2]) || !isLowerHex(fields[3]) {
+		return spanContext, errors.NewInvalid("invalid traceparent %s", traceparent)
+	}
+	_, _ = hex.Decode(spanContext.TraceID[:], []byte(fields[1]))
+	_, _ = hex.Decode(spanContext.SpanID[:], []byte(fields[2]))
+	var flags [1]byte
+	_, _ = hex.Decode(flags[:], []byte(fields[3]))
+	spanContext.Sampled = flags[0]&flagSampled != 0
+	if !spanContext.IsValid() {
+		return spanContext, errors.NewInvalid("invalid traceparent %s", traceparent)
+	}
+	return spanContext, nil
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Inject sets the trace context headers of the carrier to the span context of the given context, if any
+func Inject(ctx context.Context, carrier Carrier) {
+	spanContext := SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return
+	}
+	carrier.Set(TraceparentHeader, FormatTraceparent(spanContext))
+	if spanContext.TraceState != "" {
+		carrier.Set(TracestateHeader, spanContext.TraceState)
+	}
+}
+
+// Extract returns a context whose spans are children of the remote span identified by the trace context headers of
+// the carrier; the given context is returned as is if the carrier has no valid trace context
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	traceparent := carrier.Get(TraceparentHeader)
+	if traceparent == "" {
+		return ctx
+	}
+	spanContext, err := ParseTraceparent(traceparent)
+	if err != nil {
+		log.Debug(err)
+		return ctx
+	}
+	spanContext.TraceState = carrier.Get(TracestateHeader)
+	return ContextWithSpanContext(ctx, spanContext)
+}
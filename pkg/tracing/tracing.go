@@ -0,0 +1,204 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing traces the E2 procedures and northbound requests of the simulator and exports the spans to an
+// OpenTelemetry collector over OTLP/HTTP; the trace context of the northbound requests is propagated with the W3C
+// traceparent and tracestate headers
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+)
+
+var log = logging.GetLogger("tracing")
+
+// SpanKind kind of a span, as numbered by OTLP
+type SpanKind int
+
+const (
+	// SpanKindInternal an operation internal to the simulator
+	SpanKindInternal SpanKind = 1
+	// SpanKindServer the handling of a request received from the RIC
+	SpanKindServer SpanKind = 2
+	// SpanKindClient a request sent to the RIC
+	SpanKindClient SpanKind = 3
+	// SpanKindProducer a message sent to the RIC without response, such as an indication
+	SpanKindProducer SpanKind = 4
+)
+
+// Attribute key and value of an attribute of a span
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Int returns an integer attribute
+func Int(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// String returns a string attribute
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Bool returns a boolean attribute
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// SpanContext identifies a span within its trace
+type SpanContext struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	// Sampled whether the span is recorded; the children of spans which are not are not recorded either
+	Sampled bool
+	// TraceState vendor specific trace state received along with a remote span context, propagated as is
+	TraceState string
+}
+
+// IsValid returns true if the span context identifies a span
+func (c SpanContext) IsValid() bool {
+	return c.TraceID != [16]byte{} && c.SpanID != [8]byte{}
+}
+
+// TraceIDString returns the hexadecimal trace ID, as displayed by the tracing backends
+func (c SpanContext) TraceIDString() string {
+	return hex.EncodeToString(c.TraceID[:])
+}
+
+// Span a traced operation; all methods of a nil span, returned while tracing is disabled, are no-ops
+type Span struct {
+	tracer     *Tracer
+	context    SpanContext
+	parentID   [8]byte
+	name       string
+	kind       SpanKind
+	start      time.Time
+	mu         sync.Mutex
+	attributes []Attribute
+	err        error
+}
+
+// Context returns the span context identifying the span
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.context
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// End ends the span and queues it for export; the span status is an error if an error is given
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+	s.tracer.exporter.export(s, time.Now())
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpanContext returns a context whose spans are children of the given span
+func ContextWithSpanContext(ctx context.Context, spanContext SpanContext) context.Context {
+	return context.WithValue(ctx, spanContextKey{}, spanContext)
+}
+
+// SpanContextFromContext returns the context of the parent span of the spans started with the given context, if any
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	spanContext, _ := ctx.Value(spanContextKey{}).(SpanContext)
+	return spanContext
+}
+
+// Tracer starts spans and exports them
+type Tracer struct {
+	exporter *exporter
+}
+
+var (
+	mu     sync.RWMutex
+	tracer *Tracer
+)
+
+// Configure enables tracing, exporting the spans to the OTLP/HTTP endpoint of a collector, e.g.
+// http://otel-collector:4318; the spans are attributed to the given service
+func Configure(endpoint string, serviceName string) {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracer != nil {
+		tracer.exporter.stop()
+	}
+	log.Infof("Exporting traces to %s", endpoint)
+	tracer = &Tracer{
+		exporter: newExporter(endpoint, serviceName),
+	}
+}
+
+// Shutdown exports the pending spans and disables tracing
+func Shutdown() {
+	mu.Lock()
+	defer mu.Unlock()
+	if tracer != nil {
+		tracer.exporter.stop()
+		tracer = nil
+	}
+}
+
+// Enabled returns true if tracing is configured
+func Enabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return tracer != nil
+}
+
+// Start starts a span, child of the span of the given context if any, and returns a context for its children along
+// with the span; the span is nil if tracing is disabled or the parent span is not sampled
+func Start(ctx context.Context, name string, kind SpanKind, attributes ...Attribute) (context.Context, *Span) {
+	mu.RLock()
+	t := tracer
+	mu.RUnlock()
+	if t == nil {
+		return ctx, nil
+	}
+	parent := SpanContextFromContext(ctx)
+	if parent.IsValid() && !parent.Sampled {
+		return ctx, nil
+	}
+
+	span := &Span{
+		tracer:     t,
+		name:       name,
+		kind:       kind,
+		start:      time.Now(),
+		attributes: attributes,
+	}
+	span.context.Sampled = true
+	if parent.IsValid() {
+		span.context.TraceID = parent.TraceID
+		span.context.TraceState = parent.TraceState
+		span.parentID = parent.SpanID
+	} else {
+		_, _ = rand.Read(span.context.TraceID[:])
+	}
+	_, _ = rand.Read(span.context.SpanID[:])
+	return ContextWithSpanContext(ctx, span.context), span
+}
@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestTracesURL(t *testing.T) {
+	assert.Equal(t, "http://collector:4318/v1/traces", tracesURL("collector:4318"))
+	assert.Equal(t, "https://collector:4318/v1/traces", tracesURL("https://collector:4318"))
+	assert.Equal(t, "http://collector:4318/traces", tracesURL("http://collector:4318/traces"))
+}
+
+func TestDisabled(t *testing.T) {
+	assert.False(t, Enabled())
+	ctx, span := Start(context.Background(), "test", SpanKindInternal)
+	assert.Nil(t, span)
+	assert.False(t, SpanContextFromContext(ctx).IsValid())
+	span.SetAttributes(Int("count", 1))
+	span.End(nil)
+}
+
+func TestExport(t *testing.T) {
+	requests := make(chan *exportRequest, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tracesPath, r.URL.Path)
+		request := &exportRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(request))
+		requests <- request
+	}))
+	defer server.Close()
+
+	Configure(server.URL, "ran-simulator")
+	assert.True(t, Enabled())
+
+	ctx, parent := Start(context.Background(), "parent", SpanKindServer, String("node", "5153"))
+	_, child := Start(ctx, "child", SpanKindProducer)
+	child.SetAttributes(Int("sn", 7), Bool("ok", false))
+	child.End(errors.New("failed"))
+	parent.End(nil)
+	Shutdown()
+	assert.False(t, Enabled())
+
+	request := <-requests
+	assert.Len(t, request.ResourceSpans, 1)
+	assert.Equal(t, "ran-simulator", *request.ResourceSpans[0].Resource.Attributes[0].Value.StringValue)
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	assert.Len(t, spans, 2)
+
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, SpanKindProducer, spans[0].Kind)
+	assert.Equal(t, "7", *spans[0].Attributes[0].Value.IntValue)
+	assert.False(t, *spans[0].Attributes[1].Value.BoolValue)
+	assert.Equal(t, statusCodeError, spans[0].Status.Code)
+
+	assert.Equal(t, "parent", spans[1].Name)
+	assert.Equal(t, parent.Context().TraceIDString(), spans[1].TraceID)
+	assert.Empty(t, spans[1].ParentSpanID)
+	assert.Nil(t, spans[1].Status)
+
+	// The child belongs to the trace of the parent
+	assert.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+}
+
+func TestTraceparent(t *testing.T) {
+	// Example of the W3C trace context specification
+	spanContext, err := ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	assert.NoError(t, err)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceIDString())
+	assert.True(t, spanContext.Sampled)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", FormatTraceparent(spanContext))
+
+	spanContext, err = ParseTraceparent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	assert.NoError(t, err)
+	assert.False(t, spanContext.Sampled)
+
+	// Later versions may append fields
+	_, err = ParseTraceparent("01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra")
+	assert.NoError(t, err)
+
+	for _, traceparent := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7-01",
+	} {
+		_, err = ParseTraceparent(traceparent)
+		assert.Error(t, err, traceparent)
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	Configure(server.URL, "ran-simulator")
+	defer Shutdown()
+
+	// The spans started with an extracted context are children of the remote span
+	header := http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	header.Set(TracestateHeader, "vendor=value")
+	ctx, span := Start(Extract(context.Background(), header), "request", SpanKindServer)
+	assert.NotNil(t, span)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.Context().TraceIDString())
+	assert.Equal(t, "00f067aa0ba902b7", hex.EncodeToString(span.parentID[:]))
+	span.End(nil)
+
+	// The context of the span is injected in turn
+	header = http.Header{}
+	Inject(ctx, header)
+	spanContext, err := ParseTraceparent(header.Get(TraceparentHeader))
+	assert.NoError(t, err)
+	assert.Equal(t, span.Context().SpanID, spanContext.SpanID)
+	assert.Equal(t, "vendor=value", header.Get(TracestateHeader))
+
+	// The children of remote spans which are not sampled are not recorded, but the context is still propagated
+	header = http.Header{}
+	header.Set(TraceparentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	ctx, span = Start(Extract(context.Background(), header), "request", SpanKindServer)
+	assert.Nil(t, span)
+	header = http.Header{}
+	Inject(ctx, header)
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", header.Get(TraceparentHeader))
+
+	// Invalid trace contexts are ignored
+	header = http.Header{}
+	header.Set(TraceparentHeader, "invalid")
+	assert.False(t, SpanContextFromContext(Extract(context.Background(), header)).IsValid())
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	Configure(server.URL, "ran-simulator")
+	defer Shutdown()
+
+	// The context of the client is propagated through the metadata of the request
+	ctx := ContextWithSpanContext(context.Background(), SpanContext{TraceID: [16]byte{1}, SpanID: [8]byte{2}, Sampled: true})
+	var md metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	assert.NoError(t, UnaryClientInterceptor()(ctx, "/onos.ransim.model.NodeModel/GetNode", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"00-01000000000000000000000000000000-0200000000000000-01"}, md.Get(TraceparentHeader))
+
+	var spanContext SpanContext
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		spanContext = SpanContextFromContext(ctx)
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/onos.ransim.model.NodeModel/GetNode"}
+	_, err := UnaryServerInterceptor()(metadata.NewIncomingContext(context.Background(), md), nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, [16]byte{1}, spanContext.TraceID)
+	assert.NotEqual(t, [8]byte{2}, spanContext.SpanID)
+}
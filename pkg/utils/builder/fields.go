@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"strings"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// Fields tracks the fields provided to an E2AP or E2SM message builder through its options, so that the builder can
+// tell a mandatory field which was not provided apart from one provided with its zero value
+type Fields map[string]bool
+
+// Provide records that the given field was provided
+func (f *Fields) Provide(name string) {
+	if *f == nil {
+		*f = make(Fields)
+	}
+	(*f)[name] = true
+}
+
+// IsProvided returns true if the given field was provided
+func (f Fields) IsProvided(name string) bool {
+	return f[name]
+}
+
+// Require returns an invalid error listing those of the given mandatory fields which were not provided
+func (f Fields) Require(message string, names ...string) error {
+	var missing []string
+	for _, name := range names {
+		if !f[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.NewInvalid("%s is missing mandatory fields: %s", message, strings.Join(missing, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields(t *testing.T) {
+	var fields Fields
+	assert.False(t, fields.IsProvided("RequestID"))
+	err := fields.Require("RICindication", "RequestID", "RanFuncID")
+	assert.True(t, errors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "RequestID, RanFuncID")
+
+	fields.Provide("RequestID")
+	assert.True(t, fields.IsProvided("RequestID"))
+	err = fields.Require("RICindication", "RequestID", "RanFuncID")
+	assert.True(t, errors.IsInvalid(err))
+	assert.NotContains(t, err.Error(), "RequestID")
+
+	fields.Provide("RanFuncID")
+	assert.NoError(t, fields.Require("RICindication", "RequestID", "RanFuncID"))
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cacert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// NewClientTLSConfig creates the TLS configuration used to connect to the northbound gRPC server of a simulator
+// instance. The server certificate is verified against the CA at the given path, or against the default ONF CA the
+// default server certificates are issued by if no path is given. The host name is not verified since the default
+// server certificates carry it in their common name only, which is no longer honored for verification.
+func NewClientTLSConfig(caPath string) (*tls.Config, error) {
+	var roots *x509.CertPool
+	var err error
+	if caPath == "" {
+		roots, err = certs.GetCertPoolDefault()
+	} else {
+		roots, err = certs.GetCertPool(caPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		// Disables the default verification, which includes the host name; the chain is verified by VerifyConnection
+		InsecureSkipVerify: true,
+		VerifyConnection: func(state tls.ConnectionState) error {
+			return verifyChain(state, roots)
+		},
+	}, nil
+}
+
+// NewMutualTLSConfig creates the TLS configuration used to connect to the internal gRPC server through which another
+// simulator instance admits the UEs handed over to it, which requires a client certificate. The certificate at the
+// given paths is presented, or the default client certificate issued by the default ONF CA if no paths are given; the
+// server certificate is verified as by NewClientTLSConfig.
+func NewMutualTLSConfig(caPath string, keyPath string, certPath string) (*tls.Config, error) {
+	config, err := NewClientTLSConfig(caPath)
+	if err != nil {
+		return nil, err
+	}
+	var cert tls.Certificate
+	if keyPath == "" && certPath == "" {
+		cert, err = tls.X509KeyPair([]byte(certs.DefaultClientCrt), []byte(certs.DefaultClientKey))
+	} else {
+		cert, err = tls.LoadX509KeyPair(certPath, keyPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	config.Certificates = []tls.Certificate{cert}
+	return config, nil
+}
+
+// NewServerTLSConfig creates the TLS configuration the REST gateway of a simulator instance is served with. The
+// certificate at the given paths is presented, or the default localhost server certificate issued by the default ONF
+// CA if no paths are given, which the clients created by NewClientTLSConfig verify.
+func NewServerTLSConfig(keyPath string, certPath string) (*tls.Config, error) {
+	var cert tls.Certificate
+	var err error
+	if keyPath == "" && certPath == "" {
+		cert, err = tls.X509KeyPair([]byte(certs.DefaultLocalhostCrt), []byte(certs.DefaultLocalhostKey))
+	} else {
+		cert, err = tls.LoadX509KeyPair(certPath, keyPath)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{Certificates: []tls.Certificate{cert}}, nil
+}
+
+// verifyChain verifies the certificate chain presented by the server against the given CAs
+func verifyChain(state tls.ConnectionState, roots *x509.CertPool) error {
+	if len(state.PeerCertificates) == 0 {
+		return errors.NewUnauthorized("no server certificate presented")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
@@ -0,0 +1,56 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cacert
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/onosproject/onos-lib-go/pkg/certs"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyChain(t *testing.T) {
+	block, _ := pem.Decode([]byte(certs.DefaultLocalhostCrt))
+	cert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	state := tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}
+
+	roots, err := certs.GetCertPoolDefault()
+	assert.NoError(t, err)
+	assert.NoError(t, verifyChain(state, roots))
+
+	assert.Error(t, verifyChain(state, x509.NewCertPool()))
+	assert.Error(t, verifyChain(tls.ConnectionState{}, roots))
+}
+
+func TestNewClientTLSConfig(t *testing.T) {
+	config, err := NewClientTLSConfig("")
+	assert.NoError(t, err)
+	assert.NotNil(t, config.VerifyConnection)
+
+	_, err = NewClientTLSConfig("/nonexistent/ca.crt")
+	assert.Error(t, err)
+}
+
+func TestNewMutualTLSConfig(t *testing.T) {
+	config, err := NewMutualTLSConfig("", "", "")
+	assert.NoError(t, err)
+	assert.Len(t, config.Certificates, 1)
+
+	_, err = NewMutualTLSConfig("", "/nonexistent/tls.key", "/nonexistent/tls.crt")
+	assert.Error(t, err)
+}
+
+func TestNewServerTLSConfig(t *testing.T) {
+	config, err := NewServerTLSConfig("", "")
+	assert.NoError(t, err)
+	assert.Len(t, config.Certificates, 1)
+
+	_, err = NewServerTLSConfig("/nonexistent/tls.key", "/nonexistent/tls.crt")
+	assert.Error(t, err)
+}
@@ -6,19 +6,20 @@ package configupdate
 
 import (
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
-	"github.com/onosproject/ran-simulator/pkg/utils"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2ap/setup"
 
-	e2apcommondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
-	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
-	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
 )
 
 // ConfigurationUpdate configuration update procedure data structure
 type ConfigurationUpdate struct {
-	transactionID int32
-	plmnID        ransimtypes.Uint24
-	e2NodeID      uint64
+	transactionID  int32
+	plmnID         ransimtypes.Uint24
+	e2NodeID       uint64
+	e2NodeType     string
+	e2NodeIDLength uint32
+	fields         builder.Fields
 }
 
 // NewConfigurationUpdate creates a new instance of configuration update
@@ -34,6 +35,7 @@ func NewConfigurationUpdate(options ...func(update *ConfigurationUpdate)) *Confi
 // WithTransactionID sets transaction ID
 func WithTransactionID(transID int32) func(update *ConfigurationUpdate) {
 	return func(configUpdate *ConfigurationUpdate) {
+		configUpdate.fields.Provide("TransactionID")
 		configUpdate.transactionID = transID
 	}
 }
@@ -41,37 +43,47 @@ func WithTransactionID(transID int32) func(update *ConfigurationUpdate) {
 // WithE2NodeID sets E2 node ID
 func WithE2NodeID(e2NodeID uint64) func(update *ConfigurationUpdate) {
 	return func(configUpdate *ConfigurationUpdate) {
+		configUpdate.fields.Provide("E2NodeID")
 		configUpdate.e2NodeID = e2NodeID
 	}
 }
 
+// WithE2NodeType sets the type of the E2 node; the E2 node is a gNB by default
+func WithE2NodeType(e2NodeType string) func(update *ConfigurationUpdate) {
+	return func(configUpdate *ConfigurationUpdate) {
+		configUpdate.e2NodeType = e2NodeType
+	}
+}
+
+// WithE2NodeIDLength sets the length in bits of the E2 node ID; it defaults to the length for the type of the E2 node
+func WithE2NodeIDLength(length uint32) func(update *ConfigurationUpdate) {
+	return func(configUpdate *ConfigurationUpdate) {
+		configUpdate.e2NodeIDLength = length
+	}
+}
+
 // WithPlmnID sets plmnID
 func WithPlmnID(plmnID ransimtypes.Uint24) func(update *ConfigurationUpdate) {
 	return func(configUpdate *ConfigurationUpdate) {
+		configUpdate.fields.Provide("PlmnID")
 		configUpdate.plmnID = plmnID
 
 	}
 }
 
+// Validate checks that the mandatory fields of the configuration update were provided
+func (c *ConfigurationUpdate) Validate() error {
+	return c.fields.Require("E2nodeConfigurationUpdate", "TransactionID", "PlmnID", "E2NodeID")
+}
+
 // Build builds a configuration update request
 func (c *ConfigurationUpdate) Build() (*e2appducontents.E2NodeConfigurationUpdate, error) {
-	gE2NodeID := &e2apies.GlobalE2NodeId{
-		GlobalE2NodeId: &e2apies.GlobalE2NodeId_GNb{
-			GNb: &e2apies.GlobalE2NodeGnbId{
-				GlobalGNbId: &e2apies.GlobalgNbId{
-					PlmnId: &e2apcommondatatypes.PlmnIdentity{
-						Value: c.plmnID.ToBytes(),
-					},
-					GnbId: &e2apies.GnbIdChoice{
-						GnbIdChoice: &e2apies.GnbIdChoice_GnbId{
-							GnbId: &asn1.BitString{
-								Value: utils.Uint64ToBitString(c.e2NodeID, 28),
-								Len:   28,
-							}},
-					},
-				},
-			},
-		},
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	gE2NodeID, err := setup.NewGlobalE2NodeID(c.e2NodeType, c.plmnID, c.e2NodeID, c.e2NodeIDLength)
+	if err != nil {
+		return nil, err
 	}
 
 	configUpdate := &e2appducontents.E2NodeConfigurationUpdate{
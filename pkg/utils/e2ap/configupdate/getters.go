@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package configupdate
+
+import (
+	"fmt"
+
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+)
+
+// GetTransactionID gets the transaction ID of the E2 node configuration update acknowledge
+func GetTransactionID(ack *e2appducontents.E2NodeConfigurationUpdateAcknowledge) (int32, error) {
+	for _, v := range ack.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDTransactionID) {
+			return v.GetValue().GetTrId().GetValue(), nil
+		}
+	}
+	return 0, fmt.Errorf("TransactionID was not found")
+}
+
+// GetFailureTransactionID gets the transaction ID of the E2 node configuration update failure
+func GetFailureTransactionID(failure *e2appducontents.E2NodeConfigurationUpdateFailure) (int32, error) {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDTransactionID) {
+			return v.GetValue().GetTrId().GetValue(), nil
+		}
+	}
+	return 0, fmt.Errorf("TransactionID was not found")
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package control
+
+import (
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// NewCause returns the cause of the control failure for the given error; invalid errors, e.g. a control header or
+// message which is missing or does not decode per the service model, are reported as an invalid control message
+// and the other ones as unspecified
+func NewCause(err error) *e2apies.Cause {
+	cause := e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED
+	if errors.IsInvalid(err) {
+		cause = e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID
+	}
+	return &e2apies.Cause{
+		Cause: &e2apies.Cause_RicRequest{
+			RicRequest: cause,
+		},
+	}
+}
@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package control
+
+import (
+	"testing"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCause(t *testing.T) {
+	assert.Equal(t, e2apies.CauseRicrequest_CAUSE_RICREQUEST_CONTROL_MESSAGE_INVALID,
+		NewCause(errors.NewInvalid("bad control message")).GetRicRequest())
+	assert.Equal(t, e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
+		NewCause(errors.NewInternal("failed")).GetRicRequest())
+}
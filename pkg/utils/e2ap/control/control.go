@@ -8,6 +8,7 @@ import (
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
 )
 
 // Control defines required fields for creating control acknowledge and failure responses
@@ -19,6 +20,7 @@ type Control struct {
 	ricCtrlStatus e2apies.RiccontrolStatus
 	ricCtrlOut    []byte
 	cause         *e2apies.Cause
+	fields        builder.Fields
 }
 
 // NewControl creates a new instance of control
@@ -50,6 +52,7 @@ func (control *Control) GetReqID() int32 {
 // WithRequestID sets request ID
 func WithRequestID(reqID int32) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("RequestID")
 		control.reqID = reqID
 	}
 }
@@ -57,6 +60,7 @@ func WithRequestID(reqID int32) func(control *Control) {
 // WithRanFuncID sets ran function ID
 func WithRanFuncID(ranFuncID int32) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("RanFuncID")
 		control.ranFuncID = ranFuncID
 	}
 }
@@ -64,6 +68,7 @@ func WithRanFuncID(ranFuncID int32) func(control *Control) {
 // WithRicCallProcessID sets ric call process ID
 func WithRicCallProcessID(ricCallPrID types.RicCallProcessID) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("RicCallProcessID")
 		control.ricCallPrID = ricCallPrID
 	}
 }
@@ -71,6 +76,7 @@ func WithRicCallProcessID(ricCallPrID types.RicCallProcessID) func(control *Cont
 // WithRicInstanceID sets ric instance ID
 func WithRicInstanceID(ricInstanceID int32) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("RicInstanceID")
 		control.ricInstanceID = ricInstanceID
 	}
 }
@@ -78,6 +84,7 @@ func WithRicInstanceID(ricInstanceID int32) func(control *Control) {
 // WithCause sets failure cause
 func WithCause(cause *e2apies.Cause) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("Cause")
 		control.cause = cause
 	}
 }
@@ -85,6 +92,7 @@ func WithCause(cause *e2apies.Cause) func(control *Control) {
 // WithRicControlStatus sets ric control status
 func WithRicControlStatus(ricCtrlStatus e2apies.RiccontrolStatus) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("RicControlStatus")
 		control.ricCtrlStatus = ricCtrlStatus
 	}
 }
@@ -92,12 +100,21 @@ func WithRicControlStatus(ricCtrlStatus e2apies.RiccontrolStatus) func(control *
 // WithRicControlOutcome sets ric control outcome
 func WithRicControlOutcome(ricCtrlOut []byte) func(control *Control) {
 	return func(control *Control) {
+		control.fields.Provide("RicControlOutcome")
 		control.ricCtrlOut = ricCtrlOut
 	}
 }
 
+// Validate checks that the fields shared by the control acknowledge and failure were provided
+func (control *Control) Validate() error {
+	return control.fields.Require("RICcontrol", "RequestID", "RanFuncID", "RicInstanceID")
+}
+
 // BuildControlAcknowledge builds e2ap control acknowledge message
 func (control *Control) BuildControlAcknowledge() (response *e2appducontents.RiccontrolAcknowledge, err error) {
+	if err := control.Validate(); err != nil {
+		return nil, err
+	}
 
 	response = &e2appducontents.RiccontrolAcknowledge{
 		ProtocolIes: make([]*e2appducontents.RiccontrolAcknowledgeIes, 0),
@@ -121,6 +138,12 @@ func (control *Control) BuildControlAcknowledge() (response *e2appducontents.Ric
 
 // BuildControlFailure builds e2ap control failure message
 func (control *Control) BuildControlFailure() (response *e2appducontents.RiccontrolFailure, err error) {
+	if err := control.Validate(); err != nil {
+		return nil, err
+	}
+	if err := control.fields.Require("RICcontrolFailure", "Cause"); err != nil {
+		return nil, err
+	}
 	response = &e2appducontents.RiccontrolFailure{
 		ProtocolIes: make([]*e2appducontents.RiccontrolFailureIes, 0),
 	}
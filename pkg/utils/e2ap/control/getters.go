@@ -7,6 +7,7 @@ package control
 import (
 	"fmt"
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 )
 
@@ -60,3 +61,43 @@ func GetRicInstanceID(request *e2appducontents.RiccontrolRequest) (*int32, error
 
 	return &res, nil
 }
+
+// GetRicControlHeader gets the ASN.1 encoded ric control header
+func GetRicControlHeader(request *e2appducontents.RiccontrolRequest) []byte {
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolHeader) {
+			return v.GetValue().GetRch().GetValue()
+		}
+	}
+	return nil
+}
+
+// GetRicControlMessage gets the ASN.1 encoded ric control message
+func GetRicControlMessage(request *e2appducontents.RiccontrolRequest) []byte {
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccontrolMessage) {
+			return v.GetValue().GetRcm().GetValue()
+		}
+	}
+	return nil
+}
+
+// GetRicCallProcessID gets the ric call process ID; nil is returned if the optional IE is absent
+func GetRicCallProcessID(request *e2appducontents.RiccontrolRequest) []byte {
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRiccallProcessID) {
+			return v.GetValue().GetRcpId().GetValue()
+		}
+	}
+	return nil
+}
+
+// GetFailureCause gets the cause of a control failure
+func GetFailureCause(failure *e2appducontents.RiccontrolFailure) (*e2apies.Cause, error) {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDCause) {
+			return v.GetValue().GetC(), nil
+		}
+	}
+	return nil, fmt.Errorf("Cause was not found")
+}
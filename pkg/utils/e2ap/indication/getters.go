@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indication
+
+import (
+	"fmt"
+
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+)
+
+// GetRicActionID gets the RIC action ID
+func GetRicActionID(indication *e2appducontents.Ricindication) (*int32, error) {
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicactionID) {
+			res := v.GetValue().GetRaId().GetValue()
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("RicActionID was not found")
+}
+
+// GetRicIndicationSN gets the RIC indication sequence number
+func GetRicIndicationSN(indication *e2appducontents.Ricindication) (*int32, error) {
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicindicationSn) {
+			res := v.GetValue().GetRiSn().GetValue()
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("RicIndicationSN was not found")
+}
+
+// GetRicIndicationType gets the RIC indication type
+func GetRicIndicationType(indication *e2appducontents.Ricindication) (*e2apies.RicindicationType, error) {
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicindicationType) {
+			res := v.GetValue().GetRit()
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("RicIndicationType was not found")
+}
+
+// GetRequesterID gets the RIC requester ID
+func GetRequesterID(indication *e2appducontents.Ricindication) (*int32, error) {
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicrequestID) {
+			res := v.GetValue().GetRrId().GetRicRequestorId()
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("RicRequestID was not found")
+}
+
+// GetRicInstanceID gets the RIC instance ID
+func GetRicInstanceID(indication *e2appducontents.Ricindication) (*int32, error) {
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicrequestID) {
+			res := v.GetValue().GetRrId().GetRicInstanceId()
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("RicInstanceID was not found")
+}
+
+// GetRanFunctionID gets the RAN function ID
+func GetRanFunctionID(indication *e2appducontents.Ricindication) (*int32, error) {
+	for _, v := range indication.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRanfunctionID) {
+			res := v.GetValue().GetRfId().GetValue()
+			return &res, nil
+		}
+	}
+	return nil, fmt.Errorf("RanFunctionID was not found")
+}
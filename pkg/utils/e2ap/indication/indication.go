@@ -8,6 +8,7 @@ import (
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
 )
 
 // Indication indication data struct
@@ -18,12 +19,25 @@ type Indication struct {
 	indicationHeader  []byte
 	indicationMessage []byte
 	ricCallProcessID  []byte
-	// TODO add ric action ID and ric indication sn
+	ricActionID       int32
+	ricIndicationSN   int32
+	ricIndicationType e2apies.RicindicationType
+	fields            builder.Fields
 }
 
-// NewIndication creates a new indication
+// Defaults of the IEs that may be omitted when creating an indication
+const (
+	DefaultRicActionID     = 2
+	DefaultRicIndicationSN = 3
+)
+
+// NewIndication creates a new report indication
 func NewIndication(options ...func(*Indication)) *Indication {
-	indication := &Indication{}
+	indication := &Indication{
+		ricActionID:       DefaultRicActionID,
+		ricIndicationSN:   DefaultRicIndicationSN,
+		ricIndicationType: e2apies.RicindicationType_RICINDICATION_TYPE_REPORT,
+	}
 
 	for _, option := range options {
 		option(indication)
@@ -36,6 +50,7 @@ func NewIndication(options ...func(*Indication)) *Indication {
 // WithRequestID sets request ID
 func WithRequestID(reqID int32) func(*Indication) {
 	return func(indication *Indication) {
+		indication.fields.Provide("RequestID")
 		indication.reqID = reqID
 	}
 }
@@ -43,6 +58,7 @@ func WithRequestID(reqID int32) func(*Indication) {
 // WithRanFuncID sets ran function ID
 func WithRanFuncID(ranFuncID int32) func(*Indication) {
 	return func(indication *Indication) {
+		indication.fields.Provide("RanFuncID")
 		indication.ranFuncID = ranFuncID
 	}
 }
@@ -50,6 +66,7 @@ func WithRanFuncID(ranFuncID int32) func(*Indication) {
 // WithRicInstanceID sets ric instance ID
 func WithRicInstanceID(ricInstanceID int32) func(*Indication) {
 	return func(indication *Indication) {
+		indication.fields.Provide("RicInstanceID")
 		indication.ricInstanceID = ricInstanceID
 	}
 }
@@ -57,6 +74,7 @@ func WithRicInstanceID(ricInstanceID int32) func(*Indication) {
 // WithIndicationHeader sets indication header
 func WithIndicationHeader(indicationHeader []byte) func(*Indication) {
 	return func(indication *Indication) {
+		indication.fields.Provide("IndicationHeader")
 		indication.indicationHeader = indicationHeader
 	}
 }
@@ -64,12 +82,54 @@ func WithIndicationHeader(indicationHeader []byte) func(*Indication) {
 // WithIndicationMessage sets indication message
 func WithIndicationMessage(indicationMessage []byte) func(*Indication) {
 	return func(indication *Indication) {
+		indication.fields.Provide("IndicationMessage")
 		indication.indicationMessage = indicationMessage
 	}
 }
 
+// WithRicActionID sets the ID of the subscription action the indication reports on
+func WithRicActionID(ricActionID int32) func(*Indication) {
+	return func(indication *Indication) {
+		indication.fields.Provide("RicActionID")
+		indication.ricActionID = ricActionID
+	}
+}
+
+// WithIndicationSN sets the indication sequence number
+func WithIndicationSN(ricIndicationSN int32) func(*Indication) {
+	return func(indication *Indication) {
+		indication.fields.Provide("IndicationSN")
+		indication.ricIndicationSN = ricIndicationSN
+	}
+}
+
+// WithIndicationType sets the indication type
+func WithIndicationType(ricIndicationType e2apies.RicindicationType) func(*Indication) {
+	return func(indication *Indication) {
+		indication.fields.Provide("IndicationType")
+		indication.ricIndicationType = ricIndicationType
+	}
+}
+
+// WithCallProcessID sets the call process ID
+func WithCallProcessID(ricCallProcessID []byte) func(*Indication) {
+	return func(indication *Indication) {
+		indication.fields.Provide("CallProcessID")
+		indication.ricCallProcessID = ricCallProcessID
+	}
+}
+
+// Validate checks that the mandatory fields of the indication were provided
+func (indication *Indication) Validate() error {
+	return indication.fields.Require("RICindication", "RequestID", "RanFuncID", "RicInstanceID",
+		"IndicationHeader", "IndicationMessage")
+}
+
 // Build builds e2ap indication message
 func (indication *Indication) Build() (e2Indication *e2appducontents.Ricindication, err error) {
+	if err := indication.Validate(); err != nil {
+		return nil, err
+	}
 	rrID := types.RicRequest{
 		RequestorID: types.RicRequestorID(indication.reqID),
 		InstanceID:  types.RicInstanceID(indication.ricInstanceID),
@@ -78,8 +138,8 @@ func (indication *Indication) Build() (e2Indication *e2appducontents.Ricindicati
 		ProtocolIes: make([]*e2appducontents.RicindicationIes, 0),
 	}
 	ricIndication.SetRicRequestID(rrID).SetRanFunctionID(types.RanFunctionID(indication.ranFuncID)).
-		SetRicActionID(2).
-		SetRicIndicationSN(3).SetRicIndicationType(e2apies.RicindicationType_RICINDICATION_TYPE_REPORT).
+		SetRicActionID(indication.ricActionID).
+		SetRicIndicationSN(types.RicIndicationSn(indication.ricIndicationSN)).SetRicIndicationType(indication.ricIndicationType).
 		SetRicIndicationHeader(indication.indicationHeader).SetRicIndicationMessage(indication.indicationMessage).
 		SetRicCallProcessID(indication.ricCallProcessID)
 
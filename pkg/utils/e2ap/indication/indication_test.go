@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indication
+
+import (
+	"testing"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndication(t *testing.T) {
+	ricIndication, err := NewIndication(
+		WithRicInstanceID(1),
+		WithRanFuncID(2),
+		WithRequestID(3),
+		WithIndicationHeader([]byte{0x01}),
+		WithIndicationMessage([]byte{0x02})).Build()
+	assert.NoError(t, err)
+	actionID, err := GetRicActionID(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(DefaultRicActionID), *actionID)
+	sn, err := GetRicIndicationSN(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(DefaultRicIndicationSN), *sn)
+	indicationType, err := GetRicIndicationType(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, e2apies.RicindicationType_RICINDICATION_TYPE_REPORT, *indicationType)
+	requesterID, err := GetRequesterID(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), *requesterID)
+	instanceID, err := GetRicInstanceID(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), *instanceID)
+	ranFuncID, err := GetRanFunctionID(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), *ranFuncID)
+
+	ricIndication, err = NewIndication(
+		WithRicInstanceID(1),
+		WithRanFuncID(2),
+		WithRequestID(3),
+		WithRicActionID(7),
+		WithIndicationSN(42),
+		WithIndicationType(e2apies.RicindicationType_RICINDICATION_TYPE_INSERT),
+		WithCallProcessID([]byte{0x04}),
+		WithIndicationHeader([]byte{0x01}),
+		WithIndicationMessage([]byte{0x02})).Build()
+	assert.NoError(t, err)
+	actionID, err = GetRicActionID(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), *actionID)
+	sn, err = GetRicIndicationSN(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(42), *sn)
+	indicationType, err = GetRicIndicationType(ricIndication)
+	assert.NoError(t, err)
+	assert.Equal(t, e2apies.RicindicationType_RICINDICATION_TYPE_INSERT, *indicationType)
+}
+
+func TestIndicationMissingFields(t *testing.T) {
+	indication := NewIndication(
+		WithRicInstanceID(1),
+		WithRanFuncID(2),
+		WithIndicationHeader([]byte{0x01}))
+	err := indication.Validate()
+	assert.True(t, errors.IsInvalid(err))
+	assert.Contains(t, err.Error(), "RequestID, IndicationMessage")
+
+	ricIndication, err := indication.Build()
+	assert.True(t, errors.IsInvalid(err))
+	assert.Nil(t, ricIndication)
+}
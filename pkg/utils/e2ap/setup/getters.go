@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package setup
+
+import (
+	"fmt"
+
+	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+)
+
+// GetGlobalRicID gets the global RIC ID of the responding RIC
+func GetGlobalRicID(response *e2appducontents.E2SetupResponse) (*e2apies.GlobalRicId, error) {
+	for _, v := range response.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDGlobalRicID) {
+			return v.GetValue().GetGRicId(), nil
+		}
+	}
+	return nil, fmt.Errorf("GlobalRicID was not found")
+}
+
+// GetRanFunctionsAccepted gets the RAN functions accepted by the RIC
+func GetRanFunctionsAccepted(response *e2appducontents.E2SetupResponse) []*e2appducontents.RanfunctionIdItemIes {
+	var res []*e2appducontents.RanfunctionIdItemIes
+	for _, v := range response.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRanfunctionsAccepted) {
+			res = v.GetValue().GetRfIdl().GetValue()
+			break
+		}
+	}
+	return res
+}
+
+// GetRanFunctionsRejected gets the RAN functions rejected by the RIC along with the causes of the rejections
+func GetRanFunctionsRejected(response *e2appducontents.E2SetupResponse) []*e2appducontents.RanfunctionIdcauseItemIes {
+	var res []*e2appducontents.RanfunctionIdcauseItemIes
+	for _, v := range response.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRanfunctionsRejected) {
+			res = v.GetValue().GetRfIdcl().GetValue()
+			break
+		}
+	}
+	return res
+}
+
+// GetFailureCause gets the cause of an E2 setup failure
+func GetFailureCause(failure *e2appducontents.E2SetupFailure) (*e2apies.Cause, error) {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDCause) {
+			return v.GetValue().GetC(), nil
+		}
+	}
+	return nil, fmt.Errorf("Cause was not found")
+}
+
+// GetTransactionID gets the transaction ID of the E2 setup response
+func GetTransactionID(response *e2appducontents.E2SetupResponse) (int32, error) {
+	for _, v := range response.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDTransactionID) {
+			return v.GetValue().GetTrId().GetValue(), nil
+		}
+	}
+	return 0, fmt.Errorf("TransactionID was not found")
+}
+
+// GetFailureTransactionID gets the transaction ID of the E2 setup failure
+func GetFailureTransactionID(failure *e2appducontents.E2SetupFailure) (int32, error) {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDTransactionID) {
+			return v.GetValue().GetTrId().GetValue(), nil
+		}
+	}
+	return 0, fmt.Errorf("TransactionID was not found")
+}
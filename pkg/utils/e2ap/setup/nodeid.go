@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package setup
+
+import (
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdubuilder"
+	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+// E2 node types of the global E2 node ID
+const (
+	GNB   = "gnb"
+	EnGNB = "en-gnb"
+	NgENB = "ng-enb"
+	ENB   = "enb"
+)
+
+// Lengths in bits of the gNB IDs and of the choices of eNB IDs; 3GPP TS 38.423 and TS 36.423
+const (
+	minGnbIDLength        = 22
+	maxGnbIDLength        = 32
+	defaultGnbIDLength    = 28
+	macroEnbIDLength      = 20
+	homeEnbIDLength       = 28
+	shortMacroEnbIDLength = 18
+	longMacroEnbIDLength  = 21
+)
+
+// NewGlobalE2NodeID builds the global E2 node ID of the given node type from the BCD encoded PLMN ID and the ID of the
+// node, encoded as a bit string of the given length. gNBs and en-gNBs have 22 to 32 bit IDs, 28 bits by default. The
+// length of the ID of eNBs selects the macro (20 bits, the default), home (28 bits), short macro (18 bits) or long
+// macro (21 bits) eNB ID; ng-eNBs have no home eNB ID.
+func NewGlobalE2NodeID(nodeType string, plmnID ransimtypes.Uint24, nodeID uint64, length uint32) (*e2apies.GlobalE2NodeId, error) {
+	if nodeType == "" {
+		nodeType = GNB
+	}
+	if length == 0 {
+		length = defaultGnbIDLength
+		if nodeType == ENB || nodeType == NgENB {
+			length = macroEnbIDLength
+		}
+	}
+	if length > maxGnbIDLength || nodeID>>length != 0 {
+		return nil, errors.NewInvalid("node ID %d does not fit in %d bits", nodeID, length)
+	}
+	id := NewBitString(nodeID, length)
+	plmn := types.PlmnID(plmnID)
+
+	switch nodeType {
+	case GNB, EnGNB:
+		if length < minGnbIDLength {
+			return nil, errors.NewInvalid("invalid length %d of the ID of %s %d", length, nodeType, nodeID)
+		}
+		if nodeType == EnGNB {
+			return pdubuilder.CreateGlobalE2nodeIDEnGnb(plmn, id)
+		}
+		return pdubuilder.CreateGlobalE2nodeIDGnb(plmn, id)
+	case ENB:
+		var enbID *e2apies.EnbId
+		var err error
+		switch length {
+		case macroEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDMacro(id)
+		case homeEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDHome(id)
+		case shortMacroEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDShortMacro(id)
+		case longMacroEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDLongMacro(id)
+		default:
+			return nil, errors.NewInvalid("invalid length %d of the ID of %s %d", length, nodeType, nodeID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return pdubuilder.CreateGlobalE2nodeIDEnb(plmn, enbID)
+	case NgENB:
+		var enbID *e2apies.EnbIdChoice
+		var err error
+		switch length {
+		case macroEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDChoiceMacro(id)
+		case shortMacroEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDChoiceShortMacro(id)
+		case longMacroEnbIDLength:
+			enbID, err = pdubuilder.CreateEnbIDChoiceLongMacro(id)
+		default:
+			return nil, errors.NewInvalid("invalid length %d of the ID of %s %d", length, nodeType, nodeID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return pdubuilder.CreateGlobalE2nodeIDNgEnb(plmn, enbID)
+	}
+	return nil, errors.NewInvalid("unknown E2 node type %s", nodeType)
+}
+
+// NewBitString encodes the value as a bit string of the given length; the bits are aligned on the most significant
+// bit of the first byte and the unused trailing bits of the last byte are zero, as in aligned PER
+func NewBitString(value uint64, length uint32) *asn1.BitString {
+	numBytes := (length + 7) / 8
+	value <<= numBytes*8 - length
+	bytes := make([]byte, numBytes)
+	for i := range bytes {
+		bytes[i] = byte(value >> ((numBytes - uint32(i) - 1) * 8))
+	}
+	return &asn1.BitString{
+		Value: bytes,
+		Len:   length,
+	}
+}
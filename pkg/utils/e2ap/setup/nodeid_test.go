@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package setup
+
+import (
+	"testing"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBitString(t *testing.T) {
+	bs := NewBitString(0x3FFFFF, 22)
+	assert.Equal(t, uint32(22), bs.GetLen())
+	assert.Equal(t, []byte{0xFF, 0xFF, 0xFC}, bs.GetValue())
+
+	bs = NewBitString(0x12345678, 32)
+	assert.Equal(t, []byte{0x12, 0x34, 0x56, 0x78}, bs.GetValue())
+
+	bs = NewBitString(0x1, 28)
+	assert.Equal(t, []byte{0x00, 0x00, 0x00, 0x10}, bs.GetValue())
+}
+
+func TestNewGlobalE2NodeID(t *testing.T) {
+	plmnID := ransimtypes.NewUint24(0x13F184)
+
+	id, err := NewGlobalE2NodeID("", plmnID.Value(), 144470, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(28), id.GetGNb().GetGlobalGNbId().GetGnbId().GetGnbId().GetLen())
+	assert.Equal(t, plmnID.ToBytes(), id.GetGNb().GetGlobalGNbId().GetPlmnId().GetValue())
+
+	id, err = NewGlobalE2NodeID(GNB, plmnID.Value(), 144470, 22)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(22), id.GetGNb().GetGlobalGNbId().GetGnbId().GetGnbId().GetLen())
+
+	_, err = NewGlobalE2NodeID(GNB, plmnID.Value(), 144470, 16)
+	assert.Error(t, err)
+	_, err = NewGlobalE2NodeID(GNB, plmnID.Value(), 1<<22, 22)
+	assert.Error(t, err)
+	_, err = NewGlobalE2NodeID(NgENB, plmnID.Value(), 1, homeEnbIDLength)
+	assert.Error(t, err)
+	_, err = NewGlobalE2NodeID("nodeb", plmnID.Value(), 1, 0)
+	assert.Error(t, err)
+}
@@ -7,15 +7,12 @@ package setup
 import (
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	"github.com/onosproject/onos-e2t/api/e2ap/v2"
-	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/pdubuilder"
-	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
-	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
-	"github.com/onosproject/ran-simulator/pkg/utils"
 
 	e2ap_commondatatypes "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-commondatatypes"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	e2aptypes "github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
 )
 
 var log = logging.GetLogger("servicemodel", "utils", "setup")
@@ -25,8 +22,11 @@ type Setup struct {
 	ranFunctions                e2aptypes.RanFunctions
 	plmnID                      ransimtypes.Uint24
 	e2NodeID                    uint64
+	e2NodeType                  string
+	e2NodeIDLength              uint32
 	componentConfigAdditionList *e2appducontents.E2NodeComponentConfigAdditionList
 	transactionID               int32
+	fields                      builder.Fields
 }
 
 // NewSetupRequest creates a new setup request
@@ -43,6 +43,7 @@ func NewSetupRequest(options ...func(*Setup)) *Setup {
 // WithRanFunctions sets ran functions
 func WithRanFunctions(ranFunctions e2aptypes.RanFunctions) func(*Setup) {
 	return func(request *Setup) {
+		request.fields.Provide("RanFunctions")
 		request.ranFunctions = ranFunctions
 	}
 }
@@ -50,6 +51,7 @@ func WithRanFunctions(ranFunctions e2aptypes.RanFunctions) func(*Setup) {
 // WithPlmnID sets plmnID
 func WithPlmnID(plmnID ransimtypes.Uint24) func(*Setup) {
 	return func(request *Setup) {
+		request.fields.Provide("PlmnID")
 		request.plmnID = plmnID
 
 	}
@@ -58,13 +60,29 @@ func WithPlmnID(plmnID ransimtypes.Uint24) func(*Setup) {
 // WithE2NodeID sets E2 node ID
 func WithE2NodeID(e2NodeID uint64) func(*Setup) {
 	return func(request *Setup) {
+		request.fields.Provide("E2NodeID")
 		request.e2NodeID = e2NodeID
 	}
 }
 
+// WithE2NodeType sets the type of the E2 node; gnb, en-gnb, ng-enb or enb. The E2 node is a gNB by default.
+func WithE2NodeType(e2NodeType string) func(*Setup) {
+	return func(request *Setup) {
+		request.e2NodeType = e2NodeType
+	}
+}
+
+// WithE2NodeIDLength sets the length in bits of the E2 node ID; it defaults to the length for the type of the E2 node
+func WithE2NodeIDLength(length uint32) func(*Setup) {
+	return func(request *Setup) {
+		request.e2NodeIDLength = length
+	}
+}
+
 // WithComponentConfigUpdateList sets E2 node component config update list
 func WithComponentConfigUpdateList(componentConfigAdditionList *e2appducontents.E2NodeComponentConfigAdditionList) func(setup *Setup) {
 	return func(request *Setup) {
+		request.fields.Provide("ComponentConfigUpdateList")
 		request.componentConfigAdditionList = componentConfigAdditionList
 	}
 }
@@ -72,17 +90,23 @@ func WithComponentConfigUpdateList(componentConfigAdditionList *e2appducontents.
 // WithTransactionID sets transaction ID
 func WithTransactionID(transID int32) func(setup *Setup) {
 	return func(request *Setup) {
+		request.fields.Provide("TransactionID")
 		request.transactionID = transID
 	}
 }
 
+// Validate checks that the mandatory fields of the setup request were provided
+func (request *Setup) Validate() error {
+	return request.fields.Require("E2setupRequest", "TransactionID", "PlmnID", "E2NodeID",
+		"RanFunctions", "ComponentConfigUpdateList")
+}
+
 // Build builds e2ap setup request
 func (request *Setup) Build() (setupRequest *e2appducontents.E2SetupRequest, err error) {
-	//plmnID := types.NewUint24(request.plmnID)
-	ge2nID, err := pdubuilder.CreateGlobalE2nodeIDGnb(types.PlmnID(request.plmnID), &asn1.BitString{
-		Value: utils.Uint64ToBitString(request.e2NodeID, 28),
-		Len:   28,
-	})
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+	ge2nID, err := NewGlobalE2NodeID(request.e2NodeType, request.plmnID, request.e2NodeID, request.e2NodeIDLength)
 	if err != nil {
 		return nil, err
 	}
@@ -7,6 +7,7 @@ package subscription
 import (
 	"fmt"
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 )
 
@@ -73,3 +74,26 @@ func GetRicActionToBeSetupList(request *e2appducontents.RicsubscriptionRequest)
 
 	return res
 }
+
+// GetRicEventTriggerDefinition gets the encoded ric event trigger definition
+func GetRicEventTriggerDefinition(request *e2appducontents.RicsubscriptionRequest) []byte {
+	var res []byte
+	for _, v := range request.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDRicsubscriptionDetails) {
+			res = v.GetValue().GetRsd().GetRicEventTriggerDefinition().GetValue()
+			break
+		}
+	}
+
+	return res
+}
+
+// GetFailureCause gets the cause of a subscription failure
+func GetFailureCause(failure *e2appducontents.RicsubscriptionFailure) (*e2apies.Cause, error) {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDCause) {
+			return v.GetValue().GetC(), nil
+		}
+	}
+	return nil, fmt.Errorf("Cause was not found")
+}
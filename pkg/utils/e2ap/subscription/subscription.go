@@ -8,6 +8,7 @@ import (
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
 )
 
 // Subscription defines required fields for creating subscription response and failures
@@ -18,6 +19,7 @@ type Subscription struct {
 	ricActionsAccepted    []*types.RicActionID
 	ricActionsNotAdmitted map[types.RicActionID]*e2apies.Cause
 	cause                 *e2apies.Cause
+	fields                builder.Fields
 }
 
 // NewSubscription creates a new instance of subscription
@@ -50,6 +52,7 @@ func (subscription *Subscription) GetReqID() int32 {
 // WithRequestID sets request ID
 func WithRequestID(reqID int32) func(*Subscription) {
 	return func(subscription *Subscription) {
+		subscription.fields.Provide("RequestID")
 		subscription.reqID = reqID
 	}
 }
@@ -57,6 +60,7 @@ func WithRequestID(reqID int32) func(*Subscription) {
 // WithRanFuncID sets ran function ID
 func WithRanFuncID(ranFuncID int32) func(*Subscription) {
 	return func(subscription *Subscription) {
+		subscription.fields.Provide("RanFuncID")
 		subscription.ranFuncID = ranFuncID
 	}
 }
@@ -64,6 +68,7 @@ func WithRanFuncID(ranFuncID int32) func(*Subscription) {
 // WithRicInstanceID sets ric instance ID
 func WithRicInstanceID(ricInstanceID int32) func(*Subscription) {
 	return func(subscription *Subscription) {
+		subscription.fields.Provide("RicInstanceID")
 		subscription.ricInstanceID = ricInstanceID
 	}
 }
@@ -71,6 +76,7 @@ func WithRicInstanceID(ricInstanceID int32) func(*Subscription) {
 // WithActionsAccepted sets accepted actions
 func WithActionsAccepted(ricActionsAccepted []*types.RicActionID) func(*Subscription) {
 	return func(subscription *Subscription) {
+		subscription.fields.Provide("ActionsAccepted")
 		subscription.ricActionsAccepted = ricActionsAccepted
 	}
 }
@@ -78,6 +84,7 @@ func WithActionsAccepted(ricActionsAccepted []*types.RicActionID) func(*Subscrip
 // WithActionsNotAdmitted sets not admitted actions
 func WithActionsNotAdmitted(ricActionsNotAdmitted map[types.RicActionID]*e2apies.Cause) func(*Subscription) {
 	return func(subscription *Subscription) {
+		subscription.fields.Provide("ActionsNotAdmitted")
 		subscription.ricActionsNotAdmitted = ricActionsNotAdmitted
 	}
 }
@@ -85,12 +92,24 @@ func WithActionsNotAdmitted(ricActionsNotAdmitted map[types.RicActionID]*e2apies
 // WithCause sets subscription failure cause
 func WithCause(cause *e2apies.Cause) func(subscription *Subscription) {
 	return func(subscription *Subscription) {
+		subscription.fields.Provide("Cause")
 		subscription.cause = cause
 	}
 }
 
+// Validate checks that the fields shared by the subscription response and failure were provided
+func (subscription *Subscription) Validate() error {
+	return subscription.fields.Require("RICsubscription", "RequestID", "RanFuncID", "RicInstanceID")
+}
+
 // BuildSubscriptionFailure builds e2ap subscription failure
 func (subscription *Subscription) BuildSubscriptionFailure() (response *e2appducontents.RicsubscriptionFailure, err error) {
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	if err := subscription.fields.Require("RICsubscriptionFailure", "Cause"); err != nil {
+		return nil, err
+	}
 
 	rfID := types.RanFunctionID(subscription.ranFuncID)
 	rrID := types.RicRequest{
@@ -108,6 +127,12 @@ func (subscription *Subscription) BuildSubscriptionFailure() (response *e2appduc
 
 // BuildSubscriptionResponse builds e2ap subscription response
 func (subscription *Subscription) BuildSubscriptionResponse() (response *e2appducontents.RicsubscriptionResponse, err error) {
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	if err := subscription.fields.Require("RICsubscriptionResponse", "ActionsAccepted"); err != nil {
+		return nil, err
+	}
 
 	rfID := types.RanFunctionID(subscription.ranFuncID)
 	rrID := types.RicRequest{
@@ -7,6 +7,7 @@ package subscriptiondelete
 import (
 	"fmt"
 	v2 "github.com/onosproject/onos-e2t/api/e2ap/v2"
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 )
 
@@ -60,3 +61,13 @@ func GetRicInstanceID(request *e2appducontents.RicsubscriptionDeleteRequest) (*i
 
 	return &res, nil
 }
+
+// GetFailureCause gets the cause of a subscription delete failure
+func GetFailureCause(failure *e2appducontents.RicsubscriptionDeleteFailure) (*e2apies.Cause, error) {
+	for _, v := range failure.GetProtocolIes() {
+		if v.Id == int32(v2.ProtocolIeIDCause) {
+			return v.GetValue().GetC(), nil
+		}
+	}
+	return nil, fmt.Errorf("Cause was not found")
+}
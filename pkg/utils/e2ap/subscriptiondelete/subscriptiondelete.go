@@ -8,6 +8,7 @@ import (
 	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
 	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
 	"github.com/onosproject/onos-e2t/pkg/southbound/e2ap/types"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
 )
 
 // SubscriptionDelete required fields for creating subscription delete response and failure
@@ -16,6 +17,7 @@ type SubscriptionDelete struct {
 	ricInstanceID int32
 	ranFuncID     int32
 	cause         *e2apies.Cause
+	fields        builder.Fields
 	// TODO add more fields including cause of failure
 }
 
@@ -47,6 +49,7 @@ func (subscriptionDelete *SubscriptionDelete) GetReqID() int32 {
 // WithRequestID sets request ID
 func WithRequestID(reqID int32) func(subscriptionDelete *SubscriptionDelete) {
 	return func(subscriptionDelete *SubscriptionDelete) {
+		subscriptionDelete.fields.Provide("RequestID")
 		subscriptionDelete.reqID = reqID
 	}
 }
@@ -54,6 +57,7 @@ func WithRequestID(reqID int32) func(subscriptionDelete *SubscriptionDelete) {
 // WithRanFuncID sets ran function ID
 func WithRanFuncID(ranFuncID int32) func(subscriptionDelete *SubscriptionDelete) {
 	return func(subscriptionDelete *SubscriptionDelete) {
+		subscriptionDelete.fields.Provide("RanFuncID")
 		subscriptionDelete.ranFuncID = ranFuncID
 	}
 }
@@ -61,6 +65,7 @@ func WithRanFuncID(ranFuncID int32) func(subscriptionDelete *SubscriptionDelete)
 // WithRicInstanceID sets ric instance ID
 func WithRicInstanceID(ricInstanceID int32) func(subscriptionDelete *SubscriptionDelete) {
 	return func(subscriptionDelete *SubscriptionDelete) {
+		subscriptionDelete.fields.Provide("RicInstanceID")
 		subscriptionDelete.ricInstanceID = ricInstanceID
 	}
 }
@@ -68,12 +73,24 @@ func WithRicInstanceID(ricInstanceID int32) func(subscriptionDelete *Subscriptio
 // WithCause sets cause of subscription delete failure
 func WithCause(cause *e2apies.Cause) func(subscriptionDelete *SubscriptionDelete) {
 	return func(subscriptionDelete *SubscriptionDelete) {
+		subscriptionDelete.fields.Provide("Cause")
 		subscriptionDelete.cause = cause
 	}
 }
 
+// Validate checks that the fields shared by the subscription delete response and failure were provided
+func (subscriptionDelete *SubscriptionDelete) Validate() error {
+	return subscriptionDelete.fields.Require("RICsubscriptionDelete", "RequestID", "RanFuncID", "RicInstanceID")
+}
+
 // BuildSubscriptionDeleteFailure builds subscription delete failure
 func (subscriptionDelete *SubscriptionDelete) BuildSubscriptionDeleteFailure() (response *e2appducontents.RicsubscriptionDeleteFailure, err error) {
+	if err := subscriptionDelete.Validate(); err != nil {
+		return nil, err
+	}
+	if err := subscriptionDelete.fields.Require("RICsubscriptionDeleteFailure", "Cause"); err != nil {
+		return nil, err
+	}
 
 	rrID := types.RicRequest{
 		RequestorID: types.RicRequestorID(subscriptionDelete.reqID),
@@ -90,6 +107,9 @@ func (subscriptionDelete *SubscriptionDelete) BuildSubscriptionDeleteFailure() (
 
 // BuildSubscriptionDeleteResponse builds subscription delete response
 func (subscriptionDelete *SubscriptionDelete) BuildSubscriptionDeleteResponse() (response *e2appducontents.RicsubscriptionDeleteResponse, err error) {
+	if err := subscriptionDelete.Validate(); err != nil {
+		return nil, err
+	}
 
 	rrID := types.RicRequest{
 		RequestorID: types.RicRequestorID(subscriptionDelete.reqID),
@@ -10,6 +10,7 @@ import (
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 
 	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 	"google.golang.org/protobuf/proto"
 
 	e2smkpmies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm/v1beta1/e2sm-kpm-ies"
@@ -121,6 +122,7 @@ func (header *Header) ToAsn1Bytes(modelPlugin modelplugins.ServiceModel) ([]byte
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(modelPlugin, selfcheck.IndicationHeader, indicationHeader, indicationHeaderAsn1Bytes)
 	return indicationHeaderAsn1Bytes, nil
 }
 
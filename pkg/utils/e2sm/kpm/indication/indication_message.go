@@ -7,6 +7,7 @@ package indication
 import (
 	e2smkpmies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm/v1beta1/e2sm-kpm-ies"
 	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -48,6 +49,7 @@ func (message *Message) ToAsn1Bytes(modelPlugin modelplugins.ServiceModel) ([]by
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(modelPlugin, selfcheck.IndicationMessage, indicationMessage, indicationMessageAsn1Bytes)
 
 	return indicationMessageAsn1Bytes, nil
 }
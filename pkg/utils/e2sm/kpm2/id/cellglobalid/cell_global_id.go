@@ -56,3 +56,49 @@ func (gNRCGIID *GlobalNRCGIID) Build() (*e2smkpmv2.CellGlobalId, error) {
 		},
 	}, nil
 }
+
+// GlobalEUTRACGIID cell global EUTRACGI ID
+type GlobalEUTRACGIID struct {
+	plmnID      *ransimtypes.Uint24
+	eutraCellID *asn1.BitString
+}
+
+// NewGlobalEUTRACGIID creates new global EUTRACGI ID
+func NewGlobalEUTRACGIID(options ...func(*GlobalEUTRACGIID)) *GlobalEUTRACGIID {
+	eutracgiid := &GlobalEUTRACGIID{}
+	for _, option := range options {
+		option(eutracgiid)
+	}
+
+	return eutracgiid
+}
+
+// WithEUTRAPlmnID sets plmn ID
+func WithEUTRAPlmnID(plmnID *ransimtypes.Uint24) func(eutracgiid *GlobalEUTRACGIID) {
+	return func(eutracgiid *GlobalEUTRACGIID) {
+		eutracgiid.plmnID = plmnID
+	}
+}
+
+// WithEUTRACellID sets EUTRACellID
+func WithEUTRACellID(eutraCellID *asn1.BitString) func(eutracgiid *GlobalEUTRACGIID) {
+	return func(eutracgiid *GlobalEUTRACGIID) {
+		eutracgiid.eutraCellID = eutraCellID
+	}
+}
+
+// Build builds a global EUTRACGI ID
+func (gEUTRACGIID *GlobalEUTRACGIID) Build() (*e2smkpmv2.CellGlobalId, error) {
+	return &e2smkpmv2.CellGlobalId{
+		CellGlobalId: &e2smkpmv2.CellGlobalId_EUtraCgi{
+			EUtraCgi: &e2smkpmv2.Eutracgi{
+				PLmnIdentity: &e2smkpmv2.PlmnIdentity{
+					Value: gEUTRACGIID.plmnID.ToBytes(),
+				},
+				EUtracellIdentity: &e2smkpmv2.EutracellIdentity{
+					Value: gEUTRACGIID.eutraCellID,
+				},
+			},
+		},
+	}, nil
+}
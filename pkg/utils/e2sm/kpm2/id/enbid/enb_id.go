@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package enbid
+
+import (
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+)
+
+// GlobalENBID global eNB ID
+type GlobalENBID struct {
+	plmnID     ransimtypes.Uint24
+	macroENBID *asn1.BitString
+}
+
+// NewGlobalENBID creates new global eNB ID
+func NewGlobalENBID(options ...func(*GlobalENBID)) *GlobalENBID {
+	eNBID := &GlobalENBID{}
+	for _, option := range options {
+		option(eNBID)
+	}
+
+	return eNBID
+}
+
+// WithPlmnID sets plmn ID
+func WithPlmnID(plmnID ransimtypes.Uint24) func(eNBID *GlobalENBID) {
+	return func(eNBID *GlobalENBID) {
+		eNBID.plmnID = plmnID
+	}
+}
+
+// WithMacroENBID sets the 20 bit macro eNB ID
+func WithMacroENBID(macroENBID *asn1.BitString) func(eNBID *GlobalENBID) {
+	return func(eNBID *GlobalENBID) {
+		eNBID.macroENBID = macroENBID
+	}
+}
+
+// Build builds a global eNB ID
+func (eNBID *GlobalENBID) Build() (*e2smkpmv2.GlobalKpmnodeId, error) {
+	return &e2smkpmv2.GlobalKpmnodeId{
+		GlobalKpmnodeId: &e2smkpmv2.GlobalKpmnodeId_ENb{
+			ENb: &e2smkpmv2.GlobalKpmnodeEnbId{
+				GlobalENbId: &e2smkpmv2.GlobalEnbId{
+					PLmnIdentity: &e2smkpmv2.PlmnIdentity{
+						Value: eNBID.plmnID.ToBytes(),
+					},
+					ENbId: &e2smkpmv2.EnbId{
+						EnbId: &e2smkpmv2.EnbId_MacroENbId{
+							MacroENbId: eNBID.macroENBID,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
@@ -5,12 +5,17 @@
 package indication
 
 import (
+	"encoding/binary"
+	"time"
+
 	e2smkpmv2sm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
 	"google.golang.org/protobuf/proto"
 
 	// "google.golang.org/protobuf/proto"
 
 	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 )
 
 // Header indication header for kpm service model
@@ -21,6 +26,7 @@ type Header struct {
 	senderType        string
 	vendorName        string
 	globalKpmNodeID   *e2smkpmv2.GlobalKpmnodeId
+	fields            builder.Fields
 }
 
 // NewIndicationHeader creates a new indication header
@@ -36,11 +42,35 @@ func NewIndicationHeader(options ...func(header *Header)) *Header {
 // WithTimeStamp sets timestamp
 func WithTimeStamp(timeStamp []byte) func(header *Header) {
 	return func(header *Header) {
+		header.fields.Provide("TimeStamp")
 		header.timeStamp = timeStamp
 
 	}
 }
 
+// WithCollectionStartTime sets the start time of the first granularity period reported in the indication
+func WithCollectionStartTime(startTime time.Time) func(header *Header) {
+	return func(header *Header) {
+		header.fields.Provide("TimeStamp")
+		header.timeStamp = ToTimeStamp(startTime)
+	}
+}
+
+// ToTimeStamp encodes the given time as a 4-byte KPM time stamp in seconds since the UNIX epoch
+func ToTimeStamp(t time.Time) []byte {
+	timeStamp := make([]byte, 4)
+	binary.BigEndian.PutUint32(timeStamp, uint32(t.Unix()))
+	return timeStamp
+}
+
+// FromTimeStamp decodes the given 4-byte KPM time stamp
+func FromTimeStamp(timeStamp []byte) time.Time {
+	if len(timeStamp) != 4 {
+		return time.Time{}
+	}
+	return time.Unix(int64(binary.BigEndian.Uint32(timeStamp)), 0)
+}
+
 // WithFileFormatVersion sets file format version
 func WithFileFormatVersion(fileFormatVersion string) func(header *Header) {
 	return func(header *Header) {
@@ -95,11 +125,20 @@ func (header *Header) ToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&kpm2ServiceModel, selfcheck.IndicationHeader, indicationHeader, indicationHeaderAsn1Bytes)
 	return indicationHeaderAsn1Bytes, nil
 }
 
+// Validate checks that the mandatory fields of the indication header were provided
+func (header *Header) Validate() error {
+	return header.fields.Require("E2SM-KPM indication header", "TimeStamp")
+}
+
 // Build builds kpm v2 indication header message
 func (header *Header) Build() (*e2smkpmv2.E2SmKpmIndicationHeader, error) {
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
 	e2SmKpmPdu := e2smkpmv2.E2SmKpmIndicationHeader{
 		IndicationHeaderFormats: &e2smkpmv2.IndicationHeaderFormats{
 			E2SmKpmIndicationHeader: &e2smkpmv2.IndicationHeaderFormats_IndicationHeaderFormat1{
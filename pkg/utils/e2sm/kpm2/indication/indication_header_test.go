@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package indication
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectionStartTime(t *testing.T) {
+	startTime := time.Unix(1634567890, 0)
+	header, err := NewIndicationHeader(WithCollectionStartTime(startTime)).Build()
+	assert.NoError(t, err)
+
+	timeStamp := header.GetIndicationHeaderFormats().GetIndicationHeaderFormat1().GetColletStartTime().GetValue()
+	assert.Len(t, timeStamp, 4)
+	assert.True(t, startTime.Equal(FromTimeStamp(timeStamp)))
+	assert.True(t, FromTimeStamp([]byte{0x01}).IsZero())
+}
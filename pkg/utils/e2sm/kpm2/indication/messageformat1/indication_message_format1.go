@@ -5,8 +5,11 @@
 package messageformat1
 
 import (
+	"time"
+
 	e2smkpmv2sm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
 	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -17,6 +20,7 @@ type Message struct {
 	granularity    uint32
 	measInfoList   *e2smkpmv2.MeasurementInfoList
 	measData       *e2smkpmv2.MeasurementData
+	startTime      time.Time
 }
 
 // NewIndicationMessage creates a new indication message
@@ -50,6 +54,23 @@ func WithGranularity(granularity uint32) func(msg *Message) {
 	}
 }
 
+// WithCollectionStartTime sets the start time of the first granularity period
+func WithCollectionStartTime(startTime time.Time) func(msg *Message) {
+	return func(msg *Message) {
+		msg.startTime = startTime
+	}
+}
+
+// GetGranularityPeriodTimes returns the start time of the granularity period of each measurement data item;
+// E2SM-KPM does not carry them explicitly, they follow from the collection start time and the granularity period
+func (message *Message) GetGranularityPeriodTimes() []time.Time {
+	times := make([]time.Time, 0, len(message.measData.GetValue()))
+	for i := range message.measData.GetValue() {
+		times = append(times, message.startTime.Add(time.Duration(i)*time.Duration(message.granularity)*time.Millisecond))
+	}
+	return times
+}
+
 // WithMeasData sets measurements data
 func WithMeasData(measData *e2smkpmv2.MeasurementData) func(msg *Message) {
 	return func(msg *Message) {
@@ -81,6 +102,7 @@ func (message *Message) ToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&kpm2ServiceModel, selfcheck.IndicationMessage, indicationMessage, indicationMessageAsn1Bytes)
 
 	return indicationMessageAsn1Bytes, nil
 
@@ -7,6 +7,7 @@ package messageformat2
 import (
 	"github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/servicemodel"
 	e2smkpmv2 "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_kpm_v2_go/v2/e2sm-kpm-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -79,6 +80,7 @@ func (message *Message) ToAsn1Bytes(serviceModel servicemodel.Kpm2ServiceModel)
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&serviceModel, selfcheck.IndicationMessage, indicationMessage, indicationMessageAsn1Bytes)
 
 	return indicationMessageAsn1Bytes, nil
 
@@ -16,6 +16,8 @@ import (
 
 	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 )
 
 var log = logging.GetLogger("sm", "mho")
@@ -24,6 +26,7 @@ var log = logging.GetLogger("sm", "mho")
 type Header struct {
 	plmnID         ransimtypes.Uint24
 	nrCellIdentity []byte
+	fields         builder.Fields
 }
 
 // NewIndicationHeader creates a new indication header
@@ -39,6 +42,7 @@ func NewIndicationHeader(options ...func(header *Header)) *Header {
 // WithPlmnID sets plmnID
 func WithPlmnID(plmnID ransimtypes.Uint24) func(header *Header) {
 	return func(header *Header) {
+		header.fields.Provide("PlmnID")
 		header.plmnID = plmnID
 
 	}
@@ -47,12 +51,21 @@ func WithPlmnID(plmnID ransimtypes.Uint24) func(header *Header) {
 // WithNrcellIdentity sets nrCellIdentity
 func WithNrcellIdentity(nrCellIdentity []byte) func(header *Header) {
 	return func(header *Header) {
+		header.fields.Provide("NrcellIdentity")
 		header.nrCellIdentity = nrCellIdentity
 	}
 }
 
+// Validate checks that the mandatory fields of the indication header were provided
+func (header *Header) Validate() error {
+	return header.fields.Require("E2SM-MHO indication header", "PlmnID", "NrcellIdentity")
+}
+
 // Build builds indication header for mho service model
 func (header *Header) Build() (*mho.E2SmMhoIndicationHeader, error) {
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
 	E2SmMhoPdu := mho.E2SmMhoIndicationHeader{
 		E2SmMhoIndicationHeader: &mho.E2SmMhoIndicationHeader_IndicationHeaderFormat1{
 			IndicationHeaderFormat1: &mho.E2SmMhoIndicationHeaderFormat1{
@@ -103,5 +116,6 @@ func (header *Header) MhoToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&mhoServiceModel, selfcheck.IndicationHeader, indicationHeader, indicationHeaderAsn1Bytes)
 	return indicationHeaderAsn1Bytes, nil
 }
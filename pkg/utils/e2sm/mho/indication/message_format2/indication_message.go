@@ -10,6 +10,7 @@ import (
 	mho "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-mho-go"
 	e2smv2ies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_mho_go/v2/e2sm-v2-ies"
 	"github.com/onosproject/onos-lib-go/api/asn1/v1/asn1"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -111,6 +112,7 @@ func (message *Message) ToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&mhoServiceModel, selfcheck.IndicationMessage, indicationMessage, indicationMessageAsn1Bytes)
 
 	return indicationMessageAsn1Bytes, nil
 }
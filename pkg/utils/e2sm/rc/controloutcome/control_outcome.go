@@ -9,11 +9,14 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	e2smrcpreies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 )
 
 // ControlOutcome required fields for control outcome
 type ControlOutcome struct {
 	ranParameterID int32
+	fields         builder.Fields
 }
 
 // NewControlOutcome creates a new control outcome
@@ -28,13 +31,22 @@ func NewControlOutcome(options ...func(outcome *ControlOutcome)) *ControlOutcome
 // WithRanParameterID sets ran parameter ID
 func WithRanParameterID(ranParameterID int32) func(co *ControlOutcome) {
 	return func(co *ControlOutcome) {
+		co.fields.Provide("RanParameterID")
 		co.ranParameterID = ranParameterID
 
 	}
 }
 
+// Validate checks that the mandatory fields of the control outcome were provided
+func (co *ControlOutcome) Validate() error {
+	return co.fields.Require("E2SM-RC-PRE control outcome", "RanParameterID")
+}
+
 // Build builds rc control outcome message
 func (co *ControlOutcome) Build() (*e2smrcpreies.E2SmRcPreControlOutcome, error) {
+	if err := co.Validate(); err != nil {
+		return nil, err
+	}
 	e2smRcPreOutcomeFormat1 := e2smrcpreies.E2SmRcPreControlOutcomeFormat1{
 		OutcomeElementList: make([]*e2smrcpreies.RanparameterItem, 0),
 	}
@@ -74,6 +86,7 @@ func (co *ControlOutcome) ToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&rcPreServiceModel, selfcheck.ControlOutcome, outcomeRcMessage, outcomeAsn1Bytes)
 
 	return outcomeAsn1Bytes, nil
 }
@@ -15,12 +15,15 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	e2smrcpreies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/utils/builder"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 )
 
 // Header indication header for rc service model
 type Header struct {
 	plmnID            ransimtypes.Uint24
 	eutraCellIdentity uint64
+	fields            builder.Fields
 }
 
 // NewIndicationHeader creates a new indication header
@@ -36,6 +39,7 @@ func NewIndicationHeader(options ...func(header *Header)) *Header {
 // WithPlmnID sets plmnID
 func WithPlmnID(plmnID ransimtypes.Uint24) func(header *Header) {
 	return func(header *Header) {
+		header.fields.Provide("PlmnID")
 		header.plmnID = plmnID
 
 	}
@@ -44,12 +48,21 @@ func WithPlmnID(plmnID ransimtypes.Uint24) func(header *Header) {
 // WithNRcellIdentity sets NRcellIdentity
 func WithNRcellIdentity(nRcellIdentity uint64) func(header *Header) {
 	return func(header *Header) {
+		header.fields.Provide("NRcellIdentity")
 		header.eutraCellIdentity = nRcellIdentity
 	}
 }
 
+// Validate checks that the mandatory fields of the indication header were provided
+func (header *Header) Validate() error {
+	return header.fields.Require("E2SM-RC-PRE indication header", "PlmnID", "NRcellIdentity")
+}
+
 // Build builds indication header for rc service model
 func (header *Header) Build() (*e2smrcpreies.E2SmRcPreIndicationHeader, error) {
+	if err := header.Validate(); err != nil {
+		return nil, err
+	}
 	E2SmRcPrePdu := e2smrcpreies.E2SmRcPreIndicationHeader{
 		E2SmRcPreIndicationHeader: &e2smrcpreies.E2SmRcPreIndicationHeader_IndicationHeaderFormat1{
 			IndicationHeaderFormat1: &e2smrcpreies.E2SmRcPreIndicationHeaderFormat1{
@@ -99,5 +112,6 @@ func (header *Header) ToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&rcPreServiceModel, selfcheck.IndicationHeader, indicationHeader, indicationHeaderAsn1Bytes)
 	return indicationHeaderAsn1Bytes, nil
 }
@@ -8,6 +8,7 @@ import (
 	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
 	e2smrcpresm "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/servicemodel"
 	e2smrcpreies "github.com/onosproject/onos-e2-sm/servicemodels/e2sm_rc_pre_go/v2/e2sm-rc-pre-v2-go"
+	"github.com/onosproject/ran-simulator/pkg/utils/e2sm/selfcheck"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -15,6 +16,7 @@ import (
 type Message struct {
 	plmnID     ransimtypes.Uint24
 	earfcn     int32
+	nrArfcn    int32
 	cellSize   e2smrcpreies.CellSize
 	pci        int32
 	neighbours []*e2smrcpreies.Nrt
@@ -45,6 +47,13 @@ func WithEarfcn(earfcn int32) func(message *Message) {
 	}
 }
 
+// WithNrArfcn sets NR-ARFCN; it is reported instead of the EARFCN if set
+func WithNrArfcn(nrArfcn int32) func(message *Message) {
+	return func(message *Message) {
+		message.nrArfcn = nrArfcn
+	}
+}
+
 // WithCellSize sets cell size
 func WithCellSize(cellSize e2smrcpreies.CellSize) func(message *Message) {
 	return func(message *Message) {
@@ -81,6 +90,15 @@ func (message *Message) Build() (*e2smrcpreies.E2SmRcPreIndicationMessage, error
 			},
 		},
 	}
+	if message.nrArfcn > 0 {
+		e2SmIindicationMsg.IndicationMessageFormat1.DlArfcn = &e2smrcpreies.Arfcn{
+			Arfcn: &e2smrcpreies.Arfcn_NrArfcn{
+				NrArfcn: &e2smrcpreies.Nrarfcn{
+					Value: message.nrArfcn,
+				},
+			},
+		}
+	}
 
 	e2SmIindicationMsg.IndicationMessageFormat1.CellSize = message.cellSize
 	e2SmIindicationMsg.IndicationMessageFormat1.Pci = &e2smrcpreies.Pci{
@@ -117,6 +135,7 @@ func (message *Message) ToAsn1Bytes() ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	selfcheck.Check(&rcPreServiceModel, selfcheck.IndicationMessage, indicationMessage, indicationMessageAsn1Bytes)
 
 	return indicationMessageAsn1Bytes, nil
 }
@@ -18,6 +18,7 @@ type Neighbour struct {
 	plmnID         ransimtypes.Uint24
 	nRCellIdentity uint64
 	earfcn         int32
+	nrArfcn        int32
 	pci            int32
 	cellSize       e2smrcpreies.CellSize
 }
@@ -53,6 +54,13 @@ func WithEarfcn(earfcn int32) func(neighbour *Neighbour) {
 	}
 }
 
+// WithNrArfcn sets NR-ARFCN; it is reported instead of the EARFCN if set
+func WithNrArfcn(nrArfcn int32) func(neighbour *Neighbour) {
+	return func(neighbour *Neighbour) {
+		neighbour.nrArfcn = nrArfcn
+	}
+}
+
 // WithPci sets pci
 func WithPci(pci int32) func(neighbour *Neighbour) {
 	return func(neighbour *Neighbour) {
@@ -97,6 +105,15 @@ func (neighbour *Neighbour) Build() (*e2smrcpreies.Nrt, error) {
 			},
 		},
 	}
+	if neighbour.nrArfcn > 0 {
+		nrtMsg.DlArfcn = &e2smrcpreies.Arfcn{
+			Arfcn: &e2smrcpreies.Arfcn_NrArfcn{
+				NrArfcn: &e2smrcpreies.Nrarfcn{
+					Value: neighbour.nrArfcn,
+				},
+			},
+		}
+	}
 
 	//ToDo - return it back once the Validation is functional again
 	//if err := nrtMsg.Validate(); err != nil {
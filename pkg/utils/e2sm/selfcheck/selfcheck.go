@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selfcheck
+
+import (
+	"sync"
+	"sync/atomic"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	"github.com/onosproject/onos-lib-go/pkg/logging"
+	"github.com/onosproject/ran-simulator/pkg/modelplugins"
+	"google.golang.org/protobuf/proto"
+)
+
+var log = logging.GetLogger("e2sm", "selfcheck")
+
+// Payload kind of E2SM payload encoded by the simulator
+type Payload int
+
+const (
+	// IndicationHeader RIC indication header
+	IndicationHeader Payload = iota
+	// IndicationMessage RIC indication message
+	IndicationMessage
+	// RanFuncDescription RAN function description
+	RanFuncDescription
+	// ControlOutcome RIC control outcome
+	ControlOutcome
+)
+
+// String converts the payload kind to string
+func (p Payload) String() string {
+	return [...]string{"indication header", "indication message", "RAN function description", "control outcome"}[p]
+}
+
+// ServiceModel service model encoding the payloads; it decodes them back unless a model plugin of the same
+// service model is loaded
+type ServiceModel interface {
+	ServiceModelData() e2smtypes.ServiceModelData
+}
+
+var (
+	mu         sync.RWMutex
+	enabled    bool
+	registry   modelplugins.ModelRegistry
+	mismatches uint64
+)
+
+// Enable turns on the round trip self check of the encoded payloads; they are decoded back by the model plugins of
+// the given registry, if any is loaded for their service model, which catches drifts between the encoders of the
+// plugins and the builders of the simulator
+func Enable(modelRegistry modelplugins.ModelRegistry) {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = true
+	registry = modelRegistry
+}
+
+// Disable turns off the round trip self check
+func Disable() {
+	mu.Lock()
+	defer mu.Unlock()
+	enabled = false
+	registry = nil
+}
+
+// IsEnabled returns true if the round trip self check is on
+func IsEnabled() bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return enabled
+}
+
+// Mismatches returns the number of payloads which could not be decoded back or whose decoded form differs from
+// their source
+func Mismatches() uint64 {
+	return atomic.LoadUint64(&mismatches)
+}
+
+// Check decodes back the ASN.1 bytes encoded from the source message, if the self check is on, and logs the
+// mismatches between the decoded message and the source
+func Check(sm ServiceModel, payload Payload, source proto.Message, asn1Bytes []byte) {
+	if !IsEnabled() {
+		return
+	}
+	oid := sm.ServiceModelData().OID
+	decode := getDecoder(sm, payload)
+	if plugin := getPlugin(oid); plugin != nil {
+		decode = getDecoder(plugin, payload)
+	}
+	if decode == nil {
+		log.Debugf("No %s decoder for %s", payload, oid)
+		return
+	}
+
+	protoBytes, err := decode(asn1Bytes)
+	if err != nil {
+		atomic.AddUint64(&mismatches, 1)
+		log.Warnf("Unable to decode back the %s of %s: %v", payload, oid, err)
+		return
+	}
+	decoded := source.ProtoReflect().New().Interface()
+	if err := proto.Unmarshal(protoBytes, decoded); err != nil {
+		atomic.AddUint64(&mismatches, 1)
+		log.Warnf("Unable to unmarshal the decoded %s of %s: %v", payload, oid, err)
+		return
+	}
+	if !proto.Equal(source, decoded) {
+		atomic.AddUint64(&mismatches, 1)
+		log.Warnf("Decoded %s of %s differs from its source\nsource:  %v\ndecoded: %v", payload, oid, source, decoded)
+	}
+}
+
+func getPlugin(oid e2smtypes.OID) modelplugins.ServiceModel {
+	mu.RLock()
+	defer mu.RUnlock()
+	if registry == nil {
+		return nil
+	}
+	plugin, err := registry.GetPlugin(oid)
+	if err != nil {
+		return nil
+	}
+	return plugin
+}
+
+// getDecoder returns the method of the service model decoding the given kind of payload, if any
+func getDecoder(sm interface{}, payload Payload) func([]byte) ([]byte, error) {
+	switch payload {
+	case IndicationHeader:
+		if decoder, ok := sm.(interface {
+			IndicationHeaderASN1toProto(asn1Bytes []byte) ([]byte, error)
+		}); ok {
+			return decoder.IndicationHeaderASN1toProto
+		}
+	case IndicationMessage:
+		if decoder, ok := sm.(interface {
+			IndicationMessageASN1toProto(asn1Bytes []byte) ([]byte, error)
+		}); ok {
+			return decoder.IndicationMessageASN1toProto
+		}
+	case RanFuncDescription:
+		if decoder, ok := sm.(interface {
+			RanFuncDescriptionASN1toProto(asn1Bytes []byte) ([]byte, error)
+		}); ok {
+			return decoder.RanFuncDescriptionASN1toProto
+		}
+	case ControlOutcome:
+		if decoder, ok := sm.(interface {
+			ControlOutcomeASN1toProto(asn1Bytes []byte) ([]byte, error)
+		}); ok {
+			return decoder.ControlOutcomeASN1toProto
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package selfcheck
+
+import (
+	"testing"
+
+	e2smtypes "github.com/onosproject/onos-api/go/onos/e2t/e2sm"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeServiceModel encodes the payloads as their protobuf encoding, optionally altered by the decoder
+type fakeServiceModel struct {
+	decode func(asn1Bytes []byte) ([]byte, error)
+}
+
+func (sm *fakeServiceModel) ServiceModelData() e2smtypes.ServiceModelData {
+	return e2smtypes.ServiceModelData{Name: "fake", Version: "v1", OID: "1.3.6.1.4.1.53148.1.1.2.999"}
+}
+
+func (sm *fakeServiceModel) IndicationHeaderASN1toProto(asn1Bytes []byte) ([]byte, error) {
+	return sm.decode(asn1Bytes)
+}
+
+func encode(t *testing.T, message proto.Message) []byte {
+	bytes, err := proto.Marshal(message)
+	assert.NoError(t, err)
+	return bytes
+}
+
+func TestCheck(t *testing.T) {
+	defer Disable()
+	source := wrapperspb.String("header")
+	roundTrip := &fakeServiceModel{decode: func(asn1Bytes []byte) ([]byte, error) {
+		return asn1Bytes, nil
+	}}
+	drifted := &fakeServiceModel{decode: func(asn1Bytes []byte) ([]byte, error) {
+		return proto.Marshal(wrapperspb.String("drifted"))
+	}}
+	failed := &fakeServiceModel{decode: func(asn1Bytes []byte) ([]byte, error) {
+		return nil, errors.NewInvalid("invalid APER bytes")
+	}}
+
+	// Nothing is decoded unless enabled
+	Check(failed, IndicationHeader, source, encode(t, source))
+	assert.Equal(t, uint64(0), Mismatches())
+
+	Enable(nil)
+	assert.True(t, IsEnabled())
+	Check(roundTrip, IndicationHeader, source, encode(t, source))
+	assert.Equal(t, uint64(0), Mismatches())
+	Check(drifted, IndicationHeader, source, encode(t, source))
+	assert.Equal(t, uint64(1), Mismatches())
+	Check(failed, IndicationHeader, source, encode(t, source))
+	assert.Equal(t, uint64(2), Mismatches())
+
+	// Payloads the service model can not decode are skipped
+	Check(failed, ControlOutcome, source, encode(t, source))
+	assert.Equal(t, uint64(2), Mismatches())
+
+	Disable()
+	assert.False(t, IsEnabled())
+	Check(drifted, IndicationHeader, source, encode(t, source))
+	assert.Equal(t, uint64(2), Mismatches())
+}
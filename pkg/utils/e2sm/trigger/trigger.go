@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"fmt"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	e2appducontents "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-pdu-contents"
+	subutils "github.com/onosproject/ran-simulator/pkg/utils/e2ap/subscription"
+)
+
+// Type event trigger type of a subscription
+type Type int
+
+const (
+	// Periodic reports are sent at a fixed interval
+	Periodic Type = iota
+	// OnChange reports are sent whenever the reported information changes
+	OnChange
+	// Threshold reports are sent whenever a measurement crosses a threshold, e.g. an A3 event
+	Threshold
+)
+
+func (t Type) String() string {
+	switch t {
+	case Periodic:
+		return "periodic"
+	case OnChange:
+		return "on-change"
+	case Threshold:
+		return "threshold"
+	}
+	return fmt.Sprintf("unknown(%d)", int(t))
+}
+
+// Trigger service model independent event trigger of a subscription
+type Trigger struct {
+	Type   Type
+	Period int64 // report interval in ms of periodic triggers
+}
+
+// Decoder decodes the ASN.1 encoded event trigger definition of a service model
+type Decoder func(definition []byte) (*Trigger, error)
+
+// Error failure to decode an event trigger definition, along with the cause of the subscription failure
+type Error struct {
+	cause   *e2apies.Cause
+	message string
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Cause returns the cause of the subscription failure
+func (e *Error) Cause() *e2apies.Cause {
+	return e.cause
+}
+
+// NewMalformed returns an error for an event trigger definition which cannot be decoded
+func NewMalformed(format string, args ...interface{}) error {
+	return &Error{
+		cause: &e2apies.Cause{
+			Cause: &e2apies.Cause_Protocol{
+				Protocol: e2apies.CauseProtocol_CAUSE_PROTOCOL_ABSTRACT_SYNTAX_ERROR_FALSELY_CONSTRUCTED_MESSAGE,
+			},
+		},
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// NewUnsupported returns an error for a valid event trigger which is not supported by the service model
+func NewUnsupported(format string, args ...interface{}) error {
+	return &Error{
+		cause: &e2apies.Cause{
+			Cause: &e2apies.Cause_Protocol{
+				Protocol: e2apies.CauseProtocol_CAUSE_PROTOCOL_SEMANTIC_ERROR,
+			},
+		},
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// GetCause returns the cause of the subscription failure for the given error; errors not raised while decoding
+// the event trigger have an unspecified cause
+func GetCause(err error) *e2apies.Cause {
+	if triggerErr, ok := err.(*Error); ok {
+		return triggerErr.Cause()
+	}
+	return &e2apies.Cause{
+		Cause: &e2apies.Cause_RicRequest{
+			RicRequest: e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED,
+		},
+	}
+}
+
+// DecodeRequest decodes the event trigger definition of the subscription request
+func DecodeRequest(request *e2appducontents.RicsubscriptionRequest, decoder Decoder, supported ...Type) (*Trigger, error) {
+	return Decode(subutils.GetRicEventTriggerDefinition(request), decoder, supported...)
+}
+
+// Decode decodes the event trigger definition using the decoder of the service model and checks that the trigger
+// is among the supported trigger types
+func Decode(definition []byte, decoder Decoder, supported ...Type) (*Trigger, error) {
+	if len(definition) == 0 {
+		return nil, NewMalformed("event trigger definition is missing")
+	}
+	trigger, err := decoder(definition)
+	if err != nil {
+		if _, ok := err.(*Error); ok {
+			return nil, err
+		}
+		return nil, NewMalformed("cannot decode event trigger definition: %v", err)
+	}
+	if !isSupported(trigger.Type, supported) {
+		return nil, NewUnsupported("%s event trigger is not supported", trigger.Type)
+	}
+	if trigger.Type == Periodic && trigger.Period <= 0 {
+		return nil, NewMalformed("periodic event trigger has no valid reporting period: %d", trigger.Period)
+	}
+	return trigger, nil
+}
+
+func isSupported(triggerType Type, supported []Type) bool {
+	for _, t := range supported {
+		if t == triggerType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package trigger
+
+import (
+	"fmt"
+	"testing"
+
+	e2apies "github.com/onosproject/onos-e2t/api/e2ap/v2/e2ap-ies"
+	"github.com/stretchr/testify/assert"
+)
+
+func decoderOf(trigger *Trigger, err error) Decoder {
+	return func(definition []byte) (*Trigger, error) {
+		return trigger, err
+	}
+}
+
+func TestDecode(t *testing.T) {
+	definition := []byte{0x01}
+
+	trigger, err := Decode(definition, decoderOf(&Trigger{Type: Periodic, Period: 1000}, nil), Periodic)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), trigger.Period)
+
+	trigger, err = Decode(definition, decoderOf(&Trigger{Type: Threshold}, nil), Periodic, OnChange, Threshold)
+	assert.NoError(t, err)
+	assert.Equal(t, Threshold, trigger.Type)
+
+	_, err = Decode(definition, decoderOf(&Trigger{Type: OnChange}, nil), Periodic)
+	assert.Error(t, err)
+	assert.Equal(t, e2apies.CauseProtocol_CAUSE_PROTOCOL_SEMANTIC_ERROR, GetCause(err).GetProtocol())
+
+	_, err = Decode(definition, decoderOf(&Trigger{Type: Periodic}, nil), Periodic)
+	assert.Error(t, err)
+	assert.Equal(t, e2apies.CauseProtocol_CAUSE_PROTOCOL_ABSTRACT_SYNTAX_ERROR_FALSELY_CONSTRUCTED_MESSAGE, GetCause(err).GetProtocol())
+
+	_, err = Decode(definition, decoderOf(nil, fmt.Errorf("bad encoding")), Periodic)
+	assert.Error(t, err)
+	assert.Equal(t, e2apies.CauseProtocol_CAUSE_PROTOCOL_ABSTRACT_SYNTAX_ERROR_FALSELY_CONSTRUCTED_MESSAGE, GetCause(err).GetProtocol())
+
+	_, err = Decode(definition, decoderOf(nil, NewUnsupported("format 2")), Periodic)
+	assert.Error(t, err)
+	assert.Equal(t, e2apies.CauseProtocol_CAUSE_PROTOCOL_SEMANTIC_ERROR, GetCause(err).GetProtocol())
+
+	_, err = Decode(nil, decoderOf(&Trigger{Type: Periodic, Period: 1000}, nil), Periodic)
+	assert.Error(t, err)
+}
+
+func TestGetCause(t *testing.T) {
+	cause := GetCause(fmt.Errorf("not a trigger error"))
+	assert.Equal(t, e2apies.CauseRicrequest_CAUSE_RICREQUEST_UNSPECIFIED, cause.GetRicRequest())
+}
@@ -8,10 +8,14 @@ package utils
 import (
 	"github.com/onosproject/ran-simulator/pkg/model"
 	"math"
+	"time"
 )
 
-// Earth radius in meters
-const earthRadius = 6378100
+// Earth radius in meters, defined along with the model since the model cannot depend on the utilities
+const earthRadius = model.EarthRadius
+
+// MetersPerDegree length in meters of a degree of latitude, or of longitude at the equator
+const MetersPerDegree = model.MetersPerDegree
 
 // See: http://en.wikipedia.org/wiki/Haversine_formula
 
@@ -56,6 +60,118 @@ func InitialBearing(c1 model.Coordinate, c2 model.Coordinate) float64 {
 	return math.Mod(theta*180/math.Pi+360, 360.0) // in degrees
 }
 
+// Interpolate returns the coordinate at the given fraction of the great-circle path from c1 to c2
+func Interpolate(c1 model.Coordinate, c2 model.Coordinate, f float64) model.Coordinate {
+	d := Distance(c1, c2) / earthRadius
+	if d == 0 {
+		return c1
+	}
+	la1, lo1 := c1.Lat*math.Pi/180, c1.Lng*math.Pi/180
+	la2, lo2 := c2.Lat*math.Pi/180, c2.Lng*math.Pi/180
+
+	a := math.Sin((1-f)*d) / math.Sin(d)
+	b := math.Sin(f*d) / math.Sin(d)
+	x := a*math.Cos(la1)*math.Cos(lo1) + b*math.Cos(la2)*math.Cos(lo2)
+	y := a*math.Cos(la1)*math.Sin(lo1) + b*math.Cos(la2)*math.Sin(lo2)
+	z := a*math.Sin(la1) + b*math.Sin(la2)
+	return model.Coordinate{Lat: math.Atan2(z, math.Hypot(x, y)) * 180 / math.Pi, Lng: math.Atan2(y, x) * 180 / math.Pi}
+}
+
+// PathLength returns the length in meters of the path joining the given coordinates
+func PathLength(path []model.Coordinate) float64 {
+	var length float64
+	for i := 1; i < len(path); i++ {
+		length += Distance(path[i-1], path[i])
+	}
+	return length
+}
+
+// AlongPath returns the coordinate at the given distance in meters along the path joining the given coordinates,
+// and the bearing of the path there; distances beyond either end of the path are clamped to that end
+func AlongPath(path []model.Coordinate, dist float64) (model.Coordinate, float64) {
+	if len(path) == 0 {
+		return model.Coordinate{}, 0
+	}
+	var bearing float64
+	for i := 1; i < len(path); i++ {
+		length := Distance(path[i-1], path[i])
+		if length == 0 {
+			continue
+		}
+		bearing = InitialBearing(path[i-1], path[i])
+		if dist <= length {
+			return Interpolate(path[i-1], path[i], math.Max(dist, 0)/length), bearing
+		}
+		dist -= length
+	}
+	return path[len(path)-1], bearing
+}
+
+// Resample returns coordinates spaced by the given step in meters along the path joining the given coordinates,
+// from its start to its end; the last step is shorter unless the length of the path is a multiple of the step
+func Resample(path []model.Coordinate, step float64) []model.Coordinate {
+	if len(path) < 2 || step <= 0 {
+		return path
+	}
+	length := PathLength(path)
+	points := make([]model.Coordinate, 0, int(length/step)+2)
+	for dist := 0.0; dist < length; dist += step {
+		point, _ := AlongPath(path, dist)
+		points = append(points, point)
+	}
+	return append(points, path[len(path)-1])
+}
+
+// SamplePath returns the successive positions, at the given interval, of an object moving along the path joining
+// the given coordinates at the given speed in meters per second
+func SamplePath(path []model.Coordinate, speed float64, interval time.Duration) []model.Coordinate {
+	return Resample(path, speed*interval.Seconds())
+}
+
+// InPolygon returns true if the coordinate lies inside the polygon given by its vertices
+func InPolygon(c model.Coordinate, polygon []model.Coordinate) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Lat > c.Lat) != (b.Lat > c.Lat) && c.Lng < (b.Lng-a.Lng)*(c.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// PolygonArea returns the area in square meters of the polygon given by its vertices; the polygon is projected
+// onto a plane around its first vertex, which is accurate enough for areas spanning a few kilometers
+func PolygonArea(polygon []model.Coordinate) float64 {
+	if len(polygon) < 3 {
+		return 0
+	}
+	origin := polygon[0]
+	latScale := MetersPerDegree
+	lngScale := latScale * math.Cos(origin.Lat*math.Pi/180)
+	var sum float64
+	for i, a := range polygon {
+		b := polygon[(i+1)%len(polygon)]
+		ax, ay := (a.Lng-origin.Lng)*lngScale, (a.Lat-origin.Lat)*latScale
+		bx, by := (b.Lng-origin.Lng)*lngScale, (b.Lat-origin.Lat)*latScale
+		sum += ax*by - bx*ay
+	}
+	return math.Abs(sum) / 2
+}
+
+// BoundingBox returns the south-west and north-east corners of the box bounding the given coordinates
+func BoundingBox(coords []model.Coordinate) (model.Coordinate, model.Coordinate) {
+	min := model.Coordinate{Lat: 90.0, Lng: 180.0}
+	max := model.Coordinate{Lat: -90.0, Lng: -180.0}
+	for _, c := range coords {
+		min.Lat = math.Min(c.Lat, min.Lat)
+		min.Lng = math.Min(c.Lng, min.Lng)
+		max.Lat = math.Max(c.Lat, max.Lat)
+		max.Lng = math.Max(c.Lng, max.Lng)
+	}
+	return min, max
+}
+
 func hsin(theta float64) float64 {
 	return math.Pow(math.Sin(theta/2), 2)
 }
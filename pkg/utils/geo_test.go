@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+//
+
+package utils
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/onosproject/ran-simulator/pkg/model"
+	"gotest.tools/assert"
+)
+
+func Test_InPolygon(t *testing.T) {
+	// L-shaped polygon with the north-east quadrant cut out
+	polygon := []model.Coordinate{
+		{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 1, Lng: 2}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 1}, {Lat: 2, Lng: 0},
+	}
+	assert.Assert(t, InPolygon(model.Coordinate{Lat: 0.5, Lng: 0.5}, polygon))
+	assert.Assert(t, InPolygon(model.Coordinate{Lat: 0.5, Lng: 1.5}, polygon))
+	assert.Assert(t, InPolygon(model.Coordinate{Lat: 1.5, Lng: 0.5}, polygon))
+	assert.Assert(t, !InPolygon(model.Coordinate{Lat: 1.5, Lng: 1.5}, polygon))
+	assert.Assert(t, !InPolygon(model.Coordinate{Lat: -0.5, Lng: 0.5}, polygon))
+}
+
+func Test_PolygonArea(t *testing.T) {
+	// ~1km x ~1km square at the equator
+	side := 1000 / (earthRadius * math.Pi / 180)
+	polygon := []model.Coordinate{{Lat: 0, Lng: 0}, {Lat: 0, Lng: side}, {Lat: side, Lng: side}, {Lat: side, Lng: 0}}
+	assert.Assert(t, math.Abs(PolygonArea(polygon)-1e6) < 1)
+	assert.Equal(t, 0.0, PolygonArea(polygon[:2]))
+}
+
+func Test_BoundingBox(t *testing.T) {
+	min, max := BoundingBox([]model.Coordinate{{Lat: 1, Lng: 4}, {Lat: -2, Lng: 3}, {Lat: 0, Lng: 5}})
+	assert.Equal(t, model.Coordinate{Lat: -2, Lng: 3}, min)
+	assert.Equal(t, model.Coordinate{Lat: 1, Lng: 5}, max)
+}
+
+func Test_Interpolate(t *testing.T) {
+	// Half way between two points on the equator
+	c := Interpolate(model.Coordinate{Lat: 0, Lng: 10}, model.Coordinate{Lat: 0, Lng: 20}, 0.5)
+	assert.Assert(t, math.Abs(c.Lat) < 1e-9)
+	assert.Assert(t, math.Abs(c.Lng-15) < 1e-9)
+
+	// The great circle between two points at the same latitude bulges towards the pole
+	c1, c2 := model.Coordinate{Lat: 60, Lng: -30}, model.Coordinate{Lat: 60, Lng: 30}
+	c = Interpolate(c1, c2, 0.5)
+	assert.Assert(t, c.Lat > 60)
+	assert.Assert(t, math.Abs(Distance(c1, c)-Distance(c, c2)) < 1e-6)
+	assert.Equal(t, c1, Interpolate(c1, c1, 0.5))
+}
+
+func Test_AlongPath(t *testing.T) {
+	path := []model.Coordinate{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.01}, {Lat: 0.01, Lng: 0.01}}
+	length := PathLength(path)
+	assert.Assert(t, math.Abs(length-2*0.01*MetersPerDegree) < 1e-6)
+
+	c, bearing := AlongPath(path, length/4)
+	assert.Assert(t, math.Abs(c.Lng-0.005) < 1e-9)
+	assert.Assert(t, math.Abs(bearing-90) < 1e-6)
+	c, bearing = AlongPath(path, length*3/4)
+	assert.Assert(t, math.Abs(c.Lat-0.005) < 1e-9)
+	assert.Assert(t, math.Abs(bearing) < 1e-6)
+	c, _ = AlongPath(path, 2*length)
+	assert.Equal(t, path[2], c)
+}
+
+func Test_SamplePath(t *testing.T) {
+	path := []model.Coordinate{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.01}}
+	// ~1113m at 10m/s sampled every 10s
+	points := SamplePath(path, 10, 10*time.Second)
+	assert.Equal(t, 13, len(points))
+	for i := 1; i < len(points)-1; i++ {
+		assert.Assert(t, math.Abs(Distance(points[i-1], points[i])-100) < 1e-6)
+	}
+	assert.Equal(t, path[0], points[0])
+	assert.Equal(t, path[1], points[len(points)-1])
+}
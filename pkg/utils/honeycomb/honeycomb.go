@@ -80,6 +80,10 @@ func GenerateHoneycombTopology(mapCenter model.Coordinate, numTowers uint, secto
 				Cells:         make([]types.NCGI, 0, sectorsPerTower),
 				Status:        "stopped",
 			}
+			if !singleNode {
+				// A single node serves the cells of all towers and is named after its key
+				node.Name = fmt.Sprintf("Tower-%d", t+1)
+			}
 		}
 
 		for s = 0; s < sectorsPerTower; s++ {
@@ -96,6 +100,7 @@ func GenerateHoneycombTopology(mapCenter model.Coordinate, numTowers uint, secto
 
 			cell := model.Cell{
 				NCGI: types.ToNCGI(plmnID, types.ToNCI(gnbID, cellID)),
+				Name: fmt.Sprintf("Tower-%d/Sector-%c", t+1, 'A'+rune(s)),
 				Sector: model.Sector{
 					Center:  *points[t],
 					Azimuth: azimuth,
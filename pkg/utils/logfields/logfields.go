@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2021-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logfields
+
+import (
+	"fmt"
+
+	"github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/ran-simulator/pkg/store/subscriptions"
+)
+
+const (
+	// GnbID is the name of the field carrying the ID of the E2 node
+	GnbID = "gnbID"
+	// SubscriptionID is the name of the field carrying the ID of the subscription
+	SubscriptionID = "subscriptionID"
+)
+
+// Node returns the prefix of the log messages of the given E2 node
+func Node(gnbID types.GnbID) string {
+	return fmt.Sprintf("%s=%d", GnbID, gnbID)
+}
+
+// Subscription returns the prefix of the log messages of the given subscription, given the prefix of its E2 node
+func Subscription(prefix string, id subscriptions.ID) string {
+	return fmt.Sprintf("%s %s=%s", prefix, SubscriptionID, id)
+}
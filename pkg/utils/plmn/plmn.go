@@ -0,0 +1,123 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plmn
+
+import (
+	"fmt"
+	"strings"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/onosproject/onos-lib-go/pkg/errors"
+)
+
+const (
+	// filler is the BCD filler digit used in place of the third MNC digit of 2-digit MNCs
+	filler = 0xF
+
+	// Length length of an encoded PLMN identity in bytes
+	Length = 3
+)
+
+// Parse parses a PLMN ID given in the MCC-MNC format, e.g. "315-010", "315010" or "31501"
+func Parse(plmn string) (ransimtypes.PlmnID, error) {
+	var mcc, mnc string
+	if strings.Contains(plmn, "-") {
+		parts := strings.Split(plmn, "-")
+		if len(parts) != 2 {
+			return 0, errors.New(errors.Invalid, "invalid PLMN ID %s", plmn)
+		}
+		mcc, mnc = parts[0], parts[1]
+	} else {
+		if len(plmn) < 5 {
+			return 0, errors.New(errors.Invalid, "invalid PLMN ID %s", plmn)
+		}
+		mcc, mnc = plmn[:3], plmn[3:]
+	}
+	return Encode(mcc, mnc)
+}
+
+// Encode encodes the given MCC and MNC digits into a BCD encoded PLMN ID as specified in 3GPP TS 24.008
+func Encode(mcc string, mnc string) (ransimtypes.PlmnID, error) {
+	if len(mcc) != 3 || !isDigits(mcc) {
+		return 0, errors.New(errors.Invalid, "invalid MCC %s", mcc)
+	}
+	if (len(mnc) != 2 && len(mnc) != 3) || !isDigits(mnc) {
+		return 0, errors.New(errors.Invalid, "invalid MNC %s", mnc)
+	}
+
+	mnc3 := byte(filler)
+	if len(mnc) == 3 {
+		mnc3 = digit(mnc[2])
+	}
+	bytes := []byte{
+		digit(mcc[1])<<4 | digit(mcc[0]),
+		mnc3<<4 | digit(mcc[2]),
+		digit(mnc[1])<<4 | digit(mnc[0]),
+	}
+	return FromBytes(bytes)
+}
+
+// Decode decodes a BCD encoded PLMN ID into its MCC and MNC digits
+func Decode(plmnID ransimtypes.PlmnID) (string, string, error) {
+	bytes := ToBytes(plmnID)
+	digits := []byte{
+		bytes[0] & 0x0F, bytes[0] >> 4, bytes[1] & 0x0F,
+		bytes[2] & 0x0F, bytes[2] >> 4, bytes[1] >> 4,
+	}
+	numDigits := len(digits)
+	if digits[5] == filler {
+		numDigits--
+	}
+
+	var sb strings.Builder
+	for _, d := range digits[:numDigits] {
+		if d > 9 {
+			return "", "", errors.New(errors.Invalid, "invalid BCD encoded PLMN ID %06x", uint32(plmnID))
+		}
+		sb.WriteByte('0' + d)
+	}
+	s := sb.String()
+	return s[:3], s[3:], nil
+}
+
+// String returns the given PLMN ID in the MCC-MNC format, e.g. "315-010"
+func String(plmnID ransimtypes.PlmnID) string {
+	mcc, mnc, err := Decode(plmnID)
+	if err != nil {
+		return fmt.Sprintf("%06x", uint32(plmnID))
+	}
+	return mcc + "-" + mnc
+}
+
+// ToBytes returns the 3-byte PLMN identity carried in E2AP and E2SM payloads
+func ToBytes(plmnID ransimtypes.PlmnID) []byte {
+	return []byte{byte(plmnID >> 16), byte(plmnID >> 8), byte(plmnID)}
+}
+
+// FromBytes returns the PLMN ID of the given 3-byte PLMN identity
+func FromBytes(bytes []byte) (ransimtypes.PlmnID, error) {
+	if len(bytes) != Length {
+		return 0, errors.New(errors.Invalid, "invalid PLMN identity length %d", len(bytes))
+	}
+	return ransimtypes.PlmnID(uint32(bytes[0])<<16 | uint32(bytes[1])<<8 | uint32(bytes[2])), nil
+}
+
+// ToUint24 converts the given PLMN ID to the 3-byte integer used by the E2AP and E2SM builders
+func ToUint24(plmnID ransimtypes.PlmnID) *ransimtypes.Uint24 {
+	return ransimtypes.NewUint24(uint32(plmnID))
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func digit(c byte) byte {
+	return c - '0'
+}
@@ -0,0 +1,59 @@
+// SPDX-FileCopyrightText: 2020-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plmn
+
+import (
+	"testing"
+
+	ransimtypes "github.com/onosproject/onos-api/go/onos/ransim/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	plmnID, err := Parse("314628")
+	assert.NoError(t, err)
+	assert.Equal(t, ransimtypes.PlmnID(0x138426), plmnID)
+
+	plmnID, err = Parse("314-628")
+	assert.NoError(t, err)
+	assert.Equal(t, ransimtypes.PlmnID(0x138426), plmnID)
+
+	plmnID, err = Parse("31501")
+	assert.NoError(t, err)
+	assert.Equal(t, ransimtypes.PlmnID(0x13f510), plmnID)
+
+	for _, invalid := range []string{"", "3150", "315-0", "315-0100", "31a010", "315-010-1", "3150100"} {
+		_, err = Parse(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, s := range []string{"315-010", "314-628", "001-01", "999-999", "208-93"} {
+		plmnID, err := Parse(s)
+		assert.NoError(t, err)
+		assert.Equal(t, s, String(plmnID))
+
+		bytes := ToBytes(plmnID)
+		assert.Len(t, bytes, Length)
+		decoded, err := FromBytes(bytes)
+		assert.NoError(t, err)
+		assert.Equal(t, plmnID, decoded)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	mcc, mnc, err := Decode(0x13f510)
+	assert.NoError(t, err)
+	assert.Equal(t, "315", mcc)
+	assert.Equal(t, "01", mnc)
+
+	_, _, err = Decode(0xabcdef)
+	assert.Error(t, err)
+	assert.Equal(t, "abcdef", String(0xabcdef))
+
+	_, err = FromBytes([]byte{0x13, 0x00})
+	assert.Error(t, err)
+}